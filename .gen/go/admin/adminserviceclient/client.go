@@ -26,6 +26,7 @@ package adminserviceclient
 import (
 	"context"
 	"github.com/uber/cadence/.gen/go/admin"
+	"github.com/uber/cadence/.gen/go/replicator"
 	"github.com/uber/cadence/.gen/go/shared"
 	"go.uber.org/thriftrw/wire"
 	"go.uber.org/yarpc"
@@ -36,17 +37,83 @@ import (
 
 // Interface is a client for the AdminService service.
 type Interface interface {
+	AddCluster(
+		ctx context.Context,
+		Request *admin.AddClusterRequest,
+		opts ...yarpc.CallOption,
+	) error
+
 	DescribeHistoryHost(
 		ctx context.Context,
 		Request *shared.DescribeHistoryHostRequest,
 		opts ...yarpc.CallOption,
 	) (*shared.DescribeHistoryHostResponse, error)
 
+	DescribeReplicationStatus(
+		ctx context.Context,
+		Request *admin.DescribeReplicationStatusRequest,
+		opts ...yarpc.CallOption,
+	) (*admin.DescribeReplicationStatusResponse, error)
+
 	DescribeWorkflowExecution(
 		ctx context.Context,
 		Request *admin.DescribeWorkflowExecutionRequest,
 		opts ...yarpc.CallOption,
 	) (*admin.DescribeWorkflowExecutionResponse, error)
+
+	GetReplicationMessages(
+		ctx context.Context,
+		Request *replicator.GetReplicationMessagesRequest,
+		opts ...yarpc.CallOption,
+	) (*replicator.GetReplicationMessagesResponse, error)
+
+	GetWorkflowExecutionRawHistory(
+		ctx context.Context,
+		GetRequest *admin.GetWorkflowExecutionRawHistoryRequest,
+		opts ...yarpc.CallOption,
+	) (*admin.GetWorkflowExecutionRawHistoryResponse, error)
+
+	ImportWorkflowExecution(
+		ctx context.Context,
+		ImportRequest *admin.ImportWorkflowExecutionRequest,
+		opts ...yarpc.CallOption,
+	) error
+
+	ListClusters(
+		ctx context.Context,
+		Request *admin.ListClustersRequest,
+		opts ...yarpc.CallOption,
+	) (*admin.ListClustersResponse, error)
+
+	ListGlobalDomains(
+		ctx context.Context,
+		Request *admin.ListGlobalDomainsRequest,
+		opts ...yarpc.CallOption,
+	) (*admin.ListGlobalDomainsResponse, error)
+
+	MergeDLQMessages(
+		ctx context.Context,
+		Request *admin.MergeDLQMessagesRequest,
+		opts ...yarpc.CallOption,
+	) error
+
+	PurgeDLQMessages(
+		ctx context.Context,
+		Request *admin.PurgeDLQMessagesRequest,
+		opts ...yarpc.CallOption,
+	) error
+
+	ReadDLQMessages(
+		ctx context.Context,
+		Request *admin.ReadDLQMessagesRequest,
+		opts ...yarpc.CallOption,
+	) (*admin.ReadDLQMessagesResponse, error)
+
+	UpdateCluster(
+		ctx context.Context,
+		Request *admin.UpdateClusterRequest,
+		opts ...yarpc.CallOption,
+	) error
 }
 
 // New builds a new client for the AdminService service.
@@ -73,6 +140,29 @@ type client struct {
 	c thrift.Client
 }
 
+func (c client) AddCluster(
+	ctx context.Context,
+	_Request *admin.AddClusterRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := admin.AdminService_AddCluster_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result admin.AdminService_AddCluster_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	err = admin.AdminService_AddCluster_Helper.UnwrapResponse(&result)
+	return
+}
+
 func (c client) DescribeHistoryHost(
 	ctx context.Context,
 	_Request *shared.DescribeHistoryHostRequest,
@@ -96,6 +186,29 @@ func (c client) DescribeHistoryHost(
 	return
 }
 
+func (c client) DescribeReplicationStatus(
+	ctx context.Context,
+	_Request *admin.DescribeReplicationStatusRequest,
+	opts ...yarpc.CallOption,
+) (success *admin.DescribeReplicationStatusResponse, err error) {
+
+	args := admin.AdminService_DescribeReplicationStatus_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result admin.AdminService_DescribeReplicationStatus_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = admin.AdminService_DescribeReplicationStatus_Helper.UnwrapResponse(&result)
+	return
+}
+
 func (c client) DescribeWorkflowExecution(
 	ctx context.Context,
 	_Request *admin.DescribeWorkflowExecutionRequest,
@@ -118,3 +231,210 @@ func (c client) DescribeWorkflowExecution(
 	success, err = admin.AdminService_DescribeWorkflowExecution_Helper.UnwrapResponse(&result)
 	return
 }
+
+func (c client) GetReplicationMessages(
+	ctx context.Context,
+	_Request *replicator.GetReplicationMessagesRequest,
+	opts ...yarpc.CallOption,
+) (success *replicator.GetReplicationMessagesResponse, err error) {
+
+	args := admin.AdminService_GetReplicationMessages_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result admin.AdminService_GetReplicationMessages_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = admin.AdminService_GetReplicationMessages_Helper.UnwrapResponse(&result)
+	return
+}
+
+func (c client) GetWorkflowExecutionRawHistory(
+	ctx context.Context,
+	_GetRequest *admin.GetWorkflowExecutionRawHistoryRequest,
+	opts ...yarpc.CallOption,
+) (success *admin.GetWorkflowExecutionRawHistoryResponse, err error) {
+
+	args := admin.AdminService_GetWorkflowExecutionRawHistory_Helper.Args(_GetRequest)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result admin.AdminService_GetWorkflowExecutionRawHistory_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = admin.AdminService_GetWorkflowExecutionRawHistory_Helper.UnwrapResponse(&result)
+	return
+}
+
+func (c client) ImportWorkflowExecution(
+	ctx context.Context,
+	_ImportRequest *admin.ImportWorkflowExecutionRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := admin.AdminService_ImportWorkflowExecution_Helper.Args(_ImportRequest)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result admin.AdminService_ImportWorkflowExecution_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	err = admin.AdminService_ImportWorkflowExecution_Helper.UnwrapResponse(&result)
+	return
+}
+
+func (c client) ListClusters(
+	ctx context.Context,
+	_Request *admin.ListClustersRequest,
+	opts ...yarpc.CallOption,
+) (success *admin.ListClustersResponse, err error) {
+
+	args := admin.AdminService_ListClusters_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result admin.AdminService_ListClusters_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = admin.AdminService_ListClusters_Helper.UnwrapResponse(&result)
+	return
+}
+
+func (c client) ListGlobalDomains(
+	ctx context.Context,
+	_Request *admin.ListGlobalDomainsRequest,
+	opts ...yarpc.CallOption,
+) (success *admin.ListGlobalDomainsResponse, err error) {
+
+	args := admin.AdminService_ListGlobalDomains_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result admin.AdminService_ListGlobalDomains_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = admin.AdminService_ListGlobalDomains_Helper.UnwrapResponse(&result)
+	return
+}
+
+func (c client) MergeDLQMessages(
+	ctx context.Context,
+	_Request *admin.MergeDLQMessagesRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := admin.AdminService_MergeDLQMessages_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result admin.AdminService_MergeDLQMessages_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	err = admin.AdminService_MergeDLQMessages_Helper.UnwrapResponse(&result)
+	return
+}
+
+func (c client) PurgeDLQMessages(
+	ctx context.Context,
+	_Request *admin.PurgeDLQMessagesRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := admin.AdminService_PurgeDLQMessages_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result admin.AdminService_PurgeDLQMessages_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	err = admin.AdminService_PurgeDLQMessages_Helper.UnwrapResponse(&result)
+	return
+}
+
+func (c client) ReadDLQMessages(
+	ctx context.Context,
+	_Request *admin.ReadDLQMessagesRequest,
+	opts ...yarpc.CallOption,
+) (success *admin.ReadDLQMessagesResponse, err error) {
+
+	args := admin.AdminService_ReadDLQMessages_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result admin.AdminService_ReadDLQMessages_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = admin.AdminService_ReadDLQMessages_Helper.UnwrapResponse(&result)
+	return
+}
+
+func (c client) UpdateCluster(
+	ctx context.Context,
+	_Request *admin.UpdateClusterRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := admin.AdminService_UpdateCluster_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result admin.AdminService_UpdateCluster_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	err = admin.AdminService_UpdateCluster_Helper.UnwrapResponse(&result)
+	return
+}
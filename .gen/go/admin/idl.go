@@ -24,6 +24,7 @@
 package admin
 
 import (
+	"github.com/uber/cadence/.gen/go/replicator"
 	"github.com/uber/cadence/.gen/go/shared"
 	"go.uber.org/thriftrw/thriftreflect"
 )
@@ -33,11 +34,12 @@ var ThriftModule = &thriftreflect.ThriftModule{
 	Name:     "admin",
 	Package:  "github.com/uber/cadence/.gen/go/admin",
 	FilePath: "admin.thrift",
-	SHA1:     "bb61fbdf93f8f77bd9957ecfcacf5508cedfe81d",
+	SHA1:     "ab5a39fae73802acdca9b2289e45386e7e0e3e04",
 	Includes: []*thriftreflect.ThriftModule{
+		replicator.ThriftModule,
 		shared.ThriftModule,
 	},
 	Raw: rawIDL,
 }
 
-const rawIDL = "// Copyright (c) 2017 Uber Technologies, Inc.\n//\n// Permission is hereby granted, free of charge, to any person obtaining a copy\n// of this software and associated documentation files (the \"Software\"), to deal\n// in the Software without restriction, including without limitation the rights\n// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell\n// copies of the Software, and to permit persons to whom the Software is\n// furnished to do so, subject to the following conditions:\n//\n// The above copyright notice and this permission notice shall be included in\n// all copies or substantial portions of the Software.\n//\n// THE SOFTWARE IS PROVIDED \"AS IS\", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR\n// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,\n// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE\n// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER\n// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,\n// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN\n// THE SOFTWARE.\n\nnamespace java com.uber.cadence.admin\n\ninclude \"shared.thrift\"\n\n/**\n* AdminService provides advanced APIs for debugging and analysis with admin privillege\n**/\nservice AdminService {\n  /**\n  * DescribeWorkflowExecution returns information about the internal states of workflow execution.\n  **/\n  DescribeWorkflowExecutionResponse DescribeWorkflowExecution(1: DescribeWorkflowExecutionRequest request)\n    throws (\n      1: shared.BadRequestError         badRequestError,\n      2: shared.InternalServiceError    internalServiceError,\n      3: shared.EntityNotExistsError    entityNotExistError,\n      4: shared.AccessDeniedError       accessDeniedError,\n    )\n\n  /**\n    * DescribeHistoryHost returns information about the internal states of a history host\n    **/\n    shared.DescribeHistoryHostResponse DescribeHistoryHost(1: shared.DescribeHistoryHostRequest request)\n      throws (\n        1: shared.BadRequestError       badRequestError,\n        2: shared.InternalServiceError  internalServiceError,\n        3: shared.AccessDeniedError     accessDeniedError,\n      )\n}\n\nstruct DescribeWorkflowExecutionRequest {\n  10: optional string                       domain\n  20: optional shared.WorkflowExecution     execution\n}\n\nstruct DescribeWorkflowExecutionResponse{\n  10: optional string shardId\n  20: optional string historyAddr\n  40: optional string mutableStateInCache\n  50: optional string mutableStateInDatabase\n}"
+const rawIDL = "// Copyright (c) 2017 Uber Technologies, Inc.\n//\n// Permission is hereby granted, free of charge, to any person obtaining a copy\n// of this software and associated documentation files (the \"Software\"), to deal\n// in the Software without restriction, including without limitation the rights\n// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell\n// copies of the Software, and to permit persons to whom the Software is\n// furnished to do so, subject to the following conditions:\n//\n// The above copyright notice and this permission notice shall be included in\n// all copies or substantial portions of the Software.\n//\n// THE SOFTWARE IS PROVIDED \"AS IS\", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR\n// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,\n// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE\n// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER\n// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,\n// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN\n// THE SOFTWARE.\n\nnamespace java com.uber.cadence.admin\n\ninclude \"shared.thrift\"\ninclude \"replicator.thrift\"\n\n/**\n* AdminService provides advanced APIs for debugging and analysis with admin privillege\n**/\nservice AdminService {\n  /**\n  * DescribeWorkflowExecution returns information about the internal states of workflow execution.\n  **/\n  DescribeWorkflowExecutionResponse DescribeWorkflowExecution(1: DescribeWorkflowExecutionRequest request)\n    throws (\n      1: shared.BadRequestError         badRequestError,\n      2: shared.InternalServiceError    internalServiceError,\n      3: shared.EntityNotExistsError    entityNotExistError,\n      4: shared.AccessDeniedError       accessDeniedError,\n    )\n\n  /**\n    * DescribeHistoryHost returns information about the internal states of a history host\n    **/\n    shared.DescribeHistoryHostResponse DescribeHistoryHost(1: shared.DescribeHistoryHostRequest request)\n      throws (\n        1: shared.BadRequestError       badRequestError,\n        2: shared.InternalServiceError  internalServiceError,\n        3: shared.AccessDeniedError     accessDeniedError,\n      )\n\n  /**\n    * GetReplicationMessages returns new replication tasks of the requested shards for the polling cluster\n    **/\n    replicator.GetReplicationMessagesResponse GetReplicationMessages(1: replicator.GetReplicationMessagesRequest request)\n      throws (\n        1: shared.BadRequestError       badRequestError,\n        2: shared.InternalServiceError  internalServiceError,\n        3: shared.LimitExceededError    limitExceededError,\n        4: shared.ServiceBusyError      serviceBusyError,\n      )\n\n  /**\n    * GetWorkflowExecutionRawHistory returns the serialized history batches of a workflow run for an event range,\n    * along with the replication info of the run, used to re-replicate the events missing on another cluster\n    **/\n    GetWorkflowExecutionRawHistoryResponse GetWorkflowExecutionRawHistory(1: GetWorkflowExecutionRawHistoryRequest getRequest)\n      throws (\n        1: shared.BadRequestError       badRequestError,\n        2: shared.InternalServiceError  internalServiceError,\n        3: shared.EntityNotExistsError  entityNotExistError,\n        4: shared.ServiceBusyError      serviceBusyError,\n        5: shared.AccessDeniedError     accessDeniedError,\n      )\n\n  /**\n    * ImportWorkflowExecution recreates a workflow run from the history batches exported by\n    * GetWorkflowExecutionRawHistory, used to load production histories into another cluster for debugging\n    **/\n    void ImportWorkflowExecution(1: ImportWorkflowExecutionRequest importRequest)\n      throws (\n        1: shared.BadRequestError                      badRequestError,\n        2: shared.InternalServiceError                 internalServiceError,\n        3: shared.EntityNotExistsError                 entityNotExistError,\n        4: shared.WorkflowExecutionAlreadyStartedError workflowAlreadyStartedError,\n        5: shared.ServiceBusyError                     serviceBusyError,\n        6: shared.AccessDeniedError                    accessDeniedError,\n      )\n\n  /**\n    * ReadDLQMessages returns the replication tasks in the DLQ which have not been purged yet\n    **/\n    ReadDLQMessagesResponse ReadDLQMessages(1: ReadDLQMessagesRequest request)\n      throws (\n        1: shared.BadRequestError       badRequestError,\n        2: shared.InternalServiceError  internalServiceError,\n        3: shared.EntityNotExistsError  entityNotExistError,\n        4: shared.AccessDeniedError     accessDeniedError,\n      )\n\n  /**\n    * PurgeDLQMessages discards the DLQ messages of a partition up to and including the given offset\n    **/\n    void PurgeDLQMessages(1: PurgeDLQMessagesRequest request)\n      throws (\n        1: shared.BadRequestError       badRequestError,\n        2: shared.InternalServiceError  internalServiceError,\n        3: shared.AccessDeniedError     accessDeniedError,\n      )\n\n  /**\n    * MergeDLQMessages re-applies the given DLQ messages through the history replication path\n    **/\n    void MergeDLQMessages(1: MergeDLQMessagesRequest request)\n      throws (\n        1: shared.BadRequestError       badRequestError,\n        2: shared.InternalServiceError  internalServiceError,\n        3: shared.EntityNotExistsError  entityNotExistError,\n        4: shared.AccessDeniedError     accessDeniedError,\n        5: shared.RetryTaskError        retryTaskError,\n      )\n\n  /**\n    * DescribeReplicationStatus returns the replication levels of every shard for each remote cluster and\n    * the offsets of the consumers receiving replication tasks from the remote clusters\n    **/\n    DescribeReplicationStatusResponse DescribeReplicationStatus(1: DescribeReplicationStatusRequest request)\n      throws (\n        1: shared.BadRequestError       badRequestError,\n        2: shared.InternalServiceError  internalServiceError,\n        3: shared.AccessDeniedError     accessDeniedError,\n      )\n\n  /**\n    * AddCluster registers a cluster in the metadata store of the current cluster, the cluster is enabled and\n    * picked up by the current cluster without a restart\n    **/\n    void AddCluster(1: AddClusterRequest request)\n      throws (\n        1: shared.BadRequestError       badRequestError,\n        2: shared.InternalServiceError  internalServiceError,\n        3: shared.AccessDeniedError     accessDeniedError,\n      )\n\n  /**\n    * UpdateCluster enables or disables a cluster, or changes its frontend address or replication topic\n    **/\n    void UpdateCluster(1: UpdateClusterRequest request)\n      throws (\n        1: shared.BadRequestError       badRequestError,\n        2: shared.InternalServiceError  internalServiceError,\n        3: shared.EntityNotExistsError  entityNotExistError,\n        4: shared.AccessDeniedError     accessDeniedError,\n      )\n\n  /**\n    * ListClusters returns the clusters in the static config and the ones registered in the metadata store\n    **/\n    ListClustersResponse ListClusters(1: ListClustersRequest request)\n      throws (\n        1: shared.BadRequestError       badRequestError,\n        2: shared.InternalServiceError  internalServiceError,\n        3: shared.AccessDeniedError     accessDeniedError,\n      )\n\n  /**\n    * ListGlobalDomains returns the records of the global domains stored in the current cluster, in the form of\n    * domain replication tasks, used to reconcile the domain metadata between clusters\n    **/\n    ListGlobalDomainsResponse ListGlobalDomains(1: ListGlobalDomainsRequest request)\n      throws (\n        1: shared.BadRequestError       badRequestError,\n        2: shared.InternalServiceError  internalServiceError,\n        3: shared.AccessDeniedError     accessDeniedError,\n      )\n}\n\nstruct DescribeWorkflowExecutionRequest {\n  10: optional string                       domain\n  20: optional shared.WorkflowExecution     execution\n}\n\nstruct DescribeWorkflowExecutionResponse{\n  10: optional string shardId\n  20: optional string historyAddr\n  40: optional string mutableStateInCache\n  50: optional string mutableStateInDatabase\n}\nstruct GetWorkflowExecutionRawHistoryRequest {\n  10: optional string domain\n  20: optional shared.WorkflowExecution execution\n  30: optional i64 (js.type = \"Long\") firstEventId\n  40: optional i64 (js.type = \"Long\") nextEventId\n  50: optional i32 maximumPageSize\n  60: optional binary nextPageToken\n}\n\nstruct GetWorkflowExecutionRawHistoryResponse {\n  10: optional binary nextPageToken\n  20: optional list<shared.DataBlob> historyBatches\n  30: optional map<string, shared.ReplicationInfo> replicationInfo\n}\n\nstruct ImportWorkflowExecutionRequest {\n  10: optional string domain\n  20: optional shared.WorkflowExecution execution\n  30: optional list<shared.DataBlob> historyBatches\n}\n\nstruct ReadDLQMessagesRequest {\n  10: optional i32 shardID\n  20: optional string domain\n  30: optional i32 maximumPageSize\n  40: optional binary nextPageToken\n}\n\nstruct ReadDLQMessagesResponse {\n  10: optional list<replicator.DLQMessage> messages\n  20: optional binary nextPageToken\n}\n\nstruct PurgeDLQMessagesRequest {\n  10: optional i32 partition\n  20: optional i64 (js.type = \"Long\") inclusiveEndOffset\n}\n\nstruct MergeDLQMessagesRequest {\n  10: optional list<replicator.DLQMessageID> messageIDs\n}\n\nstruct DescribeReplicationStatusRequest {\n  // shardIDs defaults to all shards of the cluster\n  10: optional list<i32> shardIDs\n}\n\nstruct ReplicationConsumerStatus {\n  10: optional string consumerName\n  20: optional list<replicator.ConsumerPartitionOffset> partitions\n}\n\nstruct DescribeReplicationStatusResponse {\n  10: optional map<i32, replicator.ShardReplicationStatus> statusByShard\n  20: optional map<string, ReplicationConsumerStatus> consumersBySourceCluster\n}\n\nstruct ClusterInfo {\n  10: optional string clusterName\n  20: optional i64 (js.type = \"Long\") initialFailoverVersion\n  30: optional bool enabled\n  40: optional string rpcName\n  50: optional string rpcAddress\n  // replicationTopic is empty if the topic in the static config is used\n  60: optional string replicationTopic\n  70: optional bool isCurrentCluster\n  // isRegistered is false for the clusters which are only in the static config\n  80: optional bool isRegistered\n}\n\nstruct AddClusterRequest {\n  10: optional string clusterName\n  20: optional i64 (js.type = \"Long\") initialFailoverVersion\n  30: optional string rpcName\n  40: optional string rpcAddress\n  50: optional string replicationTopic\n}\n\nstruct UpdateClusterRequest {\n  10: optional string clusterName\n  20: optional bool enabled\n  30: optional string rpcName\n  40: optional string rpcAddress\n  50: optional string replicationTopic\n}\n\nstruct ListClustersRequest {\n}\n\nstruct ListClustersResponse {\n  10: optional list<ClusterInfo> clusters\n}\n\nstruct ListGlobalDomainsRequest {\n  10: optional i32 pageSize\n  20: optional binary nextPageToken\n}\n\nstruct ListGlobalDomainsResponse {\n  10: optional list<replicator.DomainTaskAttributes> domains\n  20: optional binary nextPageToken\n}\n"
@@ -233,6 +233,8 @@ func init() {
 			return true
 		case *shared.ServiceBusyError:
 			return true
+		case *shared.AccessDeniedError:
+			return true
 		default:
 			return false
 		}
@@ -264,6 +266,11 @@ func init() {
 				return nil, errors.New("WrapResponse received non-nil error type with nil value for WorkflowService_DescribeDomain_Result.ServiceBusyError")
 			}
 			return &WorkflowService_DescribeDomain_Result{ServiceBusyError: e}, nil
+		case *shared.AccessDeniedError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for WorkflowService_DescribeDomain_Result.AccessDeniedError")
+			}
+			return &WorkflowService_DescribeDomain_Result{AccessDeniedError: e}, nil
 		}
 
 		return nil, err
@@ -285,6 +292,10 @@ func init() {
 			err = result.ServiceBusyError
 			return
 		}
+		if result.AccessDeniedError != nil {
+			err = result.AccessDeniedError
+			return
+		}
 
 		if result.Success != nil {
 			success = result.Success
@@ -309,6 +320,7 @@ type WorkflowService_DescribeDomain_Result struct {
 	InternalServiceError *shared.InternalServiceError   `json:"internalServiceError,omitempty"`
 	EntityNotExistError  *shared.EntityNotExistsError   `json:"entityNotExistError,omitempty"`
 	ServiceBusyError     *shared.ServiceBusyError       `json:"serviceBusyError,omitempty"`
+	AccessDeniedError    *shared.AccessDeniedError      `json:"accessDeniedError,omitempty"`
 }
 
 // ToWire translates a WorkflowService_DescribeDomain_Result struct into a Thrift-level intermediate
@@ -328,7 +340,7 @@ type WorkflowService_DescribeDomain_Result struct {
 //   }
 func (v *WorkflowService_DescribeDomain_Result) ToWire() (wire.Value, error) {
 	var (
-		fields [5]wire.Field
+		fields [6]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -374,6 +386,14 @@ func (v *WorkflowService_DescribeDomain_Result) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 4, Value: w}
 		i++
 	}
+	if v.AccessDeniedError != nil {
+		w, err = v.AccessDeniedError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 5, Value: w}
+		i++
+	}
 
 	if i != 1 {
 		return wire.Value{}, fmt.Errorf("WorkflowService_DescribeDomain_Result should have exactly one field: got %v fields", i)
@@ -449,6 +469,14 @@ func (v *WorkflowService_DescribeDomain_Result) FromWire(w wire.Value) error {
 					return err
 				}
 
+			}
+		case 5:
+			if field.Value.Type() == wire.TStruct {
+				v.AccessDeniedError, err = _AccessDeniedError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -469,6 +497,9 @@ func (v *WorkflowService_DescribeDomain_Result) FromWire(w wire.Value) error {
 	if v.ServiceBusyError != nil {
 		count++
 	}
+	if v.AccessDeniedError != nil {
+		count++
+	}
 	if count != 1 {
 		return fmt.Errorf("WorkflowService_DescribeDomain_Result should have exactly one field: got %v fields", count)
 	}
@@ -483,7 +514,7 @@ func (v *WorkflowService_DescribeDomain_Result) String() string {
 		return "<nil>"
 	}
 
-	var fields [5]string
+	var fields [6]string
 	i := 0
 	if v.Success != nil {
 		fields[i] = fmt.Sprintf("Success: %v", v.Success)
@@ -505,6 +536,10 @@ func (v *WorkflowService_DescribeDomain_Result) String() string {
 		fields[i] = fmt.Sprintf("ServiceBusyError: %v", v.ServiceBusyError)
 		i++
 	}
+	if v.AccessDeniedError != nil {
+		fields[i] = fmt.Sprintf("AccessDeniedError: %v", v.AccessDeniedError)
+		i++
+	}
 
 	return fmt.Sprintf("WorkflowService_DescribeDomain_Result{%v}", strings.Join(fields[:i], ", "))
 }
@@ -529,6 +564,9 @@ func (v *WorkflowService_DescribeDomain_Result) Equals(rhs *WorkflowService_Desc
 	if !((v.ServiceBusyError == nil && rhs.ServiceBusyError == nil) || (v.ServiceBusyError != nil && rhs.ServiceBusyError != nil && v.ServiceBusyError.Equals(rhs.ServiceBusyError))) {
 		return false
 	}
+	if !((v.AccessDeniedError == nil && rhs.AccessDeniedError == nil) || (v.AccessDeniedError != nil && rhs.AccessDeniedError != nil && v.AccessDeniedError.Equals(rhs.AccessDeniedError))) {
+		return false
+	}
 
 	return true
 }
@@ -583,6 +621,16 @@ func (v *WorkflowService_DescribeDomain_Result) GetServiceBusyError() (o *shared
 	return
 }
 
+// GetAccessDeniedError returns the value of AccessDeniedError if it is set or its
+// zero value if it is unset.
+func (v *WorkflowService_DescribeDomain_Result) GetAccessDeniedError() (o *shared.AccessDeniedError) {
+	if v.AccessDeniedError != nil {
+		return v.AccessDeniedError
+	}
+
+	return
+}
+
 // MethodName returns the name of the Thrift function as specified in
 // the IDL, for which this struct represent the result.
 //
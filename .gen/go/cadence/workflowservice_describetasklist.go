@@ -235,6 +235,8 @@ func init() {
 			return true
 		case *shared.ServiceBusyError:
 			return true
+		case *shared.AccessDeniedError:
+			return true
 		default:
 			return false
 		}
@@ -271,6 +273,11 @@ func init() {
 				return nil, errors.New("WrapResponse received non-nil error type with nil value for WorkflowService_DescribeTaskList_Result.ServiceBusyError")
 			}
 			return &WorkflowService_DescribeTaskList_Result{ServiceBusyError: e}, nil
+		case *shared.AccessDeniedError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for WorkflowService_DescribeTaskList_Result.AccessDeniedError")
+			}
+			return &WorkflowService_DescribeTaskList_Result{AccessDeniedError: e}, nil
 		}
 
 		return nil, err
@@ -296,6 +303,10 @@ func init() {
 			err = result.ServiceBusyError
 			return
 		}
+		if result.AccessDeniedError != nil {
+			err = result.AccessDeniedError
+			return
+		}
 
 		if result.Success != nil {
 			success = result.Success
@@ -321,6 +332,7 @@ type WorkflowService_DescribeTaskList_Result struct {
 	EntityNotExistError  *shared.EntityNotExistsError     `json:"entityNotExistError,omitempty"`
 	LimitExceededError   *shared.LimitExceededError       `json:"limitExceededError,omitempty"`
 	ServiceBusyError     *shared.ServiceBusyError         `json:"serviceBusyError,omitempty"`
+	AccessDeniedError    *shared.AccessDeniedError        `json:"accessDeniedError,omitempty"`
 }
 
 // ToWire translates a WorkflowService_DescribeTaskList_Result struct into a Thrift-level intermediate
@@ -340,7 +352,7 @@ type WorkflowService_DescribeTaskList_Result struct {
 //   }
 func (v *WorkflowService_DescribeTaskList_Result) ToWire() (wire.Value, error) {
 	var (
-		fields [6]wire.Field
+		fields [7]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -394,6 +406,14 @@ func (v *WorkflowService_DescribeTaskList_Result) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 5, Value: w}
 		i++
 	}
+	if v.AccessDeniedError != nil {
+		w, err = v.AccessDeniedError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 6, Value: w}
+		i++
+	}
 
 	if i != 1 {
 		return wire.Value{}, fmt.Errorf("WorkflowService_DescribeTaskList_Result should have exactly one field: got %v fields", i)
@@ -483,6 +503,14 @@ func (v *WorkflowService_DescribeTaskList_Result) FromWire(w wire.Value) error {
 					return err
 				}
 
+			}
+		case 6:
+			if field.Value.Type() == wire.TStruct {
+				v.AccessDeniedError, err = _AccessDeniedError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -506,6 +534,9 @@ func (v *WorkflowService_DescribeTaskList_Result) FromWire(w wire.Value) error {
 	if v.ServiceBusyError != nil {
 		count++
 	}
+	if v.AccessDeniedError != nil {
+		count++
+	}
 	if count != 1 {
 		return fmt.Errorf("WorkflowService_DescribeTaskList_Result should have exactly one field: got %v fields", count)
 	}
@@ -520,7 +551,7 @@ func (v *WorkflowService_DescribeTaskList_Result) String() string {
 		return "<nil>"
 	}
 
-	var fields [6]string
+	var fields [7]string
 	i := 0
 	if v.Success != nil {
 		fields[i] = fmt.Sprintf("Success: %v", v.Success)
@@ -546,6 +577,10 @@ func (v *WorkflowService_DescribeTaskList_Result) String() string {
 		fields[i] = fmt.Sprintf("ServiceBusyError: %v", v.ServiceBusyError)
 		i++
 	}
+	if v.AccessDeniedError != nil {
+		fields[i] = fmt.Sprintf("AccessDeniedError: %v", v.AccessDeniedError)
+		i++
+	}
 
 	return fmt.Sprintf("WorkflowService_DescribeTaskList_Result{%v}", strings.Join(fields[:i], ", "))
 }
@@ -573,6 +608,9 @@ func (v *WorkflowService_DescribeTaskList_Result) Equals(rhs *WorkflowService_De
 	if !((v.ServiceBusyError == nil && rhs.ServiceBusyError == nil) || (v.ServiceBusyError != nil && rhs.ServiceBusyError != nil && v.ServiceBusyError.Equals(rhs.ServiceBusyError))) {
 		return false
 	}
+	if !((v.AccessDeniedError == nil && rhs.AccessDeniedError == nil) || (v.AccessDeniedError != nil && rhs.AccessDeniedError != nil && v.AccessDeniedError.Equals(rhs.AccessDeniedError))) {
+		return false
+	}
 
 	return true
 }
@@ -637,6 +675,16 @@ func (v *WorkflowService_DescribeTaskList_Result) GetServiceBusyError() (o *shar
 	return
 }
 
+// GetAccessDeniedError returns the value of AccessDeniedError if it is set or its
+// zero value if it is unset.
+func (v *WorkflowService_DescribeTaskList_Result) GetAccessDeniedError() (o *shared.AccessDeniedError) {
+	if v.AccessDeniedError != nil {
+		return v.AccessDeniedError
+	}
+
+	return
+}
+
 // MethodName returns the name of the Thrift function as specified in
 // the IDL, for which this struct represent the result.
 //
@@ -235,6 +235,8 @@ func init() {
 			return true
 		case *shared.LimitExceededError:
 			return true
+		case *shared.AccessDeniedError:
+			return true
 		default:
 			return false
 		}
@@ -271,6 +273,11 @@ func init() {
 				return nil, errors.New("WrapResponse received non-nil error type with nil value for WorkflowService_ListOpenWorkflowExecutions_Result.LimitExceededError")
 			}
 			return &WorkflowService_ListOpenWorkflowExecutions_Result{LimitExceededError: e}, nil
+		case *shared.AccessDeniedError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for WorkflowService_ListOpenWorkflowExecutions_Result.AccessDeniedError")
+			}
+			return &WorkflowService_ListOpenWorkflowExecutions_Result{AccessDeniedError: e}, nil
 		}
 
 		return nil, err
@@ -296,6 +303,10 @@ func init() {
 			err = result.LimitExceededError
 			return
 		}
+		if result.AccessDeniedError != nil {
+			err = result.AccessDeniedError
+			return
+		}
 
 		if result.Success != nil {
 			success = result.Success
@@ -321,6 +332,7 @@ type WorkflowService_ListOpenWorkflowExecutions_Result struct {
 	EntityNotExistError  *shared.EntityNotExistsError               `json:"entityNotExistError,omitempty"`
 	ServiceBusyError     *shared.ServiceBusyError                   `json:"serviceBusyError,omitempty"`
 	LimitExceededError   *shared.LimitExceededError                 `json:"limitExceededError,omitempty"`
+	AccessDeniedError    *shared.AccessDeniedError                  `json:"accessDeniedError,omitempty"`
 }
 
 // ToWire translates a WorkflowService_ListOpenWorkflowExecutions_Result struct into a Thrift-level intermediate
@@ -340,7 +352,7 @@ type WorkflowService_ListOpenWorkflowExecutions_Result struct {
 //   }
 func (v *WorkflowService_ListOpenWorkflowExecutions_Result) ToWire() (wire.Value, error) {
 	var (
-		fields [6]wire.Field
+		fields [7]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -394,6 +406,14 @@ func (v *WorkflowService_ListOpenWorkflowExecutions_Result) ToWire() (wire.Value
 		fields[i] = wire.Field{ID: 5, Value: w}
 		i++
 	}
+	if v.AccessDeniedError != nil {
+		w, err = v.AccessDeniedError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 6, Value: w}
+		i++
+	}
 
 	if i != 1 {
 		return wire.Value{}, fmt.Errorf("WorkflowService_ListOpenWorkflowExecutions_Result should have exactly one field: got %v fields", i)
@@ -477,6 +497,14 @@ func (v *WorkflowService_ListOpenWorkflowExecutions_Result) FromWire(w wire.Valu
 					return err
 				}
 
+			}
+		case 6:
+			if field.Value.Type() == wire.TStruct {
+				v.AccessDeniedError, err = _AccessDeniedError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -500,6 +528,9 @@ func (v *WorkflowService_ListOpenWorkflowExecutions_Result) FromWire(w wire.Valu
 	if v.LimitExceededError != nil {
 		count++
 	}
+	if v.AccessDeniedError != nil {
+		count++
+	}
 	if count != 1 {
 		return fmt.Errorf("WorkflowService_ListOpenWorkflowExecutions_Result should have exactly one field: got %v fields", count)
 	}
@@ -514,7 +545,7 @@ func (v *WorkflowService_ListOpenWorkflowExecutions_Result) String() string {
 		return "<nil>"
 	}
 
-	var fields [6]string
+	var fields [7]string
 	i := 0
 	if v.Success != nil {
 		fields[i] = fmt.Sprintf("Success: %v", v.Success)
@@ -540,6 +571,10 @@ func (v *WorkflowService_ListOpenWorkflowExecutions_Result) String() string {
 		fields[i] = fmt.Sprintf("LimitExceededError: %v", v.LimitExceededError)
 		i++
 	}
+	if v.AccessDeniedError != nil {
+		fields[i] = fmt.Sprintf("AccessDeniedError: %v", v.AccessDeniedError)
+		i++
+	}
 
 	return fmt.Sprintf("WorkflowService_ListOpenWorkflowExecutions_Result{%v}", strings.Join(fields[:i], ", "))
 }
@@ -567,6 +602,9 @@ func (v *WorkflowService_ListOpenWorkflowExecutions_Result) Equals(rhs *Workflow
 	if !((v.LimitExceededError == nil && rhs.LimitExceededError == nil) || (v.LimitExceededError != nil && rhs.LimitExceededError != nil && v.LimitExceededError.Equals(rhs.LimitExceededError))) {
 		return false
 	}
+	if !((v.AccessDeniedError == nil && rhs.AccessDeniedError == nil) || (v.AccessDeniedError != nil && rhs.AccessDeniedError != nil && v.AccessDeniedError.Equals(rhs.AccessDeniedError))) {
+		return false
+	}
 
 	return true
 }
@@ -631,6 +669,16 @@ func (v *WorkflowService_ListOpenWorkflowExecutions_Result) GetLimitExceededErro
 	return
 }
 
+// GetAccessDeniedError returns the value of AccessDeniedError if it is set or its
+// zero value if it is unset.
+func (v *WorkflowService_ListOpenWorkflowExecutions_Result) GetAccessDeniedError() (o *shared.AccessDeniedError) {
+	if v.AccessDeniedError != nil {
+		return v.AccessDeniedError
+	}
+
+	return
+}
+
 // MethodName returns the name of the Thrift function as specified in
 // the IDL, for which this struct represent the result.
 //
@@ -237,6 +237,8 @@ func init() {
 			return true
 		case *shared.DomainNotActiveError:
 			return true
+		case *shared.AccessDeniedError:
+			return true
 		default:
 			return false
 		}
@@ -278,6 +280,11 @@ func init() {
 				return nil, errors.New("WrapResponse received non-nil error type with nil value for WorkflowService_PollForActivityTask_Result.DomainNotActiveError")
 			}
 			return &WorkflowService_PollForActivityTask_Result{DomainNotActiveError: e}, nil
+		case *shared.AccessDeniedError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for WorkflowService_PollForActivityTask_Result.AccessDeniedError")
+			}
+			return &WorkflowService_PollForActivityTask_Result{AccessDeniedError: e}, nil
 		}
 
 		return nil, err
@@ -307,6 +314,10 @@ func init() {
 			err = result.DomainNotActiveError
 			return
 		}
+		if result.AccessDeniedError != nil {
+			err = result.AccessDeniedError
+			return
+		}
 
 		if result.Success != nil {
 			success = result.Success
@@ -333,6 +344,7 @@ type WorkflowService_PollForActivityTask_Result struct {
 	LimitExceededError   *shared.LimitExceededError          `json:"limitExceededError,omitempty"`
 	EntityNotExistError  *shared.EntityNotExistsError        `json:"entityNotExistError,omitempty"`
 	DomainNotActiveError *shared.DomainNotActiveError        `json:"domainNotActiveError,omitempty"`
+	AccessDeniedError    *shared.AccessDeniedError           `json:"accessDeniedError,omitempty"`
 }
 
 // ToWire translates a WorkflowService_PollForActivityTask_Result struct into a Thrift-level intermediate
@@ -352,7 +364,7 @@ type WorkflowService_PollForActivityTask_Result struct {
 //   }
 func (v *WorkflowService_PollForActivityTask_Result) ToWire() (wire.Value, error) {
 	var (
-		fields [7]wire.Field
+		fields [8]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -414,6 +426,14 @@ func (v *WorkflowService_PollForActivityTask_Result) ToWire() (wire.Value, error
 		fields[i] = wire.Field{ID: 6, Value: w}
 		i++
 	}
+	if v.AccessDeniedError != nil {
+		w, err = v.AccessDeniedError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 7, Value: w}
+		i++
+	}
 
 	if i != 1 {
 		return wire.Value{}, fmt.Errorf("WorkflowService_PollForActivityTask_Result should have exactly one field: got %v fields", i)
@@ -505,6 +525,14 @@ func (v *WorkflowService_PollForActivityTask_Result) FromWire(w wire.Value) erro
 					return err
 				}
 
+			}
+		case 7:
+			if field.Value.Type() == wire.TStruct {
+				v.AccessDeniedError, err = _AccessDeniedError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -531,6 +559,9 @@ func (v *WorkflowService_PollForActivityTask_Result) FromWire(w wire.Value) erro
 	if v.DomainNotActiveError != nil {
 		count++
 	}
+	if v.AccessDeniedError != nil {
+		count++
+	}
 	if count != 1 {
 		return fmt.Errorf("WorkflowService_PollForActivityTask_Result should have exactly one field: got %v fields", count)
 	}
@@ -545,7 +576,7 @@ func (v *WorkflowService_PollForActivityTask_Result) String() string {
 		return "<nil>"
 	}
 
-	var fields [7]string
+	var fields [8]string
 	i := 0
 	if v.Success != nil {
 		fields[i] = fmt.Sprintf("Success: %v", v.Success)
@@ -575,6 +606,10 @@ func (v *WorkflowService_PollForActivityTask_Result) String() string {
 		fields[i] = fmt.Sprintf("DomainNotActiveError: %v", v.DomainNotActiveError)
 		i++
 	}
+	if v.AccessDeniedError != nil {
+		fields[i] = fmt.Sprintf("AccessDeniedError: %v", v.AccessDeniedError)
+		i++
+	}
 
 	return fmt.Sprintf("WorkflowService_PollForActivityTask_Result{%v}", strings.Join(fields[:i], ", "))
 }
@@ -605,6 +640,9 @@ func (v *WorkflowService_PollForActivityTask_Result) Equals(rhs *WorkflowService
 	if !((v.DomainNotActiveError == nil && rhs.DomainNotActiveError == nil) || (v.DomainNotActiveError != nil && rhs.DomainNotActiveError != nil && v.DomainNotActiveError.Equals(rhs.DomainNotActiveError))) {
 		return false
 	}
+	if !((v.AccessDeniedError == nil && rhs.AccessDeniedError == nil) || (v.AccessDeniedError != nil && rhs.AccessDeniedError != nil && v.AccessDeniedError.Equals(rhs.AccessDeniedError))) {
+		return false
+	}
 
 	return true
 }
@@ -679,6 +717,16 @@ func (v *WorkflowService_PollForActivityTask_Result) GetDomainNotActiveError() (
 	return
 }
 
+// GetAccessDeniedError returns the value of AccessDeniedError if it is set or its
+// zero value if it is unset.
+func (v *WorkflowService_PollForActivityTask_Result) GetAccessDeniedError() (o *shared.AccessDeniedError) {
+	if v.AccessDeniedError != nil {
+		return v.AccessDeniedError
+	}
+
+	return
+}
+
 // MethodName returns the name of the Thrift function as specified in
 // the IDL, for which this struct represent the result.
 //
@@ -234,6 +234,8 @@ func init() {
 			return true
 		case *shared.ServiceBusyError:
 			return true
+		case *shared.AccessDeniedError:
+			return true
 		default:
 			return false
 		}
@@ -265,6 +267,11 @@ func init() {
 				return nil, errors.New("WrapResponse received non-nil error type with nil value for WorkflowService_RegisterDomain_Result.ServiceBusyError")
 			}
 			return &WorkflowService_RegisterDomain_Result{ServiceBusyError: e}, nil
+		case *shared.AccessDeniedError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for WorkflowService_RegisterDomain_Result.AccessDeniedError")
+			}
+			return &WorkflowService_RegisterDomain_Result{AccessDeniedError: e}, nil
 		}
 
 		return nil, err
@@ -286,6 +293,10 @@ func init() {
 			err = result.ServiceBusyError
 			return
 		}
+		if result.AccessDeniedError != nil {
+			err = result.AccessDeniedError
+			return
+		}
 		return
 	}
 
@@ -299,6 +310,7 @@ type WorkflowService_RegisterDomain_Result struct {
 	InternalServiceError *shared.InternalServiceError     `json:"internalServiceError,omitempty"`
 	DomainExistsError    *shared.DomainAlreadyExistsError `json:"domainExistsError,omitempty"`
 	ServiceBusyError     *shared.ServiceBusyError         `json:"serviceBusyError,omitempty"`
+	AccessDeniedError    *shared.AccessDeniedError        `json:"accessDeniedError,omitempty"`
 }
 
 // ToWire translates a WorkflowService_RegisterDomain_Result struct into a Thrift-level intermediate
@@ -318,7 +330,7 @@ type WorkflowService_RegisterDomain_Result struct {
 //   }
 func (v *WorkflowService_RegisterDomain_Result) ToWire() (wire.Value, error) {
 	var (
-		fields [4]wire.Field
+		fields [5]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -356,6 +368,14 @@ func (v *WorkflowService_RegisterDomain_Result) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 4, Value: w}
 		i++
 	}
+	if v.AccessDeniedError != nil {
+		w, err = v.AccessDeniedError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 5, Value: w}
+		i++
+	}
 
 	if i > 1 {
 		return wire.Value{}, fmt.Errorf("WorkflowService_RegisterDomain_Result should have at most one field: got %v fields", i)
@@ -423,6 +443,14 @@ func (v *WorkflowService_RegisterDomain_Result) FromWire(w wire.Value) error {
 					return err
 				}
 
+			}
+		case 5:
+			if field.Value.Type() == wire.TStruct {
+				v.AccessDeniedError, err = _AccessDeniedError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -440,6 +468,9 @@ func (v *WorkflowService_RegisterDomain_Result) FromWire(w wire.Value) error {
 	if v.ServiceBusyError != nil {
 		count++
 	}
+	if v.AccessDeniedError != nil {
+		count++
+	}
 	if count > 1 {
 		return fmt.Errorf("WorkflowService_RegisterDomain_Result should have at most one field: got %v fields", count)
 	}
@@ -454,7 +485,7 @@ func (v *WorkflowService_RegisterDomain_Result) String() string {
 		return "<nil>"
 	}
 
-	var fields [4]string
+	var fields [5]string
 	i := 0
 	if v.BadRequestError != nil {
 		fields[i] = fmt.Sprintf("BadRequestError: %v", v.BadRequestError)
@@ -472,6 +503,10 @@ func (v *WorkflowService_RegisterDomain_Result) String() string {
 		fields[i] = fmt.Sprintf("ServiceBusyError: %v", v.ServiceBusyError)
 		i++
 	}
+	if v.AccessDeniedError != nil {
+		fields[i] = fmt.Sprintf("AccessDeniedError: %v", v.AccessDeniedError)
+		i++
+	}
 
 	return fmt.Sprintf("WorkflowService_RegisterDomain_Result{%v}", strings.Join(fields[:i], ", "))
 }
@@ -493,6 +528,9 @@ func (v *WorkflowService_RegisterDomain_Result) Equals(rhs *WorkflowService_Regi
 	if !((v.ServiceBusyError == nil && rhs.ServiceBusyError == nil) || (v.ServiceBusyError != nil && rhs.ServiceBusyError != nil && v.ServiceBusyError.Equals(rhs.ServiceBusyError))) {
 		return false
 	}
+	if !((v.AccessDeniedError == nil && rhs.AccessDeniedError == nil) || (v.AccessDeniedError != nil && rhs.AccessDeniedError != nil && v.AccessDeniedError.Equals(rhs.AccessDeniedError))) {
+		return false
+	}
 
 	return true
 }
@@ -537,6 +575,16 @@ func (v *WorkflowService_RegisterDomain_Result) GetServiceBusyError() (o *shared
 	return
 }
 
+// GetAccessDeniedError returns the value of AccessDeniedError if it is set or its
+// zero value if it is unset.
+func (v *WorkflowService_RegisterDomain_Result) GetAccessDeniedError() (o *shared.AccessDeniedError) {
+	if v.AccessDeniedError != nil {
+		return v.AccessDeniedError
+	}
+
+	return
+}
+
 // MethodName returns the name of the Thrift function as specified in
 // the IDL, for which this struct represent the result.
 //
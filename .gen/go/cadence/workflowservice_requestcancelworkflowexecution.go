@@ -240,6 +240,8 @@ func init() {
 			return true
 		case *shared.LimitExceededError:
 			return true
+		case *shared.AccessDeniedError:
+			return true
 		default:
 			return false
 		}
@@ -286,6 +288,11 @@ func init() {
 				return nil, errors.New("WrapResponse received non-nil error type with nil value for WorkflowService_RequestCancelWorkflowExecution_Result.LimitExceededError")
 			}
 			return &WorkflowService_RequestCancelWorkflowExecution_Result{LimitExceededError: e}, nil
+		case *shared.AccessDeniedError:
+			if e == nil {
+				return nil, errors.New("WrapResponse received non-nil error type with nil value for WorkflowService_RequestCancelWorkflowExecution_Result.AccessDeniedError")
+			}
+			return &WorkflowService_RequestCancelWorkflowExecution_Result{AccessDeniedError: e}, nil
 		}
 
 		return nil, err
@@ -319,6 +326,10 @@ func init() {
 			err = result.LimitExceededError
 			return
 		}
+		if result.AccessDeniedError != nil {
+			err = result.AccessDeniedError
+			return
+		}
 		return
 	}
 
@@ -335,6 +346,7 @@ type WorkflowService_RequestCancelWorkflowExecution_Result struct {
 	ServiceBusyError                  *shared.ServiceBusyError                  `json:"serviceBusyError,omitempty"`
 	DomainNotActiveError              *shared.DomainNotActiveError              `json:"domainNotActiveError,omitempty"`
 	LimitExceededError                *shared.LimitExceededError                `json:"limitExceededError,omitempty"`
+	AccessDeniedError                 *shared.AccessDeniedError                 `json:"accessDeniedError,omitempty"`
 }
 
 // ToWire translates a WorkflowService_RequestCancelWorkflowExecution_Result struct into a Thrift-level intermediate
@@ -354,7 +366,7 @@ type WorkflowService_RequestCancelWorkflowExecution_Result struct {
 //   }
 func (v *WorkflowService_RequestCancelWorkflowExecution_Result) ToWire() (wire.Value, error) {
 	var (
-		fields [7]wire.Field
+		fields [8]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -416,6 +428,14 @@ func (v *WorkflowService_RequestCancelWorkflowExecution_Result) ToWire() (wire.V
 		fields[i] = wire.Field{ID: 7, Value: w}
 		i++
 	}
+	if v.AccessDeniedError != nil {
+		w, err = v.AccessDeniedError.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 8, Value: w}
+		i++
+	}
 
 	if i > 1 {
 		return wire.Value{}, fmt.Errorf("WorkflowService_RequestCancelWorkflowExecution_Result should have at most one field: got %v fields", i)
@@ -507,6 +527,14 @@ func (v *WorkflowService_RequestCancelWorkflowExecution_Result) FromWire(w wire.
 					return err
 				}
 
+			}
+		case 8:
+			if field.Value.Type() == wire.TStruct {
+				v.AccessDeniedError, err = _AccessDeniedError_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -533,6 +561,9 @@ func (v *WorkflowService_RequestCancelWorkflowExecution_Result) FromWire(w wire.
 	if v.LimitExceededError != nil {
 		count++
 	}
+	if v.AccessDeniedError != nil {
+		count++
+	}
 	if count > 1 {
 		return fmt.Errorf("WorkflowService_RequestCancelWorkflowExecution_Result should have at most one field: got %v fields", count)
 	}
@@ -547,7 +578,7 @@ func (v *WorkflowService_RequestCancelWorkflowExecution_Result) String() string
 		return "<nil>"
 	}
 
-	var fields [7]string
+	var fields [8]string
 	i := 0
 	if v.BadRequestError != nil {
 		fields[i] = fmt.Sprintf("BadRequestError: %v", v.BadRequestError)
@@ -577,6 +608,10 @@ func (v *WorkflowService_RequestCancelWorkflowExecution_Result) String() string
 		fields[i] = fmt.Sprintf("LimitExceededError: %v", v.LimitExceededError)
 		i++
 	}
+	if v.AccessDeniedError != nil {
+		fields[i] = fmt.Sprintf("AccessDeniedError: %v", v.AccessDeniedError)
+		i++
+	}
 
 	return fmt.Sprintf("WorkflowService_RequestCancelWorkflowExecution_Result{%v}", strings.Join(fields[:i], ", "))
 }
@@ -607,6 +642,9 @@ func (v *WorkflowService_RequestCancelWorkflowExecution_Result) Equals(rhs *Work
 	if !((v.LimitExceededError == nil && rhs.LimitExceededError == nil) || (v.LimitExceededError != nil && rhs.LimitExceededError != nil && v.LimitExceededError.Equals(rhs.LimitExceededError))) {
 		return false
 	}
+	if !((v.AccessDeniedError == nil && rhs.AccessDeniedError == nil) || (v.AccessDeniedError != nil && rhs.AccessDeniedError != nil && v.AccessDeniedError.Equals(rhs.AccessDeniedError))) {
+		return false
+	}
 
 	return true
 }
@@ -681,6 +719,16 @@ func (v *WorkflowService_RequestCancelWorkflowExecution_Result) GetLimitExceeded
 	return
 }
 
+// GetAccessDeniedError returns the value of AccessDeniedError if it is set or its
+// zero value if it is unset.
+func (v *WorkflowService_RequestCancelWorkflowExecution_Result) GetAccessDeniedError() (o *shared.AccessDeniedError) {
+	if v.AccessDeniedError != nil {
+		return v.AccessDeniedError
+	}
+
+	return
+}
+
 // MethodName returns the name of the Thrift function as specified in
 // the IDL, for which this struct represent the result.
 //
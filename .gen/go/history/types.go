@@ -621,9 +621,10 @@ func (v *EventAlreadyStartedError) Error() string {
 }
 
 type GetMutableStateRequest struct {
-	DomainUUID          *string                   `json:"domainUUID,omitempty"`
-	Execution           *shared.WorkflowExecution `json:"execution,omitempty"`
-	ExpectedNextEventId *int64                    `json:"expectedNextEventId,omitempty"`
+	DomainUUID                *string                   `json:"domainUUID,omitempty"`
+	Execution                 *shared.WorkflowExecution `json:"execution,omitempty"`
+	ExpectedNextEventId       *int64                    `json:"expectedNextEventId,omitempty"`
+	WaitForDecisionCompletion *bool                     `json:"waitForDecisionCompletion,omitempty"`
 }
 
 // ToWire translates a GetMutableStateRequest struct into a Thrift-level intermediate
@@ -643,7 +644,7 @@ type GetMutableStateRequest struct {
 //   }
 func (v *GetMutableStateRequest) ToWire() (wire.Value, error) {
 	var (
-		fields [3]wire.Field
+		fields [4]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -673,6 +674,14 @@ func (v *GetMutableStateRequest) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 30, Value: w}
 		i++
 	}
+	if v.WaitForDecisionCompletion != nil {
+		w, err = wire.NewValueBool(*(v.WaitForDecisionCompletion)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 40, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
@@ -726,6 +735,16 @@ func (v *GetMutableStateRequest) FromWire(w wire.Value) error {
 					return err
 				}
 
+			}
+		case 40:
+			if field.Value.Type() == wire.TBool {
+				var x bool
+				x, err = field.Value.GetBool(), error(nil)
+				v.WaitForDecisionCompletion = &x
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -740,7 +759,7 @@ func (v *GetMutableStateRequest) String() string {
 		return "<nil>"
 	}
 
-	var fields [3]string
+	var fields [4]string
 	i := 0
 	if v.DomainUUID != nil {
 		fields[i] = fmt.Sprintf("DomainUUID: %v", *(v.DomainUUID))
@@ -754,6 +773,10 @@ func (v *GetMutableStateRequest) String() string {
 		fields[i] = fmt.Sprintf("ExpectedNextEventId: %v", *(v.ExpectedNextEventId))
 		i++
 	}
+	if v.WaitForDecisionCompletion != nil {
+		fields[i] = fmt.Sprintf("WaitForDecisionCompletion: %v", *(v.WaitForDecisionCompletion))
+		i++
+	}
 
 	return fmt.Sprintf("GetMutableStateRequest{%v}", strings.Join(fields[:i], ", "))
 }
@@ -768,6 +791,16 @@ func _I64_EqualsPtr(lhs, rhs *int64) bool {
 	return lhs == nil && rhs == nil
 }
 
+func _Bool_EqualsPtr(lhs, rhs *bool) bool {
+	if lhs != nil && rhs != nil {
+
+		x := *lhs
+		y := *rhs
+		return (x == y)
+	}
+	return lhs == nil && rhs == nil
+}
+
 // Equals returns true if all the fields of this GetMutableStateRequest match the
 // provided GetMutableStateRequest.
 //
@@ -782,6 +815,9 @@ func (v *GetMutableStateRequest) Equals(rhs *GetMutableStateRequest) bool {
 	if !_I64_EqualsPtr(v.ExpectedNextEventId, rhs.ExpectedNextEventId) {
 		return false
 	}
+	if !_Bool_EqualsPtr(v.WaitForDecisionCompletion, rhs.WaitForDecisionCompletion) {
+		return false
+	}
 
 	return true
 }
@@ -816,20 +852,101 @@ func (v *GetMutableStateRequest) GetExpectedNextEventId() (o int64) {
 	return
 }
 
+// GetWaitForDecisionCompletion returns the value of WaitForDecisionCompletion if it is set or its
+// zero value if it is unset.
+func (v *GetMutableStateRequest) GetWaitForDecisionCompletion() (o bool) {
+	if v.WaitForDecisionCompletion != nil {
+		return *v.WaitForDecisionCompletion
+	}
+
+	return
+}
+
 type GetMutableStateResponse struct {
-	Execution                            *shared.WorkflowExecution `json:"execution,omitempty"`
-	WorkflowType                         *shared.WorkflowType      `json:"workflowType,omitempty"`
-	NextEventId                          *int64                    `json:"NextEventId,omitempty"`
-	LastFirstEventId                     *int64                    `json:"LastFirstEventId,omitempty"`
-	TaskList                             *shared.TaskList          `json:"taskList,omitempty"`
-	StickyTaskList                       *shared.TaskList          `json:"stickyTaskList,omitempty"`
-	ClientLibraryVersion                 *string                   `json:"clientLibraryVersion,omitempty"`
-	ClientFeatureVersion                 *string                   `json:"clientFeatureVersion,omitempty"`
-	ClientImpl                           *string                   `json:"clientImpl,omitempty"`
-	IsWorkflowRunning                    *bool                     `json:"isWorkflowRunning,omitempty"`
-	StickyTaskListScheduleToStartTimeout *int32                    `json:"stickyTaskListScheduleToStartTimeout,omitempty"`
+	Execution                            *shared.WorkflowExecution            `json:"execution,omitempty"`
+	WorkflowType                         *shared.WorkflowType                 `json:"workflowType,omitempty"`
+	NextEventId                          *int64                               `json:"NextEventId,omitempty"`
+	LastFirstEventId                     *int64                               `json:"LastFirstEventId,omitempty"`
+	TaskList                             *shared.TaskList                     `json:"taskList,omitempty"`
+	StickyTaskList                       *shared.TaskList                     `json:"stickyTaskList,omitempty"`
+	ClientLibraryVersion                 *string                              `json:"clientLibraryVersion,omitempty"`
+	ClientFeatureVersion                 *string                              `json:"clientFeatureVersion,omitempty"`
+	ClientImpl                           *string                              `json:"clientImpl,omitempty"`
+	IsWorkflowRunning                    *bool                                `json:"isWorkflowRunning,omitempty"`
+	StickyTaskListScheduleToStartTimeout *int32                               `json:"stickyTaskListScheduleToStartTimeout,omitempty"`
+	CloseStatus                          *shared.WorkflowExecutionCloseStatus `json:"closeStatus,omitempty"`
+	ReplicationInfo                      map[string]*shared.ReplicationInfo   `json:"replicationInfo,omitempty"`
+	StaleReadInfo                        *shared.StaleReadInfo                `json:"staleReadInfo,omitempty"`
+	VersionHistory                       []*shared.ReplicationInfo            `json:"versionHistory,omitempty"`
+}
+
+type _Map_String_ReplicationInfo_MapItemList map[string]*shared.ReplicationInfo
+
+func (m _Map_String_ReplicationInfo_MapItemList) ForEach(f func(wire.MapItem) error) error {
+	for k, v := range m {
+		if v == nil {
+			return fmt.Errorf("invalid [%v]: value is nil", k)
+		}
+		kw, err := wire.NewValueString(k), error(nil)
+		if err != nil {
+			return err
+		}
+
+		vw, err := v.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(wire.MapItem{Key: kw, Value: vw})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m _Map_String_ReplicationInfo_MapItemList) Size() int {
+	return len(m)
+}
+
+func (_Map_String_ReplicationInfo_MapItemList) KeyType() wire.Type {
+	return wire.TBinary
+}
+
+func (_Map_String_ReplicationInfo_MapItemList) ValueType() wire.Type {
+	return wire.TStruct
+}
+
+func (_Map_String_ReplicationInfo_MapItemList) Close() {}
+
+type _List_ReplicationInfo_ValueList []*shared.ReplicationInfo
+
+func (v _List_ReplicationInfo_ValueList) ForEach(f func(wire.Value) error) error {
+	for i, x := range v {
+		if x == nil {
+			return fmt.Errorf("invalid [%v]: value is nil", i)
+		}
+		w, err := x.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
+func (v _List_ReplicationInfo_ValueList) Size() int {
+	return len(v)
+}
+
+func (_List_ReplicationInfo_ValueList) ValueType() wire.Type {
+	return wire.TStruct
+}
+
+func (_List_ReplicationInfo_ValueList) Close() {}
+
 // ToWire translates a GetMutableStateResponse struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
@@ -847,7 +964,7 @@ type GetMutableStateResponse struct {
 //   }
 func (v *GetMutableStateResponse) ToWire() (wire.Value, error) {
 	var (
-		fields [11]wire.Field
+		fields [15]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -941,6 +1058,38 @@ func (v *GetMutableStateResponse) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 110, Value: w}
 		i++
 	}
+	if v.CloseStatus != nil {
+		w, err = v.CloseStatus.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 120, Value: w}
+		i++
+	}
+	if v.ReplicationInfo != nil {
+		w, err = wire.NewValueMap(_Map_String_ReplicationInfo_MapItemList(v.ReplicationInfo)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 130, Value: w}
+		i++
+	}
+	if v.StaleReadInfo != nil {
+		w, err = v.StaleReadInfo.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 140, Value: w}
+		i++
+	}
+	if v.VersionHistory != nil {
+		w, err = wire.NewValueList(_List_ReplicationInfo_ValueList(v.VersionHistory)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 150, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
@@ -957,6 +1106,70 @@ func _TaskList_Read(w wire.Value) (*shared.TaskList, error) {
 	return &v, err
 }
 
+func _WorkflowExecutionCloseStatus_Read(w wire.Value) (shared.WorkflowExecutionCloseStatus, error) {
+	var v shared.WorkflowExecutionCloseStatus
+	err := v.FromWire(w)
+	return v, err
+}
+
+func _ReplicationInfo_Read(w wire.Value) (*shared.ReplicationInfo, error) {
+	var v shared.ReplicationInfo
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _Map_String_ReplicationInfo_Read(m wire.MapItemList) (map[string]*shared.ReplicationInfo, error) {
+	if m.KeyType() != wire.TBinary {
+		return nil, nil
+	}
+
+	if m.ValueType() != wire.TStruct {
+		return nil, nil
+	}
+
+	o := make(map[string]*shared.ReplicationInfo, m.Size())
+	err := m.ForEach(func(x wire.MapItem) error {
+		k, err := x.Key.GetString(), error(nil)
+		if err != nil {
+			return err
+		}
+
+		v, err := _ReplicationInfo_Read(x.Value)
+		if err != nil {
+			return err
+		}
+
+		o[k] = v
+		return nil
+	})
+	m.Close()
+	return o, err
+}
+
+func _StaleReadInfo_Read(w wire.Value) (*shared.StaleReadInfo, error) {
+	var v shared.StaleReadInfo
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _List_ReplicationInfo_Read(l wire.ValueList) ([]*shared.ReplicationInfo, error) {
+	if l.ValueType() != wire.TStruct {
+		return nil, nil
+	}
+
+	o := make([]*shared.ReplicationInfo, 0, l.Size())
+	err := l.ForEach(func(x wire.Value) error {
+		i, err := _ReplicationInfo_Read(x)
+		if err != nil {
+			return err
+		}
+		o = append(o, i)
+		return nil
+	})
+	l.Close()
+	return o, err
+}
+
 // FromWire deserializes a GetMutableStateResponse struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
@@ -1080,6 +1293,40 @@ func (v *GetMutableStateResponse) FromWire(w wire.Value) error {
 					return err
 				}
 
+			}
+		case 120:
+			if field.Value.Type() == wire.TI32 {
+				var x shared.WorkflowExecutionCloseStatus
+				x, err = _WorkflowExecutionCloseStatus_Read(field.Value)
+				v.CloseStatus = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 130:
+			if field.Value.Type() == wire.TMap {
+				v.ReplicationInfo, err = _Map_String_ReplicationInfo_Read(field.Value.GetMap())
+				if err != nil {
+					return err
+				}
+
+			}
+		case 140:
+			if field.Value.Type() == wire.TStruct {
+				v.StaleReadInfo, err = _StaleReadInfo_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 150:
+			if field.Value.Type() == wire.TList {
+				v.VersionHistory, err = _List_ReplicationInfo_Read(field.Value.GetList())
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -1094,7 +1341,7 @@ func (v *GetMutableStateResponse) String() string {
 		return "<nil>"
 	}
 
-	var fields [11]string
+	var fields [14]string
 	i := 0
 	if v.Execution != nil {
 		fields[i] = fmt.Sprintf("Execution: %v", v.Execution)
@@ -1140,11 +1387,27 @@ func (v *GetMutableStateResponse) String() string {
 		fields[i] = fmt.Sprintf("StickyTaskListScheduleToStartTimeout: %v", *(v.StickyTaskListScheduleToStartTimeout))
 		i++
 	}
+	if v.CloseStatus != nil {
+		fields[i] = fmt.Sprintf("CloseStatus: %v", *(v.CloseStatus))
+		i++
+	}
+	if v.ReplicationInfo != nil {
+		fields[i] = fmt.Sprintf("ReplicationInfo: %v", v.ReplicationInfo)
+		i++
+	}
+	if v.StaleReadInfo != nil {
+		fields[i] = fmt.Sprintf("StaleReadInfo: %v", v.StaleReadInfo)
+		i++
+	}
+	if v.VersionHistory != nil {
+		fields[i] = fmt.Sprintf("VersionHistory: %v", v.VersionHistory)
+		i++
+	}
 
 	return fmt.Sprintf("GetMutableStateResponse{%v}", strings.Join(fields[:i], ", "))
 }
 
-func _Bool_EqualsPtr(lhs, rhs *bool) bool {
+func _I32_EqualsPtr(lhs, rhs *int32) bool {
 	if lhs != nil && rhs != nil {
 
 		x := *lhs
@@ -1154,16 +1417,48 @@ func _Bool_EqualsPtr(lhs, rhs *bool) bool {
 	return lhs == nil && rhs == nil
 }
 
-func _I32_EqualsPtr(lhs, rhs *int32) bool {
+func _WorkflowExecutionCloseStatus_EqualsPtr(lhs, rhs *shared.WorkflowExecutionCloseStatus) bool {
 	if lhs != nil && rhs != nil {
 
 		x := *lhs
 		y := *rhs
-		return (x == y)
+		return x.Equals(y)
 	}
 	return lhs == nil && rhs == nil
 }
 
+func _Map_String_ReplicationInfo_Equals(lhs, rhs map[string]*shared.ReplicationInfo) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+
+	for lk, lv := range lhs {
+		rv, ok := rhs[lk]
+		if !ok {
+			return false
+		}
+		if !lv.Equals(rv) {
+			return false
+		}
+	}
+	return true
+}
+
+func _List_ReplicationInfo_Equals(lhs, rhs []*shared.ReplicationInfo) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !lv.Equals(rv) {
+			return false
+		}
+	}
+
+	return true
+}
+
 // Equals returns true if all the fields of this GetMutableStateResponse match the
 // provided GetMutableStateResponse.
 //
@@ -1202,6 +1497,18 @@ func (v *GetMutableStateResponse) Equals(rhs *GetMutableStateResponse) bool {
 	if !_I32_EqualsPtr(v.StickyTaskListScheduleToStartTimeout, rhs.StickyTaskListScheduleToStartTimeout) {
 		return false
 	}
+	if !_WorkflowExecutionCloseStatus_EqualsPtr(v.CloseStatus, rhs.CloseStatus) {
+		return false
+	}
+	if !((v.ReplicationInfo == nil && rhs.ReplicationInfo == nil) || (v.ReplicationInfo != nil && rhs.ReplicationInfo != nil && _Map_String_ReplicationInfo_Equals(v.ReplicationInfo, rhs.ReplicationInfo))) {
+		return false
+	}
+	if !((v.StaleReadInfo == nil && rhs.StaleReadInfo == nil) || (v.StaleReadInfo != nil && rhs.StaleReadInfo != nil && v.StaleReadInfo.Equals(rhs.StaleReadInfo))) {
+		return false
+	}
+	if !((v.VersionHistory == nil && rhs.VersionHistory == nil) || (v.VersionHistory != nil && rhs.VersionHistory != nil && _List_ReplicationInfo_Equals(v.VersionHistory, rhs.VersionHistory))) {
+		return false
+	}
 
 	return true
 }
@@ -1223,94 +1530,386 @@ func (v *GetMutableStateResponse) GetWorkflowType() (o *shared.WorkflowType) {
 		return v.WorkflowType
 	}
 
-	return
-}
+	return
+}
+
+// GetNextEventId returns the value of NextEventId if it is set or its
+// zero value if it is unset.
+func (v *GetMutableStateResponse) GetNextEventId() (o int64) {
+	if v.NextEventId != nil {
+		return *v.NextEventId
+	}
+
+	return
+}
+
+// GetLastFirstEventId returns the value of LastFirstEventId if it is set or its
+// zero value if it is unset.
+func (v *GetMutableStateResponse) GetLastFirstEventId() (o int64) {
+	if v.LastFirstEventId != nil {
+		return *v.LastFirstEventId
+	}
+
+	return
+}
+
+// GetTaskList returns the value of TaskList if it is set or its
+// zero value if it is unset.
+func (v *GetMutableStateResponse) GetTaskList() (o *shared.TaskList) {
+	if v.TaskList != nil {
+		return v.TaskList
+	}
+
+	return
+}
+
+// GetStickyTaskList returns the value of StickyTaskList if it is set or its
+// zero value if it is unset.
+func (v *GetMutableStateResponse) GetStickyTaskList() (o *shared.TaskList) {
+	if v.StickyTaskList != nil {
+		return v.StickyTaskList
+	}
+
+	return
+}
+
+// GetClientLibraryVersion returns the value of ClientLibraryVersion if it is set or its
+// zero value if it is unset.
+func (v *GetMutableStateResponse) GetClientLibraryVersion() (o string) {
+	if v.ClientLibraryVersion != nil {
+		return *v.ClientLibraryVersion
+	}
+
+	return
+}
+
+// GetClientFeatureVersion returns the value of ClientFeatureVersion if it is set or its
+// zero value if it is unset.
+func (v *GetMutableStateResponse) GetClientFeatureVersion() (o string) {
+	if v.ClientFeatureVersion != nil {
+		return *v.ClientFeatureVersion
+	}
+
+	return
+}
+
+// GetClientImpl returns the value of ClientImpl if it is set or its
+// zero value if it is unset.
+func (v *GetMutableStateResponse) GetClientImpl() (o string) {
+	if v.ClientImpl != nil {
+		return *v.ClientImpl
+	}
+
+	return
+}
+
+// GetIsWorkflowRunning returns the value of IsWorkflowRunning if it is set or its
+// zero value if it is unset.
+func (v *GetMutableStateResponse) GetIsWorkflowRunning() (o bool) {
+	if v.IsWorkflowRunning != nil {
+		return *v.IsWorkflowRunning
+	}
+
+	return
+}
+
+// GetStickyTaskListScheduleToStartTimeout returns the value of StickyTaskListScheduleToStartTimeout if it is set or its
+// zero value if it is unset.
+func (v *GetMutableStateResponse) GetStickyTaskListScheduleToStartTimeout() (o int32) {
+	if v.StickyTaskListScheduleToStartTimeout != nil {
+		return *v.StickyTaskListScheduleToStartTimeout
+	}
+
+	return
+}
+
+// GetCloseStatus returns the value of CloseStatus if it is set or its
+// zero value if it is unset.
+func (v *GetMutableStateResponse) GetCloseStatus() (o shared.WorkflowExecutionCloseStatus) {
+	if v.CloseStatus != nil {
+		return *v.CloseStatus
+	}
+
+	return
+}
+
+// GetReplicationInfo returns the value of ReplicationInfo if it is set or its
+// zero value if it is unset.
+func (v *GetMutableStateResponse) GetReplicationInfo() (o map[string]*shared.ReplicationInfo) {
+	if v.ReplicationInfo != nil {
+		return v.ReplicationInfo
+	}
+
+	return
+}
+
+// GetStaleReadInfo returns the value of StaleReadInfo if it is set or its
+// zero value if it is unset.
+func (v *GetMutableStateResponse) GetStaleReadInfo() (o *shared.StaleReadInfo) {
+	if v.StaleReadInfo != nil {
+		return v.StaleReadInfo
+	}
+
+	return
+}
+
+// GetVersionHistory returns the value of VersionHistory if it is set or its
+// zero value if it is unset.
+func (v *GetMutableStateResponse) GetVersionHistory() (o []*shared.ReplicationInfo) {
+	if v.VersionHistory != nil {
+		return v.VersionHistory
+	}
+
+	return
+}
+
+type ImportWorkflowExecutionRequest struct {
+	DomainUUID     *string                   `json:"domainUUID,omitempty"`
+	Execution      *shared.WorkflowExecution `json:"execution,omitempty"`
+	HistoryBatches []*shared.DataBlob        `json:"historyBatches,omitempty"`
+}
+
+type _List_DataBlob_ValueList []*shared.DataBlob
+
+func (v _List_DataBlob_ValueList) ForEach(f func(wire.Value) error) error {
+	for i, x := range v {
+		if x == nil {
+			return fmt.Errorf("invalid [%v]: value is nil", i)
+		}
+		w, err := x.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v _List_DataBlob_ValueList) Size() int {
+	return len(v)
+}
+
+func (_List_DataBlob_ValueList) ValueType() wire.Type {
+	return wire.TStruct
+}
+
+func (_List_DataBlob_ValueList) Close() {}
+
+// ToWire translates a ImportWorkflowExecutionRequest struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *ImportWorkflowExecutionRequest) ToWire() (wire.Value, error) {
+	var (
+		fields [3]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.DomainUUID != nil {
+		w, err = wire.NewValueString(*(v.DomainUUID)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.Execution != nil {
+		w, err = v.Execution.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+	if v.HistoryBatches != nil {
+		w, err = wire.NewValueList(_List_DataBlob_ValueList(v.HistoryBatches)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _DataBlob_Read(w wire.Value) (*shared.DataBlob, error) {
+	var v shared.DataBlob
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _List_DataBlob_Read(l wire.ValueList) ([]*shared.DataBlob, error) {
+	if l.ValueType() != wire.TStruct {
+		return nil, nil
+	}
+
+	o := make([]*shared.DataBlob, 0, l.Size())
+	err := l.ForEach(func(x wire.Value) error {
+		i, err := _DataBlob_Read(x)
+		if err != nil {
+			return err
+		}
+		o = append(o, i)
+		return nil
+	})
+	l.Close()
+	return o, err
+}
+
+// FromWire deserializes a ImportWorkflowExecutionRequest struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a ImportWorkflowExecutionRequest struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v ImportWorkflowExecutionRequest
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *ImportWorkflowExecutionRequest) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.DomainUUID = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TStruct {
+				v.Execution, err = _WorkflowExecution_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 30:
+			if field.Value.Type() == wire.TList {
+				v.HistoryBatches, err = _List_DataBlob_Read(field.Value.GetList())
+				if err != nil {
+					return err
+				}
 
-// GetNextEventId returns the value of NextEventId if it is set or its
-// zero value if it is unset.
-func (v *GetMutableStateResponse) GetNextEventId() (o int64) {
-	if v.NextEventId != nil {
-		return *v.NextEventId
+			}
+		}
 	}
 
-	return
+	return nil
 }
 
-// GetLastFirstEventId returns the value of LastFirstEventId if it is set or its
-// zero value if it is unset.
-func (v *GetMutableStateResponse) GetLastFirstEventId() (o int64) {
-	if v.LastFirstEventId != nil {
-		return *v.LastFirstEventId
+// String returns a readable string representation of a ImportWorkflowExecutionRequest
+// struct.
+func (v *ImportWorkflowExecutionRequest) String() string {
+	if v == nil {
+		return "<nil>"
 	}
 
-	return
-}
-
-// GetTaskList returns the value of TaskList if it is set or its
-// zero value if it is unset.
-func (v *GetMutableStateResponse) GetTaskList() (o *shared.TaskList) {
-	if v.TaskList != nil {
-		return v.TaskList
+	var fields [3]string
+	i := 0
+	if v.DomainUUID != nil {
+		fields[i] = fmt.Sprintf("DomainUUID: %v", *(v.DomainUUID))
+		i++
+	}
+	if v.Execution != nil {
+		fields[i] = fmt.Sprintf("Execution: %v", v.Execution)
+		i++
+	}
+	if v.HistoryBatches != nil {
+		fields[i] = fmt.Sprintf("HistoryBatches: %v", v.HistoryBatches)
+		i++
 	}
 
-	return
+	return fmt.Sprintf("ImportWorkflowExecutionRequest{%v}", strings.Join(fields[:i], ", "))
 }
 
-// GetStickyTaskList returns the value of StickyTaskList if it is set or its
-// zero value if it is unset.
-func (v *GetMutableStateResponse) GetStickyTaskList() (o *shared.TaskList) {
-	if v.StickyTaskList != nil {
-		return v.StickyTaskList
+func _List_DataBlob_Equals(lhs, rhs []*shared.DataBlob) bool {
+	if len(lhs) != len(rhs) {
+		return false
 	}
 
-	return
-}
-
-// GetClientLibraryVersion returns the value of ClientLibraryVersion if it is set or its
-// zero value if it is unset.
-func (v *GetMutableStateResponse) GetClientLibraryVersion() (o string) {
-	if v.ClientLibraryVersion != nil {
-		return *v.ClientLibraryVersion
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !lv.Equals(rv) {
+			return false
+		}
 	}
 
-	return
+	return true
 }
 
-// GetClientFeatureVersion returns the value of ClientFeatureVersion if it is set or its
-// zero value if it is unset.
-func (v *GetMutableStateResponse) GetClientFeatureVersion() (o string) {
-	if v.ClientFeatureVersion != nil {
-		return *v.ClientFeatureVersion
+// Equals returns true if all the fields of this ImportWorkflowExecutionRequest match the
+// provided ImportWorkflowExecutionRequest.
+//
+// This function performs a deep comparison.
+func (v *ImportWorkflowExecutionRequest) Equals(rhs *ImportWorkflowExecutionRequest) bool {
+	if !_String_EqualsPtr(v.DomainUUID, rhs.DomainUUID) {
+		return false
+	}
+	if !((v.Execution == nil && rhs.Execution == nil) || (v.Execution != nil && rhs.Execution != nil && v.Execution.Equals(rhs.Execution))) {
+		return false
+	}
+	if !((v.HistoryBatches == nil && rhs.HistoryBatches == nil) || (v.HistoryBatches != nil && rhs.HistoryBatches != nil && _List_DataBlob_Equals(v.HistoryBatches, rhs.HistoryBatches))) {
+		return false
 	}
 
-	return
+	return true
 }
 
-// GetClientImpl returns the value of ClientImpl if it is set or its
+// GetDomainUUID returns the value of DomainUUID if it is set or its
 // zero value if it is unset.
-func (v *GetMutableStateResponse) GetClientImpl() (o string) {
-	if v.ClientImpl != nil {
-		return *v.ClientImpl
+func (v *ImportWorkflowExecutionRequest) GetDomainUUID() (o string) {
+	if v.DomainUUID != nil {
+		return *v.DomainUUID
 	}
 
 	return
 }
 
-// GetIsWorkflowRunning returns the value of IsWorkflowRunning if it is set or its
+// GetExecution returns the value of Execution if it is set or its
 // zero value if it is unset.
-func (v *GetMutableStateResponse) GetIsWorkflowRunning() (o bool) {
-	if v.IsWorkflowRunning != nil {
-		return *v.IsWorkflowRunning
+func (v *ImportWorkflowExecutionRequest) GetExecution() (o *shared.WorkflowExecution) {
+	if v.Execution != nil {
+		return v.Execution
 	}
 
 	return
 }
 
-// GetStickyTaskListScheduleToStartTimeout returns the value of StickyTaskListScheduleToStartTimeout if it is set or its
+// GetHistoryBatches returns the value of HistoryBatches if it is set or its
 // zero value if it is unset.
-func (v *GetMutableStateResponse) GetStickyTaskListScheduleToStartTimeout() (o int32) {
-	if v.StickyTaskListScheduleToStartTimeout != nil {
-		return *v.StickyTaskListScheduleToStartTimeout
+func (v *ImportWorkflowExecutionRequest) GetHistoryBatches() (o []*shared.DataBlob) {
+	if v.HistoryBatches != nil {
+		return v.HistoryBatches
 	}
 
 	return
@@ -2821,8 +3420,38 @@ type RecordDecisionTaskStartedResponse struct {
 	StickyExecutionEnabled    *bool                         `json:"stickyExecutionEnabled,omitempty"`
 	DecisionInfo              *shared.TransientDecisionInfo `json:"decisionInfo,omitempty"`
 	WorkflowExecutionTaskList *shared.TaskList              `json:"WorkflowExecutionTaskList,omitempty"`
+	Updates                   []*shared.WorkflowUpdate      `json:"updates,omitempty"`
+}
+
+type _List_WorkflowUpdate_ValueList []*shared.WorkflowUpdate
+
+func (v _List_WorkflowUpdate_ValueList) ForEach(f func(wire.Value) error) error {
+	for i, x := range v {
+		if x == nil {
+			return fmt.Errorf("invalid [%v]: value is nil", i)
+		}
+		w, err := x.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
+func (v _List_WorkflowUpdate_ValueList) Size() int {
+	return len(v)
+}
+
+func (_List_WorkflowUpdate_ValueList) ValueType() wire.Type {
+	return wire.TStruct
+}
+
+func (_List_WorkflowUpdate_ValueList) Close() {}
+
 // ToWire translates a RecordDecisionTaskStartedResponse struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
@@ -2840,7 +3469,7 @@ type RecordDecisionTaskStartedResponse struct {
 //   }
 func (v *RecordDecisionTaskStartedResponse) ToWire() (wire.Value, error) {
 	var (
-		fields [9]wire.Field
+		fields [10]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -2918,6 +3547,14 @@ func (v *RecordDecisionTaskStartedResponse) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 90, Value: w}
 		i++
 	}
+	if v.Updates != nil {
+		w, err = wire.NewValueList(_List_WorkflowUpdate_ValueList(v.Updates)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 100, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
@@ -2928,6 +3565,30 @@ func _TransientDecisionInfo_Read(w wire.Value) (*shared.TransientDecisionInfo, e
 	return &v, err
 }
 
+func _WorkflowUpdate_Read(w wire.Value) (*shared.WorkflowUpdate, error) {
+	var v shared.WorkflowUpdate
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _List_WorkflowUpdate_Read(l wire.ValueList) ([]*shared.WorkflowUpdate, error) {
+	if l.ValueType() != wire.TStruct {
+		return nil, nil
+	}
+
+	o := make([]*shared.WorkflowUpdate, 0, l.Size())
+	err := l.ForEach(func(x wire.Value) error {
+		i, err := _WorkflowUpdate_Read(x)
+		if err != nil {
+			return err
+		}
+		o = append(o, i)
+		return nil
+	})
+	l.Close()
+	return o, err
+}
+
 // FromWire deserializes a RecordDecisionTaskStartedResponse struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
@@ -3033,6 +3694,14 @@ func (v *RecordDecisionTaskStartedResponse) FromWire(w wire.Value) error {
 					return err
 				}
 
+			}
+		case 100:
+			if field.Value.Type() == wire.TList {
+				v.Updates, err = _List_WorkflowUpdate_Read(field.Value.GetList())
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -3047,7 +3716,7 @@ func (v *RecordDecisionTaskStartedResponse) String() string {
 		return "<nil>"
 	}
 
-	var fields [9]string
+	var fields [10]string
 	i := 0
 	if v.WorkflowType != nil {
 		fields[i] = fmt.Sprintf("WorkflowType: %v", v.WorkflowType)
@@ -3085,10 +3754,29 @@ func (v *RecordDecisionTaskStartedResponse) String() string {
 		fields[i] = fmt.Sprintf("WorkflowExecutionTaskList: %v", v.WorkflowExecutionTaskList)
 		i++
 	}
+	if v.Updates != nil {
+		fields[i] = fmt.Sprintf("Updates: %v", v.Updates)
+		i++
+	}
 
 	return fmt.Sprintf("RecordDecisionTaskStartedResponse{%v}", strings.Join(fields[:i], ", "))
 }
 
+func _List_WorkflowUpdate_Equals(lhs, rhs []*shared.WorkflowUpdate) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !lv.Equals(rv) {
+			return false
+		}
+	}
+
+	return true
+}
+
 // Equals returns true if all the fields of this RecordDecisionTaskStartedResponse match the
 // provided RecordDecisionTaskStartedResponse.
 //
@@ -3121,6 +3809,9 @@ func (v *RecordDecisionTaskStartedResponse) Equals(rhs *RecordDecisionTaskStarte
 	if !((v.WorkflowExecutionTaskList == nil && rhs.WorkflowExecutionTaskList == nil) || (v.WorkflowExecutionTaskList != nil && rhs.WorkflowExecutionTaskList != nil && v.WorkflowExecutionTaskList.Equals(rhs.WorkflowExecutionTaskList))) {
 		return false
 	}
+	if !((v.Updates == nil && rhs.Updates == nil) || (v.Updates != nil && rhs.Updates != nil && _List_WorkflowUpdate_Equals(v.Updates, rhs.Updates))) {
+		return false
+	}
 
 	return true
 }
@@ -3215,6 +3906,16 @@ func (v *RecordDecisionTaskStartedResponse) GetWorkflowExecutionTaskList() (o *s
 	return
 }
 
+// GetUpdates returns the value of Updates if it is set or its
+// zero value if it is unset.
+func (v *RecordDecisionTaskStartedResponse) GetUpdates() (o []*shared.WorkflowUpdate) {
+	if v.Updates != nil {
+		return v.Updates
+	}
+
+	return
+}
+
 type RemoveSignalMutableStateRequest struct {
 	DomainUUID        *string                   `json:"domainUUID,omitempty"`
 	WorkflowExecution *shared.WorkflowExecution `json:"workflowExecution,omitempty"`
@@ -3402,56 +4103,18 @@ func (v *RemoveSignalMutableStateRequest) GetRequestId() (o string) {
 }
 
 type ReplicateEventsRequest struct {
-	SourceCluster     *string                     `json:"sourceCluster,omitempty"`
-	DomainUUID        *string                     `json:"domainUUID,omitempty"`
-	WorkflowExecution *shared.WorkflowExecution   `json:"workflowExecution,omitempty"`
-	FirstEventId      *int64                      `json:"firstEventId,omitempty"`
-	NextEventId       *int64                      `json:"nextEventId,omitempty"`
-	Version           *int64                      `json:"version,omitempty"`
-	ReplicationInfo   map[string]*ReplicationInfo `json:"replicationInfo,omitempty"`
-	History           *shared.History             `json:"history,omitempty"`
-	NewRunHistory     *shared.History             `json:"newRunHistory,omitempty"`
-	ForceBufferEvents *bool                       `json:"forceBufferEvents,omitempty"`
-}
-
-type _Map_String_ReplicationInfo_MapItemList map[string]*ReplicationInfo
-
-func (m _Map_String_ReplicationInfo_MapItemList) ForEach(f func(wire.MapItem) error) error {
-	for k, v := range m {
-		if v == nil {
-			return fmt.Errorf("invalid [%v]: value is nil", k)
-		}
-		kw, err := wire.NewValueString(k), error(nil)
-		if err != nil {
-			return err
-		}
-
-		vw, err := v.ToWire()
-		if err != nil {
-			return err
-		}
-		err = f(wire.MapItem{Key: kw, Value: vw})
-		if err != nil {
-			return err
-		}
-	}
-	return nil
-}
-
-func (m _Map_String_ReplicationInfo_MapItemList) Size() int {
-	return len(m)
-}
-
-func (_Map_String_ReplicationInfo_MapItemList) KeyType() wire.Type {
-	return wire.TBinary
-}
-
-func (_Map_String_ReplicationInfo_MapItemList) ValueType() wire.Type {
-	return wire.TStruct
+	SourceCluster     *string                            `json:"sourceCluster,omitempty"`
+	DomainUUID        *string                            `json:"domainUUID,omitempty"`
+	WorkflowExecution *shared.WorkflowExecution          `json:"workflowExecution,omitempty"`
+	FirstEventId      *int64                             `json:"firstEventId,omitempty"`
+	NextEventId       *int64                             `json:"nextEventId,omitempty"`
+	Version           *int64                             `json:"version,omitempty"`
+	ReplicationInfo   map[string]*shared.ReplicationInfo `json:"replicationInfo,omitempty"`
+	History           *shared.History                    `json:"history,omitempty"`
+	NewRunHistory     *shared.History                    `json:"newRunHistory,omitempty"`
+	ForceBufferEvents *bool                              `json:"forceBufferEvents,omitempty"`
 }
 
-func (_Map_String_ReplicationInfo_MapItemList) Close() {}
-
 // ToWire translates a ReplicateEventsRequest struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
@@ -3559,40 +4222,6 @@ func (v *ReplicateEventsRequest) ToWire() (wire.Value, error) {
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func _ReplicationInfo_Read(w wire.Value) (*ReplicationInfo, error) {
-	var v ReplicationInfo
-	err := v.FromWire(w)
-	return &v, err
-}
-
-func _Map_String_ReplicationInfo_Read(m wire.MapItemList) (map[string]*ReplicationInfo, error) {
-	if m.KeyType() != wire.TBinary {
-		return nil, nil
-	}
-
-	if m.ValueType() != wire.TStruct {
-		return nil, nil
-	}
-
-	o := make(map[string]*ReplicationInfo, m.Size())
-	err := m.ForEach(func(x wire.MapItem) error {
-		k, err := x.Key.GetString(), error(nil)
-		if err != nil {
-			return err
-		}
-
-		v, err := _ReplicationInfo_Read(x.Value)
-		if err != nil {
-			return err
-		}
-
-		o[k] = v
-		return nil
-	})
-	m.Close()
-	return o, err
-}
-
 func _History_Read(w wire.Value) (*shared.History, error) {
 	var v shared.History
 	err := v.FromWire(w)
@@ -3768,25 +4397,8 @@ func (v *ReplicateEventsRequest) String() string {
 		fields[i] = fmt.Sprintf("ForceBufferEvents: %v", *(v.ForceBufferEvents))
 		i++
 	}
-
-	return fmt.Sprintf("ReplicateEventsRequest{%v}", strings.Join(fields[:i], ", "))
-}
-
-func _Map_String_ReplicationInfo_Equals(lhs, rhs map[string]*ReplicationInfo) bool {
-	if len(lhs) != len(rhs) {
-		return false
-	}
-
-	for lk, lv := range lhs {
-		rv, ok := rhs[lk]
-		if !ok {
-			return false
-		}
-		if !lv.Equals(rv) {
-			return false
-		}
-	}
-	return true
+
+	return fmt.Sprintf("ReplicateEventsRequest{%v}", strings.Join(fields[:i], ", "))
 }
 
 // Equals returns true if all the fields of this ReplicateEventsRequest match the
@@ -3890,7 +4502,7 @@ func (v *ReplicateEventsRequest) GetVersion() (o int64) {
 
 // GetReplicationInfo returns the value of ReplicationInfo if it is set or its
 // zero value if it is unset.
-func (v *ReplicateEventsRequest) GetReplicationInfo() (o map[string]*ReplicationInfo) {
+func (v *ReplicateEventsRequest) GetReplicationInfo() (o map[string]*shared.ReplicationInfo) {
 	if v.ReplicationInfo != nil {
 		return v.ReplicationInfo
 	}
@@ -3928,158 +4540,6 @@ func (v *ReplicateEventsRequest) GetForceBufferEvents() (o bool) {
 	return
 }
 
-type ReplicationInfo struct {
-	Version     *int64 `json:"version,omitempty"`
-	LastEventId *int64 `json:"lastEventId,omitempty"`
-}
-
-// ToWire translates a ReplicationInfo struct into a Thrift-level intermediate
-// representation. This intermediate representation may be serialized
-// into bytes using a ThriftRW protocol implementation.
-//
-// An error is returned if the struct or any of its fields failed to
-// validate.
-//
-//   x, err := v.ToWire()
-//   if err != nil {
-//     return err
-//   }
-//
-//   if err := binaryProtocol.Encode(x, writer); err != nil {
-//     return err
-//   }
-func (v *ReplicationInfo) ToWire() (wire.Value, error) {
-	var (
-		fields [2]wire.Field
-		i      int = 0
-		w      wire.Value
-		err    error
-	)
-
-	if v.Version != nil {
-		w, err = wire.NewValueI64(*(v.Version)), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 10, Value: w}
-		i++
-	}
-	if v.LastEventId != nil {
-		w, err = wire.NewValueI64(*(v.LastEventId)), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 20, Value: w}
-		i++
-	}
-
-	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
-}
-
-// FromWire deserializes a ReplicationInfo struct from its Thrift-level
-// representation. The Thrift-level representation may be obtained
-// from a ThriftRW protocol implementation.
-//
-// An error is returned if we were unable to build a ReplicationInfo struct
-// from the provided intermediate representation.
-//
-//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
-//   if err != nil {
-//     return nil, err
-//   }
-//
-//   var v ReplicationInfo
-//   if err := v.FromWire(x); err != nil {
-//     return nil, err
-//   }
-//   return &v, nil
-func (v *ReplicationInfo) FromWire(w wire.Value) error {
-	var err error
-
-	for _, field := range w.GetStruct().Fields {
-		switch field.ID {
-		case 10:
-			if field.Value.Type() == wire.TI64 {
-				var x int64
-				x, err = field.Value.GetI64(), error(nil)
-				v.Version = &x
-				if err != nil {
-					return err
-				}
-
-			}
-		case 20:
-			if field.Value.Type() == wire.TI64 {
-				var x int64
-				x, err = field.Value.GetI64(), error(nil)
-				v.LastEventId = &x
-				if err != nil {
-					return err
-				}
-
-			}
-		}
-	}
-
-	return nil
-}
-
-// String returns a readable string representation of a ReplicationInfo
-// struct.
-func (v *ReplicationInfo) String() string {
-	if v == nil {
-		return "<nil>"
-	}
-
-	var fields [2]string
-	i := 0
-	if v.Version != nil {
-		fields[i] = fmt.Sprintf("Version: %v", *(v.Version))
-		i++
-	}
-	if v.LastEventId != nil {
-		fields[i] = fmt.Sprintf("LastEventId: %v", *(v.LastEventId))
-		i++
-	}
-
-	return fmt.Sprintf("ReplicationInfo{%v}", strings.Join(fields[:i], ", "))
-}
-
-// Equals returns true if all the fields of this ReplicationInfo match the
-// provided ReplicationInfo.
-//
-// This function performs a deep comparison.
-func (v *ReplicationInfo) Equals(rhs *ReplicationInfo) bool {
-	if !_I64_EqualsPtr(v.Version, rhs.Version) {
-		return false
-	}
-	if !_I64_EqualsPtr(v.LastEventId, rhs.LastEventId) {
-		return false
-	}
-
-	return true
-}
-
-// GetVersion returns the value of Version if it is set or its
-// zero value if it is unset.
-func (v *ReplicationInfo) GetVersion() (o int64) {
-	if v.Version != nil {
-		return *v.Version
-	}
-
-	return
-}
-
-// GetLastEventId returns the value of LastEventId if it is set or its
-// zero value if it is unset.
-func (v *ReplicationInfo) GetLastEventId() (o int64) {
-	if v.LastEventId != nil {
-		return *v.LastEventId
-	}
-
-	return
-}
-
 type RequestCancelWorkflowExecutionRequest struct {
 	DomainUUID                *string                                       `json:"domainUUID,omitempty"`
 	CancelRequest             *shared.RequestCancelWorkflowExecutionRequest `json:"cancelRequest,omitempty"`
@@ -6767,3 +7227,159 @@ func (v *TerminateWorkflowExecutionRequest) GetTerminateRequest() (o *shared.Ter
 
 	return
 }
+
+type UpdateWorkflowExecutionRequest struct {
+	DomainUUID    *string                                `json:"domainUUID,omitempty"`
+	UpdateRequest *shared.UpdateWorkflowExecutionRequest `json:"updateRequest,omitempty"`
+}
+
+// ToWire translates a UpdateWorkflowExecutionRequest struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *UpdateWorkflowExecutionRequest) ToWire() (wire.Value, error) {
+	var (
+		fields [2]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.DomainUUID != nil {
+		w, err = wire.NewValueString(*(v.DomainUUID)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.UpdateRequest != nil {
+		w, err = v.UpdateRequest.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _UpdateWorkflowExecutionRequest_Read(w wire.Value) (*shared.UpdateWorkflowExecutionRequest, error) {
+	var v shared.UpdateWorkflowExecutionRequest
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a UpdateWorkflowExecutionRequest struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a UpdateWorkflowExecutionRequest struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v UpdateWorkflowExecutionRequest
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *UpdateWorkflowExecutionRequest) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.DomainUUID = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TStruct {
+				v.UpdateRequest, err = _UpdateWorkflowExecutionRequest_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a UpdateWorkflowExecutionRequest
+// struct.
+func (v *UpdateWorkflowExecutionRequest) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [2]string
+	i := 0
+	if v.DomainUUID != nil {
+		fields[i] = fmt.Sprintf("DomainUUID: %v", *(v.DomainUUID))
+		i++
+	}
+	if v.UpdateRequest != nil {
+		fields[i] = fmt.Sprintf("UpdateRequest: %v", v.UpdateRequest)
+		i++
+	}
+
+	return fmt.Sprintf("UpdateWorkflowExecutionRequest{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this UpdateWorkflowExecutionRequest match the
+// provided UpdateWorkflowExecutionRequest.
+//
+// This function performs a deep comparison.
+func (v *UpdateWorkflowExecutionRequest) Equals(rhs *UpdateWorkflowExecutionRequest) bool {
+	if !_String_EqualsPtr(v.DomainUUID, rhs.DomainUUID) {
+		return false
+	}
+	if !((v.UpdateRequest == nil && rhs.UpdateRequest == nil) || (v.UpdateRequest != nil && rhs.UpdateRequest != nil && v.UpdateRequest.Equals(rhs.UpdateRequest))) {
+		return false
+	}
+
+	return true
+}
+
+// GetDomainUUID returns the value of DomainUUID if it is set or its
+// zero value if it is unset.
+func (v *UpdateWorkflowExecutionRequest) GetDomainUUID() (o string) {
+	if v.DomainUUID != nil {
+		return *v.DomainUUID
+	}
+
+	return
+}
+
+// GetUpdateRequest returns the value of UpdateRequest if it is set or its
+// zero value if it is unset.
+func (v *UpdateWorkflowExecutionRequest) GetUpdateRequest() (o *shared.UpdateWorkflowExecutionRequest) {
+	if v.UpdateRequest != nil {
+		return v.UpdateRequest
+	}
+
+	return
+}
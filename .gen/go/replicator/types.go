@@ -27,7 +27,6 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
-	"github.com/uber/cadence/.gen/go/history"
 	"github.com/uber/cadence/.gen/go/shared"
 	"go.uber.org/thriftrw/wire"
 	"math"
@@ -35,168 +34,13 @@ import (
 	"strings"
 )
 
-type DomainOperation int32
-
-const (
-	DomainOperationCreate DomainOperation = 0
-	DomainOperationUpdate DomainOperation = 1
-)
-
-// DomainOperation_Values returns all recognized values of DomainOperation.
-func DomainOperation_Values() []DomainOperation {
-	return []DomainOperation{
-		DomainOperationCreate,
-		DomainOperationUpdate,
-	}
-}
-
-// UnmarshalText tries to decode DomainOperation from a byte slice
-// containing its name.
-//
-//   var v DomainOperation
-//   err := v.UnmarshalText([]byte("Create"))
-func (v *DomainOperation) UnmarshalText(value []byte) error {
-	switch string(value) {
-	case "Create":
-		*v = DomainOperationCreate
-		return nil
-	case "Update":
-		*v = DomainOperationUpdate
-		return nil
-	default:
-		return fmt.Errorf("unknown enum value %q for %q", value, "DomainOperation")
-	}
-}
-
-// MarshalText encodes DomainOperation to text.
-//
-// If the enum value is recognized, its name is returned. Otherwise,
-// its integer value is returned.
-//
-// This implements the TextMarshaler interface.
-func (v DomainOperation) MarshalText() ([]byte, error) {
-	switch int32(v) {
-	case 0:
-		return []byte("Create"), nil
-	case 1:
-		return []byte("Update"), nil
-	}
-	return []byte(strconv.FormatInt(int64(v), 10)), nil
-}
-
-// Ptr returns a pointer to this enum value.
-func (v DomainOperation) Ptr() *DomainOperation {
-	return &v
-}
-
-// ToWire translates DomainOperation into a Thrift-level intermediate
-// representation. This intermediate representation may be serialized
-// into bytes using a ThriftRW protocol implementation.
-//
-// Enums are represented as 32-bit integers over the wire.
-func (v DomainOperation) ToWire() (wire.Value, error) {
-	return wire.NewValueI32(int32(v)), nil
-}
-
-// FromWire deserializes DomainOperation from its Thrift-level
-// representation.
-//
-//   x, err := binaryProtocol.Decode(reader, wire.TI32)
-//   if err != nil {
-//     return DomainOperation(0), err
-//   }
-//
-//   var v DomainOperation
-//   if err := v.FromWire(x); err != nil {
-//     return DomainOperation(0), err
-//   }
-//   return v, nil
-func (v *DomainOperation) FromWire(w wire.Value) error {
-	*v = (DomainOperation)(w.GetI32())
-	return nil
-}
-
-// String returns a readable string representation of DomainOperation.
-func (v DomainOperation) String() string {
-	w := int32(v)
-	switch w {
-	case 0:
-		return "Create"
-	case 1:
-		return "Update"
-	}
-	return fmt.Sprintf("DomainOperation(%d)", w)
-}
-
-// Equals returns true if this DomainOperation value matches the provided
-// value.
-func (v DomainOperation) Equals(rhs DomainOperation) bool {
-	return v == rhs
-}
-
-// MarshalJSON serializes DomainOperation into JSON.
-//
-// If the enum value is recognized, its name is returned. Otherwise,
-// its integer value is returned.
-//
-// This implements json.Marshaler.
-func (v DomainOperation) MarshalJSON() ([]byte, error) {
-	switch int32(v) {
-	case 0:
-		return ([]byte)("\"Create\""), nil
-	case 1:
-		return ([]byte)("\"Update\""), nil
-	}
-	return ([]byte)(strconv.FormatInt(int64(v), 10)), nil
-}
-
-// UnmarshalJSON attempts to decode DomainOperation from its JSON
-// representation.
-//
-// This implementation supports both, numeric and string inputs. If a
-// string is provided, it must be a known enum name.
-//
-// This implements json.Unmarshaler.
-func (v *DomainOperation) UnmarshalJSON(text []byte) error {
-	d := json.NewDecoder(bytes.NewReader(text))
-	d.UseNumber()
-	t, err := d.Token()
-	if err != nil {
-		return err
-	}
-
-	switch w := t.(type) {
-	case json.Number:
-		x, err := w.Int64()
-		if err != nil {
-			return err
-		}
-		if x > math.MaxInt32 {
-			return fmt.Errorf("enum overflow from JSON %q for %q", text, "DomainOperation")
-		}
-		if x < math.MinInt32 {
-			return fmt.Errorf("enum underflow from JSON %q for %q", text, "DomainOperation")
-		}
-		*v = (DomainOperation)(x)
-		return nil
-	case string:
-		return v.UnmarshalText([]byte(w))
-	default:
-		return fmt.Errorf("invalid JSON value %q (%T) to unmarshal into %q", t, t, "DomainOperation")
-	}
-}
-
-type DomainTaskAttributes struct {
-	DomainOperation   *DomainOperation                       `json:"domainOperation,omitempty"`
-	ID                *string                                `json:"id,omitempty"`
-	Info              *shared.DomainInfo                     `json:"info,omitempty"`
-	Config            *shared.DomainConfiguration            `json:"config,omitempty"`
-	ReplicationConfig *shared.DomainReplicationConfiguration `json:"replicationConfig,omitempty"`
-	ConfigVersion     *int64                                 `json:"configVersion,omitempty"`
-	FailoverVersion   *int64                                 `json:"failoverVersion,omitempty"`
+type ConsumerPartitionOffset struct {
+	Partition       *int32 `json:"partition,omitempty"`
+	CommittedOffset *int64 `json:"committedOffset,omitempty"`
+	LatestOffset    *int64 `json:"latestOffset,omitempty"`
 }
 
-// ToWire translates a DomainTaskAttributes struct into a Thrift-level intermediate
+// ToWire translates a ConsumerPartitionOffset struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -211,103 +55,47 @@ type DomainTaskAttributes struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *DomainTaskAttributes) ToWire() (wire.Value, error) {
+func (v *ConsumerPartitionOffset) ToWire() (wire.Value, error) {
 	var (
-		fields [7]wire.Field
+		fields [3]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
 	)
 
-	if v.DomainOperation != nil {
-		w, err = v.DomainOperation.ToWire()
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 5, Value: w}
-		i++
-	}
-	if v.ID != nil {
-		w, err = wire.NewValueString(*(v.ID)), error(nil)
+	if v.Partition != nil {
+		w, err = wire.NewValueI32(*(v.Partition)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 10, Value: w}
 		i++
 	}
-	if v.Info != nil {
-		w, err = v.Info.ToWire()
+	if v.CommittedOffset != nil {
+		w, err = wire.NewValueI64(*(v.CommittedOffset)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 20, Value: w}
 		i++
 	}
-	if v.Config != nil {
-		w, err = v.Config.ToWire()
+	if v.LatestOffset != nil {
+		w, err = wire.NewValueI64(*(v.LatestOffset)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 30, Value: w}
 		i++
 	}
-	if v.ReplicationConfig != nil {
-		w, err = v.ReplicationConfig.ToWire()
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 40, Value: w}
-		i++
-	}
-	if v.ConfigVersion != nil {
-		w, err = wire.NewValueI64(*(v.ConfigVersion)), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 50, Value: w}
-		i++
-	}
-	if v.FailoverVersion != nil {
-		w, err = wire.NewValueI64(*(v.FailoverVersion)), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 60, Value: w}
-		i++
-	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func _DomainOperation_Read(w wire.Value) (DomainOperation, error) {
-	var v DomainOperation
-	err := v.FromWire(w)
-	return v, err
-}
-
-func _DomainInfo_Read(w wire.Value) (*shared.DomainInfo, error) {
-	var v shared.DomainInfo
-	err := v.FromWire(w)
-	return &v, err
-}
-
-func _DomainConfiguration_Read(w wire.Value) (*shared.DomainConfiguration, error) {
-	var v shared.DomainConfiguration
-	err := v.FromWire(w)
-	return &v, err
-}
-
-func _DomainReplicationConfiguration_Read(w wire.Value) (*shared.DomainReplicationConfiguration, error) {
-	var v shared.DomainReplicationConfiguration
-	err := v.FromWire(w)
-	return &v, err
-}
-
-// FromWire deserializes a DomainTaskAttributes struct from its Thrift-level
+// FromWire deserializes a ConsumerPartitionOffset struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a DomainTaskAttributes struct
+// An error is returned if we were unable to build a ConsumerPartitionOffset struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -315,75 +103,41 @@ func _DomainReplicationConfiguration_Read(w wire.Value) (*shared.DomainReplicati
 //     return nil, err
 //   }
 //
-//   var v DomainTaskAttributes
+//   var v ConsumerPartitionOffset
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *DomainTaskAttributes) FromWire(w wire.Value) error {
+func (v *ConsumerPartitionOffset) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
 		switch field.ID {
-		case 5:
-			if field.Value.Type() == wire.TI32 {
-				var x DomainOperation
-				x, err = _DomainOperation_Read(field.Value)
-				v.DomainOperation = &x
-				if err != nil {
-					return err
-				}
-
-			}
 		case 10:
-			if field.Value.Type() == wire.TBinary {
-				var x string
-				x, err = field.Value.GetString(), error(nil)
-				v.ID = &x
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.Partition = &x
 				if err != nil {
 					return err
 				}
 
 			}
 		case 20:
-			if field.Value.Type() == wire.TStruct {
-				v.Info, err = _DomainInfo_Read(field.Value)
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.CommittedOffset = &x
 				if err != nil {
 					return err
 				}
 
 			}
 		case 30:
-			if field.Value.Type() == wire.TStruct {
-				v.Config, err = _DomainConfiguration_Read(field.Value)
-				if err != nil {
-					return err
-				}
-
-			}
-		case 40:
-			if field.Value.Type() == wire.TStruct {
-				v.ReplicationConfig, err = _DomainReplicationConfiguration_Read(field.Value)
-				if err != nil {
-					return err
-				}
-
-			}
-		case 50:
-			if field.Value.Type() == wire.TI64 {
-				var x int64
-				x, err = field.Value.GetI64(), error(nil)
-				v.ConfigVersion = &x
-				if err != nil {
-					return err
-				}
-
-			}
-		case 60:
-			if field.Value.Type() == wire.TI64 {
-				var x int64
-				x, err = field.Value.GetI64(), error(nil)
-				v.FailoverVersion = &x
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.LatestOffset = &x
 				if err != nil {
 					return err
 				}
@@ -395,58 +149,32 @@ func (v *DomainTaskAttributes) FromWire(w wire.Value) error {
 	return nil
 }
 
-// String returns a readable string representation of a DomainTaskAttributes
+// String returns a readable string representation of a ConsumerPartitionOffset
 // struct.
-func (v *DomainTaskAttributes) String() string {
+func (v *ConsumerPartitionOffset) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 
-	var fields [7]string
+	var fields [3]string
 	i := 0
-	if v.DomainOperation != nil {
-		fields[i] = fmt.Sprintf("DomainOperation: %v", *(v.DomainOperation))
-		i++
-	}
-	if v.ID != nil {
-		fields[i] = fmt.Sprintf("ID: %v", *(v.ID))
-		i++
-	}
-	if v.Info != nil {
-		fields[i] = fmt.Sprintf("Info: %v", v.Info)
-		i++
-	}
-	if v.Config != nil {
-		fields[i] = fmt.Sprintf("Config: %v", v.Config)
-		i++
-	}
-	if v.ReplicationConfig != nil {
-		fields[i] = fmt.Sprintf("ReplicationConfig: %v", v.ReplicationConfig)
+	if v.Partition != nil {
+		fields[i] = fmt.Sprintf("Partition: %v", *(v.Partition))
 		i++
 	}
-	if v.ConfigVersion != nil {
-		fields[i] = fmt.Sprintf("ConfigVersion: %v", *(v.ConfigVersion))
+	if v.CommittedOffset != nil {
+		fields[i] = fmt.Sprintf("CommittedOffset: %v", *(v.CommittedOffset))
 		i++
 	}
-	if v.FailoverVersion != nil {
-		fields[i] = fmt.Sprintf("FailoverVersion: %v", *(v.FailoverVersion))
+	if v.LatestOffset != nil {
+		fields[i] = fmt.Sprintf("LatestOffset: %v", *(v.LatestOffset))
 		i++
 	}
 
-	return fmt.Sprintf("DomainTaskAttributes{%v}", strings.Join(fields[:i], ", "))
-}
-
-func _DomainOperation_EqualsPtr(lhs, rhs *DomainOperation) bool {
-	if lhs != nil && rhs != nil {
-
-		x := *lhs
-		y := *rhs
-		return x.Equals(y)
-	}
-	return lhs == nil && rhs == nil
+	return fmt.Sprintf("ConsumerPartitionOffset{%v}", strings.Join(fields[:i], ", "))
 }
 
-func _String_EqualsPtr(lhs, rhs *string) bool {
+func _I32_EqualsPtr(lhs, rhs *int32) bool {
 	if lhs != nil && rhs != nil {
 
 		x := *lhs
@@ -466,184 +194,61 @@ func _I64_EqualsPtr(lhs, rhs *int64) bool {
 	return lhs == nil && rhs == nil
 }
 
-// Equals returns true if all the fields of this DomainTaskAttributes match the
-// provided DomainTaskAttributes.
+// Equals returns true if all the fields of this ConsumerPartitionOffset match the
+// provided ConsumerPartitionOffset.
 //
 // This function performs a deep comparison.
-func (v *DomainTaskAttributes) Equals(rhs *DomainTaskAttributes) bool {
-	if !_DomainOperation_EqualsPtr(v.DomainOperation, rhs.DomainOperation) {
-		return false
-	}
-	if !_String_EqualsPtr(v.ID, rhs.ID) {
-		return false
-	}
-	if !((v.Info == nil && rhs.Info == nil) || (v.Info != nil && rhs.Info != nil && v.Info.Equals(rhs.Info))) {
+func (v *ConsumerPartitionOffset) Equals(rhs *ConsumerPartitionOffset) bool {
+	if !_I32_EqualsPtr(v.Partition, rhs.Partition) {
 		return false
 	}
-	if !((v.Config == nil && rhs.Config == nil) || (v.Config != nil && rhs.Config != nil && v.Config.Equals(rhs.Config))) {
-		return false
-	}
-	if !((v.ReplicationConfig == nil && rhs.ReplicationConfig == nil) || (v.ReplicationConfig != nil && rhs.ReplicationConfig != nil && v.ReplicationConfig.Equals(rhs.ReplicationConfig))) {
+	if !_I64_EqualsPtr(v.CommittedOffset, rhs.CommittedOffset) {
 		return false
 	}
-	if !_I64_EqualsPtr(v.ConfigVersion, rhs.ConfigVersion) {
-		return false
-	}
-	if !_I64_EqualsPtr(v.FailoverVersion, rhs.FailoverVersion) {
+	if !_I64_EqualsPtr(v.LatestOffset, rhs.LatestOffset) {
 		return false
 	}
 
 	return true
 }
 
-// GetDomainOperation returns the value of DomainOperation if it is set or its
-// zero value if it is unset.
-func (v *DomainTaskAttributes) GetDomainOperation() (o DomainOperation) {
-	if v.DomainOperation != nil {
-		return *v.DomainOperation
-	}
-
-	return
-}
-
-// GetID returns the value of ID if it is set or its
-// zero value if it is unset.
-func (v *DomainTaskAttributes) GetID() (o string) {
-	if v.ID != nil {
-		return *v.ID
-	}
-
-	return
-}
-
-// GetInfo returns the value of Info if it is set or its
-// zero value if it is unset.
-func (v *DomainTaskAttributes) GetInfo() (o *shared.DomainInfo) {
-	if v.Info != nil {
-		return v.Info
-	}
-
-	return
-}
-
-// GetConfig returns the value of Config if it is set or its
-// zero value if it is unset.
-func (v *DomainTaskAttributes) GetConfig() (o *shared.DomainConfiguration) {
-	if v.Config != nil {
-		return v.Config
-	}
-
-	return
-}
-
-// GetReplicationConfig returns the value of ReplicationConfig if it is set or its
+// GetPartition returns the value of Partition if it is set or its
 // zero value if it is unset.
-func (v *DomainTaskAttributes) GetReplicationConfig() (o *shared.DomainReplicationConfiguration) {
-	if v.ReplicationConfig != nil {
-		return v.ReplicationConfig
+func (v *ConsumerPartitionOffset) GetPartition() (o int32) {
+	if v.Partition != nil {
+		return *v.Partition
 	}
 
 	return
 }
 
-// GetConfigVersion returns the value of ConfigVersion if it is set or its
+// GetCommittedOffset returns the value of CommittedOffset if it is set or its
 // zero value if it is unset.
-func (v *DomainTaskAttributes) GetConfigVersion() (o int64) {
-	if v.ConfigVersion != nil {
-		return *v.ConfigVersion
+func (v *ConsumerPartitionOffset) GetCommittedOffset() (o int64) {
+	if v.CommittedOffset != nil {
+		return *v.CommittedOffset
 	}
 
 	return
 }
 
-// GetFailoverVersion returns the value of FailoverVersion if it is set or its
+// GetLatestOffset returns the value of LatestOffset if it is set or its
 // zero value if it is unset.
-func (v *DomainTaskAttributes) GetFailoverVersion() (o int64) {
-	if v.FailoverVersion != nil {
-		return *v.FailoverVersion
+func (v *ConsumerPartitionOffset) GetLatestOffset() (o int64) {
+	if v.LatestOffset != nil {
+		return *v.LatestOffset
 	}
 
 	return
 }
 
-type HistoryTaskAttributes struct {
-	TargetClusters  []string                            `json:"targetClusters,omitempty"`
-	DomainId        *string                             `json:"domainId,omitempty"`
-	WorkflowId      *string                             `json:"workflowId,omitempty"`
-	RunId           *string                             `json:"runId,omitempty"`
-	FirstEventId    *int64                              `json:"firstEventId,omitempty"`
-	NextEventId     *int64                              `json:"nextEventId,omitempty"`
-	Version         *int64                              `json:"version,omitempty"`
-	ReplicationInfo map[string]*history.ReplicationInfo `json:"replicationInfo,omitempty"`
-	History         *shared.History                     `json:"history,omitempty"`
-	NewRunHistory   *shared.History                     `json:"newRunHistory,omitempty"`
-}
-
-type _List_String_ValueList []string
-
-func (v _List_String_ValueList) ForEach(f func(wire.Value) error) error {
-	for _, x := range v {
-		w, err := wire.NewValueString(x), error(nil)
-		if err != nil {
-			return err
-		}
-		err = f(w)
-		if err != nil {
-			return err
-		}
-	}
-	return nil
-}
-
-func (v _List_String_ValueList) Size() int {
-	return len(v)
-}
-
-func (_List_String_ValueList) ValueType() wire.Type {
-	return wire.TBinary
-}
-
-func (_List_String_ValueList) Close() {}
-
-type _Map_String_ReplicationInfo_MapItemList map[string]*history.ReplicationInfo
-
-func (m _Map_String_ReplicationInfo_MapItemList) ForEach(f func(wire.MapItem) error) error {
-	for k, v := range m {
-		if v == nil {
-			return fmt.Errorf("invalid [%v]: value is nil", k)
-		}
-		kw, err := wire.NewValueString(k), error(nil)
-		if err != nil {
-			return err
-		}
-
-		vw, err := v.ToWire()
-		if err != nil {
-			return err
-		}
-		err = f(wire.MapItem{Key: kw, Value: vw})
-		if err != nil {
-			return err
-		}
-	}
-	return nil
-}
-
-func (m _Map_String_ReplicationInfo_MapItemList) Size() int {
-	return len(m)
-}
-
-func (_Map_String_ReplicationInfo_MapItemList) KeyType() wire.Type {
-	return wire.TBinary
-}
-
-func (_Map_String_ReplicationInfo_MapItemList) ValueType() wire.Type {
-	return wire.TStruct
+type DLQMessage struct {
+	ID              *DLQMessageID       `json:"id,omitempty"`
+	ShardID         *int32              `json:"shardID,omitempty"`
+	ReplicationTask *DLQReplicationTask `json:"replicationTask,omitempty"`
 }
 
-func (_Map_String_ReplicationInfo_MapItemList) Close() {}
-
-// ToWire translates a HistoryTaskAttributes struct into a Thrift-level intermediate
+// ToWire translates a DLQMessage struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -658,161 +263,234 @@ func (_Map_String_ReplicationInfo_MapItemList) Close() {}
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *HistoryTaskAttributes) ToWire() (wire.Value, error) {
+func (v *DLQMessage) ToWire() (wire.Value, error) {
 	var (
-		fields [10]wire.Field
+		fields [3]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
 	)
 
-	if v.TargetClusters != nil {
-		w, err = wire.NewValueList(_List_String_ValueList(v.TargetClusters)), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 5, Value: w}
-		i++
-	}
-	if v.DomainId != nil {
-		w, err = wire.NewValueString(*(v.DomainId)), error(nil)
+	if v.ID != nil {
+		w, err = v.ID.ToWire()
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 10, Value: w}
 		i++
 	}
-	if v.WorkflowId != nil {
-		w, err = wire.NewValueString(*(v.WorkflowId)), error(nil)
+	if v.ShardID != nil {
+		w, err = wire.NewValueI32(*(v.ShardID)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 20, Value: w}
 		i++
 	}
-	if v.RunId != nil {
-		w, err = wire.NewValueString(*(v.RunId)), error(nil)
+	if v.ReplicationTask != nil {
+		w, err = v.ReplicationTask.ToWire()
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 30, Value: w}
 		i++
 	}
-	if v.FirstEventId != nil {
-		w, err = wire.NewValueI64(*(v.FirstEventId)), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 40, Value: w}
-		i++
-	}
-	if v.NextEventId != nil {
-		w, err = wire.NewValueI64(*(v.NextEventId)), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 50, Value: w}
-		i++
-	}
-	if v.Version != nil {
-		w, err = wire.NewValueI64(*(v.Version)), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 60, Value: w}
-		i++
-	}
-	if v.ReplicationInfo != nil {
-		w, err = wire.NewValueMap(_Map_String_ReplicationInfo_MapItemList(v.ReplicationInfo)), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 70, Value: w}
-		i++
-	}
-	if v.History != nil {
-		w, err = v.History.ToWire()
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 80, Value: w}
-		i++
-	}
-	if v.NewRunHistory != nil {
-		w, err = v.NewRunHistory.ToWire()
-		if err != nil {
-			return w, err
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _DLQMessageID_Read(w wire.Value) (*DLQMessageID, error) {
+	var v DLQMessageID
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _DLQReplicationTask_Read(w wire.Value) (*DLQReplicationTask, error) {
+	var v DLQReplicationTask
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a DLQMessage struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a DLQMessage struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v DLQMessage
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *DLQMessage) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TStruct {
+				v.ID, err = _DLQMessageID_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.ShardID = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 30:
+			if field.Value.Type() == wire.TStruct {
+				v.ReplicationTask, err = _DLQReplicationTask_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
 		}
-		fields[i] = wire.Field{ID: 90, Value: w}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a DLQMessage
+// struct.
+func (v *DLQMessage) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [3]string
+	i := 0
+	if v.ID != nil {
+		fields[i] = fmt.Sprintf("ID: %v", v.ID)
+		i++
+	}
+	if v.ShardID != nil {
+		fields[i] = fmt.Sprintf("ShardID: %v", *(v.ShardID))
+		i++
+	}
+	if v.ReplicationTask != nil {
+		fields[i] = fmt.Sprintf("ReplicationTask: %v", v.ReplicationTask)
 		i++
 	}
 
-	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+	return fmt.Sprintf("DLQMessage{%v}", strings.Join(fields[:i], ", "))
 }
 
-func _List_String_Read(l wire.ValueList) ([]string, error) {
-	if l.ValueType() != wire.TBinary {
-		return nil, nil
+// Equals returns true if all the fields of this DLQMessage match the
+// provided DLQMessage.
+//
+// This function performs a deep comparison.
+func (v *DLQMessage) Equals(rhs *DLQMessage) bool {
+	if !((v.ID == nil && rhs.ID == nil) || (v.ID != nil && rhs.ID != nil && v.ID.Equals(rhs.ID))) {
+		return false
+	}
+	if !_I32_EqualsPtr(v.ShardID, rhs.ShardID) {
+		return false
+	}
+	if !((v.ReplicationTask == nil && rhs.ReplicationTask == nil) || (v.ReplicationTask != nil && rhs.ReplicationTask != nil && v.ReplicationTask.Equals(rhs.ReplicationTask))) {
+		return false
 	}
 
-	o := make([]string, 0, l.Size())
-	err := l.ForEach(func(x wire.Value) error {
-		i, err := x.GetString(), error(nil)
-		if err != nil {
-			return err
-		}
-		o = append(o, i)
-		return nil
-	})
-	l.Close()
-	return o, err
+	return true
 }
 
-func _ReplicationInfo_Read(w wire.Value) (*history.ReplicationInfo, error) {
-	var v history.ReplicationInfo
-	err := v.FromWire(w)
-	return &v, err
+// GetID returns the value of ID if it is set or its
+// zero value if it is unset.
+func (v *DLQMessage) GetID() (o *DLQMessageID) {
+	if v.ID != nil {
+		return v.ID
+	}
+
+	return
 }
 
-func _Map_String_ReplicationInfo_Read(m wire.MapItemList) (map[string]*history.ReplicationInfo, error) {
-	if m.KeyType() != wire.TBinary {
-		return nil, nil
+// GetShardID returns the value of ShardID if it is set or its
+// zero value if it is unset.
+func (v *DLQMessage) GetShardID() (o int32) {
+	if v.ShardID != nil {
+		return *v.ShardID
 	}
 
-	if m.ValueType() != wire.TStruct {
-		return nil, nil
+	return
+}
+
+// GetReplicationTask returns the value of ReplicationTask if it is set or its
+// zero value if it is unset.
+func (v *DLQMessage) GetReplicationTask() (o *DLQReplicationTask) {
+	if v.ReplicationTask != nil {
+		return v.ReplicationTask
 	}
 
-	o := make(map[string]*history.ReplicationInfo, m.Size())
-	err := m.ForEach(func(x wire.MapItem) error {
-		k, err := x.Key.GetString(), error(nil)
+	return
+}
+
+type DLQMessageID struct {
+	Partition *int32 `json:"partition,omitempty"`
+	Offset    *int64 `json:"offset,omitempty"`
+}
+
+// ToWire translates a DLQMessageID struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *DLQMessageID) ToWire() (wire.Value, error) {
+	var (
+		fields [2]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Partition != nil {
+		w, err = wire.NewValueI32(*(v.Partition)), error(nil)
 		if err != nil {
-			return err
+			return w, err
 		}
-
-		v, err := _ReplicationInfo_Read(x.Value)
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.Offset != nil {
+		w, err = wire.NewValueI64(*(v.Offset)), error(nil)
 		if err != nil {
-			return err
+			return w, err
 		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
 
-		o[k] = v
-		return nil
-	})
-	m.Close()
-	return o, err
-}
-
-func _History_Read(w wire.Value) (*shared.History, error) {
-	var v shared.History
-	err := v.FromWire(w)
-	return &v, err
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-// FromWire deserializes a HistoryTaskAttributes struct from its Thrift-level
+// FromWire deserializes a DLQMessageID struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a HistoryTaskAttributes struct
+// An error is returned if we were unable to build a DLQMessageID struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -820,103 +498,3518 @@ func _History_Read(w wire.Value) (*shared.History, error) {
 //     return nil, err
 //   }
 //
-//   var v HistoryTaskAttributes
+//   var v DLQMessageID
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *HistoryTaskAttributes) FromWire(w wire.Value) error {
+func (v *DLQMessageID) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
 		switch field.ID {
-		case 5:
-			if field.Value.Type() == wire.TList {
-				v.TargetClusters, err = _List_String_Read(field.Value.GetList())
-				if err != nil {
-					return err
-				}
-
-			}
 		case 10:
-			if field.Value.Type() == wire.TBinary {
-				var x string
-				x, err = field.Value.GetString(), error(nil)
-				v.DomainId = &x
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.Partition = &x
 				if err != nil {
 					return err
 				}
 
 			}
 		case 20:
-			if field.Value.Type() == wire.TBinary {
-				var x string
-				x, err = field.Value.GetString(), error(nil)
-				v.WorkflowId = &x
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.Offset = &x
 				if err != nil {
 					return err
 				}
 
 			}
-		case 30:
-			if field.Value.Type() == wire.TBinary {
-				var x string
-				x, err = field.Value.GetString(), error(nil)
-				v.RunId = &x
-				if err != nil {
-					return err
-				}
+		}
+	}
 
-			}
-		case 40:
-			if field.Value.Type() == wire.TI64 {
-				var x int64
-				x, err = field.Value.GetI64(), error(nil)
-				v.FirstEventId = &x
+	return nil
+}
+
+// String returns a readable string representation of a DLQMessageID
+// struct.
+func (v *DLQMessageID) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [2]string
+	i := 0
+	if v.Partition != nil {
+		fields[i] = fmt.Sprintf("Partition: %v", *(v.Partition))
+		i++
+	}
+	if v.Offset != nil {
+		fields[i] = fmt.Sprintf("Offset: %v", *(v.Offset))
+		i++
+	}
+
+	return fmt.Sprintf("DLQMessageID{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this DLQMessageID match the
+// provided DLQMessageID.
+//
+// This function performs a deep comparison.
+func (v *DLQMessageID) Equals(rhs *DLQMessageID) bool {
+	if !_I32_EqualsPtr(v.Partition, rhs.Partition) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.Offset, rhs.Offset) {
+		return false
+	}
+
+	return true
+}
+
+// GetPartition returns the value of Partition if it is set or its
+// zero value if it is unset.
+func (v *DLQMessageID) GetPartition() (o int32) {
+	if v.Partition != nil {
+		return *v.Partition
+	}
+
+	return
+}
+
+// GetOffset returns the value of Offset if it is set or its
+// zero value if it is unset.
+func (v *DLQMessageID) GetOffset() (o int64) {
+	if v.Offset != nil {
+		return *v.Offset
+	}
+
+	return
+}
+
+type DLQReplicationTask struct {
+	Task          *ReplicationTask `json:"task,omitempty"`
+	SourceCluster *string          `json:"sourceCluster,omitempty"`
+	LastError     *string          `json:"lastError,omitempty"`
+	Attempts      *int32           `json:"attempts,omitempty"`
+	Timestamp     *int64           `json:"timestamp,omitempty"`
+}
+
+// ToWire translates a DLQReplicationTask struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *DLQReplicationTask) ToWire() (wire.Value, error) {
+	var (
+		fields [5]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Task != nil {
+		w, err = v.Task.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.SourceCluster != nil {
+		w, err = wire.NewValueString(*(v.SourceCluster)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+	if v.LastError != nil {
+		w, err = wire.NewValueString(*(v.LastError)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+	if v.Attempts != nil {
+		w, err = wire.NewValueI32(*(v.Attempts)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 40, Value: w}
+		i++
+	}
+	if v.Timestamp != nil {
+		w, err = wire.NewValueI64(*(v.Timestamp)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 50, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _ReplicationTask_Read(w wire.Value) (*ReplicationTask, error) {
+	var v ReplicationTask
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a DLQReplicationTask struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a DLQReplicationTask struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v DLQReplicationTask
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *DLQReplicationTask) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TStruct {
+				v.Task, err = _ReplicationTask_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.SourceCluster = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 30:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.LastError = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 40:
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.Attempts = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 50:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.Timestamp = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a DLQReplicationTask
+// struct.
+func (v *DLQReplicationTask) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [5]string
+	i := 0
+	if v.Task != nil {
+		fields[i] = fmt.Sprintf("Task: %v", v.Task)
+		i++
+	}
+	if v.SourceCluster != nil {
+		fields[i] = fmt.Sprintf("SourceCluster: %v", *(v.SourceCluster))
+		i++
+	}
+	if v.LastError != nil {
+		fields[i] = fmt.Sprintf("LastError: %v", *(v.LastError))
+		i++
+	}
+	if v.Attempts != nil {
+		fields[i] = fmt.Sprintf("Attempts: %v", *(v.Attempts))
+		i++
+	}
+	if v.Timestamp != nil {
+		fields[i] = fmt.Sprintf("Timestamp: %v", *(v.Timestamp))
+		i++
+	}
+
+	return fmt.Sprintf("DLQReplicationTask{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _String_EqualsPtr(lhs, rhs *string) bool {
+	if lhs != nil && rhs != nil {
+
+		x := *lhs
+		y := *rhs
+		return (x == y)
+	}
+	return lhs == nil && rhs == nil
+}
+
+// Equals returns true if all the fields of this DLQReplicationTask match the
+// provided DLQReplicationTask.
+//
+// This function performs a deep comparison.
+func (v *DLQReplicationTask) Equals(rhs *DLQReplicationTask) bool {
+	if !((v.Task == nil && rhs.Task == nil) || (v.Task != nil && rhs.Task != nil && v.Task.Equals(rhs.Task))) {
+		return false
+	}
+	if !_String_EqualsPtr(v.SourceCluster, rhs.SourceCluster) {
+		return false
+	}
+	if !_String_EqualsPtr(v.LastError, rhs.LastError) {
+		return false
+	}
+	if !_I32_EqualsPtr(v.Attempts, rhs.Attempts) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.Timestamp, rhs.Timestamp) {
+		return false
+	}
+
+	return true
+}
+
+// GetTask returns the value of Task if it is set or its
+// zero value if it is unset.
+func (v *DLQReplicationTask) GetTask() (o *ReplicationTask) {
+	if v.Task != nil {
+		return v.Task
+	}
+
+	return
+}
+
+// GetSourceCluster returns the value of SourceCluster if it is set or its
+// zero value if it is unset.
+func (v *DLQReplicationTask) GetSourceCluster() (o string) {
+	if v.SourceCluster != nil {
+		return *v.SourceCluster
+	}
+
+	return
+}
+
+// GetLastError returns the value of LastError if it is set or its
+// zero value if it is unset.
+func (v *DLQReplicationTask) GetLastError() (o string) {
+	if v.LastError != nil {
+		return *v.LastError
+	}
+
+	return
+}
+
+// GetAttempts returns the value of Attempts if it is set or its
+// zero value if it is unset.
+func (v *DLQReplicationTask) GetAttempts() (o int32) {
+	if v.Attempts != nil {
+		return *v.Attempts
+	}
+
+	return
+}
+
+// GetTimestamp returns the value of Timestamp if it is set or its
+// zero value if it is unset.
+func (v *DLQReplicationTask) GetTimestamp() (o int64) {
+	if v.Timestamp != nil {
+		return *v.Timestamp
+	}
+
+	return
+}
+
+type DescribeReplicationStatusRequest struct {
+	ShardIDs            []int32         `json:"shardIDs,omitempty"`
+	DomainID            *string         `json:"domainID,omitempty"`
+	MaxReadLevelByShard map[int32]int64 `json:"maxReadLevelByShard,omitempty"`
+}
+
+type _List_I32_ValueList []int32
+
+func (v _List_I32_ValueList) ForEach(f func(wire.Value) error) error {
+	for _, x := range v {
+		w, err := wire.NewValueI32(x), error(nil)
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v _List_I32_ValueList) Size() int {
+	return len(v)
+}
+
+func (_List_I32_ValueList) ValueType() wire.Type {
+	return wire.TI32
+}
+
+func (_List_I32_ValueList) Close() {}
+
+type _Map_I32_I64_MapItemList map[int32]int64
+
+func (m _Map_I32_I64_MapItemList) ForEach(f func(wire.MapItem) error) error {
+	for k, v := range m {
+		kw, err := wire.NewValueI32(k), error(nil)
+		if err != nil {
+			return err
+		}
+
+		vw, err := wire.NewValueI64(v), error(nil)
+		if err != nil {
+			return err
+		}
+		err = f(wire.MapItem{Key: kw, Value: vw})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m _Map_I32_I64_MapItemList) Size() int {
+	return len(m)
+}
+
+func (_Map_I32_I64_MapItemList) KeyType() wire.Type {
+	return wire.TI32
+}
+
+func (_Map_I32_I64_MapItemList) ValueType() wire.Type {
+	return wire.TI64
+}
+
+func (_Map_I32_I64_MapItemList) Close() {}
+
+// ToWire translates a DescribeReplicationStatusRequest struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *DescribeReplicationStatusRequest) ToWire() (wire.Value, error) {
+	var (
+		fields [3]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.ShardIDs != nil {
+		w, err = wire.NewValueList(_List_I32_ValueList(v.ShardIDs)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.DomainID != nil {
+		w, err = wire.NewValueString(*(v.DomainID)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+	if v.MaxReadLevelByShard != nil {
+		w, err = wire.NewValueMap(_Map_I32_I64_MapItemList(v.MaxReadLevelByShard)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _List_I32_Read(l wire.ValueList) ([]int32, error) {
+	if l.ValueType() != wire.TI32 {
+		return nil, nil
+	}
+
+	o := make([]int32, 0, l.Size())
+	err := l.ForEach(func(x wire.Value) error {
+		i, err := x.GetI32(), error(nil)
+		if err != nil {
+			return err
+		}
+		o = append(o, i)
+		return nil
+	})
+	l.Close()
+	return o, err
+}
+
+func _Map_I32_I64_Read(m wire.MapItemList) (map[int32]int64, error) {
+	if m.KeyType() != wire.TI32 {
+		return nil, nil
+	}
+
+	if m.ValueType() != wire.TI64 {
+		return nil, nil
+	}
+
+	o := make(map[int32]int64, m.Size())
+	err := m.ForEach(func(x wire.MapItem) error {
+		k, err := x.Key.GetI32(), error(nil)
+		if err != nil {
+			return err
+		}
+
+		v, err := x.Value.GetI64(), error(nil)
+		if err != nil {
+			return err
+		}
+
+		o[k] = v
+		return nil
+	})
+	m.Close()
+	return o, err
+}
+
+// FromWire deserializes a DescribeReplicationStatusRequest struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a DescribeReplicationStatusRequest struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v DescribeReplicationStatusRequest
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *DescribeReplicationStatusRequest) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TList {
+				v.ShardIDs, err = _List_I32_Read(field.Value.GetList())
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.DomainID = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 30:
+			if field.Value.Type() == wire.TMap {
+				v.MaxReadLevelByShard, err = _Map_I32_I64_Read(field.Value.GetMap())
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a DescribeReplicationStatusRequest
+// struct.
+func (v *DescribeReplicationStatusRequest) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [3]string
+	i := 0
+	if v.ShardIDs != nil {
+		fields[i] = fmt.Sprintf("ShardIDs: %v", v.ShardIDs)
+		i++
+	}
+	if v.DomainID != nil {
+		fields[i] = fmt.Sprintf("DomainID: %v", *(v.DomainID))
+		i++
+	}
+	if v.MaxReadLevelByShard != nil {
+		fields[i] = fmt.Sprintf("MaxReadLevelByShard: %v", v.MaxReadLevelByShard)
+		i++
+	}
+
+	return fmt.Sprintf("DescribeReplicationStatusRequest{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _List_I32_Equals(lhs, rhs []int32) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !(lv == rv) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func _Map_I32_I64_Equals(lhs, rhs map[int32]int64) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+
+	for lk, lv := range lhs {
+		rv, ok := rhs[lk]
+		if !ok {
+			return false
+		}
+		if !(lv == rv) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equals returns true if all the fields of this DescribeReplicationStatusRequest match the
+// provided DescribeReplicationStatusRequest.
+//
+// This function performs a deep comparison.
+func (v *DescribeReplicationStatusRequest) Equals(rhs *DescribeReplicationStatusRequest) bool {
+	if !((v.ShardIDs == nil && rhs.ShardIDs == nil) || (v.ShardIDs != nil && rhs.ShardIDs != nil && _List_I32_Equals(v.ShardIDs, rhs.ShardIDs))) {
+		return false
+	}
+	if !_String_EqualsPtr(v.DomainID, rhs.DomainID) {
+		return false
+	}
+	if !((v.MaxReadLevelByShard == nil && rhs.MaxReadLevelByShard == nil) || (v.MaxReadLevelByShard != nil && rhs.MaxReadLevelByShard != nil && _Map_I32_I64_Equals(v.MaxReadLevelByShard, rhs.MaxReadLevelByShard))) {
+		return false
+	}
+
+	return true
+}
+
+// GetShardIDs returns the value of ShardIDs if it is set or its
+// zero value if it is unset.
+func (v *DescribeReplicationStatusRequest) GetShardIDs() (o []int32) {
+	if v.ShardIDs != nil {
+		return v.ShardIDs
+	}
+
+	return
+}
+
+// GetDomainID returns the value of DomainID if it is set or its
+// zero value if it is unset.
+func (v *DescribeReplicationStatusRequest) GetDomainID() (o string) {
+	if v.DomainID != nil {
+		return *v.DomainID
+	}
+
+	return
+}
+
+// GetMaxReadLevelByShard returns the value of MaxReadLevelByShard if it is set or its
+// zero value if it is unset.
+func (v *DescribeReplicationStatusRequest) GetMaxReadLevelByShard() (o map[int32]int64) {
+	if v.MaxReadLevelByShard != nil {
+		return v.MaxReadLevelByShard
+	}
+
+	return
+}
+
+type DescribeReplicationStatusResponse struct {
+	StatusByShard map[int32]*ShardReplicationStatus `json:"statusByShard,omitempty"`
+}
+
+type _Map_I32_ShardReplicationStatus_MapItemList map[int32]*ShardReplicationStatus
+
+func (m _Map_I32_ShardReplicationStatus_MapItemList) ForEach(f func(wire.MapItem) error) error {
+	for k, v := range m {
+		if v == nil {
+			return fmt.Errorf("invalid [%v]: value is nil", k)
+		}
+		kw, err := wire.NewValueI32(k), error(nil)
+		if err != nil {
+			return err
+		}
+
+		vw, err := v.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(wire.MapItem{Key: kw, Value: vw})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m _Map_I32_ShardReplicationStatus_MapItemList) Size() int {
+	return len(m)
+}
+
+func (_Map_I32_ShardReplicationStatus_MapItemList) KeyType() wire.Type {
+	return wire.TI32
+}
+
+func (_Map_I32_ShardReplicationStatus_MapItemList) ValueType() wire.Type {
+	return wire.TStruct
+}
+
+func (_Map_I32_ShardReplicationStatus_MapItemList) Close() {}
+
+// ToWire translates a DescribeReplicationStatusResponse struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *DescribeReplicationStatusResponse) ToWire() (wire.Value, error) {
+	var (
+		fields [1]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.StatusByShard != nil {
+		w, err = wire.NewValueMap(_Map_I32_ShardReplicationStatus_MapItemList(v.StatusByShard)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _ShardReplicationStatus_Read(w wire.Value) (*ShardReplicationStatus, error) {
+	var v ShardReplicationStatus
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _Map_I32_ShardReplicationStatus_Read(m wire.MapItemList) (map[int32]*ShardReplicationStatus, error) {
+	if m.KeyType() != wire.TI32 {
+		return nil, nil
+	}
+
+	if m.ValueType() != wire.TStruct {
+		return nil, nil
+	}
+
+	o := make(map[int32]*ShardReplicationStatus, m.Size())
+	err := m.ForEach(func(x wire.MapItem) error {
+		k, err := x.Key.GetI32(), error(nil)
+		if err != nil {
+			return err
+		}
+
+		v, err := _ShardReplicationStatus_Read(x.Value)
+		if err != nil {
+			return err
+		}
+
+		o[k] = v
+		return nil
+	})
+	m.Close()
+	return o, err
+}
+
+// FromWire deserializes a DescribeReplicationStatusResponse struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a DescribeReplicationStatusResponse struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v DescribeReplicationStatusResponse
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *DescribeReplicationStatusResponse) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TMap {
+				v.StatusByShard, err = _Map_I32_ShardReplicationStatus_Read(field.Value.GetMap())
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a DescribeReplicationStatusResponse
+// struct.
+func (v *DescribeReplicationStatusResponse) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [1]string
+	i := 0
+	if v.StatusByShard != nil {
+		fields[i] = fmt.Sprintf("StatusByShard: %v", v.StatusByShard)
+		i++
+	}
+
+	return fmt.Sprintf("DescribeReplicationStatusResponse{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _Map_I32_ShardReplicationStatus_Equals(lhs, rhs map[int32]*ShardReplicationStatus) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+
+	for lk, lv := range lhs {
+		rv, ok := rhs[lk]
+		if !ok {
+			return false
+		}
+		if !lv.Equals(rv) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equals returns true if all the fields of this DescribeReplicationStatusResponse match the
+// provided DescribeReplicationStatusResponse.
+//
+// This function performs a deep comparison.
+func (v *DescribeReplicationStatusResponse) Equals(rhs *DescribeReplicationStatusResponse) bool {
+	if !((v.StatusByShard == nil && rhs.StatusByShard == nil) || (v.StatusByShard != nil && rhs.StatusByShard != nil && _Map_I32_ShardReplicationStatus_Equals(v.StatusByShard, rhs.StatusByShard))) {
+		return false
+	}
+
+	return true
+}
+
+// GetStatusByShard returns the value of StatusByShard if it is set or its
+// zero value if it is unset.
+func (v *DescribeReplicationStatusResponse) GetStatusByShard() (o map[int32]*ShardReplicationStatus) {
+	if v.StatusByShard != nil {
+		return v.StatusByShard
+	}
+
+	return
+}
+
+type DomainOperation int32
+
+const (
+	DomainOperationCreate DomainOperation = 0
+	DomainOperationUpdate DomainOperation = 1
+)
+
+// DomainOperation_Values returns all recognized values of DomainOperation.
+func DomainOperation_Values() []DomainOperation {
+	return []DomainOperation{
+		DomainOperationCreate,
+		DomainOperationUpdate,
+	}
+}
+
+// UnmarshalText tries to decode DomainOperation from a byte slice
+// containing its name.
+//
+//   var v DomainOperation
+//   err := v.UnmarshalText([]byte("Create"))
+func (v *DomainOperation) UnmarshalText(value []byte) error {
+	switch string(value) {
+	case "Create":
+		*v = DomainOperationCreate
+		return nil
+	case "Update":
+		*v = DomainOperationUpdate
+		return nil
+	default:
+		return fmt.Errorf("unknown enum value %q for %q", value, "DomainOperation")
+	}
+}
+
+// MarshalText encodes DomainOperation to text.
+//
+// If the enum value is recognized, its name is returned. Otherwise,
+// its integer value is returned.
+//
+// This implements the TextMarshaler interface.
+func (v DomainOperation) MarshalText() ([]byte, error) {
+	switch int32(v) {
+	case 0:
+		return []byte("Create"), nil
+	case 1:
+		return []byte("Update"), nil
+	}
+	return []byte(strconv.FormatInt(int64(v), 10)), nil
+}
+
+// Ptr returns a pointer to this enum value.
+func (v DomainOperation) Ptr() *DomainOperation {
+	return &v
+}
+
+// ToWire translates DomainOperation into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// Enums are represented as 32-bit integers over the wire.
+func (v DomainOperation) ToWire() (wire.Value, error) {
+	return wire.NewValueI32(int32(v)), nil
+}
+
+// FromWire deserializes DomainOperation from its Thrift-level
+// representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TI32)
+//   if err != nil {
+//     return DomainOperation(0), err
+//   }
+//
+//   var v DomainOperation
+//   if err := v.FromWire(x); err != nil {
+//     return DomainOperation(0), err
+//   }
+//   return v, nil
+func (v *DomainOperation) FromWire(w wire.Value) error {
+	*v = (DomainOperation)(w.GetI32())
+	return nil
+}
+
+// String returns a readable string representation of DomainOperation.
+func (v DomainOperation) String() string {
+	w := int32(v)
+	switch w {
+	case 0:
+		return "Create"
+	case 1:
+		return "Update"
+	}
+	return fmt.Sprintf("DomainOperation(%d)", w)
+}
+
+// Equals returns true if this DomainOperation value matches the provided
+// value.
+func (v DomainOperation) Equals(rhs DomainOperation) bool {
+	return v == rhs
+}
+
+// MarshalJSON serializes DomainOperation into JSON.
+//
+// If the enum value is recognized, its name is returned. Otherwise,
+// its integer value is returned.
+//
+// This implements json.Marshaler.
+func (v DomainOperation) MarshalJSON() ([]byte, error) {
+	switch int32(v) {
+	case 0:
+		return ([]byte)("\"Create\""), nil
+	case 1:
+		return ([]byte)("\"Update\""), nil
+	}
+	return ([]byte)(strconv.FormatInt(int64(v), 10)), nil
+}
+
+// UnmarshalJSON attempts to decode DomainOperation from its JSON
+// representation.
+//
+// This implementation supports both, numeric and string inputs. If a
+// string is provided, it must be a known enum name.
+//
+// This implements json.Unmarshaler.
+func (v *DomainOperation) UnmarshalJSON(text []byte) error {
+	d := json.NewDecoder(bytes.NewReader(text))
+	d.UseNumber()
+	t, err := d.Token()
+	if err != nil {
+		return err
+	}
+
+	switch w := t.(type) {
+	case json.Number:
+		x, err := w.Int64()
+		if err != nil {
+			return err
+		}
+		if x > math.MaxInt32 {
+			return fmt.Errorf("enum overflow from JSON %q for %q", text, "DomainOperation")
+		}
+		if x < math.MinInt32 {
+			return fmt.Errorf("enum underflow from JSON %q for %q", text, "DomainOperation")
+		}
+		*v = (DomainOperation)(x)
+		return nil
+	case string:
+		return v.UnmarshalText([]byte(w))
+	default:
+		return fmt.Errorf("invalid JSON value %q (%T) to unmarshal into %q", t, t, "DomainOperation")
+	}
+}
+
+type DomainTaskAttributes struct {
+	DomainOperation   *DomainOperation                       `json:"domainOperation,omitempty"`
+	ID                *string                                `json:"id,omitempty"`
+	Info              *shared.DomainInfo                     `json:"info,omitempty"`
+	Config            *shared.DomainConfiguration            `json:"config,omitempty"`
+	ReplicationConfig *shared.DomainReplicationConfiguration `json:"replicationConfig,omitempty"`
+	ConfigVersion     *int64                                 `json:"configVersion,omitempty"`
+	FailoverVersion   *int64                                 `json:"failoverVersion,omitempty"`
+	FailoverHistory   []*shared.DomainFailoverEvent          `json:"failoverHistory,omitempty"`
+}
+
+type _List_DomainFailoverEvent_ValueList []*shared.DomainFailoverEvent
+
+func (v _List_DomainFailoverEvent_ValueList) ForEach(f func(wire.Value) error) error {
+	for i, x := range v {
+		if x == nil {
+			return fmt.Errorf("invalid [%v]: value is nil", i)
+		}
+		w, err := x.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v _List_DomainFailoverEvent_ValueList) Size() int {
+	return len(v)
+}
+
+func (_List_DomainFailoverEvent_ValueList) ValueType() wire.Type {
+	return wire.TStruct
+}
+
+func (_List_DomainFailoverEvent_ValueList) Close() {}
+
+// ToWire translates a DomainTaskAttributes struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *DomainTaskAttributes) ToWire() (wire.Value, error) {
+	var (
+		fields [8]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.DomainOperation != nil {
+		w, err = v.DomainOperation.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 5, Value: w}
+		i++
+	}
+	if v.ID != nil {
+		w, err = wire.NewValueString(*(v.ID)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.Info != nil {
+		w, err = v.Info.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+	if v.Config != nil {
+		w, err = v.Config.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+	if v.ReplicationConfig != nil {
+		w, err = v.ReplicationConfig.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 40, Value: w}
+		i++
+	}
+	if v.ConfigVersion != nil {
+		w, err = wire.NewValueI64(*(v.ConfigVersion)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 50, Value: w}
+		i++
+	}
+	if v.FailoverVersion != nil {
+		w, err = wire.NewValueI64(*(v.FailoverVersion)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 60, Value: w}
+		i++
+	}
+	if v.FailoverHistory != nil {
+		w, err = wire.NewValueList(_List_DomainFailoverEvent_ValueList(v.FailoverHistory)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 70, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _DomainOperation_Read(w wire.Value) (DomainOperation, error) {
+	var v DomainOperation
+	err := v.FromWire(w)
+	return v, err
+}
+
+func _DomainInfo_Read(w wire.Value) (*shared.DomainInfo, error) {
+	var v shared.DomainInfo
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _DomainConfiguration_Read(w wire.Value) (*shared.DomainConfiguration, error) {
+	var v shared.DomainConfiguration
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _DomainReplicationConfiguration_Read(w wire.Value) (*shared.DomainReplicationConfiguration, error) {
+	var v shared.DomainReplicationConfiguration
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _DomainFailoverEvent_Read(w wire.Value) (*shared.DomainFailoverEvent, error) {
+	var v shared.DomainFailoverEvent
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _List_DomainFailoverEvent_Read(l wire.ValueList) ([]*shared.DomainFailoverEvent, error) {
+	if l.ValueType() != wire.TStruct {
+		return nil, nil
+	}
+
+	o := make([]*shared.DomainFailoverEvent, 0, l.Size())
+	err := l.ForEach(func(x wire.Value) error {
+		i, err := _DomainFailoverEvent_Read(x)
+		if err != nil {
+			return err
+		}
+		o = append(o, i)
+		return nil
+	})
+	l.Close()
+	return o, err
+}
+
+// FromWire deserializes a DomainTaskAttributes struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a DomainTaskAttributes struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v DomainTaskAttributes
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *DomainTaskAttributes) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 5:
+			if field.Value.Type() == wire.TI32 {
+				var x DomainOperation
+				x, err = _DomainOperation_Read(field.Value)
+				v.DomainOperation = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.ID = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TStruct {
+				v.Info, err = _DomainInfo_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 30:
+			if field.Value.Type() == wire.TStruct {
+				v.Config, err = _DomainConfiguration_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 40:
+			if field.Value.Type() == wire.TStruct {
+				v.ReplicationConfig, err = _DomainReplicationConfiguration_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 50:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.ConfigVersion = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 60:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.FailoverVersion = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 70:
+			if field.Value.Type() == wire.TList {
+				v.FailoverHistory, err = _List_DomainFailoverEvent_Read(field.Value.GetList())
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a DomainTaskAttributes
+// struct.
+func (v *DomainTaskAttributes) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [8]string
+	i := 0
+	if v.DomainOperation != nil {
+		fields[i] = fmt.Sprintf("DomainOperation: %v", *(v.DomainOperation))
+		i++
+	}
+	if v.ID != nil {
+		fields[i] = fmt.Sprintf("ID: %v", *(v.ID))
+		i++
+	}
+	if v.Info != nil {
+		fields[i] = fmt.Sprintf("Info: %v", v.Info)
+		i++
+	}
+	if v.Config != nil {
+		fields[i] = fmt.Sprintf("Config: %v", v.Config)
+		i++
+	}
+	if v.ReplicationConfig != nil {
+		fields[i] = fmt.Sprintf("ReplicationConfig: %v", v.ReplicationConfig)
+		i++
+	}
+	if v.ConfigVersion != nil {
+		fields[i] = fmt.Sprintf("ConfigVersion: %v", *(v.ConfigVersion))
+		i++
+	}
+	if v.FailoverVersion != nil {
+		fields[i] = fmt.Sprintf("FailoverVersion: %v", *(v.FailoverVersion))
+		i++
+	}
+	if v.FailoverHistory != nil {
+		fields[i] = fmt.Sprintf("FailoverHistory: %v", v.FailoverHistory)
+		i++
+	}
+
+	return fmt.Sprintf("DomainTaskAttributes{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _DomainOperation_EqualsPtr(lhs, rhs *DomainOperation) bool {
+	if lhs != nil && rhs != nil {
+
+		x := *lhs
+		y := *rhs
+		return x.Equals(y)
+	}
+	return lhs == nil && rhs == nil
+}
+
+func _List_DomainFailoverEvent_Equals(lhs, rhs []*shared.DomainFailoverEvent) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !lv.Equals(rv) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Equals returns true if all the fields of this DomainTaskAttributes match the
+// provided DomainTaskAttributes.
+//
+// This function performs a deep comparison.
+func (v *DomainTaskAttributes) Equals(rhs *DomainTaskAttributes) bool {
+	if !_DomainOperation_EqualsPtr(v.DomainOperation, rhs.DomainOperation) {
+		return false
+	}
+	if !_String_EqualsPtr(v.ID, rhs.ID) {
+		return false
+	}
+	if !((v.Info == nil && rhs.Info == nil) || (v.Info != nil && rhs.Info != nil && v.Info.Equals(rhs.Info))) {
+		return false
+	}
+	if !((v.Config == nil && rhs.Config == nil) || (v.Config != nil && rhs.Config != nil && v.Config.Equals(rhs.Config))) {
+		return false
+	}
+	if !((v.ReplicationConfig == nil && rhs.ReplicationConfig == nil) || (v.ReplicationConfig != nil && rhs.ReplicationConfig != nil && v.ReplicationConfig.Equals(rhs.ReplicationConfig))) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.ConfigVersion, rhs.ConfigVersion) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.FailoverVersion, rhs.FailoverVersion) {
+		return false
+	}
+	if !((v.FailoverHistory == nil && rhs.FailoverHistory == nil) || (v.FailoverHistory != nil && rhs.FailoverHistory != nil && _List_DomainFailoverEvent_Equals(v.FailoverHistory, rhs.FailoverHistory))) {
+		return false
+	}
+
+	return true
+}
+
+// GetDomainOperation returns the value of DomainOperation if it is set or its
+// zero value if it is unset.
+func (v *DomainTaskAttributes) GetDomainOperation() (o DomainOperation) {
+	if v.DomainOperation != nil {
+		return *v.DomainOperation
+	}
+
+	return
+}
+
+// GetID returns the value of ID if it is set or its
+// zero value if it is unset.
+func (v *DomainTaskAttributes) GetID() (o string) {
+	if v.ID != nil {
+		return *v.ID
+	}
+
+	return
+}
+
+// GetInfo returns the value of Info if it is set or its
+// zero value if it is unset.
+func (v *DomainTaskAttributes) GetInfo() (o *shared.DomainInfo) {
+	if v.Info != nil {
+		return v.Info
+	}
+
+	return
+}
+
+// GetConfig returns the value of Config if it is set or its
+// zero value if it is unset.
+func (v *DomainTaskAttributes) GetConfig() (o *shared.DomainConfiguration) {
+	if v.Config != nil {
+		return v.Config
+	}
+
+	return
+}
+
+// GetReplicationConfig returns the value of ReplicationConfig if it is set or its
+// zero value if it is unset.
+func (v *DomainTaskAttributes) GetReplicationConfig() (o *shared.DomainReplicationConfiguration) {
+	if v.ReplicationConfig != nil {
+		return v.ReplicationConfig
+	}
+
+	return
+}
+
+// GetConfigVersion returns the value of ConfigVersion if it is set or its
+// zero value if it is unset.
+func (v *DomainTaskAttributes) GetConfigVersion() (o int64) {
+	if v.ConfigVersion != nil {
+		return *v.ConfigVersion
+	}
+
+	return
+}
+
+// GetFailoverVersion returns the value of FailoverVersion if it is set or its
+// zero value if it is unset.
+func (v *DomainTaskAttributes) GetFailoverVersion() (o int64) {
+	if v.FailoverVersion != nil {
+		return *v.FailoverVersion
+	}
+
+	return
+}
+
+// GetFailoverHistory returns the value of FailoverHistory if it is set or its
+// zero value if it is unset.
+func (v *DomainTaskAttributes) GetFailoverHistory() (o []*shared.DomainFailoverEvent) {
+	if v.FailoverHistory != nil {
+		return v.FailoverHistory
+	}
+
+	return
+}
+
+type GetReplicationMessagesRequest struct {
+	Tokens      []*ReplicationToken `json:"tokens,omitempty"`
+	ClusterName *string             `json:"clusterName,omitempty"`
+}
+
+type _List_ReplicationToken_ValueList []*ReplicationToken
+
+func (v _List_ReplicationToken_ValueList) ForEach(f func(wire.Value) error) error {
+	for i, x := range v {
+		if x == nil {
+			return fmt.Errorf("invalid [%v]: value is nil", i)
+		}
+		w, err := x.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v _List_ReplicationToken_ValueList) Size() int {
+	return len(v)
+}
+
+func (_List_ReplicationToken_ValueList) ValueType() wire.Type {
+	return wire.TStruct
+}
+
+func (_List_ReplicationToken_ValueList) Close() {}
+
+// ToWire translates a GetReplicationMessagesRequest struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *GetReplicationMessagesRequest) ToWire() (wire.Value, error) {
+	var (
+		fields [2]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Tokens != nil {
+		w, err = wire.NewValueList(_List_ReplicationToken_ValueList(v.Tokens)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.ClusterName != nil {
+		w, err = wire.NewValueString(*(v.ClusterName)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _ReplicationToken_Read(w wire.Value) (*ReplicationToken, error) {
+	var v ReplicationToken
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _List_ReplicationToken_Read(l wire.ValueList) ([]*ReplicationToken, error) {
+	if l.ValueType() != wire.TStruct {
+		return nil, nil
+	}
+
+	o := make([]*ReplicationToken, 0, l.Size())
+	err := l.ForEach(func(x wire.Value) error {
+		i, err := _ReplicationToken_Read(x)
+		if err != nil {
+			return err
+		}
+		o = append(o, i)
+		return nil
+	})
+	l.Close()
+	return o, err
+}
+
+// FromWire deserializes a GetReplicationMessagesRequest struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a GetReplicationMessagesRequest struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v GetReplicationMessagesRequest
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *GetReplicationMessagesRequest) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TList {
+				v.Tokens, err = _List_ReplicationToken_Read(field.Value.GetList())
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.ClusterName = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a GetReplicationMessagesRequest
+// struct.
+func (v *GetReplicationMessagesRequest) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [2]string
+	i := 0
+	if v.Tokens != nil {
+		fields[i] = fmt.Sprintf("Tokens: %v", v.Tokens)
+		i++
+	}
+	if v.ClusterName != nil {
+		fields[i] = fmt.Sprintf("ClusterName: %v", *(v.ClusterName))
+		i++
+	}
+
+	return fmt.Sprintf("GetReplicationMessagesRequest{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _List_ReplicationToken_Equals(lhs, rhs []*ReplicationToken) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !lv.Equals(rv) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Equals returns true if all the fields of this GetReplicationMessagesRequest match the
+// provided GetReplicationMessagesRequest.
+//
+// This function performs a deep comparison.
+func (v *GetReplicationMessagesRequest) Equals(rhs *GetReplicationMessagesRequest) bool {
+	if !((v.Tokens == nil && rhs.Tokens == nil) || (v.Tokens != nil && rhs.Tokens != nil && _List_ReplicationToken_Equals(v.Tokens, rhs.Tokens))) {
+		return false
+	}
+	if !_String_EqualsPtr(v.ClusterName, rhs.ClusterName) {
+		return false
+	}
+
+	return true
+}
+
+// GetTokens returns the value of Tokens if it is set or its
+// zero value if it is unset.
+func (v *GetReplicationMessagesRequest) GetTokens() (o []*ReplicationToken) {
+	if v.Tokens != nil {
+		return v.Tokens
+	}
+
+	return
+}
+
+// GetClusterName returns the value of ClusterName if it is set or its
+// zero value if it is unset.
+func (v *GetReplicationMessagesRequest) GetClusterName() (o string) {
+	if v.ClusterName != nil {
+		return *v.ClusterName
+	}
+
+	return
+}
+
+type GetReplicationMessagesResponse struct {
+	MessagesByShard map[int32]*ReplicationMessages `json:"messagesByShard,omitempty"`
+}
+
+type _Map_I32_ReplicationMessages_MapItemList map[int32]*ReplicationMessages
+
+func (m _Map_I32_ReplicationMessages_MapItemList) ForEach(f func(wire.MapItem) error) error {
+	for k, v := range m {
+		if v == nil {
+			return fmt.Errorf("invalid [%v]: value is nil", k)
+		}
+		kw, err := wire.NewValueI32(k), error(nil)
+		if err != nil {
+			return err
+		}
+
+		vw, err := v.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(wire.MapItem{Key: kw, Value: vw})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m _Map_I32_ReplicationMessages_MapItemList) Size() int {
+	return len(m)
+}
+
+func (_Map_I32_ReplicationMessages_MapItemList) KeyType() wire.Type {
+	return wire.TI32
+}
+
+func (_Map_I32_ReplicationMessages_MapItemList) ValueType() wire.Type {
+	return wire.TStruct
+}
+
+func (_Map_I32_ReplicationMessages_MapItemList) Close() {}
+
+// ToWire translates a GetReplicationMessagesResponse struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *GetReplicationMessagesResponse) ToWire() (wire.Value, error) {
+	var (
+		fields [1]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.MessagesByShard != nil {
+		w, err = wire.NewValueMap(_Map_I32_ReplicationMessages_MapItemList(v.MessagesByShard)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _ReplicationMessages_Read(w wire.Value) (*ReplicationMessages, error) {
+	var v ReplicationMessages
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _Map_I32_ReplicationMessages_Read(m wire.MapItemList) (map[int32]*ReplicationMessages, error) {
+	if m.KeyType() != wire.TI32 {
+		return nil, nil
+	}
+
+	if m.ValueType() != wire.TStruct {
+		return nil, nil
+	}
+
+	o := make(map[int32]*ReplicationMessages, m.Size())
+	err := m.ForEach(func(x wire.MapItem) error {
+		k, err := x.Key.GetI32(), error(nil)
+		if err != nil {
+			return err
+		}
+
+		v, err := _ReplicationMessages_Read(x.Value)
+		if err != nil {
+			return err
+		}
+
+		o[k] = v
+		return nil
+	})
+	m.Close()
+	return o, err
+}
+
+// FromWire deserializes a GetReplicationMessagesResponse struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a GetReplicationMessagesResponse struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v GetReplicationMessagesResponse
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *GetReplicationMessagesResponse) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TMap {
+				v.MessagesByShard, err = _Map_I32_ReplicationMessages_Read(field.Value.GetMap())
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a GetReplicationMessagesResponse
+// struct.
+func (v *GetReplicationMessagesResponse) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [1]string
+	i := 0
+	if v.MessagesByShard != nil {
+		fields[i] = fmt.Sprintf("MessagesByShard: %v", v.MessagesByShard)
+		i++
+	}
+
+	return fmt.Sprintf("GetReplicationMessagesResponse{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _Map_I32_ReplicationMessages_Equals(lhs, rhs map[int32]*ReplicationMessages) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+
+	for lk, lv := range lhs {
+		rv, ok := rhs[lk]
+		if !ok {
+			return false
+		}
+		if !lv.Equals(rv) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equals returns true if all the fields of this GetReplicationMessagesResponse match the
+// provided GetReplicationMessagesResponse.
+//
+// This function performs a deep comparison.
+func (v *GetReplicationMessagesResponse) Equals(rhs *GetReplicationMessagesResponse) bool {
+	if !((v.MessagesByShard == nil && rhs.MessagesByShard == nil) || (v.MessagesByShard != nil && rhs.MessagesByShard != nil && _Map_I32_ReplicationMessages_Equals(v.MessagesByShard, rhs.MessagesByShard))) {
+		return false
+	}
+
+	return true
+}
+
+// GetMessagesByShard returns the value of MessagesByShard if it is set or its
+// zero value if it is unset.
+func (v *GetReplicationMessagesResponse) GetMessagesByShard() (o map[int32]*ReplicationMessages) {
+	if v.MessagesByShard != nil {
+		return v.MessagesByShard
+	}
+
+	return
+}
+
+type HistoryTaskAttributes struct {
+	TargetClusters  []string                           `json:"targetClusters,omitempty"`
+	DomainId        *string                            `json:"domainId,omitempty"`
+	WorkflowId      *string                            `json:"workflowId,omitempty"`
+	RunId           *string                            `json:"runId,omitempty"`
+	FirstEventId    *int64                             `json:"firstEventId,omitempty"`
+	NextEventId     *int64                             `json:"nextEventId,omitempty"`
+	Version         *int64                             `json:"version,omitempty"`
+	ReplicationInfo map[string]*shared.ReplicationInfo `json:"replicationInfo,omitempty"`
+	History         *shared.History                    `json:"history,omitempty"`
+	NewRunHistory   *shared.History                    `json:"newRunHistory,omitempty"`
+}
+
+type _List_String_ValueList []string
+
+func (v _List_String_ValueList) ForEach(f func(wire.Value) error) error {
+	for _, x := range v {
+		w, err := wire.NewValueString(x), error(nil)
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v _List_String_ValueList) Size() int {
+	return len(v)
+}
+
+func (_List_String_ValueList) ValueType() wire.Type {
+	return wire.TBinary
+}
+
+func (_List_String_ValueList) Close() {}
+
+type _Map_String_ReplicationInfo_MapItemList map[string]*shared.ReplicationInfo
+
+func (m _Map_String_ReplicationInfo_MapItemList) ForEach(f func(wire.MapItem) error) error {
+	for k, v := range m {
+		if v == nil {
+			return fmt.Errorf("invalid [%v]: value is nil", k)
+		}
+		kw, err := wire.NewValueString(k), error(nil)
+		if err != nil {
+			return err
+		}
+
+		vw, err := v.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(wire.MapItem{Key: kw, Value: vw})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m _Map_String_ReplicationInfo_MapItemList) Size() int {
+	return len(m)
+}
+
+func (_Map_String_ReplicationInfo_MapItemList) KeyType() wire.Type {
+	return wire.TBinary
+}
+
+func (_Map_String_ReplicationInfo_MapItemList) ValueType() wire.Type {
+	return wire.TStruct
+}
+
+func (_Map_String_ReplicationInfo_MapItemList) Close() {}
+
+// ToWire translates a HistoryTaskAttributes struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *HistoryTaskAttributes) ToWire() (wire.Value, error) {
+	var (
+		fields [10]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.TargetClusters != nil {
+		w, err = wire.NewValueList(_List_String_ValueList(v.TargetClusters)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 5, Value: w}
+		i++
+	}
+	if v.DomainId != nil {
+		w, err = wire.NewValueString(*(v.DomainId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.WorkflowId != nil {
+		w, err = wire.NewValueString(*(v.WorkflowId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+	if v.RunId != nil {
+		w, err = wire.NewValueString(*(v.RunId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+	if v.FirstEventId != nil {
+		w, err = wire.NewValueI64(*(v.FirstEventId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 40, Value: w}
+		i++
+	}
+	if v.NextEventId != nil {
+		w, err = wire.NewValueI64(*(v.NextEventId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 50, Value: w}
+		i++
+	}
+	if v.Version != nil {
+		w, err = wire.NewValueI64(*(v.Version)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 60, Value: w}
+		i++
+	}
+	if v.ReplicationInfo != nil {
+		w, err = wire.NewValueMap(_Map_String_ReplicationInfo_MapItemList(v.ReplicationInfo)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 70, Value: w}
+		i++
+	}
+	if v.History != nil {
+		w, err = v.History.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 80, Value: w}
+		i++
+	}
+	if v.NewRunHistory != nil {
+		w, err = v.NewRunHistory.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 90, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _List_String_Read(l wire.ValueList) ([]string, error) {
+	if l.ValueType() != wire.TBinary {
+		return nil, nil
+	}
+
+	o := make([]string, 0, l.Size())
+	err := l.ForEach(func(x wire.Value) error {
+		i, err := x.GetString(), error(nil)
+		if err != nil {
+			return err
+		}
+		o = append(o, i)
+		return nil
+	})
+	l.Close()
+	return o, err
+}
+
+func _ReplicationInfo_Read(w wire.Value) (*shared.ReplicationInfo, error) {
+	var v shared.ReplicationInfo
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _Map_String_ReplicationInfo_Read(m wire.MapItemList) (map[string]*shared.ReplicationInfo, error) {
+	if m.KeyType() != wire.TBinary {
+		return nil, nil
+	}
+
+	if m.ValueType() != wire.TStruct {
+		return nil, nil
+	}
+
+	o := make(map[string]*shared.ReplicationInfo, m.Size())
+	err := m.ForEach(func(x wire.MapItem) error {
+		k, err := x.Key.GetString(), error(nil)
+		if err != nil {
+			return err
+		}
+
+		v, err := _ReplicationInfo_Read(x.Value)
+		if err != nil {
+			return err
+		}
+
+		o[k] = v
+		return nil
+	})
+	m.Close()
+	return o, err
+}
+
+func _History_Read(w wire.Value) (*shared.History, error) {
+	var v shared.History
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a HistoryTaskAttributes struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a HistoryTaskAttributes struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v HistoryTaskAttributes
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *HistoryTaskAttributes) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 5:
+			if field.Value.Type() == wire.TList {
+				v.TargetClusters, err = _List_String_Read(field.Value.GetList())
+				if err != nil {
+					return err
+				}
+
+			}
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.DomainId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.WorkflowId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 30:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.RunId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 40:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.FirstEventId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 50:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.NextEventId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 60:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.Version = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 70:
+			if field.Value.Type() == wire.TMap {
+				v.ReplicationInfo, err = _Map_String_ReplicationInfo_Read(field.Value.GetMap())
+				if err != nil {
+					return err
+				}
+
+			}
+		case 80:
+			if field.Value.Type() == wire.TStruct {
+				v.History, err = _History_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 90:
+			if field.Value.Type() == wire.TStruct {
+				v.NewRunHistory, err = _History_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a HistoryTaskAttributes
+// struct.
+func (v *HistoryTaskAttributes) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [10]string
+	i := 0
+	if v.TargetClusters != nil {
+		fields[i] = fmt.Sprintf("TargetClusters: %v", v.TargetClusters)
+		i++
+	}
+	if v.DomainId != nil {
+		fields[i] = fmt.Sprintf("DomainId: %v", *(v.DomainId))
+		i++
+	}
+	if v.WorkflowId != nil {
+		fields[i] = fmt.Sprintf("WorkflowId: %v", *(v.WorkflowId))
+		i++
+	}
+	if v.RunId != nil {
+		fields[i] = fmt.Sprintf("RunId: %v", *(v.RunId))
+		i++
+	}
+	if v.FirstEventId != nil {
+		fields[i] = fmt.Sprintf("FirstEventId: %v", *(v.FirstEventId))
+		i++
+	}
+	if v.NextEventId != nil {
+		fields[i] = fmt.Sprintf("NextEventId: %v", *(v.NextEventId))
+		i++
+	}
+	if v.Version != nil {
+		fields[i] = fmt.Sprintf("Version: %v", *(v.Version))
+		i++
+	}
+	if v.ReplicationInfo != nil {
+		fields[i] = fmt.Sprintf("ReplicationInfo: %v", v.ReplicationInfo)
+		i++
+	}
+	if v.History != nil {
+		fields[i] = fmt.Sprintf("History: %v", v.History)
+		i++
+	}
+	if v.NewRunHistory != nil {
+		fields[i] = fmt.Sprintf("NewRunHistory: %v", v.NewRunHistory)
+		i++
+	}
+
+	return fmt.Sprintf("HistoryTaskAttributes{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _List_String_Equals(lhs, rhs []string) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !(lv == rv) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func _Map_String_ReplicationInfo_Equals(lhs, rhs map[string]*shared.ReplicationInfo) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+
+	for lk, lv := range lhs {
+		rv, ok := rhs[lk]
+		if !ok {
+			return false
+		}
+		if !lv.Equals(rv) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equals returns true if all the fields of this HistoryTaskAttributes match the
+// provided HistoryTaskAttributes.
+//
+// This function performs a deep comparison.
+func (v *HistoryTaskAttributes) Equals(rhs *HistoryTaskAttributes) bool {
+	if !((v.TargetClusters == nil && rhs.TargetClusters == nil) || (v.TargetClusters != nil && rhs.TargetClusters != nil && _List_String_Equals(v.TargetClusters, rhs.TargetClusters))) {
+		return false
+	}
+	if !_String_EqualsPtr(v.DomainId, rhs.DomainId) {
+		return false
+	}
+	if !_String_EqualsPtr(v.WorkflowId, rhs.WorkflowId) {
+		return false
+	}
+	if !_String_EqualsPtr(v.RunId, rhs.RunId) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.FirstEventId, rhs.FirstEventId) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.NextEventId, rhs.NextEventId) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.Version, rhs.Version) {
+		return false
+	}
+	if !((v.ReplicationInfo == nil && rhs.ReplicationInfo == nil) || (v.ReplicationInfo != nil && rhs.ReplicationInfo != nil && _Map_String_ReplicationInfo_Equals(v.ReplicationInfo, rhs.ReplicationInfo))) {
+		return false
+	}
+	if !((v.History == nil && rhs.History == nil) || (v.History != nil && rhs.History != nil && v.History.Equals(rhs.History))) {
+		return false
+	}
+	if !((v.NewRunHistory == nil && rhs.NewRunHistory == nil) || (v.NewRunHistory != nil && rhs.NewRunHistory != nil && v.NewRunHistory.Equals(rhs.NewRunHistory))) {
+		return false
+	}
+
+	return true
+}
+
+// GetTargetClusters returns the value of TargetClusters if it is set or its
+// zero value if it is unset.
+func (v *HistoryTaskAttributes) GetTargetClusters() (o []string) {
+	if v.TargetClusters != nil {
+		return v.TargetClusters
+	}
+
+	return
+}
+
+// GetDomainId returns the value of DomainId if it is set or its
+// zero value if it is unset.
+func (v *HistoryTaskAttributes) GetDomainId() (o string) {
+	if v.DomainId != nil {
+		return *v.DomainId
+	}
+
+	return
+}
+
+// GetWorkflowId returns the value of WorkflowId if it is set or its
+// zero value if it is unset.
+func (v *HistoryTaskAttributes) GetWorkflowId() (o string) {
+	if v.WorkflowId != nil {
+		return *v.WorkflowId
+	}
+
+	return
+}
+
+// GetRunId returns the value of RunId if it is set or its
+// zero value if it is unset.
+func (v *HistoryTaskAttributes) GetRunId() (o string) {
+	if v.RunId != nil {
+		return *v.RunId
+	}
+
+	return
+}
+
+// GetFirstEventId returns the value of FirstEventId if it is set or its
+// zero value if it is unset.
+func (v *HistoryTaskAttributes) GetFirstEventId() (o int64) {
+	if v.FirstEventId != nil {
+		return *v.FirstEventId
+	}
+
+	return
+}
+
+// GetNextEventId returns the value of NextEventId if it is set or its
+// zero value if it is unset.
+func (v *HistoryTaskAttributes) GetNextEventId() (o int64) {
+	if v.NextEventId != nil {
+		return *v.NextEventId
+	}
+
+	return
+}
+
+// GetVersion returns the value of Version if it is set or its
+// zero value if it is unset.
+func (v *HistoryTaskAttributes) GetVersion() (o int64) {
+	if v.Version != nil {
+		return *v.Version
+	}
+
+	return
+}
+
+// GetReplicationInfo returns the value of ReplicationInfo if it is set or its
+// zero value if it is unset.
+func (v *HistoryTaskAttributes) GetReplicationInfo() (o map[string]*shared.ReplicationInfo) {
+	if v.ReplicationInfo != nil {
+		return v.ReplicationInfo
+	}
+
+	return
+}
+
+// GetHistory returns the value of History if it is set or its
+// zero value if it is unset.
+func (v *HistoryTaskAttributes) GetHistory() (o *shared.History) {
+	if v.History != nil {
+		return v.History
+	}
+
+	return
+}
+
+// GetNewRunHistory returns the value of NewRunHistory if it is set or its
+// zero value if it is unset.
+func (v *HistoryTaskAttributes) GetNewRunHistory() (o *shared.History) {
+	if v.NewRunHistory != nil {
+		return v.NewRunHistory
+	}
+
+	return
+}
+
+type RemoteClusterReplicationStatus struct {
+	AckLevel                      *int64 `json:"ackLevel,omitempty"`
+	OldestUnackedTaskAgeInSeconds *int64 `json:"oldestUnackedTaskAgeInSeconds,omitempty"`
+	HasUnackedTasks               *bool  `json:"hasUnackedTasks,omitempty"`
+}
+
+// ToWire translates a RemoteClusterReplicationStatus struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *RemoteClusterReplicationStatus) ToWire() (wire.Value, error) {
+	var (
+		fields [3]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.AckLevel != nil {
+		w, err = wire.NewValueI64(*(v.AckLevel)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.OldestUnackedTaskAgeInSeconds != nil {
+		w, err = wire.NewValueI64(*(v.OldestUnackedTaskAgeInSeconds)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+	if v.HasUnackedTasks != nil {
+		w, err = wire.NewValueBool(*(v.HasUnackedTasks)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a RemoteClusterReplicationStatus struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a RemoteClusterReplicationStatus struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v RemoteClusterReplicationStatus
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *RemoteClusterReplicationStatus) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.AckLevel = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.OldestUnackedTaskAgeInSeconds = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 30:
+			if field.Value.Type() == wire.TBool {
+				var x bool
+				x, err = field.Value.GetBool(), error(nil)
+				v.HasUnackedTasks = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a RemoteClusterReplicationStatus
+// struct.
+func (v *RemoteClusterReplicationStatus) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [3]string
+	i := 0
+	if v.AckLevel != nil {
+		fields[i] = fmt.Sprintf("AckLevel: %v", *(v.AckLevel))
+		i++
+	}
+	if v.OldestUnackedTaskAgeInSeconds != nil {
+		fields[i] = fmt.Sprintf("OldestUnackedTaskAgeInSeconds: %v", *(v.OldestUnackedTaskAgeInSeconds))
+		i++
+	}
+	if v.HasUnackedTasks != nil {
+		fields[i] = fmt.Sprintf("HasUnackedTasks: %v", *(v.HasUnackedTasks))
+		i++
+	}
+
+	return fmt.Sprintf("RemoteClusterReplicationStatus{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _Bool_EqualsPtr(lhs, rhs *bool) bool {
+	if lhs != nil && rhs != nil {
+
+		x := *lhs
+		y := *rhs
+		return (x == y)
+	}
+	return lhs == nil && rhs == nil
+}
+
+// Equals returns true if all the fields of this RemoteClusterReplicationStatus match the
+// provided RemoteClusterReplicationStatus.
+//
+// This function performs a deep comparison.
+func (v *RemoteClusterReplicationStatus) Equals(rhs *RemoteClusterReplicationStatus) bool {
+	if !_I64_EqualsPtr(v.AckLevel, rhs.AckLevel) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.OldestUnackedTaskAgeInSeconds, rhs.OldestUnackedTaskAgeInSeconds) {
+		return false
+	}
+	if !_Bool_EqualsPtr(v.HasUnackedTasks, rhs.HasUnackedTasks) {
+		return false
+	}
+
+	return true
+}
+
+// GetAckLevel returns the value of AckLevel if it is set or its
+// zero value if it is unset.
+func (v *RemoteClusterReplicationStatus) GetAckLevel() (o int64) {
+	if v.AckLevel != nil {
+		return *v.AckLevel
+	}
+
+	return
+}
+
+// GetOldestUnackedTaskAgeInSeconds returns the value of OldestUnackedTaskAgeInSeconds if it is set or its
+// zero value if it is unset.
+func (v *RemoteClusterReplicationStatus) GetOldestUnackedTaskAgeInSeconds() (o int64) {
+	if v.OldestUnackedTaskAgeInSeconds != nil {
+		return *v.OldestUnackedTaskAgeInSeconds
+	}
+
+	return
+}
+
+// GetHasUnackedTasks returns the value of HasUnackedTasks if it is set or its
+// zero value if it is unset.
+func (v *RemoteClusterReplicationStatus) GetHasUnackedTasks() (o bool) {
+	if v.HasUnackedTasks != nil {
+		return *v.HasUnackedTasks
+	}
+
+	return
+}
+
+type ReplicationMessages struct {
+	ReplicationTasks       []*ReplicationTask `json:"replicationTasks,omitempty"`
+	LastRetrievedMessageId *int64             `json:"lastRetrievedMessageId,omitempty"`
+	HasMore                *bool              `json:"hasMore,omitempty"`
+}
+
+type _List_ReplicationTask_ValueList []*ReplicationTask
+
+func (v _List_ReplicationTask_ValueList) ForEach(f func(wire.Value) error) error {
+	for i, x := range v {
+		if x == nil {
+			return fmt.Errorf("invalid [%v]: value is nil", i)
+		}
+		w, err := x.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v _List_ReplicationTask_ValueList) Size() int {
+	return len(v)
+}
+
+func (_List_ReplicationTask_ValueList) ValueType() wire.Type {
+	return wire.TStruct
+}
+
+func (_List_ReplicationTask_ValueList) Close() {}
+
+// ToWire translates a ReplicationMessages struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *ReplicationMessages) ToWire() (wire.Value, error) {
+	var (
+		fields [3]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.ReplicationTasks != nil {
+		w, err = wire.NewValueList(_List_ReplicationTask_ValueList(v.ReplicationTasks)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.LastRetrievedMessageId != nil {
+		w, err = wire.NewValueI64(*(v.LastRetrievedMessageId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+	if v.HasMore != nil {
+		w, err = wire.NewValueBool(*(v.HasMore)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _List_ReplicationTask_Read(l wire.ValueList) ([]*ReplicationTask, error) {
+	if l.ValueType() != wire.TStruct {
+		return nil, nil
+	}
+
+	o := make([]*ReplicationTask, 0, l.Size())
+	err := l.ForEach(func(x wire.Value) error {
+		i, err := _ReplicationTask_Read(x)
+		if err != nil {
+			return err
+		}
+		o = append(o, i)
+		return nil
+	})
+	l.Close()
+	return o, err
+}
+
+// FromWire deserializes a ReplicationMessages struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a ReplicationMessages struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v ReplicationMessages
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *ReplicationMessages) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TList {
+				v.ReplicationTasks, err = _List_ReplicationTask_Read(field.Value.GetList())
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.LastRetrievedMessageId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 30:
+			if field.Value.Type() == wire.TBool {
+				var x bool
+				x, err = field.Value.GetBool(), error(nil)
+				v.HasMore = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a ReplicationMessages
+// struct.
+func (v *ReplicationMessages) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [3]string
+	i := 0
+	if v.ReplicationTasks != nil {
+		fields[i] = fmt.Sprintf("ReplicationTasks: %v", v.ReplicationTasks)
+		i++
+	}
+	if v.LastRetrievedMessageId != nil {
+		fields[i] = fmt.Sprintf("LastRetrievedMessageId: %v", *(v.LastRetrievedMessageId))
+		i++
+	}
+	if v.HasMore != nil {
+		fields[i] = fmt.Sprintf("HasMore: %v", *(v.HasMore))
+		i++
+	}
+
+	return fmt.Sprintf("ReplicationMessages{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _List_ReplicationTask_Equals(lhs, rhs []*ReplicationTask) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !lv.Equals(rv) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Equals returns true if all the fields of this ReplicationMessages match the
+// provided ReplicationMessages.
+//
+// This function performs a deep comparison.
+func (v *ReplicationMessages) Equals(rhs *ReplicationMessages) bool {
+	if !((v.ReplicationTasks == nil && rhs.ReplicationTasks == nil) || (v.ReplicationTasks != nil && rhs.ReplicationTasks != nil && _List_ReplicationTask_Equals(v.ReplicationTasks, rhs.ReplicationTasks))) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.LastRetrievedMessageId, rhs.LastRetrievedMessageId) {
+		return false
+	}
+	if !_Bool_EqualsPtr(v.HasMore, rhs.HasMore) {
+		return false
+	}
+
+	return true
+}
+
+// GetReplicationTasks returns the value of ReplicationTasks if it is set or its
+// zero value if it is unset.
+func (v *ReplicationMessages) GetReplicationTasks() (o []*ReplicationTask) {
+	if v.ReplicationTasks != nil {
+		return v.ReplicationTasks
+	}
+
+	return
+}
+
+// GetLastRetrievedMessageId returns the value of LastRetrievedMessageId if it is set or its
+// zero value if it is unset.
+func (v *ReplicationMessages) GetLastRetrievedMessageId() (o int64) {
+	if v.LastRetrievedMessageId != nil {
+		return *v.LastRetrievedMessageId
+	}
+
+	return
+}
+
+// GetHasMore returns the value of HasMore if it is set or its
+// zero value if it is unset.
+func (v *ReplicationMessages) GetHasMore() (o bool) {
+	if v.HasMore != nil {
+		return *v.HasMore
+	}
+
+	return
+}
+
+type ReplicationTask struct {
+	TaskType                      *ReplicationTaskType           `json:"taskType,omitempty"`
+	DomainTaskAttributes          *DomainTaskAttributes          `json:"domainTaskAttributes,omitempty"`
+	HistoryTaskAttributes         *HistoryTaskAttributes         `json:"historyTaskAttributes,omitempty"`
+	SyncShardStatusTaskAttributes *SyncShardStatusTaskAttributes `json:"syncShardStatusTaskAttributes,omitempty"`
+	SourceTaskId                  *int64                         `json:"sourceTaskId,omitempty"`
+}
+
+// ToWire translates a ReplicationTask struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *ReplicationTask) ToWire() (wire.Value, error) {
+	var (
+		fields [5]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.TaskType != nil {
+		w, err = v.TaskType.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.DomainTaskAttributes != nil {
+		w, err = v.DomainTaskAttributes.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+	if v.HistoryTaskAttributes != nil {
+		w, err = v.HistoryTaskAttributes.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+	if v.SyncShardStatusTaskAttributes != nil {
+		w, err = v.SyncShardStatusTaskAttributes.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 40, Value: w}
+		i++
+	}
+	if v.SourceTaskId != nil {
+		w, err = wire.NewValueI64(*(v.SourceTaskId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 50, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _ReplicationTaskType_Read(w wire.Value) (ReplicationTaskType, error) {
+	var v ReplicationTaskType
+	err := v.FromWire(w)
+	return v, err
+}
+
+func _DomainTaskAttributes_Read(w wire.Value) (*DomainTaskAttributes, error) {
+	var v DomainTaskAttributes
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _HistoryTaskAttributes_Read(w wire.Value) (*HistoryTaskAttributes, error) {
+	var v HistoryTaskAttributes
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _SyncShardStatusTaskAttributes_Read(w wire.Value) (*SyncShardStatusTaskAttributes, error) {
+	var v SyncShardStatusTaskAttributes
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a ReplicationTask struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a ReplicationTask struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v ReplicationTask
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *ReplicationTask) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TI32 {
+				var x ReplicationTaskType
+				x, err = _ReplicationTaskType_Read(field.Value)
+				v.TaskType = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TStruct {
+				v.DomainTaskAttributes, err = _DomainTaskAttributes_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 30:
+			if field.Value.Type() == wire.TStruct {
+				v.HistoryTaskAttributes, err = _HistoryTaskAttributes_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 40:
+			if field.Value.Type() == wire.TStruct {
+				v.SyncShardStatusTaskAttributes, err = _SyncShardStatusTaskAttributes_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 50:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.SourceTaskId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a ReplicationTask
+// struct.
+func (v *ReplicationTask) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [5]string
+	i := 0
+	if v.TaskType != nil {
+		fields[i] = fmt.Sprintf("TaskType: %v", *(v.TaskType))
+		i++
+	}
+	if v.DomainTaskAttributes != nil {
+		fields[i] = fmt.Sprintf("DomainTaskAttributes: %v", v.DomainTaskAttributes)
+		i++
+	}
+	if v.HistoryTaskAttributes != nil {
+		fields[i] = fmt.Sprintf("HistoryTaskAttributes: %v", v.HistoryTaskAttributes)
+		i++
+	}
+	if v.SyncShardStatusTaskAttributes != nil {
+		fields[i] = fmt.Sprintf("SyncShardStatusTaskAttributes: %v", v.SyncShardStatusTaskAttributes)
+		i++
+	}
+	if v.SourceTaskId != nil {
+		fields[i] = fmt.Sprintf("SourceTaskId: %v", *(v.SourceTaskId))
+		i++
+	}
+
+	return fmt.Sprintf("ReplicationTask{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _ReplicationTaskType_EqualsPtr(lhs, rhs *ReplicationTaskType) bool {
+	if lhs != nil && rhs != nil {
+
+		x := *lhs
+		y := *rhs
+		return x.Equals(y)
+	}
+	return lhs == nil && rhs == nil
+}
+
+// Equals returns true if all the fields of this ReplicationTask match the
+// provided ReplicationTask.
+//
+// This function performs a deep comparison.
+func (v *ReplicationTask) Equals(rhs *ReplicationTask) bool {
+	if !_ReplicationTaskType_EqualsPtr(v.TaskType, rhs.TaskType) {
+		return false
+	}
+	if !((v.DomainTaskAttributes == nil && rhs.DomainTaskAttributes == nil) || (v.DomainTaskAttributes != nil && rhs.DomainTaskAttributes != nil && v.DomainTaskAttributes.Equals(rhs.DomainTaskAttributes))) {
+		return false
+	}
+	if !((v.HistoryTaskAttributes == nil && rhs.HistoryTaskAttributes == nil) || (v.HistoryTaskAttributes != nil && rhs.HistoryTaskAttributes != nil && v.HistoryTaskAttributes.Equals(rhs.HistoryTaskAttributes))) {
+		return false
+	}
+	if !((v.SyncShardStatusTaskAttributes == nil && rhs.SyncShardStatusTaskAttributes == nil) || (v.SyncShardStatusTaskAttributes != nil && rhs.SyncShardStatusTaskAttributes != nil && v.SyncShardStatusTaskAttributes.Equals(rhs.SyncShardStatusTaskAttributes))) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.SourceTaskId, rhs.SourceTaskId) {
+		return false
+	}
+
+	return true
+}
+
+// GetTaskType returns the value of TaskType if it is set or its
+// zero value if it is unset.
+func (v *ReplicationTask) GetTaskType() (o ReplicationTaskType) {
+	if v.TaskType != nil {
+		return *v.TaskType
+	}
+
+	return
+}
+
+// GetDomainTaskAttributes returns the value of DomainTaskAttributes if it is set or its
+// zero value if it is unset.
+func (v *ReplicationTask) GetDomainTaskAttributes() (o *DomainTaskAttributes) {
+	if v.DomainTaskAttributes != nil {
+		return v.DomainTaskAttributes
+	}
+
+	return
+}
+
+// GetHistoryTaskAttributes returns the value of HistoryTaskAttributes if it is set or its
+// zero value if it is unset.
+func (v *ReplicationTask) GetHistoryTaskAttributes() (o *HistoryTaskAttributes) {
+	if v.HistoryTaskAttributes != nil {
+		return v.HistoryTaskAttributes
+	}
+
+	return
+}
+
+// GetSyncShardStatusTaskAttributes returns the value of SyncShardStatusTaskAttributes if it is set or its
+// zero value if it is unset.
+func (v *ReplicationTask) GetSyncShardStatusTaskAttributes() (o *SyncShardStatusTaskAttributes) {
+	if v.SyncShardStatusTaskAttributes != nil {
+		return v.SyncShardStatusTaskAttributes
+	}
+
+	return
+}
+
+// GetSourceTaskId returns the value of SourceTaskId if it is set or its
+// zero value if it is unset.
+func (v *ReplicationTask) GetSourceTaskId() (o int64) {
+	if v.SourceTaskId != nil {
+		return *v.SourceTaskId
+	}
+
+	return
+}
+
+type ReplicationTaskType int32
+
+const (
+	ReplicationTaskTypeDomain          ReplicationTaskType = 0
+	ReplicationTaskTypeHistory         ReplicationTaskType = 1
+	ReplicationTaskTypeSyncShardStatus ReplicationTaskType = 2
+)
+
+// ReplicationTaskType_Values returns all recognized values of ReplicationTaskType.
+func ReplicationTaskType_Values() []ReplicationTaskType {
+	return []ReplicationTaskType{
+		ReplicationTaskTypeDomain,
+		ReplicationTaskTypeHistory,
+		ReplicationTaskTypeSyncShardStatus,
+	}
+}
+
+// UnmarshalText tries to decode ReplicationTaskType from a byte slice
+// containing its name.
+//
+//   var v ReplicationTaskType
+//   err := v.UnmarshalText([]byte("Domain"))
+func (v *ReplicationTaskType) UnmarshalText(value []byte) error {
+	switch string(value) {
+	case "Domain":
+		*v = ReplicationTaskTypeDomain
+		return nil
+	case "History":
+		*v = ReplicationTaskTypeHistory
+		return nil
+	case "SyncShardStatus":
+		*v = ReplicationTaskTypeSyncShardStatus
+		return nil
+	default:
+		return fmt.Errorf("unknown enum value %q for %q", value, "ReplicationTaskType")
+	}
+}
+
+// MarshalText encodes ReplicationTaskType to text.
+//
+// If the enum value is recognized, its name is returned. Otherwise,
+// its integer value is returned.
+//
+// This implements the TextMarshaler interface.
+func (v ReplicationTaskType) MarshalText() ([]byte, error) {
+	switch int32(v) {
+	case 0:
+		return []byte("Domain"), nil
+	case 1:
+		return []byte("History"), nil
+	case 2:
+		return []byte("SyncShardStatus"), nil
+	}
+	return []byte(strconv.FormatInt(int64(v), 10)), nil
+}
+
+// Ptr returns a pointer to this enum value.
+func (v ReplicationTaskType) Ptr() *ReplicationTaskType {
+	return &v
+}
+
+// ToWire translates ReplicationTaskType into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// Enums are represented as 32-bit integers over the wire.
+func (v ReplicationTaskType) ToWire() (wire.Value, error) {
+	return wire.NewValueI32(int32(v)), nil
+}
+
+// FromWire deserializes ReplicationTaskType from its Thrift-level
+// representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TI32)
+//   if err != nil {
+//     return ReplicationTaskType(0), err
+//   }
+//
+//   var v ReplicationTaskType
+//   if err := v.FromWire(x); err != nil {
+//     return ReplicationTaskType(0), err
+//   }
+//   return v, nil
+func (v *ReplicationTaskType) FromWire(w wire.Value) error {
+	*v = (ReplicationTaskType)(w.GetI32())
+	return nil
+}
+
+// String returns a readable string representation of ReplicationTaskType.
+func (v ReplicationTaskType) String() string {
+	w := int32(v)
+	switch w {
+	case 0:
+		return "Domain"
+	case 1:
+		return "History"
+	case 2:
+		return "SyncShardStatus"
+	}
+	return fmt.Sprintf("ReplicationTaskType(%d)", w)
+}
+
+// Equals returns true if this ReplicationTaskType value matches the provided
+// value.
+func (v ReplicationTaskType) Equals(rhs ReplicationTaskType) bool {
+	return v == rhs
+}
+
+// MarshalJSON serializes ReplicationTaskType into JSON.
+//
+// If the enum value is recognized, its name is returned. Otherwise,
+// its integer value is returned.
+//
+// This implements json.Marshaler.
+func (v ReplicationTaskType) MarshalJSON() ([]byte, error) {
+	switch int32(v) {
+	case 0:
+		return ([]byte)("\"Domain\""), nil
+	case 1:
+		return ([]byte)("\"History\""), nil
+	case 2:
+		return ([]byte)("\"SyncShardStatus\""), nil
+	}
+	return ([]byte)(strconv.FormatInt(int64(v), 10)), nil
+}
+
+// UnmarshalJSON attempts to decode ReplicationTaskType from its JSON
+// representation.
+//
+// This implementation supports both, numeric and string inputs. If a
+// string is provided, it must be a known enum name.
+//
+// This implements json.Unmarshaler.
+func (v *ReplicationTaskType) UnmarshalJSON(text []byte) error {
+	d := json.NewDecoder(bytes.NewReader(text))
+	d.UseNumber()
+	t, err := d.Token()
+	if err != nil {
+		return err
+	}
+
+	switch w := t.(type) {
+	case json.Number:
+		x, err := w.Int64()
+		if err != nil {
+			return err
+		}
+		if x > math.MaxInt32 {
+			return fmt.Errorf("enum overflow from JSON %q for %q", text, "ReplicationTaskType")
+		}
+		if x < math.MinInt32 {
+			return fmt.Errorf("enum underflow from JSON %q for %q", text, "ReplicationTaskType")
+		}
+		*v = (ReplicationTaskType)(x)
+		return nil
+	case string:
+		return v.UnmarshalText([]byte(w))
+	default:
+		return fmt.Errorf("invalid JSON value %q (%T) to unmarshal into %q", t, t, "ReplicationTaskType")
+	}
+}
+
+type ReplicationToken struct {
+	ShardID                *int32 `json:"shardID,omitempty"`
+	LastRetrievedMessageId *int64 `json:"lastRetrievedMessageId,omitempty"`
+	LastProcessedMessageId *int64 `json:"lastProcessedMessageId,omitempty"`
+}
+
+// ToWire translates a ReplicationToken struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *ReplicationToken) ToWire() (wire.Value, error) {
+	var (
+		fields [3]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.ShardID != nil {
+		w, err = wire.NewValueI32(*(v.ShardID)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.LastRetrievedMessageId != nil {
+		w, err = wire.NewValueI64(*(v.LastRetrievedMessageId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+	if v.LastProcessedMessageId != nil {
+		w, err = wire.NewValueI64(*(v.LastProcessedMessageId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a ReplicationToken struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a ReplicationToken struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v ReplicationToken
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *ReplicationToken) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.ShardID = &x
 				if err != nil {
 					return err
 				}
 
 			}
-		case 50:
+		case 20:
 			if field.Value.Type() == wire.TI64 {
 				var x int64
 				x, err = field.Value.GetI64(), error(nil)
-				v.NextEventId = &x
+				v.LastRetrievedMessageId = &x
 				if err != nil {
 					return err
 				}
 
 			}
-		case 60:
+		case 30:
 			if field.Value.Type() == wire.TI64 {
 				var x int64
 				x, err = field.Value.GetI64(), error(nil)
-				v.Version = &x
-				if err != nil {
-					return err
-				}
-
-			}
-		case 70:
-			if field.Value.Type() == wire.TMap {
-				v.ReplicationInfo, err = _Map_String_ReplicationInfo_Read(field.Value.GetMap())
-				if err != nil {
-					return err
-				}
-
-			}
-		case 80:
-			if field.Value.Type() == wire.TStruct {
-				v.History, err = _History_Read(field.Value)
-				if err != nil {
-					return err
-				}
-
-			}
-		case 90:
-			if field.Value.Type() == wire.TStruct {
-				v.NewRunHistory, err = _History_Read(field.Value)
+				v.LastProcessedMessageId = &x
 				if err != nil {
 					return err
 				}
@@ -928,238 +4021,125 @@ func (v *HistoryTaskAttributes) FromWire(w wire.Value) error {
 	return nil
 }
 
-// String returns a readable string representation of a HistoryTaskAttributes
+// String returns a readable string representation of a ReplicationToken
 // struct.
-func (v *HistoryTaskAttributes) String() string {
+func (v *ReplicationToken) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 
-	var fields [10]string
+	var fields [3]string
 	i := 0
-	if v.TargetClusters != nil {
-		fields[i] = fmt.Sprintf("TargetClusters: %v", v.TargetClusters)
-		i++
-	}
-	if v.DomainId != nil {
-		fields[i] = fmt.Sprintf("DomainId: %v", *(v.DomainId))
-		i++
-	}
-	if v.WorkflowId != nil {
-		fields[i] = fmt.Sprintf("WorkflowId: %v", *(v.WorkflowId))
-		i++
-	}
-	if v.RunId != nil {
-		fields[i] = fmt.Sprintf("RunId: %v", *(v.RunId))
-		i++
-	}
-	if v.FirstEventId != nil {
-		fields[i] = fmt.Sprintf("FirstEventId: %v", *(v.FirstEventId))
-		i++
-	}
-	if v.NextEventId != nil {
-		fields[i] = fmt.Sprintf("NextEventId: %v", *(v.NextEventId))
+	if v.ShardID != nil {
+		fields[i] = fmt.Sprintf("ShardID: %v", *(v.ShardID))
 		i++
 	}
-	if v.Version != nil {
-		fields[i] = fmt.Sprintf("Version: %v", *(v.Version))
-		i++
-	}
-	if v.ReplicationInfo != nil {
-		fields[i] = fmt.Sprintf("ReplicationInfo: %v", v.ReplicationInfo)
-		i++
-	}
-	if v.History != nil {
-		fields[i] = fmt.Sprintf("History: %v", v.History)
+	if v.LastRetrievedMessageId != nil {
+		fields[i] = fmt.Sprintf("LastRetrievedMessageId: %v", *(v.LastRetrievedMessageId))
 		i++
 	}
-	if v.NewRunHistory != nil {
-		fields[i] = fmt.Sprintf("NewRunHistory: %v", v.NewRunHistory)
+	if v.LastProcessedMessageId != nil {
+		fields[i] = fmt.Sprintf("LastProcessedMessageId: %v", *(v.LastProcessedMessageId))
 		i++
 	}
 
-	return fmt.Sprintf("HistoryTaskAttributes{%v}", strings.Join(fields[:i], ", "))
-}
-
-func _List_String_Equals(lhs, rhs []string) bool {
-	if len(lhs) != len(rhs) {
-		return false
-	}
-
-	for i, lv := range lhs {
-		rv := rhs[i]
-		if !(lv == rv) {
-			return false
-		}
-	}
-
-	return true
-}
-
-func _Map_String_ReplicationInfo_Equals(lhs, rhs map[string]*history.ReplicationInfo) bool {
-	if len(lhs) != len(rhs) {
-		return false
-	}
-
-	for lk, lv := range lhs {
-		rv, ok := rhs[lk]
-		if !ok {
-			return false
-		}
-		if !lv.Equals(rv) {
-			return false
-		}
-	}
-	return true
+	return fmt.Sprintf("ReplicationToken{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this HistoryTaskAttributes match the
-// provided HistoryTaskAttributes.
+// Equals returns true if all the fields of this ReplicationToken match the
+// provided ReplicationToken.
 //
 // This function performs a deep comparison.
-func (v *HistoryTaskAttributes) Equals(rhs *HistoryTaskAttributes) bool {
-	if !((v.TargetClusters == nil && rhs.TargetClusters == nil) || (v.TargetClusters != nil && rhs.TargetClusters != nil && _List_String_Equals(v.TargetClusters, rhs.TargetClusters))) {
-		return false
-	}
-	if !_String_EqualsPtr(v.DomainId, rhs.DomainId) {
-		return false
-	}
-	if !_String_EqualsPtr(v.WorkflowId, rhs.WorkflowId) {
-		return false
-	}
-	if !_String_EqualsPtr(v.RunId, rhs.RunId) {
-		return false
-	}
-	if !_I64_EqualsPtr(v.FirstEventId, rhs.FirstEventId) {
-		return false
-	}
-	if !_I64_EqualsPtr(v.NextEventId, rhs.NextEventId) {
-		return false
-	}
-	if !_I64_EqualsPtr(v.Version, rhs.Version) {
-		return false
-	}
-	if !((v.ReplicationInfo == nil && rhs.ReplicationInfo == nil) || (v.ReplicationInfo != nil && rhs.ReplicationInfo != nil && _Map_String_ReplicationInfo_Equals(v.ReplicationInfo, rhs.ReplicationInfo))) {
+func (v *ReplicationToken) Equals(rhs *ReplicationToken) bool {
+	if !_I32_EqualsPtr(v.ShardID, rhs.ShardID) {
 		return false
 	}
-	if !((v.History == nil && rhs.History == nil) || (v.History != nil && rhs.History != nil && v.History.Equals(rhs.History))) {
+	if !_I64_EqualsPtr(v.LastRetrievedMessageId, rhs.LastRetrievedMessageId) {
 		return false
 	}
-	if !((v.NewRunHistory == nil && rhs.NewRunHistory == nil) || (v.NewRunHistory != nil && rhs.NewRunHistory != nil && v.NewRunHistory.Equals(rhs.NewRunHistory))) {
+	if !_I64_EqualsPtr(v.LastProcessedMessageId, rhs.LastProcessedMessageId) {
 		return false
 	}
 
 	return true
 }
 
-// GetTargetClusters returns the value of TargetClusters if it is set or its
-// zero value if it is unset.
-func (v *HistoryTaskAttributes) GetTargetClusters() (o []string) {
-	if v.TargetClusters != nil {
-		return v.TargetClusters
-	}
-
-	return
-}
-
-// GetDomainId returns the value of DomainId if it is set or its
+// GetShardID returns the value of ShardID if it is set or its
 // zero value if it is unset.
-func (v *HistoryTaskAttributes) GetDomainId() (o string) {
-	if v.DomainId != nil {
-		return *v.DomainId
+func (v *ReplicationToken) GetShardID() (o int32) {
+	if v.ShardID != nil {
+		return *v.ShardID
 	}
 
 	return
 }
 
-// GetWorkflowId returns the value of WorkflowId if it is set or its
+// GetLastRetrievedMessageId returns the value of LastRetrievedMessageId if it is set or its
 // zero value if it is unset.
-func (v *HistoryTaskAttributes) GetWorkflowId() (o string) {
-	if v.WorkflowId != nil {
-		return *v.WorkflowId
+func (v *ReplicationToken) GetLastRetrievedMessageId() (o int64) {
+	if v.LastRetrievedMessageId != nil {
+		return *v.LastRetrievedMessageId
 	}
 
 	return
 }
 
-// GetRunId returns the value of RunId if it is set or its
+// GetLastProcessedMessageId returns the value of LastProcessedMessageId if it is set or its
 // zero value if it is unset.
-func (v *HistoryTaskAttributes) GetRunId() (o string) {
-	if v.RunId != nil {
-		return *v.RunId
+func (v *ReplicationToken) GetLastProcessedMessageId() (o int64) {
+	if v.LastProcessedMessageId != nil {
+		return *v.LastProcessedMessageId
 	}
 
 	return
 }
 
-// GetFirstEventId returns the value of FirstEventId if it is set or its
-// zero value if it is unset.
-func (v *HistoryTaskAttributes) GetFirstEventId() (o int64) {
-	if v.FirstEventId != nil {
-		return *v.FirstEventId
-	}
-
-	return
+type ShardReplicationStatus struct {
+	ShardID            *int32                                     `json:"shardID,omitempty"`
+	ReplicatorAckLevel *int64                                     `json:"replicatorAckLevel,omitempty"`
+	MaxReadLevel       *int64                                     `json:"maxReadLevel,omitempty"`
+	RemoteClusters     map[string]*RemoteClusterReplicationStatus `json:"remoteClusters,omitempty"`
 }
 
-// GetNextEventId returns the value of NextEventId if it is set or its
-// zero value if it is unset.
-func (v *HistoryTaskAttributes) GetNextEventId() (o int64) {
-	if v.NextEventId != nil {
-		return *v.NextEventId
-	}
+type _Map_String_RemoteClusterReplicationStatus_MapItemList map[string]*RemoteClusterReplicationStatus
 
-	return
-}
+func (m _Map_String_RemoteClusterReplicationStatus_MapItemList) ForEach(f func(wire.MapItem) error) error {
+	for k, v := range m {
+		if v == nil {
+			return fmt.Errorf("invalid [%v]: value is nil", k)
+		}
+		kw, err := wire.NewValueString(k), error(nil)
+		if err != nil {
+			return err
+		}
 
-// GetVersion returns the value of Version if it is set or its
-// zero value if it is unset.
-func (v *HistoryTaskAttributes) GetVersion() (o int64) {
-	if v.Version != nil {
-		return *v.Version
+		vw, err := v.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(wire.MapItem{Key: kw, Value: vw})
+		if err != nil {
+			return err
+		}
 	}
-
-	return
+	return nil
 }
 
-// GetReplicationInfo returns the value of ReplicationInfo if it is set or its
-// zero value if it is unset.
-func (v *HistoryTaskAttributes) GetReplicationInfo() (o map[string]*history.ReplicationInfo) {
-	if v.ReplicationInfo != nil {
-		return v.ReplicationInfo
-	}
-
-	return
+func (m _Map_String_RemoteClusterReplicationStatus_MapItemList) Size() int {
+	return len(m)
 }
 
-// GetHistory returns the value of History if it is set or its
-// zero value if it is unset.
-func (v *HistoryTaskAttributes) GetHistory() (o *shared.History) {
-	if v.History != nil {
-		return v.History
-	}
-
-	return
+func (_Map_String_RemoteClusterReplicationStatus_MapItemList) KeyType() wire.Type {
+	return wire.TBinary
 }
 
-// GetNewRunHistory returns the value of NewRunHistory if it is set or its
-// zero value if it is unset.
-func (v *HistoryTaskAttributes) GetNewRunHistory() (o *shared.History) {
-	if v.NewRunHistory != nil {
-		return v.NewRunHistory
-	}
-
-	return
+func (_Map_String_RemoteClusterReplicationStatus_MapItemList) ValueType() wire.Type {
+	return wire.TStruct
 }
 
-type ReplicationTask struct {
-	TaskType                      *ReplicationTaskType           `json:"taskType,omitempty"`
-	DomainTaskAttributes          *DomainTaskAttributes          `json:"domainTaskAttributes,omitempty"`
-	HistoryTaskAttributes         *HistoryTaskAttributes         `json:"historyTaskAttributes,omitempty"`
-	SyncShardStatusTaskAttributes *SyncShardStatusTaskAttributes `json:"syncShardStatusTaskAttributes,omitempty"`
-}
+func (_Map_String_RemoteClusterReplicationStatus_MapItemList) Close() {}
 
-// ToWire translates a ReplicationTask struct into a Thrift-level intermediate
+// ToWire translates a ShardReplicationStatus struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -1174,7 +4154,7 @@ type ReplicationTask struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *ReplicationTask) ToWire() (wire.Value, error) {
+func (v *ShardReplicationStatus) ToWire() (wire.Value, error) {
 	var (
 		fields [4]wire.Field
 		i      int = 0
@@ -1182,32 +4162,32 @@ func (v *ReplicationTask) ToWire() (wire.Value, error) {
 		err    error
 	)
 
-	if v.TaskType != nil {
-		w, err = v.TaskType.ToWire()
+	if v.ShardID != nil {
+		w, err = wire.NewValueI32(*(v.ShardID)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 10, Value: w}
 		i++
 	}
-	if v.DomainTaskAttributes != nil {
-		w, err = v.DomainTaskAttributes.ToWire()
+	if v.ReplicatorAckLevel != nil {
+		w, err = wire.NewValueI64(*(v.ReplicatorAckLevel)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 20, Value: w}
 		i++
 	}
-	if v.HistoryTaskAttributes != nil {
-		w, err = v.HistoryTaskAttributes.ToWire()
+	if v.MaxReadLevel != nil {
+		w, err = wire.NewValueI64(*(v.MaxReadLevel)), error(nil)
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 30, Value: w}
 		i++
 	}
-	if v.SyncShardStatusTaskAttributes != nil {
-		w, err = v.SyncShardStatusTaskAttributes.ToWire()
+	if v.RemoteClusters != nil {
+		w, err = wire.NewValueMap(_Map_String_RemoteClusterReplicationStatus_MapItemList(v.RemoteClusters)), error(nil)
 		if err != nil {
 			return w, err
 		}
@@ -1218,35 +4198,45 @@ func (v *ReplicationTask) ToWire() (wire.Value, error) {
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func _ReplicationTaskType_Read(w wire.Value) (ReplicationTaskType, error) {
-	var v ReplicationTaskType
-	err := v.FromWire(w)
-	return v, err
-}
-
-func _DomainTaskAttributes_Read(w wire.Value) (*DomainTaskAttributes, error) {
-	var v DomainTaskAttributes
+func _RemoteClusterReplicationStatus_Read(w wire.Value) (*RemoteClusterReplicationStatus, error) {
+	var v RemoteClusterReplicationStatus
 	err := v.FromWire(w)
 	return &v, err
 }
 
-func _HistoryTaskAttributes_Read(w wire.Value) (*HistoryTaskAttributes, error) {
-	var v HistoryTaskAttributes
-	err := v.FromWire(w)
-	return &v, err
-}
+func _Map_String_RemoteClusterReplicationStatus_Read(m wire.MapItemList) (map[string]*RemoteClusterReplicationStatus, error) {
+	if m.KeyType() != wire.TBinary {
+		return nil, nil
+	}
+
+	if m.ValueType() != wire.TStruct {
+		return nil, nil
+	}
+
+	o := make(map[string]*RemoteClusterReplicationStatus, m.Size())
+	err := m.ForEach(func(x wire.MapItem) error {
+		k, err := x.Key.GetString(), error(nil)
+		if err != nil {
+			return err
+		}
+
+		v, err := _RemoteClusterReplicationStatus_Read(x.Value)
+		if err != nil {
+			return err
+		}
 
-func _SyncShardStatusTaskAttributes_Read(w wire.Value) (*SyncShardStatusTaskAttributes, error) {
-	var v SyncShardStatusTaskAttributes
-	err := v.FromWire(w)
-	return &v, err
+		o[k] = v
+		return nil
+	})
+	m.Close()
+	return o, err
 }
 
-// FromWire deserializes a ReplicationTask struct from its Thrift-level
+// FromWire deserializes a ShardReplicationStatus struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a ReplicationTask struct
+// An error is returned if we were unable to build a ShardReplicationStatus struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -1254,45 +4244,49 @@ func _SyncShardStatusTaskAttributes_Read(w wire.Value) (*SyncShardStatusTaskAttr
 //     return nil, err
 //   }
 //
-//   var v ReplicationTask
+//   var v ShardReplicationStatus
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *ReplicationTask) FromWire(w wire.Value) error {
+func (v *ShardReplicationStatus) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
 		switch field.ID {
 		case 10:
 			if field.Value.Type() == wire.TI32 {
-				var x ReplicationTaskType
-				x, err = _ReplicationTaskType_Read(field.Value)
-				v.TaskType = &x
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.ShardID = &x
 				if err != nil {
 					return err
 				}
 
 			}
 		case 20:
-			if field.Value.Type() == wire.TStruct {
-				v.DomainTaskAttributes, err = _DomainTaskAttributes_Read(field.Value)
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.ReplicatorAckLevel = &x
 				if err != nil {
 					return err
 				}
 
 			}
 		case 30:
-			if field.Value.Type() == wire.TStruct {
-				v.HistoryTaskAttributes, err = _HistoryTaskAttributes_Read(field.Value)
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.MaxReadLevel = &x
 				if err != nil {
 					return err
 				}
 
 			}
 		case 40:
-			if field.Value.Type() == wire.TStruct {
-				v.SyncShardStatusTaskAttributes, err = _SyncShardStatusTaskAttributes_Read(field.Value)
+			if field.Value.Type() == wire.TMap {
+				v.RemoteClusters, err = _Map_String_RemoteClusterReplicationStatus_Read(field.Value.GetMap())
 				if err != nil {
 					return err
 				}
@@ -1304,268 +4298,113 @@ func (v *ReplicationTask) FromWire(w wire.Value) error {
 	return nil
 }
 
-// String returns a readable string representation of a ReplicationTask
+// String returns a readable string representation of a ShardReplicationStatus
 // struct.
-func (v *ReplicationTask) String() string {
+func (v *ShardReplicationStatus) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 
 	var fields [4]string
 	i := 0
-	if v.TaskType != nil {
-		fields[i] = fmt.Sprintf("TaskType: %v", *(v.TaskType))
+	if v.ShardID != nil {
+		fields[i] = fmt.Sprintf("ShardID: %v", *(v.ShardID))
 		i++
 	}
-	if v.DomainTaskAttributes != nil {
-		fields[i] = fmt.Sprintf("DomainTaskAttributes: %v", v.DomainTaskAttributes)
+	if v.ReplicatorAckLevel != nil {
+		fields[i] = fmt.Sprintf("ReplicatorAckLevel: %v", *(v.ReplicatorAckLevel))
 		i++
 	}
-	if v.HistoryTaskAttributes != nil {
-		fields[i] = fmt.Sprintf("HistoryTaskAttributes: %v", v.HistoryTaskAttributes)
+	if v.MaxReadLevel != nil {
+		fields[i] = fmt.Sprintf("MaxReadLevel: %v", *(v.MaxReadLevel))
 		i++
 	}
-	if v.SyncShardStatusTaskAttributes != nil {
-		fields[i] = fmt.Sprintf("SyncShardStatusTaskAttributes: %v", v.SyncShardStatusTaskAttributes)
+	if v.RemoteClusters != nil {
+		fields[i] = fmt.Sprintf("RemoteClusters: %v", v.RemoteClusters)
 		i++
 	}
 
-	return fmt.Sprintf("ReplicationTask{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("ShardReplicationStatus{%v}", strings.Join(fields[:i], ", "))
 }
 
-func _ReplicationTaskType_EqualsPtr(lhs, rhs *ReplicationTaskType) bool {
-	if lhs != nil && rhs != nil {
+func _Map_String_RemoteClusterReplicationStatus_Equals(lhs, rhs map[string]*RemoteClusterReplicationStatus) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
 
-		x := *lhs
-		y := *rhs
-		return x.Equals(y)
+	for lk, lv := range lhs {
+		rv, ok := rhs[lk]
+		if !ok {
+			return false
+		}
+		if !lv.Equals(rv) {
+			return false
+		}
 	}
-	return lhs == nil && rhs == nil
+	return true
 }
 
-// Equals returns true if all the fields of this ReplicationTask match the
-// provided ReplicationTask.
+// Equals returns true if all the fields of this ShardReplicationStatus match the
+// provided ShardReplicationStatus.
 //
 // This function performs a deep comparison.
-func (v *ReplicationTask) Equals(rhs *ReplicationTask) bool {
-	if !_ReplicationTaskType_EqualsPtr(v.TaskType, rhs.TaskType) {
+func (v *ShardReplicationStatus) Equals(rhs *ShardReplicationStatus) bool {
+	if !_I32_EqualsPtr(v.ShardID, rhs.ShardID) {
 		return false
 	}
-	if !((v.DomainTaskAttributes == nil && rhs.DomainTaskAttributes == nil) || (v.DomainTaskAttributes != nil && rhs.DomainTaskAttributes != nil && v.DomainTaskAttributes.Equals(rhs.DomainTaskAttributes))) {
+	if !_I64_EqualsPtr(v.ReplicatorAckLevel, rhs.ReplicatorAckLevel) {
 		return false
 	}
-	if !((v.HistoryTaskAttributes == nil && rhs.HistoryTaskAttributes == nil) || (v.HistoryTaskAttributes != nil && rhs.HistoryTaskAttributes != nil && v.HistoryTaskAttributes.Equals(rhs.HistoryTaskAttributes))) {
+	if !_I64_EqualsPtr(v.MaxReadLevel, rhs.MaxReadLevel) {
 		return false
 	}
-	if !((v.SyncShardStatusTaskAttributes == nil && rhs.SyncShardStatusTaskAttributes == nil) || (v.SyncShardStatusTaskAttributes != nil && rhs.SyncShardStatusTaskAttributes != nil && v.SyncShardStatusTaskAttributes.Equals(rhs.SyncShardStatusTaskAttributes))) {
+	if !((v.RemoteClusters == nil && rhs.RemoteClusters == nil) || (v.RemoteClusters != nil && rhs.RemoteClusters != nil && _Map_String_RemoteClusterReplicationStatus_Equals(v.RemoteClusters, rhs.RemoteClusters))) {
 		return false
 	}
 
 	return true
 }
 
-// GetTaskType returns the value of TaskType if it is set or its
+// GetShardID returns the value of ShardID if it is set or its
 // zero value if it is unset.
-func (v *ReplicationTask) GetTaskType() (o ReplicationTaskType) {
-	if v.TaskType != nil {
-		return *v.TaskType
+func (v *ShardReplicationStatus) GetShardID() (o int32) {
+	if v.ShardID != nil {
+		return *v.ShardID
 	}
 
 	return
 }
 
-// GetDomainTaskAttributes returns the value of DomainTaskAttributes if it is set or its
+// GetReplicatorAckLevel returns the value of ReplicatorAckLevel if it is set or its
 // zero value if it is unset.
-func (v *ReplicationTask) GetDomainTaskAttributes() (o *DomainTaskAttributes) {
-	if v.DomainTaskAttributes != nil {
-		return v.DomainTaskAttributes
+func (v *ShardReplicationStatus) GetReplicatorAckLevel() (o int64) {
+	if v.ReplicatorAckLevel != nil {
+		return *v.ReplicatorAckLevel
 	}
 
 	return
 }
 
-// GetHistoryTaskAttributes returns the value of HistoryTaskAttributes if it is set or its
+// GetMaxReadLevel returns the value of MaxReadLevel if it is set or its
 // zero value if it is unset.
-func (v *ReplicationTask) GetHistoryTaskAttributes() (o *HistoryTaskAttributes) {
-	if v.HistoryTaskAttributes != nil {
-		return v.HistoryTaskAttributes
+func (v *ShardReplicationStatus) GetMaxReadLevel() (o int64) {
+	if v.MaxReadLevel != nil {
+		return *v.MaxReadLevel
 	}
 
 	return
 }
 
-// GetSyncShardStatusTaskAttributes returns the value of SyncShardStatusTaskAttributes if it is set or its
+// GetRemoteClusters returns the value of RemoteClusters if it is set or its
 // zero value if it is unset.
-func (v *ReplicationTask) GetSyncShardStatusTaskAttributes() (o *SyncShardStatusTaskAttributes) {
-	if v.SyncShardStatusTaskAttributes != nil {
-		return v.SyncShardStatusTaskAttributes
+func (v *ShardReplicationStatus) GetRemoteClusters() (o map[string]*RemoteClusterReplicationStatus) {
+	if v.RemoteClusters != nil {
+		return v.RemoteClusters
 	}
 
 	return
 }
 
-type ReplicationTaskType int32
-
-const (
-	ReplicationTaskTypeDomain          ReplicationTaskType = 0
-	ReplicationTaskTypeHistory         ReplicationTaskType = 1
-	ReplicationTaskTypeSyncShardStatus ReplicationTaskType = 2
-)
-
-// ReplicationTaskType_Values returns all recognized values of ReplicationTaskType.
-func ReplicationTaskType_Values() []ReplicationTaskType {
-	return []ReplicationTaskType{
-		ReplicationTaskTypeDomain,
-		ReplicationTaskTypeHistory,
-		ReplicationTaskTypeSyncShardStatus,
-	}
-}
-
-// UnmarshalText tries to decode ReplicationTaskType from a byte slice
-// containing its name.
-//
-//   var v ReplicationTaskType
-//   err := v.UnmarshalText([]byte("Domain"))
-func (v *ReplicationTaskType) UnmarshalText(value []byte) error {
-	switch string(value) {
-	case "Domain":
-		*v = ReplicationTaskTypeDomain
-		return nil
-	case "History":
-		*v = ReplicationTaskTypeHistory
-		return nil
-	case "SyncShardStatus":
-		*v = ReplicationTaskTypeSyncShardStatus
-		return nil
-	default:
-		return fmt.Errorf("unknown enum value %q for %q", value, "ReplicationTaskType")
-	}
-}
-
-// MarshalText encodes ReplicationTaskType to text.
-//
-// If the enum value is recognized, its name is returned. Otherwise,
-// its integer value is returned.
-//
-// This implements the TextMarshaler interface.
-func (v ReplicationTaskType) MarshalText() ([]byte, error) {
-	switch int32(v) {
-	case 0:
-		return []byte("Domain"), nil
-	case 1:
-		return []byte("History"), nil
-	case 2:
-		return []byte("SyncShardStatus"), nil
-	}
-	return []byte(strconv.FormatInt(int64(v), 10)), nil
-}
-
-// Ptr returns a pointer to this enum value.
-func (v ReplicationTaskType) Ptr() *ReplicationTaskType {
-	return &v
-}
-
-// ToWire translates ReplicationTaskType into a Thrift-level intermediate
-// representation. This intermediate representation may be serialized
-// into bytes using a ThriftRW protocol implementation.
-//
-// Enums are represented as 32-bit integers over the wire.
-func (v ReplicationTaskType) ToWire() (wire.Value, error) {
-	return wire.NewValueI32(int32(v)), nil
-}
-
-// FromWire deserializes ReplicationTaskType from its Thrift-level
-// representation.
-//
-//   x, err := binaryProtocol.Decode(reader, wire.TI32)
-//   if err != nil {
-//     return ReplicationTaskType(0), err
-//   }
-//
-//   var v ReplicationTaskType
-//   if err := v.FromWire(x); err != nil {
-//     return ReplicationTaskType(0), err
-//   }
-//   return v, nil
-func (v *ReplicationTaskType) FromWire(w wire.Value) error {
-	*v = (ReplicationTaskType)(w.GetI32())
-	return nil
-}
-
-// String returns a readable string representation of ReplicationTaskType.
-func (v ReplicationTaskType) String() string {
-	w := int32(v)
-	switch w {
-	case 0:
-		return "Domain"
-	case 1:
-		return "History"
-	case 2:
-		return "SyncShardStatus"
-	}
-	return fmt.Sprintf("ReplicationTaskType(%d)", w)
-}
-
-// Equals returns true if this ReplicationTaskType value matches the provided
-// value.
-func (v ReplicationTaskType) Equals(rhs ReplicationTaskType) bool {
-	return v == rhs
-}
-
-// MarshalJSON serializes ReplicationTaskType into JSON.
-//
-// If the enum value is recognized, its name is returned. Otherwise,
-// its integer value is returned.
-//
-// This implements json.Marshaler.
-func (v ReplicationTaskType) MarshalJSON() ([]byte, error) {
-	switch int32(v) {
-	case 0:
-		return ([]byte)("\"Domain\""), nil
-	case 1:
-		return ([]byte)("\"History\""), nil
-	case 2:
-		return ([]byte)("\"SyncShardStatus\""), nil
-	}
-	return ([]byte)(strconv.FormatInt(int64(v), 10)), nil
-}
-
-// UnmarshalJSON attempts to decode ReplicationTaskType from its JSON
-// representation.
-//
-// This implementation supports both, numeric and string inputs. If a
-// string is provided, it must be a known enum name.
-//
-// This implements json.Unmarshaler.
-func (v *ReplicationTaskType) UnmarshalJSON(text []byte) error {
-	d := json.NewDecoder(bytes.NewReader(text))
-	d.UseNumber()
-	t, err := d.Token()
-	if err != nil {
-		return err
-	}
-
-	switch w := t.(type) {
-	case json.Number:
-		x, err := w.Int64()
-		if err != nil {
-			return err
-		}
-		if x > math.MaxInt32 {
-			return fmt.Errorf("enum overflow from JSON %q for %q", text, "ReplicationTaskType")
-		}
-		if x < math.MinInt32 {
-			return fmt.Errorf("enum underflow from JSON %q for %q", text, "ReplicationTaskType")
-		}
-		*v = (ReplicationTaskType)(x)
-		return nil
-	case string:
-		return v.UnmarshalText([]byte(w))
-	default:
-		return fmt.Errorf("invalid JSON value %q (%T) to unmarshal into %q", t, t, "ReplicationTaskType")
-	}
-}
-
 type SyncShardStatusTaskAttributes struct {
 	SourceCluster *string `json:"sourceCluster,omitempty"`
 	ShardId       *int64  `json:"shardId,omitempty"`
@@ -1058,6 +1058,248 @@ func (v *ActivityTaskFailedEventAttributes) GetIdentity() (o string) {
 	return
 }
 
+type ActivityTaskRedirectedEventAttributes struct {
+	ScheduledEventId *int64       `json:"scheduledEventId,omitempty"`
+	PreviousTaskList *TaskList    `json:"previousTaskList,omitempty"`
+	TaskList         *TaskList    `json:"taskList,omitempty"`
+	TimeoutType      *TimeoutType `json:"timeoutType,omitempty"`
+}
+
+// ToWire translates a ActivityTaskRedirectedEventAttributes struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *ActivityTaskRedirectedEventAttributes) ToWire() (wire.Value, error) {
+	var (
+		fields [4]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.ScheduledEventId != nil {
+		w, err = wire.NewValueI64(*(v.ScheduledEventId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.PreviousTaskList != nil {
+		w, err = v.PreviousTaskList.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+	if v.TaskList != nil {
+		w, err = v.TaskList.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+	if v.TimeoutType != nil {
+		w, err = v.TimeoutType.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 40, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _TaskList_Read(w wire.Value) (*TaskList, error) {
+	var v TaskList
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _TimeoutType_Read(w wire.Value) (TimeoutType, error) {
+	var v TimeoutType
+	err := v.FromWire(w)
+	return v, err
+}
+
+// FromWire deserializes a ActivityTaskRedirectedEventAttributes struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a ActivityTaskRedirectedEventAttributes struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v ActivityTaskRedirectedEventAttributes
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *ActivityTaskRedirectedEventAttributes) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.ScheduledEventId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TStruct {
+				v.PreviousTaskList, err = _TaskList_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 30:
+			if field.Value.Type() == wire.TStruct {
+				v.TaskList, err = _TaskList_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 40:
+			if field.Value.Type() == wire.TI32 {
+				var x TimeoutType
+				x, err = _TimeoutType_Read(field.Value)
+				v.TimeoutType = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a ActivityTaskRedirectedEventAttributes
+// struct.
+func (v *ActivityTaskRedirectedEventAttributes) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [4]string
+	i := 0
+	if v.ScheduledEventId != nil {
+		fields[i] = fmt.Sprintf("ScheduledEventId: %v", *(v.ScheduledEventId))
+		i++
+	}
+	if v.PreviousTaskList != nil {
+		fields[i] = fmt.Sprintf("PreviousTaskList: %v", v.PreviousTaskList)
+		i++
+	}
+	if v.TaskList != nil {
+		fields[i] = fmt.Sprintf("TaskList: %v", v.TaskList)
+		i++
+	}
+	if v.TimeoutType != nil {
+		fields[i] = fmt.Sprintf("TimeoutType: %v", *(v.TimeoutType))
+		i++
+	}
+
+	return fmt.Sprintf("ActivityTaskRedirectedEventAttributes{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _TimeoutType_EqualsPtr(lhs, rhs *TimeoutType) bool {
+	if lhs != nil && rhs != nil {
+
+		x := *lhs
+		y := *rhs
+		return x.Equals(y)
+	}
+	return lhs == nil && rhs == nil
+}
+
+// Equals returns true if all the fields of this ActivityTaskRedirectedEventAttributes match the
+// provided ActivityTaskRedirectedEventAttributes.
+//
+// This function performs a deep comparison.
+func (v *ActivityTaskRedirectedEventAttributes) Equals(rhs *ActivityTaskRedirectedEventAttributes) bool {
+	if !_I64_EqualsPtr(v.ScheduledEventId, rhs.ScheduledEventId) {
+		return false
+	}
+	if !((v.PreviousTaskList == nil && rhs.PreviousTaskList == nil) || (v.PreviousTaskList != nil && rhs.PreviousTaskList != nil && v.PreviousTaskList.Equals(rhs.PreviousTaskList))) {
+		return false
+	}
+	if !((v.TaskList == nil && rhs.TaskList == nil) || (v.TaskList != nil && rhs.TaskList != nil && v.TaskList.Equals(rhs.TaskList))) {
+		return false
+	}
+	if !_TimeoutType_EqualsPtr(v.TimeoutType, rhs.TimeoutType) {
+		return false
+	}
+
+	return true
+}
+
+// GetScheduledEventId returns the value of ScheduledEventId if it is set or its
+// zero value if it is unset.
+func (v *ActivityTaskRedirectedEventAttributes) GetScheduledEventId() (o int64) {
+	if v.ScheduledEventId != nil {
+		return *v.ScheduledEventId
+	}
+
+	return
+}
+
+// GetPreviousTaskList returns the value of PreviousTaskList if it is set or its
+// zero value if it is unset.
+func (v *ActivityTaskRedirectedEventAttributes) GetPreviousTaskList() (o *TaskList) {
+	if v.PreviousTaskList != nil {
+		return v.PreviousTaskList
+	}
+
+	return
+}
+
+// GetTaskList returns the value of TaskList if it is set or its
+// zero value if it is unset.
+func (v *ActivityTaskRedirectedEventAttributes) GetTaskList() (o *TaskList) {
+	if v.TaskList != nil {
+		return v.TaskList
+	}
+
+	return
+}
+
+// GetTimeoutType returns the value of TimeoutType if it is set or its
+// zero value if it is unset.
+func (v *ActivityTaskRedirectedEventAttributes) GetTimeoutType() (o TimeoutType) {
+	if v.TimeoutType != nil {
+		return *v.TimeoutType
+	}
+
+	return
+}
+
 type ActivityTaskScheduledEventAttributes struct {
 	ActivityId                    *string       `json:"activityId,omitempty"`
 	ActivityType                  *ActivityType `json:"activityType,omitempty"`
@@ -1070,8 +1312,39 @@ type ActivityTaskScheduledEventAttributes struct {
 	HeartbeatTimeoutSeconds       *int32        `json:"heartbeatTimeoutSeconds,omitempty"`
 	DecisionTaskCompletedEventId  *int64        `json:"decisionTaskCompletedEventId,omitempty"`
 	RetryPolicy                   *RetryPolicy  `json:"retryPolicy,omitempty"`
+	FairnessKey                   *string       `json:"fairnessKey,omitempty"`
+	FallbackTaskLists             []*TaskList   `json:"fallbackTaskLists,omitempty"`
+}
+
+type _List_TaskList_ValueList []*TaskList
+
+func (v _List_TaskList_ValueList) ForEach(f func(wire.Value) error) error {
+	for i, x := range v {
+		if x == nil {
+			return fmt.Errorf("invalid [%v]: value is nil", i)
+		}
+		w, err := x.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v _List_TaskList_ValueList) Size() int {
+	return len(v)
 }
 
+func (_List_TaskList_ValueList) ValueType() wire.Type {
+	return wire.TStruct
+}
+
+func (_List_TaskList_ValueList) Close() {}
+
 // ToWire translates a ActivityTaskScheduledEventAttributes struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
@@ -1089,7 +1362,7 @@ type ActivityTaskScheduledEventAttributes struct {
 //   }
 func (v *ActivityTaskScheduledEventAttributes) ToWire() (wire.Value, error) {
 	var (
-		fields [11]wire.Field
+		fields [13]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -1183,6 +1456,22 @@ func (v *ActivityTaskScheduledEventAttributes) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 110, Value: w}
 		i++
 	}
+	if v.FairnessKey != nil {
+		w, err = wire.NewValueString(*(v.FairnessKey)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 120, Value: w}
+		i++
+	}
+	if v.FallbackTaskLists != nil {
+		w, err = wire.NewValueList(_List_TaskList_ValueList(v.FallbackTaskLists)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 130, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
@@ -1193,18 +1482,30 @@ func _ActivityType_Read(w wire.Value) (*ActivityType, error) {
 	return &v, err
 }
 
-func _TaskList_Read(w wire.Value) (*TaskList, error) {
-	var v TaskList
-	err := v.FromWire(w)
-	return &v, err
-}
-
 func _RetryPolicy_Read(w wire.Value) (*RetryPolicy, error) {
 	var v RetryPolicy
 	err := v.FromWire(w)
 	return &v, err
 }
 
+func _List_TaskList_Read(l wire.ValueList) ([]*TaskList, error) {
+	if l.ValueType() != wire.TStruct {
+		return nil, nil
+	}
+
+	o := make([]*TaskList, 0, l.Size())
+	err := l.ForEach(func(x wire.Value) error {
+		i, err := _TaskList_Read(x)
+		if err != nil {
+			return err
+		}
+		o = append(o, i)
+		return nil
+	})
+	l.Close()
+	return o, err
+}
+
 // FromWire deserializes a ActivityTaskScheduledEventAttributes struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
@@ -1328,6 +1629,24 @@ func (v *ActivityTaskScheduledEventAttributes) FromWire(w wire.Value) error {
 					return err
 				}
 
+			}
+		case 120:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.FairnessKey = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 130:
+			if field.Value.Type() == wire.TList {
+				v.FallbackTaskLists, err = _List_TaskList_Read(field.Value.GetList())
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -1342,7 +1661,7 @@ func (v *ActivityTaskScheduledEventAttributes) String() string {
 		return "<nil>"
 	}
 
-	var fields [11]string
+	var fields [13]string
 	i := 0
 	if v.ActivityId != nil {
 		fields[i] = fmt.Sprintf("ActivityId: %v", *(v.ActivityId))
@@ -1388,6 +1707,14 @@ func (v *ActivityTaskScheduledEventAttributes) String() string {
 		fields[i] = fmt.Sprintf("RetryPolicy: %v", v.RetryPolicy)
 		i++
 	}
+	if v.FairnessKey != nil {
+		fields[i] = fmt.Sprintf("FairnessKey: %v", *(v.FairnessKey))
+		i++
+	}
+	if v.FallbackTaskLists != nil {
+		fields[i] = fmt.Sprintf("FallbackTaskLists: %v", v.FallbackTaskLists)
+		i++
+	}
 
 	return fmt.Sprintf("ActivityTaskScheduledEventAttributes{%v}", strings.Join(fields[:i], ", "))
 }
@@ -1402,6 +1729,21 @@ func _I32_EqualsPtr(lhs, rhs *int32) bool {
 	return lhs == nil && rhs == nil
 }
 
+func _List_TaskList_Equals(lhs, rhs []*TaskList) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !lv.Equals(rv) {
+			return false
+		}
+	}
+
+	return true
+}
+
 // Equals returns true if all the fields of this ActivityTaskScheduledEventAttributes match the
 // provided ActivityTaskScheduledEventAttributes.
 //
@@ -1440,6 +1782,12 @@ func (v *ActivityTaskScheduledEventAttributes) Equals(rhs *ActivityTaskScheduled
 	if !((v.RetryPolicy == nil && rhs.RetryPolicy == nil) || (v.RetryPolicy != nil && rhs.RetryPolicy != nil && v.RetryPolicy.Equals(rhs.RetryPolicy))) {
 		return false
 	}
+	if !_String_EqualsPtr(v.FairnessKey, rhs.FairnessKey) {
+		return false
+	}
+	if !((v.FallbackTaskLists == nil && rhs.FallbackTaskLists == nil) || (v.FallbackTaskLists != nil && rhs.FallbackTaskLists != nil && _List_TaskList_Equals(v.FallbackTaskLists, rhs.FallbackTaskLists))) {
+		return false
+	}
 
 	return true
 }
@@ -1554,6 +1902,26 @@ func (v *ActivityTaskScheduledEventAttributes) GetRetryPolicy() (o *RetryPolicy)
 	return
 }
 
+// GetFairnessKey returns the value of FairnessKey if it is set or its
+// zero value if it is unset.
+func (v *ActivityTaskScheduledEventAttributes) GetFairnessKey() (o string) {
+	if v.FairnessKey != nil {
+		return *v.FairnessKey
+	}
+
+	return
+}
+
+// GetFallbackTaskLists returns the value of FallbackTaskLists if it is set or its
+// zero value if it is unset.
+func (v *ActivityTaskScheduledEventAttributes) GetFallbackTaskLists() (o []*TaskList) {
+	if v.FallbackTaskLists != nil {
+		return v.FallbackTaskLists
+	}
+
+	return
+}
+
 type ActivityTaskStartedEventAttributes struct {
 	ScheduledEventId *int64  `json:"scheduledEventId,omitempty"`
 	Identity         *string `json:"identity,omitempty"`
@@ -1844,12 +2212,6 @@ func (v *ActivityTaskTimedOutEventAttributes) ToWire() (wire.Value, error) {
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func _TimeoutType_Read(w wire.Value) (TimeoutType, error) {
-	var v TimeoutType
-	err := v.FromWire(w)
-	return v, err
-}
-
 // FromWire deserializes a ActivityTaskTimedOutEventAttributes struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
@@ -1945,16 +2307,6 @@ func (v *ActivityTaskTimedOutEventAttributes) String() string {
 	return fmt.Sprintf("ActivityTaskTimedOutEventAttributes{%v}", strings.Join(fields[:i], ", "))
 }
 
-func _TimeoutType_EqualsPtr(lhs, rhs *TimeoutType) bool {
-	if lhs != nil && rhs != nil {
-
-		x := *lhs
-		y := *rhs
-		return x.Equals(y)
-	}
-	return lhs == nil && rhs == nil
-}
-
 // Equals returns true if all the fields of this ActivityTaskTimedOutEventAttributes match the
 // provided ActivityTaskTimedOutEventAttributes.
 //
@@ -5496,6 +5848,172 @@ func (v *ContinueAsNewWorkflowExecutionDecisionAttributes) GetRetryPolicy() (o *
 	return
 }
 
+type DataBlob struct {
+	EncodingType *EncodingType `json:"EncodingType,omitempty"`
+	Data         []byte        `json:"Data,omitempty"`
+}
+
+// ToWire translates a DataBlob struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *DataBlob) ToWire() (wire.Value, error) {
+	var (
+		fields [2]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.EncodingType != nil {
+		w, err = v.EncodingType.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.Data != nil {
+		w, err = wire.NewValueBinary(v.Data), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _EncodingType_Read(w wire.Value) (EncodingType, error) {
+	var v EncodingType
+	err := v.FromWire(w)
+	return v, err
+}
+
+// FromWire deserializes a DataBlob struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a DataBlob struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v DataBlob
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *DataBlob) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TI32 {
+				var x EncodingType
+				x, err = _EncodingType_Read(field.Value)
+				v.EncodingType = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TBinary {
+				v.Data, err = field.Value.GetBinary(), error(nil)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a DataBlob
+// struct.
+func (v *DataBlob) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [2]string
+	i := 0
+	if v.EncodingType != nil {
+		fields[i] = fmt.Sprintf("EncodingType: %v", *(v.EncodingType))
+		i++
+	}
+	if v.Data != nil {
+		fields[i] = fmt.Sprintf("Data: %v", v.Data)
+		i++
+	}
+
+	return fmt.Sprintf("DataBlob{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _EncodingType_EqualsPtr(lhs, rhs *EncodingType) bool {
+	if lhs != nil && rhs != nil {
+
+		x := *lhs
+		y := *rhs
+		return x.Equals(y)
+	}
+	return lhs == nil && rhs == nil
+}
+
+// Equals returns true if all the fields of this DataBlob match the
+// provided DataBlob.
+//
+// This function performs a deep comparison.
+func (v *DataBlob) Equals(rhs *DataBlob) bool {
+	if !_EncodingType_EqualsPtr(v.EncodingType, rhs.EncodingType) {
+		return false
+	}
+	if !((v.Data == nil && rhs.Data == nil) || (v.Data != nil && rhs.Data != nil && bytes.Equal(v.Data, rhs.Data))) {
+		return false
+	}
+
+	return true
+}
+
+// GetEncodingType returns the value of EncodingType if it is set or its
+// zero value if it is unset.
+func (v *DataBlob) GetEncodingType() (o EncodingType) {
+	if v.EncodingType != nil {
+		return *v.EncodingType
+	}
+
+	return
+}
+
+// GetData returns the value of Data if it is set or its
+// zero value if it is unset.
+func (v *DataBlob) GetData() (o []byte) {
+	if v.Data != nil {
+		return v.Data
+	}
+
+	return
+}
+
 type Decision struct {
 	DecisionType                                             *DecisionType                                             `json:"decisionType,omitempty"`
 	ScheduleActivityTaskDecisionAttributes                   *ScheduleActivityTaskDecisionAttributes                   `json:"scheduleActivityTaskDecisionAttributes,omitempty"`
@@ -5510,6 +6028,7 @@ type Decision struct {
 	ContinueAsNewWorkflowExecutionDecisionAttributes         *ContinueAsNewWorkflowExecutionDecisionAttributes         `json:"continueAsNewWorkflowExecutionDecisionAttributes,omitempty"`
 	StartChildWorkflowExecutionDecisionAttributes            *StartChildWorkflowExecutionDecisionAttributes            `json:"startChildWorkflowExecutionDecisionAttributes,omitempty"`
 	SignalExternalWorkflowExecutionDecisionAttributes        *SignalExternalWorkflowExecutionDecisionAttributes        `json:"signalExternalWorkflowExecutionDecisionAttributes,omitempty"`
+	RespondWorkflowUpdateDecisionAttributes                  *RespondWorkflowUpdateDecisionAttributes                  `json:"respondWorkflowUpdateDecisionAttributes,omitempty"`
 }
 
 // ToWire translates a Decision struct into a Thrift-level intermediate
@@ -5529,7 +6048,7 @@ type Decision struct {
 //   }
 func (v *Decision) ToWire() (wire.Value, error) {
 	var (
-		fields [13]wire.Field
+		fields [14]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -5639,6 +6158,14 @@ func (v *Decision) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 110, Value: w}
 		i++
 	}
+	if v.RespondWorkflowUpdateDecisionAttributes != nil {
+		w, err = v.RespondWorkflowUpdateDecisionAttributes.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 120, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
@@ -5721,6 +6248,12 @@ func _SignalExternalWorkflowExecutionDecisionAttributes_Read(w wire.Value) (*Sig
 	return &v, err
 }
 
+func _RespondWorkflowUpdateDecisionAttributes_Read(w wire.Value) (*RespondWorkflowUpdateDecisionAttributes, error) {
+	var v RespondWorkflowUpdateDecisionAttributes
+	err := v.FromWire(w)
+	return &v, err
+}
+
 // FromWire deserializes a Decision struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
@@ -5848,6 +6381,14 @@ func (v *Decision) FromWire(w wire.Value) error {
 					return err
 				}
 
+			}
+		case 120:
+			if field.Value.Type() == wire.TStruct {
+				v.RespondWorkflowUpdateDecisionAttributes, err = _RespondWorkflowUpdateDecisionAttributes_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -5862,7 +6403,7 @@ func (v *Decision) String() string {
 		return "<nil>"
 	}
 
-	var fields [13]string
+	var fields [14]string
 	i := 0
 	if v.DecisionType != nil {
 		fields[i] = fmt.Sprintf("DecisionType: %v", *(v.DecisionType))
@@ -5916,6 +6457,10 @@ func (v *Decision) String() string {
 		fields[i] = fmt.Sprintf("SignalExternalWorkflowExecutionDecisionAttributes: %v", v.SignalExternalWorkflowExecutionDecisionAttributes)
 		i++
 	}
+	if v.RespondWorkflowUpdateDecisionAttributes != nil {
+		fields[i] = fmt.Sprintf("RespondWorkflowUpdateDecisionAttributes: %v", v.RespondWorkflowUpdateDecisionAttributes)
+		i++
+	}
 
 	return fmt.Sprintf("Decision{%v}", strings.Join(fields[:i], ", "))
 }
@@ -5974,6 +6519,9 @@ func (v *Decision) Equals(rhs *Decision) bool {
 	if !((v.SignalExternalWorkflowExecutionDecisionAttributes == nil && rhs.SignalExternalWorkflowExecutionDecisionAttributes == nil) || (v.SignalExternalWorkflowExecutionDecisionAttributes != nil && rhs.SignalExternalWorkflowExecutionDecisionAttributes != nil && v.SignalExternalWorkflowExecutionDecisionAttributes.Equals(rhs.SignalExternalWorkflowExecutionDecisionAttributes))) {
 		return false
 	}
+	if !((v.RespondWorkflowUpdateDecisionAttributes == nil && rhs.RespondWorkflowUpdateDecisionAttributes == nil) || (v.RespondWorkflowUpdateDecisionAttributes != nil && rhs.RespondWorkflowUpdateDecisionAttributes != nil && v.RespondWorkflowUpdateDecisionAttributes.Equals(rhs.RespondWorkflowUpdateDecisionAttributes))) {
+		return false
+	}
 
 	return true
 }
@@ -6108,6 +6656,16 @@ func (v *Decision) GetSignalExternalWorkflowExecutionDecisionAttributes() (o *Si
 	return
 }
 
+// GetRespondWorkflowUpdateDecisionAttributes returns the value of RespondWorkflowUpdateDecisionAttributes if it is set or its
+// zero value if it is unset.
+func (v *Decision) GetRespondWorkflowUpdateDecisionAttributes() (o *RespondWorkflowUpdateDecisionAttributes) {
+	if v.RespondWorkflowUpdateDecisionAttributes != nil {
+		return v.RespondWorkflowUpdateDecisionAttributes
+	}
+
+	return
+}
+
 type DecisionTaskCompletedEventAttributes struct {
 	ExecutionContext []byte  `json:"executionContext,omitempty"`
 	ScheduledEventId *int64  `json:"scheduledEventId,omitempty"`
@@ -6352,6 +6910,7 @@ const (
 	DecisionTaskFailedCauseForceCloseDecision                                  DecisionTaskFailedCause = 16
 	DecisionTaskFailedCauseFailoverCloseDecision                               DecisionTaskFailedCause = 17
 	DecisionTaskFailedCauseBadSignalInputSize                                  DecisionTaskFailedCause = 18
+	DecisionTaskFailedCauseBadRespondWorkflowUpdateAttributes                  DecisionTaskFailedCause = 19
 )
 
 // DecisionTaskFailedCause_Values returns all recognized values of DecisionTaskFailedCause.
@@ -6376,6 +6935,7 @@ func DecisionTaskFailedCause_Values() []DecisionTaskFailedCause {
 		DecisionTaskFailedCauseForceCloseDecision,
 		DecisionTaskFailedCauseFailoverCloseDecision,
 		DecisionTaskFailedCauseBadSignalInputSize,
+		DecisionTaskFailedCauseBadRespondWorkflowUpdateAttributes,
 	}
 }
 
@@ -6443,6 +7003,9 @@ func (v *DecisionTaskFailedCause) UnmarshalText(value []byte) error {
 	case "BAD_SIGNAL_INPUT_SIZE":
 		*v = DecisionTaskFailedCauseBadSignalInputSize
 		return nil
+	case "BAD_RESPOND_WORKFLOW_UPDATE_ATTRIBUTES":
+		*v = DecisionTaskFailedCauseBadRespondWorkflowUpdateAttributes
+		return nil
 	default:
 		return fmt.Errorf("unknown enum value %q for %q", value, "DecisionTaskFailedCause")
 	}
@@ -6494,6 +7057,8 @@ func (v DecisionTaskFailedCause) MarshalText() ([]byte, error) {
 		return []byte("FAILOVER_CLOSE_DECISION"), nil
 	case 18:
 		return []byte("BAD_SIGNAL_INPUT_SIZE"), nil
+	case 19:
+		return []byte("BAD_RESPOND_WORKFLOW_UPDATE_ATTRIBUTES"), nil
 	}
 	return []byte(strconv.FormatInt(int64(v), 10)), nil
 }
@@ -6572,6 +7137,8 @@ func (v DecisionTaskFailedCause) String() string {
 		return "FAILOVER_CLOSE_DECISION"
 	case 18:
 		return "BAD_SIGNAL_INPUT_SIZE"
+	case 19:
+		return "BAD_RESPOND_WORKFLOW_UPDATE_ATTRIBUTES"
 	}
 	return fmt.Sprintf("DecisionTaskFailedCause(%d)", w)
 }
@@ -6628,6 +7195,8 @@ func (v DecisionTaskFailedCause) MarshalJSON() ([]byte, error) {
 		return ([]byte)("\"FAILOVER_CLOSE_DECISION\""), nil
 	case 18:
 		return ([]byte)("\"BAD_SIGNAL_INPUT_SIZE\""), nil
+	case 19:
+		return ([]byte)("\"BAD_RESPOND_WORKFLOW_UPDATE_ATTRIBUTES\""), nil
 	}
 	return ([]byte)(strconv.FormatInt(int64(v), 10)), nil
 }
@@ -7519,6 +8088,7 @@ const (
 	DecisionTypeContinueAsNewWorkflowExecution         DecisionType = 9
 	DecisionTypeStartChildWorkflowExecution            DecisionType = 10
 	DecisionTypeSignalExternalWorkflowExecution        DecisionType = 11
+	DecisionTypeRespondWorkflowUpdate                  DecisionType = 12
 )
 
 // DecisionType_Values returns all recognized values of DecisionType.
@@ -7536,6 +8106,7 @@ func DecisionType_Values() []DecisionType {
 		DecisionTypeContinueAsNewWorkflowExecution,
 		DecisionTypeStartChildWorkflowExecution,
 		DecisionTypeSignalExternalWorkflowExecution,
+		DecisionTypeRespondWorkflowUpdate,
 	}
 }
 
@@ -7582,6 +8153,9 @@ func (v *DecisionType) UnmarshalText(value []byte) error {
 	case "SignalExternalWorkflowExecution":
 		*v = DecisionTypeSignalExternalWorkflowExecution
 		return nil
+	case "RespondWorkflowUpdate":
+		*v = DecisionTypeRespondWorkflowUpdate
+		return nil
 	default:
 		return fmt.Errorf("unknown enum value %q for %q", value, "DecisionType")
 	}
@@ -7619,6 +8193,8 @@ func (v DecisionType) MarshalText() ([]byte, error) {
 		return []byte("StartChildWorkflowExecution"), nil
 	case 11:
 		return []byte("SignalExternalWorkflowExecution"), nil
+	case 12:
+		return []byte("RespondWorkflowUpdate"), nil
 	}
 	return []byte(strconv.FormatInt(int64(v), 10)), nil
 }
@@ -7683,6 +8259,8 @@ func (v DecisionType) String() string {
 		return "StartChildWorkflowExecution"
 	case 11:
 		return "SignalExternalWorkflowExecution"
+	case 12:
+		return "RespondWorkflowUpdate"
 	}
 	return fmt.Sprintf("DecisionType(%d)", w)
 }
@@ -7725,6 +8303,8 @@ func (v DecisionType) MarshalJSON() ([]byte, error) {
 		return ([]byte)("\"StartChildWorkflowExecution\""), nil
 	case 11:
 		return ([]byte)("\"SignalExternalWorkflowExecution\""), nil
+	case 12:
+		return ([]byte)("\"RespondWorkflowUpdate\""), nil
 	}
 	return ([]byte)(strconv.FormatInt(int64(v), 10)), nil
 }
@@ -8003,8 +8583,39 @@ type DescribeDomainResponse struct {
 	ReplicationConfiguration *DomainReplicationConfiguration `json:"replicationConfiguration,omitempty"`
 	FailoverVersion          *int64                          `json:"failoverVersion,omitempty"`
 	IsGlobalDomain           *bool                           `json:"isGlobalDomain,omitempty"`
+	FailoverInfo             *FailoverInfo                   `json:"failoverInfo,omitempty"`
+	FailoverHistory          []*DomainFailoverEvent          `json:"failoverHistory,omitempty"`
+}
+
+type _List_DomainFailoverEvent_ValueList []*DomainFailoverEvent
+
+func (v _List_DomainFailoverEvent_ValueList) ForEach(f func(wire.Value) error) error {
+	for i, x := range v {
+		if x == nil {
+			return fmt.Errorf("invalid [%v]: value is nil", i)
+		}
+		w, err := x.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v _List_DomainFailoverEvent_ValueList) Size() int {
+	return len(v)
 }
 
+func (_List_DomainFailoverEvent_ValueList) ValueType() wire.Type {
+	return wire.TStruct
+}
+
+func (_List_DomainFailoverEvent_ValueList) Close() {}
+
 // ToWire translates a DescribeDomainResponse struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
@@ -8022,7 +8633,7 @@ type DescribeDomainResponse struct {
 //   }
 func (v *DescribeDomainResponse) ToWire() (wire.Value, error) {
 	var (
-		fields [5]wire.Field
+		fields [7]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -8068,6 +8679,22 @@ func (v *DescribeDomainResponse) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 50, Value: w}
 		i++
 	}
+	if v.FailoverInfo != nil {
+		w, err = v.FailoverInfo.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 60, Value: w}
+		i++
+	}
+	if v.FailoverHistory != nil {
+		w, err = wire.NewValueList(_List_DomainFailoverEvent_ValueList(v.FailoverHistory)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 70, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
@@ -8090,6 +8717,36 @@ func _DomainReplicationConfiguration_Read(w wire.Value) (*DomainReplicationConfi
 	return &v, err
 }
 
+func _FailoverInfo_Read(w wire.Value) (*FailoverInfo, error) {
+	var v FailoverInfo
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _DomainFailoverEvent_Read(w wire.Value) (*DomainFailoverEvent, error) {
+	var v DomainFailoverEvent
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _List_DomainFailoverEvent_Read(l wire.ValueList) ([]*DomainFailoverEvent, error) {
+	if l.ValueType() != wire.TStruct {
+		return nil, nil
+	}
+
+	o := make([]*DomainFailoverEvent, 0, l.Size())
+	err := l.ForEach(func(x wire.Value) error {
+		i, err := _DomainFailoverEvent_Read(x)
+		if err != nil {
+			return err
+		}
+		o = append(o, i)
+		return nil
+	})
+	l.Close()
+	return o, err
+}
+
 // FromWire deserializes a DescribeDomainResponse struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
@@ -8155,6 +8812,22 @@ func (v *DescribeDomainResponse) FromWire(w wire.Value) error {
 					return err
 				}
 
+			}
+		case 60:
+			if field.Value.Type() == wire.TStruct {
+				v.FailoverInfo, err = _FailoverInfo_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 70:
+			if field.Value.Type() == wire.TList {
+				v.FailoverHistory, err = _List_DomainFailoverEvent_Read(field.Value.GetList())
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -8169,7 +8842,7 @@ func (v *DescribeDomainResponse) String() string {
 		return "<nil>"
 	}
 
-	var fields [5]string
+	var fields [7]string
 	i := 0
 	if v.DomainInfo != nil {
 		fields[i] = fmt.Sprintf("DomainInfo: %v", v.DomainInfo)
@@ -8191,6 +8864,14 @@ func (v *DescribeDomainResponse) String() string {
 		fields[i] = fmt.Sprintf("IsGlobalDomain: %v", *(v.IsGlobalDomain))
 		i++
 	}
+	if v.FailoverInfo != nil {
+		fields[i] = fmt.Sprintf("FailoverInfo: %v", v.FailoverInfo)
+		i++
+	}
+	if v.FailoverHistory != nil {
+		fields[i] = fmt.Sprintf("FailoverHistory: %v", v.FailoverHistory)
+		i++
+	}
 
 	return fmt.Sprintf("DescribeDomainResponse{%v}", strings.Join(fields[:i], ", "))
 }
@@ -8205,6 +8886,21 @@ func _Bool_EqualsPtr(lhs, rhs *bool) bool {
 	return lhs == nil && rhs == nil
 }
 
+func _List_DomainFailoverEvent_Equals(lhs, rhs []*DomainFailoverEvent) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !lv.Equals(rv) {
+			return false
+		}
+	}
+
+	return true
+}
+
 // Equals returns true if all the fields of this DescribeDomainResponse match the
 // provided DescribeDomainResponse.
 //
@@ -8225,6 +8921,12 @@ func (v *DescribeDomainResponse) Equals(rhs *DescribeDomainResponse) bool {
 	if !_Bool_EqualsPtr(v.IsGlobalDomain, rhs.IsGlobalDomain) {
 		return false
 	}
+	if !((v.FailoverInfo == nil && rhs.FailoverInfo == nil) || (v.FailoverInfo != nil && rhs.FailoverInfo != nil && v.FailoverInfo.Equals(rhs.FailoverInfo))) {
+		return false
+	}
+	if !((v.FailoverHistory == nil && rhs.FailoverHistory == nil) || (v.FailoverHistory != nil && rhs.FailoverHistory != nil && _List_DomainFailoverEvent_Equals(v.FailoverHistory, rhs.FailoverHistory))) {
+		return false
+	}
 
 	return true
 }
@@ -8279,6 +8981,26 @@ func (v *DescribeDomainResponse) GetIsGlobalDomain() (o bool) {
 	return
 }
 
+// GetFailoverInfo returns the value of FailoverInfo if it is set or its
+// zero value if it is unset.
+func (v *DescribeDomainResponse) GetFailoverInfo() (o *FailoverInfo) {
+	if v.FailoverInfo != nil {
+		return v.FailoverInfo
+	}
+
+	return
+}
+
+// GetFailoverHistory returns the value of FailoverHistory if it is set or its
+// zero value if it is unset.
+func (v *DescribeDomainResponse) GetFailoverHistory() (o []*DomainFailoverEvent) {
+	if v.FailoverHistory != nil {
+		return v.FailoverHistory
+	}
+
+	return
+}
+
 type DescribeHistoryHostRequest struct {
 	HostAddress      *string            `json:"hostAddress,omitempty"`
 	ShardIdForHost   *int32             `json:"shardIdForHost,omitempty"`
@@ -8989,7 +9711,8 @@ func (v *DescribeTaskListRequest) GetTaskListType() (o TaskListType) {
 }
 
 type DescribeTaskListResponse struct {
-	Pollers []*PollerInfo `json:"pollers,omitempty"`
+	Pollers       []*PollerInfo  `json:"pollers,omitempty"`
+	StaleReadInfo *StaleReadInfo `json:"staleReadInfo,omitempty"`
 }
 
 type _List_PollerInfo_ValueList []*PollerInfo
@@ -9038,7 +9761,7 @@ func (_List_PollerInfo_ValueList) Close() {}
 //   }
 func (v *DescribeTaskListResponse) ToWire() (wire.Value, error) {
 	var (
-		fields [1]wire.Field
+		fields [2]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -9052,6 +9775,14 @@ func (v *DescribeTaskListResponse) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 10, Value: w}
 		i++
 	}
+	if v.StaleReadInfo != nil {
+		w, err = v.StaleReadInfo.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
@@ -9080,6 +9811,12 @@ func _List_PollerInfo_Read(l wire.ValueList) ([]*PollerInfo, error) {
 	return o, err
 }
 
+func _StaleReadInfo_Read(w wire.Value) (*StaleReadInfo, error) {
+	var v StaleReadInfo
+	err := v.FromWire(w)
+	return &v, err
+}
+
 // FromWire deserializes a DescribeTaskListResponse struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
@@ -9109,6 +9846,14 @@ func (v *DescribeTaskListResponse) FromWire(w wire.Value) error {
 					return err
 				}
 
+			}
+		case 20:
+			if field.Value.Type() == wire.TStruct {
+				v.StaleReadInfo, err = _StaleReadInfo_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -9123,12 +9868,16 @@ func (v *DescribeTaskListResponse) String() string {
 		return "<nil>"
 	}
 
-	var fields [1]string
+	var fields [2]string
 	i := 0
 	if v.Pollers != nil {
 		fields[i] = fmt.Sprintf("Pollers: %v", v.Pollers)
 		i++
 	}
+	if v.StaleReadInfo != nil {
+		fields[i] = fmt.Sprintf("StaleReadInfo: %v", v.StaleReadInfo)
+		i++
+	}
 
 	return fmt.Sprintf("DescribeTaskListResponse{%v}", strings.Join(fields[:i], ", "))
 }
@@ -9156,6 +9905,9 @@ func (v *DescribeTaskListResponse) Equals(rhs *DescribeTaskListResponse) bool {
 	if !((v.Pollers == nil && rhs.Pollers == nil) || (v.Pollers != nil && rhs.Pollers != nil && _List_PollerInfo_Equals(v.Pollers, rhs.Pollers))) {
 		return false
 	}
+	if !((v.StaleReadInfo == nil && rhs.StaleReadInfo == nil) || (v.StaleReadInfo != nil && rhs.StaleReadInfo != nil && v.StaleReadInfo.Equals(rhs.StaleReadInfo))) {
+		return false
+	}
 
 	return true
 }
@@ -9170,6 +9922,16 @@ func (v *DescribeTaskListResponse) GetPollers() (o []*PollerInfo) {
 	return
 }
 
+// GetStaleReadInfo returns the value of StaleReadInfo if it is set or its
+// zero value if it is unset.
+func (v *DescribeTaskListResponse) GetStaleReadInfo() (o *StaleReadInfo) {
+	if v.StaleReadInfo != nil {
+		return v.StaleReadInfo
+	}
+
+	return
+}
+
 type DescribeWorkflowExecutionRequest struct {
 	Domain    *string            `json:"domain,omitempty"`
 	Execution *WorkflowExecution `json:"execution,omitempty"`
@@ -9324,6 +10086,7 @@ type DescribeWorkflowExecutionResponse struct {
 	ExecutionConfiguration *WorkflowExecutionConfiguration `json:"executionConfiguration,omitempty"`
 	WorkflowExecutionInfo  *WorkflowExecutionInfo          `json:"workflowExecutionInfo,omitempty"`
 	PendingActivities      []*PendingActivityInfo          `json:"pendingActivities,omitempty"`
+	StaleReadInfo          *StaleReadInfo                  `json:"staleReadInfo,omitempty"`
 }
 
 type _List_PendingActivityInfo_ValueList []*PendingActivityInfo
@@ -9372,7 +10135,7 @@ func (_List_PendingActivityInfo_ValueList) Close() {}
 //   }
 func (v *DescribeWorkflowExecutionResponse) ToWire() (wire.Value, error) {
 	var (
-		fields [3]wire.Field
+		fields [4]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -9402,6 +10165,14 @@ func (v *DescribeWorkflowExecutionResponse) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 30, Value: w}
 		i++
 	}
+	if v.StaleReadInfo != nil {
+		w, err = v.StaleReadInfo.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 40, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
@@ -9487,6 +10258,14 @@ func (v *DescribeWorkflowExecutionResponse) FromWire(w wire.Value) error {
 					return err
 				}
 
+			}
+		case 40:
+			if field.Value.Type() == wire.TStruct {
+				v.StaleReadInfo, err = _StaleReadInfo_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -9501,7 +10280,7 @@ func (v *DescribeWorkflowExecutionResponse) String() string {
 		return "<nil>"
 	}
 
-	var fields [3]string
+	var fields [4]string
 	i := 0
 	if v.ExecutionConfiguration != nil {
 		fields[i] = fmt.Sprintf("ExecutionConfiguration: %v", v.ExecutionConfiguration)
@@ -9515,6 +10294,10 @@ func (v *DescribeWorkflowExecutionResponse) String() string {
 		fields[i] = fmt.Sprintf("PendingActivities: %v", v.PendingActivities)
 		i++
 	}
+	if v.StaleReadInfo != nil {
+		fields[i] = fmt.Sprintf("StaleReadInfo: %v", v.StaleReadInfo)
+		i++
+	}
 
 	return fmt.Sprintf("DescribeWorkflowExecutionResponse{%v}", strings.Join(fields[:i], ", "))
 }
@@ -9548,6 +10331,9 @@ func (v *DescribeWorkflowExecutionResponse) Equals(rhs *DescribeWorkflowExecutio
 	if !((v.PendingActivities == nil && rhs.PendingActivities == nil) || (v.PendingActivities != nil && rhs.PendingActivities != nil && _List_PendingActivityInfo_Equals(v.PendingActivities, rhs.PendingActivities))) {
 		return false
 	}
+	if !((v.StaleReadInfo == nil && rhs.StaleReadInfo == nil) || (v.StaleReadInfo != nil && rhs.StaleReadInfo != nil && v.StaleReadInfo.Equals(rhs.StaleReadInfo))) {
+		return false
+	}
 
 	return true
 }
@@ -9582,6 +10368,16 @@ func (v *DescribeWorkflowExecutionResponse) GetPendingActivities() (o []*Pending
 	return
 }
 
+// GetStaleReadInfo returns the value of StaleReadInfo if it is set or its
+// zero value if it is unset.
+func (v *DescribeWorkflowExecutionResponse) GetStaleReadInfo() (o *StaleReadInfo) {
+	if v.StaleReadInfo != nil {
+		return v.StaleReadInfo
+	}
+
+	return
+}
+
 type DomainAlreadyExistsError struct {
 	Message string `json:"message,required"`
 }
@@ -10000,6 +10796,302 @@ func (v *DomainConfiguration) GetEmitMetric() (o bool) {
 	return
 }
 
+type DomainFailoverEvent struct {
+	FailoverTimestamp *int64  `json:"failoverTimestamp,omitempty"`
+	FromCluster       *string `json:"fromCluster,omitempty"`
+	ToCluster         *string `json:"toCluster,omitempty"`
+	FailoverVersion   *int64  `json:"failoverVersion,omitempty"`
+	Identity          *string `json:"identity,omitempty"`
+	Reason            *string `json:"reason,omitempty"`
+}
+
+// ToWire translates a DomainFailoverEvent struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *DomainFailoverEvent) ToWire() (wire.Value, error) {
+	var (
+		fields [6]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.FailoverTimestamp != nil {
+		w, err = wire.NewValueI64(*(v.FailoverTimestamp)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.FromCluster != nil {
+		w, err = wire.NewValueString(*(v.FromCluster)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+	if v.ToCluster != nil {
+		w, err = wire.NewValueString(*(v.ToCluster)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+	if v.FailoverVersion != nil {
+		w, err = wire.NewValueI64(*(v.FailoverVersion)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 40, Value: w}
+		i++
+	}
+	if v.Identity != nil {
+		w, err = wire.NewValueString(*(v.Identity)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 50, Value: w}
+		i++
+	}
+	if v.Reason != nil {
+		w, err = wire.NewValueString(*(v.Reason)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 60, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a DomainFailoverEvent struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a DomainFailoverEvent struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v DomainFailoverEvent
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *DomainFailoverEvent) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.FailoverTimestamp = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.FromCluster = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 30:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.ToCluster = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 40:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.FailoverVersion = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 50:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Identity = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 60:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Reason = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a DomainFailoverEvent
+// struct.
+func (v *DomainFailoverEvent) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [6]string
+	i := 0
+	if v.FailoverTimestamp != nil {
+		fields[i] = fmt.Sprintf("FailoverTimestamp: %v", *(v.FailoverTimestamp))
+		i++
+	}
+	if v.FromCluster != nil {
+		fields[i] = fmt.Sprintf("FromCluster: %v", *(v.FromCluster))
+		i++
+	}
+	if v.ToCluster != nil {
+		fields[i] = fmt.Sprintf("ToCluster: %v", *(v.ToCluster))
+		i++
+	}
+	if v.FailoverVersion != nil {
+		fields[i] = fmt.Sprintf("FailoverVersion: %v", *(v.FailoverVersion))
+		i++
+	}
+	if v.Identity != nil {
+		fields[i] = fmt.Sprintf("Identity: %v", *(v.Identity))
+		i++
+	}
+	if v.Reason != nil {
+		fields[i] = fmt.Sprintf("Reason: %v", *(v.Reason))
+		i++
+	}
+
+	return fmt.Sprintf("DomainFailoverEvent{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this DomainFailoverEvent match the
+// provided DomainFailoverEvent.
+//
+// This function performs a deep comparison.
+func (v *DomainFailoverEvent) Equals(rhs *DomainFailoverEvent) bool {
+	if !_I64_EqualsPtr(v.FailoverTimestamp, rhs.FailoverTimestamp) {
+		return false
+	}
+	if !_String_EqualsPtr(v.FromCluster, rhs.FromCluster) {
+		return false
+	}
+	if !_String_EqualsPtr(v.ToCluster, rhs.ToCluster) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.FailoverVersion, rhs.FailoverVersion) {
+		return false
+	}
+	if !_String_EqualsPtr(v.Identity, rhs.Identity) {
+		return false
+	}
+	if !_String_EqualsPtr(v.Reason, rhs.Reason) {
+		return false
+	}
+
+	return true
+}
+
+// GetFailoverTimestamp returns the value of FailoverTimestamp if it is set or its
+// zero value if it is unset.
+func (v *DomainFailoverEvent) GetFailoverTimestamp() (o int64) {
+	if v.FailoverTimestamp != nil {
+		return *v.FailoverTimestamp
+	}
+
+	return
+}
+
+// GetFromCluster returns the value of FromCluster if it is set or its
+// zero value if it is unset.
+func (v *DomainFailoverEvent) GetFromCluster() (o string) {
+	if v.FromCluster != nil {
+		return *v.FromCluster
+	}
+
+	return
+}
+
+// GetToCluster returns the value of ToCluster if it is set or its
+// zero value if it is unset.
+func (v *DomainFailoverEvent) GetToCluster() (o string) {
+	if v.ToCluster != nil {
+		return *v.ToCluster
+	}
+
+	return
+}
+
+// GetFailoverVersion returns the value of FailoverVersion if it is set or its
+// zero value if it is unset.
+func (v *DomainFailoverEvent) GetFailoverVersion() (o int64) {
+	if v.FailoverVersion != nil {
+		return *v.FailoverVersion
+	}
+
+	return
+}
+
+// GetIdentity returns the value of Identity if it is set or its
+// zero value if it is unset.
+func (v *DomainFailoverEvent) GetIdentity() (o string) {
+	if v.Identity != nil {
+		return *v.Identity
+	}
+
+	return
+}
+
+// GetReason returns the value of Reason if it is set or its
+// zero value if it is unset.
+func (v *DomainFailoverEvent) GetReason() (o string) {
+	if v.Reason != nil {
+		return *v.Reason
+	}
+
+	return
+}
+
 type DomainInfo struct {
 	Name        *string           `json:"name,omitempty"`
 	Status      *DomainStatus     `json:"status,omitempty"`
@@ -10938,6 +12030,157 @@ func (v *DomainStatus) UnmarshalJSON(text []byte) error {
 	}
 }
 
+type EncodingType int32
+
+const (
+	EncodingTypeThriftRW EncodingType = 0
+	EncodingTypeJSON     EncodingType = 1
+)
+
+// EncodingType_Values returns all recognized values of EncodingType.
+func EncodingType_Values() []EncodingType {
+	return []EncodingType{
+		EncodingTypeThriftRW,
+		EncodingTypeJSON,
+	}
+}
+
+// UnmarshalText tries to decode EncodingType from a byte slice
+// containing its name.
+//
+//   var v EncodingType
+//   err := v.UnmarshalText([]byte("ThriftRW"))
+func (v *EncodingType) UnmarshalText(value []byte) error {
+	switch string(value) {
+	case "ThriftRW":
+		*v = EncodingTypeThriftRW
+		return nil
+	case "JSON":
+		*v = EncodingTypeJSON
+		return nil
+	default:
+		return fmt.Errorf("unknown enum value %q for %q", value, "EncodingType")
+	}
+}
+
+// MarshalText encodes EncodingType to text.
+//
+// If the enum value is recognized, its name is returned. Otherwise,
+// its integer value is returned.
+//
+// This implements the TextMarshaler interface.
+func (v EncodingType) MarshalText() ([]byte, error) {
+	switch int32(v) {
+	case 0:
+		return []byte("ThriftRW"), nil
+	case 1:
+		return []byte("JSON"), nil
+	}
+	return []byte(strconv.FormatInt(int64(v), 10)), nil
+}
+
+// Ptr returns a pointer to this enum value.
+func (v EncodingType) Ptr() *EncodingType {
+	return &v
+}
+
+// ToWire translates EncodingType into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// Enums are represented as 32-bit integers over the wire.
+func (v EncodingType) ToWire() (wire.Value, error) {
+	return wire.NewValueI32(int32(v)), nil
+}
+
+// FromWire deserializes EncodingType from its Thrift-level
+// representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TI32)
+//   if err != nil {
+//     return EncodingType(0), err
+//   }
+//
+//   var v EncodingType
+//   if err := v.FromWire(x); err != nil {
+//     return EncodingType(0), err
+//   }
+//   return v, nil
+func (v *EncodingType) FromWire(w wire.Value) error {
+	*v = (EncodingType)(w.GetI32())
+	return nil
+}
+
+// String returns a readable string representation of EncodingType.
+func (v EncodingType) String() string {
+	w := int32(v)
+	switch w {
+	case 0:
+		return "ThriftRW"
+	case 1:
+		return "JSON"
+	}
+	return fmt.Sprintf("EncodingType(%d)", w)
+}
+
+// Equals returns true if this EncodingType value matches the provided
+// value.
+func (v EncodingType) Equals(rhs EncodingType) bool {
+	return v == rhs
+}
+
+// MarshalJSON serializes EncodingType into JSON.
+//
+// If the enum value is recognized, its name is returned. Otherwise,
+// its integer value is returned.
+//
+// This implements json.Marshaler.
+func (v EncodingType) MarshalJSON() ([]byte, error) {
+	switch int32(v) {
+	case 0:
+		return ([]byte)("\"ThriftRW\""), nil
+	case 1:
+		return ([]byte)("\"JSON\""), nil
+	}
+	return ([]byte)(strconv.FormatInt(int64(v), 10)), nil
+}
+
+// UnmarshalJSON attempts to decode EncodingType from its JSON
+// representation.
+//
+// This implementation supports both, numeric and string inputs. If a
+// string is provided, it must be a known enum name.
+//
+// This implements json.Unmarshaler.
+func (v *EncodingType) UnmarshalJSON(text []byte) error {
+	d := json.NewDecoder(bytes.NewReader(text))
+	d.UseNumber()
+	t, err := d.Token()
+	if err != nil {
+		return err
+	}
+
+	switch w := t.(type) {
+	case json.Number:
+		x, err := w.Int64()
+		if err != nil {
+			return err
+		}
+		if x > math.MaxInt32 {
+			return fmt.Errorf("enum overflow from JSON %q for %q", text, "EncodingType")
+		}
+		if x < math.MinInt32 {
+			return fmt.Errorf("enum underflow from JSON %q for %q", text, "EncodingType")
+		}
+		*v = (EncodingType)(x)
+		return nil
+	case string:
+		return v.UnmarshalText([]byte(w))
+	default:
+		return fmt.Errorf("invalid JSON value %q (%T) to unmarshal into %q", t, t, "EncodingType")
+	}
+}
+
 type EntityNotExistsError struct {
 	Message string `json:"message,required"`
 }
@@ -11096,6 +12339,8 @@ const (
 	EventTypeSignalExternalWorkflowExecutionInitiated        EventType = 38
 	EventTypeSignalExternalWorkflowExecutionFailed           EventType = 39
 	EventTypeExternalWorkflowExecutionSignaled               EventType = 40
+	EventTypeActivityTaskRedirected                          EventType = 41
+	EventTypeWorkflowExecutionUpdateAccepted                 EventType = 42
 )
 
 // EventType_Values returns all recognized values of EventType.
@@ -11142,6 +12387,8 @@ func EventType_Values() []EventType {
 		EventTypeSignalExternalWorkflowExecutionInitiated,
 		EventTypeSignalExternalWorkflowExecutionFailed,
 		EventTypeExternalWorkflowExecutionSignaled,
+		EventTypeActivityTaskRedirected,
+		EventTypeWorkflowExecutionUpdateAccepted,
 	}
 }
 
@@ -11275,6 +12522,12 @@ func (v *EventType) UnmarshalText(value []byte) error {
 	case "ExternalWorkflowExecutionSignaled":
 		*v = EventTypeExternalWorkflowExecutionSignaled
 		return nil
+	case "ActivityTaskRedirected":
+		*v = EventTypeActivityTaskRedirected
+		return nil
+	case "WorkflowExecutionUpdateAccepted":
+		*v = EventTypeWorkflowExecutionUpdateAccepted
+		return nil
 	default:
 		return fmt.Errorf("unknown enum value %q for %q", value, "EventType")
 	}
@@ -11370,6 +12623,10 @@ func (v EventType) MarshalText() ([]byte, error) {
 		return []byte("SignalExternalWorkflowExecutionFailed"), nil
 	case 40:
 		return []byte("ExternalWorkflowExecutionSignaled"), nil
+	case 41:
+		return []byte("ActivityTaskRedirected"), nil
+	case 42:
+		return []byte("WorkflowExecutionUpdateAccepted"), nil
 	}
 	return []byte(strconv.FormatInt(int64(v), 10)), nil
 }
@@ -11492,6 +12749,10 @@ func (v EventType) String() string {
 		return "SignalExternalWorkflowExecutionFailed"
 	case 40:
 		return "ExternalWorkflowExecutionSignaled"
+	case 41:
+		return "ActivityTaskRedirected"
+	case 42:
+		return "WorkflowExecutionUpdateAccepted"
 	}
 	return fmt.Sprintf("EventType(%d)", w)
 }
@@ -11592,6 +12853,10 @@ func (v EventType) MarshalJSON() ([]byte, error) {
 		return ([]byte)("\"SignalExternalWorkflowExecutionFailed\""), nil
 	case 40:
 		return ([]byte)("\"ExternalWorkflowExecutionSignaled\""), nil
+	case 41:
+		return ([]byte)("\"ActivityTaskRedirected\""), nil
+	case 42:
+		return ([]byte)("\"WorkflowExecutionUpdateAccepted\""), nil
 	}
 	return ([]byte)(strconv.FormatInt(int64(v), 10)), nil
 }
@@ -12188,6 +13453,158 @@ func (v *FailWorkflowExecutionDecisionAttributes) GetDetails() (o []byte) {
 	return
 }
 
+type FailoverInfo struct {
+	PendingActiveClusterName *string `json:"pendingActiveClusterName,omitempty"`
+	FailoverEndTimestamp     *int64  `json:"failoverEndTimestamp,omitempty"`
+}
+
+// ToWire translates a FailoverInfo struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *FailoverInfo) ToWire() (wire.Value, error) {
+	var (
+		fields [2]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.PendingActiveClusterName != nil {
+		w, err = wire.NewValueString(*(v.PendingActiveClusterName)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.FailoverEndTimestamp != nil {
+		w, err = wire.NewValueI64(*(v.FailoverEndTimestamp)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a FailoverInfo struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a FailoverInfo struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v FailoverInfo
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *FailoverInfo) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.PendingActiveClusterName = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.FailoverEndTimestamp = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a FailoverInfo
+// struct.
+func (v *FailoverInfo) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [2]string
+	i := 0
+	if v.PendingActiveClusterName != nil {
+		fields[i] = fmt.Sprintf("PendingActiveClusterName: %v", *(v.PendingActiveClusterName))
+		i++
+	}
+	if v.FailoverEndTimestamp != nil {
+		fields[i] = fmt.Sprintf("FailoverEndTimestamp: %v", *(v.FailoverEndTimestamp))
+		i++
+	}
+
+	return fmt.Sprintf("FailoverInfo{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this FailoverInfo match the
+// provided FailoverInfo.
+//
+// This function performs a deep comparison.
+func (v *FailoverInfo) Equals(rhs *FailoverInfo) bool {
+	if !_String_EqualsPtr(v.PendingActiveClusterName, rhs.PendingActiveClusterName) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.FailoverEndTimestamp, rhs.FailoverEndTimestamp) {
+		return false
+	}
+
+	return true
+}
+
+// GetPendingActiveClusterName returns the value of PendingActiveClusterName if it is set or its
+// zero value if it is unset.
+func (v *FailoverInfo) GetPendingActiveClusterName() (o string) {
+	if v.PendingActiveClusterName != nil {
+		return *v.PendingActiveClusterName
+	}
+
+	return
+}
+
+// GetFailoverEndTimestamp returns the value of FailoverEndTimestamp if it is set or its
+// zero value if it is unset.
+func (v *FailoverInfo) GetFailoverEndTimestamp() (o int64) {
+	if v.FailoverEndTimestamp != nil {
+		return *v.FailoverEndTimestamp
+	}
+
+	return
+}
+
 type GetWorkflowExecutionHistoryRequest struct {
 	Domain                 *string                 `json:"domain,omitempty"`
 	Execution              *WorkflowExecution      `json:"execution,omitempty"`
@@ -12195,6 +13612,8 @@ type GetWorkflowExecutionHistoryRequest struct {
 	NextPageToken          []byte                  `json:"nextPageToken,omitempty"`
 	WaitForNewEvent        *bool                   `json:"waitForNewEvent,omitempty"`
 	HistoryEventFilterType *HistoryEventFilterType `json:"HistoryEventFilterType,omitempty"`
+	ReverseOrder           *bool                   `json:"reverseOrder,omitempty"`
+	LastEventCount         *int32                  `json:"lastEventCount,omitempty"`
 }
 
 // ToWire translates a GetWorkflowExecutionHistoryRequest struct into a Thrift-level intermediate
@@ -12214,7 +13633,7 @@ type GetWorkflowExecutionHistoryRequest struct {
 //   }
 func (v *GetWorkflowExecutionHistoryRequest) ToWire() (wire.Value, error) {
 	var (
-		fields [6]wire.Field
+		fields [8]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -12268,6 +13687,22 @@ func (v *GetWorkflowExecutionHistoryRequest) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 60, Value: w}
 		i++
 	}
+	if v.ReverseOrder != nil {
+		w, err = wire.NewValueBool(*(v.ReverseOrder)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 70, Value: w}
+		i++
+	}
+	if v.LastEventCount != nil {
+		w, err = wire.NewValueI32(*(v.LastEventCount)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 80, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
@@ -12355,6 +13790,26 @@ func (v *GetWorkflowExecutionHistoryRequest) FromWire(w wire.Value) error {
 					return err
 				}
 
+			}
+		case 70:
+			if field.Value.Type() == wire.TBool {
+				var x bool
+				x, err = field.Value.GetBool(), error(nil)
+				v.ReverseOrder = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 80:
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.LastEventCount = &x
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -12369,7 +13824,7 @@ func (v *GetWorkflowExecutionHistoryRequest) String() string {
 		return "<nil>"
 	}
 
-	var fields [6]string
+	var fields [8]string
 	i := 0
 	if v.Domain != nil {
 		fields[i] = fmt.Sprintf("Domain: %v", *(v.Domain))
@@ -12395,6 +13850,14 @@ func (v *GetWorkflowExecutionHistoryRequest) String() string {
 		fields[i] = fmt.Sprintf("HistoryEventFilterType: %v", *(v.HistoryEventFilterType))
 		i++
 	}
+	if v.ReverseOrder != nil {
+		fields[i] = fmt.Sprintf("ReverseOrder: %v", *(v.ReverseOrder))
+		i++
+	}
+	if v.LastEventCount != nil {
+		fields[i] = fmt.Sprintf("LastEventCount: %v", *(v.LastEventCount))
+		i++
+	}
 
 	return fmt.Sprintf("GetWorkflowExecutionHistoryRequest{%v}", strings.Join(fields[:i], ", "))
 }
@@ -12432,6 +13895,12 @@ func (v *GetWorkflowExecutionHistoryRequest) Equals(rhs *GetWorkflowExecutionHis
 	if !_HistoryEventFilterType_EqualsPtr(v.HistoryEventFilterType, rhs.HistoryEventFilterType) {
 		return false
 	}
+	if !_Bool_EqualsPtr(v.ReverseOrder, rhs.ReverseOrder) {
+		return false
+	}
+	if !_I32_EqualsPtr(v.LastEventCount, rhs.LastEventCount) {
+		return false
+	}
 
 	return true
 }
@@ -12496,9 +13965,30 @@ func (v *GetWorkflowExecutionHistoryRequest) GetHistoryEventFilterType() (o Hist
 	return
 }
 
+// GetReverseOrder returns the value of ReverseOrder if it is set or its
+// zero value if it is unset.
+func (v *GetWorkflowExecutionHistoryRequest) GetReverseOrder() (o bool) {
+	if v.ReverseOrder != nil {
+		return *v.ReverseOrder
+	}
+
+	return
+}
+
+// GetLastEventCount returns the value of LastEventCount if it is set or its
+// zero value if it is unset.
+func (v *GetWorkflowExecutionHistoryRequest) GetLastEventCount() (o int32) {
+	if v.LastEventCount != nil {
+		return *v.LastEventCount
+	}
+
+	return
+}
+
 type GetWorkflowExecutionHistoryResponse struct {
-	History       *History `json:"history,omitempty"`
-	NextPageToken []byte   `json:"nextPageToken,omitempty"`
+	History       *History       `json:"history,omitempty"`
+	NextPageToken []byte         `json:"nextPageToken,omitempty"`
+	StaleReadInfo *StaleReadInfo `json:"staleReadInfo,omitempty"`
 }
 
 // ToWire translates a GetWorkflowExecutionHistoryResponse struct into a Thrift-level intermediate
@@ -12518,7 +14008,7 @@ type GetWorkflowExecutionHistoryResponse struct {
 //   }
 func (v *GetWorkflowExecutionHistoryResponse) ToWire() (wire.Value, error) {
 	var (
-		fields [2]wire.Field
+		fields [3]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -12540,6 +14030,14 @@ func (v *GetWorkflowExecutionHistoryResponse) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 20, Value: w}
 		i++
 	}
+	if v.StaleReadInfo != nil {
+		w, err = v.StaleReadInfo.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
@@ -12587,6 +14085,14 @@ func (v *GetWorkflowExecutionHistoryResponse) FromWire(w wire.Value) error {
 					return err
 				}
 
+			}
+		case 30:
+			if field.Value.Type() == wire.TStruct {
+				v.StaleReadInfo, err = _StaleReadInfo_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -12601,7 +14107,7 @@ func (v *GetWorkflowExecutionHistoryResponse) String() string {
 		return "<nil>"
 	}
 
-	var fields [2]string
+	var fields [3]string
 	i := 0
 	if v.History != nil {
 		fields[i] = fmt.Sprintf("History: %v", v.History)
@@ -12611,6 +14117,10 @@ func (v *GetWorkflowExecutionHistoryResponse) String() string {
 		fields[i] = fmt.Sprintf("NextPageToken: %v", v.NextPageToken)
 		i++
 	}
+	if v.StaleReadInfo != nil {
+		fields[i] = fmt.Sprintf("StaleReadInfo: %v", v.StaleReadInfo)
+		i++
+	}
 
 	return fmt.Sprintf("GetWorkflowExecutionHistoryResponse{%v}", strings.Join(fields[:i], ", "))
 }
@@ -12626,6 +14136,9 @@ func (v *GetWorkflowExecutionHistoryResponse) Equals(rhs *GetWorkflowExecutionHi
 	if !((v.NextPageToken == nil && rhs.NextPageToken == nil) || (v.NextPageToken != nil && rhs.NextPageToken != nil && bytes.Equal(v.NextPageToken, rhs.NextPageToken))) {
 		return false
 	}
+	if !((v.StaleReadInfo == nil && rhs.StaleReadInfo == nil) || (v.StaleReadInfo != nil && rhs.StaleReadInfo != nil && v.StaleReadInfo.Equals(rhs.StaleReadInfo))) {
+		return false
+	}
 
 	return true
 }
@@ -12650,6 +14163,548 @@ func (v *GetWorkflowExecutionHistoryResponse) GetNextPageToken() (o []byte) {
 	return
 }
 
+// GetStaleReadInfo returns the value of StaleReadInfo if it is set or its
+// zero value if it is unset.
+func (v *GetWorkflowExecutionHistoryResponse) GetStaleReadInfo() (o *StaleReadInfo) {
+	if v.StaleReadInfo != nil {
+		return v.StaleReadInfo
+	}
+
+	return
+}
+
+type GetWorkflowExecutionResultRequest struct {
+	Domain     *string            `json:"domain,omitempty"`
+	Execution  *WorkflowExecution `json:"execution,omitempty"`
+	FollowRuns *bool              `json:"followRuns,omitempty"`
+}
+
+// ToWire translates a GetWorkflowExecutionResultRequest struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *GetWorkflowExecutionResultRequest) ToWire() (wire.Value, error) {
+	var (
+		fields [3]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Domain != nil {
+		w, err = wire.NewValueString(*(v.Domain)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.Execution != nil {
+		w, err = v.Execution.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+	if v.FollowRuns != nil {
+		w, err = wire.NewValueBool(*(v.FollowRuns)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a GetWorkflowExecutionResultRequest struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a GetWorkflowExecutionResultRequest struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v GetWorkflowExecutionResultRequest
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *GetWorkflowExecutionResultRequest) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Domain = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TStruct {
+				v.Execution, err = _WorkflowExecution_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 30:
+			if field.Value.Type() == wire.TBool {
+				var x bool
+				x, err = field.Value.GetBool(), error(nil)
+				v.FollowRuns = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a GetWorkflowExecutionResultRequest
+// struct.
+func (v *GetWorkflowExecutionResultRequest) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [3]string
+	i := 0
+	if v.Domain != nil {
+		fields[i] = fmt.Sprintf("Domain: %v", *(v.Domain))
+		i++
+	}
+	if v.Execution != nil {
+		fields[i] = fmt.Sprintf("Execution: %v", v.Execution)
+		i++
+	}
+	if v.FollowRuns != nil {
+		fields[i] = fmt.Sprintf("FollowRuns: %v", *(v.FollowRuns))
+		i++
+	}
+
+	return fmt.Sprintf("GetWorkflowExecutionResultRequest{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this GetWorkflowExecutionResultRequest match the
+// provided GetWorkflowExecutionResultRequest.
+//
+// This function performs a deep comparison.
+func (v *GetWorkflowExecutionResultRequest) Equals(rhs *GetWorkflowExecutionResultRequest) bool {
+	if !_String_EqualsPtr(v.Domain, rhs.Domain) {
+		return false
+	}
+	if !((v.Execution == nil && rhs.Execution == nil) || (v.Execution != nil && rhs.Execution != nil && v.Execution.Equals(rhs.Execution))) {
+		return false
+	}
+	if !_Bool_EqualsPtr(v.FollowRuns, rhs.FollowRuns) {
+		return false
+	}
+
+	return true
+}
+
+// GetDomain returns the value of Domain if it is set or its
+// zero value if it is unset.
+func (v *GetWorkflowExecutionResultRequest) GetDomain() (o string) {
+	if v.Domain != nil {
+		return *v.Domain
+	}
+
+	return
+}
+
+// GetExecution returns the value of Execution if it is set or its
+// zero value if it is unset.
+func (v *GetWorkflowExecutionResultRequest) GetExecution() (o *WorkflowExecution) {
+	if v.Execution != nil {
+		return v.Execution
+	}
+
+	return
+}
+
+// GetFollowRuns returns the value of FollowRuns if it is set or its
+// zero value if it is unset.
+func (v *GetWorkflowExecutionResultRequest) GetFollowRuns() (o bool) {
+	if v.FollowRuns != nil {
+		return *v.FollowRuns
+	}
+
+	return
+}
+
+type GetWorkflowExecutionResultResponse struct {
+	Execution         *WorkflowExecution            `json:"execution,omitempty"`
+	IsWorkflowRunning *bool                         `json:"isWorkflowRunning,omitempty"`
+	CloseStatus       *WorkflowExecutionCloseStatus `json:"closeStatus,omitempty"`
+	Result            []byte                        `json:"result,omitempty"`
+	FailureReason     *string                       `json:"failureReason,omitempty"`
+	FailureDetails    []byte                        `json:"failureDetails,omitempty"`
+	CloseEvent        *HistoryEvent                 `json:"closeEvent,omitempty"`
+}
+
+// ToWire translates a GetWorkflowExecutionResultResponse struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *GetWorkflowExecutionResultResponse) ToWire() (wire.Value, error) {
+	var (
+		fields [7]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Execution != nil {
+		w, err = v.Execution.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.IsWorkflowRunning != nil {
+		w, err = wire.NewValueBool(*(v.IsWorkflowRunning)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+	if v.CloseStatus != nil {
+		w, err = v.CloseStatus.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+	if v.Result != nil {
+		w, err = wire.NewValueBinary(v.Result), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 40, Value: w}
+		i++
+	}
+	if v.FailureReason != nil {
+		w, err = wire.NewValueString(*(v.FailureReason)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 50, Value: w}
+		i++
+	}
+	if v.FailureDetails != nil {
+		w, err = wire.NewValueBinary(v.FailureDetails), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 60, Value: w}
+		i++
+	}
+	if v.CloseEvent != nil {
+		w, err = v.CloseEvent.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 70, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _WorkflowExecutionCloseStatus_Read(w wire.Value) (WorkflowExecutionCloseStatus, error) {
+	var v WorkflowExecutionCloseStatus
+	err := v.FromWire(w)
+	return v, err
+}
+
+func _HistoryEvent_Read(w wire.Value) (*HistoryEvent, error) {
+	var v HistoryEvent
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a GetWorkflowExecutionResultResponse struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a GetWorkflowExecutionResultResponse struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v GetWorkflowExecutionResultResponse
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *GetWorkflowExecutionResultResponse) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TStruct {
+				v.Execution, err = _WorkflowExecution_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TBool {
+				var x bool
+				x, err = field.Value.GetBool(), error(nil)
+				v.IsWorkflowRunning = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 30:
+			if field.Value.Type() == wire.TI32 {
+				var x WorkflowExecutionCloseStatus
+				x, err = _WorkflowExecutionCloseStatus_Read(field.Value)
+				v.CloseStatus = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 40:
+			if field.Value.Type() == wire.TBinary {
+				v.Result, err = field.Value.GetBinary(), error(nil)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 50:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.FailureReason = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 60:
+			if field.Value.Type() == wire.TBinary {
+				v.FailureDetails, err = field.Value.GetBinary(), error(nil)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 70:
+			if field.Value.Type() == wire.TStruct {
+				v.CloseEvent, err = _HistoryEvent_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a GetWorkflowExecutionResultResponse
+// struct.
+func (v *GetWorkflowExecutionResultResponse) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [7]string
+	i := 0
+	if v.Execution != nil {
+		fields[i] = fmt.Sprintf("Execution: %v", v.Execution)
+		i++
+	}
+	if v.IsWorkflowRunning != nil {
+		fields[i] = fmt.Sprintf("IsWorkflowRunning: %v", *(v.IsWorkflowRunning))
+		i++
+	}
+	if v.CloseStatus != nil {
+		fields[i] = fmt.Sprintf("CloseStatus: %v", *(v.CloseStatus))
+		i++
+	}
+	if v.Result != nil {
+		fields[i] = fmt.Sprintf("Result: %v", v.Result)
+		i++
+	}
+	if v.FailureReason != nil {
+		fields[i] = fmt.Sprintf("FailureReason: %v", *(v.FailureReason))
+		i++
+	}
+	if v.FailureDetails != nil {
+		fields[i] = fmt.Sprintf("FailureDetails: %v", v.FailureDetails)
+		i++
+	}
+	if v.CloseEvent != nil {
+		fields[i] = fmt.Sprintf("CloseEvent: %v", v.CloseEvent)
+		i++
+	}
+
+	return fmt.Sprintf("GetWorkflowExecutionResultResponse{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _WorkflowExecutionCloseStatus_EqualsPtr(lhs, rhs *WorkflowExecutionCloseStatus) bool {
+	if lhs != nil && rhs != nil {
+
+		x := *lhs
+		y := *rhs
+		return x.Equals(y)
+	}
+	return lhs == nil && rhs == nil
+}
+
+// Equals returns true if all the fields of this GetWorkflowExecutionResultResponse match the
+// provided GetWorkflowExecutionResultResponse.
+//
+// This function performs a deep comparison.
+func (v *GetWorkflowExecutionResultResponse) Equals(rhs *GetWorkflowExecutionResultResponse) bool {
+	if !((v.Execution == nil && rhs.Execution == nil) || (v.Execution != nil && rhs.Execution != nil && v.Execution.Equals(rhs.Execution))) {
+		return false
+	}
+	if !_Bool_EqualsPtr(v.IsWorkflowRunning, rhs.IsWorkflowRunning) {
+		return false
+	}
+	if !_WorkflowExecutionCloseStatus_EqualsPtr(v.CloseStatus, rhs.CloseStatus) {
+		return false
+	}
+	if !((v.Result == nil && rhs.Result == nil) || (v.Result != nil && rhs.Result != nil && bytes.Equal(v.Result, rhs.Result))) {
+		return false
+	}
+	if !_String_EqualsPtr(v.FailureReason, rhs.FailureReason) {
+		return false
+	}
+	if !((v.FailureDetails == nil && rhs.FailureDetails == nil) || (v.FailureDetails != nil && rhs.FailureDetails != nil && bytes.Equal(v.FailureDetails, rhs.FailureDetails))) {
+		return false
+	}
+	if !((v.CloseEvent == nil && rhs.CloseEvent == nil) || (v.CloseEvent != nil && rhs.CloseEvent != nil && v.CloseEvent.Equals(rhs.CloseEvent))) {
+		return false
+	}
+
+	return true
+}
+
+// GetExecution returns the value of Execution if it is set or its
+// zero value if it is unset.
+func (v *GetWorkflowExecutionResultResponse) GetExecution() (o *WorkflowExecution) {
+	if v.Execution != nil {
+		return v.Execution
+	}
+
+	return
+}
+
+// GetIsWorkflowRunning returns the value of IsWorkflowRunning if it is set or its
+// zero value if it is unset.
+func (v *GetWorkflowExecutionResultResponse) GetIsWorkflowRunning() (o bool) {
+	if v.IsWorkflowRunning != nil {
+		return *v.IsWorkflowRunning
+	}
+
+	return
+}
+
+// GetCloseStatus returns the value of CloseStatus if it is set or its
+// zero value if it is unset.
+func (v *GetWorkflowExecutionResultResponse) GetCloseStatus() (o WorkflowExecutionCloseStatus) {
+	if v.CloseStatus != nil {
+		return *v.CloseStatus
+	}
+
+	return
+}
+
+// GetResult returns the value of Result if it is set or its
+// zero value if it is unset.
+func (v *GetWorkflowExecutionResultResponse) GetResult() (o []byte) {
+	if v.Result != nil {
+		return v.Result
+	}
+
+	return
+}
+
+// GetFailureReason returns the value of FailureReason if it is set or its
+// zero value if it is unset.
+func (v *GetWorkflowExecutionResultResponse) GetFailureReason() (o string) {
+	if v.FailureReason != nil {
+		return *v.FailureReason
+	}
+
+	return
+}
+
+// GetFailureDetails returns the value of FailureDetails if it is set or its
+// zero value if it is unset.
+func (v *GetWorkflowExecutionResultResponse) GetFailureDetails() (o []byte) {
+	if v.FailureDetails != nil {
+		return v.FailureDetails
+	}
+
+	return
+}
+
+// GetCloseEvent returns the value of CloseEvent if it is set or its
+// zero value if it is unset.
+func (v *GetWorkflowExecutionResultResponse) GetCloseEvent() (o *HistoryEvent) {
+	if v.CloseEvent != nil {
+		return v.CloseEvent
+	}
+
+	return
+}
+
 type Header struct {
 	Fields map[string][]byte `json:"fields,omitempty"`
 }
@@ -12915,12 +14970,6 @@ func (v *History) ToWire() (wire.Value, error) {
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func _HistoryEvent_Read(w wire.Value) (*HistoryEvent, error) {
-	var v HistoryEvent
-	err := v.FromWire(w)
-	return &v, err
-}
-
 func _List_HistoryEvent_Read(l wire.ValueList) ([]*HistoryEvent, error) {
 	if l.ValueType() != wire.TStruct {
 		return nil, nil
@@ -13075,6 +15124,8 @@ type HistoryEvent struct {
 	SignalExternalWorkflowExecutionInitiatedEventAttributes        *SignalExternalWorkflowExecutionInitiatedEventAttributes        `json:"signalExternalWorkflowExecutionInitiatedEventAttributes,omitempty"`
 	SignalExternalWorkflowExecutionFailedEventAttributes           *SignalExternalWorkflowExecutionFailedEventAttributes           `json:"signalExternalWorkflowExecutionFailedEventAttributes,omitempty"`
 	ExternalWorkflowExecutionSignaledEventAttributes               *ExternalWorkflowExecutionSignaledEventAttributes               `json:"externalWorkflowExecutionSignaledEventAttributes,omitempty"`
+	ActivityTaskRedirectedEventAttributes                          *ActivityTaskRedirectedEventAttributes                          `json:"activityTaskRedirectedEventAttributes,omitempty"`
+	WorkflowExecutionUpdateAcceptedEventAttributes                 *WorkflowExecutionUpdateAcceptedEventAttributes                 `json:"workflowExecutionUpdateAcceptedEventAttributes,omitempty"`
 }
 
 // ToWire translates a HistoryEvent struct into a Thrift-level intermediate
@@ -13094,7 +15145,7 @@ type HistoryEvent struct {
 //   }
 func (v *HistoryEvent) ToWire() (wire.Value, error) {
 	var (
-		fields [45]wire.Field
+		fields [47]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -13460,6 +15511,22 @@ func (v *HistoryEvent) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 440, Value: w}
 		i++
 	}
+	if v.ActivityTaskRedirectedEventAttributes != nil {
+		w, err = v.ActivityTaskRedirectedEventAttributes.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 450, Value: w}
+		i++
+	}
+	if v.WorkflowExecutionUpdateAcceptedEventAttributes != nil {
+		w, err = v.WorkflowExecutionUpdateAcceptedEventAttributes.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 460, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
@@ -13716,6 +15783,18 @@ func _ExternalWorkflowExecutionSignaledEventAttributes_Read(w wire.Value) (*Exte
 	return &v, err
 }
 
+func _ActivityTaskRedirectedEventAttributes_Read(w wire.Value) (*ActivityTaskRedirectedEventAttributes, error) {
+	var v ActivityTaskRedirectedEventAttributes
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _WorkflowExecutionUpdateAcceptedEventAttributes_Read(w wire.Value) (*WorkflowExecutionUpdateAcceptedEventAttributes, error) {
+	var v WorkflowExecutionUpdateAcceptedEventAttributes
+	err := v.FromWire(w)
+	return &v, err
+}
+
 // FromWire deserializes a HistoryEvent struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
@@ -14105,6 +16184,22 @@ func (v *HistoryEvent) FromWire(w wire.Value) error {
 					return err
 				}
 
+			}
+		case 450:
+			if field.Value.Type() == wire.TStruct {
+				v.ActivityTaskRedirectedEventAttributes, err = _ActivityTaskRedirectedEventAttributes_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 460:
+			if field.Value.Type() == wire.TStruct {
+				v.WorkflowExecutionUpdateAcceptedEventAttributes, err = _WorkflowExecutionUpdateAcceptedEventAttributes_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -14119,7 +16214,7 @@ func (v *HistoryEvent) String() string {
 		return "<nil>"
 	}
 
-	var fields [45]string
+	var fields [47]string
 	i := 0
 	if v.EventId != nil {
 		fields[i] = fmt.Sprintf("EventId: %v", *(v.EventId))
@@ -14301,6 +16396,14 @@ func (v *HistoryEvent) String() string {
 		fields[i] = fmt.Sprintf("ExternalWorkflowExecutionSignaledEventAttributes: %v", v.ExternalWorkflowExecutionSignaledEventAttributes)
 		i++
 	}
+	if v.ActivityTaskRedirectedEventAttributes != nil {
+		fields[i] = fmt.Sprintf("ActivityTaskRedirectedEventAttributes: %v", v.ActivityTaskRedirectedEventAttributes)
+		i++
+	}
+	if v.WorkflowExecutionUpdateAcceptedEventAttributes != nil {
+		fields[i] = fmt.Sprintf("WorkflowExecutionUpdateAcceptedEventAttributes: %v", v.WorkflowExecutionUpdateAcceptedEventAttributes)
+		i++
+	}
 
 	return fmt.Sprintf("HistoryEvent{%v}", strings.Join(fields[:i], ", "))
 }
@@ -14455,6 +16558,12 @@ func (v *HistoryEvent) Equals(rhs *HistoryEvent) bool {
 	if !((v.ExternalWorkflowExecutionSignaledEventAttributes == nil && rhs.ExternalWorkflowExecutionSignaledEventAttributes == nil) || (v.ExternalWorkflowExecutionSignaledEventAttributes != nil && rhs.ExternalWorkflowExecutionSignaledEventAttributes != nil && v.ExternalWorkflowExecutionSignaledEventAttributes.Equals(rhs.ExternalWorkflowExecutionSignaledEventAttributes))) {
 		return false
 	}
+	if !((v.ActivityTaskRedirectedEventAttributes == nil && rhs.ActivityTaskRedirectedEventAttributes == nil) || (v.ActivityTaskRedirectedEventAttributes != nil && rhs.ActivityTaskRedirectedEventAttributes != nil && v.ActivityTaskRedirectedEventAttributes.Equals(rhs.ActivityTaskRedirectedEventAttributes))) {
+		return false
+	}
+	if !((v.WorkflowExecutionUpdateAcceptedEventAttributes == nil && rhs.WorkflowExecutionUpdateAcceptedEventAttributes == nil) || (v.WorkflowExecutionUpdateAcceptedEventAttributes != nil && rhs.WorkflowExecutionUpdateAcceptedEventAttributes != nil && v.WorkflowExecutionUpdateAcceptedEventAttributes.Equals(rhs.WorkflowExecutionUpdateAcceptedEventAttributes))) {
+		return false
+	}
 
 	return true
 }
@@ -14909,6 +17018,26 @@ func (v *HistoryEvent) GetExternalWorkflowExecutionSignaledEventAttributes() (o
 	return
 }
 
+// GetActivityTaskRedirectedEventAttributes returns the value of ActivityTaskRedirectedEventAttributes if it is set or its
+// zero value if it is unset.
+func (v *HistoryEvent) GetActivityTaskRedirectedEventAttributes() (o *ActivityTaskRedirectedEventAttributes) {
+	if v.ActivityTaskRedirectedEventAttributes != nil {
+		return v.ActivityTaskRedirectedEventAttributes
+	}
+
+	return
+}
+
+// GetWorkflowExecutionUpdateAcceptedEventAttributes returns the value of WorkflowExecutionUpdateAcceptedEventAttributes if it is set or its
+// zero value if it is unset.
+func (v *HistoryEvent) GetWorkflowExecutionUpdateAcceptedEventAttributes() (o *WorkflowExecutionUpdateAcceptedEventAttributes) {
+	if v.WorkflowExecutionUpdateAcceptedEventAttributes != nil {
+		return v.WorkflowExecutionUpdateAcceptedEventAttributes
+	}
+
+	return
+}
+
 type HistoryEventFilterType int32
 
 const (
@@ -15399,12 +17528,6 @@ func _WorkflowTypeFilter_Read(w wire.Value) (*WorkflowTypeFilter, error) {
 	return &v, err
 }
 
-func _WorkflowExecutionCloseStatus_Read(w wire.Value) (WorkflowExecutionCloseStatus, error) {
-	var v WorkflowExecutionCloseStatus
-	err := v.FromWire(w)
-	return v, err
-}
-
 // FromWire deserializes a ListClosedWorkflowExecutionsRequest struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
@@ -15536,16 +17659,6 @@ func (v *ListClosedWorkflowExecutionsRequest) String() string {
 	return fmt.Sprintf("ListClosedWorkflowExecutionsRequest{%v}", strings.Join(fields[:i], ", "))
 }
 
-func _WorkflowExecutionCloseStatus_EqualsPtr(lhs, rhs *WorkflowExecutionCloseStatus) bool {
-	if lhs != nil && rhs != nil {
-
-		x := *lhs
-		y := *rhs
-		return x.Equals(y)
-	}
-	return lhs == nil && rhs == nil
-}
-
 // Equals returns true if all the fields of this ListClosedWorkflowExecutionsRequest match the
 // provided ListClosedWorkflowExecutionsRequest.
 //
@@ -16658,6 +18771,408 @@ func (v *ListOpenWorkflowExecutionsResponse) GetNextPageToken() (o []byte) {
 	return
 }
 
+type ListTaskListsRequest struct {
+	Domain        *string `json:"domain,omitempty"`
+	PageSize      *int32  `json:"pageSize,omitempty"`
+	NextPageToken []byte  `json:"nextPageToken,omitempty"`
+}
+
+// ToWire translates a ListTaskListsRequest struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *ListTaskListsRequest) ToWire() (wire.Value, error) {
+	var (
+		fields [3]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Domain != nil {
+		w, err = wire.NewValueString(*(v.Domain)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.PageSize != nil {
+		w, err = wire.NewValueI32(*(v.PageSize)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+	if v.NextPageToken != nil {
+		w, err = wire.NewValueBinary(v.NextPageToken), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a ListTaskListsRequest struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a ListTaskListsRequest struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v ListTaskListsRequest
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *ListTaskListsRequest) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Domain = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.PageSize = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 30:
+			if field.Value.Type() == wire.TBinary {
+				v.NextPageToken, err = field.Value.GetBinary(), error(nil)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a ListTaskListsRequest
+// struct.
+func (v *ListTaskListsRequest) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [3]string
+	i := 0
+	if v.Domain != nil {
+		fields[i] = fmt.Sprintf("Domain: %v", *(v.Domain))
+		i++
+	}
+	if v.PageSize != nil {
+		fields[i] = fmt.Sprintf("PageSize: %v", *(v.PageSize))
+		i++
+	}
+	if v.NextPageToken != nil {
+		fields[i] = fmt.Sprintf("NextPageToken: %v", v.NextPageToken)
+		i++
+	}
+
+	return fmt.Sprintf("ListTaskListsRequest{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this ListTaskListsRequest match the
+// provided ListTaskListsRequest.
+//
+// This function performs a deep comparison.
+func (v *ListTaskListsRequest) Equals(rhs *ListTaskListsRequest) bool {
+	if !_String_EqualsPtr(v.Domain, rhs.Domain) {
+		return false
+	}
+	if !_I32_EqualsPtr(v.PageSize, rhs.PageSize) {
+		return false
+	}
+	if !((v.NextPageToken == nil && rhs.NextPageToken == nil) || (v.NextPageToken != nil && rhs.NextPageToken != nil && bytes.Equal(v.NextPageToken, rhs.NextPageToken))) {
+		return false
+	}
+
+	return true
+}
+
+// GetDomain returns the value of Domain if it is set or its
+// zero value if it is unset.
+func (v *ListTaskListsRequest) GetDomain() (o string) {
+	if v.Domain != nil {
+		return *v.Domain
+	}
+
+	return
+}
+
+// GetPageSize returns the value of PageSize if it is set or its
+// zero value if it is unset.
+func (v *ListTaskListsRequest) GetPageSize() (o int32) {
+	if v.PageSize != nil {
+		return *v.PageSize
+	}
+
+	return
+}
+
+// GetNextPageToken returns the value of NextPageToken if it is set or its
+// zero value if it is unset.
+func (v *ListTaskListsRequest) GetNextPageToken() (o []byte) {
+	if v.NextPageToken != nil {
+		return v.NextPageToken
+	}
+
+	return
+}
+
+type ListTaskListsResponse struct {
+	TaskLists     []*TaskListStatus `json:"taskLists,omitempty"`
+	NextPageToken []byte            `json:"nextPageToken,omitempty"`
+}
+
+type _List_TaskListStatus_ValueList []*TaskListStatus
+
+func (v _List_TaskListStatus_ValueList) ForEach(f func(wire.Value) error) error {
+	for i, x := range v {
+		if x == nil {
+			return fmt.Errorf("invalid [%v]: value is nil", i)
+		}
+		w, err := x.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v _List_TaskListStatus_ValueList) Size() int {
+	return len(v)
+}
+
+func (_List_TaskListStatus_ValueList) ValueType() wire.Type {
+	return wire.TStruct
+}
+
+func (_List_TaskListStatus_ValueList) Close() {}
+
+// ToWire translates a ListTaskListsResponse struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *ListTaskListsResponse) ToWire() (wire.Value, error) {
+	var (
+		fields [2]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.TaskLists != nil {
+		w, err = wire.NewValueList(_List_TaskListStatus_ValueList(v.TaskLists)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.NextPageToken != nil {
+		w, err = wire.NewValueBinary(v.NextPageToken), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _TaskListStatus_Read(w wire.Value) (*TaskListStatus, error) {
+	var v TaskListStatus
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _List_TaskListStatus_Read(l wire.ValueList) ([]*TaskListStatus, error) {
+	if l.ValueType() != wire.TStruct {
+		return nil, nil
+	}
+
+	o := make([]*TaskListStatus, 0, l.Size())
+	err := l.ForEach(func(x wire.Value) error {
+		i, err := _TaskListStatus_Read(x)
+		if err != nil {
+			return err
+		}
+		o = append(o, i)
+		return nil
+	})
+	l.Close()
+	return o, err
+}
+
+// FromWire deserializes a ListTaskListsResponse struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a ListTaskListsResponse struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v ListTaskListsResponse
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *ListTaskListsResponse) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TList {
+				v.TaskLists, err = _List_TaskListStatus_Read(field.Value.GetList())
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TBinary {
+				v.NextPageToken, err = field.Value.GetBinary(), error(nil)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a ListTaskListsResponse
+// struct.
+func (v *ListTaskListsResponse) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [2]string
+	i := 0
+	if v.TaskLists != nil {
+		fields[i] = fmt.Sprintf("TaskLists: %v", v.TaskLists)
+		i++
+	}
+	if v.NextPageToken != nil {
+		fields[i] = fmt.Sprintf("NextPageToken: %v", v.NextPageToken)
+		i++
+	}
+
+	return fmt.Sprintf("ListTaskListsResponse{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _List_TaskListStatus_Equals(lhs, rhs []*TaskListStatus) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !lv.Equals(rv) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Equals returns true if all the fields of this ListTaskListsResponse match the
+// provided ListTaskListsResponse.
+//
+// This function performs a deep comparison.
+func (v *ListTaskListsResponse) Equals(rhs *ListTaskListsResponse) bool {
+	if !((v.TaskLists == nil && rhs.TaskLists == nil) || (v.TaskLists != nil && rhs.TaskLists != nil && _List_TaskListStatus_Equals(v.TaskLists, rhs.TaskLists))) {
+		return false
+	}
+	if !((v.NextPageToken == nil && rhs.NextPageToken == nil) || (v.NextPageToken != nil && rhs.NextPageToken != nil && bytes.Equal(v.NextPageToken, rhs.NextPageToken))) {
+		return false
+	}
+
+	return true
+}
+
+// GetTaskLists returns the value of TaskLists if it is set or its
+// zero value if it is unset.
+func (v *ListTaskListsResponse) GetTaskLists() (o []*TaskListStatus) {
+	if v.TaskLists != nil {
+		return v.TaskLists
+	}
+
+	return
+}
+
+// GetNextPageToken returns the value of NextPageToken if it is set or its
+// zero value if it is unset.
+func (v *ListTaskListsResponse) GetNextPageToken() (o []byte) {
+	if v.NextPageToken != nil {
+		return v.NextPageToken
+	}
+
+	return
+}
+
 type MarkerRecordedEventAttributes struct {
 	MarkerName                   *string `json:"markerName,omitempty"`
 	Details                      []byte  `json:"details,omitempty"`
@@ -18280,8 +20795,38 @@ type PollForDecisionTaskResponse struct {
 	NextPageToken             []byte             `json:"nextPageToken,omitempty"`
 	Query                     *WorkflowQuery     `json:"query,omitempty"`
 	WorkflowExecutionTaskList *TaskList          `json:"WorkflowExecutionTaskList,omitempty"`
+	Updates                   []*WorkflowUpdate  `json:"updates,omitempty"`
 }
 
+type _List_WorkflowUpdate_ValueList []*WorkflowUpdate
+
+func (v _List_WorkflowUpdate_ValueList) ForEach(f func(wire.Value) error) error {
+	for i, x := range v {
+		if x == nil {
+			return fmt.Errorf("invalid [%v]: value is nil", i)
+		}
+		w, err := x.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v _List_WorkflowUpdate_ValueList) Size() int {
+	return len(v)
+}
+
+func (_List_WorkflowUpdate_ValueList) ValueType() wire.Type {
+	return wire.TStruct
+}
+
+func (_List_WorkflowUpdate_ValueList) Close() {}
+
 // ToWire translates a PollForDecisionTaskResponse struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
@@ -18299,7 +20844,7 @@ type PollForDecisionTaskResponse struct {
 //   }
 func (v *PollForDecisionTaskResponse) ToWire() (wire.Value, error) {
 	var (
-		fields [11]wire.Field
+		fields [12]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -18393,6 +20938,14 @@ func (v *PollForDecisionTaskResponse) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 90, Value: w}
 		i++
 	}
+	if v.Updates != nil {
+		w, err = wire.NewValueList(_List_WorkflowUpdate_ValueList(v.Updates)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 100, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
@@ -18403,6 +20956,30 @@ func _WorkflowQuery_Read(w wire.Value) (*WorkflowQuery, error) {
 	return &v, err
 }
 
+func _WorkflowUpdate_Read(w wire.Value) (*WorkflowUpdate, error) {
+	var v WorkflowUpdate
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _List_WorkflowUpdate_Read(l wire.ValueList) ([]*WorkflowUpdate, error) {
+	if l.ValueType() != wire.TStruct {
+		return nil, nil
+	}
+
+	o := make([]*WorkflowUpdate, 0, l.Size())
+	err := l.ForEach(func(x wire.Value) error {
+		i, err := _WorkflowUpdate_Read(x)
+		if err != nil {
+			return err
+		}
+		o = append(o, i)
+		return nil
+	})
+	l.Close()
+	return o, err
+}
+
 // FromWire deserializes a PollForDecisionTaskResponse struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
@@ -18520,6 +21097,14 @@ func (v *PollForDecisionTaskResponse) FromWire(w wire.Value) error {
 					return err
 				}
 
+			}
+		case 100:
+			if field.Value.Type() == wire.TList {
+				v.Updates, err = _List_WorkflowUpdate_Read(field.Value.GetList())
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -18534,7 +21119,7 @@ func (v *PollForDecisionTaskResponse) String() string {
 		return "<nil>"
 	}
 
-	var fields [11]string
+	var fields [12]string
 	i := 0
 	if v.TaskToken != nil {
 		fields[i] = fmt.Sprintf("TaskToken: %v", v.TaskToken)
@@ -18580,10 +21165,29 @@ func (v *PollForDecisionTaskResponse) String() string {
 		fields[i] = fmt.Sprintf("WorkflowExecutionTaskList: %v", v.WorkflowExecutionTaskList)
 		i++
 	}
+	if v.Updates != nil {
+		fields[i] = fmt.Sprintf("Updates: %v", v.Updates)
+		i++
+	}
 
 	return fmt.Sprintf("PollForDecisionTaskResponse{%v}", strings.Join(fields[:i], ", "))
 }
 
+func _List_WorkflowUpdate_Equals(lhs, rhs []*WorkflowUpdate) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !lv.Equals(rv) {
+			return false
+		}
+	}
+
+	return true
+}
+
 // Equals returns true if all the fields of this PollForDecisionTaskResponse match the
 // provided PollForDecisionTaskResponse.
 //
@@ -18622,6 +21226,9 @@ func (v *PollForDecisionTaskResponse) Equals(rhs *PollForDecisionTaskResponse) b
 	if !((v.WorkflowExecutionTaskList == nil && rhs.WorkflowExecutionTaskList == nil) || (v.WorkflowExecutionTaskList != nil && rhs.WorkflowExecutionTaskList != nil && v.WorkflowExecutionTaskList.Equals(rhs.WorkflowExecutionTaskList))) {
 		return false
 	}
+	if !((v.Updates == nil && rhs.Updates == nil) || (v.Updates != nil && rhs.Updates != nil && _List_WorkflowUpdate_Equals(v.Updates, rhs.Updates))) {
+		return false
+	}
 
 	return true
 }
@@ -18736,6 +21343,16 @@ func (v *PollForDecisionTaskResponse) GetWorkflowExecutionTaskList() (o *TaskLis
 	return
 }
 
+// GetUpdates returns the value of Updates if it is set or its
+// zero value if it is unset.
+func (v *PollForDecisionTaskResponse) GetUpdates() (o []*WorkflowUpdate) {
+	if v.Updates != nil {
+		return v.Updates
+	}
+
+	return
+}
+
 type PollerInfo struct {
 	LastAccessTime *int64  `json:"lastAccessTime,omitempty"`
 	Identity       *string `json:"identity,omitempty"`
@@ -18888,6 +21505,157 @@ func (v *PollerInfo) GetIdentity() (o string) {
 	return
 }
 
+type QueryConsistencyLevel int32
+
+const (
+	QueryConsistencyLevelEventual QueryConsistencyLevel = 0
+	QueryConsistencyLevelStrong   QueryConsistencyLevel = 1
+)
+
+// QueryConsistencyLevel_Values returns all recognized values of QueryConsistencyLevel.
+func QueryConsistencyLevel_Values() []QueryConsistencyLevel {
+	return []QueryConsistencyLevel{
+		QueryConsistencyLevelEventual,
+		QueryConsistencyLevelStrong,
+	}
+}
+
+// UnmarshalText tries to decode QueryConsistencyLevel from a byte slice
+// containing its name.
+//
+//   var v QueryConsistencyLevel
+//   err := v.UnmarshalText([]byte("EVENTUAL"))
+func (v *QueryConsistencyLevel) UnmarshalText(value []byte) error {
+	switch string(value) {
+	case "EVENTUAL":
+		*v = QueryConsistencyLevelEventual
+		return nil
+	case "STRONG":
+		*v = QueryConsistencyLevelStrong
+		return nil
+	default:
+		return fmt.Errorf("unknown enum value %q for %q", value, "QueryConsistencyLevel")
+	}
+}
+
+// MarshalText encodes QueryConsistencyLevel to text.
+//
+// If the enum value is recognized, its name is returned. Otherwise,
+// its integer value is returned.
+//
+// This implements the TextMarshaler interface.
+func (v QueryConsistencyLevel) MarshalText() ([]byte, error) {
+	switch int32(v) {
+	case 0:
+		return []byte("EVENTUAL"), nil
+	case 1:
+		return []byte("STRONG"), nil
+	}
+	return []byte(strconv.FormatInt(int64(v), 10)), nil
+}
+
+// Ptr returns a pointer to this enum value.
+func (v QueryConsistencyLevel) Ptr() *QueryConsistencyLevel {
+	return &v
+}
+
+// ToWire translates QueryConsistencyLevel into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// Enums are represented as 32-bit integers over the wire.
+func (v QueryConsistencyLevel) ToWire() (wire.Value, error) {
+	return wire.NewValueI32(int32(v)), nil
+}
+
+// FromWire deserializes QueryConsistencyLevel from its Thrift-level
+// representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TI32)
+//   if err != nil {
+//     return QueryConsistencyLevel(0), err
+//   }
+//
+//   var v QueryConsistencyLevel
+//   if err := v.FromWire(x); err != nil {
+//     return QueryConsistencyLevel(0), err
+//   }
+//   return v, nil
+func (v *QueryConsistencyLevel) FromWire(w wire.Value) error {
+	*v = (QueryConsistencyLevel)(w.GetI32())
+	return nil
+}
+
+// String returns a readable string representation of QueryConsistencyLevel.
+func (v QueryConsistencyLevel) String() string {
+	w := int32(v)
+	switch w {
+	case 0:
+		return "EVENTUAL"
+	case 1:
+		return "STRONG"
+	}
+	return fmt.Sprintf("QueryConsistencyLevel(%d)", w)
+}
+
+// Equals returns true if this QueryConsistencyLevel value matches the provided
+// value.
+func (v QueryConsistencyLevel) Equals(rhs QueryConsistencyLevel) bool {
+	return v == rhs
+}
+
+// MarshalJSON serializes QueryConsistencyLevel into JSON.
+//
+// If the enum value is recognized, its name is returned. Otherwise,
+// its integer value is returned.
+//
+// This implements json.Marshaler.
+func (v QueryConsistencyLevel) MarshalJSON() ([]byte, error) {
+	switch int32(v) {
+	case 0:
+		return ([]byte)("\"EVENTUAL\""), nil
+	case 1:
+		return ([]byte)("\"STRONG\""), nil
+	}
+	return ([]byte)(strconv.FormatInt(int64(v), 10)), nil
+}
+
+// UnmarshalJSON attempts to decode QueryConsistencyLevel from its JSON
+// representation.
+//
+// This implementation supports both, numeric and string inputs. If a
+// string is provided, it must be a known enum name.
+//
+// This implements json.Unmarshaler.
+func (v *QueryConsistencyLevel) UnmarshalJSON(text []byte) error {
+	d := json.NewDecoder(bytes.NewReader(text))
+	d.UseNumber()
+	t, err := d.Token()
+	if err != nil {
+		return err
+	}
+
+	switch w := t.(type) {
+	case json.Number:
+		x, err := w.Int64()
+		if err != nil {
+			return err
+		}
+		if x > math.MaxInt32 {
+			return fmt.Errorf("enum overflow from JSON %q for %q", text, "QueryConsistencyLevel")
+		}
+		if x < math.MinInt32 {
+			return fmt.Errorf("enum underflow from JSON %q for %q", text, "QueryConsistencyLevel")
+		}
+		*v = (QueryConsistencyLevel)(x)
+		return nil
+	case string:
+		return v.UnmarshalText([]byte(w))
+	default:
+		return fmt.Errorf("invalid JSON value %q (%T) to unmarshal into %q", t, t, "QueryConsistencyLevel")
+	}
+}
+
 type QueryFailedError struct {
 	Message string `json:"message,required"`
 }
@@ -19002,6 +21770,273 @@ func (v *QueryFailedError) Error() string {
 	return v.String()
 }
 
+type QueryRejectCondition int32
+
+const (
+	QueryRejectConditionNotOpen             QueryRejectCondition = 0
+	QueryRejectConditionNotCompletedCleanly QueryRejectCondition = 1
+)
+
+// QueryRejectCondition_Values returns all recognized values of QueryRejectCondition.
+func QueryRejectCondition_Values() []QueryRejectCondition {
+	return []QueryRejectCondition{
+		QueryRejectConditionNotOpen,
+		QueryRejectConditionNotCompletedCleanly,
+	}
+}
+
+// UnmarshalText tries to decode QueryRejectCondition from a byte slice
+// containing its name.
+//
+//   var v QueryRejectCondition
+//   err := v.UnmarshalText([]byte("NOT_OPEN"))
+func (v *QueryRejectCondition) UnmarshalText(value []byte) error {
+	switch string(value) {
+	case "NOT_OPEN":
+		*v = QueryRejectConditionNotOpen
+		return nil
+	case "NOT_COMPLETED_CLEANLY":
+		*v = QueryRejectConditionNotCompletedCleanly
+		return nil
+	default:
+		return fmt.Errorf("unknown enum value %q for %q", value, "QueryRejectCondition")
+	}
+}
+
+// MarshalText encodes QueryRejectCondition to text.
+//
+// If the enum value is recognized, its name is returned. Otherwise,
+// its integer value is returned.
+//
+// This implements the TextMarshaler interface.
+func (v QueryRejectCondition) MarshalText() ([]byte, error) {
+	switch int32(v) {
+	case 0:
+		return []byte("NOT_OPEN"), nil
+	case 1:
+		return []byte("NOT_COMPLETED_CLEANLY"), nil
+	}
+	return []byte(strconv.FormatInt(int64(v), 10)), nil
+}
+
+// Ptr returns a pointer to this enum value.
+func (v QueryRejectCondition) Ptr() *QueryRejectCondition {
+	return &v
+}
+
+// ToWire translates QueryRejectCondition into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// Enums are represented as 32-bit integers over the wire.
+func (v QueryRejectCondition) ToWire() (wire.Value, error) {
+	return wire.NewValueI32(int32(v)), nil
+}
+
+// FromWire deserializes QueryRejectCondition from its Thrift-level
+// representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TI32)
+//   if err != nil {
+//     return QueryRejectCondition(0), err
+//   }
+//
+//   var v QueryRejectCondition
+//   if err := v.FromWire(x); err != nil {
+//     return QueryRejectCondition(0), err
+//   }
+//   return v, nil
+func (v *QueryRejectCondition) FromWire(w wire.Value) error {
+	*v = (QueryRejectCondition)(w.GetI32())
+	return nil
+}
+
+// String returns a readable string representation of QueryRejectCondition.
+func (v QueryRejectCondition) String() string {
+	w := int32(v)
+	switch w {
+	case 0:
+		return "NOT_OPEN"
+	case 1:
+		return "NOT_COMPLETED_CLEANLY"
+	}
+	return fmt.Sprintf("QueryRejectCondition(%d)", w)
+}
+
+// Equals returns true if this QueryRejectCondition value matches the provided
+// value.
+func (v QueryRejectCondition) Equals(rhs QueryRejectCondition) bool {
+	return v == rhs
+}
+
+// MarshalJSON serializes QueryRejectCondition into JSON.
+//
+// If the enum value is recognized, its name is returned. Otherwise,
+// its integer value is returned.
+//
+// This implements json.Marshaler.
+func (v QueryRejectCondition) MarshalJSON() ([]byte, error) {
+	switch int32(v) {
+	case 0:
+		return ([]byte)("\"NOT_OPEN\""), nil
+	case 1:
+		return ([]byte)("\"NOT_COMPLETED_CLEANLY\""), nil
+	}
+	return ([]byte)(strconv.FormatInt(int64(v), 10)), nil
+}
+
+// UnmarshalJSON attempts to decode QueryRejectCondition from its JSON
+// representation.
+//
+// This implementation supports both, numeric and string inputs. If a
+// string is provided, it must be a known enum name.
+//
+// This implements json.Unmarshaler.
+func (v *QueryRejectCondition) UnmarshalJSON(text []byte) error {
+	d := json.NewDecoder(bytes.NewReader(text))
+	d.UseNumber()
+	t, err := d.Token()
+	if err != nil {
+		return err
+	}
+
+	switch w := t.(type) {
+	case json.Number:
+		x, err := w.Int64()
+		if err != nil {
+			return err
+		}
+		if x > math.MaxInt32 {
+			return fmt.Errorf("enum overflow from JSON %q for %q", text, "QueryRejectCondition")
+		}
+		if x < math.MinInt32 {
+			return fmt.Errorf("enum underflow from JSON %q for %q", text, "QueryRejectCondition")
+		}
+		*v = (QueryRejectCondition)(x)
+		return nil
+	case string:
+		return v.UnmarshalText([]byte(w))
+	default:
+		return fmt.Errorf("invalid JSON value %q (%T) to unmarshal into %q", t, t, "QueryRejectCondition")
+	}
+}
+
+type QueryRejected struct {
+	CloseStatus *WorkflowExecutionCloseStatus `json:"closeStatus,omitempty"`
+}
+
+// ToWire translates a QueryRejected struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *QueryRejected) ToWire() (wire.Value, error) {
+	var (
+		fields [1]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.CloseStatus != nil {
+		w, err = v.CloseStatus.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a QueryRejected struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a QueryRejected struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v QueryRejected
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *QueryRejected) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TI32 {
+				var x WorkflowExecutionCloseStatus
+				x, err = _WorkflowExecutionCloseStatus_Read(field.Value)
+				v.CloseStatus = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a QueryRejected
+// struct.
+func (v *QueryRejected) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [1]string
+	i := 0
+	if v.CloseStatus != nil {
+		fields[i] = fmt.Sprintf("CloseStatus: %v", *(v.CloseStatus))
+		i++
+	}
+
+	return fmt.Sprintf("QueryRejected{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this QueryRejected match the
+// provided QueryRejected.
+//
+// This function performs a deep comparison.
+func (v *QueryRejected) Equals(rhs *QueryRejected) bool {
+	if !_WorkflowExecutionCloseStatus_EqualsPtr(v.CloseStatus, rhs.CloseStatus) {
+		return false
+	}
+
+	return true
+}
+
+// GetCloseStatus returns the value of CloseStatus if it is set or its
+// zero value if it is unset.
+func (v *QueryRejected) GetCloseStatus() (o WorkflowExecutionCloseStatus) {
+	if v.CloseStatus != nil {
+		return *v.CloseStatus
+	}
+
+	return
+}
+
 type QueryTaskCompletedType int32
 
 const (
@@ -19154,9 +22189,11 @@ func (v *QueryTaskCompletedType) UnmarshalJSON(text []byte) error {
 }
 
 type QueryWorkflowRequest struct {
-	Domain    *string            `json:"domain,omitempty"`
-	Execution *WorkflowExecution `json:"execution,omitempty"`
-	Query     *WorkflowQuery     `json:"query,omitempty"`
+	Domain                *string                `json:"domain,omitempty"`
+	Execution             *WorkflowExecution     `json:"execution,omitempty"`
+	Query                 *WorkflowQuery         `json:"query,omitempty"`
+	QueryRejectCondition  *QueryRejectCondition  `json:"queryRejectCondition,omitempty"`
+	QueryConsistencyLevel *QueryConsistencyLevel `json:"queryConsistencyLevel,omitempty"`
 }
 
 // ToWire translates a QueryWorkflowRequest struct into a Thrift-level intermediate
@@ -19176,7 +22213,7 @@ type QueryWorkflowRequest struct {
 //   }
 func (v *QueryWorkflowRequest) ToWire() (wire.Value, error) {
 	var (
-		fields [3]wire.Field
+		fields [5]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -19206,10 +22243,38 @@ func (v *QueryWorkflowRequest) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 30, Value: w}
 		i++
 	}
+	if v.QueryRejectCondition != nil {
+		w, err = v.QueryRejectCondition.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 40, Value: w}
+		i++
+	}
+	if v.QueryConsistencyLevel != nil {
+		w, err = v.QueryConsistencyLevel.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 50, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
+func _QueryRejectCondition_Read(w wire.Value) (QueryRejectCondition, error) {
+	var v QueryRejectCondition
+	err := v.FromWire(w)
+	return v, err
+}
+
+func _QueryConsistencyLevel_Read(w wire.Value) (QueryConsistencyLevel, error) {
+	var v QueryConsistencyLevel
+	err := v.FromWire(w)
+	return v, err
+}
+
 // FromWire deserializes a QueryWorkflowRequest struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
@@ -19257,6 +22322,26 @@ func (v *QueryWorkflowRequest) FromWire(w wire.Value) error {
 					return err
 				}
 
+			}
+		case 40:
+			if field.Value.Type() == wire.TI32 {
+				var x QueryRejectCondition
+				x, err = _QueryRejectCondition_Read(field.Value)
+				v.QueryRejectCondition = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 50:
+			if field.Value.Type() == wire.TI32 {
+				var x QueryConsistencyLevel
+				x, err = _QueryConsistencyLevel_Read(field.Value)
+				v.QueryConsistencyLevel = &x
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -19271,7 +22356,7 @@ func (v *QueryWorkflowRequest) String() string {
 		return "<nil>"
 	}
 
-	var fields [3]string
+	var fields [5]string
 	i := 0
 	if v.Domain != nil {
 		fields[i] = fmt.Sprintf("Domain: %v", *(v.Domain))
@@ -19285,10 +22370,38 @@ func (v *QueryWorkflowRequest) String() string {
 		fields[i] = fmt.Sprintf("Query: %v", v.Query)
 		i++
 	}
+	if v.QueryRejectCondition != nil {
+		fields[i] = fmt.Sprintf("QueryRejectCondition: %v", *(v.QueryRejectCondition))
+		i++
+	}
+	if v.QueryConsistencyLevel != nil {
+		fields[i] = fmt.Sprintf("QueryConsistencyLevel: %v", *(v.QueryConsistencyLevel))
+		i++
+	}
 
 	return fmt.Sprintf("QueryWorkflowRequest{%v}", strings.Join(fields[:i], ", "))
 }
 
+func _QueryRejectCondition_EqualsPtr(lhs, rhs *QueryRejectCondition) bool {
+	if lhs != nil && rhs != nil {
+
+		x := *lhs
+		y := *rhs
+		return x.Equals(y)
+	}
+	return lhs == nil && rhs == nil
+}
+
+func _QueryConsistencyLevel_EqualsPtr(lhs, rhs *QueryConsistencyLevel) bool {
+	if lhs != nil && rhs != nil {
+
+		x := *lhs
+		y := *rhs
+		return x.Equals(y)
+	}
+	return lhs == nil && rhs == nil
+}
+
 // Equals returns true if all the fields of this QueryWorkflowRequest match the
 // provided QueryWorkflowRequest.
 //
@@ -19303,6 +22416,12 @@ func (v *QueryWorkflowRequest) Equals(rhs *QueryWorkflowRequest) bool {
 	if !((v.Query == nil && rhs.Query == nil) || (v.Query != nil && rhs.Query != nil && v.Query.Equals(rhs.Query))) {
 		return false
 	}
+	if !_QueryRejectCondition_EqualsPtr(v.QueryRejectCondition, rhs.QueryRejectCondition) {
+		return false
+	}
+	if !_QueryConsistencyLevel_EqualsPtr(v.QueryConsistencyLevel, rhs.QueryConsistencyLevel) {
+		return false
+	}
 
 	return true
 }
@@ -19337,8 +22456,30 @@ func (v *QueryWorkflowRequest) GetQuery() (o *WorkflowQuery) {
 	return
 }
 
+// GetQueryRejectCondition returns the value of QueryRejectCondition if it is set or its
+// zero value if it is unset.
+func (v *QueryWorkflowRequest) GetQueryRejectCondition() (o QueryRejectCondition) {
+	if v.QueryRejectCondition != nil {
+		return *v.QueryRejectCondition
+	}
+
+	return
+}
+
+// GetQueryConsistencyLevel returns the value of QueryConsistencyLevel if it is set or its
+// zero value if it is unset.
+func (v *QueryWorkflowRequest) GetQueryConsistencyLevel() (o QueryConsistencyLevel) {
+	if v.QueryConsistencyLevel != nil {
+		return *v.QueryConsistencyLevel
+	}
+
+	return
+}
+
 type QueryWorkflowResponse struct {
-	QueryResult []byte `json:"queryResult,omitempty"`
+	QueryResult   []byte         `json:"queryResult,omitempty"`
+	QueryRejected *QueryRejected `json:"queryRejected,omitempty"`
+	StaleReadInfo *StaleReadInfo `json:"staleReadInfo,omitempty"`
 }
 
 // ToWire translates a QueryWorkflowResponse struct into a Thrift-level intermediate
@@ -19358,7 +22499,7 @@ type QueryWorkflowResponse struct {
 //   }
 func (v *QueryWorkflowResponse) ToWire() (wire.Value, error) {
 	var (
-		fields [1]wire.Field
+		fields [3]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -19372,10 +22513,32 @@ func (v *QueryWorkflowResponse) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 10, Value: w}
 		i++
 	}
+	if v.QueryRejected != nil {
+		w, err = v.QueryRejected.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+	if v.StaleReadInfo != nil {
+		w, err = v.StaleReadInfo.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
+func _QueryRejected_Read(w wire.Value) (*QueryRejected, error) {
+	var v QueryRejected
+	err := v.FromWire(w)
+	return &v, err
+}
+
 // FromWire deserializes a QueryWorkflowResponse struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
@@ -19405,6 +22568,22 @@ func (v *QueryWorkflowResponse) FromWire(w wire.Value) error {
 					return err
 				}
 
+			}
+		case 20:
+			if field.Value.Type() == wire.TStruct {
+				v.QueryRejected, err = _QueryRejected_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 30:
+			if field.Value.Type() == wire.TStruct {
+				v.StaleReadInfo, err = _StaleReadInfo_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -19419,12 +22598,20 @@ func (v *QueryWorkflowResponse) String() string {
 		return "<nil>"
 	}
 
-	var fields [1]string
+	var fields [3]string
 	i := 0
 	if v.QueryResult != nil {
 		fields[i] = fmt.Sprintf("QueryResult: %v", v.QueryResult)
 		i++
 	}
+	if v.QueryRejected != nil {
+		fields[i] = fmt.Sprintf("QueryRejected: %v", v.QueryRejected)
+		i++
+	}
+	if v.StaleReadInfo != nil {
+		fields[i] = fmt.Sprintf("StaleReadInfo: %v", v.StaleReadInfo)
+		i++
+	}
 
 	return fmt.Sprintf("QueryWorkflowResponse{%v}", strings.Join(fields[:i], ", "))
 }
@@ -19437,6 +22624,12 @@ func (v *QueryWorkflowResponse) Equals(rhs *QueryWorkflowResponse) bool {
 	if !((v.QueryResult == nil && rhs.QueryResult == nil) || (v.QueryResult != nil && rhs.QueryResult != nil && bytes.Equal(v.QueryResult, rhs.QueryResult))) {
 		return false
 	}
+	if !((v.QueryRejected == nil && rhs.QueryRejected == nil) || (v.QueryRejected != nil && rhs.QueryRejected != nil && v.QueryRejected.Equals(rhs.QueryRejected))) {
+		return false
+	}
+	if !((v.StaleReadInfo == nil && rhs.StaleReadInfo == nil) || (v.StaleReadInfo != nil && rhs.StaleReadInfo != nil && v.StaleReadInfo.Equals(rhs.StaleReadInfo))) {
+		return false
+	}
 
 	return true
 }
@@ -19451,6 +22644,26 @@ func (v *QueryWorkflowResponse) GetQueryResult() (o []byte) {
 	return
 }
 
+// GetQueryRejected returns the value of QueryRejected if it is set or its
+// zero value if it is unset.
+func (v *QueryWorkflowResponse) GetQueryRejected() (o *QueryRejected) {
+	if v.QueryRejected != nil {
+		return v.QueryRejected
+	}
+
+	return
+}
+
+// GetStaleReadInfo returns the value of StaleReadInfo if it is set or its
+// zero value if it is unset.
+func (v *QueryWorkflowResponse) GetStaleReadInfo() (o *StaleReadInfo) {
+	if v.StaleReadInfo != nil {
+		return v.StaleReadInfo
+	}
+
+	return
+}
+
 type RecordActivityTaskHeartbeatByIDRequest struct {
 	Domain     *string `json:"domain,omitempty"`
 	WorkflowID *string `json:"workflowID,omitempty"`
@@ -20593,6 +23806,158 @@ func (v *RegisterDomainRequest) GetData() (o map[string]string) {
 	return
 }
 
+type ReplicationInfo struct {
+	Version     *int64 `json:"version,omitempty"`
+	LastEventId *int64 `json:"lastEventId,omitempty"`
+}
+
+// ToWire translates a ReplicationInfo struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *ReplicationInfo) ToWire() (wire.Value, error) {
+	var (
+		fields [2]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Version != nil {
+		w, err = wire.NewValueI64(*(v.Version)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.LastEventId != nil {
+		w, err = wire.NewValueI64(*(v.LastEventId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a ReplicationInfo struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a ReplicationInfo struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v ReplicationInfo
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *ReplicationInfo) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.Version = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.LastEventId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a ReplicationInfo
+// struct.
+func (v *ReplicationInfo) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [2]string
+	i := 0
+	if v.Version != nil {
+		fields[i] = fmt.Sprintf("Version: %v", *(v.Version))
+		i++
+	}
+	if v.LastEventId != nil {
+		fields[i] = fmt.Sprintf("LastEventId: %v", *(v.LastEventId))
+		i++
+	}
+
+	return fmt.Sprintf("ReplicationInfo{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this ReplicationInfo match the
+// provided ReplicationInfo.
+//
+// This function performs a deep comparison.
+func (v *ReplicationInfo) Equals(rhs *ReplicationInfo) bool {
+	if !_I64_EqualsPtr(v.Version, rhs.Version) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.LastEventId, rhs.LastEventId) {
+		return false
+	}
+
+	return true
+}
+
+// GetVersion returns the value of Version if it is set or its
+// zero value if it is unset.
+func (v *ReplicationInfo) GetVersion() (o int64) {
+	if v.Version != nil {
+		return *v.Version
+	}
+
+	return
+}
+
+// GetLastEventId returns the value of LastEventId if it is set or its
+// zero value if it is unset.
+func (v *ReplicationInfo) GetLastEventId() (o int64) {
+	if v.LastEventId != nil {
+		return *v.LastEventId
+	}
+
+	return
+}
+
 type RequestCancelActivityTaskDecisionAttributes struct {
 	ActivityId *string `json:"activityId,omitempty"`
 }
@@ -24647,6 +28012,228 @@ func (v *RespondQueryTaskCompletedRequest) GetErrorMessage() (o string) {
 	return
 }
 
+type RespondWorkflowUpdateDecisionAttributes struct {
+	UpdateId        *string `json:"updateId,omitempty"`
+	Accepted        *bool   `json:"accepted,omitempty"`
+	Result          []byte  `json:"result,omitempty"`
+	RejectionReason *string `json:"rejectionReason,omitempty"`
+}
+
+// ToWire translates a RespondWorkflowUpdateDecisionAttributes struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *RespondWorkflowUpdateDecisionAttributes) ToWire() (wire.Value, error) {
+	var (
+		fields [4]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.UpdateId != nil {
+		w, err = wire.NewValueString(*(v.UpdateId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.Accepted != nil {
+		w, err = wire.NewValueBool(*(v.Accepted)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+	if v.Result != nil {
+		w, err = wire.NewValueBinary(v.Result), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+	if v.RejectionReason != nil {
+		w, err = wire.NewValueString(*(v.RejectionReason)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 40, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a RespondWorkflowUpdateDecisionAttributes struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a RespondWorkflowUpdateDecisionAttributes struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v RespondWorkflowUpdateDecisionAttributes
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *RespondWorkflowUpdateDecisionAttributes) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.UpdateId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TBool {
+				var x bool
+				x, err = field.Value.GetBool(), error(nil)
+				v.Accepted = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 30:
+			if field.Value.Type() == wire.TBinary {
+				v.Result, err = field.Value.GetBinary(), error(nil)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 40:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.RejectionReason = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a RespondWorkflowUpdateDecisionAttributes
+// struct.
+func (v *RespondWorkflowUpdateDecisionAttributes) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [4]string
+	i := 0
+	if v.UpdateId != nil {
+		fields[i] = fmt.Sprintf("UpdateId: %v", *(v.UpdateId))
+		i++
+	}
+	if v.Accepted != nil {
+		fields[i] = fmt.Sprintf("Accepted: %v", *(v.Accepted))
+		i++
+	}
+	if v.Result != nil {
+		fields[i] = fmt.Sprintf("Result: %v", v.Result)
+		i++
+	}
+	if v.RejectionReason != nil {
+		fields[i] = fmt.Sprintf("RejectionReason: %v", *(v.RejectionReason))
+		i++
+	}
+
+	return fmt.Sprintf("RespondWorkflowUpdateDecisionAttributes{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this RespondWorkflowUpdateDecisionAttributes match the
+// provided RespondWorkflowUpdateDecisionAttributes.
+//
+// This function performs a deep comparison.
+func (v *RespondWorkflowUpdateDecisionAttributes) Equals(rhs *RespondWorkflowUpdateDecisionAttributes) bool {
+	if !_String_EqualsPtr(v.UpdateId, rhs.UpdateId) {
+		return false
+	}
+	if !_Bool_EqualsPtr(v.Accepted, rhs.Accepted) {
+		return false
+	}
+	if !((v.Result == nil && rhs.Result == nil) || (v.Result != nil && rhs.Result != nil && bytes.Equal(v.Result, rhs.Result))) {
+		return false
+	}
+	if !_String_EqualsPtr(v.RejectionReason, rhs.RejectionReason) {
+		return false
+	}
+
+	return true
+}
+
+// GetUpdateId returns the value of UpdateId if it is set or its
+// zero value if it is unset.
+func (v *RespondWorkflowUpdateDecisionAttributes) GetUpdateId() (o string) {
+	if v.UpdateId != nil {
+		return *v.UpdateId
+	}
+
+	return
+}
+
+// GetAccepted returns the value of Accepted if it is set or its
+// zero value if it is unset.
+func (v *RespondWorkflowUpdateDecisionAttributes) GetAccepted() (o bool) {
+	if v.Accepted != nil {
+		return *v.Accepted
+	}
+
+	return
+}
+
+// GetResult returns the value of Result if it is set or its
+// zero value if it is unset.
+func (v *RespondWorkflowUpdateDecisionAttributes) GetResult() (o []byte) {
+	if v.Result != nil {
+		return v.Result
+	}
+
+	return
+}
+
+// GetRejectionReason returns the value of RejectionReason if it is set or its
+// zero value if it is unset.
+func (v *RespondWorkflowUpdateDecisionAttributes) GetRejectionReason() (o string) {
+	if v.RejectionReason != nil {
+		return *v.RejectionReason
+	}
+
+	return
+}
+
 type RetryPolicy struct {
 	InitialIntervalInSeconds    *int32   `json:"initialIntervalInSeconds,omitempty"`
 	BackoffCoefficient          *float64 `json:"backoffCoefficient,omitempty"`
@@ -25011,7 +28598,11 @@ func (v *RetryPolicy) GetExpirationIntervalInSeconds() (o int32) {
 }
 
 type RetryTaskError struct {
-	Message string `json:"message,required"`
+	Message     string  `json:"message,required"`
+	DomainId    *string `json:"domainId,omitempty"`
+	WorkflowId  *string `json:"workflowId,omitempty"`
+	RunId       *string `json:"runId,omitempty"`
+	NextEventId *int64  `json:"nextEventId,omitempty"`
 }
 
 // ToWire translates a RetryTaskError struct into a Thrift-level intermediate
@@ -25031,7 +28622,7 @@ type RetryTaskError struct {
 //   }
 func (v *RetryTaskError) ToWire() (wire.Value, error) {
 	var (
-		fields [1]wire.Field
+		fields [5]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -25043,6 +28634,38 @@ func (v *RetryTaskError) ToWire() (wire.Value, error) {
 	}
 	fields[i] = wire.Field{ID: 1, Value: w}
 	i++
+	if v.DomainId != nil {
+		w, err = wire.NewValueString(*(v.DomainId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 2, Value: w}
+		i++
+	}
+	if v.WorkflowId != nil {
+		w, err = wire.NewValueString(*(v.WorkflowId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 3, Value: w}
+		i++
+	}
+	if v.RunId != nil {
+		w, err = wire.NewValueString(*(v.RunId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 4, Value: w}
+		i++
+	}
+	if v.NextEventId != nil {
+		w, err = wire.NewValueI64(*(v.NextEventId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 5, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
@@ -25079,6 +28702,46 @@ func (v *RetryTaskError) FromWire(w wire.Value) error {
 				}
 				messageIsSet = true
 			}
+		case 2:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.DomainId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 3:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.WorkflowId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 4:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.RunId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 5:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.NextEventId = &x
+				if err != nil {
+					return err
+				}
+
+			}
 		}
 	}
 
@@ -25096,10 +28759,26 @@ func (v *RetryTaskError) String() string {
 		return "<nil>"
 	}
 
-	var fields [1]string
+	var fields [5]string
 	i := 0
 	fields[i] = fmt.Sprintf("Message: %v", v.Message)
 	i++
+	if v.DomainId != nil {
+		fields[i] = fmt.Sprintf("DomainId: %v", *(v.DomainId))
+		i++
+	}
+	if v.WorkflowId != nil {
+		fields[i] = fmt.Sprintf("WorkflowId: %v", *(v.WorkflowId))
+		i++
+	}
+	if v.RunId != nil {
+		fields[i] = fmt.Sprintf("RunId: %v", *(v.RunId))
+		i++
+	}
+	if v.NextEventId != nil {
+		fields[i] = fmt.Sprintf("NextEventId: %v", *(v.NextEventId))
+		i++
+	}
 
 	return fmt.Sprintf("RetryTaskError{%v}", strings.Join(fields[:i], ", "))
 }
@@ -25112,6 +28791,18 @@ func (v *RetryTaskError) Equals(rhs *RetryTaskError) bool {
 	if !(v.Message == rhs.Message) {
 		return false
 	}
+	if !_String_EqualsPtr(v.DomainId, rhs.DomainId) {
+		return false
+	}
+	if !_String_EqualsPtr(v.WorkflowId, rhs.WorkflowId) {
+		return false
+	}
+	if !_String_EqualsPtr(v.RunId, rhs.RunId) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.NextEventId, rhs.NextEventId) {
+		return false
+	}
 
 	return true
 }
@@ -25120,6 +28811,46 @@ func (v *RetryTaskError) Equals(rhs *RetryTaskError) bool {
 // zero value if it is unset.
 func (v *RetryTaskError) GetMessage() (o string) { return v.Message }
 
+// GetDomainId returns the value of DomainId if it is set or its
+// zero value if it is unset.
+func (v *RetryTaskError) GetDomainId() (o string) {
+	if v.DomainId != nil {
+		return *v.DomainId
+	}
+
+	return
+}
+
+// GetWorkflowId returns the value of WorkflowId if it is set or its
+// zero value if it is unset.
+func (v *RetryTaskError) GetWorkflowId() (o string) {
+	if v.WorkflowId != nil {
+		return *v.WorkflowId
+	}
+
+	return
+}
+
+// GetRunId returns the value of RunId if it is set or its
+// zero value if it is unset.
+func (v *RetryTaskError) GetRunId() (o string) {
+	if v.RunId != nil {
+		return *v.RunId
+	}
+
+	return
+}
+
+// GetNextEventId returns the value of NextEventId if it is set or its
+// zero value if it is unset.
+func (v *RetryTaskError) GetNextEventId() (o int64) {
+	if v.NextEventId != nil {
+		return *v.NextEventId
+	}
+
+	return
+}
+
 func (v *RetryTaskError) Error() string {
 	return v.String()
 }
@@ -25135,6 +28866,8 @@ type ScheduleActivityTaskDecisionAttributes struct {
 	StartToCloseTimeoutSeconds    *int32        `json:"startToCloseTimeoutSeconds,omitempty"`
 	HeartbeatTimeoutSeconds       *int32        `json:"heartbeatTimeoutSeconds,omitempty"`
 	RetryPolicy                   *RetryPolicy  `json:"retryPolicy,omitempty"`
+	FairnessKey                   *string       `json:"fairnessKey,omitempty"`
+	FallbackTaskLists             []*TaskList   `json:"fallbackTaskLists,omitempty"`
 }
 
 // ToWire translates a ScheduleActivityTaskDecisionAttributes struct into a Thrift-level intermediate
@@ -25154,7 +28887,7 @@ type ScheduleActivityTaskDecisionAttributes struct {
 //   }
 func (v *ScheduleActivityTaskDecisionAttributes) ToWire() (wire.Value, error) {
 	var (
-		fields [10]wire.Field
+		fields [12]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -25240,6 +28973,22 @@ func (v *ScheduleActivityTaskDecisionAttributes) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 70, Value: w}
 		i++
 	}
+	if v.FairnessKey != nil {
+		w, err = wire.NewValueString(*(v.FairnessKey)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 80, Value: w}
+		i++
+	}
+	if v.FallbackTaskLists != nil {
+		w, err = wire.NewValueList(_List_TaskList_ValueList(v.FallbackTaskLists)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 90, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
@@ -25357,6 +29106,24 @@ func (v *ScheduleActivityTaskDecisionAttributes) FromWire(w wire.Value) error {
 					return err
 				}
 
+			}
+		case 80:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.FairnessKey = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 90:
+			if field.Value.Type() == wire.TList {
+				v.FallbackTaskLists, err = _List_TaskList_Read(field.Value.GetList())
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -25371,7 +29138,7 @@ func (v *ScheduleActivityTaskDecisionAttributes) String() string {
 		return "<nil>"
 	}
 
-	var fields [10]string
+	var fields [12]string
 	i := 0
 	if v.ActivityId != nil {
 		fields[i] = fmt.Sprintf("ActivityId: %v", *(v.ActivityId))
@@ -25413,6 +29180,14 @@ func (v *ScheduleActivityTaskDecisionAttributes) String() string {
 		fields[i] = fmt.Sprintf("RetryPolicy: %v", v.RetryPolicy)
 		i++
 	}
+	if v.FairnessKey != nil {
+		fields[i] = fmt.Sprintf("FairnessKey: %v", *(v.FairnessKey))
+		i++
+	}
+	if v.FallbackTaskLists != nil {
+		fields[i] = fmt.Sprintf("FallbackTaskLists: %v", v.FallbackTaskLists)
+		i++
+	}
 
 	return fmt.Sprintf("ScheduleActivityTaskDecisionAttributes{%v}", strings.Join(fields[:i], ", "))
 }
@@ -25452,6 +29227,12 @@ func (v *ScheduleActivityTaskDecisionAttributes) Equals(rhs *ScheduleActivityTas
 	if !((v.RetryPolicy == nil && rhs.RetryPolicy == nil) || (v.RetryPolicy != nil && rhs.RetryPolicy != nil && v.RetryPolicy.Equals(rhs.RetryPolicy))) {
 		return false
 	}
+	if !_String_EqualsPtr(v.FairnessKey, rhs.FairnessKey) {
+		return false
+	}
+	if !((v.FallbackTaskLists == nil && rhs.FallbackTaskLists == nil) || (v.FallbackTaskLists != nil && rhs.FallbackTaskLists != nil && _List_TaskList_Equals(v.FallbackTaskLists, rhs.FallbackTaskLists))) {
+		return false
+	}
 
 	return true
 }
@@ -25556,6 +29337,26 @@ func (v *ScheduleActivityTaskDecisionAttributes) GetRetryPolicy() (o *RetryPolic
 	return
 }
 
+// GetFairnessKey returns the value of FairnessKey if it is set or its
+// zero value if it is unset.
+func (v *ScheduleActivityTaskDecisionAttributes) GetFairnessKey() (o string) {
+	if v.FairnessKey != nil {
+		return *v.FairnessKey
+	}
+
+	return
+}
+
+// GetFallbackTaskLists returns the value of FallbackTaskLists if it is set or its
+// zero value if it is unset.
+func (v *ScheduleActivityTaskDecisionAttributes) GetFallbackTaskLists() (o []*TaskList) {
+	if v.FallbackTaskLists != nil {
+		return v.FallbackTaskLists
+	}
+
+	return
+}
+
 type ServiceBusyError struct {
 	Message string `json:"message,required"`
 }
@@ -27648,6 +31449,158 @@ func (v *SignalWorkflowExecutionRequest) GetControl() (o []byte) {
 	return
 }
 
+type StaleReadInfo struct {
+	ActiveClusterName       *string `json:"activeClusterName,omitempty"`
+	ReplicationLagInSeconds *int64  `json:"replicationLagInSeconds,omitempty"`
+}
+
+// ToWire translates a StaleReadInfo struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *StaleReadInfo) ToWire() (wire.Value, error) {
+	var (
+		fields [2]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.ActiveClusterName != nil {
+		w, err = wire.NewValueString(*(v.ActiveClusterName)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.ReplicationLagInSeconds != nil {
+		w, err = wire.NewValueI64(*(v.ReplicationLagInSeconds)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a StaleReadInfo struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a StaleReadInfo struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v StaleReadInfo
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *StaleReadInfo) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.ActiveClusterName = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.ReplicationLagInSeconds = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a StaleReadInfo
+// struct.
+func (v *StaleReadInfo) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [2]string
+	i := 0
+	if v.ActiveClusterName != nil {
+		fields[i] = fmt.Sprintf("ActiveClusterName: %v", *(v.ActiveClusterName))
+		i++
+	}
+	if v.ReplicationLagInSeconds != nil {
+		fields[i] = fmt.Sprintf("ReplicationLagInSeconds: %v", *(v.ReplicationLagInSeconds))
+		i++
+	}
+
+	return fmt.Sprintf("StaleReadInfo{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this StaleReadInfo match the
+// provided StaleReadInfo.
+//
+// This function performs a deep comparison.
+func (v *StaleReadInfo) Equals(rhs *StaleReadInfo) bool {
+	if !_String_EqualsPtr(v.ActiveClusterName, rhs.ActiveClusterName) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.ReplicationLagInSeconds, rhs.ReplicationLagInSeconds) {
+		return false
+	}
+
+	return true
+}
+
+// GetActiveClusterName returns the value of ActiveClusterName if it is set or its
+// zero value if it is unset.
+func (v *StaleReadInfo) GetActiveClusterName() (o string) {
+	if v.ActiveClusterName != nil {
+		return *v.ActiveClusterName
+	}
+
+	return
+}
+
+// GetReplicationLagInSeconds returns the value of ReplicationLagInSeconds if it is set or its
+// zero value if it is unset.
+func (v *StaleReadInfo) GetReplicationLagInSeconds() (o int64) {
+	if v.ReplicationLagInSeconds != nil {
+		return *v.ReplicationLagInSeconds
+	}
+
+	return
+}
+
 type StartChildWorkflowExecutionDecisionAttributes struct {
 	Domain                              *string                `json:"domain,omitempty"`
 	WorkflowId                          *string                `json:"workflowId,omitempty"`
@@ -30485,6 +34438,264 @@ func (v *TaskListMetadata) GetMaxTasksPerSecond() (o float64) {
 	return
 }
 
+type TaskListStatus struct {
+	TaskList       *TaskList     `json:"taskList,omitempty"`
+	TaskListType   *TaskListType `json:"taskListType,omitempty"`
+	AckLevel       *int64        `json:"ackLevel,omitempty"`
+	LastUpdateTime *int64        `json:"lastUpdateTime,omitempty"`
+	Loaded         *bool         `json:"loaded,omitempty"`
+}
+
+// ToWire translates a TaskListStatus struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *TaskListStatus) ToWire() (wire.Value, error) {
+	var (
+		fields [5]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.TaskList != nil {
+		w, err = v.TaskList.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.TaskListType != nil {
+		w, err = v.TaskListType.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+	if v.AckLevel != nil {
+		w, err = wire.NewValueI64(*(v.AckLevel)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+	if v.LastUpdateTime != nil {
+		w, err = wire.NewValueI64(*(v.LastUpdateTime)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 40, Value: w}
+		i++
+	}
+	if v.Loaded != nil {
+		w, err = wire.NewValueBool(*(v.Loaded)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 50, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a TaskListStatus struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a TaskListStatus struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v TaskListStatus
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *TaskListStatus) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TStruct {
+				v.TaskList, err = _TaskList_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TI32 {
+				var x TaskListType
+				x, err = _TaskListType_Read(field.Value)
+				v.TaskListType = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 30:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.AckLevel = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 40:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.LastUpdateTime = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 50:
+			if field.Value.Type() == wire.TBool {
+				var x bool
+				x, err = field.Value.GetBool(), error(nil)
+				v.Loaded = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a TaskListStatus
+// struct.
+func (v *TaskListStatus) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [5]string
+	i := 0
+	if v.TaskList != nil {
+		fields[i] = fmt.Sprintf("TaskList: %v", v.TaskList)
+		i++
+	}
+	if v.TaskListType != nil {
+		fields[i] = fmt.Sprintf("TaskListType: %v", *(v.TaskListType))
+		i++
+	}
+	if v.AckLevel != nil {
+		fields[i] = fmt.Sprintf("AckLevel: %v", *(v.AckLevel))
+		i++
+	}
+	if v.LastUpdateTime != nil {
+		fields[i] = fmt.Sprintf("LastUpdateTime: %v", *(v.LastUpdateTime))
+		i++
+	}
+	if v.Loaded != nil {
+		fields[i] = fmt.Sprintf("Loaded: %v", *(v.Loaded))
+		i++
+	}
+
+	return fmt.Sprintf("TaskListStatus{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this TaskListStatus match the
+// provided TaskListStatus.
+//
+// This function performs a deep comparison.
+func (v *TaskListStatus) Equals(rhs *TaskListStatus) bool {
+	if !((v.TaskList == nil && rhs.TaskList == nil) || (v.TaskList != nil && rhs.TaskList != nil && v.TaskList.Equals(rhs.TaskList))) {
+		return false
+	}
+	if !_TaskListType_EqualsPtr(v.TaskListType, rhs.TaskListType) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.AckLevel, rhs.AckLevel) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.LastUpdateTime, rhs.LastUpdateTime) {
+		return false
+	}
+	if !_Bool_EqualsPtr(v.Loaded, rhs.Loaded) {
+		return false
+	}
+
+	return true
+}
+
+// GetTaskList returns the value of TaskList if it is set or its
+// zero value if it is unset.
+func (v *TaskListStatus) GetTaskList() (o *TaskList) {
+	if v.TaskList != nil {
+		return v.TaskList
+	}
+
+	return
+}
+
+// GetTaskListType returns the value of TaskListType if it is set or its
+// zero value if it is unset.
+func (v *TaskListStatus) GetTaskListType() (o TaskListType) {
+	if v.TaskListType != nil {
+		return *v.TaskListType
+	}
+
+	return
+}
+
+// GetAckLevel returns the value of AckLevel if it is set or its
+// zero value if it is unset.
+func (v *TaskListStatus) GetAckLevel() (o int64) {
+	if v.AckLevel != nil {
+		return *v.AckLevel
+	}
+
+	return
+}
+
+// GetLastUpdateTime returns the value of LastUpdateTime if it is set or its
+// zero value if it is unset.
+func (v *TaskListStatus) GetLastUpdateTime() (o int64) {
+	if v.LastUpdateTime != nil {
+		return *v.LastUpdateTime
+	}
+
+	return
+}
+
+// GetLoaded returns the value of Loaded if it is set or its
+// zero value if it is unset.
+func (v *TaskListStatus) GetLoaded() (o bool) {
+	if v.Loaded != nil {
+		return *v.Loaded
+	}
+
+	return
+}
+
 type TaskListType int32
 
 const (
@@ -31968,6 +36179,9 @@ type UpdateDomainRequest struct {
 	UpdatedInfo              *UpdateDomainInfo               `json:"updatedInfo,omitempty"`
 	Configuration            *DomainConfiguration            `json:"configuration,omitempty"`
 	ReplicationConfiguration *DomainReplicationConfiguration `json:"replicationConfiguration,omitempty"`
+	FailoverTimeoutInSeconds *int32                          `json:"failoverTimeoutInSeconds,omitempty"`
+	FailoverReason           *string                         `json:"failoverReason,omitempty"`
+	Identity                 *string                         `json:"identity,omitempty"`
 }
 
 // ToWire translates a UpdateDomainRequest struct into a Thrift-level intermediate
@@ -31987,7 +36201,7 @@ type UpdateDomainRequest struct {
 //   }
 func (v *UpdateDomainRequest) ToWire() (wire.Value, error) {
 	var (
-		fields [4]wire.Field
+		fields [7]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -32025,6 +36239,30 @@ func (v *UpdateDomainRequest) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 40, Value: w}
 		i++
 	}
+	if v.FailoverTimeoutInSeconds != nil {
+		w, err = wire.NewValueI32(*(v.FailoverTimeoutInSeconds)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 50, Value: w}
+		i++
+	}
+	if v.FailoverReason != nil {
+		w, err = wire.NewValueString(*(v.FailoverReason)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 60, Value: w}
+		i++
+	}
+	if v.Identity != nil {
+		w, err = wire.NewValueString(*(v.Identity)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 70, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
@@ -32090,6 +36328,36 @@ func (v *UpdateDomainRequest) FromWire(w wire.Value) error {
 					return err
 				}
 
+			}
+		case 50:
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.FailoverTimeoutInSeconds = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 60:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.FailoverReason = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 70:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Identity = &x
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -32104,7 +36372,7 @@ func (v *UpdateDomainRequest) String() string {
 		return "<nil>"
 	}
 
-	var fields [4]string
+	var fields [7]string
 	i := 0
 	if v.Name != nil {
 		fields[i] = fmt.Sprintf("Name: %v", *(v.Name))
@@ -32122,6 +36390,18 @@ func (v *UpdateDomainRequest) String() string {
 		fields[i] = fmt.Sprintf("ReplicationConfiguration: %v", v.ReplicationConfiguration)
 		i++
 	}
+	if v.FailoverTimeoutInSeconds != nil {
+		fields[i] = fmt.Sprintf("FailoverTimeoutInSeconds: %v", *(v.FailoverTimeoutInSeconds))
+		i++
+	}
+	if v.FailoverReason != nil {
+		fields[i] = fmt.Sprintf("FailoverReason: %v", *(v.FailoverReason))
+		i++
+	}
+	if v.Identity != nil {
+		fields[i] = fmt.Sprintf("Identity: %v", *(v.Identity))
+		i++
+	}
 
 	return fmt.Sprintf("UpdateDomainRequest{%v}", strings.Join(fields[:i], ", "))
 }
@@ -32143,6 +36423,15 @@ func (v *UpdateDomainRequest) Equals(rhs *UpdateDomainRequest) bool {
 	if !((v.ReplicationConfiguration == nil && rhs.ReplicationConfiguration == nil) || (v.ReplicationConfiguration != nil && rhs.ReplicationConfiguration != nil && v.ReplicationConfiguration.Equals(rhs.ReplicationConfiguration))) {
 		return false
 	}
+	if !_I32_EqualsPtr(v.FailoverTimeoutInSeconds, rhs.FailoverTimeoutInSeconds) {
+		return false
+	}
+	if !_String_EqualsPtr(v.FailoverReason, rhs.FailoverReason) {
+		return false
+	}
+	if !_String_EqualsPtr(v.Identity, rhs.Identity) {
+		return false
+	}
 
 	return true
 }
@@ -32187,12 +36476,44 @@ func (v *UpdateDomainRequest) GetReplicationConfiguration() (o *DomainReplicatio
 	return
 }
 
+// GetFailoverTimeoutInSeconds returns the value of FailoverTimeoutInSeconds if it is set or its
+// zero value if it is unset.
+func (v *UpdateDomainRequest) GetFailoverTimeoutInSeconds() (o int32) {
+	if v.FailoverTimeoutInSeconds != nil {
+		return *v.FailoverTimeoutInSeconds
+	}
+
+	return
+}
+
+// GetFailoverReason returns the value of FailoverReason if it is set or its
+// zero value if it is unset.
+func (v *UpdateDomainRequest) GetFailoverReason() (o string) {
+	if v.FailoverReason != nil {
+		return *v.FailoverReason
+	}
+
+	return
+}
+
+// GetIdentity returns the value of Identity if it is set or its
+// zero value if it is unset.
+func (v *UpdateDomainRequest) GetIdentity() (o string) {
+	if v.Identity != nil {
+		return *v.Identity
+	}
+
+	return
+}
+
 type UpdateDomainResponse struct {
 	DomainInfo               *DomainInfo                     `json:"domainInfo,omitempty"`
 	Configuration            *DomainConfiguration            `json:"configuration,omitempty"`
 	ReplicationConfiguration *DomainReplicationConfiguration `json:"replicationConfiguration,omitempty"`
 	FailoverVersion          *int64                          `json:"failoverVersion,omitempty"`
 	IsGlobalDomain           *bool                           `json:"isGlobalDomain,omitempty"`
+	FailoverInfo             *FailoverInfo                   `json:"failoverInfo,omitempty"`
+	FailoverHistory          []*DomainFailoverEvent          `json:"failoverHistory,omitempty"`
 }
 
 // ToWire translates a UpdateDomainResponse struct into a Thrift-level intermediate
@@ -32212,7 +36533,7 @@ type UpdateDomainResponse struct {
 //   }
 func (v *UpdateDomainResponse) ToWire() (wire.Value, error) {
 	var (
-		fields [5]wire.Field
+		fields [7]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -32258,6 +36579,22 @@ func (v *UpdateDomainResponse) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 50, Value: w}
 		i++
 	}
+	if v.FailoverInfo != nil {
+		w, err = v.FailoverInfo.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 60, Value: w}
+		i++
+	}
+	if v.FailoverHistory != nil {
+		w, err = wire.NewValueList(_List_DomainFailoverEvent_ValueList(v.FailoverHistory)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 70, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
@@ -32327,6 +36664,22 @@ func (v *UpdateDomainResponse) FromWire(w wire.Value) error {
 					return err
 				}
 
+			}
+		case 60:
+			if field.Value.Type() == wire.TStruct {
+				v.FailoverInfo, err = _FailoverInfo_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 70:
+			if field.Value.Type() == wire.TList {
+				v.FailoverHistory, err = _List_DomainFailoverEvent_Read(field.Value.GetList())
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -32341,7 +36694,7 @@ func (v *UpdateDomainResponse) String() string {
 		return "<nil>"
 	}
 
-	var fields [5]string
+	var fields [7]string
 	i := 0
 	if v.DomainInfo != nil {
 		fields[i] = fmt.Sprintf("DomainInfo: %v", v.DomainInfo)
@@ -32363,6 +36716,14 @@ func (v *UpdateDomainResponse) String() string {
 		fields[i] = fmt.Sprintf("IsGlobalDomain: %v", *(v.IsGlobalDomain))
 		i++
 	}
+	if v.FailoverInfo != nil {
+		fields[i] = fmt.Sprintf("FailoverInfo: %v", v.FailoverInfo)
+		i++
+	}
+	if v.FailoverHistory != nil {
+		fields[i] = fmt.Sprintf("FailoverHistory: %v", v.FailoverHistory)
+		i++
+	}
 
 	return fmt.Sprintf("UpdateDomainResponse{%v}", strings.Join(fields[:i], ", "))
 }
@@ -32387,6 +36748,12 @@ func (v *UpdateDomainResponse) Equals(rhs *UpdateDomainResponse) bool {
 	if !_Bool_EqualsPtr(v.IsGlobalDomain, rhs.IsGlobalDomain) {
 		return false
 	}
+	if !((v.FailoverInfo == nil && rhs.FailoverInfo == nil) || (v.FailoverInfo != nil && rhs.FailoverInfo != nil && v.FailoverInfo.Equals(rhs.FailoverInfo))) {
+		return false
+	}
+	if !((v.FailoverHistory == nil && rhs.FailoverHistory == nil) || (v.FailoverHistory != nil && rhs.FailoverHistory != nil && _List_DomainFailoverEvent_Equals(v.FailoverHistory, rhs.FailoverHistory))) {
+		return false
+	}
 
 	return true
 }
@@ -32441,6 +36808,472 @@ func (v *UpdateDomainResponse) GetIsGlobalDomain() (o bool) {
 	return
 }
 
+// GetFailoverInfo returns the value of FailoverInfo if it is set or its
+// zero value if it is unset.
+func (v *UpdateDomainResponse) GetFailoverInfo() (o *FailoverInfo) {
+	if v.FailoverInfo != nil {
+		return v.FailoverInfo
+	}
+
+	return
+}
+
+// GetFailoverHistory returns the value of FailoverHistory if it is set or its
+// zero value if it is unset.
+func (v *UpdateDomainResponse) GetFailoverHistory() (o []*DomainFailoverEvent) {
+	if v.FailoverHistory != nil {
+		return v.FailoverHistory
+	}
+
+	return
+}
+
+type UpdateWorkflowExecutionRequest struct {
+	Domain            *string            `json:"domain,omitempty"`
+	WorkflowExecution *WorkflowExecution `json:"workflowExecution,omitempty"`
+	UpdateName        *string            `json:"updateName,omitempty"`
+	Input             []byte             `json:"input,omitempty"`
+	Identity          *string            `json:"identity,omitempty"`
+	RequestId         *string            `json:"requestId,omitempty"`
+}
+
+// ToWire translates a UpdateWorkflowExecutionRequest struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *UpdateWorkflowExecutionRequest) ToWire() (wire.Value, error) {
+	var (
+		fields [6]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Domain != nil {
+		w, err = wire.NewValueString(*(v.Domain)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.WorkflowExecution != nil {
+		w, err = v.WorkflowExecution.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+	if v.UpdateName != nil {
+		w, err = wire.NewValueString(*(v.UpdateName)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+	if v.Input != nil {
+		w, err = wire.NewValueBinary(v.Input), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 40, Value: w}
+		i++
+	}
+	if v.Identity != nil {
+		w, err = wire.NewValueString(*(v.Identity)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 50, Value: w}
+		i++
+	}
+	if v.RequestId != nil {
+		w, err = wire.NewValueString(*(v.RequestId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 60, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a UpdateWorkflowExecutionRequest struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a UpdateWorkflowExecutionRequest struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v UpdateWorkflowExecutionRequest
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *UpdateWorkflowExecutionRequest) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Domain = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TStruct {
+				v.WorkflowExecution, err = _WorkflowExecution_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 30:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.UpdateName = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 40:
+			if field.Value.Type() == wire.TBinary {
+				v.Input, err = field.Value.GetBinary(), error(nil)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 50:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Identity = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 60:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.RequestId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a UpdateWorkflowExecutionRequest
+// struct.
+func (v *UpdateWorkflowExecutionRequest) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [6]string
+	i := 0
+	if v.Domain != nil {
+		fields[i] = fmt.Sprintf("Domain: %v", *(v.Domain))
+		i++
+	}
+	if v.WorkflowExecution != nil {
+		fields[i] = fmt.Sprintf("WorkflowExecution: %v", v.WorkflowExecution)
+		i++
+	}
+	if v.UpdateName != nil {
+		fields[i] = fmt.Sprintf("UpdateName: %v", *(v.UpdateName))
+		i++
+	}
+	if v.Input != nil {
+		fields[i] = fmt.Sprintf("Input: %v", v.Input)
+		i++
+	}
+	if v.Identity != nil {
+		fields[i] = fmt.Sprintf("Identity: %v", *(v.Identity))
+		i++
+	}
+	if v.RequestId != nil {
+		fields[i] = fmt.Sprintf("RequestId: %v", *(v.RequestId))
+		i++
+	}
+
+	return fmt.Sprintf("UpdateWorkflowExecutionRequest{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this UpdateWorkflowExecutionRequest match the
+// provided UpdateWorkflowExecutionRequest.
+//
+// This function performs a deep comparison.
+func (v *UpdateWorkflowExecutionRequest) Equals(rhs *UpdateWorkflowExecutionRequest) bool {
+	if !_String_EqualsPtr(v.Domain, rhs.Domain) {
+		return false
+	}
+	if !((v.WorkflowExecution == nil && rhs.WorkflowExecution == nil) || (v.WorkflowExecution != nil && rhs.WorkflowExecution != nil && v.WorkflowExecution.Equals(rhs.WorkflowExecution))) {
+		return false
+	}
+	if !_String_EqualsPtr(v.UpdateName, rhs.UpdateName) {
+		return false
+	}
+	if !((v.Input == nil && rhs.Input == nil) || (v.Input != nil && rhs.Input != nil && bytes.Equal(v.Input, rhs.Input))) {
+		return false
+	}
+	if !_String_EqualsPtr(v.Identity, rhs.Identity) {
+		return false
+	}
+	if !_String_EqualsPtr(v.RequestId, rhs.RequestId) {
+		return false
+	}
+
+	return true
+}
+
+// GetDomain returns the value of Domain if it is set or its
+// zero value if it is unset.
+func (v *UpdateWorkflowExecutionRequest) GetDomain() (o string) {
+	if v.Domain != nil {
+		return *v.Domain
+	}
+
+	return
+}
+
+// GetWorkflowExecution returns the value of WorkflowExecution if it is set or its
+// zero value if it is unset.
+func (v *UpdateWorkflowExecutionRequest) GetWorkflowExecution() (o *WorkflowExecution) {
+	if v.WorkflowExecution != nil {
+		return v.WorkflowExecution
+	}
+
+	return
+}
+
+// GetUpdateName returns the value of UpdateName if it is set or its
+// zero value if it is unset.
+func (v *UpdateWorkflowExecutionRequest) GetUpdateName() (o string) {
+	if v.UpdateName != nil {
+		return *v.UpdateName
+	}
+
+	return
+}
+
+// GetInput returns the value of Input if it is set or its
+// zero value if it is unset.
+func (v *UpdateWorkflowExecutionRequest) GetInput() (o []byte) {
+	if v.Input != nil {
+		return v.Input
+	}
+
+	return
+}
+
+// GetIdentity returns the value of Identity if it is set or its
+// zero value if it is unset.
+func (v *UpdateWorkflowExecutionRequest) GetIdentity() (o string) {
+	if v.Identity != nil {
+		return *v.Identity
+	}
+
+	return
+}
+
+// GetRequestId returns the value of RequestId if it is set or its
+// zero value if it is unset.
+func (v *UpdateWorkflowExecutionRequest) GetRequestId() (o string) {
+	if v.RequestId != nil {
+		return *v.RequestId
+	}
+
+	return
+}
+
+type UpdateWorkflowExecutionResponse struct {
+	Result   []byte                  `json:"result,omitempty"`
+	Rejected *WorkflowUpdateRejected `json:"rejected,omitempty"`
+}
+
+// ToWire translates a UpdateWorkflowExecutionResponse struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *UpdateWorkflowExecutionResponse) ToWire() (wire.Value, error) {
+	var (
+		fields [2]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Result != nil {
+		w, err = wire.NewValueBinary(v.Result), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.Rejected != nil {
+		w, err = v.Rejected.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _WorkflowUpdateRejected_Read(w wire.Value) (*WorkflowUpdateRejected, error) {
+	var v WorkflowUpdateRejected
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a UpdateWorkflowExecutionResponse struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a UpdateWorkflowExecutionResponse struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v UpdateWorkflowExecutionResponse
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *UpdateWorkflowExecutionResponse) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				v.Result, err = field.Value.GetBinary(), error(nil)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TStruct {
+				v.Rejected, err = _WorkflowUpdateRejected_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a UpdateWorkflowExecutionResponse
+// struct.
+func (v *UpdateWorkflowExecutionResponse) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [2]string
+	i := 0
+	if v.Result != nil {
+		fields[i] = fmt.Sprintf("Result: %v", v.Result)
+		i++
+	}
+	if v.Rejected != nil {
+		fields[i] = fmt.Sprintf("Rejected: %v", v.Rejected)
+		i++
+	}
+
+	return fmt.Sprintf("UpdateWorkflowExecutionResponse{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this UpdateWorkflowExecutionResponse match the
+// provided UpdateWorkflowExecutionResponse.
+//
+// This function performs a deep comparison.
+func (v *UpdateWorkflowExecutionResponse) Equals(rhs *UpdateWorkflowExecutionResponse) bool {
+	if !((v.Result == nil && rhs.Result == nil) || (v.Result != nil && rhs.Result != nil && bytes.Equal(v.Result, rhs.Result))) {
+		return false
+	}
+	if !((v.Rejected == nil && rhs.Rejected == nil) || (v.Rejected != nil && rhs.Rejected != nil && v.Rejected.Equals(rhs.Rejected))) {
+		return false
+	}
+
+	return true
+}
+
+// GetResult returns the value of Result if it is set or its
+// zero value if it is unset.
+func (v *UpdateWorkflowExecutionResponse) GetResult() (o []byte) {
+	if v.Result != nil {
+		return v.Result
+	}
+
+	return
+}
+
+// GetRejected returns the value of Rejected if it is set or its
+// zero value if it is unset.
+func (v *UpdateWorkflowExecutionResponse) GetRejected() (o *WorkflowUpdateRejected) {
+	if v.Rejected != nil {
+		return v.Rejected
+	}
+
+	return
+}
+
 type WorkflowExecution struct {
 	WorkflowId *string `json:"workflowId,omitempty"`
 	RunId      *string `json:"runId,omitempty"`
@@ -35742,6 +40575,262 @@ func (v *WorkflowExecutionTimedOutEventAttributes) GetTimeoutType() (o TimeoutTy
 	return
 }
 
+type WorkflowExecutionUpdateAcceptedEventAttributes struct {
+	UpdateId                     *string `json:"updateId,omitempty"`
+	UpdateName                   *string `json:"updateName,omitempty"`
+	Input                        []byte  `json:"input,omitempty"`
+	Result                       []byte  `json:"result,omitempty"`
+	DecisionTaskCompletedEventId *int64  `json:"decisionTaskCompletedEventId,omitempty"`
+}
+
+// ToWire translates a WorkflowExecutionUpdateAcceptedEventAttributes struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *WorkflowExecutionUpdateAcceptedEventAttributes) ToWire() (wire.Value, error) {
+	var (
+		fields [5]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.UpdateId != nil {
+		w, err = wire.NewValueString(*(v.UpdateId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.UpdateName != nil {
+		w, err = wire.NewValueString(*(v.UpdateName)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+	if v.Input != nil {
+		w, err = wire.NewValueBinary(v.Input), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+	if v.Result != nil {
+		w, err = wire.NewValueBinary(v.Result), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 40, Value: w}
+		i++
+	}
+	if v.DecisionTaskCompletedEventId != nil {
+		w, err = wire.NewValueI64(*(v.DecisionTaskCompletedEventId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 50, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a WorkflowExecutionUpdateAcceptedEventAttributes struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a WorkflowExecutionUpdateAcceptedEventAttributes struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v WorkflowExecutionUpdateAcceptedEventAttributes
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *WorkflowExecutionUpdateAcceptedEventAttributes) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.UpdateId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.UpdateName = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 30:
+			if field.Value.Type() == wire.TBinary {
+				v.Input, err = field.Value.GetBinary(), error(nil)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 40:
+			if field.Value.Type() == wire.TBinary {
+				v.Result, err = field.Value.GetBinary(), error(nil)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 50:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.DecisionTaskCompletedEventId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a WorkflowExecutionUpdateAcceptedEventAttributes
+// struct.
+func (v *WorkflowExecutionUpdateAcceptedEventAttributes) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [5]string
+	i := 0
+	if v.UpdateId != nil {
+		fields[i] = fmt.Sprintf("UpdateId: %v", *(v.UpdateId))
+		i++
+	}
+	if v.UpdateName != nil {
+		fields[i] = fmt.Sprintf("UpdateName: %v", *(v.UpdateName))
+		i++
+	}
+	if v.Input != nil {
+		fields[i] = fmt.Sprintf("Input: %v", v.Input)
+		i++
+	}
+	if v.Result != nil {
+		fields[i] = fmt.Sprintf("Result: %v", v.Result)
+		i++
+	}
+	if v.DecisionTaskCompletedEventId != nil {
+		fields[i] = fmt.Sprintf("DecisionTaskCompletedEventId: %v", *(v.DecisionTaskCompletedEventId))
+		i++
+	}
+
+	return fmt.Sprintf("WorkflowExecutionUpdateAcceptedEventAttributes{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this WorkflowExecutionUpdateAcceptedEventAttributes match the
+// provided WorkflowExecutionUpdateAcceptedEventAttributes.
+//
+// This function performs a deep comparison.
+func (v *WorkflowExecutionUpdateAcceptedEventAttributes) Equals(rhs *WorkflowExecutionUpdateAcceptedEventAttributes) bool {
+	if !_String_EqualsPtr(v.UpdateId, rhs.UpdateId) {
+		return false
+	}
+	if !_String_EqualsPtr(v.UpdateName, rhs.UpdateName) {
+		return false
+	}
+	if !((v.Input == nil && rhs.Input == nil) || (v.Input != nil && rhs.Input != nil && bytes.Equal(v.Input, rhs.Input))) {
+		return false
+	}
+	if !((v.Result == nil && rhs.Result == nil) || (v.Result != nil && rhs.Result != nil && bytes.Equal(v.Result, rhs.Result))) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.DecisionTaskCompletedEventId, rhs.DecisionTaskCompletedEventId) {
+		return false
+	}
+
+	return true
+}
+
+// GetUpdateId returns the value of UpdateId if it is set or its
+// zero value if it is unset.
+func (v *WorkflowExecutionUpdateAcceptedEventAttributes) GetUpdateId() (o string) {
+	if v.UpdateId != nil {
+		return *v.UpdateId
+	}
+
+	return
+}
+
+// GetUpdateName returns the value of UpdateName if it is set or its
+// zero value if it is unset.
+func (v *WorkflowExecutionUpdateAcceptedEventAttributes) GetUpdateName() (o string) {
+	if v.UpdateName != nil {
+		return *v.UpdateName
+	}
+
+	return
+}
+
+// GetInput returns the value of Input if it is set or its
+// zero value if it is unset.
+func (v *WorkflowExecutionUpdateAcceptedEventAttributes) GetInput() (o []byte) {
+	if v.Input != nil {
+		return v.Input
+	}
+
+	return
+}
+
+// GetResult returns the value of Result if it is set or its
+// zero value if it is unset.
+func (v *WorkflowExecutionUpdateAcceptedEventAttributes) GetResult() (o []byte) {
+	if v.Result != nil {
+		return v.Result
+	}
+
+	return
+}
+
+// GetDecisionTaskCompletedEventId returns the value of DecisionTaskCompletedEventId if it is set or its
+// zero value if it is unset.
+func (v *WorkflowExecutionUpdateAcceptedEventAttributes) GetDecisionTaskCompletedEventId() (o int64) {
+	if v.DecisionTaskCompletedEventId != nil {
+		return *v.DecisionTaskCompletedEventId
+	}
+
+	return
+}
+
 type WorkflowIdReusePolicy int32
 
 const (
@@ -36285,3 +41374,305 @@ func (v *WorkflowTypeFilter) GetName() (o string) {
 
 	return
 }
+
+type WorkflowUpdate struct {
+	UpdateId   *string `json:"updateId,omitempty"`
+	UpdateName *string `json:"updateName,omitempty"`
+	Input      []byte  `json:"input,omitempty"`
+}
+
+// ToWire translates a WorkflowUpdate struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *WorkflowUpdate) ToWire() (wire.Value, error) {
+	var (
+		fields [3]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.UpdateId != nil {
+		w, err = wire.NewValueString(*(v.UpdateId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.UpdateName != nil {
+		w, err = wire.NewValueString(*(v.UpdateName)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+	if v.Input != nil {
+		w, err = wire.NewValueBinary(v.Input), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a WorkflowUpdate struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a WorkflowUpdate struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v WorkflowUpdate
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *WorkflowUpdate) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.UpdateId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.UpdateName = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 30:
+			if field.Value.Type() == wire.TBinary {
+				v.Input, err = field.Value.GetBinary(), error(nil)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a WorkflowUpdate
+// struct.
+func (v *WorkflowUpdate) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [3]string
+	i := 0
+	if v.UpdateId != nil {
+		fields[i] = fmt.Sprintf("UpdateId: %v", *(v.UpdateId))
+		i++
+	}
+	if v.UpdateName != nil {
+		fields[i] = fmt.Sprintf("UpdateName: %v", *(v.UpdateName))
+		i++
+	}
+	if v.Input != nil {
+		fields[i] = fmt.Sprintf("Input: %v", v.Input)
+		i++
+	}
+
+	return fmt.Sprintf("WorkflowUpdate{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this WorkflowUpdate match the
+// provided WorkflowUpdate.
+//
+// This function performs a deep comparison.
+func (v *WorkflowUpdate) Equals(rhs *WorkflowUpdate) bool {
+	if !_String_EqualsPtr(v.UpdateId, rhs.UpdateId) {
+		return false
+	}
+	if !_String_EqualsPtr(v.UpdateName, rhs.UpdateName) {
+		return false
+	}
+	if !((v.Input == nil && rhs.Input == nil) || (v.Input != nil && rhs.Input != nil && bytes.Equal(v.Input, rhs.Input))) {
+		return false
+	}
+
+	return true
+}
+
+// GetUpdateId returns the value of UpdateId if it is set or its
+// zero value if it is unset.
+func (v *WorkflowUpdate) GetUpdateId() (o string) {
+	if v.UpdateId != nil {
+		return *v.UpdateId
+	}
+
+	return
+}
+
+// GetUpdateName returns the value of UpdateName if it is set or its
+// zero value if it is unset.
+func (v *WorkflowUpdate) GetUpdateName() (o string) {
+	if v.UpdateName != nil {
+		return *v.UpdateName
+	}
+
+	return
+}
+
+// GetInput returns the value of Input if it is set or its
+// zero value if it is unset.
+func (v *WorkflowUpdate) GetInput() (o []byte) {
+	if v.Input != nil {
+		return v.Input
+	}
+
+	return
+}
+
+type WorkflowUpdateRejected struct {
+	Reason *string `json:"reason,omitempty"`
+}
+
+// ToWire translates a WorkflowUpdateRejected struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *WorkflowUpdateRejected) ToWire() (wire.Value, error) {
+	var (
+		fields [1]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Reason != nil {
+		w, err = wire.NewValueString(*(v.Reason)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a WorkflowUpdateRejected struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a WorkflowUpdateRejected struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v WorkflowUpdateRejected
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *WorkflowUpdateRejected) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Reason = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a WorkflowUpdateRejected
+// struct.
+func (v *WorkflowUpdateRejected) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [1]string
+	i := 0
+	if v.Reason != nil {
+		fields[i] = fmt.Sprintf("Reason: %v", *(v.Reason))
+		i++
+	}
+
+	return fmt.Sprintf("WorkflowUpdateRejected{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this WorkflowUpdateRejected match the
+// provided WorkflowUpdateRejected.
+//
+// This function performs a deep comparison.
+func (v *WorkflowUpdateRejected) Equals(rhs *WorkflowUpdateRejected) bool {
+	if !_String_EqualsPtr(v.Reason, rhs.Reason) {
+		return false
+	}
+
+	return true
+}
+
+// GetReason returns the value of Reason if it is set or its
+// zero value if it is unset.
+func (v *WorkflowUpdateRejected) GetReason() (o string) {
+	if v.Reason != nil {
+		return *v.Reason
+	}
+
+	return
+}
@@ -21,6 +21,10 @@
 package client
 
 import (
+	"github.com/uber/cadence/.gen/go/admin/adminserviceclient"
+	"github.com/uber/cadence/.gen/go/cadence/workflowserviceclient"
+	"github.com/uber/cadence/client/admin"
+	"github.com/uber/cadence/client/frontend"
 	"github.com/uber/cadence/client/history"
 	"github.com/uber/cadence/client/matching"
 	"github.com/uber/cadence/common"
@@ -32,6 +36,8 @@ import (
 type Factory interface {
 	NewHistoryClient() (history.Client, error)
 	NewMatchingClient() (matching.Client, error)
+	NewRemoteFrontendClient(rpcName string, rpcAddress string) frontend.Client
+	NewRemoteAdminClient(rpcName string, rpcAddress string) admin.Client
 }
 
 type rpcClientFactory struct {
@@ -73,3 +79,15 @@ func (cf *rpcClientFactory) NewMatchingClient() (matching.Client, error) {
 	}
 	return client, nil
 }
+
+// NewRemoteFrontendClient creates a client to the frontend of another cluster
+func (cf *rpcClientFactory) NewRemoteFrontendClient(rpcName string, rpcAddress string) frontend.Client {
+	dispatcher := cf.df.CreateDispatcherForOutbound(common.FrontendServiceName, rpcName, rpcAddress)
+	return workflowserviceclient.New(dispatcher.ClientConfig(rpcName))
+}
+
+// NewRemoteAdminClient creates a client to the admin service of another cluster, served by its frontend
+func (cf *rpcClientFactory) NewRemoteAdminClient(rpcName string, rpcAddress string) admin.Client {
+	dispatcher := cf.df.CreateDispatcherForOutbound(common.FrontendServiceName, rpcName, rpcAddress)
+	return adminserviceclient.New(dispatcher.ClientConfig(rpcName))
+}
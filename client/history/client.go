@@ -27,6 +27,7 @@ import (
 
 	h "github.com/uber/cadence/.gen/go/history"
 	"github.com/uber/cadence/.gen/go/history/historyserviceclient"
+	r "github.com/uber/cadence/.gen/go/replicator"
 	workflow "github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/membership"
@@ -447,6 +448,30 @@ func (c *clientImpl) SignalWorkflowExecution(
 	return err
 }
 
+func (c *clientImpl) UpdateWorkflowExecution(
+	ctx context.Context,
+	request *h.UpdateWorkflowExecutionRequest,
+	opts ...yarpc.CallOption) (*workflow.UpdateWorkflowExecutionResponse, error) {
+	client, err := c.getHostForRequest(*request.UpdateRequest.WorkflowExecution.WorkflowId)
+	if err != nil {
+		return nil, err
+	}
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	var response *workflow.UpdateWorkflowExecutionResponse
+	op := func(ctx context.Context, client historyserviceclient.Interface) error {
+		var err error
+		ctx, cancel := c.createContext(ctx)
+		defer cancel()
+		response, err = client.UpdateWorkflowExecution(ctx, request, opts...)
+		return err
+	}
+	err = c.executeWithRedirect(ctx, client, op)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
 func (c *clientImpl) SignalWithStartWorkflowExecution(
 	ctx context.Context,
 	request *h.SignalWithStartWorkflowExecutionRequest,
@@ -562,6 +587,24 @@ func (c *clientImpl) ReplicateEvents(
 	return err
 }
 
+func (c *clientImpl) ImportWorkflowExecution(
+	ctx context.Context,
+	request *h.ImportWorkflowExecutionRequest,
+	opts ...yarpc.CallOption) error {
+	client, err := c.getHostForRequest(request.Execution.GetWorkflowId())
+	if err != nil {
+		return err
+	}
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	op := func(ctx context.Context, client historyserviceclient.Interface) error {
+		ctx, cancel := c.createContext(ctx)
+		defer cancel()
+		return client.ImportWorkflowExecution(ctx, request, opts...)
+	}
+	err = c.executeWithRedirect(ctx, client, op)
+	return err
+}
+
 func (c *clientImpl) SyncShardStatus(
 	ctx context.Context,
 	request *h.SyncShardStatusRequest,
@@ -584,6 +627,94 @@ func (c *clientImpl) SyncShardStatus(
 	return err
 }
 
+func (c *clientImpl) GetReplicationMessages(
+	ctx context.Context,
+	request *r.GetReplicationMessagesRequest,
+	opts ...yarpc.CallOption) (*r.GetReplicationMessagesResponse, error) {
+
+	// tokens are grouped by the host owning their shard so that each host is called only once
+	requestsByHost := make(map[string]*r.GetReplicationMessagesRequest)
+	for _, token := range request.Tokens {
+		host, err := c.resolver.Lookup(string(rune(token.GetShardID())))
+		if err != nil {
+			return nil, err
+		}
+		hostRequest, ok := requestsByHost[host.GetAddress()]
+		if !ok {
+			hostRequest = &r.GetReplicationMessagesRequest{ClusterName: request.ClusterName}
+			requestsByHost[host.GetAddress()] = hostRequest
+		}
+		hostRequest.Tokens = append(hostRequest.Tokens, token)
+	}
+
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	response := &r.GetReplicationMessagesResponse{MessagesByShard: make(map[int32]*r.ReplicationMessages)}
+	for hostAddr, hostRequest := range requestsByHost {
+		var hostResponse *r.GetReplicationMessagesResponse
+		op := func(ctx context.Context, client historyserviceclient.Interface) error {
+			var err error
+			ctx, cancel := c.createContext(ctx)
+			defer cancel()
+			hostResponse, err = client.GetReplicationMessages(ctx, hostRequest, opts...)
+			return err
+		}
+		if err := c.executeWithRedirect(ctx, c.getThriftClient(hostAddr), op); err != nil {
+			return nil, err
+		}
+		for shardID, messages := range hostResponse.MessagesByShard {
+			response.MessagesByShard[shardID] = messages
+		}
+	}
+	return response, nil
+}
+
+func (c *clientImpl) DescribeReplicationStatus(
+	ctx context.Context,
+	request *r.DescribeReplicationStatusRequest,
+	opts ...yarpc.CallOption) (*r.DescribeReplicationStatusResponse, error) {
+
+	// shards are grouped by the host owning them so that each host is called only once
+	requestsByHost := make(map[string]*r.DescribeReplicationStatusRequest)
+	for _, shardID := range request.ShardIDs {
+		host, err := c.resolver.Lookup(string(rune(shardID)))
+		if err != nil {
+			return nil, err
+		}
+		hostRequest, ok := requestsByHost[host.GetAddress()]
+		if !ok {
+			hostRequest = &r.DescribeReplicationStatusRequest{DomainID: request.DomainID}
+			requestsByHost[host.GetAddress()] = hostRequest
+		}
+		hostRequest.ShardIDs = append(hostRequest.ShardIDs, shardID)
+		if maxReadLevel, ok := request.MaxReadLevelByShard[shardID]; ok {
+			if hostRequest.MaxReadLevelByShard == nil {
+				hostRequest.MaxReadLevelByShard = make(map[int32]int64)
+			}
+			hostRequest.MaxReadLevelByShard[shardID] = maxReadLevel
+		}
+	}
+
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	response := &r.DescribeReplicationStatusResponse{StatusByShard: make(map[int32]*r.ShardReplicationStatus)}
+	for hostAddr, hostRequest := range requestsByHost {
+		var hostResponse *r.DescribeReplicationStatusResponse
+		op := func(ctx context.Context, client historyserviceclient.Interface) error {
+			var err error
+			ctx, cancel := c.createContext(ctx)
+			defer cancel()
+			hostResponse, err = client.DescribeReplicationStatus(ctx, hostRequest, opts...)
+			return err
+		}
+		if err := c.executeWithRedirect(ctx, c.getThriftClient(hostAddr), op); err != nil {
+			return nil, err
+		}
+		for shardID, status := range hostResponse.StatusByShard {
+			response.StatusByShard[shardID] = status
+		}
+	}
+	return response, nil
+}
+
 func (c *clientImpl) getHostForRequest(workflowID string) (historyserviceclient.Interface, error) {
 	key := common.WorkflowIDToHistoryShard(workflowID, c.numberOfShards)
 	host, err := c.resolver.Lookup(string(key))
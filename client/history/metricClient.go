@@ -24,6 +24,7 @@ import (
 	"context"
 
 	h "github.com/uber/cadence/.gen/go/history"
+	r "github.com/uber/cadence/.gen/go/replicator"
 	"github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/common/metrics"
 	"go.uber.org/yarpc"
@@ -300,6 +301,23 @@ func (c *metricClient) SignalWorkflowExecution(
 	return err
 }
 
+func (c *metricClient) UpdateWorkflowExecution(
+	context context.Context,
+	request *h.UpdateWorkflowExecutionRequest,
+	opts ...yarpc.CallOption) (*shared.UpdateWorkflowExecutionResponse, error) {
+	c.metricsClient.IncCounter(metrics.HistoryClientUpdateWorkflowExecutionScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.HistoryClientUpdateWorkflowExecutionScope, metrics.CadenceClientLatency)
+	resp, err := c.client.UpdateWorkflowExecution(context, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.HistoryClientUpdateWorkflowExecutionScope, metrics.CadenceClientFailures)
+	}
+
+	return resp, err
+}
+
 func (c *metricClient) SignalWithStartWorkflowExecution(
 	context context.Context,
 	request *h.SignalWithStartWorkflowExecutionRequest,
@@ -402,6 +420,23 @@ func (c *metricClient) ReplicateEvents(
 	return err
 }
 
+func (c *metricClient) ImportWorkflowExecution(
+	context context.Context,
+	request *h.ImportWorkflowExecutionRequest,
+	opts ...yarpc.CallOption) error {
+	c.metricsClient.IncCounter(metrics.HistoryClientImportWorkflowExecutionScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.HistoryClientImportWorkflowExecutionScope, metrics.CadenceClientLatency)
+	err := c.client.ImportWorkflowExecution(context, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.HistoryClientImportWorkflowExecutionScope, metrics.CadenceClientFailures)
+	}
+
+	return err
+}
+
 func (c *metricClient) SyncShardStatus(
 	context context.Context,
 	request *h.SyncShardStatusRequest,
@@ -418,3 +453,37 @@ func (c *metricClient) SyncShardStatus(
 
 	return err
 }
+
+func (c *metricClient) GetReplicationMessages(
+	context context.Context,
+	request *r.GetReplicationMessagesRequest,
+	opts ...yarpc.CallOption) (*r.GetReplicationMessagesResponse, error) {
+	c.metricsClient.IncCounter(metrics.HistoryClientGetReplicationMessagesScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.HistoryClientGetReplicationMessagesScope, metrics.CadenceClientLatency)
+	resp, err := c.client.GetReplicationMessages(context, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.HistoryClientGetReplicationMessagesScope, metrics.CadenceClientFailures)
+	}
+
+	return resp, err
+}
+
+func (c *metricClient) DescribeReplicationStatus(
+	context context.Context,
+	request *r.DescribeReplicationStatusRequest,
+	opts ...yarpc.CallOption) (*r.DescribeReplicationStatusResponse, error) {
+	c.metricsClient.IncCounter(metrics.HistoryClientDescribeReplicationStatusScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.HistoryClientDescribeReplicationStatusScope, metrics.CadenceClientLatency)
+	resp, err := c.client.DescribeReplicationStatus(context, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.HistoryClientDescribeReplicationStatusScope, metrics.CadenceClientFailures)
+	}
+
+	return resp, err
+}
@@ -179,3 +179,20 @@ func (c *metricClient) DescribeTaskList(
 
 	return resp, err
 }
+
+func (c *metricClient) ListTaskLists(
+	ctx context.Context,
+	request *m.ListTaskListsRequest,
+	opts ...yarpc.CallOption) (*workflow.ListTaskListsResponse, error) {
+	c.metricsClient.IncCounter(metrics.MatchingClientListTaskListsScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.MatchingClientListTaskListsScope, metrics.CadenceClientLatency)
+	resp, err := c.client.ListTaskLists(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.MatchingClientListTaskListsScope, metrics.CadenceClientFailures)
+	}
+
+	return resp, err
+}
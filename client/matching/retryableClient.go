@@ -156,3 +156,19 @@ func (c *retryableClient) DescribeTaskList(
 	err := backoff.Retry(op, c.policy, c.isRetryable)
 	return resp, err
 }
+
+func (c *retryableClient) ListTaskLists(
+	ctx context.Context,
+	request *m.ListTaskListsRequest,
+	opts ...yarpc.CallOption) (*workflow.ListTaskListsResponse, error) {
+
+	var resp *workflow.ListTaskListsResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.ListTaskLists(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
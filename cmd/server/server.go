@@ -25,7 +25,9 @@ import (
 	"time"
 
 	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/authorization"
 	"github.com/uber/cadence/common/cluster"
+	"github.com/uber/cadence/common/persistence/cassandra"
 	"github.com/uber/cadence/common/service"
 	"github.com/uber/cadence/common/service/config"
 	"github.com/uber/cadence/common/service/dynamicconfig"
@@ -100,6 +102,7 @@ func (s *server) startService() common.Daemon {
 	params.Name = "cadence-" + s.name
 	params.Logger = s.cfg.Log.NewBarkLogger()
 	params.CassandraConfig = s.cfg.Cassandra
+	params.Authorization = s.cfg.Authorization
 
 	params.RingpopFactory, err = s.cfg.Ringpop.NewFactory()
 	if err != nil {
@@ -111,19 +114,39 @@ func (s *server) startService() common.Daemon {
 
 	svcCfg := s.cfg.Services[s.name]
 	params.MetricScope = svcCfg.Metrics.NewScope()
-	params.RPCFactory = svcCfg.RPC.NewFactory(params.Name, params.Logger)
+	params.RPCFactory = svcCfg.RPC.NewFactory(params.Name, params.Logger, authorization.WithPeerIdentity)
 	params.PProfInitializer = svcCfg.PProf.NewInitializer(params.Logger)
 	enableGlobalDomain := dc.GetBoolProperty(dynamicconfig.EnableGlobalDomain, s.cfg.ClustersInfo.EnableGlobalDomain)
-	params.ClusterMetadata = cluster.NewMetadata(
+	// the clusters registered at runtime are stored along with the global domains, so they are read
+	// through the metadata manager of the configured persistence, the same one the services use
+	clusterStore, err := cassandra.NewMetadataManagerProxy(params.CassandraConfig.Hosts,
+		params.CassandraConfig.Port,
+		params.CassandraConfig.User,
+		params.CassandraConfig.Password,
+		params.CassandraConfig.Datacenter,
+		params.CassandraConfig.Keyspace,
+		s.cfg.ClustersInfo.CurrentClusterName,
+		params.Logger)
+	if err != nil {
+		log.Fatalf("error creating cluster metadata store: %v", err)
+	}
+	clusterMetadata := cluster.NewDynamicMetadata(
 		enableGlobalDomain,
 		s.cfg.ClustersInfo.FailoverVersionIncrement,
 		s.cfg.ClustersInfo.MasterClusterName,
 		s.cfg.ClustersInfo.CurrentClusterName,
 		s.cfg.ClustersInfo.ClusterInitialFailoverVersions,
+		s.cfg.ClustersInfo.ClusterAddress,
+		clusterStore,
+		dc.GetDurationProperty(dynamicconfig.ClusterMetadataRefreshInterval, time.Minute),
+		params.Logger,
 	)
+	clusterMetadata.Start()
+	params.ClusterMetadata = clusterMetadata
 	// TODO: We need to switch Cadence to use zap logger, until then just pass zap.NewNop
 	if params.ClusterMetadata.IsGlobalDomainEnabled() {
-		params.MessagingClient = messaging.NewKafkaClient(&s.cfg.Kafka, zap.NewNop(), params.Logger, params.MetricScope)
+		params.MessagingClient = messaging.NewKafkaClient(&s.cfg.Kafka, params.ClusterMetadata, zap.NewNop(), params.Logger,
+			params.MetricScope)
 	} else {
 		params.MessagingClient = nil
 	}
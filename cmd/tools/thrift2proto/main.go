@@ -0,0 +1,64 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/uber/cadence/.gen/go/cadence"
+	"github.com/uber/cadence/common/codec/thriftproto"
+)
+
+// command line utility that generates the protobuf definition of the
+// WorkflowService from its thrift IDL. Usage as follows:
+//
+//	go run ./cmd/tools/thrift2proto -out idl/github.com/uber/cadence/cadence.proto
+func main() {
+	var out, license, protoPackage string
+	flag.StringVar(&out, "out", "idl/github.com/uber/cadence/cadence.proto", "output proto file")
+	flag.StringVar(&license, "license", "LICENSE", "license header to prepend")
+	flag.StringVar(&protoPackage, "package", "uber.cadence", "protobuf package name")
+	flag.Parse()
+
+	if err := generate(out, license, protoPackage); err != nil {
+		fmt.Println(err)
+		os.Exit(-1)
+	}
+}
+
+func generate(out string, license string, protoPackage string) error {
+	header, err := ioutil.ReadFile(license)
+	if err != nil {
+		return fmt.Errorf("error reading license file, err=%v", err)
+	}
+	schema, err := thriftproto.NewSchema(cadence.ThriftModule, "WorkflowService")
+	if err != nil {
+		return err
+	}
+	proto, err := thriftproto.GenerateProto(schema, protoPackage)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(out, append(append(header, '\n'), proto...), 0644)
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package authorization
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/service/config"
+)
+
+const (
+	// DomainDataKeyReaders is the domain data key holding the comma separated identities allowed to read the domain
+	DomainDataKeyReaders = "cadence.acl.readers"
+	// DomainDataKeyWriters is the domain data key holding the comma separated identities allowed to write the domain
+	DomainDataKeyWriters = "cadence.acl.writers"
+
+	anyIdentity = "*"
+)
+
+type (
+	// aclAuthorizer authorizes calls against the admins of the cluster and the ACL of the target domain,
+	// which is the union of the ACL in the static config and the ACL in the domain data
+	aclAuthorizer struct {
+		admins      map[string]struct{}
+		domains     map[string]config.DomainACL
+		domainCache cache.DomainCache
+	}
+)
+
+// NewACLAuthorizer creates an authorizer driven by per domain ACLs
+func NewACLAuthorizer(cfg config.Authorization, domainCache cache.DomainCache) Authorizer {
+	admins := make(map[string]struct{}, len(cfg.Admins))
+	for _, admin := range cfg.Admins {
+		admins[admin] = struct{}{}
+	}
+	return &aclAuthorizer{
+		admins:      admins,
+		domains:     cfg.Domains,
+		domainCache: domainCache,
+	}
+}
+
+func (a *aclAuthorizer) Authorize(ctx context.Context, attributes *Attributes) (Result, error) {
+	if attributes.Identity == "" {
+		return deny("caller identity is not set"), nil
+	}
+	if _, ok := a.admins[attributes.Identity]; ok {
+		return Result{Decision: DecisionAllow}, nil
+	}
+	if attributes.Permission == PermissionAdmin {
+		return deny(fmt.Sprintf("%v is not an admin", attributes.Identity)), nil
+	}
+	if attributes.DomainName == "" {
+		// Calls which are not scoped to a domain, like ListDomains, only need an identity
+		return Result{Decision: DecisionAllow}, nil
+	}
+
+	var readers, writers []string
+	if acl, ok := a.domains[attributes.DomainName]; ok {
+		readers = append(readers, acl.Readers...)
+		writers = append(writers, acl.Writers...)
+	}
+	entry, err := a.domainCache.GetDomain(attributes.DomainName)
+	if err != nil {
+		return Result{}, err
+	}
+	data := entry.GetInfo().Data
+	readers = append(readers, splitIdentities(data[DomainDataKeyReaders])...)
+	writers = append(writers, splitIdentities(data[DomainDataKeyWriters])...)
+
+	// writers are implicitly readers
+	if contains(writers, attributes.Identity) ||
+		(attributes.Permission == PermissionRead && contains(readers, attributes.Identity)) {
+		return Result{Decision: DecisionAllow}, nil
+	}
+	return deny(fmt.Sprintf("%v has no %v permission on domain %v",
+		attributes.Identity, attributes.Permission, attributes.DomainName)), nil
+}
+
+func deny(reason string) Result {
+	return Result{Decision: DecisionDeny, Reason: reason}
+}
+
+func splitIdentities(value string) []string {
+	var identities []string
+	for _, identity := range strings.Split(value, ",") {
+		if identity = strings.TrimSpace(identity); identity != "" {
+			identities = append(identities, identity)
+		}
+	}
+	return identities
+}
+
+func contains(acl []string, identity string) bool {
+	for _, entry := range acl {
+		if entry == identity || entry == anyIdentity {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package authorization
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/service/config"
+)
+
+type (
+	aclAuthorizerSuite struct {
+		suite.Suite
+
+		domainCache *cache.DomainCacheMock
+		authorizer  Authorizer
+	}
+)
+
+func TestACLAuthorizerSuite(t *testing.T) {
+	s := new(aclAuthorizerSuite)
+	suite.Run(t, s)
+}
+
+func (s *aclAuthorizerSuite) SetupTest() {
+	s.domainCache = &cache.DomainCacheMock{}
+	s.authorizer = NewACLAuthorizer(config.Authorization{
+		Enable: true,
+		Admins: []string{"admin"},
+		Domains: map[string]config.DomainACL{
+			"orders": {Readers: []string{"auditor"}, Writers: []string{"orders-service"}},
+		},
+	}, s.domainCache)
+	s.domainCache.On("GetDomain", "orders").Return(cache.NewDomainCacheEntryWithInfo(&persistence.DomainInfo{
+		Name: "orders",
+		Data: map[string]string{
+			DomainDataKeyReaders: "dashboard, oncall",
+			DomainDataKeyWriters: "billing-service",
+		},
+	}), nil)
+	s.domainCache.On("GetDomain", "public").Return(cache.NewDomainCacheEntryWithInfo(&persistence.DomainInfo{
+		Name: "public",
+		Data: map[string]string{DomainDataKeyReaders: anyIdentity},
+	}), nil)
+}
+
+func (s *aclAuthorizerSuite) authorize(identity string, permission Permission, domain string) Decision {
+	result, err := s.authorizer.Authorize(context.Background(), &Attributes{
+		Identity:   identity,
+		APIName:    "test",
+		Permission: permission,
+		DomainName: domain,
+	})
+	s.NoError(err)
+	if result.Decision == DecisionDeny {
+		s.NotEmpty(result.Reason)
+	}
+	return result.Decision
+}
+
+func (s *aclAuthorizerSuite) TestMissingIdentity() {
+	s.Equal(DecisionDeny, s.authorize("", PermissionRead, "orders"))
+}
+
+func (s *aclAuthorizerSuite) TestAdmin() {
+	s.Equal(DecisionAllow, s.authorize("admin", PermissionAdmin, ""))
+	s.Equal(DecisionAllow, s.authorize("admin", PermissionWrite, "orders"))
+	s.Equal(DecisionDeny, s.authorize("orders-service", PermissionAdmin, "orders"))
+}
+
+func (s *aclAuthorizerSuite) TestNoDomain() {
+	s.Equal(DecisionAllow, s.authorize("anyone", PermissionRead, ""))
+}
+
+func (s *aclAuthorizerSuite) TestConfigACL() {
+	s.Equal(DecisionAllow, s.authorize("auditor", PermissionRead, "orders"))
+	s.Equal(DecisionDeny, s.authorize("auditor", PermissionWrite, "orders"))
+	s.Equal(DecisionAllow, s.authorize("orders-service", PermissionRead, "orders"))
+	s.Equal(DecisionAllow, s.authorize("orders-service", PermissionWrite, "orders"))
+	s.Equal(DecisionDeny, s.authorize("stranger", PermissionRead, "orders"))
+}
+
+func (s *aclAuthorizerSuite) TestDomainDataACL() {
+	s.Equal(DecisionAllow, s.authorize("oncall", PermissionRead, "orders"))
+	s.Equal(DecisionDeny, s.authorize("oncall", PermissionWrite, "orders"))
+	s.Equal(DecisionAllow, s.authorize("billing-service", PermissionWrite, "orders"))
+}
+
+func (s *aclAuthorizerSuite) TestWildcard() {
+	s.Equal(DecisionAllow, s.authorize("stranger", PermissionRead, "public"))
+	s.Equal(DecisionDeny, s.authorize("stranger", PermissionWrite, "public"))
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package authorization
+
+import (
+	"context"
+
+	"github.com/uber/cadence/.gen/go/shared"
+)
+
+const (
+	// DecisionAllow means the caller may perform the API call
+	DecisionAllow Decision = iota
+	// DecisionDeny means the caller must be rejected with an AccessDeniedError
+	DecisionDeny
+)
+
+const (
+	// PermissionRead is required by the APIs which only read the state of a domain
+	PermissionRead Permission = iota
+	// PermissionWrite is required by the APIs which change workflows or process their tasks
+	PermissionWrite
+	// PermissionAdmin is required by the admin APIs and by domain management
+	PermissionAdmin
+)
+
+type (
+	// Decision is the result of an authorization check
+	Decision int
+
+	// Permission is the level of access an API requires
+	Permission int
+
+	// Attributes describes the API call being authorized
+	Attributes struct {
+		// Identity of the caller, empty if the caller did not identify itself
+		Identity   string
+		APIName    string
+		Permission Permission
+		// DomainName is empty for the APIs which are not scoped to a domain
+		DomainName string
+		// Execution is the target workflow execution, if any
+		Execution *shared.WorkflowExecution
+	}
+
+	// Result is the outcome of an authorization check
+	Result struct {
+		Decision Decision
+		// Reason explains a denial, it is returned to the caller and written to the audit log
+		Reason string
+	}
+
+	// Authorizer decides whether a caller may invoke an API. It is invoked by the frontend for
+	// every WorkflowHandler and AdminHandler method.
+	Authorizer interface {
+		Authorize(ctx context.Context, attributes *Attributes) (Result, error)
+	}
+
+	nopAuthorizer struct{}
+)
+
+// NewNopAuthorizer creates an authorizer which allows every call
+func NewNopAuthorizer() Authorizer {
+	return &nopAuthorizer{}
+}
+
+func (a *nopAuthorizer) Authorize(ctx context.Context, attributes *Attributes) (Result, error) {
+	return Result{Decision: DecisionAllow}, nil
+}
+
+func (p Permission) String() string {
+	switch p {
+	case PermissionRead:
+		return "read"
+	case PermissionWrite:
+		return "write"
+	case PermissionAdmin:
+		return "admin"
+	}
+	return "unknown"
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package authorization
+
+import (
+	"context"
+	"errors"
+
+	"go.uber.org/yarpc"
+)
+
+// CallerIdentityHeader is the request header through which callers identify themselves
+const CallerIdentityHeader = "cadence-caller-identity"
+
+// ErrCallerIdentityMismatch is returned when the identity header disagrees with the identity verified
+// by the transport and the verified peer is not allowed to call on behalf of others
+var ErrCallerIdentityMismatch = errors.New("caller identity header does not match the verified peer identity")
+
+type peerIdentityCtxKey struct{}
+
+// WithPeerIdentity returns a context carrying the identity established by the transport, such as the
+// subject of a verified TLS client certificate
+func WithPeerIdentity(ctx context.Context, identity string) context.Context {
+	return context.WithValue(ctx, peerIdentityCtxKey{}, identity)
+}
+
+// GetCallerIdentity returns the identity of the caller of the current request. An identity verified
+// by the transport takes precedence over the self reported identity header, which is only trusted
+// when there is no verified identity or when the verified peer is one of the delegates.
+func GetCallerIdentity(ctx context.Context, delegates []string) (string, error) {
+	if ctx == nil {
+		return "", nil
+	}
+	header := ""
+	if call := yarpc.CallFromContext(ctx); call != nil {
+		header = call.Header(CallerIdentityHeader)
+	}
+	peer, _ := ctx.Value(peerIdentityCtxKey{}).(string)
+	if peer == "" {
+		return header, nil
+	}
+	if header == "" || header == peer {
+		return peer, nil
+	}
+	for _, delegate := range delegates {
+		if delegate == peer {
+			return header, nil
+		}
+	}
+	return "", ErrCallerIdentityMismatch
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package authorization
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/yarpc/api/encoding"
+	"go.uber.org/yarpc/api/transport"
+)
+
+type (
+	identitySuite struct {
+		suite.Suite
+	}
+)
+
+func TestIdentitySuite(t *testing.T) {
+	s := new(identitySuite)
+	suite.Run(t, s)
+}
+
+func (s *identitySuite) callContext(header string) context.Context {
+	ctx, call := encoding.NewInboundCall(context.Background())
+	headers := transport.NewHeaders()
+	if header != "" {
+		headers = headers.With(CallerIdentityHeader, header)
+	}
+	s.NoError(call.ReadFromRequest(&transport.Request{Headers: headers}))
+	return ctx
+}
+
+func (s *identitySuite) TestHeaderOnly() {
+	identity, err := GetCallerIdentity(s.callContext("alice"), nil)
+	s.NoError(err)
+	s.Equal("alice", identity)
+}
+
+func (s *identitySuite) TestPeerIdentityWithoutHeader() {
+	identity, err := GetCallerIdentity(WithPeerIdentity(s.callContext(""), "alice"), nil)
+	s.NoError(err)
+	s.Equal("alice", identity)
+
+	identity, err = GetCallerIdentity(WithPeerIdentity(s.callContext("alice"), "alice"), nil)
+	s.NoError(err)
+	s.Equal("alice", identity)
+}
+
+func (s *identitySuite) TestHeaderDisagreesWithPeerIdentity() {
+	_, err := GetCallerIdentity(WithPeerIdentity(s.callContext("admin"), "alice"), []string{"frontend"})
+	s.Equal(ErrCallerIdentityMismatch, err)
+}
+
+func (s *identitySuite) TestDelegateCallsOnBehalfOfHeader() {
+	identity, err := GetCallerIdentity(WithPeerIdentity(s.callContext("alice"), "frontend"), []string{"frontend"})
+	s.NoError(err)
+	s.Equal("alice", identity)
+}
@@ -112,6 +112,8 @@ type (
 		isGlobalDomain              bool
 		failoverNotificationVersion int64
 		notificationVersion         int64
+		pendingActiveClusterName    string
+		failoverEndTime             int64
 		expiry                      time.Time
 	}
 )
@@ -148,6 +150,17 @@ func NewDomainCacheEntryWithInfo(info *persistence.DomainInfo) *DomainCacheEntry
 	}
 }
 
+// NewGlobalDomainCacheEntryForTest returns an entry of a global domain, used by tests
+func NewGlobalDomainCacheEntryForTest(info *persistence.DomainInfo, replicationConfig *persistence.DomainReplicationConfig,
+	clusterMetadata cluster.Metadata) *DomainCacheEntry {
+	entry := newDomainCacheEntry(clusterMetadata)
+	entry.info = info
+	entry.config = &persistence.DomainConfig{}
+	entry.replicationConfig = replicationConfig
+	entry.isGlobalDomain = true
+	return entry
+}
+
 func (c *domainCache) GetCacheSize() (sizeOfCacheByName int64, sizeOfCacheByID int64) {
 	return int64(c.cacheByID.Size()), int64(c.cacheNameToID.Size())
 }
@@ -386,6 +399,8 @@ func (c *domainCache) updateIDToDomainCache(id string, record *DomainCacheEntry)
 	entry.isGlobalDomain = record.isGlobalDomain
 	entry.failoverNotificationVersion = record.failoverNotificationVersion
 	entry.notificationVersion = record.notificationVersion
+	entry.pendingActiveClusterName = record.pendingActiveClusterName
+	entry.failoverEndTime = record.failoverEndTime
 	entry.expiry = c.timeSource.Now().Add(domainCacheEntryTTL)
 
 	nextDomain := entry.duplicate()
@@ -505,6 +520,8 @@ func (c *domainCache) buildEntryFromRecord(record *persistence.GetDomainResponse
 	newEntry.isGlobalDomain = record.IsGlobalDomain
 	newEntry.failoverNotificationVersion = record.FailoverNotificationVersion
 	newEntry.notificationVersion = record.NotificationVersion
+	newEntry.pendingActiveClusterName = record.PendingActiveClusterName
+	newEntry.failoverEndTime = record.FailoverEndTime
 	return newEntry
 }
 
@@ -524,6 +541,8 @@ func (entry *DomainCacheEntry) duplicate() *DomainCacheEntry {
 	result.isGlobalDomain = entry.isGlobalDomain
 	result.failoverNotificationVersion = entry.failoverNotificationVersion
 	result.notificationVersion = entry.notificationVersion
+	result.pendingActiveClusterName = entry.pendingActiveClusterName
+	result.failoverEndTime = entry.failoverEndTime
 	return result
 }
 
@@ -571,6 +590,21 @@ func (entry *DomainCacheEntry) GetNotificationVersion() int64 {
 	return entry.notificationVersion
 }
 
+// GetPendingActiveClusterName return the cluster a graceful failover is moving the domain to, empty if none
+func (entry *DomainCacheEntry) GetPendingActiveClusterName() string {
+	return entry.pendingActiveClusterName
+}
+
+// GetFailoverEndTime return the unix nano time when the graceful failover drain times out, 0 if none
+func (entry *DomainCacheEntry) GetFailoverEndTime() int64 {
+	return entry.failoverEndTime
+}
+
+// IsFailoverInProgress return whether the domain is draining in flight mutations before a graceful failover
+func (entry *DomainCacheEntry) IsFailoverInProgress() bool {
+	return entry.pendingActiveClusterName != "" && time.Now().UnixNano() < entry.failoverEndTime
+}
+
 // IsDomainActive return whether the domain is active, i.e. non global domain or global domain which active cluster is the current cluster
 func (entry *DomainCacheEntry) IsDomainActive() bool {
 	if !entry.isGlobalDomain {
@@ -587,9 +621,13 @@ func (entry *DomainCacheEntry) CanReplicateEvent() bool {
 	return entry.isGlobalDomain && len(entry.replicationConfig.Clusters) > 1
 }
 
-// GetDomainNotActiveErr return err if domain is not active, nil otherwise
+// GetDomainNotActiveErr return err if domain is not active or is draining for a graceful failover, nil otherwise
 func (entry *DomainCacheEntry) GetDomainNotActiveErr() error {
 	if entry.IsDomainActive() {
+		if entry.IsFailoverInProgress() {
+			// domain is still active, but new mutations are held back until the graceful failover completes
+			return errors.NewDomainFailoverInProgressError(entry.info.Name, entry.clusterMetadata.GetCurrentClusterName(), entry.pendingActiveClusterName)
+		}
 		// domain is consider active
 		return nil
 	}
@@ -24,6 +24,7 @@ import (
 	"os"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/pborman/uuid"
 	"github.com/uber-go/tally"
@@ -32,6 +33,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"github.com/uber-common/bark"
+	workflow "github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/common/cluster"
 	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/mocks"
@@ -570,9 +572,50 @@ func (s *domainCacheSuite) buildEntryFromRecord(record *persistence.GetDomainRes
 	newEntry.isGlobalDomain = record.IsGlobalDomain
 	newEntry.failoverNotificationVersion = record.FailoverNotificationVersion
 	newEntry.notificationVersion = record.NotificationVersion
+	newEntry.pendingActiveClusterName = record.PendingActiveClusterName
+	newEntry.failoverEndTime = record.FailoverEndTime
 	return newEntry
 }
 
+func (s *domainCacheSuite) TestGetDomainNotActiveErr_GracefulFailover() {
+	s.clusterMetadata.On("GetCurrentClusterName").Return(cluster.TestCurrentClusterName)
+	domainRecord := &persistence.GetDomainResponse{
+		Info:   &persistence.DomainInfo{ID: uuid.New(), Name: "some random domain name"},
+		Config: &persistence.DomainConfig{Retention: 1},
+		ReplicationConfig: &persistence.DomainReplicationConfig{
+			ActiveClusterName: cluster.TestCurrentClusterName,
+			Clusters: []*persistence.ClusterReplicationConfig{
+				&persistence.ClusterReplicationConfig{ClusterName: cluster.TestCurrentClusterName},
+				&persistence.ClusterReplicationConfig{ClusterName: cluster.TestAlternativeClusterName},
+			},
+		},
+		IsGlobalDomain: true,
+	}
+
+	entry := s.buildEntryFromRecord(domainRecord)
+	s.False(entry.IsFailoverInProgress())
+	s.Nil(entry.GetDomainNotActiveErr())
+
+	domainRecord.PendingActiveClusterName = cluster.TestAlternativeClusterName
+	domainRecord.FailoverEndTime = time.Now().Add(time.Minute).UnixNano()
+	entry = s.buildEntryFromRecord(domainRecord)
+	s.True(entry.IsFailoverInProgress())
+	s.True(entry.IsDomainActive())
+	err := entry.GetDomainNotActiveErr()
+	s.IsType(&workflow.ServiceBusyError{}, err)
+
+	// once the drain times out the domain accepts mutations until the failover is switched
+	domainRecord.FailoverEndTime = time.Now().Add(-time.Minute).UnixNano()
+	entry = s.buildEntryFromRecord(domainRecord)
+	s.False(entry.IsFailoverInProgress())
+	s.Nil(entry.GetDomainNotActiveErr())
+
+	domainRecord.ReplicationConfig.ActiveClusterName = cluster.TestAlternativeClusterName
+	domainRecord.FailoverEndTime = time.Now().Add(time.Minute).UnixNano()
+	entry = s.buildEntryFromRecord(domainRecord)
+	s.IsType(&workflow.DomainNotActiveError{}, entry.GetDomainNotActiveErr())
+}
+
 func Test_GetRetentionDays(t *testing.T) {
 	d := &DomainCacheEntry{
 		info: &persistence.DomainInfo{
@@ -21,8 +21,18 @@
 package cluster
 
 import (
+	"errors"
 	"fmt"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/uber-common/bark"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/logging"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/service/config"
 	"github.com/uber/cadence/common/service/dynamicconfig"
 )
 
@@ -42,10 +52,42 @@ type (
 		GetMasterClusterName() string
 		// GetCurrentClusterName return the current cluster name
 		GetCurrentClusterName() string
-		// GetAllClusterFailoverVersions return the all cluster name -> corresponding initial failover version
+		// GetAllClusterFailoverVersions return the all cluster name -> corresponding initial failover version,
+		// including the disabled clusters
 		GetAllClusterFailoverVersions() map[string]int64
-		// ClusterNameForFailoverVersion return the corresponding cluster name for a given failover version
-		ClusterNameForFailoverVersion(failoverVersion int64) string
+		// ClusterNameForFailoverVersion return the corresponding cluster name for a given failover version,
+		// an error is returned if the version belongs to a cluster which is not (yet) known
+		ClusterNameForFailoverVersion(failoverVersion int64) (string, error)
+		// GetAllClientAddress return the frontend address for each enabled cluster name
+		GetAllClientAddress() map[string]config.Address
+		// IsClusterEnabled return whether the cluster is known and not disabled
+		IsClusterEnabled(clusterName string) bool
+		// GetReplicationTopic return the replication topic registered for the cluster, empty if the topic
+		// in the static config is used
+		GetReplicationTopic(clusterName string) string
+		// ValidateClusterRegistration return an error if the cluster cannot be registered or updated in the
+		// metadata store
+		ValidateClusterRegistration(cluster *persistence.ClusterInfo) error
+		// RegisterClusterChangeCallback set a callback invoked after the registered clusters changed
+		RegisterClusterChangeCallback(shard int, callback CallbackFn)
+		// UnregisterClusterChangeCallback delete a cluster change callback
+		UnregisterClusterChangeCallback(shard int)
+	}
+
+	// CallbackFn is function to be called when the clusters are changed, the callback function will be
+	// called within the callback lock, make sure it will not (un)register a callback in case of deadlock
+	CallbackFn func()
+
+	// DynamicMetadata is the Metadata which also contains the clusters registered in the metadata store,
+	// the registered clusters are reloaded in the background once started
+	DynamicMetadata interface {
+		common.Daemon
+		Metadata
+	}
+
+	// Store loads the clusters registered in the metadata store
+	Store interface {
+		ListClusters() (*persistence.ListClustersResponse, error)
 	}
 
 	metadataImpl struct {
@@ -59,16 +101,71 @@ type (
 		masterClusterName string
 		// currentClusterName is the name of the current cluster
 		currentClusterName string
+		// staticFailoverVersions contains the cluster name -> corresponding initial failover version of the
+		// clusters in the static config
+		staticFailoverVersions map[string]int64
+		// staticAddresses contains the frontend address of the clusters in the static config
+		staticAddresses map[string]config.Address
+		// clusters holds the *clusterState of the static and the registered clusters
+		clusters atomic.Value
+
+		callbackLock sync.Mutex
+		callbacks    map[int]CallbackFn
+	}
+
+	// clusterState is the immutable view of all the clusters, replaced as a whole when the registered
+	// clusters change
+	clusterState struct {
 		// clusterInitialFailoverVersions contains all cluster name -> corresponding initial failover version
 		clusterInitialFailoverVersions map[string]int64
-		// clusterInitialFailoverVersions contains all initial failover version -> corresponding cluster name
+		// initialFailoverVersionClusters contains all initial failover version -> corresponding cluster name
 		initialFailoverVersionClusters map[int64]string
+		// clusterToAddress contains the frontend address of the enabled clusters
+		clusterToAddress map[string]config.Address
+		// clusterToReplicationTopic contains the replication topic of the registered clusters which have one
+		clusterToReplicationTopic map[string]string
+		// disabledClusters contains the clusters which are disabled
+		disabledClusters map[string]struct{}
+	}
+
+	dynamicMetadataImpl struct {
+		*metadataImpl
+		store           Store
+		refreshInterval dynamicconfig.DurationPropertyFn
+		logger          bark.Logger
+		status          int32
+		shutdownC       chan struct{}
 	}
 )
 
 // NewMetadata create a new instance of Metadata
 func NewMetadata(enableGlobalDomain dynamicconfig.BoolPropertyFn, failoverVersionIncrement int64,
-	masterClusterName string, currentClusterName string, clusterInitialFailoverVersions map[string]int64) Metadata {
+	masterClusterName string, currentClusterName string, clusterInitialFailoverVersions map[string]int64,
+	clusterToAddress map[string]config.Address) Metadata {
+	return newMetadata(enableGlobalDomain, failoverVersionIncrement, masterClusterName, currentClusterName,
+		clusterInitialFailoverVersions, clusterToAddress)
+}
+
+// NewDynamicMetadata create a new instance of DynamicMetadata, which adds the clusters registered in the store
+// to the ones in the static config and reloads them every refresh interval
+func NewDynamicMetadata(enableGlobalDomain dynamicconfig.BoolPropertyFn, failoverVersionIncrement int64,
+	masterClusterName string, currentClusterName string, clusterInitialFailoverVersions map[string]int64,
+	clusterToAddress map[string]config.Address, store Store, refreshInterval dynamicconfig.DurationPropertyFn,
+	logger bark.Logger) DynamicMetadata {
+	return &dynamicMetadataImpl{
+		metadataImpl: newMetadata(enableGlobalDomain, failoverVersionIncrement, masterClusterName, currentClusterName,
+			clusterInitialFailoverVersions, clusterToAddress),
+		store:           store,
+		refreshInterval: refreshInterval,
+		logger:          logger,
+		status:          common.DaemonStatusInitialized,
+		shutdownC:       make(chan struct{}),
+	}
+}
+
+func newMetadata(enableGlobalDomain dynamicconfig.BoolPropertyFn, failoverVersionIncrement int64,
+	masterClusterName string, currentClusterName string, clusterInitialFailoverVersions map[string]int64,
+	clusterToAddress map[string]config.Address) *metadataImpl {
 
 	if len(clusterInitialFailoverVersions) < 0 {
 		panic("Empty initial failover versions for cluster")
@@ -101,15 +198,32 @@ func NewMetadata(enableGlobalDomain dynamicconfig.BoolPropertyFn, failoverVersio
 	if len(initialFailoverVersionClusters) != len(clusterInitialFailoverVersions) {
 		panic("Cluster to initial failover versions have duplicate initial versions")
 	}
+	for clusterName, address := range clusterToAddress {
+		if _, ok := clusterInitialFailoverVersions[clusterName]; !ok {
+			panic(fmt.Sprintf("Cluster address is specified for unknown cluster %v", clusterName))
+		}
+		if len(address.RPCName) == 0 || len(address.RPCAddress) == 0 {
+			panic(fmt.Sprintf("Cluster address of %v is missing the rpc name or address", clusterName))
+		}
+	}
 
-	return &metadataImpl{
-		enableGlobalDomain:             enableGlobalDomain,
-		failoverVersionIncrement:       failoverVersionIncrement,
-		masterClusterName:              masterClusterName,
-		currentClusterName:             currentClusterName,
+	metadata := &metadataImpl{
+		enableGlobalDomain:       enableGlobalDomain,
+		failoverVersionIncrement: failoverVersionIncrement,
+		masterClusterName:        masterClusterName,
+		currentClusterName:       currentClusterName,
+		staticFailoverVersions:   clusterInitialFailoverVersions,
+		staticAddresses:          clusterToAddress,
+		callbacks:                make(map[int]CallbackFn),
+	}
+	metadata.clusters.Store(&clusterState{
 		clusterInitialFailoverVersions: clusterInitialFailoverVersions,
 		initialFailoverVersionClusters: initialFailoverVersionClusters,
-	}
+		clusterToAddress:               clusterToAddress,
+		clusterToReplicationTopic:      make(map[string]string),
+		disabledClusters:               make(map[string]struct{}),
+	})
+	return metadata
 }
 
 // IsGlobalDomainEnabled whether the global domain is enabled,
@@ -120,12 +234,13 @@ func (metadata *metadataImpl) IsGlobalDomainEnabled() bool {
 
 // GetNextFailoverVersion return the next failover version based on input
 func (metadata *metadataImpl) GetNextFailoverVersion(cluster string, currentFailoverVersion int64) int64 {
-	initialFailoverVersion, ok := metadata.clusterInitialFailoverVersions[cluster]
+	state := metadata.getClusterState()
+	initialFailoverVersion, ok := state.clusterInitialFailoverVersions[cluster]
 	if !ok {
 		panic(fmt.Sprintf(
 			"Unknown cluster name: %v with given cluster initial failover version map: %v.",
 			cluster,
-			metadata.clusterInitialFailoverVersions,
+			state.clusterInitialFailoverVersions,
 		))
 	}
 	failoverVersion := currentFailoverVersion/metadata.failoverVersionIncrement*metadata.failoverVersionIncrement + initialFailoverVersion
@@ -156,20 +271,204 @@ func (metadata *metadataImpl) GetCurrentClusterName() string {
 
 // GetAllClusterFailoverVersions return the all cluster name -> corresponding initial failover version
 func (metadata *metadataImpl) GetAllClusterFailoverVersions() map[string]int64 {
-	return metadata.clusterInitialFailoverVersions
+	return metadata.getClusterState().clusterInitialFailoverVersions
 }
 
 // ClusterNameForFailoverVersion return the corresponding cluster name for a given failover version
-func (metadata *metadataImpl) ClusterNameForFailoverVersion(failoverVersion int64) string {
+func (metadata *metadataImpl) ClusterNameForFailoverVersion(failoverVersion int64) (string, error) {
+	state := metadata.getClusterState()
 	initialFailoverVersion := failoverVersion % metadata.failoverVersionIncrement
-	clusterName, ok := metadata.initialFailoverVersionClusters[initialFailoverVersion]
+	clusterName, ok := state.initialFailoverVersionClusters[initialFailoverVersion]
 	if !ok {
-		panic(fmt.Sprintf(
-			"Unknown initial failover version %v with given cluster initial failover version map: %v and failover version increment %v.",
+		return "", fmt.Errorf(
+			"unknown initial failover version %v of failover version %v with failover version increment %v",
 			initialFailoverVersion,
-			metadata.clusterInitialFailoverVersions,
+			failoverVersion,
 			metadata.failoverVersionIncrement,
-		))
+		)
+	}
+	return clusterName, nil
+}
+
+// GetAllClientAddress return the frontend address for each cluster name
+func (metadata *metadataImpl) GetAllClientAddress() map[string]config.Address {
+	return metadata.getClusterState().clusterToAddress
+}
+
+// IsClusterEnabled return whether the cluster is known and not disabled
+func (metadata *metadataImpl) IsClusterEnabled(clusterName string) bool {
+	state := metadata.getClusterState()
+	if _, ok := state.clusterInitialFailoverVersions[clusterName]; !ok {
+		return false
+	}
+	_, disabled := state.disabledClusters[clusterName]
+	return !disabled
+}
+
+// GetReplicationTopic return the replication topic registered for the cluster
+func (metadata *metadataImpl) GetReplicationTopic(clusterName string) string {
+	return metadata.getClusterState().clusterToReplicationTopic[clusterName]
+}
+
+// ValidateClusterRegistration return an error if the cluster cannot be registered or updated
+func (metadata *metadataImpl) ValidateClusterRegistration(cluster *persistence.ClusterInfo) error {
+	return metadata.validateRegisteredCluster(metadata.getClusterState(), cluster)
+}
+
+func (metadata *metadataImpl) validateRegisteredCluster(state *clusterState, cluster *persistence.ClusterInfo) error {
+	if len(cluster.ClusterName) == 0 {
+		return errors.New("cluster name is empty")
+	}
+	if cluster.InitialFailoverVersion < 0 || cluster.InitialFailoverVersion >= metadata.failoverVersionIncrement {
+		return fmt.Errorf("initial failover version %v is not within [0, %v)",
+			cluster.InitialFailoverVersion, metadata.failoverVersionIncrement)
+	}
+	if initialFailoverVersion, ok := state.clusterInitialFailoverVersions[cluster.ClusterName]; ok &&
+		initialFailoverVersion != cluster.InitialFailoverVersion {
+		return fmt.Errorf("initial failover version of cluster %v is %v and cannot be changed",
+			cluster.ClusterName, initialFailoverVersion)
+	}
+	if clusterName, ok := state.initialFailoverVersionClusters[cluster.InitialFailoverVersion]; ok &&
+		clusterName != cluster.ClusterName {
+		return fmt.Errorf("initial failover version %v is used by cluster %v",
+			cluster.InitialFailoverVersion, clusterName)
+	}
+	if !cluster.Enabled && (cluster.ClusterName == metadata.currentClusterName || cluster.ClusterName == metadata.masterClusterName) {
+		return fmt.Errorf("cluster %v is the current or the master cluster and cannot be disabled", cluster.ClusterName)
+	}
+	if cluster.Enabled && cluster.ClusterName != metadata.currentClusterName &&
+		(len(cluster.RPCName) == 0 || len(cluster.RPCAddress) == 0) {
+		if _, ok := metadata.staticAddresses[cluster.ClusterName]; !ok {
+			return fmt.Errorf("enabled cluster %v is missing the rpc name or address", cluster.ClusterName)
+		}
+	}
+	return nil
+}
+
+// RegisterClusterChangeCallback set a cluster change callback
+// WARN: the callback function will be triggered when holding the callback lock, make sure the callback
+// function will not register or unregister a callback in case of dead lock
+func (metadata *metadataImpl) RegisterClusterChangeCallback(shard int, callback CallbackFn) {
+	metadata.callbackLock.Lock()
+	defer metadata.callbackLock.Unlock()
+
+	metadata.callbacks[shard] = callback
+}
+
+// UnregisterClusterChangeCallback delete a cluster change callback, the callback is not invoked anymore
+// once this returns
+func (metadata *metadataImpl) UnregisterClusterChangeCallback(shard int) {
+	metadata.callbackLock.Lock()
+	defer metadata.callbackLock.Unlock()
+
+	delete(metadata.callbacks, shard)
+}
+
+func (metadata *metadataImpl) getClusterState() *clusterState {
+	return metadata.clusters.Load().(*clusterState)
+}
+
+// updateRegisteredClusters rebuilds the cluster state from the static config and the registered clusters,
+// the registered clusters which conflict with the ones already added are skipped and returned, the cluster change
+// callbacks are invoked if the clusters changed
+func (metadata *metadataImpl) updateRegisteredClusters(registered []*persistence.ClusterInfo) map[string]error {
+	state := &clusterState{
+		clusterInitialFailoverVersions: make(map[string]int64),
+		initialFailoverVersionClusters: make(map[int64]string),
+		clusterToAddress:               make(map[string]config.Address),
+		clusterToReplicationTopic:      make(map[string]string),
+		disabledClusters:               make(map[string]struct{}),
+	}
+	for clusterName, initialFailoverVersion := range metadata.staticFailoverVersions {
+		state.clusterInitialFailoverVersions[clusterName] = initialFailoverVersion
+		state.initialFailoverVersionClusters[initialFailoverVersion] = clusterName
+	}
+	for clusterName, address := range metadata.staticAddresses {
+		state.clusterToAddress[clusterName] = address
+	}
+
+	skipped := make(map[string]error)
+	for _, cluster := range registered {
+		if err := metadata.validateRegisteredCluster(state, cluster); err != nil {
+			skipped[cluster.ClusterName] = err
+			continue
+		}
+		state.clusterInitialFailoverVersions[cluster.ClusterName] = cluster.InitialFailoverVersion
+		state.initialFailoverVersionClusters[cluster.InitialFailoverVersion] = cluster.ClusterName
+		if len(cluster.RPCName) > 0 && len(cluster.RPCAddress) > 0 {
+			state.clusterToAddress[cluster.ClusterName] = config.Address{
+				RPCName:    cluster.RPCName,
+				RPCAddress: cluster.RPCAddress,
+			}
+		}
+		if len(cluster.ReplicationTopic) > 0 {
+			state.clusterToReplicationTopic[cluster.ClusterName] = cluster.ReplicationTopic
+		}
+		if !cluster.Enabled {
+			state.disabledClusters[cluster.ClusterName] = struct{}{}
+			delete(state.clusterToAddress, cluster.ClusterName)
+		}
+	}
+	if reflect.DeepEqual(metadata.getClusterState(), state) {
+		return skipped
+	}
+	metadata.clusters.Store(state)
+
+	metadata.callbackLock.Lock()
+	defer metadata.callbackLock.Unlock()
+	for _, callback := range metadata.callbacks {
+		callback()
+	}
+	return skipped
+}
+
+// Start loads the registered clusters and starts reloading them in the background
+func (metadata *dynamicMetadataImpl) Start() {
+	if !atomic.CompareAndSwapInt32(&metadata.status, common.DaemonStatusInitialized, common.DaemonStatusStarted) {
+		return
+	}
+
+	metadata.refreshClusters()
+	go metadata.refreshLoop()
+}
+
+// Stop stops reloading the registered clusters
+func (metadata *dynamicMetadataImpl) Stop() {
+	if !atomic.CompareAndSwapInt32(&metadata.status, common.DaemonStatusStarted, common.DaemonStatusStopped) {
+		return
+	}
+	close(metadata.shutdownC)
+}
+
+func (metadata *dynamicMetadataImpl) refreshLoop() {
+	timer := time.NewTimer(metadata.refreshInterval())
+	defer timer.Stop()
+	for {
+		select {
+		case <-metadata.shutdownC:
+			return
+		case <-timer.C:
+			metadata.refreshClusters()
+			timer.Reset(metadata.refreshInterval())
+		}
+	}
+}
+
+// refreshClusters reloads the registered clusters, the clusters loaded before are kept if the store
+// cannot be read
+func (metadata *dynamicMetadataImpl) refreshClusters() {
+	resp, err := metadata.store.ListClusters()
+	if err != nil {
+		metadata.logger.WithFields(bark.Fields{
+			logging.TagErr: err,
+		}).Warn("Failed to load the registered clusters.")
+		return
+	}
+
+	for clusterName, err := range metadata.updateRegisteredClusters(resp.Clusters) {
+		metadata.logger.WithFields(bark.Fields{
+			logging.TagClusterName: clusterName,
+			logging.TagErr:         err,
+		}).Warn("Skipped invalid registered cluster.")
 	}
-	return clusterName
 }
@@ -20,7 +20,10 @@
 
 package cluster
 
-import "github.com/uber/cadence/common/service/dynamicconfig"
+import (
+	"github.com/uber/cadence/common/service/config"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
 
 const (
 	// TestCurrentClusterInitialFailoverVersion is initial failover version for current cluster
@@ -43,6 +46,11 @@ var (
 		TestCurrentClusterName:     TestCurrentClusterInitialFailoverVersion,
 		TestAlternativeClusterName: TestAlternativeClusterInitialFailoverVersion,
 	}
+	// TestAllClusterAddress is the frontend address of all the clusters used for test
+	TestAllClusterAddress = map[string]config.Address{
+		TestCurrentClusterName:     {RPCName: "cadence-frontend", RPCAddress: "127.0.0.1:7933"},
+		TestAlternativeClusterName: {RPCName: "cadence-frontend", RPCAddress: "127.0.0.1:8933"},
+	}
 )
 
 // GetTestClusterMetadata return an cluster metadata instance, which is initialized
@@ -57,5 +65,6 @@ func GetTestClusterMetadata(enableGlobalDomain bool, isMasterCluster bool) Metad
 		masterClusterName,
 		TestCurrentClusterName,
 		TestAllClusterFailoverVersions,
+		TestAllClusterAddress,
 	)
 }
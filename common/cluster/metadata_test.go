@@ -0,0 +1,190 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cluster
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/uber/cadence/common/persistence"
+)
+
+type (
+	metadataSuite struct {
+		suite.Suite
+		metadata *metadataImpl
+	}
+)
+
+func TestMetadataSuite(t *testing.T) {
+	s := new(metadataSuite)
+	suite.Run(t, s)
+}
+
+func (s *metadataSuite) SetupTest() {
+	s.metadata = GetTestClusterMetadata(true, true).(*metadataImpl)
+}
+
+func (s *metadataSuite) TestUpdateRegisteredClusters_AddCluster() {
+	skipped := s.metadata.updateRegisteredClusters([]*persistence.ClusterInfo{
+		{
+			ClusterName:            "other",
+			InitialFailoverVersion: 2,
+			Enabled:                true,
+			RPCName:                "cadence-frontend",
+			RPCAddress:             "127.0.0.1:9933",
+			ReplicationTopic:       "other-topic",
+		},
+	})
+	s.Empty(skipped)
+	s.True(s.metadata.IsClusterEnabled("other"))
+	clusterName, err := s.metadata.ClusterNameForFailoverVersion(12)
+	s.NoError(err)
+	s.Equal("other", clusterName)
+	s.Equal(int64(2), s.metadata.GetAllClusterFailoverVersions()["other"])
+	s.Equal("127.0.0.1:9933", s.metadata.GetAllClientAddress()["other"].RPCAddress)
+	s.Equal("other-topic", s.metadata.GetReplicationTopic("other"))
+	s.Equal(int64(12), s.metadata.GetNextFailoverVersion("other", 5))
+}
+
+func (s *metadataSuite) TestUpdateRegisteredClusters_DisableCluster() {
+	skipped := s.metadata.updateRegisteredClusters([]*persistence.ClusterInfo{
+		{
+			ClusterName:            TestAlternativeClusterName,
+			InitialFailoverVersion: TestAlternativeClusterInitialFailoverVersion,
+			Enabled:                false,
+		},
+	})
+	s.Empty(skipped)
+	s.False(s.metadata.IsClusterEnabled(TestAlternativeClusterName))
+	s.True(s.metadata.IsClusterEnabled(TestCurrentClusterName))
+	_, ok := s.metadata.GetAllClientAddress()[TestAlternativeClusterName]
+	s.False(ok)
+	// versions of a disabled cluster are still resolvable for the existing history
+	clusterName, err := s.metadata.ClusterNameForFailoverVersion(TestAlternativeClusterInitialFailoverVersion)
+	s.NoError(err)
+	s.Equal(TestAlternativeClusterName, clusterName)
+
+	s.metadata.updateRegisteredClusters(nil)
+	s.True(s.metadata.IsClusterEnabled(TestAlternativeClusterName))
+	s.Equal(TestAllClusterAddress[TestAlternativeClusterName], s.metadata.GetAllClientAddress()[TestAlternativeClusterName])
+}
+
+func (s *metadataSuite) TestUpdateRegisteredClusters_SkipConflictingCluster() {
+	skipped := s.metadata.updateRegisteredClusters([]*persistence.ClusterInfo{
+		{
+			ClusterName:            "other",
+			InitialFailoverVersion: TestAlternativeClusterInitialFailoverVersion,
+			Enabled:                true,
+			RPCName:                "cadence-frontend",
+			RPCAddress:             "127.0.0.1:9933",
+		},
+		{
+			ClusterName:            TestCurrentClusterName,
+			InitialFailoverVersion: TestCurrentClusterInitialFailoverVersion,
+			Enabled:                false,
+		},
+	})
+	s.Len(skipped, 2)
+	s.Contains(skipped, "other")
+	s.Contains(skipped, TestCurrentClusterName)
+	s.False(s.metadata.IsClusterEnabled("other"))
+	s.True(s.metadata.IsClusterEnabled(TestCurrentClusterName))
+}
+
+func (s *metadataSuite) TestValidateClusterRegistration() {
+	s.NoError(s.metadata.ValidateClusterRegistration(&persistence.ClusterInfo{
+		ClusterName:            "other",
+		InitialFailoverVersion: 2,
+		Enabled:                true,
+		RPCName:                "cadence-frontend",
+		RPCAddress:             "127.0.0.1:9933",
+	}))
+	s.NoError(s.metadata.ValidateClusterRegistration(&persistence.ClusterInfo{
+		ClusterName:            TestAlternativeClusterName,
+		InitialFailoverVersion: TestAlternativeClusterInitialFailoverVersion,
+		Enabled:                true,
+	}))
+
+	invalidClusters := []*persistence.ClusterInfo{
+		{ClusterName: "", InitialFailoverVersion: 2, Enabled: false},
+		{ClusterName: "other", InitialFailoverVersion: TestFailoverVersionIncrement, Enabled: false},
+		{ClusterName: "other", InitialFailoverVersion: -1, Enabled: false},
+		{ClusterName: "other", InitialFailoverVersion: TestAlternativeClusterInitialFailoverVersion, Enabled: false},
+		{ClusterName: TestAlternativeClusterName, InitialFailoverVersion: 2, Enabled: false},
+		{ClusterName: TestCurrentClusterName, InitialFailoverVersion: TestCurrentClusterInitialFailoverVersion, Enabled: false},
+		{ClusterName: "other", InitialFailoverVersion: 2, Enabled: true},
+	}
+	for _, cluster := range invalidClusters {
+		s.Error(s.metadata.ValidateClusterRegistration(cluster), "cluster: %v", cluster)
+	}
+}
+
+func (s *metadataSuite) TestIsClusterEnabled_UnknownCluster() {
+	s.False(s.metadata.IsClusterEnabled("unknown"))
+}
+
+func (s *metadataSuite) TestClusterNameForFailoverVersion_UnknownCluster() {
+	// the version of a cluster not registered (yet) is reported as an error instead of crashing the host
+	_, err := s.metadata.ClusterNameForFailoverVersion(s.metadata.failoverVersionIncrement + 2)
+	s.Error(err)
+
+	s.metadata.updateRegisteredClusters([]*persistence.ClusterInfo{
+		{
+			ClusterName:            "other",
+			InitialFailoverVersion: 2,
+			Enabled:                true,
+			RPCName:                "cadence-frontend",
+			RPCAddress:             "127.0.0.1:9933",
+		},
+	})
+	clusterName, err := s.metadata.ClusterNameForFailoverVersion(s.metadata.failoverVersionIncrement + 2)
+	s.NoError(err)
+	s.Equal("other", clusterName)
+}
+
+func (s *metadataSuite) TestUpdateRegisteredClusters_ClusterChangeCallback() {
+	notified := 0
+	s.metadata.RegisterClusterChangeCallback(1, func() { notified++ })
+	registered := []*persistence.ClusterInfo{
+		{
+			ClusterName:            "other",
+			InitialFailoverVersion: 2,
+			Enabled:                true,
+			RPCName:                "cadence-frontend",
+			RPCAddress:             "127.0.0.1:9933",
+		},
+	}
+
+	s.metadata.updateRegisteredClusters(registered)
+	s.Equal(1, notified)
+	// reloading the same clusters is not a change
+	s.metadata.updateRegisteredClusters(registered)
+	s.Equal(1, notified)
+
+	registered[0].Enabled = false
+	s.metadata.updateRegisteredClusters(registered)
+	s.Equal(2, notified)
+
+	s.metadata.UnregisterClusterChangeCallback(1)
+	s.metadata.updateRegisteredClusters(nil)
+	s.Equal(2, notified)
+}
@@ -0,0 +1,323 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package thriftproto
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+
+	"go.uber.org/thriftrw/compile"
+	"go.uber.org/thriftrw/wire"
+)
+
+// EncodeJSON encodes a thrift struct as JSON keyed by the thrift field names. Following the
+// protobuf JSON mapping, i64 values are strings, binary values are base64 and enums are names.
+func EncodeJSON(spec compile.TypeSpec, value wire.Value) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := writeJSON(&buf, spec, value); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// DecodeJSON decodes the JSON representation of a thrift struct into its thrift value
+func DecodeJSON(spec compile.TypeSpec, data []byte) (wire.Value, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var object interface{}
+	if err := decoder.Decode(&object); err != nil {
+		return wire.Value{}, err
+	}
+	if _, err := structSpec(spec); err != nil {
+		return wire.Value{}, err
+	}
+	return fromJSON(spec, object)
+}
+
+func writeJSON(buf *bytes.Buffer, spec compile.TypeSpec, value wire.Value) error {
+	switch t := compile.RootTypeSpec(spec).(type) {
+	case *compile.StructSpec:
+		buf.WriteByte('{')
+		first := true
+		for _, field := range value.GetStruct().Fields {
+			fieldSpec := fieldByID(t, field.ID)
+			if fieldSpec == nil {
+				continue
+			}
+			if !first {
+				buf.WriteByte(',')
+			}
+			first = false
+			writeJSONString(buf, fieldSpec.Name)
+			buf.WriteByte(':')
+			if err := writeJSON(buf, fieldSpec.Type, field.Value); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	case *compile.ListSpec:
+		return writeJSONList(buf, t.ValueSpec, wire.ValueListToSlice(value.GetList()))
+	case *compile.SetSpec:
+		return writeJSONList(buf, t.ValueSpec, wire.ValueListToSlice(value.GetSet()))
+	case *compile.MapSpec:
+		buf.WriteByte('{')
+		for i, item := range wire.MapItemListToSlice(value.GetMap()) {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			writeJSONString(buf, formatKey(t.KeySpec, item.Key))
+			buf.WriteByte(':')
+			if err := writeJSON(buf, t.ValueSpec, item.Value); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	case *compile.BoolSpec:
+		buf.WriteString(strconv.FormatBool(value.GetBool()))
+	case *compile.I8Spec:
+		buf.WriteString(strconv.Itoa(int(value.GetI8())))
+	case *compile.I16Spec:
+		buf.WriteString(strconv.Itoa(int(value.GetI16())))
+	case *compile.I32Spec:
+		buf.WriteString(strconv.Itoa(int(value.GetI32())))
+	case *compile.I64Spec:
+		writeJSONString(buf, strconv.FormatInt(value.GetI64(), 10))
+	case *compile.DoubleSpec:
+		d := value.GetDouble()
+		if math.IsNaN(d) || math.IsInf(d, 0) {
+			writeJSONString(buf, strconv.FormatFloat(d, 'g', -1, 64))
+		} else {
+			buf.WriteString(strconv.FormatFloat(d, 'g', -1, 64))
+		}
+	case *compile.StringSpec:
+		writeJSONString(buf, value.GetString())
+	case *compile.BinarySpec:
+		writeJSONString(buf, base64.StdEncoding.EncodeToString(value.GetBinary()))
+	case *compile.EnumSpec:
+		writeJSONString(buf, formatKey(t, value))
+	default:
+		return fmt.Errorf("%v cannot be mapped to JSON", spec.ThriftName())
+	}
+	return nil
+}
+
+func writeJSONList(buf *bytes.Buffer, spec compile.TypeSpec, items []wire.Value) error {
+	buf.WriteByte('[')
+	for i, item := range items {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		if err := writeJSON(buf, spec, item); err != nil {
+			return err
+		}
+	}
+	buf.WriteByte(']')
+	return nil
+}
+
+func writeJSONString(buf *bytes.Buffer, s string) {
+	// marshaling a string cannot fail
+	encoded, _ := json.Marshal(s)
+	buf.Write(encoded)
+}
+
+// formatKey formats a scalar as a string, as used for map keys and enum names
+func formatKey(spec compile.TypeSpec, value wire.Value) string {
+	switch t := compile.RootTypeSpec(spec).(type) {
+	case *compile.EnumSpec:
+		for _, item := range t.Items {
+			if item.Value == value.GetI32() {
+				return item.Name
+			}
+		}
+		return strconv.Itoa(int(value.GetI32()))
+	case *compile.BinarySpec:
+		return base64.StdEncoding.EncodeToString(value.GetBinary())
+	}
+	switch value.Type() {
+	case wire.TBool:
+		return strconv.FormatBool(value.GetBool())
+	case wire.TI8:
+		return strconv.Itoa(int(value.GetI8()))
+	case wire.TI16:
+		return strconv.Itoa(int(value.GetI16()))
+	case wire.TI32:
+		return strconv.Itoa(int(value.GetI32()))
+	case wire.TI64:
+		return strconv.FormatInt(value.GetI64(), 10)
+	case wire.TDouble:
+		return strconv.FormatFloat(value.GetDouble(), 'g', -1, 64)
+	default:
+		return value.GetString()
+	}
+}
+
+func fromJSON(spec compile.TypeSpec, object interface{}) (wire.Value, error) {
+	switch t := compile.RootTypeSpec(spec).(type) {
+	case *compile.StructSpec:
+		fields, ok := object.(map[string]interface{})
+		if !ok {
+			return wire.Value{}, fmt.Errorf("expected object for %v", t.Name)
+		}
+		for name := range fields {
+			if _, err := t.Fields.FindByName(name); err != nil {
+				return wire.Value{}, fmt.Errorf("unknown field %q in %v", name, t.Name)
+			}
+		}
+		var values []wire.Field
+		for _, fieldSpec := range t.Fields {
+			field, ok := fields[fieldSpec.Name]
+			if !ok || field == nil {
+				continue
+			}
+			value, err := fromJSON(fieldSpec.Type, field)
+			if err != nil {
+				return wire.Value{}, fmt.Errorf("%v.%v: %v", t.Name, fieldSpec.Name, err)
+			}
+			values = append(values, wire.Field{ID: fieldSpec.ID, Value: value})
+		}
+		return wire.NewValueStruct(wire.Struct{Fields: values}), nil
+	case *compile.ListSpec:
+		items, err := fromJSONList(t.ValueSpec, object)
+		if err != nil {
+			return wire.Value{}, err
+		}
+		return wire.NewValueList(wire.ValueListFromSlice(compile.RootTypeSpec(t.ValueSpec).TypeCode(), items)), nil
+	case *compile.SetSpec:
+		items, err := fromJSONList(t.ValueSpec, object)
+		if err != nil {
+			return wire.Value{}, err
+		}
+		return wire.NewValueSet(wire.ValueListFromSlice(compile.RootTypeSpec(t.ValueSpec).TypeCode(), items)), nil
+	case *compile.MapSpec:
+		entries, ok := object.(map[string]interface{})
+		if !ok {
+			return wire.Value{}, fmt.Errorf("expected object for %v", t.ThriftName())
+		}
+		var items []wire.MapItem
+		for key, entry := range entries {
+			k, err := fromJSON(t.KeySpec, key)
+			if err != nil {
+				return wire.Value{}, err
+			}
+			v, err := fromJSON(t.ValueSpec, entry)
+			if err != nil {
+				return wire.Value{}, err
+			}
+			items = append(items, wire.MapItem{Key: k, Value: v})
+		}
+		return wire.NewValueMap(wire.MapItemListFromSlice(compile.RootTypeSpec(t.KeySpec).TypeCode(),
+			compile.RootTypeSpec(t.ValueSpec).TypeCode(), items)), nil
+	case *compile.BoolSpec:
+		switch v := object.(type) {
+		case bool:
+			return wire.NewValueBool(v), nil
+		case string:
+			b, err := strconv.ParseBool(v)
+			return wire.NewValueBool(b), err
+		}
+		return wire.Value{}, fmt.Errorf("expected bool, got %v", object)
+	case *compile.I8Spec:
+		v, err := parseInt(object, 8)
+		return wire.NewValueI8(int8(v)), err
+	case *compile.I16Spec:
+		v, err := parseInt(object, 16)
+		return wire.NewValueI16(int16(v)), err
+	case *compile.I32Spec:
+		v, err := parseInt(object, 32)
+		return wire.NewValueI32(int32(v)), err
+	case *compile.I64Spec:
+		v, err := parseInt(object, 64)
+		return wire.NewValueI64(v), err
+	case *compile.DoubleSpec:
+		var s string
+		switch v := object.(type) {
+		case json.Number:
+			s = v.String()
+		case string:
+			s = v
+		default:
+			return wire.Value{}, fmt.Errorf("expected number, got %v", object)
+		}
+		d, err := strconv.ParseFloat(s, 64)
+		return wire.NewValueDouble(d), err
+	case *compile.StringSpec:
+		s, ok := object.(string)
+		if !ok {
+			return wire.Value{}, fmt.Errorf("expected string, got %v", object)
+		}
+		return wire.NewValueBinary([]byte(s)), nil
+	case *compile.BinarySpec:
+		s, ok := object.(string)
+		if !ok {
+			return wire.Value{}, fmt.Errorf("expected base64 string, got %v", object)
+		}
+		b, err := base64.StdEncoding.DecodeString(s)
+		return wire.NewValueBinary(b), err
+	case *compile.EnumSpec:
+		if name, ok := object.(string); ok {
+			if item, ok := t.LookupItem(name); ok {
+				return wire.NewValueI32(item.Value), nil
+			}
+		}
+		v, err := parseInt(object, 32)
+		if err != nil {
+			return wire.Value{}, fmt.Errorf("unknown %v %v", t.Name, object)
+		}
+		return wire.NewValueI32(int32(v)), nil
+	default:
+		return wire.Value{}, fmt.Errorf("%v cannot be mapped from JSON", spec.ThriftName())
+	}
+}
+
+func fromJSONList(spec compile.TypeSpec, object interface{}) ([]wire.Value, error) {
+	array, ok := object.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected array, got %v", object)
+	}
+	items := make([]wire.Value, 0, len(array))
+	for _, element := range array {
+		item, err := fromJSON(spec, element)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// parseInt accepts integers as JSON numbers or strings
+func parseInt(object interface{}, bitSize int) (int64, error) {
+	var s string
+	switch v := object.(type) {
+	case json.Number:
+		s = v.String()
+	case string:
+		s = v
+	default:
+		return 0, fmt.Errorf("expected integer, got %v", object)
+	}
+	return strconv.ParseInt(s, 10, bitSize)
+}
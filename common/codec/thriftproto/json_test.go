@@ -0,0 +1,135 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package thriftproto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/uber/cadence/.gen/go/cadence"
+	"github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"go.uber.org/thriftrw/compile"
+)
+
+type (
+	jsonSuite struct {
+		suite.Suite
+		schema *Schema
+	}
+)
+
+func TestJSONSuite(t *testing.T) {
+	suite.Run(t, new(jsonSuite))
+}
+
+func (s *jsonSuite) SetupTest() {
+	schema, err := NewSchema(cadence.ThriftModule, "WorkflowService")
+	s.NoError(err)
+	s.schema = schema
+}
+
+func (s *jsonSuite) TestRoundTrip() {
+	request := newTestStartRequest()
+	value, err := request.ToWire()
+	s.NoError(err)
+
+	data, err := EncodeJSON(s.requestSpec("StartWorkflowExecution"), value)
+	s.NoError(err)
+	decoded, err := DecodeJSON(s.requestSpec("StartWorkflowExecution"), data)
+	s.NoError(err)
+
+	var result shared.StartWorkflowExecutionRequest
+	s.NoError(result.FromWire(decoded))
+	s.True(request.Equals(&result), "%v != %v", request, &result)
+
+	domain := newTestRegisterDomainRequest()
+	value, err = domain.ToWire()
+	s.NoError(err)
+	data, err = EncodeJSON(s.requestSpec("RegisterDomain"), value)
+	s.NoError(err)
+	decoded, err = DecodeJSON(s.requestSpec("RegisterDomain"), data)
+	s.NoError(err)
+
+	var domainResult shared.RegisterDomainRequest
+	s.NoError(domainResult.FromWire(decoded))
+	s.True(domain.Equals(&domainResult), "%v != %v", domain, &domainResult)
+}
+
+func (s *jsonSuite) TestEncode() {
+	started := shared.EventTypeWorkflowExecutionStarted
+	event := &shared.HistoryEvent{
+		EventId:   common.Int64Ptr(1538000000123456789),
+		EventType: &started,
+		WorkflowExecutionStartedEventAttributes: &shared.WorkflowExecutionStartedEventAttributes{
+			Input: []byte{0xfb, 0xff},
+		},
+	}
+	value, err := event.ToWire()
+	s.NoError(err)
+
+	data, err := EncodeJSON(s.sharedType("HistoryEvent"), value)
+	s.NoError(err)
+	s.JSONEq(`{
+		"eventId": "1538000000123456789",
+		"eventType": "WorkflowExecutionStarted",
+		"workflowExecutionStartedEventAttributes": {"input": "+/8="}
+	}`, string(data))
+}
+
+func (s *jsonSuite) TestDecode_NumbersAccepted() {
+	decoded, err := DecodeJSON(s.sharedType("HistoryEvent"), []byte(`{"eventId": 7, "eventType": 0}`))
+	s.NoError(err)
+
+	var result shared.HistoryEvent
+	s.NoError(result.FromWire(decoded))
+	s.Equal(int64(7), result.GetEventId())
+	s.Equal(shared.EventTypeWorkflowExecutionStarted, result.GetEventType())
+}
+
+func (s *jsonSuite) TestDecode_Errors() {
+	spec := s.requestSpec("StartWorkflowExecution")
+	for _, data := range []string{
+		`{"unknownField": 1}`,
+		`{"domain": 1}`,
+		`{"input": "not base64!"}`,
+		`{"workflowIdReusePolicy": "NoSuchPolicy"}`,
+		`[]`,
+		`{`,
+	} {
+		_, err := DecodeJSON(spec, []byte(data))
+		s.Error(err, data)
+	}
+}
+
+func (s *jsonSuite) requestSpec(method string) compile.TypeSpec {
+	spec, ok := s.schema.Method(method)
+	s.True(ok)
+	arg, err := RequestSpec(spec)
+	s.NoError(err)
+	return arg.Type
+}
+
+func (s *jsonSuite) sharedType(name string) compile.TypeSpec {
+	spec, err := s.schema.module.Includes["shared"].Module.LookupType(name)
+	s.NoError(err)
+	return spec
+}
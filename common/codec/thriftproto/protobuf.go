@@ -0,0 +1,428 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package thriftproto
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+
+	"go.uber.org/thriftrw/compile"
+	"go.uber.org/thriftrw/wire"
+)
+
+// protobuf wire types
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+var errTruncated = errors.New("truncated protobuf message")
+
+type (
+	// protoField is a single field read from a protobuf message
+	protoField struct {
+		number   uint64
+		wireType uint64
+		varint   uint64
+		bytes    []byte
+	}
+)
+
+// EncodeProto encodes a thrift struct as the protobuf message mirroring it
+func EncodeProto(spec compile.TypeSpec, value wire.Value) ([]byte, error) {
+	s, err := structSpec(spec)
+	if err != nil {
+		return nil, err
+	}
+	return encodeStruct(nil, s, value.GetStruct())
+}
+
+// DecodeProto decodes the protobuf message mirroring a thrift struct into its thrift value
+func DecodeProto(spec compile.TypeSpec, data []byte) (wire.Value, error) {
+	s, err := structSpec(spec)
+	if err != nil {
+		return wire.Value{}, err
+	}
+	return decodeStruct(s, data)
+}
+
+func encodeStruct(b []byte, spec *compile.StructSpec, value wire.Struct) ([]byte, error) {
+	var err error
+	for _, field := range value.Fields {
+		fieldSpec := fieldByID(spec, field.ID)
+		if fieldSpec == nil {
+			continue
+		}
+		if b, err = encodeField(b, uint64(field.ID), fieldSpec.Type, field.Value); err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+func encodeField(b []byte, number uint64, spec compile.TypeSpec, value wire.Value) ([]byte, error) {
+	var err error
+	switch t := compile.RootTypeSpec(spec).(type) {
+	case *compile.ListSpec:
+		for _, item := range wire.ValueListToSlice(value.GetList()) {
+			if b, err = encodeValue(b, number, t.ValueSpec, item); err != nil {
+				return nil, err
+			}
+		}
+		return b, nil
+	case *compile.SetSpec:
+		for _, item := range wire.ValueListToSlice(value.GetSet()) {
+			if b, err = encodeValue(b, number, t.ValueSpec, item); err != nil {
+				return nil, err
+			}
+		}
+		return b, nil
+	case *compile.MapSpec:
+		for _, item := range wire.MapItemListToSlice(value.GetMap()) {
+			var entry []byte
+			if entry, err = encodeValue(entry, 1, t.KeySpec, item.Key); err != nil {
+				return nil, err
+			}
+			if entry, err = encodeValue(entry, 2, t.ValueSpec, item.Value); err != nil {
+				return nil, err
+			}
+			b = appendTag(b, number, wireBytes)
+			b = appendBytes(b, entry)
+		}
+		return b, nil
+	default:
+		return encodeValue(b, number, spec, value)
+	}
+}
+
+func encodeValue(b []byte, number uint64, spec compile.TypeSpec, value wire.Value) ([]byte, error) {
+	switch value.Type() {
+	case wire.TBool:
+		b = appendTag(b, number, wireVarint)
+		if value.GetBool() {
+			return appendVarint(b, 1), nil
+		}
+		return appendVarint(b, 0), nil
+	case wire.TI8:
+		return appendVarint(appendTag(b, number, wireVarint), uint64(int64(value.GetI8()))), nil
+	case wire.TI16:
+		return appendVarint(appendTag(b, number, wireVarint), uint64(int64(value.GetI16()))), nil
+	case wire.TI32:
+		return appendVarint(appendTag(b, number, wireVarint), uint64(int64(value.GetI32()))), nil
+	case wire.TI64:
+		return appendVarint(appendTag(b, number, wireVarint), uint64(value.GetI64())), nil
+	case wire.TDouble:
+		b = appendTag(b, number, wireFixed64)
+		var bits [8]byte
+		binary.LittleEndian.PutUint64(bits[:], math.Float64bits(value.GetDouble()))
+		return append(b, bits[:]...), nil
+	case wire.TBinary:
+		return appendBytes(appendTag(b, number, wireBytes), value.GetBinary()), nil
+	case wire.TStruct:
+		s, err := structSpec(spec)
+		if err != nil {
+			return nil, err
+		}
+		message, err := encodeStruct(nil, s, value.GetStruct())
+		if err != nil {
+			return nil, err
+		}
+		return appendBytes(appendTag(b, number, wireBytes), message), nil
+	default:
+		return nil, fmt.Errorf("nested %v in %v cannot be mapped to protobuf", value.Type(), spec.ThriftName())
+	}
+}
+
+func decodeStruct(spec *compile.StructSpec, data []byte) (wire.Value, error) {
+	values := make(map[int16]wire.Value)
+	lists := make(map[int16][]wire.Value)
+	maps := make(map[int16][]wire.MapItem)
+
+	for len(data) > 0 {
+		var field protoField
+		var err error
+		if field, data, err = readField(data); err != nil {
+			return wire.Value{}, err
+		}
+		if field.number > math.MaxInt16 {
+			continue
+		}
+		id := int16(field.number)
+		fieldSpec := fieldByID(spec, id)
+		if fieldSpec == nil {
+			continue
+		}
+
+		switch t := compile.RootTypeSpec(fieldSpec.Type).(type) {
+		case *compile.ListSpec:
+			items, err := decodeRepeated(t.ValueSpec, field)
+			if err != nil {
+				return wire.Value{}, err
+			}
+			lists[id] = append(lists[id], items...)
+		case *compile.SetSpec:
+			items, err := decodeRepeated(t.ValueSpec, field)
+			if err != nil {
+				return wire.Value{}, err
+			}
+			lists[id] = append(lists[id], items...)
+		case *compile.MapSpec:
+			item, err := decodeMapEntry(t, field)
+			if err != nil {
+				return wire.Value{}, err
+			}
+			maps[id] = append(maps[id], item)
+		default:
+			value, err := decodeValue(fieldSpec.Type, field)
+			if err != nil {
+				return wire.Value{}, fmt.Errorf("%v.%v: %v", spec.Name, fieldSpec.Name, err)
+			}
+			values[id] = value
+		}
+	}
+
+	var fields []wire.Field
+	for _, fieldSpec := range spec.Fields {
+		switch t := compile.RootTypeSpec(fieldSpec.Type).(type) {
+		case *compile.ListSpec:
+			if items, ok := lists[fieldSpec.ID]; ok {
+				list := wire.ValueListFromSlice(compile.RootTypeSpec(t.ValueSpec).TypeCode(), items)
+				fields = append(fields, wire.Field{ID: fieldSpec.ID, Value: wire.NewValueList(list)})
+			}
+		case *compile.SetSpec:
+			if items, ok := lists[fieldSpec.ID]; ok {
+				set := wire.ValueListFromSlice(compile.RootTypeSpec(t.ValueSpec).TypeCode(), items)
+				fields = append(fields, wire.Field{ID: fieldSpec.ID, Value: wire.NewValueSet(set)})
+			}
+		case *compile.MapSpec:
+			if items, ok := maps[fieldSpec.ID]; ok {
+				m := wire.MapItemListFromSlice(compile.RootTypeSpec(t.KeySpec).TypeCode(),
+					compile.RootTypeSpec(t.ValueSpec).TypeCode(), items)
+				fields = append(fields, wire.Field{ID: fieldSpec.ID, Value: wire.NewValueMap(m)})
+			}
+		default:
+			if value, ok := values[fieldSpec.ID]; ok {
+				fields = append(fields, wire.Field{ID: fieldSpec.ID, Value: value})
+			}
+		}
+	}
+	return wire.NewValueStruct(wire.Struct{Fields: fields}), nil
+}
+
+// decodeRepeated decodes one occurrence of a repeated field, scalars may be packed
+func decodeRepeated(spec compile.TypeSpec, field protoField) ([]wire.Value, error) {
+	if field.wireType != wireBytes || !isPackable(spec) {
+		value, err := decodeValue(spec, field)
+		if err != nil {
+			return nil, err
+		}
+		return []wire.Value{value}, nil
+	}
+
+	var items []wire.Value
+	data := field.bytes
+	for len(data) > 0 {
+		item := protoField{number: field.number}
+		if _, ok := compile.RootTypeSpec(spec).(*compile.DoubleSpec); ok {
+			if len(data) < 8 {
+				return nil, errTruncated
+			}
+			item.wireType = wireFixed64
+			item.varint = binary.LittleEndian.Uint64(data)
+			data = data[8:]
+		} else {
+			v, n := binary.Uvarint(data)
+			if n <= 0 {
+				return nil, errTruncated
+			}
+			item.wireType = wireVarint
+			item.varint = v
+			data = data[n:]
+		}
+		value, err := decodeValue(spec, item)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, value)
+	}
+	return items, nil
+}
+
+func decodeMapEntry(spec *compile.MapSpec, field protoField) (wire.MapItem, error) {
+	if field.wireType != wireBytes {
+		return wire.MapItem{}, fmt.Errorf("map entry has wire type %v", field.wireType)
+	}
+	var key, value *wire.Value
+	data := field.bytes
+	for len(data) > 0 {
+		var entryField protoField
+		var err error
+		if entryField, data, err = readField(data); err != nil {
+			return wire.MapItem{}, err
+		}
+		switch entryField.number {
+		case 1:
+			k, err := decodeValue(spec.KeySpec, entryField)
+			if err != nil {
+				return wire.MapItem{}, err
+			}
+			key = &k
+		case 2:
+			v, err := decodeValue(spec.ValueSpec, entryField)
+			if err != nil {
+				return wire.MapItem{}, err
+			}
+			value = &v
+		}
+	}
+	// absent key or value is the zero value in protobuf
+	if key == nil {
+		k, err := decodeValue(spec.KeySpec, zeroField(spec.KeySpec))
+		if err != nil {
+			return wire.MapItem{}, err
+		}
+		key = &k
+	}
+	if value == nil {
+		v, err := decodeValue(spec.ValueSpec, zeroField(spec.ValueSpec))
+		if err != nil {
+			return wire.MapItem{}, err
+		}
+		value = &v
+	}
+	return wire.MapItem{Key: *key, Value: *value}, nil
+}
+
+func decodeValue(spec compile.TypeSpec, field protoField) (wire.Value, error) {
+	root := compile.RootTypeSpec(spec)
+	expected := uint64(wireVarint)
+	switch root.(type) {
+	case *compile.DoubleSpec:
+		expected = wireFixed64
+	case *compile.StringSpec, *compile.BinarySpec, *compile.StructSpec:
+		expected = wireBytes
+	}
+	if field.wireType != expected {
+		return wire.Value{}, fmt.Errorf("field %v has wire type %v, expected %v for %v",
+			field.number, field.wireType, expected, spec.ThriftName())
+	}
+
+	switch t := root.(type) {
+	case *compile.BoolSpec:
+		return wire.NewValueBool(field.varint != 0), nil
+	case *compile.I8Spec:
+		return wire.NewValueI8(int8(field.varint)), nil
+	case *compile.I16Spec:
+		return wire.NewValueI16(int16(field.varint)), nil
+	case *compile.I32Spec, *compile.EnumSpec:
+		return wire.NewValueI32(int32(field.varint)), nil
+	case *compile.I64Spec:
+		return wire.NewValueI64(int64(field.varint)), nil
+	case *compile.DoubleSpec:
+		return wire.NewValueDouble(math.Float64frombits(field.varint)), nil
+	case *compile.StringSpec, *compile.BinarySpec:
+		// strings are copied into a byte slice, wire.NewValueString does not keep its string reachable
+		return wire.NewValueBinary(append([]byte{}, field.bytes...)), nil
+	case *compile.StructSpec:
+		return decodeStruct(t, field.bytes)
+	default:
+		return wire.Value{}, fmt.Errorf("nested %v cannot be mapped from protobuf", spec.ThriftName())
+	}
+}
+
+func readField(data []byte) (protoField, []byte, error) {
+	tag, n := binary.Uvarint(data)
+	if n <= 0 {
+		return protoField{}, nil, errTruncated
+	}
+	data = data[n:]
+	field := protoField{number: tag >> 3, wireType: tag & 7}
+
+	switch field.wireType {
+	case wireVarint:
+		v, n := binary.Uvarint(data)
+		if n <= 0 {
+			return protoField{}, nil, errTruncated
+		}
+		field.varint = v
+		data = data[n:]
+	case wireFixed64:
+		if len(data) < 8 {
+			return protoField{}, nil, errTruncated
+		}
+		field.varint = binary.LittleEndian.Uint64(data)
+		data = data[8:]
+	case wireBytes:
+		length, n := binary.Uvarint(data)
+		if n <= 0 || uint64(len(data)-n) < length {
+			return protoField{}, nil, errTruncated
+		}
+		field.bytes = data[n : n+int(length)]
+		data = data[n+int(length):]
+	case wireFixed32:
+		if len(data) < 4 {
+			return protoField{}, nil, errTruncated
+		}
+		field.varint = uint64(binary.LittleEndian.Uint32(data))
+		data = data[4:]
+	default:
+		return protoField{}, nil, fmt.Errorf("unsupported protobuf wire type %v", field.wireType)
+	}
+	return field, data, nil
+}
+
+func zeroField(spec compile.TypeSpec) protoField {
+	switch compile.RootTypeSpec(spec).(type) {
+	case *compile.DoubleSpec:
+		return protoField{wireType: wireFixed64}
+	case *compile.StringSpec, *compile.BinarySpec, *compile.StructSpec:
+		return protoField{wireType: wireBytes}
+	default:
+		return protoField{wireType: wireVarint}
+	}
+}
+
+func isPackable(spec compile.TypeSpec) bool {
+	switch compile.RootTypeSpec(spec).(type) {
+	case *compile.StringSpec, *compile.BinarySpec, *compile.StructSpec:
+		return false
+	default:
+		return true
+	}
+}
+
+func appendTag(b []byte, number uint64, wireType uint64) []byte {
+	return appendVarint(b, number<<3|wireType)
+}
+
+func appendVarint(b []byte, v uint64) []byte {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(buf[:], v)
+	return append(b, buf[:n]...)
+}
+
+func appendBytes(b []byte, data []byte) []byte {
+	return append(appendVarint(b, uint64(len(data))), data...)
+}
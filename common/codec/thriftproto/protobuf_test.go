@@ -0,0 +1,236 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package thriftproto
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber/cadence/.gen/go/cadence"
+	"github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"go.uber.org/thriftrw/compile"
+	"go.uber.org/thriftrw/wire"
+)
+
+type (
+	protobufSuite struct {
+		suite.Suite
+		schema *Schema
+	}
+)
+
+func TestProtobufSuite(t *testing.T) {
+	suite.Run(t, new(protobufSuite))
+}
+
+func (s *protobufSuite) SetupTest() {
+	schema, err := NewSchema(cadence.ThriftModule, "WorkflowService")
+	s.NoError(err)
+	s.schema = schema
+}
+
+func (s *protobufSuite) TestRoundTrip_StartWorkflowExecutionRequest() {
+	request := newTestStartRequest()
+	value, err := request.ToWire()
+	s.NoError(err)
+
+	data, err := EncodeProto(s.requestSpec("StartWorkflowExecution"), value)
+	s.NoError(err)
+	decoded, err := DecodeProto(s.requestSpec("StartWorkflowExecution"), data)
+	s.NoError(err)
+
+	var result shared.StartWorkflowExecutionRequest
+	s.NoError(result.FromWire(decoded))
+	s.True(request.Equals(&result), "%v != %v", request, &result)
+}
+
+func (s *protobufSuite) TestRoundTrip_History() {
+	response := newTestHistoryResponse()
+	value, err := response.ToWire()
+	s.NoError(err)
+
+	spec := s.responseSpec("GetWorkflowExecutionHistory")
+	data, err := EncodeProto(spec, value)
+	s.NoError(err)
+	decoded, err := DecodeProto(spec, data)
+	s.NoError(err)
+
+	var result shared.GetWorkflowExecutionHistoryResponse
+	s.NoError(result.FromWire(decoded))
+	s.True(response.Equals(&result), "%v != %v", response, &result)
+}
+
+func (s *protobufSuite) TestRoundTrip_RegisterDomainRequest() {
+	request := newTestRegisterDomainRequest()
+	value, err := request.ToWire()
+	s.NoError(err)
+
+	data, err := EncodeProto(s.requestSpec("RegisterDomain"), value)
+	s.NoError(err)
+	decoded, err := DecodeProto(s.requestSpec("RegisterDomain"), data)
+	s.NoError(err)
+
+	var result shared.RegisterDomainRequest
+	s.NoError(result.FromWire(decoded))
+	s.True(request.Equals(&result), "%v != %v", request, &result)
+}
+
+func (s *protobufSuite) TestWireFormat() {
+	request := &shared.TerminateWorkflowExecutionRequest{
+		Domain:  common.StringPtr("orders"),
+		Details: []byte{1, 2},
+	}
+	value, err := request.ToWire()
+	s.NoError(err)
+	data, err := EncodeProto(s.requestSpec("TerminateWorkflowExecution"), value)
+	s.NoError(err)
+
+	// fields are numbered by their thrift ids, domain is 10 and details is 40
+	buf := proto.NewBuffer(data)
+	tag, err := buf.DecodeVarint()
+	s.NoError(err)
+	s.Equal(uint64(10<<3|proto.WireBytes), tag)
+	domain, err := buf.DecodeStringBytes()
+	s.NoError(err)
+	s.Equal("orders", domain)
+	tag, err = buf.DecodeVarint()
+	s.NoError(err)
+	s.Equal(uint64(40<<3|proto.WireBytes), tag)
+	details, err := buf.DecodeRawBytes(true)
+	s.NoError(err)
+	s.Equal([]byte{1, 2}, details)
+}
+
+func (s *protobufSuite) TestDecodePackedAndUnknownFields() {
+	spec := s.requestSpec("StartWorkflowExecution")
+	buf := proto.NewBuffer(nil)
+	// unknown field
+	buf.EncodeVarint(999<<3 | proto.WireVarint)
+	buf.EncodeVarint(7)
+	// nested retryPolicy message
+	retry := proto.NewBuffer(nil)
+	retry.EncodeVarint(40<<3 | proto.WireVarint)
+	retry.EncodeVarint(3)
+	buf.EncodeVarint(120<<3 | proto.WireBytes)
+	buf.EncodeRawBytes(retry.Bytes())
+
+	decoded, err := DecodeProto(spec, buf.Bytes())
+	s.NoError(err)
+	var result shared.StartWorkflowExecutionRequest
+	s.NoError(result.FromWire(decoded))
+	s.Equal(int32(3), result.RetryPolicy.GetMaximumAttempts())
+
+	// packed repeated scalars are decoded like the unpacked form
+	listSpec := &compile.StructSpec{Name: "Packed", Fields: compile.FieldGroup{
+		{ID: 1, Name: "values", Type: &compile.ListSpec{ValueSpec: &compile.I32Spec{}}},
+	}}
+	packed := proto.NewBuffer(nil)
+	packed.EncodeVarint(1<<3 | proto.WireBytes)
+	packed.EncodeRawBytes([]byte{1, 2, 3})
+	value, err := DecodeProto(listSpec, packed.Bytes())
+	s.NoError(err)
+	items := wire.ValueListToSlice(value.GetStruct().Fields[0].Value.GetList())
+	s.Len(items, 3)
+	s.Equal(int32(3), items[2].GetI32())
+}
+
+func (s *protobufSuite) TestDecodeTruncated() {
+	_, err := DecodeProto(s.requestSpec("StartWorkflowExecution"), []byte{10<<3 | proto.WireBytes, 5, 'a'})
+	s.Error(err)
+}
+
+func (s *protobufSuite) requestSpec(method string) compile.TypeSpec {
+	spec, ok := s.schema.Method(method)
+	s.True(ok)
+	arg, err := RequestSpec(spec)
+	s.NoError(err)
+	return arg.Type
+}
+
+func (s *protobufSuite) responseSpec(method string) compile.TypeSpec {
+	spec, ok := s.schema.Method(method)
+	s.True(ok)
+	return ResponseSpec(spec)
+}
+
+func newTestStartRequest() *shared.StartWorkflowExecutionRequest {
+	policy := shared.WorkflowIdReusePolicyRejectDuplicate
+	return &shared.StartWorkflowExecutionRequest{
+		Domain:                              common.StringPtr("orders"),
+		WorkflowId:                          common.StringPtr("order-42"),
+		WorkflowType:                        &shared.WorkflowType{Name: common.StringPtr("process-order")},
+		TaskList:                            &shared.TaskList{Name: common.StringPtr("orders-tl")},
+		Input:                               []byte{0, 1, 2, 255},
+		ExecutionStartToCloseTimeoutSeconds: common.Int32Ptr(0),
+		TaskStartToCloseTimeoutSeconds:      common.Int32Ptr(-5),
+		Identity:                            common.StringPtr("worker@host"),
+		RequestId:                           common.StringPtr("7f1c"),
+		WorkflowIdReusePolicy:               &policy,
+		RetryPolicy: &shared.RetryPolicy{
+			InitialIntervalInSeconds: common.Int32Ptr(1),
+			BackoffCoefficient:       common.Float64Ptr(2.5),
+			MaximumAttempts:          common.Int32Ptr(3),
+			NonRetriableErrorReasons: []string{"bad-input", "not-found"},
+		},
+	}
+}
+
+func newTestHistoryResponse() *shared.GetWorkflowExecutionHistoryResponse {
+	started := shared.EventTypeWorkflowExecutionStarted
+	timedOut := shared.EventTypeActivityTaskTimedOut
+	timeoutType := shared.TimeoutTypeScheduleToStart
+	return &shared.GetWorkflowExecutionHistoryResponse{
+		History: &shared.History{Events: []*shared.HistoryEvent{
+			{
+				EventId:   common.Int64Ptr(1),
+				Timestamp: common.Int64Ptr(1538000000123456789),
+				EventType: &started,
+				WorkflowExecutionStartedEventAttributes: &shared.WorkflowExecutionStartedEventAttributes{
+					WorkflowType: &shared.WorkflowType{Name: common.StringPtr("process-order")},
+					Input:        []byte("{}"),
+				},
+			},
+			{
+				EventId:   common.Int64Ptr(-2),
+				EventType: &timedOut,
+				ActivityTaskTimedOutEventAttributes: &shared.ActivityTaskTimedOutEventAttributes{
+					TimeoutType: &timeoutType,
+				},
+			},
+		}},
+		NextPageToken: []byte{1},
+	}
+}
+
+func newTestRegisterDomainRequest() *shared.RegisterDomainRequest {
+	return &shared.RegisterDomainRequest{
+		Name:       common.StringPtr("orders"),
+		EmitMetric: common.BoolPtr(false),
+		Clusters: []*shared.ClusterReplicationConfiguration{
+			{ClusterName: common.StringPtr("active")},
+			{ClusterName: common.StringPtr("standby")},
+		},
+		ActiveClusterName: common.StringPtr("active"),
+		Data:              map[string]string{"team": "payments", "": "empty-key"},
+	}
+}
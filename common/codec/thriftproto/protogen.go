@@ -0,0 +1,243 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package thriftproto
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"go.uber.org/thriftrw/compile"
+)
+
+type (
+	// protoGenerator writes the protobuf definition mirroring a thrift service
+	protoGenerator struct {
+		buf      bytes.Buffer
+		types    map[string]compile.TypeSpec
+		enumVals map[string]string
+	}
+)
+
+// GenerateProto returns the proto2 definition of the service and of every type it references.
+// Messages keep the thrift field names and use the thrift field ids as field numbers, void
+// methods return google.protobuf.Empty and enum values are prefixed with the enum name because
+// protobuf enum values share the scope of the package.
+func GenerateProto(schema *Schema, protoPackage string) ([]byte, error) {
+	g := &protoGenerator{
+		types:    make(map[string]compile.TypeSpec),
+		enumVals: make(map[string]string),
+	}
+	for _, name := range schema.Methods() {
+		method, _ := schema.Method(name)
+		request, err := RequestSpec(method)
+		if err != nil {
+			return nil, err
+		}
+		g.collect(request.Type)
+		if response := ResponseSpec(method); response != nil {
+			g.collect(response)
+		}
+		if method.ResultSpec != nil {
+			for _, exception := range method.ResultSpec.Exceptions {
+				g.collect(exception.Type)
+			}
+		}
+	}
+
+	g.printf("// Code generated by thrift2proto from the thrift IDL. DO NOT EDIT.\n\n")
+	g.printf("syntax = \"proto2\";\n\n")
+	g.printf("package %v;\n\n", protoPackage)
+	g.printf("import \"google/protobuf/empty.proto\";\n\n")
+
+	g.printf("service %v {\n", schema.ServiceName())
+	for _, name := range schema.Methods() {
+		method, _ := schema.Method(name)
+		request, _ := RequestSpec(method)
+		response := "google.protobuf.Empty"
+		if spec := ResponseSpec(method); spec != nil {
+			response = compile.RootTypeSpec(spec).ThriftName()
+		}
+		g.printf("  rpc %v (%v) returns (%v);\n", name, compile.RootTypeSpec(request.Type).ThriftName(), response)
+	}
+	g.printf("}\n")
+
+	names := make([]string, 0, len(g.types))
+	for name := range g.types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		g.printf("\n")
+		var err error
+		switch t := g.types[name].(type) {
+		case *compile.EnumSpec:
+			err = g.writeEnum(t)
+		case *compile.StructSpec:
+			err = g.writeMessage(t)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return g.buf.Bytes(), nil
+}
+
+// collect records the enums and structs reachable from the type
+func (g *protoGenerator) collect(spec compile.TypeSpec) {
+	switch t := compile.RootTypeSpec(spec).(type) {
+	case *compile.EnumSpec:
+		g.types[t.Name] = t
+	case *compile.StructSpec:
+		if _, ok := g.types[t.Name]; ok {
+			return
+		}
+		g.types[t.Name] = t
+		for _, field := range t.Fields {
+			g.collect(field.Type)
+		}
+	case *compile.ListSpec:
+		g.collect(t.ValueSpec)
+	case *compile.SetSpec:
+		g.collect(t.ValueSpec)
+	case *compile.MapSpec:
+		g.collect(t.KeySpec)
+		g.collect(t.ValueSpec)
+	}
+}
+
+func (g *protoGenerator) writeEnum(spec *compile.EnumSpec) error {
+	g.writeDoc("", spec.Doc)
+	g.printf("enum %v {\n", spec.Name)
+	prefix := upperSnakeCase(spec.Name)
+	for _, item := range spec.Items {
+		name := prefix + "_" + upperSnakeCase(item.Name)
+		if other, ok := g.enumVals[name]; ok {
+			return fmt.Errorf("enum value %v of %v collides with %v", name, spec.Name, other)
+		}
+		g.enumVals[name] = spec.Name
+		g.writeDoc("  ", item.Doc)
+		g.printf("  %v = %v;\n", name, item.Value)
+	}
+	g.printf("}\n")
+	return nil
+}
+
+func (g *protoGenerator) writeMessage(spec *compile.StructSpec) error {
+	g.writeDoc("", spec.Doc)
+	g.printf("message %v {\n", spec.Name)
+	for _, field := range spec.Fields {
+		if field.ID <= 0 {
+			return fmt.Errorf("%v.%v has no valid field id", spec.Name, field.Name)
+		}
+		label, typ, err := protoType(field.Type)
+		if err != nil {
+			return fmt.Errorf("%v.%v: %v", spec.Name, field.Name, err)
+		}
+		g.writeDoc("  ", field.Doc)
+		if label != "" {
+			g.printf("  %v %v %v = %v;\n", label, typ, field.Name, field.ID)
+		} else {
+			g.printf("  %v %v = %v;\n", typ, field.Name, field.ID)
+		}
+	}
+	g.printf("}\n")
+	return nil
+}
+
+func (g *protoGenerator) writeDoc(indent string, doc string) {
+	doc = strings.TrimSpace(doc)
+	if doc == "" {
+		return
+	}
+	for _, line := range strings.Split(doc, "\n") {
+		g.printf("%v// %v\n", indent, strings.TrimSpace(line))
+	}
+}
+
+func (g *protoGenerator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+// protoType returns the label and protobuf type of a field
+func protoType(spec compile.TypeSpec) (string, string, error) {
+	switch t := compile.RootTypeSpec(spec).(type) {
+	case *compile.ListSpec:
+		typ, err := scalarProtoType(t.ValueSpec)
+		return "repeated", typ, err
+	case *compile.SetSpec:
+		typ, err := scalarProtoType(t.ValueSpec)
+		return "repeated", typ, err
+	case *compile.MapSpec:
+		key, err := scalarProtoType(t.KeySpec)
+		if err != nil {
+			return "", "", err
+		}
+		switch compile.RootTypeSpec(t.KeySpec).(type) {
+		case *compile.DoubleSpec, *compile.BinarySpec, *compile.EnumSpec, *compile.StructSpec:
+			return "", "", fmt.Errorf("%v cannot be a protobuf map key", t.KeySpec.ThriftName())
+		}
+		value, err := scalarProtoType(t.ValueSpec)
+		return "", fmt.Sprintf("map<%v, %v>", key, value), err
+	default:
+		typ, err := scalarProtoType(spec)
+		return "optional", typ, err
+	}
+}
+
+func scalarProtoType(spec compile.TypeSpec) (string, error) {
+	switch t := compile.RootTypeSpec(spec).(type) {
+	case *compile.BoolSpec:
+		return "bool", nil
+	case *compile.I8Spec, *compile.I16Spec, *compile.I32Spec:
+		return "int32", nil
+	case *compile.I64Spec:
+		return "int64", nil
+	case *compile.DoubleSpec:
+		return "double", nil
+	case *compile.StringSpec:
+		return "string", nil
+	case *compile.BinarySpec:
+		return "bytes", nil
+	case *compile.EnumSpec:
+		return t.Name, nil
+	case *compile.StructSpec:
+		return t.Name, nil
+	default:
+		return "", fmt.Errorf("nested %v cannot be mapped to protobuf", spec.ThriftName())
+	}
+}
+
+// upperSnakeCase converts names like WorkflowExecutionStarted to WORKFLOW_EXECUTION_STARTED
+func upperSnakeCase(name string) string {
+	var buf bytes.Buffer
+	runes := []rune(name)
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) && runes[i-1] != '_' &&
+			(unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+			buf.WriteByte('_')
+		}
+		buf.WriteRune(unicode.ToUpper(r))
+	}
+	return buf.String()
+}
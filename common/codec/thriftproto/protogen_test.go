@@ -0,0 +1,59 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package thriftproto
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/uber/cadence/.gen/go/cadence"
+)
+
+func TestGenerateProto(t *testing.T) {
+	schema, err := NewSchema(cadence.ThriftModule, "WorkflowService")
+	require.NoError(t, err)
+
+	generated, err := GenerateProto(schema, "uber.cadence")
+	require.NoError(t, err)
+	proto := string(generated)
+
+	require.Contains(t, proto, "package uber.cadence;")
+	require.Contains(t, proto, "rpc StartWorkflowExecution (StartWorkflowExecutionRequest) returns (StartWorkflowExecutionResponse);")
+	require.Contains(t, proto, "rpc SignalWorkflowExecution (SignalWorkflowExecutionRequest) returns (google.protobuf.Empty);")
+	require.Contains(t, proto, "  optional string domain = 10;")
+	require.Contains(t, proto, "  repeated string nonRetriableErrorReasons = 50;")
+	require.Contains(t, proto, "  map<string, string> data = 80;")
+	require.Contains(t, proto, "  EVENT_TYPE_WORKFLOW_EXECUTION_STARTED = 0;")
+	require.Equal(t, 1, strings.Count(proto, "message StartWorkflowExecutionRequest {"))
+}
+
+func TestUpperSnakeCase(t *testing.T) {
+	for name, expected := range map[string]string{
+		"WorkflowExecutionStarted": "WORKFLOW_EXECUTION_STARTED",
+		"SCHEDULE_TO_START":        "SCHEDULE_TO_START",
+		"AllowDuplicateFailedOnly": "ALLOW_DUPLICATE_FAILED_ONLY",
+		"TTLExpired":               "TTL_EXPIRED",
+		"EventType":                "EVENT_TYPE",
+	} {
+		require.Equal(t, expected, upperSnakeCase(name))
+	}
+}
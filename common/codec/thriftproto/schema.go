@@ -0,0 +1,148 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package thriftproto maps the thrift wire representation of a service onto protobuf and JSON.
+// Protobuf messages mirror the thrift structs, using the thrift field ids as field numbers, so
+// the same handlers can serve gRPC and HTTP/JSON callers.
+package thriftproto
+
+import (
+	"fmt"
+	"path"
+	"sort"
+
+	"go.uber.org/thriftrw/compile"
+	"go.uber.org/thriftrw/thriftreflect"
+)
+
+type (
+	// Schema is the compiled thrift IDL of a service
+	Schema struct {
+		module  *compile.Module
+		service *compile.ServiceSpec
+	}
+
+	// idlFS serves the IDL embedded in the generated thrift modules to the thrift compiler
+	idlFS map[string][]byte
+)
+
+// NewSchema compiles the IDL embedded in the generated module and looks up the named service
+func NewSchema(module *thriftreflect.ThriftModule, serviceName string) (*Schema, error) {
+	fs := make(idlFS)
+	fs.add(module)
+	compiled, err := compile.Compile("/"+module.FilePath, compile.Filesystem(fs))
+	if err != nil {
+		return nil, err
+	}
+	service, ok := compiled.Services[serviceName]
+	if !ok {
+		return nil, fmt.Errorf("service %v not found in %v", serviceName, module.FilePath)
+	}
+	return &Schema{module: compiled, service: service}, nil
+}
+
+// ServiceName returns the name of the thrift service
+func (s *Schema) ServiceName() string {
+	return s.service.Name
+}
+
+// Methods returns the names of the methods of the service in sorted order
+func (s *Schema) Methods() []string {
+	methods := make([]string, 0, len(s.service.Functions))
+	for name := range s.service.Functions {
+		methods = append(methods, name)
+	}
+	sort.Strings(methods)
+	return methods
+}
+
+// Method returns the spec of the named method
+func (s *Schema) Method(name string) (*compile.FunctionSpec, bool) {
+	method, ok := s.service.Functions[name]
+	return method, ok
+}
+
+// RequestSpec returns the type of the single argument taken by the method
+func RequestSpec(method *compile.FunctionSpec) (*compile.FieldSpec, error) {
+	if len(method.ArgsSpec) != 1 {
+		return nil, fmt.Errorf("method %v must take exactly one argument", method.Name)
+	}
+	return method.ArgsSpec[0], nil
+}
+
+// ResponseSpec returns the type returned by the method, which is nil for void methods
+func ResponseSpec(method *compile.FunctionSpec) compile.TypeSpec {
+	if method.ResultSpec == nil {
+		return nil
+	}
+	return method.ResultSpec.ReturnType
+}
+
+// ExceptionSpec returns the exception thrown by the method with the given field id
+func ExceptionSpec(method *compile.FunctionSpec, id int16) (*compile.FieldSpec, bool) {
+	if method.ResultSpec == nil {
+		return nil, false
+	}
+	for _, exception := range method.ResultSpec.Exceptions {
+		if exception.ID == id {
+			return exception, true
+		}
+	}
+	return nil, false
+}
+
+func (fs idlFS) add(module *thriftreflect.ThriftModule) {
+	fs["/"+module.FilePath] = []byte(module.Raw)
+	for _, include := range module.Includes {
+		fs.add(include)
+	}
+}
+
+func (fs idlFS) Read(filename string) ([]byte, error) {
+	raw, ok := fs[path.Clean(filename)]
+	if !ok {
+		return nil, fmt.Errorf("thrift file %v not found", filename)
+	}
+	return raw, nil
+}
+
+func (fs idlFS) Abs(p string) (string, error) {
+	if path.IsAbs(p) {
+		return path.Clean(p), nil
+	}
+	return path.Join("/", p), nil
+}
+
+func structSpec(spec compile.TypeSpec) (*compile.StructSpec, error) {
+	s, ok := compile.RootTypeSpec(spec).(*compile.StructSpec)
+	if !ok {
+		return nil, fmt.Errorf("%v is not a struct", spec.ThriftName())
+	}
+	return s, nil
+}
+
+func fieldByID(spec *compile.StructSpec, id int16) *compile.FieldSpec {
+	for _, field := range spec.Fields {
+		if field.ID == id {
+			return field
+		}
+	}
+	return nil
+}
@@ -88,6 +88,9 @@ const (
 	ReplicationTaskProcessorShutdownTimedout = 7105
 	ReplicationTaskProcessingFailed          = 7106
 
+	// Frontend events
+	AuthorizationDenied = 8000
+
 	// General purpose events
 	OperationFailed   = 9000
 	OperationStuck    = 9001
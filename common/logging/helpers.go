@@ -64,6 +64,18 @@ func LogOperationStuckEvent(logger bark.Logger, msg string, err error) {
 	}).Error(msg)
 }
 
+// LogAuthorizationDeniedEvent is used to audit requests rejected by the authorizer
+func LogAuthorizationDeniedEvent(logger bark.Logger, identity, api, domain, workflowID, runID, reason string) {
+	logger.WithFields(bark.Fields{
+		TagWorkflowEventID:     AuthorizationDenied,
+		TagCallerIdentity:      identity,
+		TagAPIName:             api,
+		TagDomainName:          domain,
+		TagWorkflowExecutionID: workflowID,
+		TagWorkflowRunID:       runID,
+	}).Warnf("Access denied: %v", reason)
+}
+
 // LogInternalServiceError is used to log internal service error
 func LogInternalServiceError(logger bark.Logger, err error) {
 	logger.WithFields(bark.Fields{
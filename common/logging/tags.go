@@ -49,6 +49,8 @@ const (
 	TagTaskType                   = "task-type"
 	TagSourceCluster              = "source-cluster"
 	TagPrevActiveCluster          = "prev-active-cluster"
+	TagTargetCluster              = "target-cluster"
+	TagClusterName                = "cluster-name"
 	TagTopicName                  = "topic-name"
 	TagConsumerName               = "consumer-name"
 	TagPartition                  = "partition"
@@ -69,6 +71,10 @@ const (
 	TagAttemptStart               = "attempt-start"
 	TagAttemptEnd                 = "attempt-end"
 	TagSize                       = "size"
+	TagDomainName                 = "domain-name"
+	TagDomainDifferences          = "domain-differences"
+	TagCallerIdentity             = "caller-identity"
+	TagAPIName                    = "api-name"
 
 	// workflow logging tag values
 	// TagWorkflowComponent Values
@@ -82,7 +88,11 @@ const (
 	TagValueMatchingEngineComponent           = "matching-engine"
 	TagValueReplicatorComponent               = "replicator"
 	TagValueReplicationTaskProcessorComponent = "replication-task-processor"
+	TagValueReplicationTaskPollerComponent    = "replication-task-poller"
 	TagValueHistoryReplicatorComponent        = "history-replicator"
+	TagValueHistoryImporterComponent          = "history-importer"
+	TagValueTaskListScavengerComponent        = "tasklist-scavenger"
+	TagValueDomainReconcilerComponent         = "domain-reconciler"
 
 	// TagHistoryBuilderAction values
 	TagValueActionWorkflowStarted                 = "add-workflowexecution-started-event"
@@ -96,6 +106,7 @@ const (
 	TagValueActionActivityTaskCompleted           = "add-activitytask-completed-event"
 	TagValueActionActivityTaskFailed              = "add-activitytask-failed-event"
 	TagValueActionActivityTaskTimedOut            = "add-activitytask-timed-event"
+	TagValueActionActivityTaskRedirected          = "add-activitytask-redirected-event"
 	TagValueActionActivityTaskCanceled            = "add-activitytask-canceled-event"
 	TagValueActionActivityTaskCancelRequest       = "add-activitytask-cancel-request-event"
 	TagValueActionActivityTaskCancelRequestFailed = "add-activitytask-cancel-request-failed-event"
@@ -108,6 +119,7 @@ const (
 	TagValueActionTimerCanceled                   = "add-timer-Canceled-event"
 	TagValueActionWorkflowTerminated              = "add-workflowexecution-terminated-event"
 	TagValueActionWorkflowSignaled                = "add-workflowexecution-signaled-event"
+	TagValueActionWorkflowUpdateAccepted          = "add-workflowexecution-update-accepted-event"
 	TagValueActionContinueAsNew                   = "add-continue-as-new-event"
 	TagValueActionWorkflowCanceled                = "add-workflowexecution-canceled-event"
 	TagValueActionChildExecutionStarted           = "add-childexecution-started-event"
@@ -29,6 +29,10 @@ type (
 	Client interface {
 		NewConsumer(currentCluster, sourceCluster, consumerName string, concurrency int) (Consumer, error)
 		NewProducer(sourceCluster string) (Producer, error)
+		NewDLQ(currentCluster string) (DLQ, error)
+		// GetConsumerOffsets returns the committed and the latest offset of every partition of the source cluster
+		// topic read by the given consumer
+		GetConsumerOffsets(sourceCluster, consumerName string) ([]*replicator.ConsumerPartitionOffset, error)
 	}
 
 	// Consumer is the unified interface for both internal and external kafka clients
@@ -61,4 +65,17 @@ type (
 		PublishBatch(msgs []*replicator.ReplicationTask) error
 		Close() error
 	}
+
+	// DLQ is the interface used to inspect and manage the replication dead-letter queue of a cluster
+	DLQ interface {
+		// Publish sends a replication task which exhausted all retries to the DLQ
+		Publish(msg *replicator.DLQReplicationTask) error
+		// ReadMessages returns up to maxCount messages which have not been purged yet, starting from the page token
+		ReadMessages(pageToken []byte, maxCount int) ([]*replicator.DLQMessage, []byte, error)
+		// ReadMessage returns the message with the given ID
+		ReadMessage(id *replicator.DLQMessageID) (*replicator.DLQMessage, error)
+		// Purge discards all messages of the partition up to and including the given offset
+		Purge(partition int32, inclusiveEndOffset int64) error
+		Close() error
+	}
 )
@@ -21,6 +21,8 @@
 package messaging
 
 import (
+	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/Shopify/sarama"
@@ -28,6 +30,8 @@ import (
 	uberKafkaClient "github.com/uber-go/kafka-client"
 	uberKafka "github.com/uber-go/kafka-client/kafka"
 	"github.com/uber-go/tally"
+	"github.com/uber/cadence/.gen/go/replicator"
+	"github.com/uber/cadence/common"
 	"go.uber.org/zap"
 )
 
@@ -36,9 +40,19 @@ const rcvBufferSize = 2 * 1024
 type (
 	// This is a default implementation of Client interface which makes use of uber-go/kafka-client as consumer
 	kafkaClient struct {
-		config *KafkaConfig
-		client uberKafkaClient.Client
-		logger bark.Logger
+		config        *KafkaConfig
+		topicProvider ClusterTopicProvider
+		client        uberKafkaClient.Client
+		logger        bark.Logger
+	}
+
+	// ClusterTopicProvider provides the replication topics of the cadence clusters registered at runtime
+	ClusterTopicProvider interface {
+		// GetCurrentClusterName returns the name of the current cadence cluster
+		GetCurrentClusterName() string
+		// GetReplicationTopic returns the replication topic registered for the cadence cluster, empty if
+		// the configured topic is used
+		GetReplicationTopic(clusterName string) string
 	}
 
 	// a wrapper of uberKafka.Consumer to let the compiler happy
@@ -50,8 +64,10 @@ type (
 	}
 )
 
-// NewKafkaClient is used to create an instance of KafkaClient
-func NewKafkaClient(kc *KafkaConfig, zLogger *zap.Logger, logger bark.Logger, metricScope tally.Scope) Client {
+// NewKafkaClient is used to create an instance of KafkaClient, the replication topics registered at runtime
+// are looked up from the topic provider if it is not nil
+func NewKafkaClient(kc *KafkaConfig, topicProvider ClusterTopicProvider, zLogger *zap.Logger, logger bark.Logger,
+	metricScope tally.Scope) Client {
 	kc.Validate()
 
 	// mapping from cluster name to list of broker ip addresses
@@ -74,9 +90,10 @@ func NewKafkaClient(kc *KafkaConfig, zLogger *zap.Logger, logger bark.Logger, me
 	client := uberKafkaClient.New(uberKafka.NewStaticNameResolver(topicClusterAssignment, brokers), zLogger, metricScope)
 
 	return &kafkaClient{
-		config: kc,
-		client: client,
-		logger: logger,
+		config:        kc,
+		topicProvider: topicProvider,
+		client:        client,
+		logger:        logger,
 	}
 }
 
@@ -126,11 +143,11 @@ func (c *kafkaConsumer) Messages() <-chan Message {
 
 // NewConsumer is used to create a Kafka consumer
 func (c *kafkaClient) NewConsumer(currentCluster, sourceCluster, consumerName string, concurrency int) (Consumer, error) {
-	currentTopics := c.config.getTopicsForCadenceCluster(currentCluster)
-	sourceTopics := c.config.getTopicsForCadenceCluster(sourceCluster)
+	currentTopics := c.getTopicsForCadenceCluster(currentCluster)
+	sourceTopics := c.getTopicsForCadenceCluster(sourceCluster)
 
-	topicKafkaCluster := c.config.getKafkaClusterForTopic(sourceTopics.Topic)
-	dqlTopicKafkaCluster := c.config.getKafkaClusterForTopic(currentTopics.DLQTopic)
+	topicKafkaCluster := c.getKafkaClusterForTopic(sourceTopics.Topic)
+	dqlTopicKafkaCluster := c.getKafkaClusterForTopic(currentTopics.DLQTopic)
 
 	topicList := uberKafka.ConsumerTopicList{
 		uberKafka.ConsumerTopic{
@@ -158,8 +175,8 @@ func (c *kafkaClient) NewConsumer(currentCluster, sourceCluster, consumerName st
 
 // NewProducer is used to create a Kafka producer for shipping replication tasks
 func (c *kafkaClient) NewProducer(sourceCluster string) (Producer, error) {
-	topics := c.config.getTopicsForCadenceCluster(sourceCluster)
-	kafkaClusterName := c.config.getKafkaClusterForTopic(topics.Topic)
+	topics := c.getTopicsForCadenceCluster(sourceCluster)
+	kafkaClusterName := c.getKafkaClusterForTopic(topics.Topic)
 	brokers := c.config.getBrokersForKafkaCluster(kafkaClusterName)
 
 	producer, err := sarama.NewSyncProducer(brokers, nil)
@@ -169,3 +186,101 @@ func (c *kafkaClient) NewProducer(sourceCluster string) (Producer, error) {
 
 	return NewKafkaProducer(topics.Topic, producer, c.logger), nil
 }
+
+// NewDLQ is used to create the DLQ of the current cluster, which is used to publish and re-drive replication
+// tasks which failed processing
+func (c *kafkaClient) NewDLQ(currentCluster string) (DLQ, error) {
+	topics := c.getTopicsForCadenceCluster(currentCluster)
+	kafkaClusterName := c.getKafkaClusterForTopic(topics.DLQTopic)
+	brokers := c.config.getBrokersForKafkaCluster(kafkaClusterName)
+
+	return NewKafkaDLQ(topics.DLQTopic, getDLQGroupName(currentCluster), brokers, c.logger)
+}
+
+// GetConsumerOffsets returns the committed and the latest offset of every partition of the source cluster topic
+// read by the given consumer, partitions without a committed offset report the oldest offset which is where the
+// consumer starts from
+func (c *kafkaClient) GetConsumerOffsets(sourceCluster, consumerName string) ([]*replicator.ConsumerPartitionOffset, error) {
+	topics := c.getTopicsForCadenceCluster(sourceCluster)
+	kafkaClusterName := c.getKafkaClusterForTopic(topics.Topic)
+	brokers := c.config.getBrokersForKafkaCluster(kafkaClusterName)
+
+	client, err := sarama.NewClient(brokers, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	partitions, err := client.Partitions(topics.Topic)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(partitions, func(i, j int) bool { return partitions[i] < partitions[j] })
+
+	offsetManager, err := sarama.NewOffsetManagerFromClient(consumerName, client)
+	if err != nil {
+		return nil, err
+	}
+	defer offsetManager.Close()
+
+	offsets := make([]*replicator.ConsumerPartitionOffset, 0, len(partitions))
+	for _, partition := range partitions {
+		oldest, err := client.GetOffset(topics.Topic, partition, sarama.OffsetOldest)
+		if err != nil {
+			return nil, err
+		}
+		latest, err := client.GetOffset(topics.Topic, partition, sarama.OffsetNewest)
+		if err != nil {
+			return nil, err
+		}
+
+		partitionOffsetManager, err := offsetManager.ManagePartition(topics.Topic, partition)
+		if err != nil {
+			return nil, err
+		}
+		committed, _ := partitionOffsetManager.NextOffset()
+		partitionOffsetManager.Close()
+		if committed < oldest {
+			committed = oldest
+		}
+
+		offsets = append(offsets, &replicator.ConsumerPartitionOffset{
+			Partition:       common.Int32Ptr(partition),
+			CommittedOffset: common.Int64Ptr(committed),
+			LatestOffset:    common.Int64Ptr(latest),
+		})
+	}
+	return offsets, nil
+}
+
+// getTopicsForCadenceCluster returns the configured topics of the cadence cluster, with the replication topic
+// registered at runtime if there is one
+func (c *kafkaClient) getTopicsForCadenceCluster(cadenceCluster string) TopicList {
+	topics := c.config.getTopicsForCadenceCluster(cadenceCluster)
+	if c.topicProvider != nil {
+		if topic := c.topicProvider.GetReplicationTopic(cadenceCluster); topic != "" {
+			topics.Topic = topic
+		}
+	}
+	return topics
+}
+
+// getKafkaClusterForTopic returns the kafka cluster of the topic, the topics registered at runtime are not in
+// the config and have to be in the kafka cluster of the replication topic of the current cadence cluster
+func (c *kafkaClient) getKafkaClusterForTopic(topic string) string {
+	if _, ok := c.config.Topics[topic]; ok || c.topicProvider == nil {
+		return c.config.getKafkaClusterForTopic(topic)
+	}
+	currentTopics := c.config.getTopicsForCadenceCluster(c.topicProvider.GetCurrentClusterName())
+	return c.config.getKafkaClusterForTopic(currentTopics.Topic)
+}
+
+func getDLQGroupName(currentCluster string) string {
+	return fmt.Sprintf("%v_dlq_manager", currentCluster)
+}
+
+// GetReplicationConsumerName returns the name of the consumer group reading the replication tasks
+// of the source cluster on the current cluster
+func GetReplicationConsumerName(currentCluster, sourceCluster string) string {
+	return fmt.Sprintf("%v_consumer_for_%v", currentCluster, sourceCluster)
+}
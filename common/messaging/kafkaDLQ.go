@@ -0,0 +1,356 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package messaging
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/uber-common/bark"
+	"github.com/uber/cadence/.gen/go/replicator"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/codec"
+	"github.com/uber/cadence/common/logging"
+)
+
+const dlqReadTimeout = 10 * time.Second
+
+type (
+	// kafkaDLQ is the Kafka based DLQ implementation, purged messages are tracked as the committed offsets
+	// of a dedicated consumer group on the DLQ topic
+	kafkaDLQ struct {
+		topic      string
+		group      string
+		client     sarama.Client
+		producer   sarama.SyncProducer
+		msgEncoder codec.BinaryEncoder
+		logger     bark.Logger
+	}
+
+	// dlqPageToken holds the next offset to read for each partition of the DLQ topic
+	dlqPageToken struct {
+		NextOffsets map[int32]int64
+	}
+)
+
+var (
+	// ErrDLQMessageNotFound is the error to indicate the DLQ message does not exist or was already purged
+	ErrDLQMessageNotFound = errors.New("DLQ message not found or already purged")
+	// ErrDLQReadTimeout is the error to indicate reading messages from the DLQ topic timed out
+	ErrDLQReadTimeout = errors.New("timed out reading messages from DLQ")
+	// ErrInvalidDLQPageToken is the error to indicate the DLQ page token cannot be parsed
+	ErrInvalidDLQPageToken = errors.New("invalid DLQ page token")
+)
+
+var _ DLQ = (*kafkaDLQ)(nil)
+
+// NewKafkaDLQ is used to create the Kafka based DLQ implementation
+func NewKafkaDLQ(topic, group string, brokers []string, logger bark.Logger) (DLQ, error) {
+	config := sarama.NewConfig()
+	config.Producer.Return.Successes = true
+	config.Consumer.Offsets.Initial = sarama.OffsetOldest
+
+	client, err := sarama.NewClient(brokers, config)
+	if err != nil {
+		return nil, err
+	}
+	producer, err := sarama.NewSyncProducerFromClient(client)
+	if err != nil {
+		client.Close()
+		return nil, err
+	}
+
+	return &kafkaDLQ{
+		topic:      topic,
+		group:      group,
+		client:     client,
+		producer:   producer,
+		msgEncoder: codec.NewThriftRWEncoder(),
+		logger: logger.WithFields(bark.Fields{
+			logging.TagTopicName: topic,
+		}),
+	}, nil
+}
+
+// Publish sends a replication task which exhausted all retries to the DLQ topic
+func (d *kafkaDLQ) Publish(msg *replicator.DLQReplicationTask) error {
+	payload, err := d.msgEncoder.Encode(msg)
+	if err != nil {
+		return err
+	}
+
+	var partitionKey sarama.Encoder
+	if attr := msg.Task.GetHistoryTaskAttributes(); attr != nil {
+		partitionKey = sarama.StringEncoder(attr.GetWorkflowId())
+	}
+
+	partition, offset, err := d.producer.SendMessage(&sarama.ProducerMessage{
+		Topic: d.topic,
+		Key:   partitionKey,
+		Value: sarama.ByteEncoder(payload),
+	})
+	if err != nil {
+		d.logger.WithFields(bark.Fields{
+			logging.TagPartition: partition,
+			logging.TagOffset:    offset,
+			logging.TagErr:       err,
+		}).Warn("Failed to publish message to DLQ")
+		return err
+	}
+	return nil
+}
+
+// ReadMessages returns up to maxCount messages which have not been purged yet, partitions are read in order
+func (d *kafkaDLQ) ReadMessages(pageToken []byte, maxCount int) ([]*replicator.DLQMessage, []byte, error) {
+	token, err := d.getPageToken(pageToken)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	partitions := make([]int32, 0, len(token.NextOffsets))
+	for partition := range token.NextOffsets {
+		partitions = append(partitions, partition)
+	}
+	sort.Slice(partitions, func(i, j int) bool { return partitions[i] < partitions[j] })
+
+	consumer, err := sarama.NewConsumerFromClient(d.client)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer consumer.Close()
+
+	var messages []*replicator.DLQMessage
+	hasMore := false
+	for _, partition := range partitions {
+		if len(messages) >= maxCount {
+			hasMore = true
+			break
+		}
+
+		start, end, err := d.getReadRange(partition, token.NextOffsets[partition])
+		if err != nil {
+			return nil, nil, err
+		}
+		if start >= end {
+			delete(token.NextOffsets, partition)
+			continue
+		}
+
+		count := maxCount - len(messages)
+		if int64(count) > end-start {
+			count = int(end - start)
+		}
+		partitionMessages, err := d.consume(consumer, partition, start, count)
+		if err != nil {
+			return nil, nil, err
+		}
+		messages = append(messages, partitionMessages...)
+
+		next := partitionMessages[len(partitionMessages)-1].ID.GetOffset() + 1
+		if next < end {
+			token.NextOffsets[partition] = next
+			hasMore = true
+		} else {
+			delete(token.NextOffsets, partition)
+		}
+	}
+
+	if !hasMore || len(token.NextOffsets) == 0 {
+		return messages, nil, nil
+	}
+	nextPageToken, err := json.Marshal(token)
+	if err != nil {
+		return nil, nil, err
+	}
+	return messages, nextPageToken, nil
+}
+
+// ReadMessage returns the message with the given ID, purged messages are reported as not found
+func (d *kafkaDLQ) ReadMessage(id *replicator.DLQMessageID) (*replicator.DLQMessage, error) {
+	start, end, err := d.getReadRange(id.GetPartition(), id.GetOffset())
+	if err != nil {
+		return nil, err
+	}
+	if start != id.GetOffset() || start >= end {
+		return nil, ErrDLQMessageNotFound
+	}
+
+	consumer, err := sarama.NewConsumerFromClient(d.client)
+	if err != nil {
+		return nil, err
+	}
+	defer consumer.Close()
+
+	messages, err := d.consume(consumer, id.GetPartition(), start, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(messages) == 0 || messages[0].ID.GetOffset() != id.GetOffset() {
+		return nil, ErrDLQMessageNotFound
+	}
+	return messages[0], nil
+}
+
+// Purge discards all messages of the partition up to and including the given offset by committing the offset after it
+func (d *kafkaDLQ) Purge(partition int32, inclusiveEndOffset int64) error {
+	offsetManager, err := sarama.NewOffsetManagerFromClient(d.group, d.client)
+	if err != nil {
+		return err
+	}
+	defer offsetManager.Close()
+
+	partitionOffsetManager, err := offsetManager.ManagePartition(d.topic, partition)
+	if err != nil {
+		return err
+	}
+	partitionOffsetManager.MarkOffset(inclusiveEndOffset+1, "")
+	// closing the partition offset manager waits for the marked offset to be committed
+	return partitionOffsetManager.Close()
+}
+
+// Close is used to close the DLQ producer and client
+func (d *kafkaDLQ) Close() error {
+	if err := d.producer.Close(); err != nil {
+		return err
+	}
+	return d.client.Close()
+}
+
+// getPageToken returns the page token, the first page starts from the committed offset of every partition
+func (d *kafkaDLQ) getPageToken(pageToken []byte) (*dlqPageToken, error) {
+	if len(pageToken) != 0 {
+		token := &dlqPageToken{}
+		if err := json.Unmarshal(pageToken, token); err != nil || token.NextOffsets == nil {
+			return nil, ErrInvalidDLQPageToken
+		}
+		return token, nil
+	}
+
+	partitions, err := d.client.Partitions(d.topic)
+	if err != nil {
+		return nil, err
+	}
+	token := &dlqPageToken{NextOffsets: make(map[int32]int64, len(partitions))}
+	for _, partition := range partitions {
+		token.NextOffsets[partition] = sarama.OffsetOldest
+	}
+	return token, nil
+}
+
+// getReadRange returns the offset range [start, end) which can be read from the partition, start is moved
+// forward past the purged messages and the messages removed by retention
+func (d *kafkaDLQ) getReadRange(partition int32, offset int64) (int64, int64, error) {
+	committed, err := d.getCommittedOffset(partition)
+	if err != nil {
+		return 0, 0, err
+	}
+	oldest, err := d.client.GetOffset(d.topic, partition, sarama.OffsetOldest)
+	if err != nil {
+		return 0, 0, err
+	}
+	newest, err := d.client.GetOffset(d.topic, partition, sarama.OffsetNewest)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	start := offset
+	if start < committed {
+		start = committed
+	}
+	if start < oldest {
+		start = oldest
+	}
+	return start, newest, nil
+}
+
+func (d *kafkaDLQ) getCommittedOffset(partition int32) (int64, error) {
+	offsetManager, err := sarama.NewOffsetManagerFromClient(d.group, d.client)
+	if err != nil {
+		return 0, err
+	}
+	defer offsetManager.Close()
+
+	partitionOffsetManager, err := offsetManager.ManagePartition(d.topic, partition)
+	if err != nil {
+		return 0, err
+	}
+	defer partitionOffsetManager.Close()
+
+	offset, _ := partitionOffsetManager.NextOffset()
+	return offset, nil
+}
+
+func (d *kafkaDLQ) consume(consumer sarama.Consumer, partition int32, start int64, count int) ([]*replicator.DLQMessage, error) {
+	partitionConsumer, err := consumer.ConsumePartition(d.topic, partition, start)
+	if err != nil {
+		return nil, err
+	}
+	defer partitionConsumer.Close()
+
+	timer := time.NewTimer(dlqReadTimeout)
+	defer timer.Stop()
+
+	var messages []*replicator.DLQMessage
+	for len(messages) < count {
+		select {
+		case msg := <-partitionConsumer.Messages():
+			messages = append(messages, d.deserialize(msg))
+		case err := <-partitionConsumer.Errors():
+			return nil, err
+		case <-timer.C:
+			return nil, ErrDLQReadTimeout
+		}
+	}
+	return messages, nil
+}
+
+// deserialize decodes the DLQ message, messages nacked to the DLQ by the Kafka consumer only contain
+// the raw replication task
+func (d *kafkaDLQ) deserialize(msg *sarama.ConsumerMessage) *replicator.DLQMessage {
+	dlqMessage := &replicator.DLQMessage{
+		ID: &replicator.DLQMessageID{
+			Partition: common.Int32Ptr(msg.Partition),
+			Offset:    common.Int64Ptr(msg.Offset),
+		},
+	}
+
+	dlqTask := &replicator.DLQReplicationTask{}
+	if err := d.msgEncoder.Decode(msg.Value, dlqTask); err == nil && dlqTask.Task != nil {
+		dlqMessage.ReplicationTask = dlqTask
+		return dlqMessage
+	}
+
+	task := &replicator.ReplicationTask{}
+	if err := d.msgEncoder.Decode(msg.Value, task); err != nil {
+		dlqMessage.ReplicationTask = &replicator.DLQReplicationTask{
+			LastError: common.StringPtr("failed to deserialize DLQ message: " + err.Error()),
+		}
+		return dlqMessage
+	}
+	dlqMessage.ReplicationTask = &replicator.DLQReplicationTask{Task: task}
+	if !msg.Timestamp.IsZero() {
+		dlqMessage.ReplicationTask.Timestamp = common.Int64Ptr(msg.Timestamp.UnixNano())
+	}
+	return dlqMessage
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package messaging
+
+import (
+	"testing"
+
+	"github.com/Shopify/sarama"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-common/bark"
+	"github.com/uber/cadence/.gen/go/replicator"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/codec"
+)
+
+type (
+	kafkaDLQSuite struct {
+		suite.Suite
+		dlq        *kafkaDLQ
+		msgEncoder codec.BinaryEncoder
+	}
+)
+
+func TestKafkaDLQSuite(t *testing.T) {
+	s := new(kafkaDLQSuite)
+	suite.Run(t, s)
+}
+
+func (s *kafkaDLQSuite) SetupTest() {
+	s.msgEncoder = codec.NewThriftRWEncoder()
+	s.dlq = &kafkaDLQ{
+		topic:      "test-dlq",
+		msgEncoder: s.msgEncoder,
+		logger:     bark.NewLoggerFromLogrus(log.New()),
+	}
+}
+
+func (s *kafkaDLQSuite) TestDeserialize_DLQReplicationTask() {
+	dlqTask := &replicator.DLQReplicationTask{
+		Task:          s.newHistoryTask(),
+		SourceCluster: common.StringPtr("standby"),
+		LastError:     common.StringPtr("test error"),
+		Attempts:      common.Int32Ptr(5),
+		Timestamp:     common.Int64Ptr(12345),
+	}
+	payload, err := s.msgEncoder.Encode(dlqTask)
+	s.NoError(err)
+
+	message := s.dlq.deserialize(&sarama.ConsumerMessage{Partition: 1, Offset: 10, Value: payload})
+	s.Equal(int32(1), message.GetID().GetPartition())
+	s.Equal(int64(10), message.GetID().GetOffset())
+	s.Equal(dlqTask, message.ReplicationTask)
+}
+
+func (s *kafkaDLQSuite) TestDeserialize_NackedReplicationTask() {
+	task := s.newHistoryTask()
+	task.SourceTaskId = common.Int64Ptr(100)
+	payload, err := s.msgEncoder.Encode(task)
+	s.NoError(err)
+
+	message := s.dlq.deserialize(&sarama.ConsumerMessage{Partition: 1, Offset: 10, Value: payload})
+	s.Equal(task, message.GetReplicationTask().GetTask())
+	s.Nil(message.GetReplicationTask().LastError)
+	s.Nil(message.GetReplicationTask().Timestamp)
+}
+
+func (s *kafkaDLQSuite) TestDeserialize_InvalidPayload() {
+	message := s.dlq.deserialize(&sarama.ConsumerMessage{Partition: 1, Offset: 10, Value: []byte("invalid")})
+	s.Nil(message.GetReplicationTask().GetTask())
+	s.NotEmpty(message.GetReplicationTask().GetLastError())
+}
+
+func (s *kafkaDLQSuite) newHistoryTask() *replicator.ReplicationTask {
+	return &replicator.ReplicationTask{
+		TaskType: replicator.ReplicationTaskTypeHistory.Ptr(),
+		HistoryTaskAttributes: &replicator.HistoryTaskAttributes{
+			TargetClusters: []string{"standby"},
+			DomainId:       common.StringPtr("test-domain-id"),
+			WorkflowId:     common.StringPtr("test-wf-id"),
+			RunId:          common.StringPtr("test-run-id"),
+			FirstEventId:   common.Int64Ptr(5),
+			NextEventId:    common.Int64Ptr(8),
+			Version:        common.Int64Ptr(1),
+		},
+	}
+}
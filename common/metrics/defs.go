@@ -69,6 +69,11 @@ const (
 	ShardTagName       = "shard"
 	CadenceRoleTagName = "cadence-role"
 	StatsTypeTagName   = "stats-type"
+	DomainTagName      = "domain"
+	// TargetClusterTagName is the remote cluster receiving the replication tasks
+	TargetClusterTagName = "target-cluster"
+	// RemoteClusterTagName is the remote cluster the domain records are reconciled with
+	RemoteClusterTagName = "remote-cluster"
 )
 
 // This package should hold all the metrics and tags for cadence
@@ -136,6 +141,8 @@ const (
 	PersistenceResetMutableStateScope
 	// PersistenceDeleteWorkflowExecutionScope tracks DeleteWorkflowExecution calls made by service to persistence layer
 	PersistenceDeleteWorkflowExecutionScope
+	// PersistenceDeleteCurrentWorkflowExecutionScope tracks DeleteCurrentWorkflowExecution calls made by service to persistence layer
+	PersistenceDeleteCurrentWorkflowExecutionScope
 	// PersistenceGetCurrentExecutionScope tracks GetCurrentExecution calls made by service to persistence layer
 	PersistenceGetCurrentExecutionScope
 	// PersistenceGetTransferTasksScope tracks GetTransferTasks calls made by service to persistence layer
@@ -160,14 +167,24 @@ const (
 	PersistenceGetTasksScope
 	// PersistenceCompleteTaskScope tracks CompleteTask calls made by service to persistence layer
 	PersistenceCompleteTaskScope
+	// PersistenceCompleteTasksLessThanScope is the metric scope for persistence.TaskManager.PersistenceCompleteTasksLessThan API
+	PersistenceCompleteTasksLessThanScope
 	// PersistenceLeaseTaskListScope tracks LeaseTaskList calls made by service to persistence layer
 	PersistenceLeaseTaskListScope
 	// PersistenceUpdateTaskListScope tracks PersistenceUpdateTaskListScope calls made by service to persistence layer
 	PersistenceUpdateTaskListScope
+	// PersistenceGetTaskListScope is the metric scope for persistence.TaskManager.GetTaskList API
+	PersistenceGetTaskListScope
+	// PersistenceListTaskListScope is the metric scope for persistence.TaskManager.ListTaskList API
+	PersistenceListTaskListScope
+	// PersistenceDeleteTaskListScope is the metric scope for persistence.TaskManager.DeleteTaskList API
+	PersistenceDeleteTaskListScope
 	// PersistenceAppendHistoryEventsScope tracks AppendHistoryEvents calls made by service to persistence layer
 	PersistenceAppendHistoryEventsScope
 	// PersistenceGetWorkflowExecutionHistoryScope tracks GetWorkflowExecutionHistory calls made by service to persistence layer
 	PersistenceGetWorkflowExecutionHistoryScope
+	// PersistenceGetWorkflowExecutionRawHistoryScope tracks GetWorkflowExecutionRawHistory calls made by service to persistence layer
+	PersistenceGetWorkflowExecutionRawHistoryScope
 	// PersistenceDeleteWorkflowExecutionHistoryScope tracks DeleteWorkflowExecutionHistory calls made by service to persistence layer
 	PersistenceDeleteWorkflowExecutionHistoryScope
 	// PersistenceCreateDomainScope tracks CreateDomain calls made by service to persistence layer
@@ -184,6 +201,10 @@ const (
 	PersistenceListDomainScope
 	// PersistenceGetMetadataScope tracks DeleteDomainByName calls made by service to persistence layer
 	PersistenceGetMetadataScope
+	// PersistenceListClustersScope tracks ListClusters calls made by service to persistence layer
+	PersistenceListClustersScope
+	// PersistenceUpsertClusterScope tracks UpsertCluster calls made by service to persistence layer
+	PersistenceUpsertClusterScope
 	// PersistenceRecordWorkflowExecutionStartedScope tracks RecordWorkflowExecutionStarted calls made by service to persistence layer
 	PersistenceRecordWorkflowExecutionStartedScope
 	// PersistenceRecordWorkflowExecutionClosedScope tracks RecordWorkflowExecutionClosed calls made by service to persistence layer
@@ -232,6 +253,8 @@ const (
 	HistoryClientRequestCancelWorkflowExecutionScope
 	// HistoryClientSignalWorkflowExecutionScope tracks RPC calls to history service
 	HistoryClientSignalWorkflowExecutionScope
+	// HistoryClientUpdateWorkflowExecutionScope tracks RPC calls to history service
+	HistoryClientUpdateWorkflowExecutionScope
 	// HistoryClientSignalWithStartWorkflowExecutionScope tracks RPC calls to history service
 	HistoryClientSignalWithStartWorkflowExecutionScope
 	// HistoryClientRemoveSignalMutableStateScope tracks RPC calls to history service
@@ -244,8 +267,14 @@ const (
 	HistoryClientRecordChildExecutionCompletedScope
 	// HistoryClientReplicateEventsScope tracks RPC calls to history service
 	HistoryClientReplicateEventsScope
+	// HistoryClientImportWorkflowExecutionScope tracks RPC calls to history service
+	HistoryClientImportWorkflowExecutionScope
 	// HistoryClientSyncShardStatusScope tracks RPC calls to history service
 	HistoryClientSyncShardStatusScope
+	// HistoryClientGetReplicationMessagesScope tracks RPC calls to history service
+	HistoryClientGetReplicationMessagesScope
+	// HistoryClientDescribeReplicationStatusScope tracks RPC calls to history service
+	HistoryClientDescribeReplicationStatusScope
 	// MatchingClientPollForDecisionTaskScope tracks RPC calls to matching service
 	MatchingClientPollForDecisionTaskScope
 	// MatchingClientPollForActivityTaskScope tracks RPC calls to matching service
@@ -262,6 +291,8 @@ const (
 	MatchingClientCancelOutstandingPollScope
 	// MatchingClientDescribeTaskListScope tracks RPC calls to matching service
 	MatchingClientDescribeTaskListScope
+	// MatchingClientListTaskListsScope tracks RPC calls to matching service
+	MatchingClientListTaskListsScope
 	// DomainCacheScope tracks domain cache callbacks
 	DomainCacheScope
 
@@ -300,8 +331,12 @@ const (
 	FrontendRespondActivityTaskCanceledByIDScope
 	// FrontendGetWorkflowExecutionHistoryScope is the metric scope for frontend.GetWorkflowExecutionHistory
 	FrontendGetWorkflowExecutionHistoryScope
+	// FrontendGetWorkflowExecutionResultScope is the metric scope for frontend.GetWorkflowExecutionResult
+	FrontendGetWorkflowExecutionResultScope
 	// FrontendSignalWorkflowExecutionScope is the metric scope for frontend.SignalWorkflowExecution
 	FrontendSignalWorkflowExecutionScope
+	// FrontendUpdateWorkflowExecutionScope is the metric scope for frontend.UpdateWorkflowExecution
+	FrontendUpdateWorkflowExecutionScope
 	// FrontendSignalWithStartWorkflowExecutionScope is the metric scope for frontend.SignalWithStartWorkflowExecution
 	FrontendSignalWithStartWorkflowExecutionScope
 	// FrontendTerminateWorkflowExecutionScope is the metric scope for frontend.TerminateWorkflowExecution
@@ -326,6 +361,8 @@ const (
 	FrontendDescribeWorkflowExecutionScope
 	// FrontendDescribeTaskListScope is the metric scope for frontend.DescribeTaskList
 	FrontendDescribeTaskListScope
+	// FrontendListTaskListsScope is the metric scope for frontend.ListTaskLists
+	FrontendListTaskListsScope
 	// FrontendResetStickyTaskListScope is the metric scope for frontend.ResetStickyTaskList
 	FrontendResetStickyTaskListScope
 	// FrontendListDomainsScope is the metric scope for frontend.ListDomain
@@ -362,6 +399,8 @@ const (
 	HistoryRecordActivityTaskStartedScope
 	// HistorySignalWorkflowExecutionScope tracks SignalWorkflowExecution API calls received by service
 	HistorySignalWorkflowExecutionScope
+	// HistoryUpdateWorkflowExecutionScope tracks UpdateWorkflowExecution API calls received by service
+	HistoryUpdateWorkflowExecutionScope
 	// HistorySignalWithStartWorkflowExecutionScope tracks SignalWithStartWorkflowExecution API calls received by service
 	HistorySignalWithStartWorkflowExecutionScope
 	// HistoryRemoveSignalMutableStateScope tracks RemoveSignalMutableState API calls received by service
@@ -376,8 +415,14 @@ const (
 	HistoryRequestCancelWorkflowExecutionScope
 	// HistoryReplicateEventsScope tracks ReplicateEvents API calls received by service
 	HistoryReplicateEventsScope
+	// HistoryImportWorkflowExecutionScope tracks ImportWorkflowExecution API calls received by service
+	HistoryImportWorkflowExecutionScope
 	// HistorySyncShardStatusScope tracks ReplicateEvents API calls received by service
 	HistorySyncShardStatusScope
+	// HistoryGetReplicationMessagesScope tracks GetReplicationMessages API calls received by service
+	HistoryGetReplicationMessagesScope
+	// HistoryDescribeReplicationStatusScope tracks DescribeReplicationStatus API calls received by service
+	HistoryDescribeReplicationStatusScope
 	// HistoryShardControllerScope is the scope used by shard controller
 	HistoryShardControllerScope
 	// TransferQueueProcessorScope is the scope used by all metric emitted by transfer queue processor
@@ -450,6 +495,8 @@ const (
 	ReplicatorQueueProcessorScope
 	// ReplicatorTaskHistoryScope is the scope used for history task processing by replicator queue processor
 	ReplicatorTaskHistoryScope
+	// ReplicationTaskPollerScope is the scope used by the poller pulling replication tasks from a remote cluster
+	ReplicationTaskPollerScope
 	// ReplicateHistoryEventsScope is the scope used by historyReplicator API for applying events
 	ReplicateHistoryEventsScope
 	// ShardInfoScope is the scope used when updating shard info
@@ -494,6 +541,8 @@ const (
 	MatchingCancelOutstandingPollScope
 	// MatchingDescribeTaskListScope tracks DescribeTaskList API calls received by service
 	MatchingDescribeTaskListScope
+	// MatchingListTaskListsScope tracks ListTaskLists API calls received by service
+	MatchingListTaskListsScope
 
 	NumMatchingScopes
 )
@@ -508,6 +557,10 @@ const (
 	HistoryReplicationTaskScope
 	// SyncShardTaskScope is the scope used by sync shrad information processing
 	SyncShardTaskScope
+	// TaskListScavengerScope is the scope used by the task list scavenger
+	TaskListScavengerScope
+	// DomainReconcilerScope is the scope used by the domain reconciler
+	DomainReconcilerScope
 
 	NumWorkerScopes
 )
@@ -524,6 +577,7 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		PersistenceUpdateWorkflowExecutionScope:                  {operation: "UpdateWorkflowExecution"},
 		PersistenceResetMutableStateScope:                        {operation: "ResetMutableState"},
 		PersistenceDeleteWorkflowExecutionScope:                  {operation: "DeleteWorkflowExecution"},
+		PersistenceDeleteCurrentWorkflowExecutionScope:           {operation: "DeleteCurrentWorkflowExecution"},
 		PersistenceGetCurrentExecutionScope:                      {operation: "GetCurrentExecution"},
 		PersistenceGetTransferTasksScope:                         {operation: "GetTransferTasks"},
 		PersistenceGetReplicationTasksScope:                      {operation: "GetReplicationTasks"},
@@ -536,10 +590,15 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		PersistenceCreateTaskScope:                               {operation: "CreateTask", tags: map[string]string{ShardTagName: NoneShardsTagValue}},
 		PersistenceGetTasksScope:                                 {operation: "GetTasks", tags: map[string]string{ShardTagName: NoneShardsTagValue}},
 		PersistenceCompleteTaskScope:                             {operation: "CompleteTask", tags: map[string]string{ShardTagName: NoneShardsTagValue}},
+		PersistenceCompleteTasksLessThanScope:                    {operation: "CompleteTasksLessThan", tags: map[string]string{ShardTagName: NoneShardsTagValue}},
 		PersistenceLeaseTaskListScope:                            {operation: "LeaseTaskList", tags: map[string]string{ShardTagName: NoneShardsTagValue}},
 		PersistenceUpdateTaskListScope:                           {operation: "UpdateTaskList", tags: map[string]string{ShardTagName: NoneShardsTagValue}},
+		PersistenceGetTaskListScope:                              {operation: "GetTaskList", tags: map[string]string{ShardTagName: NoneShardsTagValue}},
+		PersistenceListTaskListScope:                             {operation: "ListTaskList", tags: map[string]string{ShardTagName: NoneShardsTagValue}},
+		PersistenceDeleteTaskListScope:                           {operation: "DeleteTaskList", tags: map[string]string{ShardTagName: NoneShardsTagValue}},
 		PersistenceAppendHistoryEventsScope:                      {operation: "AppendHistoryEvents", tags: map[string]string{ShardTagName: NoneShardsTagValue}},
 		PersistenceGetWorkflowExecutionHistoryScope:              {operation: "GetWorkflowExecutionHistory", tags: map[string]string{ShardTagName: NoneShardsTagValue}},
+		PersistenceGetWorkflowExecutionRawHistoryScope:           {operation: "GetWorkflowExecutionRawHistory", tags: map[string]string{ShardTagName: NoneShardsTagValue}},
 		PersistenceDeleteWorkflowExecutionHistoryScope:           {operation: "DeleteWorkflowExecutionHistory", tags: map[string]string{ShardTagName: NoneShardsTagValue}},
 		PersistenceCreateDomainScope:                             {operation: "CreateDomain", tags: map[string]string{ShardTagName: NoneShardsTagValue}},
 		PersistenceGetDomainScope:                                {operation: "GetDomain", tags: map[string]string{ShardTagName: NoneShardsTagValue}},
@@ -548,6 +607,8 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		PersistenceDeleteDomainByNameScope:                       {operation: "DeleteDomainByName", tags: map[string]string{ShardTagName: NoneShardsTagValue}},
 		PersistenceListDomainScope:                               {operation: "ListDomain", tags: map[string]string{ShardTagName: NoneShardsTagValue}},
 		PersistenceGetMetadataScope:                              {operation: "GetMetadata", tags: map[string]string{ShardTagName: NoneShardsTagValue}},
+		PersistenceListClustersScope:                             {operation: "ListClusters", tags: map[string]string{ShardTagName: NoneShardsTagValue}},
+		PersistenceUpsertClusterScope:                            {operation: "UpsertCluster", tags: map[string]string{ShardTagName: NoneShardsTagValue}},
 		PersistenceRecordWorkflowExecutionStartedScope:           {operation: "RecordWorkflowExecutionStarted"},
 		PersistenceRecordWorkflowExecutionClosedScope:            {operation: "RecordWorkflowExecutionClosed"},
 		PersistenceListOpenWorkflowExecutionsScope:               {operation: "ListOpenWorkflowExecutions"},
@@ -573,13 +634,17 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		HistoryClientRecordActivityTaskStartedScope:        {operation: "HistoryClientRecordActivityTaskStarted", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
 		HistoryClientRequestCancelWorkflowExecutionScope:   {operation: "HistoryClientRequestCancelWorkflowExecution", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
 		HistoryClientSignalWorkflowExecutionScope:          {operation: "HistoryClientSignalWorkflowExecution", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
+		HistoryClientUpdateWorkflowExecutionScope:          {operation: "HistoryClientUpdateWorkflowExecution", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
 		HistoryClientSignalWithStartWorkflowExecutionScope: {operation: "HistoryClientSignalWithStartWorkflowExecution", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
 		HistoryClientRemoveSignalMutableStateScope:         {operation: "HistoryClientRemoveSignalMutableStateScope", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
 		HistoryClientTerminateWorkflowExecutionScope:       {operation: "HistoryClientTerminateWorkflowExecution", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
 		HistoryClientScheduleDecisionTaskScope:             {operation: "HistoryClientScheduleDecisionTask", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
 		HistoryClientRecordChildExecutionCompletedScope:    {operation: "HistoryClientRecordChildExecutionCompleted", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
 		HistoryClientReplicateEventsScope:                  {operation: "HistoryClientReplicateEvents", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
+		HistoryClientImportWorkflowExecutionScope:          {operation: "HistoryClientImportWorkflowExecution", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
 		HistoryClientSyncShardStatusScope:                  {operation: "HistoryClientSyncShardStatusScope", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
+		HistoryClientGetReplicationMessagesScope:           {operation: "HistoryClientGetReplicationMessagesScope", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
+		HistoryClientDescribeReplicationStatusScope:        {operation: "HistoryClientDescribeReplicationStatus", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
 		MatchingClientPollForDecisionTaskScope:             {operation: "MatchingClientPollForDecisionTask", tags: map[string]string{CadenceRoleTagName: MatchingRoleTagValue}},
 		MatchingClientPollForActivityTaskScope:             {operation: "MatchingClientPollForActivityTask", tags: map[string]string{CadenceRoleTagName: MatchingRoleTagValue}},
 		MatchingClientAddActivityTaskScope:                 {operation: "MatchingClientAddActivityTask", tags: map[string]string{CadenceRoleTagName: MatchingRoleTagValue}},
@@ -588,6 +653,7 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		MatchingClientRespondQueryTaskCompletedScope:       {operation: "MatchingClientRespondQueryTaskCompleted", tags: map[string]string{CadenceRoleTagName: MatchingRoleTagValue}},
 		MatchingClientCancelOutstandingPollScope:           {operation: "MatchingClientCancelOutstandingPoll", tags: map[string]string{CadenceRoleTagName: MatchingRoleTagValue}},
 		MatchingClientDescribeTaskListScope:                {operation: "MatchingClientDescribeTaskList", tags: map[string]string{CadenceRoleTagName: MatchingRoleTagValue}},
+		MatchingClientListTaskListsScope:                   {operation: "MatchingClientListTaskLists", tags: map[string]string{CadenceRoleTagName: MatchingRoleTagValue}},
 		DomainCacheScope:                                   {operation: "DomainCache"},
 	},
 	// Frontend Scope Names
@@ -607,7 +673,9 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		FrontendRespondActivityTaskFailedByIDScope:    {operation: "RespondActivityTaskFailedByID"},
 		FrontendRespondActivityTaskCanceledByIDScope:  {operation: "RespondActivityTaskCanceledByID"},
 		FrontendGetWorkflowExecutionHistoryScope:      {operation: "GetWorkflowExecutionHistory"},
+		FrontendGetWorkflowExecutionResultScope:       {operation: "GetWorkflowExecutionResult"},
 		FrontendSignalWorkflowExecutionScope:          {operation: "SignalWorkflowExecution"},
+		FrontendUpdateWorkflowExecutionScope:          {operation: "UpdateWorkflowExecution"},
 		FrontendSignalWithStartWorkflowExecutionScope: {operation: "SignalWithStartWorkflowExecution"},
 		FrontendTerminateWorkflowExecutionScope:       {operation: "TerminateWorkflowExecution"},
 		FrontendRequestCancelWorkflowExecutionScope:   {operation: "RequestCancelWorkflowExecution"},
@@ -621,6 +689,7 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		FrontendQueryWorkflowScope:                    {operation: "QueryWorkflow"},
 		FrontendDescribeWorkflowExecutionScope:        {operation: "DescribeWorkflowExecution"},
 		FrontendDescribeTaskListScope:                 {operation: "DescribeTaskList"},
+		FrontendListTaskListsScope:                    {operation: "ListTaskLists"},
 		FrontendResetStickyTaskListScope:              {operation: "ResetStickyTaskList"},
 	},
 	// History Scope Names
@@ -638,6 +707,7 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		HistoryRecordDecisionTaskStartedScope:        {operation: "RecordDecisionTaskStarted"},
 		HistoryRecordActivityTaskStartedScope:        {operation: "RecordActivityTaskStarted"},
 		HistorySignalWorkflowExecutionScope:          {operation: "SignalWorkflowExecution"},
+		HistoryUpdateWorkflowExecutionScope:          {operation: "UpdateWorkflowExecution"},
 		HistorySignalWithStartWorkflowExecutionScope: {operation: "SignalWithStartWorkflowExecution"},
 		HistoryRemoveSignalMutableStateScope:         {operation: "RemoveSignalMutableState"},
 		HistoryTerminateWorkflowExecutionScope:       {operation: "TerminateWorkflowExecution"},
@@ -645,7 +715,10 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		HistoryRecordChildExecutionCompletedScope:    {operation: "RecordChildExecutionCompleted"},
 		HistoryRequestCancelWorkflowExecutionScope:   {operation: "RequestCancelWorkflowExecution"},
 		HistoryReplicateEventsScope:                  {operation: "ReplicateEvents"},
+		HistoryImportWorkflowExecutionScope:          {operation: "ImportWorkflowExecution"},
 		HistorySyncShardStatusScope:                  {operation: "SyncShardStatus"},
+		HistoryGetReplicationMessagesScope:           {operation: "GetReplicationMessages"},
+		HistoryDescribeReplicationStatusScope:        {operation: "DescribeReplicationStatus"},
 		HistoryShardControllerScope:                  {operation: "ShardController"},
 		TransferQueueProcessorScope:                  {operation: "TransferQueueProcessor"},
 		TransferActiveQueueProcessorScope:            {operation: "TransferActiveQueueProcessor"},
@@ -682,6 +755,7 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		HistoryEventNotificationScope:                {operation: "HistoryEventNotification"},
 		ReplicatorQueueProcessorScope:                {operation: "ReplicatorQueueProcessor"},
 		ReplicatorTaskHistoryScope:                   {operation: "ReplicatorTaskHistory"},
+		ReplicationTaskPollerScope:                   {operation: "ReplicationTaskPoller"},
 		ReplicateHistoryEventsScope:                  {operation: "ReplicateHistoryEvents"},
 		ShardInfoScope:                               {operation: "ShardInfo"},
 		WorkflowContextScope:                         {operation: "WorkflowContext"},
@@ -704,6 +778,7 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		MatchingRespondQueryTaskCompletedScope: {operation: "RespondQueryTaskCompleted"},
 		MatchingCancelOutstandingPollScope:     {operation: "CancelOutstandingPoll"},
 		MatchingDescribeTaskListScope:          {operation: "DescribeTaskList"},
+		MatchingListTaskListsScope:             {operation: "ListTaskLists"},
 	},
 	// Worker Scope Names
 	Worker: {
@@ -711,6 +786,8 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		DomainReplicationTaskScope:  {operation: "DomainReplicationTask"},
 		HistoryReplicationTaskScope: {operation: "HistoryReplicationTask"},
 		SyncShardTaskScope:          {operation: "SyncShardTask"},
+		TaskListScavengerScope:      {operation: "TaskListScavenger"},
+		DomainReconcilerScope:       {operation: "DomainReconciler"},
 	},
 }
 
@@ -729,6 +806,7 @@ const (
 	CadenceErrCancellationAlreadyRequestedCounter
 	CadenceErrQueryFailedCounter
 	CadenceErrLimitExceededCounter
+	CadenceErrAccessDeniedCounter
 	CadenceErrContextTimeoutCounter
 	CadenceErrRetryTaskCounter
 	PersistenceRequests
@@ -785,6 +863,9 @@ const (
 	DecisionTypeChildWorkflowCounter
 	DecisionTypeContinueAsNewCounter
 	DecisionTypeSignalExternalWorkflowCounter
+	DecisionTypeRespondWorkflowUpdateCounter
+	WorkflowUpdateAcceptedCounter
+	WorkflowUpdateRejectedCounter
 	MultipleCompletionDecisionsCounter
 	FailedDecisionsCounter
 	StaleMutableStateCounter
@@ -793,6 +874,7 @@ const (
 	CadenceErrShardOwnershipLostCounter
 	HeartbeatTimeoutCounter
 	ScheduleToStartTimeoutCounter
+	ActivityRedirectedCounter
 	StartToCloseTimeoutCounter
 	ScheduleToCloseTimeoutCounter
 	NewTimerCounter
@@ -830,6 +912,10 @@ const (
 	ReplicationEventsSizeTimer
 	BufferReplicationTaskTimer
 	UnbufferReplicationTaskTimer
+	ReplicationTaskLagTimer
+	ReplicationTasksLagTimer
+	ReplicationTaskDLQCounter
+	ReplicationTaskBlockedCounter
 	HistoryConflictsCounter
 	CompleteTaskFailedCounter
 	HistoryCacheRequests
@@ -875,15 +961,49 @@ const (
 	SyncThrottleCounter
 	BufferThrottleCounter
 	SyncMatchLatency
+	FairDispatchBufferedTasksGauge
+	FairDispatchActiveKeysGauge
+	FairDispatchReorderedCounter
+	FairDispatchBufferLatency
+	HandoffReleaseCounter
+	HandoffReleaseFailureCounter
+	HandoffTimeoutCounter
+	HandoffWaitLatency
 
 	NumMatchingMetrics
 )
 
+// Frontend metrics enum
+const (
+	HostThrottledCounter = iota + NumCommonMetrics
+	DomainPollThrottledCounter
+	DomainStartThrottledCounter
+	DomainSignalThrottledCounter
+	DomainVisibilityThrottledCounter
+	DomainAPIThrottledCounter
+	ForwardedRequests
+	ForwardedRequestFailures
+	ForwardedRequestLatency
+
+	NumFrontendMetrics
+)
+
 // Worker metrics enum
 const (
 	ReplicatorMessages = iota + NumCommonMetrics
 	ReplicatorFailures
 	ReplicatorLatency
+	ReplicatorDLQMessages
+	TaskListScavengerPassLatency
+	TaskListScavengerProcessedCount
+	TaskListScavengerTasksDeletedCount
+	TaskListScavengerTaskListsDeletedCount
+	TaskListScavengerFailures
+	DomainReconcilerPassLatency
+	DomainReconcilerComparedCount
+	DomainReconcilerMismatchCount
+	DomainReconcilerRepairedCount
+	DomainReconcilerFailures
 
 	NumWorkerMetrics
 )
@@ -904,6 +1024,7 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		CadenceErrCancellationAlreadyRequestedCounter:       {metricName: "cadence.errors.cancellation-already-requested", metricType: Counter},
 		CadenceErrQueryFailedCounter:                        {metricName: "cadence.errors.query-failed", metricType: Counter},
 		CadenceErrLimitExceededCounter:                      {metricName: "cadence.errors.limit-exceeded", metricType: Counter},
+		CadenceErrAccessDeniedCounter:                       {metricName: "cadence.errors.access-denied", metricType: Counter},
 		CadenceErrContextTimeoutCounter:                     {metricName: "cadence.errors.context-timeout", metricType: Counter},
 		CadenceErrRetryTaskCounter:                          {metricName: "cadence.errors.retry-task", metricType: Counter},
 		PersistenceRequests:                                 {metricName: "persistence.requests", metricType: Counter},
@@ -927,7 +1048,17 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		DomainCacheBeforeCallbackLatency:                    {metricName: "domain-cache.before-callbacks.latency", metricType: Timer},
 		DomainCacheAfterCallbackLatency:                     {metricName: "domain-cache.after-callbacks.latency", metricType: Timer},
 	},
-	Frontend: {},
+	Frontend: {
+		HostThrottledCounter:             {metricName: "host-throttled", metricType: Counter},
+		DomainPollThrottledCounter:       {metricName: "domain-throttled.poll", metricType: Counter},
+		DomainStartThrottledCounter:      {metricName: "domain-throttled.start", metricType: Counter},
+		DomainSignalThrottledCounter:     {metricName: "domain-throttled.signal", metricType: Counter},
+		DomainVisibilityThrottledCounter: {metricName: "domain-throttled.visibility", metricType: Counter},
+		DomainAPIThrottledCounter:        {metricName: "domain-throttled.api", metricType: Counter},
+		ForwardedRequests:                {metricName: "forwarded.requests", metricType: Counter},
+		ForwardedRequestFailures:         {metricName: "forwarded.errors", metricType: Counter},
+		ForwardedRequestLatency:          {metricName: "forwarded.latency", metricType: Timer},
+	},
 	History: {
 		TaskRequests:                                 {metricName: "task.requests", metricType: Counter},
 		TaskLatency:                                  {metricName: "task.latency", metricType: Timer},
@@ -954,6 +1085,9 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		DecisionTypeContinueAsNewCounter:             {metricName: "continue-as-new-decision", metricType: Counter},
 		DecisionTypeSignalExternalWorkflowCounter:    {metricName: "signal-external-workflow-decision", metricType: Counter},
 		DecisionTypeChildWorkflowCounter:             {metricName: "child-workflow-decision", metricType: Counter},
+		DecisionTypeRespondWorkflowUpdateCounter:     {metricName: "respond-workflow-update-decision", metricType: Counter},
+		WorkflowUpdateAcceptedCounter:                {metricName: "workflow-update-accepted", metricType: Counter},
+		WorkflowUpdateRejectedCounter:                {metricName: "workflow-update-rejected", metricType: Counter},
 		MultipleCompletionDecisionsCounter:           {metricName: "multiple-completion-decisions", metricType: Counter},
 		FailedDecisionsCounter:                       {metricName: "failed-decisions", metricType: Counter},
 		StaleMutableStateCounter:                     {metricName: "stale-mutable-state", metricType: Counter},
@@ -962,6 +1096,7 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		CadenceErrEventAlreadyStartedCounter:         {metricName: "cadence.errors.event-already-started", metricType: Counter},
 		HeartbeatTimeoutCounter:                      {metricName: "heartbeat-timeout", metricType: Counter},
 		ScheduleToStartTimeoutCounter:                {metricName: "schedule-to-start-timeout", metricType: Counter},
+		ActivityRedirectedCounter:                    {metricName: "activity-redirected", metricType: Counter},
 		StartToCloseTimeoutCounter:                   {metricName: "start-to-close-timeout", metricType: Counter},
 		ScheduleToCloseTimeoutCounter:                {metricName: "schedule-to-close-timeout", metricType: Counter},
 		NewTimerCounter:                              {metricName: "new-timer", metricType: Counter},
@@ -999,6 +1134,10 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		ReplicationEventsSizeTimer:                   {metricName: "replication-events-size", metricType: Timer},
 		BufferReplicationTaskTimer:                   {metricName: "buffer-replication-tasks", metricType: Timer},
 		UnbufferReplicationTaskTimer:                 {metricName: "unbuffer-replication-tasks", metricType: Timer},
+		ReplicationTaskLagTimer:                      {metricName: "replication-task-lag", metricType: Timer},
+		ReplicationTasksLagTimer:                     {metricName: "replication-tasks-lag", metricType: Timer},
+		ReplicationTaskDLQCounter:                    {metricName: "replication-task-dlq", metricType: Counter},
+		ReplicationTaskBlockedCounter:                {metricName: "replication-task-blocked", metricType: Counter},
 		HistoryConflictsCounter:                      {metricName: "history-conflicts", metricType: Counter},
 		CompleteTaskFailedCounter:                    {metricName: "complete-task-fail-count", metricType: Counter},
 		HistoryCacheRequests:                         {metricName: "history-cache.requests", metricType: Counter},
@@ -1030,21 +1169,40 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		DeleteRequestCancelInfoCount:                 {metricName: "delete-request-cancel-info", metricType: Timer},
 	},
 	Matching: {
-		PollSuccessCounter:            {metricName: "poll.success"},
-		PollTimeoutCounter:            {metricName: "poll.timeouts"},
-		PollSuccessWithSyncCounter:    {metricName: "poll.success.sync"},
-		LeaseRequestCounter:           {metricName: "lease.requests"},
-		LeaseFailureCounter:           {metricName: "lease.failures"},
-		ConditionFailedErrorCounter:   {metricName: "condition-failed-errors"},
-		RespondQueryTaskFailedCounter: {metricName: "respond-query-failed"},
-		SyncThrottleCounter:           {metricName: "sync.throttle.count"},
-		BufferThrottleCounter:         {metricName: "buffer.throttle.count"},
-		SyncMatchLatency:              {metricName: "syncmatch.latency", metricType: Timer},
+		PollSuccessCounter:             {metricName: "poll.success"},
+		PollTimeoutCounter:             {metricName: "poll.timeouts"},
+		PollSuccessWithSyncCounter:     {metricName: "poll.success.sync"},
+		LeaseRequestCounter:            {metricName: "lease.requests"},
+		LeaseFailureCounter:            {metricName: "lease.failures"},
+		ConditionFailedErrorCounter:    {metricName: "condition-failed-errors"},
+		RespondQueryTaskFailedCounter:  {metricName: "respond-query-failed"},
+		SyncThrottleCounter:            {metricName: "sync.throttle.count"},
+		BufferThrottleCounter:          {metricName: "buffer.throttle.count"},
+		SyncMatchLatency:               {metricName: "syncmatch.latency", metricType: Timer},
+		FairDispatchBufferedTasksGauge: {metricName: "fairdispatch.buffered-tasks", metricType: Gauge},
+		FairDispatchActiveKeysGauge:    {metricName: "fairdispatch.active-keys", metricType: Gauge},
+		FairDispatchReorderedCounter:   {metricName: "fairdispatch.reordered"},
+		FairDispatchBufferLatency:      {metricName: "fairdispatch.buffer-latency", metricType: Timer},
+		HandoffReleaseCounter:          {metricName: "handoff.releases"},
+		HandoffReleaseFailureCounter:   {metricName: "handoff.release-failures"},
+		HandoffTimeoutCounter:          {metricName: "handoff.timeouts"},
+		HandoffWaitLatency:             {metricName: "handoff.wait-latency", metricType: Timer},
 	},
 	Worker: {
-		ReplicatorMessages: {metricName: "replicator.messages"},
-		ReplicatorFailures: {metricName: "replicator.errors"},
-		ReplicatorLatency:  {metricName: "replicator.latency"},
+		ReplicatorMessages:                     {metricName: "replicator.messages"},
+		ReplicatorFailures:                     {metricName: "replicator.errors"},
+		ReplicatorLatency:                      {metricName: "replicator.latency"},
+		ReplicatorDLQMessages:                  {metricName: "replicator.dlq-messages"},
+		TaskListScavengerPassLatency:           {metricName: "tasklist-scavenger.pass-latency", metricType: Timer},
+		TaskListScavengerProcessedCount:        {metricName: "tasklist-scavenger.processed", metricType: Counter},
+		TaskListScavengerTasksDeletedCount:     {metricName: "tasklist-scavenger.tasks-deleted", metricType: Counter},
+		TaskListScavengerTaskListsDeletedCount: {metricName: "tasklist-scavenger.tasklists-deleted", metricType: Counter},
+		TaskListScavengerFailures:              {metricName: "tasklist-scavenger.errors", metricType: Counter},
+		DomainReconcilerPassLatency:            {metricName: "domain-reconciler.pass-latency", metricType: Timer},
+		DomainReconcilerComparedCount:          {metricName: "domain-reconciler.compared", metricType: Counter},
+		DomainReconcilerMismatchCount:          {metricName: "domain-reconciler.mismatches", metricType: Counter},
+		DomainReconcilerRepairedCount:          {metricName: "domain-reconciler.repaired", metricType: Counter},
+		DomainReconcilerFailures:               {metricName: "domain-reconciler.errors", metricType: Counter},
 	},
 }
 
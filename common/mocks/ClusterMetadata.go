@@ -20,7 +20,12 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	mock "github.com/stretchr/testify/mock"
+	"github.com/uber/cadence/common/cluster"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/service/config"
+)
 
 // ClusterMetadata is an autogenerated mock type for the Metadata type
 type ClusterMetadata struct {
@@ -28,7 +33,7 @@ type ClusterMetadata struct {
 }
 
 // ClusterNameForFailoverVersion provides a mock function with given fields:
-func (_m *ClusterMetadata) ClusterNameForFailoverVersion(failoverVersion int64) string {
+func (_m *ClusterMetadata) ClusterNameForFailoverVersion(failoverVersion int64) (string, error) {
 	ret := _m.Called(failoverVersion)
 
 	var r0 string
@@ -38,6 +43,29 @@ func (_m *ClusterMetadata) ClusterNameForFailoverVersion(failoverVersion int64)
 		r0 = ret.Get(0).(string)
 	}
 
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(failoverVersion)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAllClientAddress provides a mock function with given fields:
+func (_m *ClusterMetadata) GetAllClientAddress() map[string]config.Address {
+	ret := _m.Called()
+
+	var r0 map[string]config.Address
+	if rf, ok := ret.Get(0).(func() map[string]config.Address); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]config.Address)
+		}
+	}
+
 	return r0
 }
 
@@ -140,3 +168,55 @@ func (_m *ClusterMetadata) IsMasterCluster() bool {
 
 	return r0
 }
+
+// IsClusterEnabled provides a mock function with given fields: clusterName
+func (_m *ClusterMetadata) IsClusterEnabled(clusterName string) bool {
+	ret := _m.Called(clusterName)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(clusterName)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// GetReplicationTopic provides a mock function with given fields: clusterName
+func (_m *ClusterMetadata) GetReplicationTopic(clusterName string) string {
+	ret := _m.Called(clusterName)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(clusterName)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// ValidateClusterRegistration provides a mock function with given fields: cluster
+func (_m *ClusterMetadata) ValidateClusterRegistration(cluster *persistence.ClusterInfo) error {
+	ret := _m.Called(cluster)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*persistence.ClusterInfo) error); ok {
+		r0 = rf(cluster)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RegisterClusterChangeCallback provides a mock function with given fields: shard, callback
+func (_m *ClusterMetadata) RegisterClusterChangeCallback(shard int, callback cluster.CallbackFn) {
+	_m.Called(shard, callback)
+}
+
+// UnregisterClusterChangeCallback provides a mock function with given fields: shard
+func (_m *ClusterMetadata) UnregisterClusterChangeCallback(shard int) {
+	_m.Called(shard)
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package mocks
+
+import (
+	mock "github.com/stretchr/testify/mock"
+	"github.com/uber/cadence/.gen/go/replicator"
+	"github.com/uber/cadence/common/messaging"
+)
+
+// DLQ is an autogenerated mock type for the DLQ type
+type DLQ struct {
+	mock.Mock
+}
+
+// Close provides a mock function with given fields:
+func (_m *DLQ) Close() error {
+	ret := _m.Called()
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Publish provides a mock function with given fields: msg
+func (_m *DLQ) Publish(msg *replicator.DLQReplicationTask) error {
+	ret := _m.Called(msg)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*replicator.DLQReplicationTask) error); ok {
+		r0 = rf(msg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Purge provides a mock function with given fields: partition, inclusiveEndOffset
+func (_m *DLQ) Purge(partition int32, inclusiveEndOffset int64) error {
+	ret := _m.Called(partition, inclusiveEndOffset)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int32, int64) error); ok {
+		r0 = rf(partition, inclusiveEndOffset)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReadMessage provides a mock function with given fields: id
+func (_m *DLQ) ReadMessage(id *replicator.DLQMessageID) (*replicator.DLQMessage, error) {
+	ret := _m.Called(id)
+
+	var r0 *replicator.DLQMessage
+	if rf, ok := ret.Get(0).(func(*replicator.DLQMessageID) *replicator.DLQMessage); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*replicator.DLQMessage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*replicator.DLQMessageID) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReadMessages provides a mock function with given fields: pageToken, maxCount
+func (_m *DLQ) ReadMessages(pageToken []byte, maxCount int) ([]*replicator.DLQMessage, []byte, error) {
+	ret := _m.Called(pageToken, maxCount)
+
+	var r0 []*replicator.DLQMessage
+	if rf, ok := ret.Get(0).(func([]byte, int) []*replicator.DLQMessage); ok {
+		r0 = rf(pageToken, maxCount)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*replicator.DLQMessage)
+		}
+	}
+
+	var r1 []byte
+	if rf, ok := ret.Get(1).(func([]byte, int) []byte); ok {
+		r1 = rf(pageToken, maxCount)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]byte)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func([]byte, int) error); ok {
+		r2 = rf(pageToken, maxCount)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+var _ messaging.DLQ = (*DLQ)(nil)
@@ -28,6 +28,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/uber/cadence/.gen/go/history"
 	"github.com/uber/cadence/.gen/go/history/historyserviceclient"
+	"github.com/uber/cadence/.gen/go/replicator"
 	"github.com/uber/cadence/.gen/go/shared"
 )
 
@@ -315,6 +316,31 @@ func (_m *HistoryClient) SignalWorkflowExecution(ctx context.Context, signalRequ
 	return r0
 }
 
+// UpdateWorkflowExecution provides a mock function with given fields: ctx, updateRequest
+func (_m *HistoryClient) UpdateWorkflowExecution(ctx context.Context,
+	updateRequest *history.UpdateWorkflowExecutionRequest,
+	opts ...yarpc.CallOption) (*shared.UpdateWorkflowExecutionResponse, error) {
+	ret := _m.Called(ctx, updateRequest)
+
+	var r0 *shared.UpdateWorkflowExecutionResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *history.UpdateWorkflowExecutionRequest) *shared.UpdateWorkflowExecutionResponse); ok {
+		r0 = rf(ctx, updateRequest)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shared.UpdateWorkflowExecutionResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *history.UpdateWorkflowExecutionRequest) error); ok {
+		r1 = rf(ctx, updateRequest)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SignalWithStartWorkflowExecution provides a mock function with given fields: ctx, signalWithStartRequest
 func (_m *HistoryClient) SignalWithStartWorkflowExecution(ctx context.Context,
 	signalWithStartRequest *history.SignalWithStartWorkflowExecutionRequest,
@@ -447,6 +473,20 @@ func (_m *HistoryClient) ReplicateEvents(ctx context.Context, request *history.R
 	return r0
 }
 
+// ImportWorkflowExecution provides a mock function with given fields: ctx, request
+func (_m *HistoryClient) ImportWorkflowExecution(ctx context.Context, request *history.ImportWorkflowExecutionRequest, opts ...yarpc.CallOption) error {
+	ret := _m.Called(ctx, request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *history.ImportWorkflowExecutionRequest) error); ok {
+		r0 = rf(ctx, request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SyncShardStatus provides a mock function with given fields: ctx, request
 func (_m *HistoryClient) SyncShardStatus(ctx context.Context, request *history.SyncShardStatusRequest, opts ...yarpc.CallOption) error {
 	ret := _m.Called(ctx, request)
@@ -460,3 +500,49 @@ func (_m *HistoryClient) SyncShardStatus(ctx context.Context, request *history.S
 
 	return r0
 }
+
+// GetReplicationMessages provides a mock function with given fields: ctx, request
+func (_m *HistoryClient) GetReplicationMessages(ctx context.Context, request *replicator.GetReplicationMessagesRequest, opts ...yarpc.CallOption) (*replicator.GetReplicationMessagesResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *replicator.GetReplicationMessagesResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *replicator.GetReplicationMessagesRequest) *replicator.GetReplicationMessagesResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*replicator.GetReplicationMessagesResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *replicator.GetReplicationMessagesRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DescribeReplicationStatus provides a mock function with given fields: ctx, request
+func (_m *HistoryClient) DescribeReplicationStatus(ctx context.Context, request *replicator.DescribeReplicationStatusRequest, opts ...yarpc.CallOption) (*replicator.DescribeReplicationStatusResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *replicator.DescribeReplicationStatusResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *replicator.DescribeReplicationStatusRequest) *replicator.DescribeReplicationStatusResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*replicator.DescribeReplicationStatusResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *replicator.DescribeReplicationStatusRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
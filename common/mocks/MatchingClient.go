@@ -188,3 +188,27 @@ func (_m *MatchingClient) DescribeTaskList(ctx context.Context,
 
 	return r0, r1
 }
+
+// ListTaskLists provides a mock function with given fields: ctx, request
+func (_m *MatchingClient) ListTaskLists(ctx context.Context,
+	request *matching.ListTaskListsRequest, opts ...yarpc.CallOption) (*shared.ListTaskListsResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *shared.ListTaskListsResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *matching.ListTaskListsRequest) *shared.ListTaskListsResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shared.ListTaskListsResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *matching.ListTaskListsRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -21,6 +21,9 @@
 package mocks
 
 import (
+	"errors"
+
+	"github.com/uber/cadence/.gen/go/replicator"
 	"github.com/uber/cadence/common/messaging"
 )
 
@@ -32,6 +35,11 @@ type (
 	}
 )
 
+var (
+	errDLQNotSupported             = errors.New("DLQ is not supported by the mock messaging client")
+	errConsumerOffsetsNotSupported = errors.New("consumer offsets are not supported by the mock messaging client")
+)
+
 var _ messaging.Client = (*MessagingClient)(nil)
 
 // NewMockMessagingClient generate a dummy implementation of messaging client
@@ -51,3 +59,13 @@ func (c *MessagingClient) NewConsumer(currentCluster, sourceCluster, consumerNam
 func (c *MessagingClient) NewProducer(sourceCluster string) (messaging.Producer, error) {
 	return c.publisherMock, nil
 }
+
+// NewDLQ returns an error as DLQ is not supported by the mock messaging client
+func (c *MessagingClient) NewDLQ(currentCluster string) (messaging.DLQ, error) {
+	return nil, errDLQNotSupported
+}
+
+// GetConsumerOffsets returns an error as consumer offsets are not supported by the mock messaging client
+func (c *MessagingClient) GetConsumerOffsets(sourceCluster, consumerName string) ([]*replicator.ConsumerPartitionOffset, error) {
+	return nil, errConsumerOffsetsNotSupported
+}
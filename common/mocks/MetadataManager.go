@@ -166,3 +166,40 @@ func (_m *MetadataManager) GetMetadata() (*persistence.GetMetadataResponse, erro
 
 	return r0, r1
 }
+
+// ListClusters provides a mock function with given fields:
+func (_m *MetadataManager) ListClusters() (*persistence.ListClustersResponse, error) {
+	ret := _m.Called()
+
+	var r0 *persistence.ListClustersResponse
+	if rf, ok := ret.Get(0).(func() *persistence.ListClustersResponse); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*persistence.ListClustersResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpsertCluster provides a mock function with given fields: request
+func (_m *MetadataManager) UpsertCluster(request *persistence.UpsertClusterRequest) error {
+	ret := _m.Called(request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*persistence.UpsertClusterRequest) error); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
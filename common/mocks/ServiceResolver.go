@@ -51,6 +51,20 @@ func (_m *ServiceResolver) Lookup(key string) (*membership.HostInfo, error) {
 	return r0, r1
 }
 
+// MemberCount is am mock implementation
+func (_m *ServiceResolver) MemberCount() int {
+	ret := _m.Called()
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	return r0
+}
+
 // AddListener is am mock implementation
 func (_m *ServiceResolver) AddListener(name string, notifyChannel chan<- *membership.ChangedEvent) error {
 	ret := _m.Called(name, notifyChannel)
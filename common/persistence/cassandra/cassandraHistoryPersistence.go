@@ -47,6 +47,15 @@ const (
 		`AND first_event_id >= ? ` +
 		`AND first_event_id < ?`
 
+	templateGetWorkflowExecutionHistoryReverse = `SELECT first_event_id, event_batch_version, data, data_encoding FROM events ` +
+		`WHERE domain_id = ? ` +
+		`AND workflow_id = ? ` +
+		`AND run_id = ? ` +
+		`AND first_event_id >= ? ` +
+		`AND first_event_id < ? ` +
+		`ORDER BY first_event_id DESC ` +
+		`LIMIT ?`
+
 	templateDeleteWorkflowExecutionHistory = `DELETE FROM events ` +
 		`WHERE domain_id = ? ` +
 		`AND workflow_id = ? ` +
@@ -144,6 +153,10 @@ func (h *cassandraHistoryPersistence) AppendHistoryEvents(request *p.InternalApp
 
 func (h *cassandraHistoryPersistence) GetWorkflowExecutionHistory(request *p.InternalGetWorkflowExecutionHistoryRequest) (
 	*p.InternalGetWorkflowExecutionHistoryResponse, error) {
+	if request.Reverse {
+		return h.getWorkflowExecutionHistoryReverse(request)
+	}
+
 	execution := request.Execution
 	query := h.session.Query(templateGetWorkflowExecutionHistory,
 		request.DomainID,
@@ -170,6 +183,7 @@ func (h *cassandraHistoryPersistence) GetWorkflowExecutionHistory(request *p.Int
 
 	eventBatch := &p.DataBlob{}
 	history := make([]*p.DataBlob, 0)
+	eventBatchVersions := make([]int64, 0)
 
 	for iter.Scan(nil, &eventBatchVersionPointer, &eventBatch.Data, &eventBatch.Encoding) {
 		found = true
@@ -179,6 +193,7 @@ func (h *cassandraHistoryPersistence) GetWorkflowExecutionHistory(request *p.Int
 		}
 		if eventBatchVersion >= lastEventBatchVersion {
 			history = append(history, eventBatch)
+			eventBatchVersions = append(eventBatchVersions, eventBatchVersion)
 			lastEventBatchVersion = eventBatchVersion
 		}
 
@@ -206,11 +221,67 @@ func (h *cassandraHistoryPersistence) GetWorkflowExecutionHistory(request *p.Int
 		NextPageToken:         nextPageToken,
 		History:               history,
 		LastEventBatchVersion: lastEventBatchVersion,
+		EventBatchVersions:    eventBatchVersions,
 	}
 
 	return response, nil
 }
 
+func (h *cassandraHistoryPersistence) getWorkflowExecutionHistoryReverse(
+	request *p.InternalGetWorkflowExecutionHistoryRequest) (*p.InternalGetWorkflowExecutionHistoryResponse, error) {
+	execution := request.Execution
+	query := h.session.Query(templateGetWorkflowExecutionHistoryReverse,
+		request.DomainID,
+		*execution.WorkflowId,
+		*execution.RunId,
+		request.FirstEventID,
+		request.NextEventID,
+		request.PageSize)
+
+	iter := query.Iter()
+	if iter == nil {
+		return nil, &workflow.InternalServiceError{
+			Message: "GetWorkflowExecutionHistory operation failed.  Not able to create query iterator.",
+		}
+	}
+
+	eventBatchVersionPointer := new(int64)
+	eventBatch := &p.DataBlob{}
+	history := make([]*p.DataBlob, 0)
+	eventBatchVersions := make([]int64, 0)
+
+	for iter.Scan(nil, &eventBatchVersionPointer, &eventBatch.Data, &eventBatch.Encoding) {
+		eventBatchVersion := common.EmptyVersion
+		if eventBatchVersionPointer != nil {
+			eventBatchVersion = *eventBatchVersionPointer
+		}
+		history = append(history, eventBatch)
+		eventBatchVersions = append(eventBatchVersions, eventBatchVersion)
+
+		eventBatchVersionPointer = new(int64)
+		eventBatch = &p.DataBlob{}
+	}
+
+	if err := iter.Close(); err != nil {
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("GetWorkflowExecutionHistory operation failed. Error: %v", err),
+		}
+	}
+
+	if len(history) == 0 {
+		return nil, &workflow.EntityNotExistsError{
+			Message: fmt.Sprintf("Workflow execution history not found.  WorkflowId: %v, RunId: %v",
+				*execution.WorkflowId, *execution.RunId),
+		}
+	}
+
+	return &p.InternalGetWorkflowExecutionHistoryResponse{
+		History:               history,
+		LastEventBatchVersion: request.LastEventBatchVersion,
+		EventBatchVersions:    eventBatchVersions,
+	}, nil
+}
+
 func (h *cassandraHistoryPersistence) DeleteWorkflowExecutionHistory(
 	request *p.DeleteWorkflowExecutionHistoryRequest) error {
 	execution := request.Execution
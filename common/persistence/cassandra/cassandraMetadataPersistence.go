@@ -349,6 +349,14 @@ func (m *cassandraMetadataPersistence) GetMetadata() (*p.GetMetadataResponse, er
 	panic("cassandraMetadataPersistence do not support get metadata operation.")
 }
 
+func (m *cassandraMetadataPersistence) ListClusters() (*p.ListClustersResponse, error) {
+	panic("cassandraMetadataPersistence do not support list clusters operation.")
+}
+
+func (m *cassandraMetadataPersistence) UpsertCluster(request *p.UpsertClusterRequest) error {
+	panic("cassandraMetadataPersistence do not support upsert cluster operation.")
+}
+
 func (m *cassandraMetadataPersistence) deleteDomain(name, ID string) error {
 	query := m.session.Query(templateDeleteDomainByNameQuery, name)
 	if err := query.Exec(); err != nil {
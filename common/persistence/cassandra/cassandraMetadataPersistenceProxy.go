@@ -80,6 +80,14 @@ func (m *metadataManagerProxy) GetMetadata() (*p.GetMetadataResponse, error) {
 	return m.metadataMgrV2.GetMetadata()
 }
 
+func (m *metadataManagerProxy) ListClusters() (*p.ListClustersResponse, error) {
+	return m.metadataMgrV2.ListClusters()
+}
+
+func (m *metadataManagerProxy) UpsertCluster(request *p.UpsertClusterRequest) error {
+	return m.metadataMgrV2.UpsertCluster(request)
+}
+
 func (m *metadataManagerProxy) Close() {
 	m.metadataMgr.Close()
 	m.metadataMgrV2.Close()
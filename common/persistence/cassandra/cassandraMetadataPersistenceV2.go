@@ -32,11 +32,12 @@ import (
 
 const constDomainPartition = 0
 const domainMetadataRecordName = "cadence-domain-metadata"
+const constClusterPartition = 0
 
 const (
 	templateCreateDomainByNameQueryWithinBatchV2 = `INSERT INTO domains_by_name_v2 (` +
-		`domains_partition, name, domain, config, replication_config, is_global_domain, config_version, failover_version, failover_notification_version, notification_version) ` +
-		`VALUES(?, ?, ` + templateDomainInfoType + `, ` + templateDomainConfigType + `, ` + templateDomainReplicationConfigType + `, ?, ?, ?, ?, ?) IF NOT EXISTS`
+		`domains_partition, name, domain, config, replication_config, is_global_domain, config_version, failover_version, failover_notification_version, notification_version, failover_history) ` +
+		`VALUES(?, ?, ` + templateDomainInfoType + `, ` + templateDomainConfigType + `, ` + templateDomainReplicationConfigType + `, ?, ?, ?, ?, ?, ?) IF NOT EXISTS`
 
 	templateGetDomainByNameQueryV2 = `SELECT domain.id, domain.name, domain.status, domain.description, ` +
 		`domain.owner_email, domain.data, config.retention, config.emit_metric, ` +
@@ -45,7 +46,10 @@ const (
 		`config_version, ` +
 		`failover_version, ` +
 		`failover_notification_version, ` +
-		`notification_version ` +
+		`notification_version, ` +
+		`pending_active_cluster_name, ` +
+		`failover_end_time, ` +
+		`failover_history ` +
 		`FROM domains_by_name_v2 ` +
 		`WHERE domains_partition = ? ` +
 		`and name = ?`
@@ -57,7 +61,10 @@ const (
 		`config_version = ? ,` +
 		`failover_version = ? ,` +
 		`failover_notification_version = ? , ` +
-		`notification_version = ? ` +
+		`notification_version = ? , ` +
+		`pending_active_cluster_name = ? , ` +
+		`failover_end_time = ? , ` +
+		`failover_history = ? ` +
 		`WHERE domains_partition = ? ` +
 		`and name = ?`
 
@@ -83,9 +90,20 @@ const (
 		`config_version, ` +
 		`failover_version, ` +
 		`failover_notification_version, ` +
-		`notification_version ` +
+		`notification_version, ` +
+		`pending_active_cluster_name, ` +
+		`failover_end_time, ` +
+		`failover_history ` +
 		`FROM domains_by_name_v2 ` +
 		`WHERE domains_partition = ? `
+
+	templateListClustersQuery = `SELECT cluster_name, initial_failover_version, enabled, rpc_name, rpc_address, replication_topic ` +
+		`FROM cluster_metadata ` +
+		`WHERE cluster_partition = ?`
+
+	templateUpsertClusterQuery = `INSERT INTO cluster_metadata (` +
+		`cluster_partition, cluster_name, initial_failover_version, enabled, rpc_name, rpc_address, replication_topic) ` +
+		`VALUES(?, ?, ?, ?, ?, ?, ?)`
 )
 
 type (
@@ -167,6 +185,7 @@ func (m *cassandraMetadataPersistenceV2) CreateDomain(request *p.CreateDomainReq
 		request.FailoverVersion,
 		p.InitialFailoverNotificationVersion,
 		metadata.NotificationVersion,
+		p.SerializeDomainFailoverHistory(request.FailoverHistory),
 	)
 	m.updateMetadataBatch(batch, metadata.NotificationVersion)
 
@@ -222,6 +241,9 @@ func (m *cassandraMetadataPersistenceV2) UpdateDomain(request *p.UpdateDomainReq
 		request.FailoverVersion,
 		request.FailoverNotificationVersion,
 		request.NotificationVersion,
+		request.PendingActiveClusterName,
+		request.FailoverEndTime,
+		p.SerializeDomainFailoverHistory(request.FailoverHistory),
 		constDomainPartition,
 		request.Info.Name,
 	)
@@ -261,6 +283,9 @@ func (m *cassandraMetadataPersistenceV2) GetDomain(request *p.GetDomainRequest)
 	var failoverVersion int64
 	var configVersion int64
 	var isGlobalDomain bool
+	var pendingActiveClusterName string
+	var failoverEndTime int64
+	var failoverHistory []map[string]interface{}
 
 	if len(request.ID) > 0 && len(request.Name) > 0 {
 		return nil, &workflow.BadRequestError{
@@ -313,6 +338,9 @@ func (m *cassandraMetadataPersistenceV2) GetDomain(request *p.GetDomainRequest)
 		&failoverVersion,
 		&failoverNotificationVersion,
 		&notificationVersion,
+		&pendingActiveClusterName,
+		&failoverEndTime,
+		&failoverHistory,
 	)
 
 	if err != nil {
@@ -332,6 +360,9 @@ func (m *cassandraMetadataPersistenceV2) GetDomain(request *p.GetDomainRequest)
 		FailoverVersion:             failoverVersion,
 		FailoverNotificationVersion: failoverNotificationVersion,
 		NotificationVersion:         notificationVersion,
+		PendingActiveClusterName:    pendingActiveClusterName,
+		FailoverEndTime:             failoverEndTime,
+		FailoverHistory:             p.DeserializeDomainFailoverHistory(failoverHistory),
 	}, nil
 }
 
@@ -353,6 +384,7 @@ func (m *cassandraMetadataPersistenceV2) ListDomains(request *p.ListDomainsReque
 		ReplicationConfig: &p.DomainReplicationConfig{},
 	}
 	var replicationClusters []map[string]interface{}
+	var failoverHistory []map[string]interface{}
 	response := &p.ListDomainsResponse{}
 	for iter.Scan(
 		&name,
@@ -361,12 +393,14 @@ func (m *cassandraMetadataPersistenceV2) ListDomains(request *p.ListDomainsReque
 		&domain.ReplicationConfig.ActiveClusterName, &replicationClusters,
 		&domain.IsGlobalDomain, &domain.ConfigVersion, &domain.FailoverVersion,
 		&domain.FailoverNotificationVersion, &domain.NotificationVersion,
+		&domain.PendingActiveClusterName, &domain.FailoverEndTime, &failoverHistory,
 	) {
 		if name != domainMetadataRecordName {
 			// do not inlcude the metadata record
 			domain.ReplicationConfig.ActiveClusterName = p.GetOrUseDefaultActiveCluster(m.currentClusterName, domain.ReplicationConfig.ActiveClusterName)
 			domain.ReplicationConfig.Clusters = p.DeserializeClusterConfigs(replicationClusters)
 			domain.ReplicationConfig.Clusters = p.GetOrUseDefaultClusters(m.currentClusterName, domain.ReplicationConfig.Clusters)
+			domain.FailoverHistory = p.DeserializeDomainFailoverHistory(failoverHistory)
 			response.Domains = append(response.Domains, domain)
 		}
 		domain = &p.GetDomainResponse{
@@ -405,7 +439,7 @@ func (m *cassandraMetadataPersistenceV2) DeleteDomain(request *p.DeleteDomainReq
 func (m *cassandraMetadataPersistenceV2) DeleteDomainByName(request *p.DeleteDomainByNameRequest) error {
 	var ID string
 	query := m.session.Query(templateGetDomainByNameQueryV2, constDomainPartition, request.Name)
-	err := query.Scan(&ID, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	err := query.Scan(&ID, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 	if err != nil {
 		if err == gocql.ErrNotFound {
 			return nil
@@ -462,3 +496,47 @@ func (m *cassandraMetadataPersistenceV2) deleteDomain(name, ID string) error {
 
 	return nil
 }
+
+func (m *cassandraMetadataPersistenceV2) ListClusters() (*p.ListClustersResponse, error) {
+	iter := m.session.Query(templateListClustersQuery, constClusterPartition).Iter()
+	if iter == nil {
+		return nil, &workflow.InternalServiceError{
+			Message: "ListClusters operation failed.  Not able to create query iterator.",
+		}
+	}
+
+	response := &p.ListClustersResponse{}
+	cluster := &p.ClusterInfo{}
+	for iter.Scan(
+		&cluster.ClusterName, &cluster.InitialFailoverVersion, &cluster.Enabled,
+		&cluster.RPCName, &cluster.RPCAddress, &cluster.ReplicationTopic,
+	) {
+		response.Clusters = append(response.Clusters, cluster)
+		cluster = &p.ClusterInfo{}
+	}
+	if err := iter.Close(); err != nil {
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("ListClusters operation failed. Error: %v", err),
+		}
+	}
+	return response, nil
+}
+
+func (m *cassandraMetadataPersistenceV2) UpsertCluster(request *p.UpsertClusterRequest) error {
+	cluster := request.Cluster
+	query := m.session.Query(templateUpsertClusterQuery,
+		constClusterPartition,
+		cluster.ClusterName,
+		cluster.InitialFailoverVersion,
+		cluster.Enabled,
+		cluster.RPCName,
+		cluster.RPCAddress,
+		cluster.ReplicationTopic,
+	)
+	if err := query.Exec(); err != nil {
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("UpsertCluster operation failed. Error: %v", err),
+		}
+	}
+	return nil
+}
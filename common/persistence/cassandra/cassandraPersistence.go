@@ -107,6 +107,7 @@ const (
 		`timer_ack_level: ?, ` +
 		`cluster_transfer_ack_level: ?, ` +
 		`cluster_timer_ack_level: ?, ` +
+		`cluster_replication_level: ?, ` +
 		`domain_notification_version: ? ` +
 		`}`
 
@@ -163,7 +164,8 @@ const (
 		`start_version: ?, ` +
 		`last_write_version: ?, ` +
 		`last_write_event_id: ?, ` +
-		`last_replication_info: ?` +
+		`last_replication_info: ?, ` +
+		`version_history: ?` +
 		`}`
 
 	templateTransferTaskType = `{` +
@@ -212,6 +214,7 @@ const (
 		`schedule_id: ?, ` +
 		`scheduled_event: ?, ` +
 		`scheduled_time: ?, ` +
+		`redirected_time: ?, ` +
 		`started_id: ?, ` +
 		`started_event: ?, ` +
 		`started_time: ?, ` +
@@ -298,14 +301,17 @@ const (
 		`name: ?, ` +
 		`type: ?, ` +
 		`ack_level: ?, ` +
-		`kind: ? ` +
+		`kind: ?, ` +
+		`last_updated: ?, ` +
+		`released: ? ` +
 		`}`
 
 	templateTaskType = `{` +
 		`domain_id: ?, ` +
 		`workflow_id: ?, ` +
 		`run_id: ?, ` +
-		`schedule_id: ?` +
+		`schedule_id: ?, ` +
+		`fairness_key: ?` +
 		`}`
 
 	templateCreateShardQuery = `INSERT INTO executions (` +
@@ -387,7 +393,7 @@ const (
 		`and task_id = ? ` +
 		`IF range_id = ?`
 
-	templateGetWorkflowExecutionQuery = `SELECT execution, replication_state, activity_map, timer_map, child_executions_map, request_cancel_map, signal_map, signal_requested, buffered_events_list, buffered_replication_tasks_map ` +
+	templateGetWorkflowExecutionQuery = `SELECT execution, replication_state, activity_map, timer_map, child_executions_map, request_cancel_map, signal_map, signal_requested, accepted_updates, buffered_events_list, buffered_replication_tasks_map ` +
 		`FROM executions ` +
 		`WHERE shard_id = ? ` +
 		`and type = ? ` +
@@ -550,6 +556,39 @@ const (
 		`and task_id = ? ` +
 		`IF next_event_id = ?`
 
+	templateUpdateAcceptedUpdatesQuery = `UPDATE executions ` +
+		`SET accepted_updates = accepted_updates + ? ` +
+		`WHERE shard_id = ? ` +
+		`and type = ? ` +
+		`and domain_id = ? ` +
+		`and workflow_id = ? ` +
+		`and run_id = ? ` +
+		`and visibility_ts = ? ` +
+		`and task_id = ? ` +
+		`IF next_event_id = ?`
+
+	templateDeleteAcceptedUpdatesQuery = `UPDATE executions ` +
+		`SET accepted_updates = accepted_updates - ? ` +
+		`WHERE shard_id = ? ` +
+		`and type = ? ` +
+		`and domain_id = ? ` +
+		`and workflow_id = ? ` +
+		`and run_id = ? ` +
+		`and visibility_ts = ? ` +
+		`and task_id = ? ` +
+		`IF next_event_id = ?`
+
+	templateResetAcceptedUpdatesQuery = `UPDATE executions ` +
+		`SET accepted_updates = ? ` +
+		`WHERE shard_id = ? ` +
+		`and type = ? ` +
+		`and domain_id = ? ` +
+		`and workflow_id = ? ` +
+		`and run_id = ? ` +
+		`and visibility_ts = ? ` +
+		`and task_id = ? ` +
+		`IF next_event_id = ?`
+
 	templateResetSignalRequestedQuery = `UPDATE executions ` +
 		`SET signal_requested = ?` +
 		`WHERE shard_id = ? ` +
@@ -691,6 +730,16 @@ const (
 		`and visibility_ts = ? ` +
 		`and task_id = ? `
 
+	templateDeleteCurrentWorkflowExecutionQuery = `DELETE FROM executions ` +
+		`WHERE shard_id = ? ` +
+		`and type = ? ` +
+		`and domain_id = ? ` +
+		`and workflow_id = ? ` +
+		`and run_id = ? ` +
+		`and visibility_ts = ? ` +
+		`and task_id = ? ` +
+		`IF current_run_id = ? `
+
 	templateDeleteWorkflowExecutionSignalRequestedQuery = `UPDATE executions ` +
 		`SET signal_requested = signal_requested - ? ` +
 		`WHERE shard_id = ? ` +
@@ -795,6 +844,13 @@ const (
 		`and type = ? ` +
 		`and task_id = ?`
 
+	templateCompleteTasksLessThanQuery = `DELETE FROM tasks ` +
+		`WHERE domain_id = ? ` +
+		`and task_list_name = ? ` +
+		`and task_list_type = ? ` +
+		`and type = ? ` +
+		`and task_id <= ?`
+
 	templateGetTaskList = `SELECT ` +
 		`range_id, ` +
 		`task_list ` +
@@ -834,6 +890,41 @@ const (
 		`range_id, ` +
 		`task_list ` +
 		`) VALUES (?, ?, ?, ?, ?, ?, ` + templateTaskListType + `) USING TTL ?`
+
+	templateListTaskListQuery = `SELECT ` +
+		`range_id, ` +
+		`task_list ` +
+		`FROM tasks ` +
+		`WHERE type = ? ` +
+		`and task_id = ? ` +
+		`ALLOW FILTERING`
+
+	templateInsertTaskListByDomainQuery = `INSERT INTO task_lists_by_domain (` +
+		`domain_id, ` +
+		`task_list_name, ` +
+		`task_list_type ` +
+		`) VALUES (?, ?, ?)`
+
+	templateInsertTaskListByDomainQueryWithTTL = templateInsertTaskListByDomainQuery + ` USING TTL ?`
+
+	templateListTaskListByDomainQuery = `SELECT ` +
+		`task_list_name, ` +
+		`task_list_type ` +
+		`FROM task_lists_by_domain ` +
+		`WHERE domain_id = ?`
+
+	templateDeleteTaskListByDomainQuery = `DELETE FROM task_lists_by_domain ` +
+		`WHERE domain_id = ? ` +
+		`and task_list_name = ? ` +
+		`and task_list_type = ?`
+
+	templateDeleteTaskListQuery = `DELETE FROM tasks ` +
+		`WHERE domain_id = ? ` +
+		`and task_list_name = ? ` +
+		`and task_list_type = ? ` +
+		`and type = ? ` +
+		`and task_id = ? ` +
+		`IF range_id = ?`
 )
 
 var (
@@ -920,6 +1011,7 @@ func (d *cassandraPersistence) CreateShard(request *p.CreateShardRequest) error
 		shardInfo.TimerAckLevel,
 		shardInfo.ClusterTransferAckLevel,
 		shardInfo.ClusterTimerAckLevel,
+		shardInfo.ClusterReplicationLevel,
 		shardInfo.DomainNotificationVersion,
 		shardInfo.RangeID)
 
@@ -995,6 +1087,7 @@ func (d *cassandraPersistence) UpdateShard(request *p.UpdateShardRequest) error
 		shardInfo.TimerAckLevel,
 		shardInfo.ClusterTransferAckLevel,
 		shardInfo.ClusterTimerAckLevel,
+		shardInfo.ClusterReplicationLevel,
 		shardInfo.DomainNotificationVersion,
 		shardInfo.RangeID,
 		shardInfo.ShardID,
@@ -1323,6 +1416,7 @@ func (d *cassandraPersistence) CreateWorkflowExecutionWithinBatch(request *p.Cre
 		for k, v := range request.ReplicationState.LastReplicationInfo {
 			lastReplicationInfo[k] = createReplicationInfoMap(v)
 		}
+		versionHistory := createVersionHistoryList(request.ReplicationState.VersionHistory)
 
 		batch.Query(templateCreateWorkflowExecutionWithReplicationQuery,
 			d.shardID,
@@ -1380,6 +1474,7 @@ func (d *cassandraPersistence) CreateWorkflowExecutionWithinBatch(request *p.Cre
 			request.ReplicationState.LastWriteVersion,
 			request.ReplicationState.LastWriteEventID,
 			lastReplicationInfo,
+			versionHistory,
 			request.NextEventID,
 			defaultVisibilityTimestamp,
 			rowTypeExecutionTaskID)
@@ -1470,6 +1565,12 @@ func (d *cassandraPersistence) GetWorkflowExecution(request *p.GetWorkflowExecut
 	}
 	state.SignalRequestedIDs = signalRequestedIDs
 
+	acceptedUpdates := make(map[string]int64)
+	for updateID, eventID := range result["accepted_updates"].(map[string]int64) {
+		acceptedUpdates[updateID] = eventID
+	}
+	state.AcceptedUpdates = acceptedUpdates
+
 	eList := result["buffered_events_list"].([]map[string]interface{})
 	bufferedEventsBlobs := make([]*p.DataBlob, 0, len(eList))
 	for _, v := range eList {
@@ -1557,6 +1658,7 @@ func (d *cassandraPersistence) UpdateWorkflowExecution(request *p.InternalUpdate
 		for k, v := range replicationState.LastReplicationInfo {
 			lastReplicationInfo[k] = createReplicationInfoMap(v)
 		}
+		versionHistory := createVersionHistoryList(replicationState.VersionHistory)
 
 		batch.Query(templateUpdateWorkflowExecutionWithReplicationQuery,
 			executionInfo.DomainID,
@@ -1609,6 +1711,7 @@ func (d *cassandraPersistence) UpdateWorkflowExecution(request *p.InternalUpdate
 			replicationState.LastWriteVersion,
 			replicationState.LastWriteEventID,
 			lastReplicationInfo,
+			versionHistory,
 			executionInfo.NextEventID,
 			d.shardID,
 			rowTypeExecution,
@@ -1653,6 +1756,9 @@ func (d *cassandraPersistence) UpdateWorkflowExecution(request *p.InternalUpdate
 	d.updateSignalsRequested(batch, request.UpsertSignalRequestedIDs, request.DeleteSignalRequestedID,
 		executionInfo.DomainID, executionInfo.WorkflowID, executionInfo.RunID, request.Condition, request.RangeID)
 
+	d.updateAcceptedUpdates(batch, request.UpsertAcceptedUpdates, request.DeleteAcceptedUpdates,
+		executionInfo.DomainID, executionInfo.WorkflowID, executionInfo.RunID, request.Condition)
+
 	d.updateBufferedEvents(batch, request.NewBufferedEvents, request.ClearBufferedEvents,
 		executionInfo.DomainID, executionInfo.WorkflowID, executionInfo.RunID, request.Condition, request.RangeID)
 
@@ -1774,6 +1880,7 @@ func (d *cassandraPersistence) ResetMutableState(request *p.InternalResetMutable
 	for k, v := range replicationState.LastReplicationInfo {
 		lastReplicationInfo[k] = createReplicationInfoMap(v)
 	}
+	versionHistory := createVersionHistoryList(replicationState.VersionHistory)
 
 	parentDomainID := emptyDomainID
 	if executionInfo.ParentDomainID != "" {
@@ -1855,6 +1962,7 @@ func (d *cassandraPersistence) ResetMutableState(request *p.InternalResetMutable
 		replicationState.LastWriteVersion,
 		replicationState.LastWriteEventID,
 		lastReplicationInfo,
+		versionHistory,
 		executionInfo.NextEventID,
 		d.shardID,
 		rowTypeExecution,
@@ -1883,6 +1991,9 @@ func (d *cassandraPersistence) ResetMutableState(request *p.InternalResetMutable
 	d.resetSignalRequested(batch, request.InsertSignalRequestedIDs, executionInfo.DomainID, executionInfo.WorkflowID,
 		executionInfo.RunID, request.Condition)
 
+	d.resetAcceptedUpdates(batch, request.InsertAcceptedUpdates, executionInfo.DomainID, executionInfo.WorkflowID,
+		executionInfo.RunID, request.Condition)
+
 	d.resetBufferedEvents(batch, executionInfo.DomainID, executionInfo.WorkflowID, executionInfo.RunID, request.Condition)
 
 	// Verifies that the RangeID has not changed
@@ -2038,6 +2149,34 @@ func (d *cassandraPersistence) DeleteWorkflowExecution(request *p.DeleteWorkflow
 	return nil
 }
 
+func (d *cassandraPersistence) DeleteCurrentWorkflowExecution(request *p.DeleteCurrentWorkflowExecutionRequest) error {
+	query := d.session.Query(templateDeleteCurrentWorkflowExecutionQuery,
+		d.shardID,
+		rowTypeExecution,
+		request.DomainID,
+		request.WorkflowID,
+		permanentRunID,
+		defaultVisibilityTimestamp,
+		rowTypeExecutionTaskID,
+		request.RunID)
+
+	// the record is left as is when it points to another run
+	previous := make(map[string]interface{})
+	_, err := query.MapScanCAS(previous)
+	if err != nil {
+		if isThrottlingError(err) {
+			return &workflow.ServiceBusyError{
+				Message: fmt.Sprintf("DeleteCurrentWorkflowExecution operation failed. Error: %v", err),
+			}
+		}
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("DeleteCurrentWorkflowExecution operation failed. Error: %v", err),
+		}
+	}
+
+	return nil
+}
+
 func (d *cassandraPersistence) GetCurrentExecution(request *p.GetCurrentExecutionRequest) (*p.GetCurrentExecutionResponse,
 	error) {
 	query := d.session.Query(templateGetCurrentExecutionQuery,
@@ -2309,6 +2448,7 @@ func (d *cassandraPersistence) LeaseTaskList(request *p.LeaseTaskListRequest) (*
 		rowTypeTaskList,
 		taskListTaskID,
 	)
+	now := time.Now()
 	var rangeID, ackLevel int64
 	var tlDB map[string]interface{}
 	err := query.Scan(&rangeID, &tlDB)
@@ -2326,6 +2466,8 @@ func (d *cassandraPersistence) LeaseTaskList(request *p.LeaseTaskListRequest) (*
 				request.TaskType,
 				0,
 				request.TaskListKind,
+				now,
+				false,
 			)
 		} else if isThrottlingError(err) {
 			return nil, &workflow.ServiceBusyError{
@@ -2348,6 +2490,8 @@ func (d *cassandraPersistence) LeaseTaskList(request *p.LeaseTaskListRequest) (*
 			request.TaskType,
 			ackLevel,
 			taskListKind,
+			now,
+			false,
 			request.DomainID,
 			&request.TaskList,
 			request.TaskType,
@@ -2356,6 +2500,13 @@ func (d *cassandraPersistence) LeaseTaskList(request *p.LeaseTaskListRequest) (*
 			rangeID,
 		)
 	}
+	// the lookup entry is written on every lease so task lists created before the lookup existed are
+	// indexed the next time they are loaded
+	if err := d.insertTaskListByDomain(request.DomainID, request.TaskList, request.TaskType,
+		request.TaskListKind); err != nil {
+		return nil, err
+	}
+
 	previous := make(map[string]interface{})
 	applied, err := query.MapScanCAS(previous)
 	if err != nil {
@@ -2374,7 +2525,15 @@ func (d *cassandraPersistence) LeaseTaskList(request *p.LeaseTaskListRequest) (*
 			Msg: fmt.Sprintf("LeaseTaskList failed to apply. db rangeID %v", previousRangeID),
 		}
 	}
-	tli := &p.TaskListInfo{DomainID: request.DomainID, Name: request.TaskList, TaskType: request.TaskType, RangeID: rangeID + 1, AckLevel: ackLevel, Kind: request.TaskListKind}
+	tli := &p.TaskListInfo{
+		DomainID:    request.DomainID,
+		Name:        request.TaskList,
+		TaskType:    request.TaskType,
+		RangeID:     rangeID + 1,
+		AckLevel:    ackLevel,
+		Kind:        request.TaskListKind,
+		LastUpdated: now,
+	}
 	return &p.LeaseTaskListResponse{TaskListInfo: tli}, nil
 }
 
@@ -2383,7 +2542,8 @@ func (d *cassandraPersistence) UpdateTaskList(request *p.UpdateTaskListRequest)
 	tli := request.TaskListInfo
 
 	if tli.Kind == p.TaskListKindSticky { // if task_list is sticky, then update with TTL
-		query := d.session.Query(templateUpdateTaskListQueryWithTTL,
+		batch := d.session.NewBatch(gocql.LoggedBatch)
+		batch.Query(templateUpdateTaskListQueryWithTTL,
 			tli.DomainID,
 			&tli.Name,
 			tli.TaskType,
@@ -2395,9 +2555,17 @@ func (d *cassandraPersistence) UpdateTaskList(request *p.UpdateTaskListRequest)
 			tli.TaskType,
 			tli.AckLevel,
 			tli.Kind,
+			time.Now(),
+			tli.Released,
+			stickyTaskListTTL,
+		)
+		batch.Query(templateInsertTaskListByDomainQueryWithTTL,
+			tli.DomainID,
+			tli.Name,
+			tli.TaskType,
 			stickyTaskListTTL,
 		)
-		err := query.Exec()
+		err := d.session.ExecuteBatch(batch)
 		if err != nil {
 			if isThrottlingError(err) {
 				return nil, &workflow.ServiceBusyError{
@@ -2418,6 +2586,8 @@ func (d *cassandraPersistence) UpdateTaskList(request *p.UpdateTaskListRequest)
 		tli.TaskType,
 		tli.AckLevel,
 		tli.Kind,
+		time.Now(),
+		tli.Released,
 		tli.DomainID,
 		&tli.Name,
 		tli.TaskType,
@@ -2454,6 +2624,218 @@ func (d *cassandraPersistence) UpdateTaskList(request *p.UpdateTaskListRequest)
 	return &p.UpdateTaskListResponse{}, nil
 }
 
+// From TaskManager interface
+func (d *cassandraPersistence) GetTaskList(request *p.GetTaskListRequest) (*p.GetTaskListResponse, error) {
+	query := d.session.Query(templateGetTaskList,
+		request.DomainID,
+		request.TaskList,
+		request.TaskType,
+		rowTypeTaskList,
+		taskListTaskID,
+	)
+	var rangeID int64
+	var tlDB map[string]interface{}
+	if err := query.Scan(&rangeID, &tlDB); err != nil {
+		if err == gocql.ErrNotFound {
+			return nil, &workflow.EntityNotExistsError{
+				Message: fmt.Sprintf("Task list not found. TaskList: %v, TaskType: %v", request.TaskList, request.TaskType),
+			}
+		} else if isThrottlingError(err) {
+			return nil, &workflow.ServiceBusyError{
+				Message: fmt.Sprintf("GetTaskList operation failed. TaskList: %v, TaskType: %v, Error: %v",
+					request.TaskList, request.TaskType, err),
+			}
+		}
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("GetTaskList operation failed. TaskList: %v, TaskType: %v, Error: %v",
+				request.TaskList, request.TaskType, err),
+		}
+	}
+
+	tli := createTaskListInfo(tlDB)
+	tli.RangeID = rangeID
+	return &p.GetTaskListResponse{TaskListInfo: tli}, nil
+}
+
+// From TaskManager interface
+func (d *cassandraPersistence) ListTaskList(request *p.ListTaskListRequest) (*p.ListTaskListResponse, error) {
+	if request.DomainID != "" {
+		return d.listTaskListByDomain(request)
+	}
+
+	query := d.session.Query(templateListTaskListQuery,
+		rowTypeTaskList,
+		taskListTaskID,
+	).PageSize(request.PageSize).PageState(request.PageToken)
+
+	iter := query.Iter()
+	if iter == nil {
+		return nil, &workflow.InternalServiceError{
+			Message: "ListTaskList operation failed.  Not able to create query iterator.",
+		}
+	}
+
+	response := &p.ListTaskListResponse{}
+	row := make(map[string]interface{})
+	for iter.MapScan(row) {
+		tli := createTaskListInfo(row["task_list"].(map[string]interface{}))
+		tli.RangeID = row["range_id"].(int64)
+		response.Items = append(response.Items, tli)
+		row = make(map[string]interface{}) // Reinitialize map as initialized fails on unmarshalling
+	}
+	nextPageToken := iter.PageState()
+	response.NextPageToken = make([]byte, len(nextPageToken))
+	copy(response.NextPageToken, nextPageToken)
+
+	if err := iter.Close(); err != nil {
+		if isThrottlingError(err) {
+			return nil, &workflow.ServiceBusyError{
+				Message: fmt.Sprintf("ListTaskList operation failed. Error: %v", err),
+			}
+		}
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("ListTaskList operation failed. Error: %v", err),
+		}
+	}
+
+	return response, nil
+}
+
+// listTaskListByDomain pages through the task_lists_by_domain lookup of a single partition and reads the
+// task list rows it points to, lookup entries whose task list row is gone are skipped
+func (d *cassandraPersistence) listTaskListByDomain(request *p.ListTaskListRequest) (*p.ListTaskListResponse, error) {
+	query := d.session.Query(templateListTaskListByDomainQuery,
+		request.DomainID,
+	).PageSize(request.PageSize).PageState(request.PageToken)
+
+	iter := query.Iter()
+	if iter == nil {
+		return nil, &workflow.InternalServiceError{
+			Message: "ListTaskList operation failed.  Not able to create query iterator.",
+		}
+	}
+
+	var names []string
+	var types []int
+	var name string
+	var taskType int
+	for iter.Scan(&name, &taskType) {
+		names = append(names, name)
+		types = append(types, taskType)
+	}
+	nextPageToken := iter.PageState()
+
+	if err := iter.Close(); err != nil {
+		if isThrottlingError(err) {
+			return nil, &workflow.ServiceBusyError{
+				Message: fmt.Sprintf("ListTaskList operation failed. Error: %v", err),
+			}
+		}
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("ListTaskList operation failed. Error: %v", err),
+		}
+	}
+
+	response := &p.ListTaskListResponse{}
+	for i := range names {
+		resp, err := d.GetTaskList(&p.GetTaskListRequest{
+			DomainID: request.DomainID,
+			TaskList: names[i],
+			TaskType: types[i],
+		})
+		if err != nil {
+			if _, ok := err.(*workflow.EntityNotExistsError); ok {
+				continue
+			}
+			return nil, err
+		}
+		response.Items = append(response.Items, resp.TaskListInfo)
+	}
+	response.NextPageToken = make([]byte, len(nextPageToken))
+	copy(response.NextPageToken, nextPageToken)
+	return response, nil
+}
+
+func (d *cassandraPersistence) insertTaskListByDomain(domainID, taskList string, taskType, taskListKind int) error {
+	var query *gocql.Query
+	if taskListKind == p.TaskListKindSticky {
+		query = d.session.Query(templateInsertTaskListByDomainQueryWithTTL,
+			domainID,
+			taskList,
+			taskType,
+			stickyTaskListTTL,
+		)
+	} else {
+		query = d.session.Query(templateInsertTaskListByDomainQuery,
+			domainID,
+			taskList,
+			taskType,
+		)
+	}
+	if err := query.Exec(); err != nil {
+		if isThrottlingError(err) {
+			return &workflow.ServiceBusyError{
+				Message: fmt.Sprintf("LeaseTaskList operation failed. TaskList: %v, TaskType: %v, Error: %v",
+					taskList, taskType, err),
+			}
+		}
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("LeaseTaskList operation failed. TaskList: %v, TaskType: %v, Error: %v",
+				taskList, taskType, err),
+		}
+	}
+	return nil
+}
+
+// From TaskManager interface
+func (d *cassandraPersistence) DeleteTaskList(request *p.DeleteTaskListRequest) error {
+	query := d.session.Query(templateDeleteTaskListQuery,
+		request.DomainID,
+		request.TaskListName,
+		request.TaskListType,
+		rowTypeTaskList,
+		taskListTaskID,
+		request.RangeID,
+	)
+
+	previous := make(map[string]interface{})
+	applied, err := query.MapScanCAS(previous)
+	if err != nil {
+		if isThrottlingError(err) {
+			return &workflow.ServiceBusyError{
+				Message: fmt.Sprintf("DeleteTaskList operation failed. Error: %v", err),
+			}
+		}
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("DeleteTaskList operation failed. Error: %v", err),
+		}
+	}
+	if !applied {
+		return &p.ConditionFailedError{
+			Msg: fmt.Sprintf("DeleteTaskList failed to apply. TaskList: %v, taskListType: %v, rangeID: %v, db rangeID: %v",
+				request.TaskListName, request.TaskListType, request.RangeID, previous["range_id"]),
+		}
+	}
+
+	query = d.session.Query(templateDeleteTaskListByDomainQuery,
+		request.DomainID,
+		request.TaskListName,
+		request.TaskListType,
+	)
+	if err := query.Exec(); err != nil {
+		if isThrottlingError(err) {
+			return &workflow.ServiceBusyError{
+				Message: fmt.Sprintf("DeleteTaskList operation failed. Error: %v", err),
+			}
+		}
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("DeleteTaskList operation failed. Error: %v", err),
+		}
+	}
+
+	return nil
+}
+
 // From TaskManager interface
 func (d *cassandraPersistence) CreateTasks(request *p.CreateTasksRequest) (*p.CreateTasksResponse, error) {
 	batch := d.session.NewBatch(gocql.LoggedBatch)
@@ -2475,7 +2857,8 @@ func (d *cassandraPersistence) CreateTasks(request *p.CreateTasksRequest) (*p.Cr
 				domainID,
 				task.Execution.GetWorkflowId(),
 				task.Execution.GetRunId(),
-				scheduleID)
+				scheduleID,
+				task.Data.FairnessKey)
 		} else {
 			batch.Query(templateCreateTaskWithTTLQuery,
 				domainID,
@@ -2487,6 +2870,7 @@ func (d *cassandraPersistence) CreateTasks(request *p.CreateTasksRequest) (*p.Cr
 				task.Execution.GetWorkflowId(),
 				task.Execution.GetRunId(),
 				scheduleID,
+				task.Data.FairnessKey,
 				task.Data.ScheduleToStartTimeout)
 		}
 	}
@@ -2499,6 +2883,8 @@ func (d *cassandraPersistence) CreateTasks(request *p.CreateTasksRequest) (*p.Cr
 		taskListType,
 		ackLevel,
 		taskListKind,
+		time.Now(),
+		false,
 		domainID,
 		taskList,
 		taskListType,
@@ -2604,6 +2990,31 @@ func (d *cassandraPersistence) CompleteTask(request *p.CompleteTaskRequest) erro
 	return nil
 }
 
+// From TaskManager interface
+func (d *cassandraPersistence) CompleteTasksLessThan(request *p.CompleteTasksLessThanRequest) (int, error) {
+	query := d.session.Query(templateCompleteTasksLessThanQuery,
+		request.DomainID,
+		request.TaskListName,
+		request.TaskType,
+		rowTypeTask,
+		request.TaskID,
+	)
+
+	err := query.Exec()
+	if err != nil {
+		if isThrottlingError(err) {
+			return 0, &workflow.ServiceBusyError{
+				Message: fmt.Sprintf("CompleteTasksLessThan operation failed. Error: %v", err),
+			}
+		}
+		return 0, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("CompleteTasksLessThan operation failed. Error: %v", err),
+		}
+	}
+
+	return p.UnknownNumRowsAffected, nil
+}
+
 func (d *cassandraPersistence) GetTimerIndexTasks(request *p.GetTimerIndexTasksRequest) (*p.GetTimerIndexTasksResponse,
 	error) {
 	// Reading timer tasks need to be quorum level consistent, otherwise we could loose task
@@ -2855,6 +3266,7 @@ func (d *cassandraPersistence) updateActivityInfos(batch *gocql.Batch, activityI
 			a.ScheduleID,
 			a.ScheduledEvent.Data,
 			a.ScheduledTime,
+			a.RedirectedTime,
 			a.StartedID,
 			a.StartedEvent.Data,
 			a.StartedTime,
@@ -3190,6 +3602,50 @@ func (d *cassandraPersistence) updateSignalsRequested(batch *gocql.Batch, signal
 	}
 }
 
+func (d *cassandraPersistence) updateAcceptedUpdates(batch *gocql.Batch, acceptedUpdates map[string]int64,
+	deleteUpdateIDs []string, domainID, workflowID, runID string, condition int64) {
+
+	if len(acceptedUpdates) > 0 {
+		batch.Query(templateUpdateAcceptedUpdatesQuery,
+			acceptedUpdates,
+			d.shardID,
+			rowTypeExecution,
+			domainID,
+			workflowID,
+			runID,
+			defaultVisibilityTimestamp,
+			rowTypeExecutionTaskID,
+			condition)
+	}
+
+	if len(deleteUpdateIDs) > 0 {
+		batch.Query(templateDeleteAcceptedUpdatesQuery,
+			deleteUpdateIDs, // for cassandra set binding, which removes the keys from the map
+			d.shardID,
+			rowTypeExecution,
+			domainID,
+			workflowID,
+			runID,
+			defaultVisibilityTimestamp,
+			rowTypeExecutionTaskID,
+			condition)
+	}
+}
+
+func (d *cassandraPersistence) resetAcceptedUpdates(batch *gocql.Batch, acceptedUpdates map[string]int64,
+	domainID, workflowID, runID string, condition int64) {
+	batch.Query(templateResetAcceptedUpdatesQuery,
+		acceptedUpdates,
+		d.shardID,
+		rowTypeExecution,
+		domainID,
+		workflowID,
+		runID,
+		defaultVisibilityTimestamp,
+		rowTypeExecutionTaskID,
+		condition)
+}
+
 func (d *cassandraPersistence) resetSignalRequested(batch *gocql.Batch, signalRequested []string,
 	domainID, workflowID, runID string, condition int64) {
 	batch.Query(templateResetSignalRequestedQuery,
@@ -3319,6 +3775,8 @@ func createShardInfo(currentCluster string, result map[string]interface{}) *p.Sh
 			info.ClusterTransferAckLevel = v.(map[string]int64)
 		case "cluster_timer_ack_level":
 			info.ClusterTimerAckLevel = v.(map[string]time.Time)
+		case "cluster_replication_level":
+			info.ClusterReplicationLevel = v.(map[string]int64)
 		case "domain_notification_version":
 			info.DomainNotificationVersion = v.(int64)
 		}
@@ -3334,6 +3792,9 @@ func createShardInfo(currentCluster string, result map[string]interface{}) *p.Sh
 			currentCluster: info.TimerAckLevel,
 		}
 	}
+	if info.ClusterReplicationLevel == nil {
+		info.ClusterReplicationLevel = make(map[string]int64)
+	}
 
 	return info
 }
@@ -3462,6 +3923,10 @@ func createReplicationState(result map[string]interface{}) *p.ReplicationState {
 			for key, value := range replicationInfoMap {
 				info.LastReplicationInfo[key] = createReplicationInfo(value)
 			}
+		case "version_history":
+			for _, value := range v.([]map[string]interface{}) {
+				info.VersionHistory = append(info.VersionHistory, createReplicationInfo(value))
+			}
 		}
 	}
 
@@ -3555,6 +4020,8 @@ func createActivityInfo(result map[string]interface{}) *p.InternalActivityInfo {
 			info.ScheduledEvent.Data = v.([]byte)
 		case "scheduled_time":
 			info.ScheduledTime = v.(time.Time)
+		case "redirected_time":
+			info.RedirectedTime = v.(time.Time)
 		case "started_id":
 			info.StartedID = v.(int64)
 		case "started_event":
@@ -3737,6 +4204,7 @@ func resetActivityInfoMap(activityInfos []*p.InternalActivityInfo) (map[int64]ma
 		aInfo["schedule_id"] = a.ScheduleID
 		aInfo["scheduled_event"] = a.ScheduledEvent.Data
 		aInfo["scheduled_time"] = a.ScheduledTime
+		aInfo["redirected_time"] = a.RedirectedTime
 		aInfo["started_id"] = a.StartedID
 		aInfo["started_event"] = a.StartedEvent.Data
 		aInfo["started_time"] = a.StartedTime
@@ -3864,6 +4332,32 @@ func createTaskInfo(result map[string]interface{}) *p.TaskInfo {
 			info.RunID = v.(gocql.UUID).String()
 		case "schedule_id":
 			info.ScheduleID = v.(int64)
+		case "fairness_key":
+			info.FairnessKey = v.(string)
+		}
+	}
+
+	return info
+}
+
+func createTaskListInfo(result map[string]interface{}) *p.TaskListInfo {
+	info := &p.TaskListInfo{}
+	for k, v := range result {
+		switch k {
+		case "domain_id":
+			info.DomainID = v.(gocql.UUID).String()
+		case "name":
+			info.Name = v.(string)
+		case "type":
+			info.TaskType = v.(int)
+		case "ack_level":
+			info.AckLevel = v.(int64)
+		case "kind":
+			info.Kind = v.(int)
+		case "last_updated":
+			info.LastUpdated = v.(time.Time)
+		case "released":
+			info.Released = v.(bool)
 		}
 	}
 
@@ -3922,6 +4416,15 @@ func createReplicationInfoMap(info *p.ReplicationInfo) map[string]interface{} {
 	return rInfoMap
 }
 
+func createVersionHistoryList(history []*p.ReplicationInfo) []map[string]interface{} {
+	versionHistory := make([]map[string]interface{}, 0, len(history))
+	for _, item := range history {
+		versionHistory = append(versionHistory, createReplicationInfoMap(item))
+	}
+
+	return versionHistory
+}
+
 func isTimeoutError(err error) bool {
 	if err == gocql.ErrTimeoutNoResponse {
 		return true
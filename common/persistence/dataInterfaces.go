@@ -117,6 +117,9 @@ const (
 	// TransferTaskTransferTargetRunID is the the dummy run ID for transfer tasks of types
 	// that do not have a target workflow
 	TransferTaskTransferTargetRunID = "30000000-0000-f000-f000-000000000002"
+
+	// UnknownNumRowsAffected is returned when the number of rows that an API affected cannot be determined
+	UnknownNumRowsAffected = -1
 )
 
 type (
@@ -168,6 +171,7 @@ type (
 		TimerAckLevel             time.Time
 		ClusterTransferAckLevel   map[string]int64
 		ClusterTimerAckLevel      map[string]time.Time
+		ClusterReplicationLevel   map[string]int64                 // remote cluster -> last replication task ID applied by it
 		TransferFailoverLevels    map[string]TransferFailoverLevel // uuid -> TransferFailoverLevel
 		TimerFailoverLevels       map[string]TimerFailoverLevel    // uuid -> TimerFailoverLevel
 		DomainNotificationVersion int64
@@ -246,6 +250,9 @@ type (
 		LastWriteVersion    int64
 		LastWriteEventID    int64
 		LastReplicationInfo map[string]*ReplicationInfo
+		// VersionHistory holds the last event ID written with each version, in ascending order of event ID.
+		// It is empty for the executions started before it was recorded.
+		VersionHistory []*ReplicationInfo
 	}
 
 	// TransferTaskInfo describes a transfer task
@@ -294,12 +301,15 @@ type (
 
 	// TaskListInfo describes a state of a task list implementation.
 	TaskListInfo struct {
-		DomainID string
-		Name     string
-		TaskType int
-		RangeID  int64
-		AckLevel int64
-		Kind     int
+		DomainID    string
+		Name        string
+		TaskType    int
+		RangeID     int64
+		AckLevel    int64
+		Kind        int
+		LastUpdated time.Time
+		// Released is set by an owner which gracefully handed the task list over to its next owner
+		Released bool
 	}
 
 	// TaskInfo describes either activity or decision task
@@ -310,6 +320,10 @@ type (
 		TaskID                 int64
 		ScheduleID             int64
 		ScheduleToStartTimeout int32
+		// Expiry is the time after which the task is no longer dispatched, zero if the task never expires
+		Expiry time.Time
+		// FairnessKey groups the tasks interleaved by fair dispatch, empty means the workflow ID is used
+		FairnessKey string
 	}
 
 	// Task is the generic interface for workflow tasks
@@ -467,6 +481,7 @@ type (
 		RequestCancelInfos       map[int64]*RequestCancelInfo
 		SignalInfos              map[int64]*SignalInfo
 		SignalRequestedIDs       map[string]struct{}
+		AcceptedUpdates          map[string]int64 // Update ID -> event ID of its UpdateAccepted event
 		ExecutionInfo            *WorkflowExecutionInfo
 		ReplicationState         *ReplicationState
 		BufferedEvents           []*workflow.HistoryEvent
@@ -479,6 +494,7 @@ type (
 		ScheduleID               int64
 		ScheduledEvent           *workflow.HistoryEvent
 		ScheduledTime            time.Time
+		RedirectedTime           time.Time // when the activity was last moved to a fallback task list
 		StartedID                int64
 		StartedEvent             *workflow.HistoryEvent
 		StartedTime              time.Time
@@ -670,6 +686,8 @@ type (
 		DeleteSignalInfo              *int64
 		UpsertSignalRequestedIDs      []string
 		DeleteSignalRequestedID       string
+		UpsertAcceptedUpdates         map[string]int64
+		DeleteAcceptedUpdates         []string
 		NewBufferedEvents             []*workflow.HistoryEvent
 		ClearBufferedEvents           bool
 		NewBufferedReplicationTask    *BufferedReplicationTask
@@ -693,6 +711,7 @@ type (
 		InsertRequestCancelInfos  []*RequestCancelInfo
 		InsertSignalInfos         []*SignalInfo
 		InsertSignalRequestedIDs  []string
+		InsertAcceptedUpdates     map[string]int64
 		//Optional. It is to suggest a binary encoding type to serialize history events
 		Encoding common.EncodingType
 	}
@@ -704,6 +723,14 @@ type (
 		RunID      string
 	}
 
+	// DeleteCurrentWorkflowExecutionRequest is used to delete the current run record of a workflow, the record is
+	// only deleted while it points to RunID
+	DeleteCurrentWorkflowExecutionRequest struct {
+		DomainID   string
+		WorkflowID string
+		RunID      string
+	}
+
 	// GetTransferTasksRequest is used to read tasks from the transfer task queue
 	GetTransferTasksRequest struct {
 		ReadLevel     int64
@@ -821,6 +848,50 @@ type (
 		TaskID   int64
 	}
 
+	// CompleteTasksLessThanRequest is used to complete all tasks of a task list up to a task ID
+	CompleteTasksLessThanRequest struct {
+		DomainID     string
+		TaskListName string
+		TaskType     int
+		TaskID       int64 // inclusive
+		Limit        int   // max number of tasks to complete, ignored by stores that cannot enforce it
+	}
+
+	// GetTaskListRequest is used to read the current state of a task list without leasing it
+	GetTaskListRequest struct {
+		DomainID string
+		TaskList string
+		TaskType int
+	}
+
+	// GetTaskListResponse is the response to GetTaskListRequest
+	GetTaskListResponse struct {
+		TaskListInfo *TaskListInfo
+	}
+
+	// ListTaskListRequest is used to scan through all the task lists, or only the task lists of
+	// a single domain when DomainID is set. The unscoped scan reads the whole tasks table and is
+	// meant for background maintenance only
+	ListTaskListRequest struct {
+		DomainID  string
+		PageSize  int
+		PageToken []byte
+	}
+
+	// ListTaskListResponse is the response to ListTaskListRequest
+	ListTaskListResponse struct {
+		Items         []*TaskListInfo
+		NextPageToken []byte
+	}
+
+	// DeleteTaskListRequest is used to delete a task list, the deletion only happens if the rangeID matches
+	DeleteTaskListRequest struct {
+		DomainID     string
+		TaskListName string
+		TaskListType int
+		RangeID      int64
+	}
+
 	// GetTimerIndexTasksRequest is the request for GetTimerIndexTasks
 	// TODO: replace this with an iterator that can configure min and max index.
 	GetTimerIndexTasksRequest struct {
@@ -862,6 +933,11 @@ type (
 		PageSize int
 		// Token to continue reading next page of history append transactions.  Pass in empty slice for first page
 		NextPageToken []byte
+		// Reverse returns the history from the newest event, events are in descending order of event ID
+		Reverse bool
+		// VersionHistory of the execution, used by a reverse read to tell the event batches shadowed by an
+		// overwrite from the history
+		VersionHistory []*ReplicationInfo
 	}
 
 	// GetWorkflowExecutionHistoryResponse is the response to GetWorkflowExecutionHistoryRequest
@@ -876,6 +952,16 @@ type (
 		Size int
 	}
 
+	// GetWorkflowExecutionRawHistoryResponse is the response to GetWorkflowExecutionRawHistory
+	GetWorkflowExecutionRawHistoryResponse struct {
+		// HistoryBatches are the serialized event batches, in the encoding they were stored with
+		HistoryBatches []*DataBlob
+		// Token to read next page if there are more events beyond page size.
+		NextPageToken []byte
+		// Size of history read from store
+		Size int
+	}
+
 	// DeleteWorkflowExecutionHistoryRequest is used to delete workflow execution history
 	DeleteWorkflowExecutionHistoryRequest struct {
 		DomainID  string
@@ -910,6 +996,16 @@ type (
 		ClusterName string
 	}
 
+	// DomainFailoverEvent records a change of the active cluster of a domain
+	DomainFailoverEvent struct {
+		FailoverTime    int64 // unix nanoseconds
+		FromCluster     string
+		ToCluster       string
+		FailoverVersion int64
+		Identity        string
+		Reason          string
+	}
+
 	// CreateDomainRequest is used to create the domain
 	CreateDomainRequest struct {
 		Info              *DomainInfo
@@ -918,6 +1014,7 @@ type (
 		IsGlobalDomain    bool
 		ConfigVersion     int64
 		FailoverVersion   int64
+		FailoverHistory   []*DomainFailoverEvent
 	}
 
 	// CreateDomainResponse is the response for CreateDomain
@@ -942,6 +1039,12 @@ type (
 		FailoverNotificationVersion int64
 		NotificationVersion         int64
 		TableVersion                int
+		// PendingActiveClusterName and FailoverEndTime describe the graceful failover in progress, only
+		// global domains stored in the v2 table support graceful failover
+		PendingActiveClusterName string
+		FailoverEndTime          int64
+		// FailoverHistory is the most recent failovers of the domain, oldest first
+		FailoverHistory []*DomainFailoverEvent
 	}
 
 	// UpdateDomainRequest is used to update domain
@@ -954,6 +1057,9 @@ type (
 		FailoverNotificationVersion int64
 		NotificationVersion         int64
 		TableVersion                int
+		PendingActiveClusterName    string
+		FailoverEndTime             int64
+		FailoverHistory             []*DomainFailoverEvent
 	}
 
 	// DeleteDomainRequest is used to delete domain entry from domains table
@@ -983,6 +1089,30 @@ type (
 		NotificationVersion int64
 	}
 
+	// ClusterInfo describes a cluster registered in the metadata store at runtime, on top of the clusters in
+	// the static config
+	ClusterInfo struct {
+		ClusterName            string
+		InitialFailoverVersion int64
+		Enabled                bool
+		// RPCName and RPCAddress are the service name and address of the frontend of the cluster
+		RPCName    string
+		RPCAddress string
+		// ReplicationTopic is the topic the cluster publishes its replication tasks to, empty if the topic in
+		// the static config is used
+		ReplicationTopic string
+	}
+
+	// ListClustersResponse is the response for ListClusters
+	ListClustersResponse struct {
+		Clusters []*ClusterInfo
+	}
+
+	// UpsertClusterRequest is used to register a cluster or update a registered one
+	UpsertClusterRequest struct {
+		Cluster *ClusterInfo
+	}
+
 	// MutableStateStats is the size stats for MutableState
 	MutableStateStats struct {
 		// Total size of mutable state
@@ -1066,6 +1196,7 @@ type (
 		UpdateWorkflowExecution(request *UpdateWorkflowExecutionRequest) (*UpdateWorkflowExecutionResponse, error)
 		ResetMutableState(request *ResetMutableStateRequest) error
 		DeleteWorkflowExecution(request *DeleteWorkflowExecutionRequest) error
+		DeleteCurrentWorkflowExecution(request *DeleteCurrentWorkflowExecutionRequest) error
 		GetCurrentExecution(request *GetCurrentExecutionRequest) (*GetCurrentExecutionResponse, error)
 
 		// Transfer task related methods
@@ -1094,9 +1225,16 @@ type (
 		Closeable
 		LeaseTaskList(request *LeaseTaskListRequest) (*LeaseTaskListResponse, error)
 		UpdateTaskList(request *UpdateTaskListRequest) (*UpdateTaskListResponse, error)
+		// GetTaskList returns EntityNotExistsError if the task list was never leased
+		GetTaskList(request *GetTaskListRequest) (*GetTaskListResponse, error)
 		CreateTasks(request *CreateTasksRequest) (*CreateTasksResponse, error)
 		GetTasks(request *GetTasksRequest) (*GetTasksResponse, error)
 		CompleteTask(request *CompleteTaskRequest) error
+		// CompleteTasksLessThan completes tasks less than or equal to the given task ID and returns the number of
+		// tasks deleted, or UnknownNumRowsAffected if the store cannot tell
+		CompleteTasksLessThan(request *CompleteTasksLessThanRequest) (int, error)
+		ListTaskList(request *ListTaskListRequest) (*ListTaskListResponse, error)
+		DeleteTaskList(request *DeleteTaskListRequest) error
 	}
 
 	// HistoryManager is used to manage Workflow Execution HistoryEventBatch
@@ -1106,6 +1244,9 @@ type (
 		// GetWorkflowExecutionHistory retrieves the paginated list of history events for given execution
 		GetWorkflowExecutionHistory(request *GetWorkflowExecutionHistoryRequest) (*GetWorkflowExecutionHistoryResponse,
 			error)
+		// GetWorkflowExecutionRawHistory retrieves the paginated list of serialized history batches for given execution
+		GetWorkflowExecutionRawHistory(request *GetWorkflowExecutionHistoryRequest) (*GetWorkflowExecutionRawHistoryResponse,
+			error)
 		DeleteWorkflowExecutionHistory(request *DeleteWorkflowExecutionHistoryRequest) error
 	}
 
@@ -1119,6 +1260,8 @@ type (
 		DeleteDomainByName(request *DeleteDomainByNameRequest) error
 		ListDomains(request *ListDomainsRequest) (*ListDomainsResponse, error)
 		GetMetadata() (*GetMetadataResponse, error)
+		ListClusters() (*ListClustersResponse, error)
+		UpsertCluster(request *UpsertClusterRequest) error
 	}
 )
 
@@ -1744,6 +1887,47 @@ func (config *ClusterReplicationConfig) deserialize(input map[string]interface{}
 	config.ClusterName = input["cluster_name"].(string)
 }
 
+// SerializeDomainFailoverHistory makes an array of *DomainFailoverEvent serializable
+// by flattening them into map[string]interface{}
+func SerializeDomainFailoverHistory(history []*DomainFailoverEvent) []map[string]interface{} {
+	serializedHistory := []map[string]interface{}{}
+	for _, event := range history {
+		serializedHistory = append(serializedHistory, event.serialize())
+	}
+	return serializedHistory
+}
+
+// DeserializeDomainFailoverHistory creates an array of DomainFailoverEvent from an array of map representations
+func DeserializeDomainFailoverHistory(history []map[string]interface{}) []*DomainFailoverEvent {
+	deserializedHistory := []*DomainFailoverEvent{}
+	for _, input := range history {
+		event := &DomainFailoverEvent{}
+		event.deserialize(input)
+		deserializedHistory = append(deserializedHistory, event)
+	}
+	return deserializedHistory
+}
+
+func (event *DomainFailoverEvent) serialize() map[string]interface{} {
+	output := make(map[string]interface{})
+	output["failover_time"] = event.FailoverTime
+	output["from_cluster"] = event.FromCluster
+	output["to_cluster"] = event.ToCluster
+	output["failover_version"] = event.FailoverVersion
+	output["identity"] = event.Identity
+	output["reason"] = event.Reason
+	return output
+}
+
+func (event *DomainFailoverEvent) deserialize(input map[string]interface{}) {
+	event.FailoverTime = input["failover_time"].(int64)
+	event.FromCluster = input["from_cluster"].(string)
+	event.ToCluster = input["to_cluster"].(string)
+	event.FailoverVersion = input["failover_version"].(int64)
+	event.Identity = input["identity"].(string)
+	event.Reason = input["reason"].(string)
+}
+
 // GetVisibilityTSFrom - helper method to get visibility timestamp
 func GetVisibilityTSFrom(task Task) (time.Time, error) {
 	switch task.GetType() {
@@ -1832,6 +2016,27 @@ func SetVisibilityTSFrom(task Task, t time.Time) {
 	}
 }
 
+// UpdateVersionHistory records that the events up to lastEventID were written with the given version
+func UpdateVersionHistory(history []*ReplicationInfo, version, lastEventID int64) []*ReplicationInfo {
+	size := len(history)
+	if size > 0 && history[size-1].Version == version {
+		size--
+	}
+	updated := make([]*ReplicationInfo, size, size+1)
+	copy(updated, history)
+	return append(updated, &ReplicationInfo{Version: version, LastEventID: lastEventID})
+}
+
+// GetVersionForEventID returns the version the given event was written with according to the version history
+func GetVersionForEventID(history []*ReplicationInfo, eventID int64) (int64, bool) {
+	for _, item := range history {
+		if eventID <= item.LastEventID {
+			return item.Version, true
+		}
+	}
+	return common.EmptyVersion, false
+}
+
 // DBTimestampToUnixNano converts CQL timestamp to UnixNano
 func DBTimestampToUnixNano(milliseconds int64) int64 {
 	return milliseconds * 1000 * 1000 // Milliseconds are 10⁻³, nanoseconds are 10⁻⁹, (-3) - (-9) = 6, so multiply by 10⁶
@@ -58,6 +58,7 @@ func (m *executionManagerImpl) GetWorkflowExecution(request *GetWorkflowExecutio
 			RequestCancelInfos: response.State.RequestCancelInfos,
 			SignalInfos:        response.State.SignalInfos,
 			SignalRequestedIDs: response.State.SignalRequestedIDs,
+			AcceptedUpdates:    response.State.AcceptedUpdates,
 			ReplicationState:   response.State.ReplicationState,
 		},
 	}
@@ -221,6 +222,7 @@ func (m *executionManagerImpl) DeserializeActivityInfos(infos map[int64]*Interna
 			Version:                  v.Version,
 			ScheduleID:               v.ScheduleID,
 			ScheduledTime:            v.ScheduledTime,
+			RedirectedTime:           v.RedirectedTime,
 			StartedID:                v.StartedID,
 			StartedTime:              v.StartedTime,
 			ActivityID:               v.ActivityID,
@@ -304,6 +306,8 @@ func (m *executionManagerImpl) UpdateWorkflowExecution(request *UpdateWorkflowEx
 		DeleteSignalInfo:              request.DeleteSignalInfo,
 		UpsertSignalRequestedIDs:      request.UpsertSignalRequestedIDs,
 		DeleteSignalRequestedID:       request.DeleteSignalRequestedID,
+		UpsertAcceptedUpdates:         request.UpsertAcceptedUpdates,
+		DeleteAcceptedUpdates:         request.DeleteAcceptedUpdates,
 		ClearBufferedEvents:           request.ClearBufferedEvents,
 		DeleteBufferedReplicationTask: request.DeleteBufferedReplicationTask,
 	}
@@ -382,6 +386,7 @@ func (m *executionManagerImpl) SerializeUpsertActivityInfos(infos []*ActivityInf
 			ScheduleID:               v.ScheduleID,
 			ScheduledEvent:           scheduledEvent,
 			ScheduledTime:            v.ScheduledTime,
+			RedirectedTime:           v.RedirectedTime,
 			StartedID:                v.StartedID,
 			StartedEvent:             startedEvent,
 			StartedTime:              v.StartedTime,
@@ -501,6 +506,7 @@ func (m *executionManagerImpl) ResetMutableState(request *ResetMutableStateReque
 		InsertRequestCancelInfos:  request.InsertRequestCancelInfos,
 		InsertSignalInfos:         request.InsertSignalInfos,
 		InsertSignalRequestedIDs:  request.InsertSignalRequestedIDs,
+		InsertAcceptedUpdates:     request.InsertAcceptedUpdates,
 	}
 	return m.persistence.ResetMutableState(newRequest)
 }
@@ -512,6 +518,10 @@ func (m *executionManagerImpl) DeleteWorkflowExecution(request *DeleteWorkflowEx
 	return m.persistence.DeleteWorkflowExecution(request)
 }
 
+func (m *executionManagerImpl) DeleteCurrentWorkflowExecution(request *DeleteCurrentWorkflowExecutionRequest) error {
+	return m.persistence.DeleteCurrentWorkflowExecution(request)
+}
+
 func (m *executionManagerImpl) GetCurrentExecution(request *GetCurrentExecutionRequest) (*GetCurrentExecutionResponse, error) {
 	return m.persistence.GetCurrentExecution(request)
 }
@@ -23,6 +23,7 @@ package persistence
 import (
 	"encoding/json"
 	"fmt"
+	"math"
 
 	"github.com/uber-common/bark"
 	workflow "github.com/uber/cadence/.gen/go/shared"
@@ -44,6 +45,19 @@ type (
 		LastEventID           int64
 		Data                  []byte
 	}
+
+	// historyBatch is a batch of history events loaded by a forward read, along with its serialized form
+	historyBatch struct {
+		blob   *DataBlob
+		events []*workflow.HistoryEvent
+	}
+
+	// reverseEventBatch is a batch of history events loaded by a reverse read
+	reverseEventBatch struct {
+		events  []*workflow.HistoryEvent
+		version int64
+		size    int
+	}
 )
 
 var _ HistoryManager = (*historyManagerImpl)(nil)
@@ -79,11 +93,73 @@ func (m *historyManagerImpl) AppendHistoryEvents(request *AppendHistoryEventsReq
 
 // GetWorkflowExecutionHistory retrieves the paginated list of history events for given execution
 func (m *historyManagerImpl) GetWorkflowExecutionHistory(request *GetWorkflowExecutionHistoryRequest) (*GetWorkflowExecutionHistoryResponse, error) {
-	token, err := m.deserializeToken(request)
+	if request.Reverse {
+		return m.getWorkflowExecutionHistoryReverse(request)
+	}
+
+	token, batches, size, err := m.readHistoryBatches(request)
+	if err != nil {
+		return nil, err
+	}
+
+	newResponse := &GetWorkflowExecutionHistoryResponse{}
+
+	history := &workflow.History{
+		Events: make([]*workflow.HistoryEvent, 0),
+	}
+
+	// first_event_id of the last batch
+	lastFirstEventID := common.EmptyEventID
+	for _, b := range batches {
+		lastFirstEventID = b.events[0].GetEventId()
+		history.Events = append(history.Events, b.events...)
+	}
+
+	newResponse.Size = size
+	newResponse.LastFirstEventID = lastFirstEventID
+	newResponse.History = history
+	newResponse.NextPageToken, err = m.serializeToken(token, request.NextEventID)
+	if err != nil {
+		return nil, err
+	}
+
+	return newResponse, nil
+}
+
+// GetWorkflowExecutionRawHistory retrieves the paginated list of serialized history batches for given execution,
+// the staled batches are skipped the same way as GetWorkflowExecutionHistory
+func (m *historyManagerImpl) GetWorkflowExecutionRawHistory(request *GetWorkflowExecutionHistoryRequest) (
+	*GetWorkflowExecutionRawHistoryResponse, error) {
+	if request.Reverse {
+		return nil, &workflow.BadRequestError{Message: "Reverse order is not supported for raw history."}
+	}
+
+	token, batches, size, err := m.readHistoryBatches(request)
 	if err != nil {
 		return nil, err
 	}
 
+	newResponse := &GetWorkflowExecutionRawHistoryResponse{Size: size}
+	for _, b := range batches {
+		newResponse.HistoryBatches = append(newResponse.HistoryBatches, b.blob)
+	}
+	newResponse.NextPageToken, err = m.serializeToken(token, request.NextEventID)
+	if err != nil {
+		return nil, err
+	}
+
+	return newResponse, nil
+}
+
+// readHistoryBatches reads a page of history batches in order, the staled batches overwritten by a later batch
+// are skipped
+func (m *historyManagerImpl) readHistoryBatches(request *GetWorkflowExecutionHistoryRequest) (
+	*historyToken, []*historyBatch, int, error) {
+	token, err := m.deserializeToken(request)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
 	// persistence API expects the actual cassandra paging token
 	newRequest := &InternalGetWorkflowExecutionHistoryRequest{
 		LastEventBatchVersion: token.LastEventBatchVersion,
@@ -97,60 +173,204 @@ func (m *historyManagerImpl) GetWorkflowExecutionHistory(request *GetWorkflowExe
 	}
 	response, err := m.persistence.GetWorkflowExecutionHistory(newRequest)
 	if err != nil {
-		return nil, err
+		return nil, nil, 0, err
 	}
 	// we store LastEventBatchVersion in the token. The reason we do it here is for historic reason.
 	token.LastEventBatchVersion = response.LastEventBatchVersion
 	token.Data = response.NextPageToken
 
-	newResponse := &GetWorkflowExecutionHistoryResponse{}
-
-	history := &workflow.History{
-		Events: make([]*workflow.HistoryEvent, 0),
-	}
-
-	// first_event_id of the last batch
-	lastFirstEventID := common.EmptyEventID
+	var batches []*historyBatch
 	size := 0
-
 	for _, b := range response.History {
 		size += len(b.Data)
-		historyBatch, err := m.serializer.DeserializeBatchEvents(b)
+		events, err := m.serializer.DeserializeBatchEvents(b)
 		if err != nil {
-			return nil, err
+			return nil, nil, 0, err
 		}
 
-		if len(historyBatch) == 0 || historyBatch[0].GetEventId() > token.LastEventID+1 {
-			logger := m.logger.WithFields(bark.Fields{
-				logging.TagWorkflowExecutionID: request.Execution.GetWorkflowId(),
-				logging.TagWorkflowRunID:       request.Execution.GetRunId(),
-				logging.TagDomainID:            request.DomainID,
-			})
-			logger.Error("Unexpected event batch")
-			return nil, fmt.Errorf("corrupted history event batch")
+		if len(events) == 0 || events[0].GetEventId() > token.LastEventID+1 {
+			return nil, nil, 0, m.corruptedHistoryError(request)
 		}
 
-		if historyBatch[0].GetEventId() != token.LastEventID+1 {
+		if events[0].GetEventId() != token.LastEventID+1 {
 			// staled event batch, skip it
 			continue
 		}
 
-		lastFirstEventID = historyBatch[0].GetEventId()
-		history.Events = append(history.Events, historyBatch...)
-		token.LastEventID = historyBatch[len(historyBatch)-1].GetEventId()
+		batches = append(batches, &historyBatch{blob: b, events: events})
+		token.LastEventID = events[len(events)-1].GetEventId()
 	}
 
-	newResponse.Size = size
-	newResponse.LastFirstEventID = lastFirstEventID
-	newResponse.History = history
-	newResponse.NextPageToken, err = m.serializeToken(token, request.NextEventID)
+	return token, batches, size, nil
+}
+
+// getWorkflowExecutionHistoryReverse retrieves the paginated list of history events from the newest one.
+//
+// A forward read skips the rows shadowed by an overwrite because they do not chain to the batch read before
+// them, but read backwards the batch covering a shadowed row only comes after it. Shadowed rows always carry
+// a lower batch version than the events the version history of the execution records for them, so given the
+// version history they are skipped as they are read. Without it, which is the case for the executions started
+// before it was recorded, a batch is returned once it chains to the batch returned before it without a version
+// drop on the way, and the batches after a version drop are held back until the rows covering them are read.
+func (m *historyManagerImpl) getWorkflowExecutionHistoryReverse(request *GetWorkflowExecutionHistoryRequest) (
+	*GetWorkflowExecutionHistoryResponse, error) {
+
+	token, err := m.deserializeReverseToken(request)
 	if err != nil {
 		return nil, err
 	}
 
+	var candidates, accepted []*reverseEventBatch
+	nextEventID := token.LastEventID
+	exhausted := nextEventID <= request.FirstEventID
+	for !exhausted {
+		response, err := m.persistence.GetWorkflowExecutionHistory(&InternalGetWorkflowExecutionHistoryRequest{
+			LastEventBatchVersion: token.LastEventBatchVersion,
+
+			DomainID:     request.DomainID,
+			Execution:    request.Execution,
+			FirstEventID: request.FirstEventID,
+			NextEventID:  nextEventID,
+			PageSize:     request.PageSize,
+			Reverse:      true,
+		})
+		if err != nil {
+			if _, ok := err.(*workflow.EntityNotExistsError); !ok || nextEventID == token.LastEventID {
+				return nil, err
+			}
+			// the previous read ended right at the beginning of the history
+			response = &InternalGetWorkflowExecutionHistoryResponse{}
+		}
+
+		for i, b := range response.History {
+			historyBatch, err := m.serializer.DeserializeBatchEvents(b)
+			if err != nil {
+				return nil, err
+			}
+			if len(historyBatch) == 0 {
+				return nil, m.corruptedHistoryError(request)
+			}
+
+			nextEventID = historyBatch[0].GetEventId()
+			lastEventID := historyBatch[len(historyBatch)-1].GetEventId()
+			if lastEventID >= token.LastEventID {
+				// shadowed event batch overlapping the events already returned, skip it
+				continue
+			}
+			if len(request.VersionHistory) > 0 {
+				version, ok := GetVersionForEventID(request.VersionHistory, lastEventID)
+				if !ok || version != response.EventBatchVersions[i] {
+					// shadowed event batch written with another version than the history, skip it
+					continue
+				}
+			}
+			// the candidates covered by this batch are shadowed event batches
+			for len(candidates) > 0 && candidates[len(candidates)-1].events[0].GetEventId() <= lastEventID {
+				candidates = candidates[:len(candidates)-1]
+			}
+			candidates = append(candidates, &reverseEventBatch{
+				events:  historyBatch,
+				version: response.EventBatchVersions[i],
+				size:    len(b.Data),
+			})
+		}
+
+		exhausted = len(response.History) < request.PageSize || nextEventID <= request.FirstEventID
+		accepted = acceptReverseEventBatches(candidates, token, request.PageSize, exhausted,
+			len(request.VersionHistory) > 0)
+		if len(accepted) > 0 {
+			break
+		}
+	}
+	if exhausted && len(accepted) < len(candidates) {
+		return nil, m.corruptedHistoryError(request)
+	}
+
+	history := &workflow.History{
+		Events: make([]*workflow.HistoryEvent, 0),
+	}
+	lastFirstEventID := common.EmptyEventID
+	size := 0
+	for _, batch := range accepted {
+		for i := len(batch.events) - 1; i >= 0; i-- {
+			history.Events = append(history.Events, batch.events[i])
+		}
+		lastFirstEventID = batch.events[0].GetEventId()
+		size += batch.size
+		token.LastEventID = lastFirstEventID
+		token.LastEventBatchVersion = batch.version
+	}
+
+	newResponse := &GetWorkflowExecutionHistoryResponse{
+		History:          history,
+		LastFirstEventID: lastFirstEventID,
+		Size:             size,
+	}
+	if !exhausted {
+		newResponse.NextPageToken, err = m.serializeReverseToken(token)
+		if err != nil {
+			return nil, err
+		}
+	}
 	return newResponse, nil
 }
 
+// acceptReverseEventBatches returns the leading candidates known to be part of the history. The candidates
+// checked against the version history are all part of it, otherwise once the read reached the beginning of
+// the history, every candidate chaining to the events already returned is accepted
+func acceptReverseEventBatches(candidates []*reverseEventBatch, token *historyToken, pageSize int,
+	exhausted bool, verified bool) []*reverseEventBatch {
+
+	expectedLastEventID := token.LastEventID - 1
+	version := token.LastEventBatchVersion
+	for i, batch := range candidates {
+		if !exhausted && (i == pageSize || (!verified && batch.version < version)) {
+			return candidates[:i]
+		}
+		if batch.events[len(batch.events)-1].GetEventId() != expectedLastEventID {
+			return candidates[:i]
+		}
+		expectedLastEventID = batch.events[0].GetEventId() - 1
+		version = batch.version
+	}
+	return candidates
+}
+
+func (m *historyManagerImpl) corruptedHistoryError(request *GetWorkflowExecutionHistoryRequest) error {
+	logger := m.logger.WithFields(bark.Fields{
+		logging.TagWorkflowExecutionID: request.Execution.GetWorkflowId(),
+		logging.TagWorkflowRunID:       request.Execution.GetRunId(),
+		logging.TagDomainID:            request.DomainID,
+	})
+	logger.Error("Unexpected event batch")
+	return fmt.Errorf("corrupted history event batch")
+}
+
+func (m *historyManagerImpl) deserializeReverseToken(request *GetWorkflowExecutionHistoryRequest) (*historyToken, error) {
+	// for a reverse read, the token tracks the oldest batch returned so far
+	token := &historyToken{
+		LastEventBatchVersion: math.MinInt64,
+		LastEventID:           request.NextEventID,
+	}
+
+	if len(request.NextPageToken) == 0 {
+		return token, nil
+	}
+
+	if err := json.Unmarshal(request.NextPageToken, token); err != nil {
+		return nil, &workflow.BadRequestError{Message: "Invalid history event token."}
+	}
+	return token, nil
+}
+
+func (m *historyManagerImpl) serializeReverseToken(token *historyToken) ([]byte, error) {
+	data, err := json.Marshal(token)
+	if err != nil {
+		return nil, &workflow.InternalServiceError{Message: "Error generating history event token."}
+	}
+	return data, nil
+}
+
 func (m *historyManagerImpl) deserializeToken(request *GetWorkflowExecutionHistoryRequest) (*historyToken, error) {
 	token := &historyToken{
 		LastEventBatchVersion: common.EmptyVersion,
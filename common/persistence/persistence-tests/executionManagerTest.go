@@ -701,6 +701,44 @@ func (s *ExecutionManagerSuite) TestDeleteCurrentWorkflow() {
 	s.NoError(err2)
 }
 
+// TestDeleteCurrentWorkflowExecution test
+func (s *ExecutionManagerSuite) TestDeleteCurrentWorkflowExecution() {
+	domainID := "0c9f3a6e-5a4c-4d2e-9b7a-3c1e8f2d6b4a"
+	workflowExecution := gen.WorkflowExecution{
+		WorkflowId: common.StringPtr("delete-current-workflow-execution-test"),
+		RunId:      common.StringPtr("7b2e4d1c-8f3a-4e6b-a5c9-2d1f0e3b4a6c"),
+	}
+
+	task0, err0 := s.CreateWorkflowExecution(domainID, workflowExecution, "queue1", "wType", 20, 13, nil, 3, 0, 2, nil)
+	s.NoError(err0)
+	s.NotNil(task0, "Expected non empty task identifier.")
+
+	// the current run record is kept while it points to another run
+	err1 := s.ExecutionManager.DeleteCurrentWorkflowExecution(&p.DeleteCurrentWorkflowExecutionRequest{
+		DomainID:   domainID,
+		WorkflowID: workflowExecution.GetWorkflowId(),
+		RunID:      "1f6a3c2b-4d5e-4f7a-8b9c-0d1e2f3a4b5c",
+	})
+	s.NoError(err1)
+	runID2, err2 := s.GetCurrentWorkflowRunID(domainID, workflowExecution.GetWorkflowId())
+	s.NoError(err2)
+	s.Equal(workflowExecution.GetRunId(), runID2)
+
+	err3 := s.ExecutionManager.DeleteCurrentWorkflowExecution(&p.DeleteCurrentWorkflowExecutionRequest{
+		DomainID:   domainID,
+		WorkflowID: workflowExecution.GetWorkflowId(),
+		RunID:      workflowExecution.GetRunId(),
+	})
+	s.NoError(err3)
+	runID4, err4 := s.GetCurrentWorkflowRunID(domainID, workflowExecution.GetWorkflowId())
+	s.Error(err4)
+	s.Empty(runID4)
+
+	// execution record should still be there
+	_, err5 := s.GetWorkflowExecutionInfo(domainID, workflowExecution)
+	s.NoError(err5)
+}
+
 // TestGetCurrentWorkflow test
 func (s *ExecutionManagerSuite) TestGetCurrentWorkflow() {
 	domainID := "54d15308-e20e-4b91-a00f-a518a3892790"
@@ -1721,6 +1759,49 @@ func (s *ExecutionManagerSuite) TestWorkflowMutableStateSignalRequested() {
 	s.Equal(0, len(state.SignalRequestedIDs))
 }
 
+// TestWorkflowMutableStateAcceptedUpdates test
+func (s *ExecutionManagerSuite) TestWorkflowMutableStateAcceptedUpdates() {
+	domainID := uuid.New()
+	runID := uuid.New()
+	workflowExecution := gen.WorkflowExecution{
+		WorkflowId: common.StringPtr("test-workflow-mutable-accepted-updates-test"),
+		RunId:      common.StringPtr(runID),
+	}
+
+	task0, err0 := s.CreateWorkflowExecution(domainID, workflowExecution, "taskList", "wType", 20, 13, nil, 3, 0, 2, nil)
+	s.NoError(err0)
+	s.NotNil(task0, "Expected non empty task identifier.")
+
+	state0, err1 := s.GetWorkflowExecutionInfo(domainID, workflowExecution)
+	s.NoError(err1)
+	info0 := state0.ExecutionInfo
+	s.NotNil(info0, "Valid Workflow info expected.")
+	s.Equal(0, len(state0.AcceptedUpdates))
+
+	updatedInfo := copyWorkflowExecutionInfo(info0)
+	updatedInfo.NextEventID = int64(5)
+	updatedInfo.LastProcessedEvent = int64(2)
+	err2 := s.UpsertAcceptedUpdatesState(updatedInfo, int64(3), map[string]int64{"update-1": 4})
+	s.NoError(err2)
+
+	updatedInfo.NextEventID = int64(8)
+	err2 = s.UpsertAcceptedUpdatesState(updatedInfo, int64(5), map[string]int64{"update-2": 7})
+	s.NoError(err2)
+
+	state, err1 := s.GetWorkflowExecutionInfo(domainID, workflowExecution)
+	s.NoError(err1)
+	s.NotNil(state, "expected valid state.")
+	s.Equal(map[string]int64{"update-1": 4, "update-2": 7}, state.AcceptedUpdates)
+
+	err2 = s.DeleteAcceptedUpdatesState(updatedInfo, int64(8), []string{"update-1"})
+	s.NoError(err2)
+
+	state, err1 = s.GetWorkflowExecutionInfo(domainID, workflowExecution)
+	s.NoError(err1)
+	s.NotNil(state, "expected valid state.")
+	s.Equal(map[string]int64{"update-2": 7}, state.AcceptedUpdates)
+}
+
 // TestWorkflowMutableStateBufferedReplicationTasks test
 func (s *ExecutionManagerSuite) TestWorkflowMutableStateBufferedReplicationTasks() {
 	domainID := "714f8491-a34e-4301-a5af-f0cf5d8660c6"
@@ -3019,6 +3100,7 @@ func (s *ExecutionManagerSuite) TestCreateGetShardBackfill() {
 	shardInfo.ClusterTimerAckLevel = map[string]time.Time{
 		s.ClusterMetadata.GetCurrentClusterName(): currentClusterTimerAck,
 	}
+	shardInfo.ClusterReplicationLevel = map[string]int64{}
 	resp, err := s.ShardMgr.GetShard(&p.GetShardRequest{ShardID: shardID})
 	s.NoError(err)
 	s.True(timeComparator(shardInfo.UpdatedAt, resp.ShardInfo.UpdatedAt, timePrecision))
@@ -3040,6 +3122,7 @@ func (s *ExecutionManagerSuite) TestCreateGetUpdateGetShard() {
 	alternativeClusterTransferAck := int64(32)
 	currentClusterTimerAck := timestampConvertor(time.Now().Add(-10 * time.Second))
 	alternativeClusterTimerAck := timestampConvertor(time.Now().Add(-20 * time.Second))
+	alternativeClusterReplicationLevel := int64(25)
 	domainNotificationVersion := int64(8192)
 	shardInfo := &p.ShardInfo{
 		ShardID:             shardID,
@@ -3058,6 +3141,9 @@ func (s *ExecutionManagerSuite) TestCreateGetUpdateGetShard() {
 			cluster.TestCurrentClusterName:     currentClusterTimerAck,
 			cluster.TestAlternativeClusterName: alternativeClusterTimerAck,
 		},
+		ClusterReplicationLevel: map[string]int64{
+			cluster.TestAlternativeClusterName: alternativeClusterReplicationLevel,
+		},
 		DomainNotificationVersion: domainNotificationVersion,
 	}
 	createRequest := &p.CreateShardRequest{
@@ -3079,6 +3165,7 @@ func (s *ExecutionManagerSuite) TestCreateGetUpdateGetShard() {
 	alternativeClusterTransferAck = int64(320)
 	currentClusterTimerAck = timestampConvertor(time.Now().Add(-100 * time.Second))
 	alternativeClusterTimerAck = timestampConvertor(time.Now().Add(-200 * time.Second))
+	alternativeClusterReplicationLevel = int64(250)
 	domainNotificationVersion = int64(16384)
 	shardInfo = &p.ShardInfo{
 		ShardID:             shardID,
@@ -3097,6 +3184,9 @@ func (s *ExecutionManagerSuite) TestCreateGetUpdateGetShard() {
 			cluster.TestCurrentClusterName:     currentClusterTimerAck,
 			cluster.TestAlternativeClusterName: alternativeClusterTimerAck,
 		},
+		ClusterReplicationLevel: map[string]int64{
+			cluster.TestAlternativeClusterName: alternativeClusterReplicationLevel,
+		},
 		DomainNotificationVersion: domainNotificationVersion,
 	}
 	updateRequest := &p.UpdateShardRequest{
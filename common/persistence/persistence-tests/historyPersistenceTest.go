@@ -301,6 +301,109 @@ func (s *HistoryPersistenceSuite) TestOverwriteAndShadowingHistoryEvents() {
 	for i, e := range historyEvents {
 		s.Equal(int64(i+1), e.GetEventId())
 	}
+
+	for _, pageSize := range []int{1, 3, 25} {
+		historyEvents = []*gen.HistoryEvent{}
+		token = nil
+		for {
+			history, token, err = s.GetWorkflowExecutionHistoryReverse(domainID, workflowExecution, 1, 25, pageSize, token, nil)
+			s.Nil(err)
+			historyEvents = append(historyEvents, history.Events...)
+			if len(token) == 0 {
+				break
+			}
+		}
+		s.Equal(24, len(historyEvents))
+		for i, e := range historyEvents {
+			s.Equal(int64(24-i), e.GetEventId())
+		}
+	}
+
+	// given the version history, the shadowed batches are skipped without holding back the batches after them
+	versionHistory := []*p.ReplicationInfo{
+		{Version: version1, LastEventID: 7},
+		{Version: version2, LastEventID: 24},
+	}
+	history, token, err = s.GetWorkflowExecutionHistoryReverse(domainID, workflowExecution, 1, 25, 1, nil, versionHistory)
+	s.Nil(err)
+	s.NotEmpty(token)
+	s.Equal([]int64{24}, eventIDsForTest(history))
+
+	for _, pageSize := range []int{1, 3, 25} {
+		historyEvents = []*gen.HistoryEvent{}
+		token = nil
+		for {
+			history, token, err = s.GetWorkflowExecutionHistoryReverse(domainID, workflowExecution, 1, 25, pageSize, token,
+				versionHistory)
+			s.Nil(err)
+			historyEvents = append(historyEvents, history.Events...)
+			if len(token) == 0 {
+				break
+			}
+		}
+		s.Equal(24, len(historyEvents))
+		for i, e := range historyEvents {
+			s.Equal(int64(24-i), e.GetEventId())
+		}
+	}
+}
+
+func (s *HistoryPersistenceSuite) TestGetHistoryEventsReverse() {
+	domainID := "a82ac0c1-4a2a-4d68-9a6c-2b8dbd41e9ee"
+	workflowExecution := gen.WorkflowExecution{
+		WorkflowId: common.StringPtr("get-history-events-reverse-test"),
+		RunId:      common.StringPtr("0c6a9a0b-3e47-4b52-8a4e-5b4b0d3d5c0e"),
+	}
+
+	eventBatches := []*gen.History{
+		newBatchEventForTest([]int64{1, 2}, 1),
+		newBatchEventForTest([]int64{3}, 1),
+		newBatchEventForTest([]int64{4, 5, 6}, 2),
+		newBatchEventForTest([]int64{7}, 2),
+	}
+	for i, be := range eventBatches {
+		err := s.AppendHistoryEvents(domainID, workflowExecution, be.Events[0].GetEventId(), be.Events[0].GetVersion(), 1, int64(i), be, false)
+		s.Nil(err)
+	}
+
+	history, token, err := s.GetWorkflowExecutionHistoryReverse(domainID, workflowExecution, 1, 8, 2, nil, nil)
+	s.Nil(err)
+	s.NotEmpty(token)
+	s.Equal([]int64{7, 6, 5, 4}, eventIDsForTest(history))
+
+	history, token, err = s.GetWorkflowExecutionHistoryReverse(domainID, workflowExecution, 1, 8, 2, token, nil)
+	s.Nil(err)
+	s.Empty(token)
+	s.Equal([]int64{3, 2, 1}, eventIDsForTest(history))
+
+	// events after the next event ID are not returned
+	history, token, err = s.GetWorkflowExecutionHistoryReverse(domainID, workflowExecution, 1, 7, 10, nil, nil)
+	s.Nil(err)
+	s.Empty(token)
+	s.Equal([]int64{6, 5, 4, 3, 2, 1}, eventIDsForTest(history))
+
+	// given the version history, a version drop does not hold back the batches before it
+	versionHistory := []*p.ReplicationInfo{
+		{Version: 1, LastEventID: 3},
+		{Version: 2, LastEventID: 7},
+	}
+	history, token, err = s.GetWorkflowExecutionHistoryReverse(domainID, workflowExecution, 1, 8, 3, nil, versionHistory)
+	s.Nil(err)
+	s.NotEmpty(token)
+	s.Equal([]int64{7, 6, 5, 4, 3}, eventIDsForTest(history))
+
+	history, token, err = s.GetWorkflowExecutionHistoryReverse(domainID, workflowExecution, 1, 8, 3, token, versionHistory)
+	s.Nil(err)
+	s.Empty(token)
+	s.Equal([]int64{2, 1}, eventIDsForTest(history))
+}
+
+func eventIDsForTest(history *gen.History) []int64 {
+	var eventIDs []int64
+	for _, e := range history.Events {
+		eventIDs = append(eventIDs, e.GetEventId())
+	}
+	return eventIDs
 }
 
 // AppendHistoryEvents helper
@@ -341,6 +444,29 @@ func (s *HistoryPersistenceSuite) GetWorkflowExecutionHistory(domainID string, w
 	return response.History, response.NextPageToken, nil
 }
 
+// GetWorkflowExecutionHistoryReverse helper
+func (s *HistoryPersistenceSuite) GetWorkflowExecutionHistoryReverse(domainID string, workflowExecution gen.WorkflowExecution,
+	firstEventID, nextEventID int64, pageSize int, token []byte, versionHistory []*p.ReplicationInfo) (*gen.History,
+	[]byte, error) {
+
+	response, err := s.HistoryMgr.GetWorkflowExecutionHistory(&p.GetWorkflowExecutionHistoryRequest{
+		DomainID:       domainID,
+		Execution:      workflowExecution,
+		FirstEventID:   firstEventID,
+		NextEventID:    nextEventID,
+		PageSize:       pageSize,
+		NextPageToken:  token,
+		Reverse:        true,
+		VersionHistory: versionHistory,
+	})
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return response.History, response.NextPageToken, nil
+}
+
 // DeleteWorkflowExecutionHistory helper
 func (s *HistoryPersistenceSuite) DeleteWorkflowExecutionHistory(domainID string,
 	workflowExecution gen.WorkflowExecution) error {
@@ -199,6 +199,58 @@ func (s *MatchingPersistenceSuite) TestLeaseAndUpdateTaskList() {
 	s.Error(err)
 }
 
+// TestGetAndReleaseTaskList test
+func (s *MatchingPersistenceSuite) TestGetAndReleaseTaskList() {
+	domainID := uuid.New()
+	taskList := "get-and-release-task-list"
+	_, err := s.TaskMgr.GetTaskList(&p.GetTaskListRequest{
+		DomainID: domainID,
+		TaskList: taskList,
+		TaskType: p.TaskListTypeActivity,
+	})
+	s.IsType(&gen.EntityNotExistsError{}, err)
+
+	response, err := s.TaskMgr.LeaseTaskList(&p.LeaseTaskListRequest{
+		DomainID: domainID,
+		TaskList: taskList,
+		TaskType: p.TaskListTypeActivity,
+	})
+	s.NoError(err)
+	tli := response.TaskListInfo
+
+	tli.AckLevel = 10
+	tli.Released = true
+	_, err = s.TaskMgr.UpdateTaskList(&p.UpdateTaskListRequest{TaskListInfo: tli})
+	s.NoError(err)
+
+	getResponse, err := s.TaskMgr.GetTaskList(&p.GetTaskListRequest{
+		DomainID: domainID,
+		TaskList: taskList,
+		TaskType: p.TaskListTypeActivity,
+	})
+	s.NoError(err)
+	s.EqualValues(1, getResponse.TaskListInfo.RangeID)
+	s.EqualValues(10, getResponse.TaskListInfo.AckLevel)
+	s.True(getResponse.TaskListInfo.Released)
+
+	// the next owner's lease clears the released flag
+	_, err = s.TaskMgr.LeaseTaskList(&p.LeaseTaskListRequest{
+		DomainID: domainID,
+		TaskList: taskList,
+		TaskType: p.TaskListTypeActivity,
+	})
+	s.NoError(err)
+	getResponse, err = s.TaskMgr.GetTaskList(&p.GetTaskListRequest{
+		DomainID: domainID,
+		TaskList: taskList,
+		TaskType: p.TaskListTypeActivity,
+	})
+	s.NoError(err)
+	s.EqualValues(2, getResponse.TaskListInfo.RangeID)
+	s.EqualValues(10, getResponse.TaskListInfo.AckLevel)
+	s.False(getResponse.TaskListInfo.Released)
+}
+
 // TestLeaseAndUpdateTaskListSticky test
 func (s *MatchingPersistenceSuite) TestLeaseAndUpdateTaskListSticky() {
 	domainID := uuid.New()
@@ -228,3 +280,118 @@ func (s *MatchingPersistenceSuite) TestLeaseAndUpdateTaskListSticky() {
 	})
 	s.NoError(err) // because update with ttl doesn't check rangeID
 }
+
+// TestCompleteTasksLessThan test
+func (s *MatchingPersistenceSuite) TestCompleteTasksLessThan() {
+	domainID := uuid.New()
+	workflowExecution := gen.WorkflowExecution{WorkflowId: common.StringPtr("complete-tasks-less-than-test"),
+		RunId: common.StringPtr("5a81b0bc-7a5d-4a7e-8d16-3b0a84ad2e9c")}
+	taskList := "3b0a84ad2e9c"
+	_, err := s.CreateActivityTasks(domainID, workflowExecution, map[int64]string{
+		10: taskList,
+		20: taskList,
+		30: taskList,
+		40: taskList,
+	})
+	s.NoError(err)
+
+	resp, err := s.GetTasks(domainID, taskList, p.TaskListTypeActivity, 10)
+	s.NoError(err)
+	s.Equal(4, len(resp.Tasks))
+
+	_, err = s.TaskMgr.CompleteTasksLessThan(&p.CompleteTasksLessThanRequest{
+		DomainID:     domainID,
+		TaskListName: taskList,
+		TaskType:     p.TaskListTypeActivity,
+		TaskID:       resp.Tasks[1].TaskID,
+		Limit:        10,
+	})
+	s.NoError(err)
+
+	remaining, err := s.GetTasks(domainID, taskList, p.TaskListTypeActivity, 10)
+	s.NoError(err)
+	s.Equal(2, len(remaining.Tasks))
+	s.Equal(resp.Tasks[2].TaskID, remaining.Tasks[0].TaskID)
+	s.Equal(resp.Tasks[3].TaskID, remaining.Tasks[1].TaskID)
+}
+
+// TestListAndDeleteTaskList test
+func (s *MatchingPersistenceSuite) TestListAndDeleteTaskList() {
+	domainID := uuid.New()
+	taskLists := map[string]struct{}{"list-delete-1": {}, "list-delete-2": {}, "list-delete-3": {}}
+	leased := make(map[string]*p.TaskListInfo)
+	for name := range taskLists {
+		resp, err := s.TaskMgr.LeaseTaskList(&p.LeaseTaskListRequest{
+			DomainID: domainID,
+			TaskList: name,
+			TaskType: p.TaskListTypeActivity,
+		})
+		s.NoError(err)
+		s.False(resp.TaskListInfo.LastUpdated.IsZero())
+		leased[name] = resp.TaskListInfo
+	}
+
+	found := make(map[string]*p.TaskListInfo)
+	var pageToken []byte
+	for {
+		resp, err := s.TaskMgr.ListTaskList(&p.ListTaskListRequest{PageSize: 2, PageToken: pageToken})
+		s.NoError(err)
+		for _, info := range resp.Items {
+			if info.DomainID == domainID {
+				found[info.Name] = info
+			}
+		}
+		if len(resp.NextPageToken) == 0 {
+			break
+		}
+		pageToken = resp.NextPageToken
+	}
+	s.Equal(len(taskLists), len(found))
+	for name, info := range found {
+		s.Equal(p.TaskListTypeActivity, info.TaskType)
+		s.Equal(leased[name].RangeID, info.RangeID)
+		s.WithinDuration(leased[name].LastUpdated, info.LastUpdated, time.Second)
+	}
+
+	byDomain, err := s.TaskMgr.ListTaskList(&p.ListTaskListRequest{DomainID: domainID, PageSize: 10})
+	s.NoError(err)
+	s.Equal(len(taskLists), len(byDomain.Items))
+	for _, info := range byDomain.Items {
+		s.Equal(domainID, info.DomainID)
+		_, ok := taskLists[info.Name]
+		s.True(ok)
+	}
+
+	info := leased["list-delete-1"]
+	err = s.TaskMgr.DeleteTaskList(&p.DeleteTaskListRequest{
+		DomainID:     domainID,
+		TaskListName: info.Name,
+		TaskListType: info.TaskType,
+		RangeID:      info.RangeID + 1,
+	})
+	s.Error(err)
+	s.IsType(&p.ConditionFailedError{}, err)
+
+	err = s.TaskMgr.DeleteTaskList(&p.DeleteTaskListRequest{
+		DomainID:     domainID,
+		TaskListName: info.Name,
+		TaskListType: info.TaskType,
+		RangeID:      info.RangeID,
+	})
+	s.NoError(err)
+
+	byDomain, err = s.TaskMgr.ListTaskList(&p.ListTaskListRequest{DomainID: domainID, PageSize: 10})
+	s.NoError(err)
+	s.Equal(len(taskLists)-1, len(byDomain.Items))
+	for _, info := range byDomain.Items {
+		s.NotEqual("list-delete-1", info.Name)
+	}
+
+	resp, err := s.TaskMgr.LeaseTaskList(&p.LeaseTaskListRequest{
+		DomainID: domainID,
+		TaskList: info.Name,
+		TaskType: p.TaskListTypeActivity,
+	})
+	s.NoError(err)
+	s.EqualValues(1, resp.TaskListInfo.RangeID)
+}
@@ -28,6 +28,7 @@ import (
 	"sync"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	gen "github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/common/cluster"
@@ -628,7 +629,160 @@ func (m *MetadataPersistenceSuiteV2) TestUpdateDomain() {
 	m.Equal(notificationVersion, resp5.NotificationVersion)
 }
 
+// TestUpdateDomainGracefulFailover test
+func (m *MetadataPersistenceSuiteV2) TestUpdateDomainGracefulFailover() {
+	id := uuid.New()
+	name := "update-domain-graceful-failover-test-name"
+	clusterActive := "some random active cluster name"
+	clusterStandby := "some random standby cluster name"
+	replicationConfig := &p.DomainReplicationConfig{
+		ActiveClusterName: clusterActive,
+		Clusters: []*p.ClusterReplicationConfig{
+			{ClusterName: clusterActive},
+			{ClusterName: clusterStandby},
+		},
+	}
+
+	_, err := m.CreateDomain(
+		&p.DomainInfo{ID: id, Name: name, Status: p.DomainStatusRegistered},
+		&p.DomainConfig{Retention: 10},
+		replicationConfig,
+		true,
+		0,
+		0,
+	)
+	m.NoError(err)
+
+	resp1, err := m.GetDomain(id, "")
+	m.NoError(err)
+	m.Equal("", resp1.PendingActiveClusterName)
+	m.Equal(int64(0), resp1.FailoverEndTime)
+
+	metadata, err := m.MetadataManagerV2.GetMetadata()
+	m.NoError(err)
+	failoverEndTime := time.Now().Add(time.Minute).UnixNano()
+	err = m.MetadataManagerV2.UpdateDomain(&p.UpdateDomainRequest{
+		Info:                        resp1.Info,
+		Config:                      resp1.Config,
+		ReplicationConfig:           resp1.ReplicationConfig,
+		ConfigVersion:               resp1.ConfigVersion,
+		FailoverVersion:             resp1.FailoverVersion,
+		FailoverNotificationVersion: resp1.FailoverNotificationVersion,
+		NotificationVersion:         metadata.NotificationVersion,
+		PendingActiveClusterName:    clusterStandby,
+		FailoverEndTime:             failoverEndTime,
+	})
+	m.NoError(err)
+
+	resp2, err := m.GetDomain("", name)
+	m.NoError(err)
+	m.Equal(clusterActive, resp2.ReplicationConfig.ActiveClusterName)
+	m.Equal(clusterStandby, resp2.PendingActiveClusterName)
+	m.Equal(failoverEndTime, resp2.FailoverEndTime)
+
+	resp3, err := m.ListDomains(10, nil)
+	m.NoError(err)
+	m.Equal(1, len(resp3.Domains))
+	m.Equal(clusterStandby, resp3.Domains[0].PendingActiveClusterName)
+	m.Equal(failoverEndTime, resp3.Domains[0].FailoverEndTime)
+}
+
+// TestUpdateDomainFailoverHistory test
+func (m *MetadataPersistenceSuiteV2) TestUpdateDomainFailoverHistory() {
+	id := uuid.New()
+	name := "update-domain-failover-history-test-name"
+	clusterActive := "some random active cluster name"
+	clusterStandby := "some random standby cluster name"
+	firstFailover := &p.DomainFailoverEvent{
+		FailoverTime:    time.Now().Add(-time.Hour).UnixNano(),
+		FromCluster:     clusterStandby,
+		ToCluster:       clusterActive,
+		FailoverVersion: 10,
+		Identity:        "some random identity",
+		Reason:          "some random reason",
+	}
+
+	_, err := m.MetadataManagerV2.CreateDomain(&p.CreateDomainRequest{
+		Info:   &p.DomainInfo{ID: id, Name: name, Status: p.DomainStatusRegistered},
+		Config: &p.DomainConfig{Retention: 10},
+		ReplicationConfig: &p.DomainReplicationConfig{
+			ActiveClusterName: clusterActive,
+			Clusters: []*p.ClusterReplicationConfig{
+				{ClusterName: clusterActive},
+				{ClusterName: clusterStandby},
+			},
+		},
+		IsGlobalDomain:  true,
+		FailoverVersion: 10,
+		FailoverHistory: []*p.DomainFailoverEvent{firstFailover},
+	})
+	m.NoError(err)
+
+	resp1, err := m.GetDomain(id, "")
+	m.NoError(err)
+	m.Equal([]*p.DomainFailoverEvent{firstFailover}, resp1.FailoverHistory)
+
+	secondFailover := &p.DomainFailoverEvent{
+		FailoverTime:    time.Now().UnixNano(),
+		FromCluster:     clusterActive,
+		ToCluster:       clusterStandby,
+		FailoverVersion: 11,
+		Identity:        "another random identity",
+	}
+	metadata, err := m.MetadataManagerV2.GetMetadata()
+	m.NoError(err)
+	resp1.ReplicationConfig.ActiveClusterName = clusterStandby
+	err = m.MetadataManagerV2.UpdateDomain(&p.UpdateDomainRequest{
+		Info:                        resp1.Info,
+		Config:                      resp1.Config,
+		ReplicationConfig:           resp1.ReplicationConfig,
+		ConfigVersion:               resp1.ConfigVersion,
+		FailoverVersion:             secondFailover.FailoverVersion,
+		FailoverNotificationVersion: metadata.NotificationVersion,
+		NotificationVersion:         metadata.NotificationVersion,
+		FailoverHistory:             append(resp1.FailoverHistory, secondFailover),
+	})
+	m.NoError(err)
+
+	resp2, err := m.GetDomain("", name)
+	m.NoError(err)
+	m.Equal([]*p.DomainFailoverEvent{firstFailover, secondFailover}, resp2.FailoverHistory)
+
+	resp3, err := m.ListDomains(10, nil)
+	m.NoError(err)
+	m.Equal(1, len(resp3.Domains))
+	m.Equal([]*p.DomainFailoverEvent{firstFailover, secondFailover}, resp3.Domains[0].FailoverHistory)
+}
+
 // TestDeleteDomain test
+func (m *MetadataPersistenceSuiteV2) TestUpsertCluster() {
+	cluster := &p.ClusterInfo{
+		ClusterName:            "upsert-cluster-test-name",
+		InitialFailoverVersion: 7,
+		Enabled:                true,
+		RPCName:                "cadence-frontend",
+		RPCAddress:             "127.0.0.1:7933",
+		ReplicationTopic:       "some random replication topic",
+	}
+	err := m.MetadataManagerV2.UpsertCluster(&p.UpsertClusterRequest{Cluster: cluster})
+	m.NoError(err)
+
+	resp, err := m.MetadataManagerV2.ListClusters()
+	m.NoError(err)
+	m.Contains(resp.Clusters, cluster)
+
+	updated := *cluster
+	updated.Enabled = false
+	updated.RPCAddress = "127.0.0.1:8933"
+	err = m.MetadataManagerV2.UpsertCluster(&p.UpsertClusterRequest{Cluster: &updated})
+	m.NoError(err)
+
+	resp, err = m.MetadataManagerV2.ListClusters()
+	m.NoError(err)
+	m.Contains(resp.Clusters, &updated)
+	m.NotContains(resp.Clusters, cluster)
+}
+
 func (m *MetadataPersistenceSuiteV2) TestDeleteDomain() {
 	id := uuid.New()
 	name := "delete-domain-test-name"
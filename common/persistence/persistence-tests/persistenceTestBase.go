@@ -447,6 +447,32 @@ func (s *TestBase) UpsertSignalsRequestedState(updatedInfo *p.WorkflowExecutionI
 		nil, nil, upsertSignalsRequested, "")
 }
 
+// UpsertAcceptedUpdatesState is a utility method to add accepted updates to mutable state
+func (s *TestBase) UpsertAcceptedUpdatesState(updatedInfo *p.WorkflowExecutionInfo, condition int64,
+	upsertAcceptedUpdates map[string]int64) error {
+	_, err := s.ExecutionManager.UpdateWorkflowExecution(&p.UpdateWorkflowExecutionRequest{
+		ExecutionInfo:         updatedInfo,
+		Condition:             condition,
+		RangeID:               s.ShardInfo.RangeID,
+		UpsertAcceptedUpdates: upsertAcceptedUpdates,
+		Encoding:              pickRandomEncoding(),
+	})
+	return err
+}
+
+// DeleteAcceptedUpdatesState is a utility method to delete accepted updates from mutable state
+func (s *TestBase) DeleteAcceptedUpdatesState(updatedInfo *p.WorkflowExecutionInfo, condition int64,
+	deleteAcceptedUpdates []string) error {
+	_, err := s.ExecutionManager.UpdateWorkflowExecution(&p.UpdateWorkflowExecutionRequest{
+		ExecutionInfo:         updatedInfo,
+		Condition:             condition,
+		RangeID:               s.ShardInfo.RangeID,
+		DeleteAcceptedUpdates: deleteAcceptedUpdates,
+		Encoding:              pickRandomEncoding(),
+	})
+	return err
+}
+
 // DeleteChildExecutionsState is a utility method to delete child execution from mutable state
 func (s *TestBase) DeleteChildExecutionsState(updatedInfo *p.WorkflowExecutionInfo, condition int64,
 	deleteChildInfo int64) error {
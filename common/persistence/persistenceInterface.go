@@ -54,6 +54,7 @@ type (
 
 		CreateWorkflowExecution(request *CreateWorkflowExecutionRequest) (*CreateWorkflowExecutionResponse, error)
 		DeleteWorkflowExecution(request *DeleteWorkflowExecutionRequest) error
+		DeleteCurrentWorkflowExecution(request *DeleteCurrentWorkflowExecutionRequest) error
 		GetCurrentExecution(request *GetCurrentExecutionRequest) (*GetCurrentExecutionResponse, error)
 
 		// Transfer task related methods
@@ -147,6 +148,7 @@ type (
 		RequestCancelInfos       map[int64]*RequestCancelInfo
 		SignalInfos              map[int64]*SignalInfo
 		SignalRequestedIDs       map[string]struct{}
+		AcceptedUpdates          map[string]int64
 		ExecutionInfo            *InternalWorkflowExecutionInfo
 		ReplicationState         *ReplicationState
 		BufferedEvents           []*DataBlob
@@ -159,6 +161,7 @@ type (
 		ScheduleID               int64
 		ScheduledEvent           *DataBlob
 		ScheduledTime            time.Time
+		RedirectedTime           time.Time
 		StartedID                int64
 		StartedEvent             *DataBlob
 		StartedTime              time.Time
@@ -235,6 +238,8 @@ type (
 		DeleteSignalInfo              *int64
 		UpsertSignalRequestedIDs      []string
 		DeleteSignalRequestedID       string
+		UpsertAcceptedUpdates         map[string]int64
+		DeleteAcceptedUpdates         []string
 		NewBufferedEvents             *DataBlob
 		ClearBufferedEvents           bool
 		NewBufferedReplicationTask    *InternalBufferedReplicationTask
@@ -256,6 +261,7 @@ type (
 		InsertRequestCancelInfos  []*RequestCancelInfo
 		InsertSignalInfos         []*SignalInfo
 		InsertSignalRequestedIDs  []string
+		InsertAcceptedUpdates     map[string]int64
 	}
 
 	// InternalAppendHistoryEventsRequest is used to append new events to workflow execution history  for Persistence Interface
@@ -290,6 +296,9 @@ type (
 		PageSize int
 		// Token to continue reading next page of history append transactions.  Pass in empty slice for first page
 		NextPageToken []byte
+		// Reverse reads the batches in descending order of first event ID without filtering them by version.
+		// Reverse reads are not paged by the store, callers narrow NextEventID instead
+		Reverse bool
 	}
 
 	// InternalGetWorkflowExecutionHistoryResponse is the response to GetWorkflowExecutionHistoryRequest for Persistence Interface
@@ -300,6 +309,8 @@ type (
 		NextPageToken []byte
 		// an extra field passing to DataInterface
 		LastEventBatchVersion int64
+		// the event batch version of each of the batches in History
+		EventBatchVersions []int64
 	}
 )
 
@@ -80,6 +80,7 @@ type (
 		LastWriteVersion             *int64
 		LastWriteEventID             *int64
 		LastReplicationInfo          *[]byte
+		VersionHistory               *[]byte
 		LastFirstEventID             int64
 		NextEventID                  int64
 		LastProcessedEvent           int64
@@ -225,8 +226,8 @@ cancel_request_id`
 	executionsCancelColumnsTags = `:cancel_requested,
 :cancel_request_id`
 
-	executionsReplicationStateColumns     = `start_version, current_version, last_write_version, last_write_event_id, last_replication_info`
-	executionsReplicationStateColumnsTags = `:start_version, :current_version, :last_write_version, :last_write_event_id, :last_replication_info`
+	executionsReplicationStateColumns     = `start_version, current_version, last_write_version, last_write_event_id, last_replication_info, version_history`
+	executionsReplicationStateColumnsTags = `:start_version, :current_version, :last_write_version, :last_write_event_id, :last_replication_info, :version_history`
 
 	createExecutionSQLQuery = `INSERT INTO executions
 (` + executionsNonNullableColumns + `,` +
@@ -287,7 +288,8 @@ start_version = :start_version,
 current_version = :current_version,
 last_write_version = :last_write_version,
 last_write_event_id = :last_write_event_id,
-last_replication_info = :last_replication_info
+last_replication_info = :last_replication_info,
+version_history = :version_history
 WHERE
 shard_id = :shard_id AND
 domain_id = :domain_id AND
@@ -305,6 +307,12 @@ run_id = :run_id
 shard_id = ? AND
 domain_id = ? AND
 workflow_id = ? AND
+run_id = ?`
+
+	deleteCurrentExecutionSQLQuery = `DELETE FROM current_executions WHERE
+shard_id = ? AND
+domain_id = ? AND
+workflow_id = ? AND
 run_id = ?`
 
 	deleteExecutionSQLQuery = `DELETE FROM executions WHERE
@@ -652,6 +660,13 @@ func (m *sqlExecutionManager) GetWorkflowExecution(request *p.GetWorkflowExecuti
 			}
 		}
 	}
+	if execution.VersionHistory != nil {
+		if err := gobDeserialize(*execution.VersionHistory, &state.ReplicationState.VersionHistory); err != nil {
+			return nil, &workflow.InternalServiceError{
+				Message: fmt.Sprintf("GetWorkflowExecution failed. Failed to deserialize VersionHistory. Error: %v", err),
+			}
+		}
+	}
 
 	if execution.ParentDomainID != nil {
 		state.ExecutionInfo.ParentDomainID = *execution.ParentDomainID
@@ -766,6 +781,20 @@ func (m *sqlExecutionManager) GetWorkflowExecution(request *p.GetWorkflowExecuti
 		}
 	}
 
+	{
+		var err error
+		state.AcceptedUpdates, err = getAcceptedUpdates(tx,
+			m.shardID,
+			request.DomainID,
+			*request.Execution.WorkflowId,
+			*request.Execution.RunId)
+		if err != nil {
+			return nil, &workflow.InternalServiceError{
+				Message: fmt.Sprintf("GetWorkflowExecution failed. Failed to get accepted updates. Error: %v", err),
+			}
+		}
+	}
+
 	return &p.GetWorkflowExecutionResponse{State: &state}, nil
 }
 
@@ -933,6 +962,18 @@ func (m *sqlExecutionManager) UpdateWorkflowExecution(request *p.UpdateWorkflowE
 		}
 	}
 
+	if err := updateAcceptedUpdates(tx,
+		request.UpsertAcceptedUpdates,
+		request.DeleteAcceptedUpdates,
+		m.shardID,
+		request.ExecutionInfo.DomainID,
+		request.ExecutionInfo.WorkflowID,
+		request.ExecutionInfo.RunID); err != nil {
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("UpdateWorkflowExecution operation failed. Error: %v", err),
+		}
+	}
+
 	if request.ContinueAsNew != nil {
 		if err := createCurrentExecution(tx, request.ContinueAsNew, m.shardID); err != nil {
 			return err
@@ -1163,6 +1204,28 @@ func (m *sqlExecutionManager) ResetMutableState(request *p.ResetMutableStateRequ
 		}
 	}
 
+	if err := deleteAcceptedUpdatesMap(tx,
+		m.shardID,
+		request.ExecutionInfo.DomainID,
+		request.ExecutionInfo.WorkflowID,
+		request.ExecutionInfo.RunID); err != nil {
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("ResetMutableState operation failed. Failed to clear accepted updates map. Error: %v", err),
+		}
+	}
+
+	if err := updateAcceptedUpdates(tx,
+		request.InsertAcceptedUpdates,
+		nil,
+		m.shardID,
+		request.ExecutionInfo.DomainID,
+		request.ExecutionInfo.WorkflowID,
+		request.ExecutionInfo.RunID); err != nil {
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("ResetMutableState operation failed. Failed to insert into accepted updates map after clearing. Error: %v", err),
+		}
+	}
+
 	if err := lockShard(tx, m.shardID, request.RangeID); err != nil {
 		switch err.(type) {
 		case *p.ShardOwnershipLostError:
@@ -1192,6 +1255,15 @@ func (m *sqlExecutionManager) DeleteWorkflowExecution(request *p.DeleteWorkflowE
 	return nil
 }
 
+func (m *sqlExecutionManager) DeleteCurrentWorkflowExecution(request *p.DeleteCurrentWorkflowExecutionRequest) error {
+	if _, err := m.db.Exec(deleteCurrentExecutionSQLQuery, m.shardID, request.DomainID, request.WorkflowID, request.RunID); err != nil {
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("DeleteCurrentWorkflowExecution operation failed. Error: %v", err),
+		}
+	}
+	return nil
+}
+
 func (m *sqlExecutionManager) GetCurrentExecution(request *p.GetCurrentExecutionRequest) (*p.GetCurrentExecutionResponse, error) {
 	var row currentExecutionRow
 	if err := m.db.Get(&row, getCurrentExecutionSQLQuery, m.shardID, request.DomainID, request.WorkflowID); err != nil {
@@ -1387,6 +1459,14 @@ func createExecution(tx *sqlx.Tx, request *p.CreateWorkflowExecutionRequest, sha
 			}
 		}
 		args.LastReplicationInfo = &lastReplicationInfo
+
+		versionHistory, err := gobSerialize(&request.ReplicationState.VersionHistory)
+		if err != nil {
+			return &workflow.InternalServiceError{
+				Message: fmt.Sprintf("CreateWorkflowExecution operation failed. Failed to serialize VersionHistory. Error: %v", err),
+			}
+		}
+		args.VersionHistory = &versionHistory
 	}
 
 	if request.ParentExecution != nil {
@@ -1894,6 +1974,13 @@ func updateExecution(tx *sqlx.Tx,
 			}
 		}
 		args.LastReplicationInfo = &lastReplicationInfo
+		versionHistory, err := gobSerialize(&replicationState.VersionHistory)
+		if err != nil {
+			return &workflow.InternalServiceError{
+				Message: fmt.Sprintf("CreateWorkflowExecution operation failed. Failed to serialize VersionHistory. Error: %v", err),
+			}
+		}
+		args.VersionHistory = &versionHistory
 	}
 
 	if executionInfo.ParentDomainID != "" {
@@ -86,6 +86,11 @@ first_event_id = :first_event_id`
 		`WHERE domain_id = ? AND workflow_id = ? AND run_id = ? AND first_event_id >= ? AND first_event_id < ? ` +
 		`ORDER BY first_event_id LIMIT ?`
 
+	getWorkflowExecutionHistoryReverseSQLQuery = `SELECT first_event_id, batch_version, data, data_encoding ` +
+		`FROM events ` +
+		`WHERE domain_id = ? AND workflow_id = ? AND run_id = ? AND first_event_id >= ? AND first_event_id < ? ` +
+		`ORDER BY first_event_id DESC LIMIT ?`
+
 	deleteWorkflowExecutionHistorySQLQuery = `DELETE FROM events WHERE domain_id = ? AND workflow_id = ? AND run_id = ?`
 
 	lockEventSQLQuery = `SELECT range_id, tx_id FROM events ` +
@@ -132,6 +137,10 @@ func (m *sqlHistoryManager) AppendHistoryEvents(request *p.InternalAppendHistory
 func (m *sqlHistoryManager) GetWorkflowExecutionHistory(request *p.InternalGetWorkflowExecutionHistoryRequest) (
 	*p.InternalGetWorkflowExecutionHistoryResponse, error) {
 
+	if request.Reverse {
+		return m.getWorkflowExecutionHistoryReverse(request)
+	}
+
 	token := newHistoryPageToken(request.FirstEventID - 1)
 	if request.NextPageToken != nil && len(request.NextPageToken) > 0 {
 		if err := token.deserialize(request.NextPageToken); err != nil {
@@ -163,6 +172,7 @@ func (m *sqlHistoryManager) GetWorkflowExecutionHistory(request *p.InternalGetWo
 	}
 
 	history := make([]*p.DataBlob, 0)
+	eventBatchVersions := make([]int64, 0)
 	lastEventBatchVersion := request.LastEventBatchVersion
 
 	for _, v := range rows {
@@ -175,6 +185,7 @@ func (m *sqlHistoryManager) GetWorkflowExecutionHistory(request *p.InternalGetWo
 		}
 		if eventBatchVersion >= lastEventBatchVersion {
 			history = append(history, eventBatch)
+			eventBatchVersions = append(eventBatchVersions, eventBatchVersion)
 			lastEventBatchVersion = eventBatchVersion
 		}
 		token.LastEventID = v.FirstEventID
@@ -183,10 +194,54 @@ func (m *sqlHistoryManager) GetWorkflowExecutionHistory(request *p.InternalGetWo
 	return &p.InternalGetWorkflowExecutionHistoryResponse{
 		History:               history,
 		LastEventBatchVersion: lastEventBatchVersion,
+		EventBatchVersions:    eventBatchVersions,
 		NextPageToken:         token.serialize(),
 	}, nil
 }
 
+func (m *sqlHistoryManager) getWorkflowExecutionHistoryReverse(request *p.InternalGetWorkflowExecutionHistoryRequest) (
+	*p.InternalGetWorkflowExecutionHistoryResponse, error) {
+
+	var rows []eventsRow
+	err := m.db.Select(&rows, getWorkflowExecutionHistoryReverseSQLQuery,
+		request.DomainID,
+		request.Execution.WorkflowId,
+		request.Execution.RunId,
+		request.FirstEventID,
+		request.NextEventID,
+		request.PageSize)
+
+	if err == sql.ErrNoRows || (err == nil && len(rows) == 0) {
+		return nil, &workflow.EntityNotExistsError{
+			Message: fmt.Sprintf("Workflow execution history not found.  WorkflowId: %v, RunId: %v",
+				*request.Execution.WorkflowId, *request.Execution.RunId),
+		}
+	}
+
+	if err != nil {
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("GetWorkflowExecutionHistory: %v", err),
+		}
+	}
+
+	history := make([]*p.DataBlob, 0, len(rows))
+	eventBatchVersions := make([]int64, 0, len(rows))
+	for _, v := range rows {
+		eventBatchVersion := common.EmptyVersion
+		if v.BatchVersion > 0 {
+			eventBatchVersion = v.BatchVersion
+		}
+		history = append(history, &p.DataBlob{Data: v.Data, Encoding: common.EncodingType(v.DataEncoding)})
+		eventBatchVersions = append(eventBatchVersions, eventBatchVersion)
+	}
+
+	return &p.InternalGetWorkflowExecutionHistoryResponse{
+		History:               history,
+		LastEventBatchVersion: request.LastEventBatchVersion,
+		EventBatchVersions:    eventBatchVersions,
+	}, nil
+}
+
 func (m *sqlHistoryManager) DeleteWorkflowExecutionHistory(request *p.DeleteWorkflowExecutionHistoryRequest) error {
 	if _, err := m.db.Exec(deleteWorkflowExecutionHistorySQLQuery, request.DomainID, request.Execution.WorkflowId, request.Execution.RunId); err != nil {
 		return &workflow.InternalServiceError{
@@ -58,12 +58,15 @@ type (
 		Clusters          *[]byte
 		ConfigVersion     int64
 		FailoverVersion   int64
+		FailoverHistory   *[]byte
 	}
 
 	flatUpdateDomainRequest struct {
 		domainCommon
 		FailoverNotificationVersion int64
 		NotificationVersion         int64
+		PendingActiveClusterName    string
+		FailoverEndTime             int64
 	}
 
 	domainRow struct {
@@ -71,6 +74,8 @@ type (
 		FailoverNotificationVersion int64
 		NotificationVersion         int64
 		IsGlobalDomain              bool
+		PendingActiveClusterName    string
+		FailoverEndTime             int64
 	}
 )
 
@@ -90,7 +95,8 @@ const (
 		clusters, 
 		notification_version,
 		failover_notification_version,
-		data
+		data,
+		failover_history
 		)
 		VALUES(
 		:id,
@@ -107,7 +113,8 @@ const (
 		:clusters,
 		:notification_version,
 		:failover_notification_version,
-		:data
+		:data,
+		:failover_history
 		)`
 
 	getDomainPart = `SELECT
@@ -125,7 +132,10 @@ const (
 		clusters,
 		notification_version,
 		failover_notification_version,
-		data
+		data,
+		pending_active_cluster_name,
+		failover_end_time,
+		failover_history
 FROM domains
 `
 	getDomainByIDSQLQuery = getDomainPart +
@@ -146,7 +156,10 @@ SET
 		clusters = :clusters,
 		notification_version = :notification_version,
 		failover_notification_version = :failover_notification_version,
-		data = :data
+		data = :data,
+		pending_active_cluster_name = :pending_active_cluster_name,
+		failover_end_time = :failover_end_time,
+		failover_history = :failover_history
 WHERE
 name = :name AND
 id = :id`
@@ -161,6 +174,15 @@ id = :id`
 	updateMetadataSQLQuery = `UPDATE domain_metadata
 SET notification_version = :notification_version + 1 
 WHERE notification_version = :notification_version`
+
+	listClustersSQLQuery = `SELECT cluster_name, initial_failover_version, enabled, rpc_name, rpc_address, replication_topic
+FROM clusters`
+
+	upsertClusterSQLQuery = `INSERT INTO clusters (
+		cluster_name, initial_failover_version, enabled, rpc_name, rpc_address, replication_topic)
+		VALUES(:cluster_name, :initial_failover_version, :enabled, :rpc_name, :rpc_address, :replication_topic)
+		ON DUPLICATE KEY UPDATE initial_failover_version = VALUES(initial_failover_version), enabled = VALUES(enabled),
+		rpc_name = VALUES(rpc_name), rpc_address = VALUES(rpc_address), replication_topic = VALUES(replication_topic)`
 )
 
 func (m *sqlMetadataManagerV2) Close() {
@@ -236,6 +258,13 @@ func (m *sqlMetadataManagerV2) CreateDomain(request *persistence.CreateDomainReq
 		}
 	}
 
+	failoverHistory, err := gobSerialize(persistence.SerializeDomainFailoverHistory(request.FailoverHistory))
+	if err != nil {
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("CreateDomain operation failed. Failed to encode FailoverHistory. Error: %v", err),
+		}
+	}
+
 	metadata, err := m.GetMetadata()
 	if err != nil {
 		return nil, err
@@ -259,6 +288,7 @@ func (m *sqlMetadataManagerV2) CreateDomain(request *persistence.CreateDomainReq
 
 				ConfigVersion:   request.ConfigVersion,
 				FailoverVersion: request.FailoverVersion,
+				FailoverHistory: &failoverHistory,
 			},
 
 			NotificationVersion:         metadata.NotificationVersion,
@@ -355,6 +385,15 @@ func (m *sqlMetadataManagerV2) domainRowToGetDomainResponse(result *domainRow) (
 		}
 	}
 
+	var failoverHistory []map[string]interface{}
+	if result.FailoverHistory != nil {
+		if err := gobDeserialize(*result.FailoverHistory, &failoverHistory); err != nil {
+			return nil, &workflow.InternalServiceError{
+				Message: fmt.Sprintf("Error in deserializing FailoverHistory. Error: %v", err),
+			}
+		}
+	}
+
 	return &persistence.GetDomainResponse{
 		Info: &persistence.DomainInfo{
 			ID:          result.ID,
@@ -374,6 +413,9 @@ func (m *sqlMetadataManagerV2) domainRowToGetDomainResponse(result *domainRow) (
 		ConfigVersion:               result.ConfigVersion,
 		NotificationVersion:         result.NotificationVersion,
 		FailoverNotificationVersion: result.FailoverNotificationVersion,
+		PendingActiveClusterName:    result.PendingActiveClusterName,
+		FailoverEndTime:             result.FailoverEndTime,
+		FailoverHistory:             persistence.DeserializeDomainFailoverHistory(failoverHistory),
 	}, nil
 }
 
@@ -392,6 +434,13 @@ func (m *sqlMetadataManagerV2) UpdateDomain(request *persistence.UpdateDomainReq
 		}
 	}
 
+	failoverHistory, err := gobSerialize(persistence.SerializeDomainFailoverHistory(request.FailoverHistory))
+	if err != nil {
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("UpdateDomain operation failed. Failed to encode FailoverHistory. Value: %v", request.FailoverHistory),
+		}
+	}
+
 	return runTransaction("UpdateDomain", m.db, func(tx *sqlx.Tx) error {
 		result, err := tx.NamedExec(updateDomainSQLQuery, &flatUpdateDomainRequest{
 			domainCommon: domainCommon{
@@ -408,9 +457,12 @@ func (m *sqlMetadataManagerV2) UpdateDomain(request *persistence.UpdateDomainReq
 				Clusters:          &clusters,
 				ConfigVersion:     request.ConfigVersion,
 				FailoverVersion:   request.FailoverVersion,
+				FailoverHistory:   &failoverHistory,
 			},
 			FailoverNotificationVersion: request.FailoverNotificationVersion,
 			NotificationVersion:         request.NotificationVersion,
+			PendingActiveClusterName:    request.PendingActiveClusterName,
+			FailoverEndTime:             request.FailoverEndTime,
 		})
 		if err != nil {
 			return err
@@ -454,6 +506,25 @@ func (m *sqlMetadataManagerV2) GetMetadata() (*persistence.GetMetadataResponse,
 	return &persistence.GetMetadataResponse{NotificationVersion: notificationVersion}, nil
 }
 
+func (m *sqlMetadataManagerV2) ListClusters() (*persistence.ListClustersResponse, error) {
+	var clusters []*persistence.ClusterInfo
+	if err := m.db.Select(&clusters, listClustersSQLQuery); err != nil {
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("ListClusters operation failed. Error: %v", err),
+		}
+	}
+	return &persistence.ListClustersResponse{Clusters: clusters}, nil
+}
+
+func (m *sqlMetadataManagerV2) UpsertCluster(request *persistence.UpsertClusterRequest) error {
+	if _, err := m.db.NamedExec(upsertClusterSQLQuery, request.Cluster); err != nil {
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("UpsertCluster operation failed. Error: %v", err),
+		}
+	}
+	return nil
+}
+
 func (m *sqlMetadataManagerV2) ListDomains(request *persistence.ListDomainsRequest) (*persistence.ListDomainsResponse, error) {
 	rows, err := m.db.Queryx(listDomainsSQLQuery)
 	if err != nil {
@@ -50,6 +50,7 @@ type (
 		TimerAckLevel             time.Time
 		ClusterTransferAckLevel   []byte
 		ClusterTimerAckLevel      []byte
+		ClusterReplicationLevel   []byte
 		DomainNotificationVersion int64
 	}
 )
@@ -66,6 +67,7 @@ transfer_ack_level,
 timer_ack_level,
 cluster_transfer_ack_level,
 cluster_timer_ack_level,
+cluster_replication_level,
 domain_notification_version)
 VALUES
 (:shard_id, 
@@ -78,6 +80,7 @@ VALUES
 :timer_ack_level,
 :cluster_transfer_ack_level,
 :cluster_timer_ack_level,
+:cluster_replication_level,
 :domain_notification_version)`
 
 	getShardSQLQuery = `SELECT
@@ -91,6 +94,7 @@ transfer_ack_level,
 timer_ack_level,
 cluster_transfer_ack_level,
 cluster_timer_ack_level,
+cluster_replication_level,
 domain_notification_version
 FROM shards WHERE
 shard_id = ?
@@ -109,6 +113,7 @@ transfer_ack_level = :transfer_ack_level,
 timer_ack_level = :timer_ack_level,
 cluster_transfer_ack_level = :cluster_transfer_ack_level,
 cluster_timer_ack_level = :cluster_timer_ack_level,
+cluster_replication_level = :cluster_replication_level,
 domain_notification_version = :domain_notification_version
 WHERE
 shard_id = :shard_id
@@ -199,6 +204,13 @@ func (m *sqlShardManager) GetShard(request *persistence.GetShardRequest) (*persi
 		}
 	}
 
+	clusterReplicationLevel := make(map[string]int64)
+	if err := gobDeserialize(row.ClusterReplicationLevel, &clusterReplicationLevel); err != nil {
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("GetShard operation failed. Failed to deserialize ShardInfo.ClusterReplicationLevel. ShardId: %v. Error: %v", request.ShardID, err),
+		}
+	}
+
 	resp := &persistence.GetShardResponse{ShardInfo: &persistence.ShardInfo{
 		ShardID:                   int(row.ShardID),
 		Owner:                     row.Owner,
@@ -210,6 +222,7 @@ func (m *sqlShardManager) GetShard(request *persistence.GetShardRequest) (*persi
 		TimerAckLevel:             row.TimerAckLevel,
 		ClusterTransferAckLevel:   clusterTransferAckLevel,
 		ClusterTimerAckLevel:      clusterTimerAckLevel,
+		ClusterReplicationLevel:   clusterReplicationLevel,
 		DomainNotificationVersion: row.DomainNotificationVersion,
 	}}
 
@@ -283,6 +296,13 @@ func shardInfoToShardsRow(s persistence.ShardInfo) (*shardsRow, error) {
 		}
 	}
 
+	clusterReplicationLevel, err := gobSerialize(s.ClusterReplicationLevel)
+	if err != nil {
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("CreateShard operation failed. Failed to serialize ShardInfo.ClusterReplicationLevel. Error: %v", err),
+		}
+	}
+
 	return &shardsRow{
 		ShardID:                   int64(s.ShardID),
 		Owner:                     s.Owner,
@@ -294,6 +314,7 @@ func shardInfoToShardsRow(s persistence.ShardInfo) (*shardsRow, error) {
 		TimerAckLevel:             s.TimerAckLevel,
 		ClusterTransferAckLevel:   clusterTransferAckLevel,
 		ClusterTimerAckLevel:      clusterTimerAckLevel,
+		ClusterReplicationLevel:   clusterReplicationLevel,
 		DomainNotificationVersion: s.DomainNotificationVersion,
 	}, nil
 }
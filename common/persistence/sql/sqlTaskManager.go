@@ -47,27 +47,36 @@ type (
 		TaskListName string
 		TaskListType int64
 		ExpiryTs     time.Time
+		FairnessKey  string
 	}
 
 	tasksListsRow struct {
-		DomainID string
-		RangeID  int64
-		Name     string
-		TaskType int64
-		AckLevel int64
-		Kind     int64
-		ExpiryTs time.Time
+		DomainID    string
+		RangeID     int64
+		Name        string
+		TaskType    int64
+		AckLevel    int64
+		Kind        int64
+		LastUpdated time.Time
+		ExpiryTs    time.Time
+		Released    bool
 	}
 
 	updateTaskListsRow struct {
 		tasksListsRow
 		OldRangeID int64
 	}
+
+	taskListPageToken struct {
+		DomainID string
+		Name     string
+		TaskType int64
+	}
 )
 
 const (
-	taskListCreatePart = `INTO task_lists(domain_id, range_id, name, task_type, ack_level, kind, expiry_ts) ` +
-		`VALUES (:domain_id, :range_id, :name, :task_type, :ack_level, :kind, :expiry_ts)`
+	taskListCreatePart = `INTO task_lists(domain_id, range_id, name, task_type, ack_level, kind, last_updated, expiry_ts, released) ` +
+		`VALUES (:domain_id, :range_id, :name, :task_type, :ack_level, :kind, :last_updated, :expiry_ts, :released)`
 
 	// (default range ID: initialRangeID == 1)
 	createTaskListSQLQuery = `INSERT ` + taskListCreatePart
@@ -81,30 +90,49 @@ name = :name,
 task_type = :task_type,
 ack_level = :ack_level,
 kind = :kind,
-expiry_ts = :expiry_ts
+last_updated = :last_updated,
+expiry_ts = :expiry_ts,
+released = :released
 WHERE
 domain_id = :domain_id AND
 name = :name AND
 task_type = :task_type
 `
 
-	getTaskListSQLQuery = `SELECT domain_id, range_id, name, task_type, ack_level, kind, expiry_ts ` +
+	getTaskListSQLQuery = `SELECT domain_id, range_id, name, task_type, ack_level, kind, last_updated, expiry_ts, released ` +
 		`FROM task_lists ` +
 		`WHERE domain_id = ? AND name = ? AND task_type = ?`
 
+	listTaskListSQLQuery = `SELECT domain_id, range_id, name, task_type, ack_level, kind, last_updated, expiry_ts, released ` +
+		`FROM task_lists ` +
+		`WHERE (domain_id, name, task_type) > (?, ?, ?) ` +
+		`ORDER BY domain_id, name, task_type LIMIT ?`
+
+	listTaskListByDomainSQLQuery = `SELECT domain_id, range_id, name, task_type, ack_level, kind, last_updated, expiry_ts, released ` +
+		`FROM task_lists ` +
+		`WHERE domain_id = ? AND (name, task_type) > (?, ?) ` +
+		`ORDER BY name, task_type LIMIT ?`
+
+	deleteTaskListSQLQuery = `DELETE FROM task_lists ` +
+		`WHERE domain_id = ? AND name = ? AND task_type = ? AND range_id = ?`
+
 	lockTaskListSQLQuery = `SELECT range_id FROM task_lists ` +
 		`WHERE domain_id = ? AND name = ? AND task_type = ? FOR UPDATE`
 
-	getTaskSQLQuery = `SELECT workflow_id, run_id, schedule_id, task_id ` +
+	getTaskSQLQuery = `SELECT workflow_id, run_id, schedule_id, task_id, expiry_ts, fairness_key ` +
 		`FROM tasks ` +
 		`WHERE domain_id = ? AND task_list_name = ? AND task_list_type = ? AND task_id > ? AND task_id <= ?`
 
 	createTaskSQLQuery = `INSERT INTO ` +
-		`tasks(domain_id, workflow_id, run_id, schedule_id, task_list_name, task_list_type, task_id, expiry_ts) ` +
-		`VALUES(:domain_id, :workflow_id, :run_id, :schedule_id, :task_list_name, :task_list_type, :task_id, :expiry_ts)`
+		`tasks(domain_id, workflow_id, run_id, schedule_id, task_list_name, task_list_type, task_id, expiry_ts, fairness_key) ` +
+		`VALUES(:domain_id, :workflow_id, :run_id, :schedule_id, :task_list_name, :task_list_type, :task_id, :expiry_ts, :fairness_key)`
 
 	deleteTaskSQLQuery = `DELETE FROM tasks ` +
 		`WHERE domain_id = ? AND task_list_name = ? AND task_list_type = ? AND task_id = ?`
+
+	rangeDeleteTaskSQLQuery = `DELETE FROM tasks ` +
+		`WHERE domain_id = ? AND task_list_name = ? AND task_list_type = ? AND task_id <= ? ` +
+		`ORDER BY domain_id, task_list_name, task_list_type, task_id LIMIT ?`
 )
 
 // NewTaskPersistence creates a new instance of TaskManager
@@ -128,15 +156,17 @@ func (m *sqlTaskManager) LeaseTaskList(request *persistence.LeaseTaskListRequest
 	var row tasksListsRow
 	var rangeID int64
 	var ackLevel int64
+	now := time.Now()
 	if err := m.db.Get(&row, getTaskListSQLQuery, request.DomainID, request.TaskList, request.TaskType); err != nil {
 		if err == sql.ErrNoRows {
 			row = tasksListsRow{
-				DomainID: request.DomainID,
-				Name:     request.TaskList,
-				TaskType: int64(request.TaskType),
-				AckLevel: ackLevel,
-				Kind:     int64(request.TaskListKind),
-				ExpiryTs: time.Time{},
+				DomainID:    request.DomainID,
+				Name:        request.TaskList,
+				TaskType:    int64(request.TaskType),
+				AckLevel:    ackLevel,
+				Kind:        int64(request.TaskListKind),
+				LastUpdated: now,
+				ExpiryTs:    time.Time{},
 			}
 			if _, err := m.db.NamedExec(createTaskListSQLQuery, &row); err != nil {
 				return nil, &workflow.InternalServiceError{
@@ -163,13 +193,14 @@ func (m *sqlTaskManager) LeaseTaskList(request *persistence.LeaseTaskListRequest
 		result, err1 := tx.NamedExec(updateTaskListSQLQuery,
 			&updateTaskListsRow{
 				tasksListsRow{
-					DomainID: row.DomainID,
-					RangeID:  row.RangeID + 1,
-					Name:     row.Name,
-					TaskType: row.TaskType,
-					AckLevel: row.AckLevel,
-					Kind:     row.Kind,
-					ExpiryTs: row.ExpiryTs,
+					DomainID:    row.DomainID,
+					RangeID:     row.RangeID + 1,
+					Name:        row.Name,
+					TaskType:    row.TaskType,
+					AckLevel:    row.AckLevel,
+					Kind:        row.Kind,
+					LastUpdated: now,
+					ExpiryTs:    row.ExpiryTs,
 				},
 				row.RangeID,
 			})
@@ -184,12 +215,13 @@ func (m *sqlTaskManager) LeaseTaskList(request *persistence.LeaseTaskListRequest
 			return fmt.Errorf("%v rows affected instead of 1", rowsAffected)
 		}
 		resp = &persistence.LeaseTaskListResponse{TaskListInfo: &persistence.TaskListInfo{
-			DomainID: request.DomainID,
-			Name:     request.TaskList,
-			TaskType: request.TaskType,
-			RangeID:  rangeID + 1,
-			AckLevel: ackLevel,
-			Kind:     request.TaskListKind,
+			DomainID:    request.DomainID,
+			Name:        request.TaskList,
+			TaskType:    request.TaskType,
+			RangeID:     rangeID + 1,
+			AckLevel:    ackLevel,
+			Kind:        request.TaskListKind,
+			LastUpdated: now,
 		}}
 		return nil
 	})
@@ -197,16 +229,19 @@ func (m *sqlTaskManager) LeaseTaskList(request *persistence.LeaseTaskListRequest
 }
 
 func (m *sqlTaskManager) UpdateTaskList(request *persistence.UpdateTaskListRequest) (*persistence.UpdateTaskListResponse, error) {
+	now := time.Now()
 	if request.TaskListInfo.Kind == persistence.TaskListKindSticky {
 		// If sticky, update with TTL
 		if _, err := m.db.NamedExec(updateTaskListWithTTLSQLQuery, &tasksListsRow{
-			DomainID: request.TaskListInfo.DomainID,
-			RangeID:  request.TaskListInfo.RangeID,
-			Name:     request.TaskListInfo.Name,
-			TaskType: int64(request.TaskListInfo.TaskType),
-			AckLevel: request.TaskListInfo.AckLevel,
-			Kind:     int64(request.TaskListInfo.Kind),
-			ExpiryTs: stickyTaskListTTL(),
+			DomainID:    request.TaskListInfo.DomainID,
+			RangeID:     request.TaskListInfo.RangeID,
+			Name:        request.TaskListInfo.Name,
+			TaskType:    int64(request.TaskListInfo.TaskType),
+			AckLevel:    request.TaskListInfo.AckLevel,
+			Kind:        int64(request.TaskListInfo.Kind),
+			LastUpdated: now,
+			ExpiryTs:    stickyTaskListTTL(),
+			Released:    request.TaskListInfo.Released,
 		}); err != nil {
 			return nil, &workflow.InternalServiceError{
 				Message: fmt.Sprintf("UpdateTaskList operation failed. Failed to make sticky task list. Error: %v", err),
@@ -229,7 +264,9 @@ func (m *sqlTaskManager) UpdateTaskList(request *persistence.UpdateTaskListReque
 					int64(request.TaskListInfo.TaskType),
 					request.TaskListInfo.AckLevel,
 					int64(request.TaskListInfo.Kind),
+					now,
 					time.Time{},
+					request.TaskListInfo.Released,
 				},
 				request.TaskListInfo.RangeID,
 			})
@@ -265,6 +302,7 @@ func (m *sqlTaskManager) CreateTasks(request *persistence.CreateTasksRequest) (*
 			TaskListType: int64(request.TaskListInfo.TaskType),
 			TaskID:       v.TaskID,
 			ExpiryTs:     expiryTime,
+			FairnessKey:  v.Data.FairnessKey,
 		}
 	}
 	var resp *persistence.CreateTasksResponse
@@ -299,11 +337,13 @@ func (m *sqlTaskManager) GetTasks(request *persistence.GetTasksRequest) (*persis
 	var tasks = make([]*persistence.TaskInfo, len(rows))
 	for i, v := range rows {
 		tasks[i] = &persistence.TaskInfo{
-			DomainID:   request.DomainID,
-			WorkflowID: v.WorkflowID,
-			RunID:      v.RunID,
-			TaskID:     v.TaskID,
-			ScheduleID: v.ScheduleID,
+			DomainID:    request.DomainID,
+			WorkflowID:  v.WorkflowID,
+			RunID:       v.RunID,
+			TaskID:      v.TaskID,
+			ScheduleID:  v.ScheduleID,
+			Expiry:      v.ExpiryTs,
+			FairnessKey: v.FairnessKey,
 		}
 	}
 
@@ -321,6 +361,124 @@ func (m *sqlTaskManager) CompleteTask(request *persistence.CompleteTaskRequest)
 	return nil
 }
 
+func (m *sqlTaskManager) CompleteTasksLessThan(request *persistence.CompleteTasksLessThanRequest) (int, error) {
+	result, err := m.db.Exec(rangeDeleteTaskSQLQuery,
+		request.DomainID, request.TaskListName, request.TaskType, request.TaskID, request.Limit)
+	if err != nil {
+		return 0, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("CompleteTasksLessThan operation failed. Error: %v", err),
+		}
+	}
+	nRows, err := result.RowsAffected()
+	if err != nil {
+		return 0, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("CompleteTasksLessThan operation failed. rowsAffected error: %v", err),
+		}
+	}
+	return int(nRows), nil
+}
+
+func (m *sqlTaskManager) GetTaskList(request *persistence.GetTaskListRequest) (*persistence.GetTaskListResponse, error) {
+	var row tasksListsRow
+	if err := m.db.Get(&row, getTaskListSQLQuery, request.DomainID, request.TaskList, request.TaskType); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, &workflow.EntityNotExistsError{
+				Message: fmt.Sprintf("Task list not found. TaskList: %v, TaskType: %v", request.TaskList, request.TaskType),
+			}
+		}
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("GetTaskList operation failed. Error: %v", err),
+		}
+	}
+
+	return &persistence.GetTaskListResponse{TaskListInfo: &persistence.TaskListInfo{
+		DomainID:    row.DomainID,
+		Name:        row.Name,
+		TaskType:    int(row.TaskType),
+		RangeID:     row.RangeID,
+		AckLevel:    row.AckLevel,
+		Kind:        int(row.Kind),
+		LastUpdated: row.LastUpdated,
+		Released:    row.Released,
+	}}, nil
+}
+
+func (m *sqlTaskManager) ListTaskList(request *persistence.ListTaskListRequest) (*persistence.ListTaskListResponse, error) {
+	token := taskListPageToken{TaskType: -1}
+	if len(request.PageToken) > 0 {
+		if err := gobDeserialize(request.PageToken, &token); err != nil {
+			return nil, err
+		}
+	}
+
+	var rows []tasksListsRow
+	var err error
+	if request.DomainID != "" {
+		err = m.db.Select(&rows, listTaskListByDomainSQLQuery,
+			request.DomainID, token.Name, token.TaskType, request.PageSize)
+	} else {
+		err = m.db.Select(&rows, listTaskListSQLQuery,
+			token.DomainID, token.Name, token.TaskType, request.PageSize)
+	}
+	if err != nil {
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("ListTaskList operation failed. Failed to get rows. Error: %v", err),
+		}
+	}
+
+	response := &persistence.ListTaskListResponse{Items: make([]*persistence.TaskListInfo, len(rows))}
+	for i, v := range rows {
+		response.Items[i] = &persistence.TaskListInfo{
+			DomainID:    v.DomainID,
+			Name:        v.Name,
+			TaskType:    int(v.TaskType),
+			RangeID:     v.RangeID,
+			AckLevel:    v.AckLevel,
+			Kind:        int(v.Kind),
+			LastUpdated: v.LastUpdated,
+			Released:    v.Released,
+		}
+	}
+
+	if len(rows) > 0 && len(rows) == request.PageSize {
+		last := rows[len(rows)-1]
+		nextPageToken, err := gobSerialize(&taskListPageToken{
+			DomainID: last.DomainID,
+			Name:     last.Name,
+			TaskType: last.TaskType,
+		})
+		if err != nil {
+			return nil, err
+		}
+		response.NextPageToken = nextPageToken
+	}
+
+	return response, nil
+}
+
+func (m *sqlTaskManager) DeleteTaskList(request *persistence.DeleteTaskListRequest) error {
+	result, err := m.db.Exec(deleteTaskListSQLQuery,
+		request.DomainID, request.TaskListName, request.TaskListType, request.RangeID)
+	if err != nil {
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("DeleteTaskList operation failed. Error: %v", err),
+		}
+	}
+	nRows, err := result.RowsAffected()
+	if err != nil {
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("DeleteTaskList operation failed. rowsAffected error: %v", err),
+		}
+	}
+	if nRows != 1 {
+		return &persistence.ConditionFailedError{
+			Msg: fmt.Sprintf("DeleteTaskList failed to apply. TaskList: %v, taskListType: %v, rangeID: %v",
+				request.TaskListName, request.TaskListType, request.RangeID),
+		}
+	}
+	return nil
+}
+
 func lockTaskList(tx *sqlx.Tx, domainID, name string, taskListType int, oldRangeID int64) error {
 	var rangeID int64
 	if err := tx.Get(&rangeID, lockTaskListSQLQuery, domainID, name, taskListType); err != nil {
@@ -126,6 +126,7 @@ var (
 		"version",
 		"scheduled_event",
 		"scheduled_time",
+		"redirected_time",
 		"started_id",
 		"started_event",
 		"started_time",
@@ -174,6 +175,7 @@ type (
 		Version                  int64
 		ScheduledEvent           *[]byte
 		ScheduledTime            time.Time
+		RedirectedTime           time.Time
 		StartedID                int64
 		StartedEvent             *[]byte
 		StartedTime              time.Time
@@ -223,6 +225,7 @@ func updateActivityInfos(tx *sqlx.Tx,
 				Version:                  v.Version,
 				ScheduledEvent:           nil,
 				ScheduledTime:            v.ScheduledTime,
+				RedirectedTime:           v.RedirectedTime,
 				StartedID:                v.StartedID,
 				StartedEvent:             nil,
 				StartedTime:              v.StartedTime,
@@ -360,6 +363,7 @@ func getActivityInfoMap(tx *sqlx.Tx,
 			ScheduleID:               v.ScheduleID,
 			ScheduledEvent:           nil,
 			ScheduledTime:            v.ScheduledTime,
+			RedirectedTime:           v.RedirectedTime,
 			StartedID:                v.StartedID,
 			StartedEvent:             nil,
 			StartedTime:              v.StartedTime,
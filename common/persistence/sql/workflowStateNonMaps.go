@@ -51,6 +51,32 @@ signal_id = :signal_id`
 shard_id = ? AND
 domain_id = ? AND
 workflow_id = ? AND
+run_id = ?`
+
+	deleteAcceptedUpdatesMapSQLQuery = `DELETE FROM accepted_updates_maps
+WHERE
+shard_id = :shard_id AND
+domain_id = :domain_id AND
+workflow_id = :workflow_id AND
+run_id = :run_id
+`
+
+	removeFromAcceptedUpdatesMapSQLQuery = `DELETE FROM accepted_updates_maps
+WHERE
+shard_id = :shard_id AND
+domain_id = :domain_id AND
+workflow_id = :workflow_id AND
+run_id = :run_id AND
+update_id = :update_id`
+
+	addToAcceptedUpdatesMapSQLQuery = `INSERT IGNORE INTO accepted_updates_maps
+(shard_id, domain_id, workflow_id, run_id, update_id, event_id) VALUES
+(:shard_id, :domain_id, :workflow_id, :run_id, :update_id, :event_id)`
+
+	getAcceptedUpdatesMapSQLQuery = `SELECT update_id, event_id FROM accepted_updates_maps WHERE
+shard_id = ? AND
+domain_id = ? AND
+workflow_id = ? AND
 run_id = ?`
 )
 
@@ -62,6 +88,15 @@ type (
 		RunID      string
 		SignalID   string
 	}
+
+	acceptedUpdatesMapsRow struct {
+		ShardID    int64
+		DomainID   string
+		WorkflowID string
+		RunID      string
+		UpdateID   string
+		EventID    int64
+	}
 )
 
 func updateSignalsRequested(tx *sqlx.Tx,
@@ -148,3 +183,90 @@ func deleteSignalsRequestedSet(tx *sqlx.Tx, shardID int, domainID, workflowID, r
 	}
 	return nil
 }
+
+func updateAcceptedUpdates(tx *sqlx.Tx,
+	acceptedUpdates map[string]int64,
+	deleteUpdateIDs []string,
+	shardID int,
+	domainID, workflowID, runID string) error {
+	for _, updateID := range deleteUpdateIDs {
+		if _, err := tx.NamedExec(removeFromAcceptedUpdatesMapSQLQuery, &acceptedUpdatesMapsRow{
+			ShardID:    int64(shardID),
+			DomainID:   domainID,
+			WorkflowID: workflowID,
+			RunID:      runID,
+			UpdateID:   updateID,
+		}); err != nil {
+			return &workflow.InternalServiceError{
+				Message: fmt.Sprintf("Failed to update accepted updates. Failed to execute delete query. Error: %v", err),
+			}
+		}
+	}
+
+	if len(acceptedUpdates) == 0 {
+		return nil
+	}
+
+	acceptedUpdatesMapsRows := make([]acceptedUpdatesMapsRow, 0, len(acceptedUpdates))
+	for updateID, eventID := range acceptedUpdates {
+		acceptedUpdatesMapsRows = append(acceptedUpdatesMapsRows, acceptedUpdatesMapsRow{
+			ShardID:    int64(shardID),
+			DomainID:   domainID,
+			WorkflowID: workflowID,
+			RunID:      runID,
+			UpdateID:   updateID,
+			EventID:    eventID,
+		})
+	}
+
+	query, args, err := tx.BindNamed(addToAcceptedUpdatesMapSQLQuery, acceptedUpdatesMapsRows)
+	if err != nil {
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("Failed to update accepted updates. Failed to bind query. Error: %v", err),
+		}
+	}
+
+	if _, err := tx.Exec(query, args...); err != nil {
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("Failed to update accepted updates. Failed to execute update query. Error: %v", err),
+		}
+	}
+	return nil
+}
+
+func getAcceptedUpdates(tx *sqlx.Tx,
+	shardID int,
+	domainID,
+	workflowID,
+	runID string) (map[string]int64, error) {
+	var rows []acceptedUpdatesMapsRow
+	if err := tx.Select(&rows, getAcceptedUpdatesMapSQLQuery,
+		shardID,
+		domainID,
+		workflowID,
+		runID); err != nil {
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("Failed to get accepted updates. Error: %v", err),
+		}
+	}
+
+	var ret = make(map[string]int64)
+	for _, row := range rows {
+		ret[row.UpdateID] = row.EventID
+	}
+	return ret, nil
+}
+
+func deleteAcceptedUpdatesMap(tx *sqlx.Tx, shardID int, domainID, workflowID, runID string) error {
+	if _, err := tx.NamedExec(deleteAcceptedUpdatesMapSQLQuery, &acceptedUpdatesMapsRow{
+		ShardID:    int64(shardID),
+		DomainID:   domainID,
+		WorkflowID: workflowID,
+		RunID:      runID,
+	}); err != nil {
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("Failed to delete accepted updates map. Error: %v", err),
+		}
+	}
+	return nil
+}
@@ -21,6 +21,8 @@
 package common
 
 import (
+	"net"
+
 	"go.uber.org/yarpc"
 	"golang.org/x/net/context"
 )
@@ -51,6 +53,13 @@ type (
 		CreateDispatcher() *yarpc.Dispatcher
 		CreateDispatcherForOutbound(callerName, serviceName, hostName string) *yarpc.Dispatcher
 	}
+
+	// GatewayListenerFactory is implemented by the RPCFactories which can also serve the frontend
+	// API over gRPC and HTTP/JSON. A nil listener means the endpoint is disabled.
+	GatewayListenerFactory interface {
+		CreateGRPCListener() (net.Listener, error)
+		CreateHTTPListener() (net.Listener, error)
+	}
 )
 
 // AggregateYarpcOptions aggregate the header information from context to existing yarpc call options
@@ -44,6 +44,8 @@ type (
 		Services map[string]Service `yaml:"services"`
 		// Kafka is the config for connecting to kafka
 		Kafka messaging.KafkaConfig `yaml:"kafka"`
+		// Authorization is the config for authorizing frontend and admin API callers
+		Authorization Authorization `yaml:"authorization"`
 	}
 
 	// Service contains the service specific config items
@@ -56,6 +58,27 @@ type (
 		PProf PProf `yaml:"pprof"`
 	}
 
+	// Authorization contains the config for authorizing callers of the frontend and admin APIs
+	Authorization struct {
+		// Enable turns on the ACL authorizer, all requests are allowed when it is off
+		Enable bool `yaml:"enable"`
+		// Admins are the identities allowed to call the admin APIs and to manage domains
+		Admins []string `yaml:"admins"`
+		// Domains maps a domain name to an ACL, which is merged with the ACL kept in the domain data
+		Domains map[string]DomainACL `yaml:"domains"`
+		// Delegates are the transport verified identities, such as the frontends of the other clusters,
+		// which may call on behalf of the identity named in the caller identity header
+		Delegates []string `yaml:"delegates"`
+	}
+
+	// DomainACL lists the identities allowed to access a domain, "*" matches any identity
+	DomainACL struct {
+		// Readers may describe, list and query the workflows of the domain
+		Readers []string `yaml:"readers"`
+		// Writers may additionally start, signal and terminate workflows and process their tasks
+		Writers []string `yaml:"writers"`
+	}
+
 	// PProf contains the rpc config items
 	PProf struct {
 		// Port is the port on which the PProf will bind to
@@ -72,6 +95,30 @@ type (
 		DisableLogging bool `yaml:"disableLogging"`
 		// LogLevel is the desired log level
 		LogLevel string `yaml:"logLevel"`
+		// GRPCPort is the port serving the frontend API over gRPC, zero disables it
+		GRPCPort int `yaml:"grpcPort"`
+		// HTTPPort is the port serving the frontend API over HTTP/JSON, zero disables it
+		HTTPPort int `yaml:"httpPort"`
+		// TLS is the config for securing inbound and outbound rpc with mutual TLS
+		TLS TLS `yaml:"tls"`
+	}
+
+	// TLS contains the mutual TLS config items for rpc
+	TLS struct {
+		// Enable turns on TLS for inbound and outbound rpc
+		Enable bool `yaml:"enable"`
+		// CertFile is the path of the PEM encoded certificate presented by this host
+		CertFile string `yaml:"certFile"`
+		// KeyFile is the path of the PEM encoded private key of the certificate
+		KeyFile string `yaml:"keyFile"`
+		// CaFile is the path of the PEM encoded CA bundle used to verify peers
+		CaFile string `yaml:"caFile"`
+		// RequireClientAuth rejects inbound connections without a client certificate signed by the CA
+		RequireClientAuth bool `yaml:"requireClientAuth"`
+		// ServerName is the name verified against the certificate of outbound peers, defaults to the peer host
+		ServerName string `yaml:"serverName"`
+		// RefreshInterval is how often the files are checked for rotated certificates, defaults to one minute
+		RefreshInterval time.Duration `yaml:"refreshInterval"`
 	}
 
 	// Ringpop contains the ringpop config items
@@ -140,6 +187,17 @@ type (
 		CurrentClusterName string `yaml:"currentClusterName"`
 		// ClusterInitialFailoverVersions contains all cluster names to corresponding initial failover version
 		ClusterInitialFailoverVersions map[string]int64 `yaml:"clusterInitialFailoverVersion"`
+		// ClusterAddress contains the frontend address of the clusters, used to forward requests
+		// of domains which are active in another cluster
+		ClusterAddress map[string]Address `yaml:"clusterAddress"`
+	}
+
+	// Address indicates the remote cluster's service name and address
+	Address struct {
+		// RPCName indicate the remote service name
+		RPCName string `yaml:"rpcName"`
+		// RPCAddress indicate the remote service address(Host:Port)
+		RPCAddress string `yaml:"rpcAddress"`
 	}
 
 	// Metrics contains the config items for metrics subsystem
@@ -21,30 +21,65 @@
 package config
 
 import (
+	"crypto/tls"
 	"fmt"
 	"net"
+	"sync"
 
 	"github.com/uber-common/bark"
+	"github.com/uber/cadence/common/membership"
+	tcg "github.com/uber/tchannel-go"
 	"go.uber.org/yarpc"
 	"go.uber.org/yarpc/transport/tchannel"
 )
 
+const (
+	// ringpopServiceName is the tchannel service used by ringpop gossip, which cannot dial with TLS
+	ringpopServiceName = "ringpop"
+	// ringMembershipListenerName identifies the listener which ties the TLS outbounds to the ring membership
+	ringMembershipListenerName = "rpc-tls-outbounds"
+)
+
 // RPCFactory is an implementation of service.RPCFactory interface
 type RPCFactory struct {
 	config      *RPC
 	serviceName string
 	ch          *tchannel.ChannelTransport
 	logger      bark.Logger
+
+	certStore      *certificateStore
+	peerIdentityFn PeerIdentityFn
+	tlsChannel     *tcg.Channel
+	tlsListener    *tlsListener
+	sync.Mutex
+	outbounds map[string]*tlsOutbound
+	// outboundHosts are all the hosts a dispatcher was created for, their TLS outbounds are reopened when
+	// they join the ring again, since the clients keep using the dispatchers they created
+	outboundHosts map[string]struct{}
+	monitor       membership.Monitor
+	services      []string
+	ringStopCh    chan struct{}
 }
 
 // NewFactory builds a new RPCFactory
-// conforming to the underlying configuration
-func (cfg *RPC) NewFactory(sName string, logger bark.Logger) *RPCFactory {
-	return newRPCFactory(cfg, sName, logger)
+// conforming to the underlying configuration. When TLS is enabled, peerIdentityFn
+// receives the identity verified by TLS of the caller of every inbound call.
+func (cfg *RPC) NewFactory(sName string, logger bark.Logger, peerIdentityFn PeerIdentityFn) *RPCFactory {
+	return newRPCFactory(cfg, sName, logger, peerIdentityFn)
 }
 
-func newRPCFactory(cfg *RPC, sName string, logger bark.Logger) *RPCFactory {
+func newRPCFactory(cfg *RPC, sName string, logger bark.Logger, peerIdentityFn PeerIdentityFn) *RPCFactory {
 	factory := &RPCFactory{config: cfg, serviceName: sName, logger: logger}
+	if cfg.TLS.Enable {
+		certStore, err := newCertificateStore(&cfg.TLS, logger)
+		if err != nil {
+			logger.WithField("error", err).Fatal("Failed to load rpc TLS certificates")
+		}
+		factory.certStore = certStore
+		factory.peerIdentityFn = peerIdentityFn
+		factory.outbounds = make(map[string]*tlsOutbound)
+		factory.outboundHosts = make(map[string]struct{})
+	}
 	return factory
 }
 
@@ -53,17 +88,26 @@ func (d *RPCFactory) CreateDispatcher() *yarpc.Dispatcher {
 	// Setup dispatcher for onebox
 	var err error
 	hostAddress := fmt.Sprintf("%v:%v", d.getListenIP(), d.config.Port)
-	d.ch, err = tchannel.NewChannelTransport(
-		tchannel.ServiceName(d.serviceName),
-		tchannel.ListenAddr(hostAddress))
+	if d.certStore != nil {
+		d.ch, err = d.createTLSTransport(hostAddress)
+	} else {
+		d.ch, err = tchannel.NewChannelTransport(
+			tchannel.ServiceName(d.serviceName),
+			tchannel.ListenAddr(hostAddress))
+	}
 	if err != nil {
 		d.logger.WithField("error", err).Fatal("Failed to create transport channel")
 	}
 	d.logger.Infof("Created RPC dispatcher for '%v' and listening at '%v'",
 		d.serviceName, hostAddress)
+	var inboundMiddleware yarpc.InboundMiddleware
+	if d.certStore != nil && d.peerIdentityFn != nil {
+		inboundMiddleware.Unary = &peerIdentityInboundMiddleware{peerIdentityFn: d.peerIdentityFn}
+	}
 	return yarpc.NewDispatcher(yarpc.Config{
-		Name:     d.serviceName,
-		Inbounds: yarpc.Inbounds{d.ch.NewInbound()},
+		Name:              d.serviceName,
+		Inbounds:          yarpc.Inbounds{d.ch.NewInbound()},
+		InboundMiddleware: inboundMiddleware,
 	})
 }
 
@@ -73,6 +117,9 @@ func (d *RPCFactory) CreateDispatcherForOutbound(
 	// Setup dispatcher(outbound) for onebox
 	d.logger.Infof("Created RPC dispatcher outbound for service '%v' for host '%v'",
 		serviceName, hostName)
+	if d.certStore != nil {
+		d.ensureTLSOutbound(hostName)
+	}
 	dispatcher := yarpc.NewDispatcher(yarpc.Config{
 		Name: callerName,
 		Outbounds: yarpc.Outbounds{
@@ -85,6 +132,142 @@ func (d *RPCFactory) CreateDispatcherForOutbound(
 	return dispatcher
 }
 
+// CreateGRPCListener creates the listener for the gRPC endpoint of the frontend
+func (d *RPCFactory) CreateGRPCListener() (net.Listener, error) {
+	return d.createGatewayListener(d.config.GRPCPort)
+}
+
+// CreateHTTPListener creates the listener for the HTTP/JSON endpoint of the frontend
+func (d *RPCFactory) CreateHTTPListener() (net.Listener, error) {
+	return d.createGatewayListener(d.config.HTTPPort)
+}
+
+func (d *RPCFactory) createGatewayListener(port int) (net.Listener, error) {
+	if port == 0 {
+		return nil, nil
+	}
+	listener, err := net.Listen("tcp", fmt.Sprintf("%v:%v", d.getListenIP(), port))
+	if err != nil {
+		return nil, err
+	}
+	if d.certStore != nil {
+		listener = tls.NewListener(listener, d.certStore.serverConfig("h2", "http/1.1"))
+	}
+	return listener, nil
+}
+
+// createTLSTransport creates a transport whose channel serves TLS connections, plaintext
+// connections are only allowed to carry ringpop gossip
+func (d *RPCFactory) createTLSTransport(hostAddress string) (*tchannel.ChannelTransport, error) {
+	ch, err := tcg.NewChannel(d.serviceName, nil)
+	if err != nil {
+		return nil, err
+	}
+	listener, err := net.Listen("tcp", hostAddress)
+	if err != nil {
+		return nil, err
+	}
+	d.tlsListener = newTLSListener(listener, d.certStore, []string{ringpopServiceName}, d.logger)
+	if err := ch.Serve(d.tlsListener); err != nil {
+		return nil, err
+	}
+	d.tlsChannel = ch
+	return tchannel.NewChannelTransport(tchannel.WithChannel(ch))
+}
+
+// WatchRingMembership ties the TLS outbounds to the hosts in the rings of the given services to the
+// membership of the hosts: the outbound to a host is closed when it leaves the ring and opened again when it
+// rejoins. Outbounds to hosts which are not in any of the rings, like the frontends of remote clusters, stay open.
+func (d *RPCFactory) WatchRingMembership(monitor membership.Monitor, services []string) error {
+	if d.certStore == nil {
+		return nil
+	}
+	eventCh := make(chan *membership.ChangedEvent, len(services))
+	for _, service := range services {
+		if err := monitor.AddListener(service, ringMembershipListenerName, eventCh); err != nil {
+			return err
+		}
+	}
+	d.Lock()
+	d.monitor = monitor
+	d.services = services
+	d.ringStopCh = make(chan struct{})
+	stopCh := d.ringStopCh
+	d.Unlock()
+
+	go func() {
+		for {
+			select {
+			case event := <-eventCh:
+				d.handleRingMembershipChange(event)
+			case <-stopCh:
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// StopWatchingRingMembership stops following the ring membership and closes all the TLS outbounds
+func (d *RPCFactory) StopWatchingRingMembership() {
+	d.Lock()
+	defer d.Unlock()
+	if d.ringStopCh == nil {
+		return
+	}
+	for _, service := range d.services {
+		d.monitor.RemoveListener(service, ringMembershipListenerName)
+	}
+	close(d.ringStopCh)
+	d.ringStopCh = nil
+	for hostName, outbound := range d.outbounds {
+		outbound.close()
+		delete(d.outbounds, hostName)
+	}
+}
+
+func (d *RPCFactory) handleRingMembershipChange(event *membership.ChangedEvent) {
+	d.Lock()
+	defer d.Unlock()
+	for _, host := range event.HostsRemoved {
+		hostName := host.GetAddress()
+		if outbound, ok := d.outbounds[hostName]; ok {
+			delete(d.outbounds, hostName)
+			outbound.close()
+			d.logger.WithField("peer", hostName).Info("Closed TLS connection to host which left the ring")
+		}
+	}
+	for _, host := range event.HostsAdded {
+		hostName := host.GetAddress()
+		if _, ok := d.outboundHosts[hostName]; !ok {
+			continue
+		}
+		if _, ok := d.outbounds[hostName]; !ok {
+			outbound := newTLSOutbound(hostName, d.tlsChannel, d.tlsListener, d.certStore, d.logger)
+			d.outbounds[hostName] = outbound
+			outbound.start()
+		}
+	}
+}
+
+// ensureTLSOutbound makes sure that the channel keeps a TLS connection to the host, over which the
+// outbound calls to the host are made. The host is dialed outside of the lock, so that an unreachable
+// host only holds up the callers of that host.
+func (d *RPCFactory) ensureTLSOutbound(hostName string) {
+	d.Lock()
+	d.outboundHosts[hostName] = struct{}{}
+	outbound, ok := d.outbounds[hostName]
+	if !ok {
+		outbound = newTLSOutbound(hostName, d.tlsChannel, d.tlsListener, d.certStore, d.logger)
+		d.outbounds[hostName] = outbound
+		outbound.start()
+	}
+	d.Unlock()
+
+	// calls made before the first connection is registered would make tchannel dial the host in plaintext
+	outbound.waitForStart()
+}
+
 func (d *RPCFactory) getListenIP() net.IP {
 	if d.config.BindOnLocalHost {
 		return net.IPv4(127, 0, 0, 1)
@@ -0,0 +1,187 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package config
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/uber-common/bark"
+)
+
+const defaultTLSRefreshInterval = time.Minute
+
+type (
+	// certificateStore holds the certificate and CA pool used for rpc TLS. The files are checked
+	// for changes at most once per refresh interval, so rotated certificates are picked up by new
+	// connections without restarting the host.
+	certificateStore struct {
+		config *TLS
+		logger bark.Logger
+
+		sync.RWMutex
+		cert      *tls.Certificate
+		caPool    *x509.CertPool
+		modTimes  map[string]time.Time
+		lastCheck time.Time
+	}
+)
+
+func newCertificateStore(cfg *TLS, logger bark.Logger) (*certificateStore, error) {
+	if cfg.CertFile == "" || cfg.KeyFile == "" || cfg.CaFile == "" {
+		return nil, errors.New("tls config requires certFile, keyFile and caFile")
+	}
+	s := &certificateStore{config: cfg, logger: logger}
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// serverConfig returns the TLS config used to accept inbound connections, nextProtos are
+// the application protocols offered during the handshake
+func (s *certificateStore) serverConfig(nextProtos ...string) *tls.Config {
+	return &tls.Config{
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			cert, caPool := s.current()
+			clientAuth := tls.VerifyClientCertIfGiven
+			if s.config.RequireClientAuth {
+				clientAuth = tls.RequireAndVerifyClientCert
+			}
+			return &tls.Config{
+				Certificates: []tls.Certificate{*cert},
+				ClientCAs:    caPool,
+				ClientAuth:   clientAuth,
+				NextProtos:   nextProtos,
+				MinVersion:   tls.VersionTLS12,
+			}, nil
+		},
+	}
+}
+
+// clientConfig returns the TLS config used to dial the given host
+func (s *certificateStore) clientConfig(host string) *tls.Config {
+	cert, caPool := s.current()
+	serverName := s.config.ServerName
+	if serverName == "" {
+		serverName = host
+	}
+	return &tls.Config{
+		Certificates: []tls.Certificate{*cert},
+		RootCAs:      caPool,
+		ServerName:   serverName,
+		MinVersion:   tls.VersionTLS12,
+	}
+}
+
+// current returns the latest certificate and CA pool, reloading them first if the files changed
+func (s *certificateStore) current() (*tls.Certificate, *x509.CertPool) {
+	s.refresh()
+	s.RLock()
+	defer s.RUnlock()
+	return s.cert, s.caPool
+}
+
+func (s *certificateStore) refresh() {
+	interval := s.config.RefreshInterval
+	if interval <= 0 {
+		interval = defaultTLSRefreshInterval
+	}
+	s.RLock()
+	due := time.Since(s.lastCheck) >= interval
+	s.RUnlock()
+	if !due {
+		return
+	}
+	if err := s.load(); err != nil {
+		// keep serving with the previous certificate until the files are fixed
+		s.logger.WithField("error", err).Error("Failed to reload rpc TLS certificates")
+	}
+}
+
+func (s *certificateStore) load() error {
+	modTimes, err := s.statFiles()
+	if err != nil {
+		s.touch()
+		return err
+	}
+	s.RLock()
+	changed := s.cert == nil
+	for file, modTime := range modTimes {
+		if !s.modTimes[file].Equal(modTime) {
+			changed = true
+		}
+	}
+	s.RUnlock()
+	if !changed {
+		s.touch()
+		return nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(s.config.CertFile, s.config.KeyFile)
+	if err != nil {
+		s.touch()
+		return err
+	}
+	caPEM, err := ioutil.ReadFile(s.config.CaFile)
+	if err != nil {
+		s.touch()
+		return err
+	}
+	caPool := x509.NewCertPool()
+	if !caPool.AppendCertsFromPEM(caPEM) {
+		s.touch()
+		return fmt.Errorf("no certificates found in %v", s.config.CaFile)
+	}
+
+	s.Lock()
+	s.cert = &cert
+	s.caPool = caPool
+	s.modTimes = modTimes
+	s.lastCheck = time.Now()
+	s.Unlock()
+	s.logger.Infof("Loaded rpc TLS certificate from '%v'", s.config.CertFile)
+	return nil
+}
+
+func (s *certificateStore) statFiles() (map[string]time.Time, error) {
+	modTimes := make(map[string]time.Time)
+	for _, file := range []string{s.config.CertFile, s.config.KeyFile, s.config.CaFile} {
+		info, err := os.Stat(file)
+		if err != nil {
+			return nil, err
+		}
+		modTimes[file] = info.ModTime()
+	}
+	return modTimes, nil
+}
+
+func (s *certificateStore) touch() {
+	s.Lock()
+	s.lastCheck = time.Now()
+	s.Unlock()
+}
@@ -0,0 +1,695 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package config
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/uber-common/bark"
+	"github.com/uber/cadence/common/backoff"
+	tcg "github.com/uber/tchannel-go"
+	"go.uber.org/yarpc/api/transport"
+)
+
+const (
+	tlsHandshakeTimeout = 10 * time.Second
+	// tlsReconnectInterval is how long to wait before dialing a remote host again after its TLS connection closed,
+	// failed dials back off exponentially from it up to tlsMaxReconnectInterval
+	tlsReconnectInterval    = time.Second
+	tlsMaxReconnectInterval = time.Minute
+
+	// tlsRecordTypeHandshake is the first byte sent by a TLS client
+	tlsRecordTypeHandshake = 0x16
+
+	// layout of the tchannel frames inspected on plaintext connections
+	tchannelFrameHeaderSize  = 16
+	tchannelFrameTypeInitReq = 0x01
+	tchannelFrameTypeInitRes = 0x02
+	tchannelFrameTypeCallReq = 0x03
+	// flags:1 ttl:4 tracing:25 precede the length of the service name in a call request
+	tchannelCallReqServiceOffset = 30
+	// the id of the init request, which is the first frame sent on a tchannel connection
+	tchannelInitMessageID = 1
+
+	// tlsPeerIdentitySeparator separates the process name reported by a peer from the identity verified
+	// by TLS, which is appended to the process name of every inbound connection
+	tlsPeerIdentitySeparator = " tls-identity="
+)
+
+var errTLSListenerClosed = errors.New("tls listener is closed")
+
+type (
+	// PeerIdentityFn attaches the identity of the peer verified by the transport to the context of an inbound call
+	PeerIdentityFn func(ctx context.Context, identity string) context.Context
+
+	// tlsListener accepts TLS connections for rpc. Ringpop cannot dial with TLS, so plaintext
+	// connections are still accepted but may only carry calls to the services in plaintextServices.
+	// Outbound connections dialed by tlsOutbound are handed to the channel through the listener too.
+	tlsListener struct {
+		net.Listener
+		store             *certificateStore
+		config            *tls.Config
+		plaintextServices map[string]struct{}
+		logger            bark.Logger
+
+		acceptCh  chan acceptResult
+		injectCh  chan net.Conn
+		closeCh   chan struct{}
+		closeOnce sync.Once
+	}
+
+	acceptResult struct {
+		conn net.Conn
+		err  error
+	}
+
+	// sniffedConn picks TLS or plaintext from the first byte sent by the peer
+	sniffedConn struct {
+		net.Conn
+		listener *tlsListener
+		once     sync.Once
+		conn     net.Conn
+		err      error
+	}
+
+	// bufferedConn replays the bytes read ahead while sniffing the connection
+	bufferedConn struct {
+		net.Conn
+		reader *bufio.Reader
+	}
+
+	// plaintextConn passes through tchannel frames and rejects call requests to services which
+	// require TLS
+	plaintextConn struct {
+		net.Conn
+		reader      io.Reader
+		services    map[string]struct{}
+		pending     []byte
+		initialized bool
+	}
+
+	// identityConn appends the identity verified by TLS to the process name in the init request of the
+	// peer, so that it is available to the handlers of the calls made over the connection
+	identityConn struct {
+		net.Conn
+		identity    string
+		pending     []byte
+		initialized bool
+	}
+
+	// tlsOutbound keeps a TLS connection to a remote host registered in the channel, so that outbound
+	// calls to the host are made over TLS. tchannel has no hook for dialing with TLS, so the connection is
+	// dialed and initialized here and handed to the channel through its listener, as if the remote host
+	// had connected to this host.
+	tlsOutbound struct {
+		hostPort string
+		channel  *tcg.Channel
+		listener *tlsListener
+		store    *certificateStore
+		logger   bark.Logger
+
+		startedCh chan struct{}
+		closeCh   chan struct{}
+		closeOnce sync.Once
+		sync.Mutex
+		conn *injectedConn
+	}
+
+	// injectedConn replays the init request of the remote host to the channel and drops the init response
+	// of the channel, since the connection was already initialized with the remote host when it was dialed
+	injectedConn struct {
+		net.Conn
+		pending     []byte
+		written     []byte
+		initialized bool
+		readyCh     chan struct{}
+		closeCh     chan struct{}
+		closeOnce   sync.Once
+	}
+
+	// peerIdentityInboundMiddleware attaches the identity verified by TLS to the context of inbound calls
+	peerIdentityInboundMiddleware struct {
+		peerIdentityFn PeerIdentityFn
+	}
+)
+
+func newTLSListener(l net.Listener, store *certificateStore, plaintextServices []string,
+	logger bark.Logger) *tlsListener {
+	services := make(map[string]struct{}, len(plaintextServices))
+	for _, service := range plaintextServices {
+		services[service] = struct{}{}
+	}
+	listener := &tlsListener{
+		Listener:          l,
+		store:             store,
+		config:            store.serverConfig(),
+		plaintextServices: services,
+		logger:            logger,
+		acceptCh:          make(chan acceptResult),
+		injectCh:          make(chan net.Conn),
+		closeCh:           make(chan struct{}),
+	}
+	go listener.acceptLoop()
+	return listener
+}
+
+// Accept returns the next connection, the TLS handshake happens on its first read or write
+func (l *tlsListener) Accept() (net.Conn, error) {
+	select {
+	case conn := <-l.injectCh:
+		return conn, nil
+	case result := <-l.acceptCh:
+		if result.err != nil {
+			return nil, result.err
+		}
+		return &sniffedConn{Conn: result.conn, listener: l}, nil
+	case <-l.closeCh:
+		return nil, errTLSListenerClosed
+	}
+}
+
+// Close stops accepting connections
+func (l *tlsListener) Close() error {
+	l.closeOnce.Do(func() { close(l.closeCh) })
+	return l.Listener.Close()
+}
+
+func (l *tlsListener) acceptLoop() {
+	for {
+		conn, err := l.Listener.Accept()
+		select {
+		case l.acceptCh <- acceptResult{conn: conn, err: err}:
+		case <-l.closeCh:
+			if conn != nil {
+				conn.Close()
+			}
+			return
+		}
+		if err != nil {
+			if ne, ok := err.(net.Error); !ok || !ne.Temporary() {
+				return
+			}
+		}
+	}
+}
+
+// inject hands a connection to the channel serving the listener
+func (l *tlsListener) inject(conn net.Conn) error {
+	select {
+	case l.injectCh <- conn:
+		return nil
+	case <-l.closeCh:
+		return errTLSListenerClosed
+	}
+}
+
+func (c *sniffedConn) Read(b []byte) (int, error) {
+	c.once.Do(c.sniff)
+	if c.err != nil {
+		return 0, c.err
+	}
+	return c.conn.Read(b)
+}
+
+func (c *sniffedConn) Write(b []byte) (int, error) {
+	c.once.Do(c.sniff)
+	if c.err != nil {
+		return 0, c.err
+	}
+	return c.conn.Write(b)
+}
+
+func (c *sniffedConn) sniff() {
+	c.Conn.SetDeadline(time.Now().Add(tlsHandshakeTimeout))
+	defer c.Conn.SetDeadline(time.Time{})
+
+	conn := &bufferedConn{Conn: c.Conn, reader: bufio.NewReader(c.Conn)}
+	first, err := conn.reader.Peek(1)
+	if err != nil {
+		c.err = err
+		return
+	}
+	if first[0] != tlsRecordTypeHandshake {
+		c.conn = &plaintextConn{Conn: conn, reader: conn.reader, services: c.listener.plaintextServices}
+		return
+	}
+
+	tlsConn := tls.Server(conn, c.listener.config)
+	if err := tlsConn.Handshake(); err != nil {
+		c.listener.logger.WithFields(bark.Fields{
+			"error": err,
+			"peer":  c.RemoteAddr().String(),
+		}).Warn("Rejected rpc connection, TLS handshake failed")
+		c.err = err
+		return
+	}
+	c.conn = &identityConn{Conn: tlsConn, identity: TLSConnectionIdentity(tlsConn.ConnectionState())}
+}
+
+func (c *bufferedConn) Read(b []byte) (int, error) {
+	return c.reader.Read(b)
+}
+
+func (c *plaintextConn) Read(b []byte) (int, error) {
+	if len(c.pending) == 0 {
+		frame, err := c.readFrame()
+		if err != nil {
+			return 0, err
+		}
+		c.pending = frame
+	}
+	n := copy(b, c.pending)
+	c.pending = c.pending[n:]
+	return n, nil
+}
+
+func (c *plaintextConn) readFrame() ([]byte, error) {
+	frame, err := readTChannelFrame(c.reader)
+	if err != nil {
+		return nil, err
+	}
+
+	if !c.initialized && frame[2] == tchannelFrameTypeInitReq {
+		// plaintext peers have no verified identity, whatever their process name claims
+		c.initialized = true
+		return appendInitIdentity(frame, "")
+	}
+	if frame[2] == tchannelFrameTypeCallReq {
+		payload := frame[tchannelFrameHeaderSize:]
+		if len(payload) <= tchannelCallReqServiceOffset {
+			return nil, fmt.Errorf("invalid tchannel call request")
+		}
+		end := tchannelCallReqServiceOffset + 1 + int(payload[tchannelCallReqServiceOffset])
+		if end > len(payload) {
+			return nil, fmt.Errorf("invalid tchannel call request")
+		}
+		service := string(payload[tchannelCallReqServiceOffset+1 : end])
+		if _, ok := c.services[service]; !ok {
+			c.Close()
+			return nil, fmt.Errorf("calls to %v require TLS", service)
+		}
+	}
+	return frame, nil
+}
+
+func (c *identityConn) Read(b []byte) (int, error) {
+	if !c.initialized {
+		frame, err := readTChannelFrame(c.Conn)
+		if err != nil {
+			return 0, err
+		}
+		if frame[2] == tchannelFrameTypeInitReq {
+			if frame, err = appendInitIdentity(frame, c.identity); err != nil {
+				return 0, err
+			}
+		}
+		c.pending = frame
+		c.initialized = true
+	}
+	if len(c.pending) > 0 {
+		n := copy(b, c.pending)
+		c.pending = c.pending[n:]
+		return n, nil
+	}
+	return c.Conn.Read(b)
+}
+
+func newTLSOutbound(hostPort string, channel *tcg.Channel, listener *tlsListener, store *certificateStore,
+	logger bark.Logger) *tlsOutbound {
+	return &tlsOutbound{
+		hostPort:  hostPort,
+		channel:   channel,
+		listener:  listener,
+		store:     store,
+		logger:    logger,
+		startedCh: make(chan struct{}),
+		closeCh:   make(chan struct{}),
+	}
+}
+
+// start dials the remote host in the background and keeps the connection open until the outbound is closed
+func (o *tlsOutbound) start() {
+	go o.maintain()
+}
+
+// waitForStart blocks until the first attempt to connect to the remote host is over
+func (o *tlsOutbound) waitForStart() {
+	select {
+	case <-o.startedCh:
+	case <-o.closeCh:
+	}
+}
+
+// close closes the connection to the remote host and stops dialing it again
+func (o *tlsOutbound) close() {
+	o.closeOnce.Do(func() { close(o.closeCh) })
+	o.Lock()
+	defer o.Unlock()
+	if o.conn != nil {
+		o.conn.Close()
+		o.conn = nil
+	}
+}
+
+// maintain dials the remote host again whenever its connection closes, until the outbound or the listener
+// is closed. Failed dials back off, so that an unreachable host is neither hammered nor floods the log.
+func (o *tlsOutbound) maintain() {
+	retryPolicy := backoff.NewExponentialRetryPolicy(tlsReconnectInterval)
+	retryPolicy.SetMaximumInterval(tlsMaxReconnectInterval)
+	retryPolicy.SetExpirationInterval(backoff.NoInterval)
+
+	failures := 0
+	for {
+		closeCh, err := o.connect()
+		o.markStarted()
+		wait := tlsReconnectInterval
+		if err != nil {
+			o.logConnectFailure(err)
+			wait = retryPolicy.ComputeNextDelay(0, failures)
+			failures++
+		} else {
+			failures = 0
+			select {
+			case <-closeCh:
+			case <-o.closeCh:
+				return
+			case <-o.listener.closeCh:
+				return
+			}
+		}
+
+		select {
+		case <-time.After(wait):
+		case <-o.closeCh:
+			return
+		case <-o.listener.closeCh:
+			return
+		}
+	}
+}
+
+// markStarted releases the callers waiting for the first attempt to connect, it is only called by maintain
+func (o *tlsOutbound) markStarted() {
+	select {
+	case <-o.startedCh:
+	default:
+		close(o.startedCh)
+	}
+}
+
+// connect dials the remote host over TLS, exchanges the tchannel init messages with it and hands the
+// connection to the channel. Returns a channel which is closed once the connection is closed.
+func (o *tlsOutbound) connect() (<-chan struct{}, error) {
+	host, _, err := net.SplitHostPort(o.hostPort)
+	if err != nil {
+		host = o.hostPort
+	}
+	dialer := &net.Dialer{Timeout: tlsHandshakeTimeout}
+	conn, err := tls.DialWithDialer(dialer, "tcp", o.hostPort, o.store.clientConfig(host))
+	if err != nil {
+		return nil, err
+	}
+
+	params, err := o.initialize(conn)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	// calls to the remote host are routed by the host port they are made to, and carry the identity verified
+	// by TLS like the calls on the connections accepted by the listener
+	params[tcg.InitParamHostPort] = o.hostPort
+	params[tcg.InitParamProcessName] += tlsPeerIdentitySeparator + TLSConnectionIdentity(conn.ConnectionState())
+
+	injected := &injectedConn{
+		Conn:    conn,
+		pending: writeInitFrame(tchannelFrameTypeInitReq, params),
+		readyCh: make(chan struct{}),
+		closeCh: make(chan struct{}),
+	}
+	if err := o.listener.inject(injected); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if err := o.waitForConnection(injected); err != nil {
+		injected.Close()
+		return nil, err
+	}
+
+	o.Lock()
+	defer o.Unlock()
+	select {
+	case <-o.closeCh:
+		injected.Close()
+		return nil, fmt.Errorf("tls outbound to %v is closed", o.hostPort)
+	default:
+	}
+	o.conn = injected
+	return injected.closeCh, nil
+}
+
+// initialize sends the init request of the channel to the remote host and returns the init params of its response
+func (o *tlsOutbound) initialize(conn net.Conn) (map[string]string, error) {
+	conn.SetDeadline(time.Now().Add(tlsHandshakeTimeout))
+	defer conn.SetDeadline(time.Time{})
+
+	local := o.channel.PeerInfo()
+	req := writeInitFrame(tchannelFrameTypeInitReq, map[string]string{
+		tcg.InitParamHostPort:                local.HostPort,
+		tcg.InitParamProcessName:             local.ProcessName,
+		tcg.InitParamTChannelLanguage:        local.Version.Language,
+		tcg.InitParamTChannelLanguageVersion: local.Version.LanguageVersion,
+		tcg.InitParamTChannelVersion:         local.Version.TChannelVersion,
+	})
+	if _, err := conn.Write(req); err != nil {
+		return nil, err
+	}
+	res, err := readTChannelFrame(conn)
+	if err != nil {
+		return nil, err
+	}
+	if res[2] != tchannelFrameTypeInitRes {
+		return nil, fmt.Errorf("expected tchannel init response from %v, got frame type %v", o.hostPort, res[2])
+	}
+	return readInitParams(res)
+}
+
+// waitForConnection waits until the channel has registered the injected connection with the peer
+func (o *tlsOutbound) waitForConnection(conn *injectedConn) error {
+	timer := time.NewTimer(tlsHandshakeTimeout)
+	defer timer.Stop()
+	select {
+	case <-conn.readyCh:
+	case <-conn.closeCh:
+		return fmt.Errorf("connection to %v closed during tchannel handshake", o.hostPort)
+	case <-timer.C:
+		return fmt.Errorf("timed out waiting for tchannel handshake with %v", o.hostPort)
+	}
+
+	peer := o.channel.RootPeers().GetOrAdd(o.hostPort)
+	for {
+		if inbound, _ := peer.NumConnections(); inbound > 0 {
+			return nil
+		}
+		select {
+		case <-time.After(time.Millisecond):
+		case <-timer.C:
+			return fmt.Errorf("timed out waiting for the connection to %v to become active", o.hostPort)
+		}
+	}
+}
+
+func (o *tlsOutbound) logConnectFailure(err error) {
+	o.logger.WithFields(bark.Fields{
+		"error": err,
+		"peer":  o.hostPort,
+	}).Warn("Failed to open TLS connection")
+}
+
+func (c *injectedConn) Read(b []byte) (int, error) {
+	if len(c.pending) > 0 {
+		n := copy(b, c.pending)
+		c.pending = c.pending[n:]
+		return n, nil
+	}
+	return c.Conn.Read(b)
+}
+
+func (c *injectedConn) Write(b []byte) (int, error) {
+	if c.initialized {
+		return c.Conn.Write(b)
+	}
+
+	c.written = append(c.written, b...)
+	if len(c.written) < tchannelFrameHeaderSize {
+		return len(b), nil
+	}
+	size := int(binary.BigEndian.Uint16(c.written))
+	if len(c.written) < size {
+		return len(b), nil
+	}
+	rest := c.written[size:]
+	c.written = nil
+	c.initialized = true
+	close(c.readyCh)
+	if len(rest) > 0 {
+		if _, err := c.Conn.Write(rest); err != nil {
+			return 0, err
+		}
+	}
+	return len(b), nil
+}
+
+func (c *injectedConn) Close() error {
+	c.closeOnce.Do(func() { close(c.closeCh) })
+	return c.Conn.Close()
+}
+
+func (m *peerIdentityInboundMiddleware) Handle(ctx context.Context, req *transport.Request,
+	resw transport.ResponseWriter, h transport.UnaryHandler) error {
+	if identity := TLSPeerIdentity(ctx); identity != "" {
+		ctx = m.peerIdentityFn(ctx, identity)
+	}
+	return h.Handle(ctx, req, resw)
+}
+
+// TLSPeerIdentity returns the subject common name of the verified TLS certificate of the peer which made the
+// inbound tchannel call, or an empty string if the peer did not present one. It is only meaningful when TLS
+// is enabled for rpc.
+func TLSPeerIdentity(ctx context.Context) string {
+	call := tcg.CurrentCall(ctx)
+	if call == nil {
+		return ""
+	}
+	processName := call.RemotePeer().ProcessName
+	i := strings.LastIndex(processName, tlsPeerIdentitySeparator)
+	if i < 0 {
+		return ""
+	}
+	return processName[i+len(tlsPeerIdentitySeparator):]
+}
+
+// TLSConnectionIdentity returns the subject common name of the verified certificate of the peer of a TLS
+// connection, or an empty string if the peer did not present one
+func TLSConnectionIdentity(state tls.ConnectionState) string {
+	if len(state.VerifiedChains) == 0 || len(state.VerifiedChains[0]) == 0 {
+		return ""
+	}
+	return state.VerifiedChains[0][0].Subject.CommonName
+}
+
+func readTChannelFrame(r io.Reader) ([]byte, error) {
+	header := make([]byte, tchannelFrameHeaderSize)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, err
+	}
+	size := int(binary.BigEndian.Uint16(header))
+	if size < tchannelFrameHeaderSize {
+		return nil, fmt.Errorf("invalid tchannel frame size %v", size)
+	}
+	frame := make([]byte, size)
+	copy(frame, header)
+	if _, err := io.ReadFull(r, frame[tchannelFrameHeaderSize:]); err != nil {
+		return nil, err
+	}
+	return frame, nil
+}
+
+// appendInitIdentity rewrites the init request frame with the identity appended to the process name
+func appendInitIdentity(frame []byte, identity string) ([]byte, error) {
+	params, err := readInitParams(frame)
+	if err != nil {
+		return nil, err
+	}
+	params[tcg.InitParamProcessName] += tlsPeerIdentitySeparator + identity
+	return writeInitFrame(tchannelFrameTypeInitReq, params), nil
+}
+
+// readInitParams parses the version:2 nh:2 (key~2 value~2){nh} payload of a tchannel init message
+func readInitParams(frame []byte) (map[string]string, error) {
+	payload := frame[tchannelFrameHeaderSize:]
+	if len(payload) < 4 {
+		return nil, fmt.Errorf("invalid tchannel init message")
+	}
+	if version := binary.BigEndian.Uint16(payload); version != tcg.CurrentProtocolVersion {
+		return nil, fmt.Errorf("unsupported tchannel protocol version %v", version)
+	}
+	count := int(binary.BigEndian.Uint16(payload[2:]))
+	payload = payload[4:]
+	readString := func() (string, error) {
+		if len(payload) < 2 {
+			return "", fmt.Errorf("invalid tchannel init message")
+		}
+		size := int(binary.BigEndian.Uint16(payload))
+		if len(payload) < 2+size {
+			return "", fmt.Errorf("invalid tchannel init message")
+		}
+		value := string(payload[2 : 2+size])
+		payload = payload[2+size:]
+		return value, nil
+	}
+
+	params := make(map[string]string, count)
+	for i := 0; i < count; i++ {
+		key, err := readString()
+		if err != nil {
+			return nil, err
+		}
+		value, err := readString()
+		if err != nil {
+			return nil, err
+		}
+		params[key] = value
+	}
+	return params, nil
+}
+
+func writeInitFrame(frameType byte, params map[string]string) []byte {
+	size := tchannelFrameHeaderSize + 4
+	for key, value := range params {
+		size += 4 + len(key) + len(value)
+	}
+	frame := make([]byte, size)
+	binary.BigEndian.PutUint16(frame, uint16(size))
+	frame[2] = frameType
+	binary.BigEndian.PutUint32(frame[4:], tchannelInitMessageID)
+
+	offset := tchannelFrameHeaderSize
+	binary.BigEndian.PutUint16(frame[offset:], tcg.CurrentProtocolVersion)
+	binary.BigEndian.PutUint16(frame[offset+2:], uint16(len(params)))
+	offset += 4
+	for key, value := range params {
+		for _, s := range []string{key, value} {
+			binary.BigEndian.PutUint16(frame[offset:], uint16(len(s)))
+			offset += 2 + copy(frame[offset+2:], s)
+		}
+	}
+	return frame
+}
@@ -0,0 +1,348 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package config
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/binary"
+	"encoding/pem"
+	"io"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-common/bark"
+	"github.com/uber/cadence/common/membership"
+	tcg "github.com/uber/tchannel-go"
+	"github.com/uber/tchannel-go/raw"
+	"golang.org/x/net/context"
+)
+
+type (
+	tlsSuite struct {
+		suite.Suite
+		dir    string
+		caCert *x509.Certificate
+		caKey  *ecdsa.PrivateKey
+		config *TLS
+		logger bark.Logger
+
+		listeners []net.Listener
+		channels  []*tcg.Channel
+	}
+
+	// identityHandler responds with the identity verified by TLS of the caller
+	identityHandler struct{}
+)
+
+func TestTLSSuite(t *testing.T) {
+	suite.Run(t, new(tlsSuite))
+}
+
+func (s *tlsSuite) SetupTest() {
+	dir, err := ioutil.TempDir("", "cadence-tls-test")
+	s.NoError(err)
+	s.dir = dir
+	s.logger = bark.NewNopLogger()
+
+	s.caKey, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	s.NoError(err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "cadence-test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &s.caKey.PublicKey, s.caKey)
+	s.NoError(err)
+	s.caCert, err = x509.ParseCertificate(der)
+	s.NoError(err)
+	s.writePEM("ca.pem", "CERTIFICATE", der)
+
+	s.config = &TLS{
+		Enable:            true,
+		CertFile:          filepath.Join(dir, "cert.pem"),
+		KeyFile:           filepath.Join(dir, "key.pem"),
+		CaFile:            filepath.Join(dir, "ca.pem"),
+		RequireClientAuth: true,
+		ServerName:        "cadence",
+		RefreshInterval:   time.Millisecond,
+	}
+	s.issueCertificate(2)
+}
+
+func (s *tlsSuite) TearDownTest() {
+	for _, ch := range s.channels {
+		ch.Close()
+	}
+	s.channels = nil
+	for _, listener := range s.listeners {
+		listener.Close()
+	}
+	s.listeners = nil
+	os.RemoveAll(s.dir)
+}
+
+func (s *tlsSuite) TestCertificateRotation() {
+	store, err := newCertificateStore(s.config, s.logger)
+	s.NoError(err)
+	cert, _ := store.current()
+	s.Equal(int64(2), s.serial(cert))
+
+	s.issueCertificate(3)
+	future := time.Now().Add(time.Minute)
+	for _, file := range []string{s.config.CertFile, s.config.KeyFile} {
+		s.NoError(os.Chtimes(file, future, future))
+	}
+	time.Sleep(2 * time.Millisecond)
+	cert, _ = store.current()
+	s.Equal(int64(3), s.serial(cert))
+
+	// broken files keep the previous certificate
+	s.NoError(ioutil.WriteFile(s.config.CertFile, []byte("garbage"), 0600))
+	s.NoError(os.Chtimes(s.config.CertFile, future.Add(time.Minute), future.Add(time.Minute)))
+	time.Sleep(2 * time.Millisecond)
+	cert, _ = store.current()
+	s.Equal(int64(3), s.serial(cert))
+}
+
+func (s *tlsSuite) TestOutboundCallOverTLS() {
+	store, err := newCertificateStore(s.config, s.logger)
+	s.NoError(err)
+	server, _ := s.startChannel("server", store)
+	server.Register(raw.Wrap(identityHandler{}), "identity")
+	client, clientListener := s.startChannel("client", store)
+	hostPort := server.PeerInfo().HostPort
+
+	outbound := newTLSOutbound(hostPort, client, clientListener, store, s.logger)
+	outbound.start()
+	defer outbound.close()
+	outbound.waitForStart()
+	ctx, cancel := tcg.NewContext(time.Second)
+	defer cancel()
+	_, identity, _, err := raw.Call(ctx, client, hostPort, "server", "identity", nil, nil)
+	s.NoError(err)
+	s.Equal("cadence", string(identity))
+
+	// the call was made over the injected TLS connection, tchannel did not dial the server itself
+	inbound, outbounds := client.RootPeers().GetOrAdd(hostPort).NumConnections()
+	s.Equal(1, inbound)
+	s.Equal(0, outbounds)
+}
+
+func (s *tlsSuite) TestOutboundFollowsRingMembership() {
+	store, err := newCertificateStore(s.config, s.logger)
+	s.NoError(err)
+	server, _ := s.startChannel("server", store)
+	client, clientListener := s.startChannel("client", store)
+	hostPort := server.PeerInfo().HostPort
+	host := []*membership.HostInfo{membership.NewHostInfo(hostPort, nil)}
+	factory := &RPCFactory{
+		logger:        s.logger,
+		certStore:     store,
+		tlsChannel:    client,
+		tlsListener:   clientListener,
+		outbounds:     make(map[string]*tlsOutbound),
+		outboundHosts: make(map[string]struct{}),
+	}
+
+	factory.ensureTLSOutbound(hostPort)
+	s.True(s.waitForConnections(client, hostPort, 1))
+
+	// the connection is closed and not dialed again once the host left the ring
+	factory.handleRingMembershipChange(&membership.ChangedEvent{HostsRemoved: host})
+	s.Empty(factory.outbounds)
+	s.True(s.waitForConnections(client, hostPort, 0))
+
+	factory.handleRingMembershipChange(&membership.ChangedEvent{HostsAdded: host})
+	s.Len(factory.outbounds, 1)
+	s.True(s.waitForConnections(client, hostPort, 1))
+
+	// hosts no dispatcher was created for are left alone
+	other := []*membership.HostInfo{membership.NewHostInfo("127.0.0.1:1", nil)}
+	factory.handleRingMembershipChange(&membership.ChangedEvent{HostsAdded: other})
+	s.Len(factory.outbounds, 1)
+}
+
+func (s *tlsSuite) TestPlaintextPeerHasNoIdentity() {
+	store, err := newCertificateStore(s.config, s.logger)
+	s.NoError(err)
+	server, _ := s.startChannel("server", store)
+	server.GetSubChannel(ringpopServiceName).Register(raw.Wrap(identityHandler{}), "identity")
+
+	client, err := tcg.NewChannel("client", &tcg.ChannelOptions{
+		ProcessName: "client" + tlsPeerIdentitySeparator + "cadence",
+		Logger:      tcg.NullLogger,
+	})
+	s.NoError(err)
+	s.channels = append(s.channels, client)
+	ctx, cancel := tcg.NewContext(time.Second)
+	defer cancel()
+	_, identity, _, err := raw.Call(ctx, client, server.PeerInfo().HostPort, ringpopServiceName, "identity", nil, nil)
+	s.NoError(err)
+	s.Empty(identity)
+}
+
+func (s *tlsSuite) TestListenerRejectsClientWithoutCertificate() {
+	store, err := newCertificateStore(s.config, s.logger)
+	s.NoError(err)
+	addr := s.startEchoServer(store)
+
+	config := store.clientConfig("cadence")
+	config.Certificates = nil
+	conn, err := tls.Dial("tcp", addr, config)
+	if err == nil {
+		// TLS 1.3 reports the rejected client certificate on the first read
+		defer conn.Close()
+		conn.Write(callReqFrame("cadence-frontend"))
+		_, err = conn.Read(make([]byte, 1))
+	}
+	s.Error(err)
+}
+
+func (s *tlsSuite) TestPlaintextOnlyAllowsRingpop() {
+	store, err := newCertificateStore(s.config, s.logger)
+	s.NoError(err)
+	addr := s.startEchoServer(store)
+
+	conn, err := net.Dial("tcp", addr)
+	s.NoError(err)
+	defer conn.Close()
+	frame := callReqFrame(ringpopServiceName)
+	_, err = conn.Write(frame)
+	s.NoError(err)
+	echo := make([]byte, len(frame))
+	_, err = io.ReadFull(conn, echo)
+	s.NoError(err)
+	s.Equal(frame, echo)
+
+	_, err = conn.Write(callReqFrame("cadence-frontend"))
+	s.NoError(err)
+	_, err = io.ReadFull(conn, echo)
+	s.Error(err)
+}
+
+func (s *tlsSuite) startEchoServer(store *certificateStore) string {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	s.NoError(err)
+	listener := newTLSListener(l, store, []string{ringpopServiceName}, s.logger)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				io.Copy(conn, conn)
+			}()
+		}
+	}()
+	s.listeners = append(s.listeners, listener)
+	return l.Addr().String()
+}
+
+func (s *tlsSuite) startChannel(serviceName string, store *certificateStore) (*tcg.Channel, *tlsListener) {
+	ch, err := tcg.NewChannel(serviceName, &tcg.ChannelOptions{Logger: tcg.NullLogger})
+	s.NoError(err)
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	s.NoError(err)
+	listener := newTLSListener(l, store, []string{ringpopServiceName}, s.logger)
+	s.NoError(ch.Serve(listener))
+	s.channels = append(s.channels, ch)
+	s.listeners = append(s.listeners, listener)
+	return ch, listener
+}
+
+// waitForConnections waits until the channel has the given number of connections to the host
+func (s *tlsSuite) waitForConnections(ch *tcg.Channel, hostPort string, count int) bool {
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		inbound, outbound := ch.RootPeers().GetOrAdd(hostPort).NumConnections()
+		if inbound+outbound == count {
+			return true
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return false
+}
+
+func (s *tlsSuite) issueCertificate(serial int64) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	s.NoError(err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "cadence"},
+		DNSNames:     []string{"cadence"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, s.caCert, &key.PublicKey, s.caKey)
+	s.NoError(err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	s.NoError(err)
+	s.writePEM("cert.pem", "CERTIFICATE", der)
+	s.writePEM("key.pem", "EC PRIVATE KEY", keyDER)
+}
+
+func (s *tlsSuite) writePEM(name string, blockType string, der []byte) {
+	data := pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+	s.NoError(ioutil.WriteFile(filepath.Join(s.dir, name), data, 0600))
+}
+
+func (s *tlsSuite) serial(cert *tls.Certificate) int64 {
+	parsed, err := x509.ParseCertificate(cert.Certificate[0])
+	s.NoError(err)
+	return parsed.SerialNumber.Int64()
+}
+
+// callReqFrame builds a minimal tchannel call request frame for the service
+func callReqFrame(service string) []byte {
+	payload := make([]byte, tchannelCallReqServiceOffset+1+len(service))
+	payload[tchannelCallReqServiceOffset] = byte(len(service))
+	copy(payload[tchannelCallReqServiceOffset+1:], service)
+	frame := make([]byte, tchannelFrameHeaderSize+len(payload))
+	binary.BigEndian.PutUint16(frame, uint16(len(frame)))
+	frame[2] = tchannelFrameTypeCallReq
+	copy(frame[tchannelFrameHeaderSize:], payload)
+	return frame
+}
+
+func (h identityHandler) Handle(ctx context.Context, args *raw.Args) (*raw.Res, error) {
+	return &raw.Res{Arg3: []byte(TLSPeerIdentity(ctx))}, nil
+}
+
+func (h identityHandler) OnError(ctx context.Context, err error) {}
@@ -88,6 +88,9 @@ type DurationPropertyFnWithTaskListInfoFilters func(domain string, taskList stri
 // BoolPropertyFn is a wrapper to get bool property from dynamic config
 type BoolPropertyFn func(opts ...FilterOption) bool
 
+// BoolPropertyFnWithDomainFilter is a wrapper to get bool property from dynamic config with domain as filter
+type BoolPropertyFnWithDomainFilter func(domain string) bool
+
 // StringPropertyFnWithDomainFilter is a wrapper to get string property from dynamic config
 type StringPropertyFnWithDomainFilter func(domain string) string
 
@@ -219,6 +222,18 @@ func (c *Collection) GetBoolProperty(key Key, defaultValue bool) BoolPropertyFn
 	}
 }
 
+// GetBoolPropertyFilteredByDomain gets property with domain filter and asserts that it's a bool
+func (c *Collection) GetBoolPropertyFilteredByDomain(key Key, defaultValue bool) BoolPropertyFnWithDomainFilter {
+	return func(domain string) bool {
+		val, err := c.client.GetBoolValue(key, getFilterMap(DomainFilter(domain)), defaultValue)
+		if err != nil {
+			c.logNoValue(key, err)
+		}
+		c.logValue(key, val, defaultValue)
+		return val
+	}
+}
+
 // GetStringPropertyFnWithDomainFilter gets property with domain filter and asserts that its domain
 func (c *Collection) GetStringPropertyFnWithDomainFilter(key Key, defaultValue string) StringPropertyFnWithDomainFilter {
 	return func(domain string) string {
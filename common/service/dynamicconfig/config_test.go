@@ -191,6 +191,15 @@ func (s *configSuite) TestGetBoolProperty() {
 	s.Equal(false, value())
 }
 
+func (s *configSuite) TestGetBoolPropertyFilteredByDomain() {
+	key := testGetBoolPropertyFilteredByDomainKey
+	domain := "testDomain"
+	value := s.cln.GetBoolPropertyFilteredByDomain(key, false)
+	s.Equal(false, value(domain))
+	s.client.SetValue(key, true)
+	s.Equal(true, value(domain))
+}
+
 func (s *configSuite) TestGetBoolPropertyFilteredByTaskListInfo() {
 	key := testGetBoolPropertyFilteredByTaskListInfoKey
 	domain := "testDomain"
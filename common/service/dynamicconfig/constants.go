@@ -46,19 +46,36 @@ var keys = map[Key]string{
 	testGetIntPropertyFilteredByTaskListInfoKey:      "testGetIntPropertyFilteredByTaskListInfoKey",
 	testGetDurationPropertyFilteredByTaskListInfoKey: "testGetDurationPropertyFilteredByTaskListInfoKey",
 	testGetBoolPropertyFilteredByTaskListInfoKey:     "testGetBoolPropertyFilteredByTaskListInfoKey",
+	testGetBoolPropertyFilteredByDomainKey:           "testGetBoolPropertyFilteredByDomainKey",
 
 	// system settings
-	EnableGlobalDomain:       "system.enableGlobalDomain",
-	EnableNewKafkaClient:     "system.enableNewKafkaClient",
-	EnableVisibilitySampling: "system.enableVisibilitySampling",
+	EnableGlobalDomain:             "system.enableGlobalDomain",
+	EnableNewKafkaClient:           "system.enableNewKafkaClient",
+	EnableVisibilitySampling:       "system.enableVisibilitySampling",
+	ClusterMetadataRefreshInterval: "system.clusterMetadataRefreshInterval",
 
 	// frontend settings
-	FrontendPersistenceMaxQPS:      "frontend.persistenceMaxQPS",
-	FrontendVisibilityMaxPageSize:  "frontend.visibilityMaxPageSize",
-	FrontendHistoryMaxPageSize:     "frontend.historyMaxPageSize",
-	FrontendRPS:                    "frontend.rps",
-	FrontendHistoryMgrNumConns:     "frontend.historyMgrNumConns",
-	MaxDecisionStartToCloseTimeout: "frontend.maxDecisionStartToCloseTimeout",
+	FrontendPersistenceMaxQPS:              "frontend.persistenceMaxQPS",
+	FrontendVisibilityMaxPageSize:          "frontend.visibilityMaxPageSize",
+	FrontendHistoryMaxPageSize:             "frontend.historyMaxPageSize",
+	FrontendHistoryMaxLastEventCount:       "frontend.historyMaxLastEventCount",
+	FrontendRPS:                            "frontend.rps",
+	FrontendDomainRPS:                      "frontend.domainRPS",
+	FrontendDomainPollRPS:                  "frontend.domainPollRPS",
+	FrontendDomainStartRPS:                 "frontend.domainStartRPS",
+	FrontendDomainSignalRPS:                "frontend.domainSignalRPS",
+	FrontendDomainVisibilityRPS:            "frontend.domainVisibilityRPS",
+	FrontendEnableGlobalRateLimit:          "frontend.enableGlobalRateLimit",
+	FrontendHistoryMgrNumConns:             "frontend.historyMgrNumConns",
+	FrontendForwardPoll:                    "frontend.forwardPoll",
+	FrontendForwardStart:                   "frontend.forwardStart",
+	FrontendForwardSignal:                  "frontend.forwardSignal",
+	FrontendForwardAPI:                     "frontend.forwardAPI",
+	FrontendGracefulFailoverPollInterval:   "frontend.gracefulFailoverPollInterval",
+	FrontendGracefulFailoverMaxTimeout:     "frontend.gracefulFailoverMaxTimeout",
+	FrontendGracefulFailoverResumeInterval: "frontend.gracefulFailoverResumeInterval",
+	FrontendDomainFailoverHistoryMaxSize:   "frontend.domainFailoverHistoryMaxSize",
+	MaxDecisionStartToCloseTimeout:         "frontend.maxDecisionStartToCloseTimeout",
 
 	// matching settings
 	MatchingRPS:                             "matching.rps",
@@ -72,6 +89,10 @@ var keys = map[Key]string{
 	MaxTasklistIdleTime:                     "matching.maxTasklistIdleTime",
 	MatchingOutstandingTaskAppendsThreshold: "matching.outstandingTaskAppendsThreshold",
 	MatchingMaxTaskBatchSize:                "matching.maxTaskBatchSize",
+	MatchingEnableFairDispatch:              "matching.enableFairDispatch",
+	MatchingFairDispatchQuantum:             "matching.fairDispatchQuantum",
+	MatchingFairDispatchBufferSize:          "matching.fairDispatchBufferSize",
+	MatchingTaskListHandoffTimeout:          "matching.taskListHandoffTimeout",
 
 	// history settings
 	HistoryRPS:                                            "history.rps",
@@ -127,12 +148,24 @@ var keys = map[Key]string{
 	ExecutionMgrNumConns:                                  "history.executionMgrNumConns",
 	HistoryMgrNumConns:                                    "history.historyMgrNumConns",
 	MaximumBufferedEventsBatch:                            "history.maximumBufferedEventsBatch",
+	MaximumAcceptedUpdates:                                "history.maximumAcceptedUpdates",
 	ShardUpdateMinInterval:                                "history.shardUpdateMinInterval",
 	ShardSyncMinInterval:                                  "history.shardSyncMinInterval",
+	EnableRPCReplication:                                  "history.enableRPCReplication",
+	ReplicationTaskPollerWaitInterval:                     "history.replicationTaskPollerWaitInterval",
+	ReplicationTaskPollerMaxRetryCount:                    "history.replicationTaskPollerMaxRetryCount",
 	DefaultEventEncoding:                                  "history.defaultEventEncoding",
 
 	// worker settings
-	WorkerPersistenceMaxQPS: "worker.persistenceMaxQPS",
+	WorkerPersistenceMaxQPS:        "worker.persistenceMaxQPS",
+	EnableTaskListScavenger:        "worker.enableTaskListScavenger",
+	TaskListScavengerInterval:      "worker.taskListScavengerInterval",
+	TaskListScavengerMaxIdleTime:   "worker.taskListScavengerMaxIdleTime",
+	TaskListScavengerTaskBatchSize: "worker.taskListScavengerTaskBatchSize",
+	ReplicatorClusterSyncInterval:  "worker.replicatorClusterSyncInterval",
+	EnableDomainReconciler:         "worker.enableDomainReconciler",
+	DomainReconcilerInterval:       "worker.domainReconcilerInterval",
+	EnableDomainReconcilerRepair:   "worker.enableDomainReconcilerRepair",
 }
 
 const (
@@ -149,6 +182,7 @@ const (
 	testGetIntPropertyFilteredByTaskListInfoKey
 	testGetDurationPropertyFilteredByTaskListInfoKey
 	testGetBoolPropertyFilteredByTaskListInfoKey
+	testGetBoolPropertyFilteredByDomainKey
 
 	// EnableGlobalDomain is key for enable global domain
 	EnableGlobalDomain
@@ -156,6 +190,8 @@ const (
 	EnableNewKafkaClient
 	// EnableVisibilitySampling is key for enable visibility sampling
 	EnableVisibilitySampling
+	// ClusterMetadataRefreshInterval is the interval to reload the clusters registered in the metadata store
+	ClusterMetadataRefreshInterval
 
 	// key for frontend
 
@@ -165,10 +201,40 @@ const (
 	FrontendVisibilityMaxPageSize
 	// FrontendHistoryMaxPageSize is default max size for GetWorkflowExecutionHistory in one page
 	FrontendHistoryMaxPageSize
+	// FrontendHistoryMaxLastEventCount is the max number of last events GetWorkflowExecutionHistory returns
+	FrontendHistoryMaxLastEventCount
 	// FrontendRPS is workflow rate limit per second
 	FrontendRPS
+	// FrontendDomainRPS is the per domain rate limit for APIs without a dedicated quota
+	FrontendDomainRPS
+	// FrontendDomainPollRPS is the per domain rate limit for poll requests
+	FrontendDomainPollRPS
+	// FrontendDomainStartRPS is the per domain rate limit for workflow starts
+	FrontendDomainStartRPS
+	// FrontendDomainSignalRPS is the per domain rate limit for signals
+	FrontendDomainSignalRPS
+	// FrontendDomainVisibilityRPS is the per domain rate limit for visibility queries
+	FrontendDomainVisibilityRPS
+	// FrontendEnableGlobalRateLimit is whether the rate limits apply to the whole cluster instead of each frontend host
+	FrontendEnableGlobalRateLimit
 	// FrontendHistoryMgrNumConns is for persistence cluster.NumConns
 	FrontendHistoryMgrNumConns
+	// FrontendForwardPoll is whether polls of a domain active in another cluster are forwarded to that cluster
+	FrontendForwardPoll
+	// FrontendForwardStart is whether workflow starts of a domain active in another cluster are forwarded to that cluster
+	FrontendForwardStart
+	// FrontendForwardSignal is whether signals of a domain active in another cluster are forwarded to that cluster
+	FrontendForwardSignal
+	// FrontendForwardAPI is whether the other workflow APIs of a domain active in another cluster are forwarded to that cluster
+	FrontendForwardAPI
+	// FrontendGracefulFailoverPollInterval is how often a graceful failover checks whether replication has caught up
+	FrontendGracefulFailoverPollInterval
+	// FrontendGracefulFailoverMaxTimeout is the longest a graceful failover may wait for replication to catch up
+	FrontendGracefulFailoverMaxTimeout
+	// FrontendGracefulFailoverResumeInterval is how often a frontend looks for graceful failovers it is not draining
+	FrontendGracefulFailoverResumeInterval
+	// FrontendDomainFailoverHistoryMaxSize is how many of the most recent failovers are kept in the domain record
+	FrontendDomainFailoverHistoryMaxSize
 	// MaxDecisionStartToCloseTimeout is max decision timeout in seconds
 	MaxDecisionStartToCloseTimeout
 
@@ -196,6 +262,14 @@ const (
 	MatchingOutstandingTaskAppendsThreshold
 	// MatchingMaxTaskBatchSize is max batch size for task writer
 	MatchingMaxTaskBatchSize
+	// MatchingEnableFairDispatch is to interleave backlog dispatch across workflows (or fairness keys)
+	MatchingEnableFairDispatch
+	// MatchingFairDispatchQuantum is the number of tasks a fairness key dispatches in a row before yielding
+	MatchingFairDispatchQuantum
+	// MatchingFairDispatchBufferSize is the max number of backlog tasks buffered in memory for fair dispatch
+	MatchingFairDispatchBufferSize
+	// MatchingTaskListHandoffTimeout is the max time a new owner waits for the previous owner to release a task list
+	MatchingTaskListHandoffTimeout
 
 	// key for history
 
@@ -305,16 +379,42 @@ const (
 	HistoryMgrNumConns
 	// MaximumBufferedEventsBatch is max number of buffer event in mutable state
 	MaximumBufferedEventsBatch
+	// MaximumAcceptedUpdates is max number of accepted updates kept in mutable state to deduplicate retried updates
+	MaximumAcceptedUpdates
 	// ShardUpdateMinInterval is the minimal time interval which the shard info can be updated
 	ShardUpdateMinInterval
 	// ShardSyncMinInterval is the minimal time interval which the shard info should be sync to remote
 	ShardSyncMinInterval
+	// EnableRPCReplication switches cross cluster replication of history from kafka to remote clusters pulling replication tasks over RPC
+	EnableRPCReplication
+	// ReplicationTaskPollerWaitInterval is how long a replication task poller waits before polling again once a remote shard has no more tasks
+	ReplicationTaskPollerWaitInterval
+	// ReplicationTaskPollerMaxRetryCount is how many times in a row a replication task poller applies a failing task before moving it to the DLQ
+	ReplicationTaskPollerMaxRetryCount
 	// DefaultEventEncoding is the encoding type for history events
 	DefaultEventEncoding
 	// key for histoworkerry
 
 	// WorkerPersistenceMaxQPS is the max qps worker host can query DB
 	WorkerPersistenceMaxQPS
+	// EnableTaskListScavenger indicates if the task list scavenger should run on worker hosts
+	EnableTaskListScavenger
+	// TaskListScavengerInterval is the interval between two scavenger passes
+	TaskListScavengerInterval
+	// TaskListScavengerMaxIdleTime is how long a task list must go without being updated before it is scavenged
+	TaskListScavengerMaxIdleTime
+	// TaskListScavengerTaskBatchSize is the number of tasks read and deleted at a time by the scavenger
+	TaskListScavengerTaskBatchSize
+	// ReplicatorClusterSyncInterval is the interval to start and stop the replication task processors of the
+	// remote clusters which are added, enabled or disabled at runtime
+	ReplicatorClusterSyncInterval
+	// EnableDomainReconciler indicates if the domain reconciler should run on worker hosts
+	EnableDomainReconciler
+	// DomainReconcilerInterval is the interval between two domain reconciler passes
+	DomainReconcilerInterval
+	// EnableDomainReconcilerRepair indicates if the domain reconciler should repair the domain records of the
+	// current cluster with the remote records which have a higher config or failover version
+	EnableDomainReconcilerRepair
 
 	// lastKeyForTest must be the last one in this const group for testing purpose
 	lastKeyForTest
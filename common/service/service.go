@@ -63,6 +63,14 @@ type (
 		ReplicatorConfig config.Replicator
 		MessagingClient  messaging.Client
 		DynamicConfig    dynamicconfig.Client
+		Authorization    config.Authorization
+	}
+
+	// ringMembershipWatcher is implemented by the rpc factories which keep connections to the hosts in the
+	// rings of the cadence services
+	ringMembershipWatcher interface {
+		WatchRingMembership(monitor membership.Monitor, services []string) error
+		StopWatchingRingMembership()
 	}
 
 	// RingpopFactory provides a bootstrapped ringpop
@@ -177,6 +185,12 @@ func (h *serviceImpl) Start() {
 	}
 	h.hostInfo = hostInfo
 
+	if watcher, ok := h.rpcFactory.(ringMembershipWatcher); ok {
+		if err := watcher.WatchRingMembership(h.membershipMonitor, cadenceServices); err != nil {
+			h.logger.WithFields(bark.Fields{logging.TagErr: err}).Fatal("failed to watch ring membership for rpc")
+		}
+	}
+
 	h.clientFactory = client.NewRPCClientFactory(h.rpcFactory, h.membershipMonitor, h.metricsClient,
 		h.numberOfHistoryShards)
 
@@ -189,6 +203,10 @@ func (h *serviceImpl) Start() {
 
 // Stop closes the associated transport
 func (h *serviceImpl) Stop() {
+	if watcher, ok := h.rpcFactory.(ringMembershipWatcher); ok {
+		watcher.StopWatchingRingMembership()
+	}
+
 	if h.membershipMonitor != nil {
 		h.membershipMonitor.Stop()
 	}
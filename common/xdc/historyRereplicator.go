@@ -0,0 +1,197 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package xdc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/uber-common/bark"
+	"github.com/uber/cadence/.gen/go/admin"
+	h "github.com/uber/cadence/.gen/go/history"
+	"github.com/uber/cadence/.gen/go/replicator"
+	"github.com/uber/cadence/.gen/go/shared"
+	adminClient "github.com/uber/cadence/client/admin"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/logging"
+	"github.com/uber/cadence/common/persistence"
+)
+
+type (
+	// ReplicateEventsFn applies a batch of replicated history events on the current cluster
+	ReplicateEventsFn func(ctx context.Context, request *h.ReplicateEventsRequest) error
+
+	// HistoryRereplicator re-replicates the history events missing on the current cluster from the source cluster
+	HistoryRereplicator interface {
+		// SendHistory fetches the events [firstEventID, nextEventID) of the workflow run from the source cluster
+		// and applies them in order
+		SendHistory(sourceCluster string, domainID string, workflowID string, runID string, firstEventID int64,
+			nextEventID int64) error
+		// SendMissingHistory re-replicates the events the history replication task depends on, as indicated by
+		// the RetryTaskError returned when applying the task
+		SendMissingHistory(sourceCluster string, task *replicator.HistoryTaskAttributes, retryErr *shared.RetryTaskError) error
+	}
+
+	historyRereplicator struct {
+		domainCache       cache.DomainCache
+		adminClients      map[string]adminClient.Client
+		replicateEventsFn ReplicateEventsFn
+		serializer        persistence.HistorySerializer
+		logger            bark.Logger
+	}
+)
+
+const (
+	rereplicationPageSize = 100
+	rereplicationTimeout  = 30 * time.Second
+)
+
+var (
+	// ErrNoMissingHistory is the error to indicate the RetryTaskError does not point to missing events of the task's run
+	ErrNoMissingHistory = errors.New("retry task error does not indicate missing history of the workflow run")
+)
+
+var _ HistoryRereplicator = (*historyRereplicator)(nil)
+
+// NewHistoryRereplicator creates a new HistoryRereplicator, the admin clients are keyed by the remote cluster name
+func NewHistoryRereplicator(domainCache cache.DomainCache, adminClients map[string]adminClient.Client,
+	replicateEventsFn ReplicateEventsFn, serializer persistence.HistorySerializer, logger bark.Logger) HistoryRereplicator {
+	return &historyRereplicator{
+		domainCache:       domainCache,
+		adminClients:      adminClients,
+		replicateEventsFn: replicateEventsFn,
+		serializer:        serializer,
+		logger:            logger,
+	}
+}
+
+// SendMissingHistory re-replicates the events between the next event ID expected by the current cluster and
+// the first event of the task
+func (r *historyRereplicator) SendMissingHistory(sourceCluster string, task *replicator.HistoryTaskAttributes,
+	retryErr *shared.RetryTaskError) error {
+	if retryErr.RunId == nil || retryErr.GetRunId() != task.GetRunId() || retryErr.GetNextEventId() >= task.GetFirstEventId() {
+		return ErrNoMissingHistory
+	}
+
+	return r.SendHistory(sourceCluster, task.GetDomainId(), task.GetWorkflowId(), task.GetRunId(),
+		retryErr.GetNextEventId(), task.GetFirstEventId())
+}
+
+// SendHistory fetches the events [firstEventID, nextEventID) of the workflow run from the source cluster and
+// applies them in order
+func (r *historyRereplicator) SendHistory(sourceCluster string, domainID string, workflowID string, runID string,
+	firstEventID int64, nextEventID int64) error {
+	logger := r.logger.WithFields(bark.Fields{
+		logging.TagSourceCluster:       sourceCluster,
+		logging.TagDomainID:            domainID,
+		logging.TagWorkflowExecutionID: workflowID,
+		logging.TagWorkflowRunID:       runID,
+		logging.TagFirstEventID:        firstEventID,
+		logging.TagNextEventID:         nextEventID,
+	})
+
+	remoteAdminClient, ok := r.adminClients[sourceCluster]
+	if !ok {
+		return fmt.Errorf("no admin client for source cluster %v", sourceCluster)
+	}
+	domainEntry, err := r.domainCache.GetDomainByID(domainID)
+	if err != nil {
+		return err
+	}
+
+	execution := &shared.WorkflowExecution{
+		WorkflowId: common.StringPtr(workflowID),
+		RunId:      common.StringPtr(runID),
+	}
+	var pageToken []byte
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), rereplicationTimeout)
+		response, err := remoteAdminClient.GetWorkflowExecutionRawHistory(ctx, &admin.GetWorkflowExecutionRawHistoryRequest{
+			Domain:          common.StringPtr(domainEntry.GetInfo().Name),
+			Execution:       execution,
+			FirstEventId:    common.Int64Ptr(firstEventID),
+			NextEventId:     common.Int64Ptr(nextEventID),
+			MaximumPageSize: common.Int32Ptr(rereplicationPageSize),
+			NextPageToken:   pageToken,
+		})
+		cancel()
+		if err != nil {
+			logger.WithField(logging.TagErr, err).Warn("Failed to fetch missing history from source cluster.")
+			return err
+		}
+
+		for _, batch := range response.HistoryBatches {
+			events, err := r.serializer.DeserializeBatchEvents(convertDataBlob(batch))
+			if err != nil {
+				return err
+			}
+			if len(events) == 0 {
+				continue
+			}
+			if err := r.replicateEvents(sourceCluster, domainID, execution, events, response.ReplicationInfo); err != nil {
+				logger.WithField(logging.TagErr, err).Warn("Failed to apply missing history from source cluster.")
+				return err
+			}
+		}
+
+		pageToken = response.NextPageToken
+		if len(pageToken) == 0 {
+			break
+		}
+	}
+
+	logger.Info("Re-replicated missing history from source cluster.")
+	return nil
+}
+
+func (r *historyRereplicator) replicateEvents(sourceCluster string, domainID string, execution *shared.WorkflowExecution,
+	events []*shared.HistoryEvent, replicationInfo map[string]*shared.ReplicationInfo) error {
+	firstEvent := events[0]
+	lastEvent := events[len(events)-1]
+
+	ctx, cancel := context.WithTimeout(context.Background(), rereplicationTimeout)
+	defer cancel()
+	return r.replicateEventsFn(ctx, &h.ReplicateEventsRequest{
+		SourceCluster:     common.StringPtr(sourceCluster),
+		DomainUUID:        common.StringPtr(domainID),
+		WorkflowExecution: execution,
+		FirstEventId:      common.Int64Ptr(firstEvent.GetEventId()),
+		NextEventId:       common.Int64Ptr(lastEvent.GetEventId() + 1),
+		Version:           common.Int64Ptr(firstEvent.GetVersion()),
+		ReplicationInfo:   replicationInfo,
+		History:           &shared.History{Events: events},
+		ForceBufferEvents: common.BoolPtr(false),
+	})
+}
+
+func convertDataBlob(blob *shared.DataBlob) *persistence.DataBlob {
+	encoding := common.EncodingType(common.EncodingTypeThriftRW)
+	if blob.GetEncodingType() == shared.EncodingTypeJSON {
+		encoding = common.EncodingTypeJSON
+	}
+	return &persistence.DataBlob{
+		Encoding: encoding,
+		Data:     blob.Data,
+	}
+}
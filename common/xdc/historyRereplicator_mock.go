@@ -0,0 +1,62 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package xdc
+
+import (
+	"github.com/stretchr/testify/mock"
+	"github.com/uber/cadence/.gen/go/replicator"
+	"github.com/uber/cadence/.gen/go/shared"
+)
+
+// HistoryRereplicatorMock is an autogenerated mock type for the HistoryRereplicator type
+type HistoryRereplicatorMock struct {
+	mock.Mock
+}
+
+// SendHistory provides a mock function with given fields: sourceCluster, domainID, workflowID, runID, firstEventID, nextEventID
+func (_m *HistoryRereplicatorMock) SendHistory(sourceCluster string, domainID string, workflowID string, runID string, firstEventID int64, nextEventID int64) error {
+	ret := _m.Called(sourceCluster, domainID, workflowID, runID, firstEventID, nextEventID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, string, string, int64, int64) error); ok {
+		r0 = rf(sourceCluster, domainID, workflowID, runID, firstEventID, nextEventID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SendMissingHistory provides a mock function with given fields: sourceCluster, task, retryErr
+func (_m *HistoryRereplicatorMock) SendMissingHistory(sourceCluster string, task *replicator.HistoryTaskAttributes, retryErr *shared.RetryTaskError) error {
+	ret := _m.Called(sourceCluster, task, retryErr)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, *replicator.HistoryTaskAttributes, *shared.RetryTaskError) error); ok {
+		r0 = rf(sourceCluster, task, retryErr)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+var _ HistoryRereplicator = (*HistoryRereplicatorMock)(nil)
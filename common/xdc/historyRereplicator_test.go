@@ -0,0 +1,220 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package xdc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-common/bark"
+	"github.com/uber/cadence/.gen/go/admin"
+	"github.com/uber/cadence/.gen/go/admin/adminservicetest"
+	h "github.com/uber/cadence/.gen/go/history"
+	"github.com/uber/cadence/.gen/go/replicator"
+	"github.com/uber/cadence/.gen/go/shared"
+	adminClient "github.com/uber/cadence/client/admin"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/persistence"
+)
+
+type (
+	historyRereplicatorSuite struct {
+		suite.Suite
+		mockCtrl        *gomock.Controller
+		mockAdminClient *adminservicetest.MockClient
+		mockDomainCache *cache.DomainCacheMock
+		serializer      persistence.HistorySerializer
+		requests        []*h.ReplicateEventsRequest
+		replicateErr    error
+		rereplicator    *historyRereplicator
+	}
+)
+
+const (
+	testSourceCluster = "standby"
+	testDomainID      = "domain-id"
+	testDomainName    = "domain-name"
+	testWorkflowID    = "workflow-id"
+	testRunID         = "run-id"
+)
+
+func TestHistoryRereplicatorSuite(t *testing.T) {
+	s := new(historyRereplicatorSuite)
+	suite.Run(t, s)
+}
+
+func (s *historyRereplicatorSuite) SetupTest() {
+	s.mockCtrl = gomock.NewController(s.T())
+	s.mockAdminClient = adminservicetest.NewMockClient(s.mockCtrl)
+	s.mockDomainCache = &cache.DomainCacheMock{}
+	s.mockDomainCache.On("GetDomainByID", testDomainID).Return(
+		cache.NewDomainCacheEntryWithInfo(&persistence.DomainInfo{ID: testDomainID, Name: testDomainName}), nil).Maybe()
+	s.serializer = persistence.NewHistorySerializer()
+	s.requests = nil
+	s.replicateErr = nil
+	s.rereplicator = NewHistoryRereplicator(s.mockDomainCache,
+		map[string]adminClient.Client{testSourceCluster: s.mockAdminClient},
+		func(ctx context.Context, request *h.ReplicateEventsRequest) error {
+			s.requests = append(s.requests, request)
+			return s.replicateErr
+		},
+		s.serializer, bark.NewLoggerFromLogrus(logrus.New())).(*historyRereplicator)
+}
+
+func (s *historyRereplicatorSuite) TearDownTest() {
+	s.mockCtrl.Finish()
+	s.mockDomainCache.AssertExpectations(s.T())
+}
+
+func (s *historyRereplicatorSuite) TestSendHistory_MultiplePages() {
+	replicationInfo := map[string]*shared.ReplicationInfo{
+		testSourceCluster: {Version: common.Int64Ptr(10), LastEventId: common.Int64Ptr(5)},
+	}
+	s.mockAdminClient.EXPECT().GetWorkflowExecutionRawHistory(gomock.Any(), &admin.GetWorkflowExecutionRawHistoryRequest{
+		Domain:          common.StringPtr(testDomainName),
+		Execution:       &shared.WorkflowExecution{WorkflowId: common.StringPtr(testWorkflowID), RunId: common.StringPtr(testRunID)},
+		FirstEventId:    common.Int64Ptr(2),
+		NextEventId:     common.Int64Ptr(6),
+		MaximumPageSize: common.Int32Ptr(rereplicationPageSize),
+	}).Return(&admin.GetWorkflowExecutionRawHistoryResponse{
+		HistoryBatches:  []*shared.DataBlob{s.dataBlob(2, 3, common.EncodingTypeThriftRW)},
+		NextPageToken:   []byte("token"),
+		ReplicationInfo: replicationInfo,
+	}, nil)
+	s.mockAdminClient.EXPECT().GetWorkflowExecutionRawHistory(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, request *admin.GetWorkflowExecutionRawHistoryRequest) (*admin.GetWorkflowExecutionRawHistoryResponse, error) {
+			s.Equal([]byte("token"), request.NextPageToken)
+			return &admin.GetWorkflowExecutionRawHistoryResponse{
+				HistoryBatches:  []*shared.DataBlob{s.dataBlob(4, 5, common.EncodingTypeJSON)},
+				ReplicationInfo: replicationInfo,
+			}, nil
+		})
+
+	err := s.rereplicator.SendHistory(testSourceCluster, testDomainID, testWorkflowID, testRunID, 2, 6)
+	s.NoError(err)
+	s.Len(s.requests, 2)
+	s.Equal(int64(2), s.requests[0].GetFirstEventId())
+	s.Equal(int64(4), s.requests[0].GetNextEventId())
+	s.Equal(int64(4), s.requests[1].GetFirstEventId())
+	s.Equal(int64(6), s.requests[1].GetNextEventId())
+	for _, request := range s.requests {
+		s.Equal(testSourceCluster, request.GetSourceCluster())
+		s.Equal(testDomainID, request.GetDomainUUID())
+		s.Equal(testRunID, request.WorkflowExecution.GetRunId())
+		s.Equal(int64(10), request.GetVersion())
+		s.Equal(replicationInfo, request.ReplicationInfo)
+		s.False(request.GetForceBufferEvents())
+	}
+}
+
+func (s *historyRereplicatorSuite) TestSendHistory_ReplicateError() {
+	s.replicateErr = errors.New("some random error")
+	s.mockAdminClient.EXPECT().GetWorkflowExecutionRawHistory(gomock.Any(), gomock.Any()).Return(
+		&admin.GetWorkflowExecutionRawHistoryResponse{
+			HistoryBatches: []*shared.DataBlob{s.dataBlob(2, 3, common.EncodingTypeThriftRW)},
+			NextPageToken:  []byte("token"),
+		}, nil)
+
+	err := s.rereplicator.SendHistory(testSourceCluster, testDomainID, testWorkflowID, testRunID, 2, 6)
+	s.Equal(s.replicateErr, err)
+	s.Len(s.requests, 1)
+}
+
+func (s *historyRereplicatorSuite) TestSendHistory_UnknownCluster() {
+	err := s.rereplicator.SendHistory("unknown", testDomainID, testWorkflowID, testRunID, 2, 6)
+	s.Error(err)
+	s.Empty(s.requests)
+}
+
+func (s *historyRereplicatorSuite) TestSendMissingHistory() {
+	s.mockAdminClient.EXPECT().GetWorkflowExecutionRawHistory(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, request *admin.GetWorkflowExecutionRawHistoryRequest) (*admin.GetWorkflowExecutionRawHistoryResponse, error) {
+			s.Equal(int64(3), request.GetFirstEventId())
+			s.Equal(int64(7), request.GetNextEventId())
+			return &admin.GetWorkflowExecutionRawHistoryResponse{
+				HistoryBatches: []*shared.DataBlob{s.dataBlob(3, 6, common.EncodingTypeThriftRW)},
+			}, nil
+		})
+
+	err := s.rereplicator.SendMissingHistory(testSourceCluster, s.historyTask(7), s.retryTaskError(testRunID, 3))
+	s.NoError(err)
+	s.Len(s.requests, 1)
+}
+
+func (s *historyRereplicatorSuite) TestSendMissingHistory_NoMissingHistory() {
+	task := s.historyTask(7)
+	s.Equal(ErrNoMissingHistory, s.rereplicator.SendMissingHistory(testSourceCluster, task, &shared.RetryTaskError{}))
+	s.Equal(ErrNoMissingHistory, s.rereplicator.SendMissingHistory(testSourceCluster, task, s.retryTaskError("other-run-id", 3)))
+	s.Equal(ErrNoMissingHistory, s.rereplicator.SendMissingHistory(testSourceCluster, task, s.retryTaskError(testRunID, 7)))
+	s.Empty(s.requests)
+	s.mockDomainCache.AssertNotCalled(s.T(), "GetDomainByID", mock.Anything)
+}
+
+func (s *historyRereplicatorSuite) historyTask(firstEventID int64) *replicator.HistoryTaskAttributes {
+	return &replicator.HistoryTaskAttributes{
+		DomainId:     common.StringPtr(testDomainID),
+		WorkflowId:   common.StringPtr(testWorkflowID),
+		RunId:        common.StringPtr(testRunID),
+		FirstEventId: common.Int64Ptr(firstEventID),
+		NextEventId:  common.Int64Ptr(firstEventID + 1),
+		Version:      common.Int64Ptr(10),
+	}
+}
+
+func (s *historyRereplicatorSuite) retryTaskError(runID string, nextEventID int64) *shared.RetryTaskError {
+	return &shared.RetryTaskError{
+		DomainId:    common.StringPtr(testDomainID),
+		WorkflowId:  common.StringPtr(testWorkflowID),
+		RunId:       common.StringPtr(runID),
+		NextEventId: common.Int64Ptr(nextEventID),
+	}
+}
+
+func (s *historyRereplicatorSuite) dataBlob(firstEventID, lastEventID int64, encoding common.EncodingType) *shared.DataBlob {
+	var events []*shared.HistoryEvent
+	for eventID := firstEventID; eventID <= lastEventID; eventID++ {
+		events = append(events, &shared.HistoryEvent{
+			EventId:   common.Int64Ptr(eventID),
+			Version:   common.Int64Ptr(10),
+			EventType: shared.EventTypeDecisionTaskScheduled.Ptr(),
+		})
+	}
+	blob, err := s.serializer.SerializeBatchEvents(events, encoding)
+	s.NoError(err)
+	return convertPersistenceDataBlob(blob)
+}
+
+func convertPersistenceDataBlob(blob *persistence.DataBlob) *shared.DataBlob {
+	encoding := shared.EncodingTypeThriftRW
+	if blob.Encoding == common.EncodingTypeJSON {
+		encoding = shared.EncodingTypeJSON
+	}
+	return &shared.DataBlob{
+		EncodingType: encoding.Ptr(),
+		Data:         blob.Data,
+	}
+}
@@ -291,7 +291,8 @@ func (c *cadenceImpl) startFrontend(rpHosts []string, startWG *sync.WaitGroup) {
 
 	c.frontEndService = service.New(params)
 	c.frontendHandler = frontend.NewWorkflowHandler(
-		c.frontEndService, frontend.NewConfig(dynamicconfig.NewNopCollection()), c.metadataMgr, c.historyMgr, c.visibilityMgr, kafkaProducer)
+		c.frontEndService, frontend.NewConfig(dynamicconfig.NewNopCollection(), c.numberOfHistoryShards), c.metadataMgr, c.historyMgr, c.visibilityMgr, kafkaProducer,
+		config.Authorization{})
 	err = c.frontendHandler.Start()
 	if err != nil {
 		c.logger.WithField("error", err).Fatal("Failed to start frontend")
@@ -371,7 +372,7 @@ func (c *cadenceImpl) startWorker(rpHosts []string, startWG *sync.WaitGroup) {
 
 	workerConfig := worker.NewConfig(dynamicconfig.NewNopCollection())
 	workerConfig.ReplicatorConcurrency = 10
-	c.replicator = worker.NewReplicator(c.clusterMetadata, metadataManager, historyClient,
+	c.replicator = worker.NewReplicator(c.clusterMetadata, metadataManager, historyClient, service.GetClientFactory(),
 		workerConfig, c.messagingClient, c.logger, service.GetMetricsClient())
 	if err := c.replicator.Start(); err != nil {
 		c.replicator.Stop()
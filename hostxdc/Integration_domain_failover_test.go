@@ -125,6 +125,7 @@ func (s *testCluster) setupCluster(no int) {
 		clusterInfo.MasterClusterName,
 		clusterInfo.CurrentClusterName,
 		clusterInfo.ClusterInitialFailoverVersions,
+		clusterInfo.ClusterAddress,
 	)
 	cassandra.InitTestSuiteWithMetadata(&s.TestBase, &options, metadata)
 	s.setupShards()
@@ -173,7 +174,7 @@ func (s *testCluster) createMessagingClient() messaging.Client {
 		Topics:         topics,
 		ClusterToTopic: clusterToTopic,
 	}
-	return messaging.NewKafkaClient(&kafkaConfig, zap.NewNop(), s.logger, tally.NoopScope)
+	return messaging.NewKafkaClient(&kafkaConfig, nil, zap.NewNop(), s.logger, tally.NoopScope)
 }
 
 func getTopicList(topicName string) messaging.TopicList {
@@ -21,6 +21,7 @@
 namespace java com.uber.cadence.admin
 
 include "shared.thrift"
+include "replicator.thrift"
 
 /**
 * AdminService provides advanced APIs for debugging and analysis with admin privillege
@@ -46,6 +47,131 @@ service AdminService {
         2: shared.InternalServiceError  internalServiceError,
         3: shared.AccessDeniedError     accessDeniedError,
       )
+
+  /**
+    * GetReplicationMessages returns new replication tasks of the requested shards for the polling cluster
+    **/
+    replicator.GetReplicationMessagesResponse GetReplicationMessages(1: replicator.GetReplicationMessagesRequest request)
+      throws (
+        1: shared.BadRequestError       badRequestError,
+        2: shared.InternalServiceError  internalServiceError,
+        3: shared.LimitExceededError    limitExceededError,
+        4: shared.ServiceBusyError      serviceBusyError,
+      )
+
+  /**
+    * GetWorkflowExecutionRawHistory returns the serialized history batches of a workflow run for an event range,
+    * along with the replication info of the run, used to re-replicate the events missing on another cluster
+    **/
+    GetWorkflowExecutionRawHistoryResponse GetWorkflowExecutionRawHistory(1: GetWorkflowExecutionRawHistoryRequest getRequest)
+      throws (
+        1: shared.BadRequestError       badRequestError,
+        2: shared.InternalServiceError  internalServiceError,
+        3: shared.EntityNotExistsError  entityNotExistError,
+        4: shared.ServiceBusyError      serviceBusyError,
+        5: shared.AccessDeniedError     accessDeniedError,
+      )
+
+  /**
+    * ImportWorkflowExecution recreates a workflow run from the history batches exported by
+    * GetWorkflowExecutionRawHistory, used to load production histories into another cluster for debugging
+    **/
+    void ImportWorkflowExecution(1: ImportWorkflowExecutionRequest importRequest)
+      throws (
+        1: shared.BadRequestError                      badRequestError,
+        2: shared.InternalServiceError                 internalServiceError,
+        3: shared.EntityNotExistsError                 entityNotExistError,
+        4: shared.WorkflowExecutionAlreadyStartedError workflowAlreadyStartedError,
+        5: shared.ServiceBusyError                     serviceBusyError,
+        6: shared.AccessDeniedError                    accessDeniedError,
+      )
+
+  /**
+    * ReadDLQMessages returns the replication tasks in the DLQ which have not been purged yet
+    **/
+    ReadDLQMessagesResponse ReadDLQMessages(1: ReadDLQMessagesRequest request)
+      throws (
+        1: shared.BadRequestError       badRequestError,
+        2: shared.InternalServiceError  internalServiceError,
+        3: shared.EntityNotExistsError  entityNotExistError,
+        4: shared.AccessDeniedError     accessDeniedError,
+      )
+
+  /**
+    * PurgeDLQMessages discards the DLQ messages of a partition up to and including the given offset
+    **/
+    void PurgeDLQMessages(1: PurgeDLQMessagesRequest request)
+      throws (
+        1: shared.BadRequestError       badRequestError,
+        2: shared.InternalServiceError  internalServiceError,
+        3: shared.AccessDeniedError     accessDeniedError,
+      )
+
+  /**
+    * MergeDLQMessages re-applies the given DLQ messages through the history replication path
+    **/
+    void MergeDLQMessages(1: MergeDLQMessagesRequest request)
+      throws (
+        1: shared.BadRequestError       badRequestError,
+        2: shared.InternalServiceError  internalServiceError,
+        3: shared.EntityNotExistsError  entityNotExistError,
+        4: shared.AccessDeniedError     accessDeniedError,
+        5: shared.RetryTaskError        retryTaskError,
+      )
+
+  /**
+    * DescribeReplicationStatus returns the replication levels of every shard for each remote cluster and
+    * the offsets of the consumers receiving replication tasks from the remote clusters
+    **/
+    DescribeReplicationStatusResponse DescribeReplicationStatus(1: DescribeReplicationStatusRequest request)
+      throws (
+        1: shared.BadRequestError       badRequestError,
+        2: shared.InternalServiceError  internalServiceError,
+        3: shared.AccessDeniedError     accessDeniedError,
+      )
+
+  /**
+    * AddCluster registers a cluster in the metadata store of the current cluster, the cluster is enabled and
+    * picked up by the current cluster without a restart
+    **/
+    void AddCluster(1: AddClusterRequest request)
+      throws (
+        1: shared.BadRequestError       badRequestError,
+        2: shared.InternalServiceError  internalServiceError,
+        3: shared.AccessDeniedError     accessDeniedError,
+      )
+
+  /**
+    * UpdateCluster enables or disables a cluster, or changes its frontend address or replication topic
+    **/
+    void UpdateCluster(1: UpdateClusterRequest request)
+      throws (
+        1: shared.BadRequestError       badRequestError,
+        2: shared.InternalServiceError  internalServiceError,
+        3: shared.EntityNotExistsError  entityNotExistError,
+        4: shared.AccessDeniedError     accessDeniedError,
+      )
+
+  /**
+    * ListClusters returns the clusters in the static config and the ones registered in the metadata store
+    **/
+    ListClustersResponse ListClusters(1: ListClustersRequest request)
+      throws (
+        1: shared.BadRequestError       badRequestError,
+        2: shared.InternalServiceError  internalServiceError,
+        3: shared.AccessDeniedError     accessDeniedError,
+      )
+
+  /**
+    * ListGlobalDomains returns the records of the global domains stored in the current cluster, in the form of
+    * domain replication tasks, used to reconcile the domain metadata between clusters
+    **/
+    ListGlobalDomainsResponse ListGlobalDomains(1: ListGlobalDomainsRequest request)
+      throws (
+        1: shared.BadRequestError       badRequestError,
+        2: shared.InternalServiceError  internalServiceError,
+        3: shared.AccessDeniedError     accessDeniedError,
+      )
 }
 
 struct DescribeWorkflowExecutionRequest {
@@ -58,4 +184,106 @@ struct DescribeWorkflowExecutionResponse{
   20: optional string historyAddr
   40: optional string mutableStateInCache
   50: optional string mutableStateInDatabase
-}
\ No newline at end of file
+}
+struct GetWorkflowExecutionRawHistoryRequest {
+  10: optional string domain
+  20: optional shared.WorkflowExecution execution
+  30: optional i64 (js.type = "Long") firstEventId
+  40: optional i64 (js.type = "Long") nextEventId
+  50: optional i32 maximumPageSize
+  60: optional binary nextPageToken
+}
+
+struct GetWorkflowExecutionRawHistoryResponse {
+  10: optional binary nextPageToken
+  20: optional list<shared.DataBlob> historyBatches
+  30: optional map<string, shared.ReplicationInfo> replicationInfo
+}
+
+struct ImportWorkflowExecutionRequest {
+  10: optional string domain
+  20: optional shared.WorkflowExecution execution
+  30: optional list<shared.DataBlob> historyBatches
+}
+
+struct ReadDLQMessagesRequest {
+  10: optional i32 shardID
+  20: optional string domain
+  30: optional i32 maximumPageSize
+  40: optional binary nextPageToken
+}
+
+struct ReadDLQMessagesResponse {
+  10: optional list<replicator.DLQMessage> messages
+  20: optional binary nextPageToken
+}
+
+struct PurgeDLQMessagesRequest {
+  10: optional i32 partition
+  20: optional i64 (js.type = "Long") inclusiveEndOffset
+}
+
+struct MergeDLQMessagesRequest {
+  10: optional list<replicator.DLQMessageID> messageIDs
+}
+
+struct DescribeReplicationStatusRequest {
+  // shardIDs defaults to all shards of the cluster
+  10: optional list<i32> shardIDs
+}
+
+struct ReplicationConsumerStatus {
+  10: optional string consumerName
+  20: optional list<replicator.ConsumerPartitionOffset> partitions
+}
+
+struct DescribeReplicationStatusResponse {
+  10: optional map<i32, replicator.ShardReplicationStatus> statusByShard
+  20: optional map<string, ReplicationConsumerStatus> consumersBySourceCluster
+}
+
+struct ClusterInfo {
+  10: optional string clusterName
+  20: optional i64 (js.type = "Long") initialFailoverVersion
+  30: optional bool enabled
+  40: optional string rpcName
+  50: optional string rpcAddress
+  // replicationTopic is empty if the topic in the static config is used
+  60: optional string replicationTopic
+  70: optional bool isCurrentCluster
+  // isRegistered is false for the clusters which are only in the static config
+  80: optional bool isRegistered
+}
+
+struct AddClusterRequest {
+  10: optional string clusterName
+  20: optional i64 (js.type = "Long") initialFailoverVersion
+  30: optional string rpcName
+  40: optional string rpcAddress
+  50: optional string replicationTopic
+}
+
+struct UpdateClusterRequest {
+  10: optional string clusterName
+  20: optional bool enabled
+  30: optional string rpcName
+  40: optional string rpcAddress
+  50: optional string replicationTopic
+}
+
+struct ListClustersRequest {
+}
+
+struct ListClustersResponse {
+  10: optional list<ClusterInfo> clusters
+}
+
+struct ListGlobalDomainsRequest {
+  10: optional i32 pageSize
+  20: optional binary nextPageToken
+}
+
+struct ListGlobalDomainsResponse {
+  10: optional list<replicator.DomainTaskAttributes> domains
+  20: optional binary nextPageToken
+}
@@ -43,6 +43,7 @@ service WorkflowService {
       2: shared.InternalServiceError internalServiceError,
       3: shared.DomainAlreadyExistsError domainExistsError,
       4: shared.ServiceBusyError serviceBusyError,
+      5: shared.AccessDeniedError accessDeniedError,
     )
 
   /**
@@ -54,6 +55,7 @@ service WorkflowService {
       2: shared.InternalServiceError internalServiceError,
       3: shared.EntityNotExistsError entityNotExistError,
       4: shared.ServiceBusyError serviceBusyError,
+      5: shared.AccessDeniedError accessDeniedError,
     )
 
   /**
@@ -65,6 +67,7 @@ service WorkflowService {
         2: shared.InternalServiceError internalServiceError,
         3: shared.EntityNotExistsError entityNotExistError,
         4: shared.ServiceBusyError serviceBusyError,
+        5: shared.AccessDeniedError accessDeniedError,
       )
 
   /**
@@ -77,6 +80,7 @@ service WorkflowService {
         3: shared.EntityNotExistsError entityNotExistError,
         4: shared.ServiceBusyError serviceBusyError,
         5: shared.DomainNotActiveError domainNotActiveError,
+        6: shared.AccessDeniedError accessDeniedError,
       )
 
   /**
@@ -91,6 +95,7 @@ service WorkflowService {
       3: shared.EntityNotExistsError entityNotExistError,
       4: shared.ServiceBusyError serviceBusyError,
       5: shared.DomainNotActiveError domainNotActiveError,
+      6: shared.AccessDeniedError accessDeniedError,
     )
 
   /**
@@ -108,6 +113,7 @@ service WorkflowService {
       5: shared.DomainNotActiveError domainNotActiveError,
       6: shared.LimitExceededError limitExceededError,
       7: shared.EntityNotExistsError entityNotExistError,
+      8: shared.AccessDeniedError accessDeniedError,
     )
 
   /**
@@ -120,6 +126,22 @@ service WorkflowService {
       2: shared.InternalServiceError internalServiceError,
       3: shared.EntityNotExistsError entityNotExistError,
       4: shared.ServiceBusyError serviceBusyError,
+      5: shared.AccessDeniedError accessDeniedError,
+    )
+
+  /**
+  * GetWorkflowExecutionResult long polls until the specified workflow execution is closed and returns its close
+  * status together with its result or failure.  When the long poll expires before the run is closed, the response
+  * has 'isWorkflowRunning' set and the caller is expected to call again.  It fails with 'EntityNotExistError' if
+  * specified workflow execution in unknown to the service.
+  **/
+  shared.GetWorkflowExecutionResultResponse GetWorkflowExecutionResult(1: shared.GetWorkflowExecutionResultRequest getRequest)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.EntityNotExistsError entityNotExistError,
+      4: shared.ServiceBusyError serviceBusyError,
+      5: shared.AccessDeniedError accessDeniedError,
     )
 
   /**
@@ -137,6 +159,7 @@ service WorkflowService {
       4: shared.LimitExceededError limitExceededError,
       5: shared.EntityNotExistsError entityNotExistError,
       6: shared.DomainNotActiveError domainNotActiveError,
+      7: shared.AccessDeniedError accessDeniedError,
     )
 
   /**
@@ -155,6 +178,7 @@ service WorkflowService {
       4: shared.DomainNotActiveError domainNotActiveError,
       5: shared.LimitExceededError limitExceededError,
       6: shared.ServiceBusyError serviceBusyError,
+      7: shared.AccessDeniedError accessDeniedError,
     )
 
   /**
@@ -171,6 +195,7 @@ service WorkflowService {
       4: shared.DomainNotActiveError domainNotActiveError,
       5: shared.LimitExceededError limitExceededError,
       6: shared.ServiceBusyError serviceBusyError,
+      7: shared.AccessDeniedError accessDeniedError,
     )
 
   /**
@@ -190,6 +215,7 @@ service WorkflowService {
       4: shared.LimitExceededError limitExceededError,
       5: shared.EntityNotExistsError entityNotExistError,
       6: shared.DomainNotActiveError domainNotActiveError,
+      7: shared.AccessDeniedError accessDeniedError,
     )
 
   /**
@@ -207,6 +233,7 @@ service WorkflowService {
       4: shared.DomainNotActiveError domainNotActiveError,
       5: shared.LimitExceededError limitExceededError,
       6: shared.ServiceBusyError serviceBusyError,
+      7: shared.AccessDeniedError accessDeniedError,
     )
 
   /**
@@ -224,6 +251,7 @@ service WorkflowService {
       4: shared.DomainNotActiveError domainNotActiveError,
       5: shared.LimitExceededError limitExceededError,
       6: shared.ServiceBusyError serviceBusyError,
+      7: shared.AccessDeniedError accessDeniedError,
     )
 
   /**
@@ -241,6 +269,7 @@ service WorkflowService {
       4: shared.DomainNotActiveError domainNotActiveError,
       5: shared.LimitExceededError limitExceededError,
       6: shared.ServiceBusyError serviceBusyError,
+      7: shared.AccessDeniedError accessDeniedError,
     )
 
   /**
@@ -258,6 +287,7 @@ service WorkflowService {
       4: shared.DomainNotActiveError domainNotActiveError,
       5: shared.LimitExceededError limitExceededError,
       6: shared.ServiceBusyError serviceBusyError,
+      7: shared.AccessDeniedError accessDeniedError,
     )
 
   /**
@@ -275,6 +305,7 @@ service WorkflowService {
       4: shared.DomainNotActiveError domainNotActiveError,
       5: shared.LimitExceededError limitExceededError,
       6: shared.ServiceBusyError serviceBusyError,
+      7: shared.AccessDeniedError accessDeniedError,
     )
 
   /**
@@ -292,6 +323,7 @@ service WorkflowService {
       4: shared.DomainNotActiveError domainNotActiveError,
       5: shared.LimitExceededError limitExceededError,
       6: shared.ServiceBusyError serviceBusyError,
+      7: shared.AccessDeniedError accessDeniedError,
     )
 
   /**
@@ -309,6 +341,7 @@ service WorkflowService {
       4: shared.DomainNotActiveError domainNotActiveError,
       5: shared.LimitExceededError limitExceededError,
       6: shared.ServiceBusyError serviceBusyError,
+      7: shared.AccessDeniedError accessDeniedError,
     )
 
   /**
@@ -326,6 +359,7 @@ service WorkflowService {
       4: shared.DomainNotActiveError domainNotActiveError,
       5: shared.LimitExceededError limitExceededError,
       6: shared.ServiceBusyError serviceBusyError,
+      7: shared.AccessDeniedError accessDeniedError,
     )
 
   /**
@@ -343,6 +377,7 @@ service WorkflowService {
       5: shared.ServiceBusyError serviceBusyError,
       6: shared.DomainNotActiveError domainNotActiveError,
       7: shared.LimitExceededError limitExceededError,
+      8: shared.AccessDeniedError accessDeniedError,
     )
 
   /**
@@ -357,6 +392,23 @@ service WorkflowService {
       4: shared.ServiceBusyError serviceBusyError,
       5: shared.DomainNotActiveError domainNotActiveError,
       6: shared.LimitExceededError limitExceededError,
+      7: shared.AccessDeniedError accessDeniedError,
+    )
+
+  /**
+  * UpdateWorkflowExecution is used to send an update request to a running workflow execution. The update is
+  * delivered to the workflow as part of a decision task, and the call blocks until a decision accepts or rejects
+  * it. Accepted updates result in WorkflowExecutionUpdateAccepted event recorded in the history.
+  **/
+  shared.UpdateWorkflowExecutionResponse UpdateWorkflowExecution(1: shared.UpdateWorkflowExecutionRequest updateRequest)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.EntityNotExistsError entityNotExistError,
+      4: shared.ServiceBusyError serviceBusyError,
+      5: shared.DomainNotActiveError domainNotActiveError,
+      6: shared.LimitExceededError limitExceededError,
+      7: shared.AccessDeniedError accessDeniedError,
     )
 
   /**
@@ -374,6 +426,7 @@ service WorkflowService {
       4: shared.ServiceBusyError serviceBusyError,
       5: shared.DomainNotActiveError domainNotActiveError,
       6: shared.LimitExceededError limitExceededError,
+      7: shared.AccessDeniedError accessDeniedError,
     )
 
   /**
@@ -388,6 +441,7 @@ service WorkflowService {
       4: shared.ServiceBusyError serviceBusyError,
       5: shared.DomainNotActiveError domainNotActiveError,
       6: shared.LimitExceededError limitExceededError,
+      7: shared.AccessDeniedError accessDeniedError,
     )
 
   /**
@@ -400,6 +454,7 @@ service WorkflowService {
       3: shared.EntityNotExistsError entityNotExistError,
       4: shared.ServiceBusyError serviceBusyError,
       5: shared.LimitExceededError limitExceededError,
+      6: shared.AccessDeniedError accessDeniedError,
     )
 
   /**
@@ -411,6 +466,7 @@ service WorkflowService {
       2: shared.InternalServiceError internalServiceError,
       3: shared.EntityNotExistsError entityNotExistError,
       4: shared.ServiceBusyError serviceBusyError,
+      5: shared.AccessDeniedError accessDeniedError,
     )
 
   /**
@@ -426,6 +482,7 @@ service WorkflowService {
       4: shared.LimitExceededError limitExceededError,
       5: shared.ServiceBusyError serviceBusyError,
       6: shared.DomainNotActiveError domainNotActiveError,
+      7: shared.AccessDeniedError accessDeniedError,
     )
 
   /**
@@ -445,6 +502,7 @@ service WorkflowService {
       4: shared.LimitExceededError limitExceededError,
       5: shared.ServiceBusyError serviceBusyError,
       6: shared.DomainNotActiveError domainNotActiveError,
+      7: shared.AccessDeniedError accessDeniedError,
     )
 
   /**
@@ -458,6 +516,7 @@ service WorkflowService {
 	  4: shared.QueryFailedError queryFailedError,
 	  5: shared.LimitExceededError limitExceededError,
       6: shared.ServiceBusyError serviceBusyError,
+      7: shared.AccessDeniedError accessDeniedError,
 	)
 
   /**
@@ -470,6 +529,7 @@ service WorkflowService {
       3: shared.EntityNotExistsError entityNotExistError,
       4: shared.LimitExceededError limitExceededError,
       5: shared.ServiceBusyError serviceBusyError,
+      6: shared.AccessDeniedError accessDeniedError,
     )
 
   /**
@@ -483,6 +543,21 @@ service WorkflowService {
       3: shared.EntityNotExistsError entityNotExistError,
       4: shared.LimitExceededError limitExceededError,
       5: shared.ServiceBusyError serviceBusyError,
+      6: shared.AccessDeniedError accessDeniedError,
+    )
+
+  /**
+  * ListTaskLists returns the task lists of a domain along with their kind, ack level, last update time and
+  * whether they are currently loaded by a matching host.
+  **/
+  shared.ListTaskListsResponse ListTaskLists(1: shared.ListTaskListsRequest request)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.EntityNotExistsError entityNotExistError,
+      4: shared.LimitExceededError limitExceededError,
+      5: shared.ServiceBusyError serviceBusyError,
+      6: shared.AccessDeniedError accessDeniedError,
     )
 
 }
@@ -19,6 +19,7 @@
 // THE SOFTWARE.
 
 include "shared.thrift"
+include "replicator.thrift"
 
 namespace java com.uber.cadence.history
 
@@ -60,6 +61,8 @@ struct GetMutableStateRequest {
   10: optional string domainUUID
   20: optional shared.WorkflowExecution execution
   30: optional i64 (js.type = "Long") expectedNextEventId
+  // waitForDecisionCompletion blocks the call until no decision task is in flight
+  40: optional bool waitForDecisionCompletion
 }
 
 struct GetMutableStateResponse {
@@ -74,6 +77,12 @@ struct GetMutableStateResponse {
   90: optional string clientImpl
   100: optional bool isWorkflowRunning
   110: optional i32 stickyTaskListScheduleToStartTimeout
+  120: optional shared.WorkflowExecutionCloseStatus closeStatus
+  130: optional map<string, shared.ReplicationInfo> replicationInfo
+  // staleReadInfo is set if the domain is active in another cluster
+  140: optional shared.StaleReadInfo staleReadInfo
+  // versionHistory holds the last event id written with each version, in event id order
+  150: optional list<shared.ReplicationInfo> versionHistory
 }
 
 struct ResetStickyTaskListRequest {
@@ -156,6 +165,7 @@ struct RecordDecisionTaskStartedResponse {
   70: optional bool stickyExecutionEnabled
   80: optional shared.TransientDecisionInfo decisionInfo
   90: optional shared.TaskList WorkflowExecutionTaskList
+  100: optional list<shared.WorkflowUpdate> updates
 }
 
 struct SignalWorkflowExecutionRequest {
@@ -165,6 +175,11 @@ struct SignalWorkflowExecutionRequest {
   40: optional bool childWorkflowOnly
 }
 
+struct UpdateWorkflowExecutionRequest {
+  10: optional string domainUUID
+  20: optional shared.UpdateWorkflowExecutionRequest updateRequest
+}
+
 struct SignalWithStartWorkflowExecutionRequest {
   10: optional string domainUUID
   20: optional shared.SignalWithStartWorkflowExecutionRequest signalWithStartRequest
@@ -214,11 +229,6 @@ struct RecordChildExecutionCompletedRequest {
   50: optional shared.HistoryEvent completionEvent
 }
 
-struct ReplicationInfo {
-  10: optional i64 (js.type = "Long") version
-  20: optional i64 (js.type = "Long") lastEventId
-}
-
 struct ReplicateEventsRequest {
   10: optional string sourceCluster
   20: optional string domainUUID
@@ -226,12 +236,18 @@ struct ReplicateEventsRequest {
   40: optional i64 (js.type = "Long") firstEventId
   50: optional i64 (js.type = "Long") nextEventId
   60: optional i64 (js.type = "Long") version
-  70: optional map<string, ReplicationInfo> replicationInfo
+  70: optional map<string, shared.ReplicationInfo> replicationInfo
   80: optional shared.History history
   90: optional shared.History newRunHistory
   100: optional bool forceBufferEvents
 }
 
+struct ImportWorkflowExecutionRequest {
+  10: optional string domainUUID
+  20: optional shared.WorkflowExecution execution
+  30: optional list<shared.DataBlob> historyBatches
+}
+
 struct SyncShardStatusRequest {
   10: optional string sourceCluster
   20: optional i64 (js.type = "Long") shardId
@@ -448,6 +464,21 @@ service HistoryService {
       7: shared.LimitExceededError limitExceededError,
     )
 
+  /**
+  * UpdateWorkflowExecution is used to deliver an update request to a running workflow execution as part of a
+  * decision task. It blocks until a decision accepts or rejects the update.
+  **/
+  shared.UpdateWorkflowExecutionResponse UpdateWorkflowExecution(1: UpdateWorkflowExecutionRequest updateRequest)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.EntityNotExistsError entityNotExistError,
+      4: ShardOwnershipLostError shardOwnershipLostError,
+      5: shared.DomainNotActiveError domainNotActiveError,
+      6: shared.ServiceBusyError serviceBusyError,
+      7: shared.LimitExceededError limitExceededError,
+    )
+
   /**
   * SignalWithStartWorkflowExecution is used to ensure sending a signal event to a workflow execution.
   * If workflow is running, this results in WorkflowExecutionSignaled event recorded in the history
@@ -581,6 +612,21 @@ service HistoryService {
       6: shared.ServiceBusyError serviceBusyError,
     )
 
+  /**
+  * ImportWorkflowExecution recreates a workflow run from its exported history batches, the mutable state is rebuilt
+  * from the events and no tasks are generated for the imported run.
+  **/
+  void ImportWorkflowExecution(1: ImportWorkflowExecutionRequest importRequest)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.EntityNotExistsError entityNotExistError,
+      4: ShardOwnershipLostError shardOwnershipLostError,
+      5: shared.WorkflowExecutionAlreadyStartedError workflowAlreadyStartedError,
+      6: shared.LimitExceededError limitExceededError,
+      7: shared.ServiceBusyError serviceBusyError,
+    )
+
   /**
   * DescribeMutableState returns information about the internal states of workflow mutable state.
   **/
@@ -603,4 +649,28 @@ service HistoryService {
       2: shared.InternalServiceError internalServiceError,
       3: shared.AccessDeniedError accessDeniedError,
     )
+
+  /**
+  * GetReplicationMessages returns new replication tasks of the requested shards since the read level of each token
+  **/
+  replicator.GetReplicationMessagesResponse GetReplicationMessages(1: replicator.GetReplicationMessagesRequest request)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: ShardOwnershipLostError shardOwnershipLostError,
+      4: shared.LimitExceededError limitExceededError,
+      5: shared.ServiceBusyError serviceBusyError,
+    )
+
+  /**
+  * DescribeReplicationStatus returns the replication levels of the requested shards for every remote cluster
+  **/
+  replicator.DescribeReplicationStatusResponse DescribeReplicationStatus(1: replicator.DescribeReplicationStatusRequest request)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: ShardOwnershipLostError shardOwnershipLostError,
+      4: shared.LimitExceededError limitExceededError,
+      5: shared.ServiceBusyError serviceBusyError,
+    )
 }
@@ -41,6 +41,7 @@ struct PollForDecisionTaskResponse {
   80: optional shared.WorkflowQuery query
   90: optional shared.TransientDecisionInfo decisionInfo
   100: optional shared.TaskList WorkflowExecutionTaskList
+  110: optional list<shared.WorkflowUpdate> updates
 }
 
 struct PollForActivityTaskRequest {
@@ -64,6 +65,7 @@ struct AddActivityTaskRequest {
   40: optional shared.TaskList taskList
   50: optional i64 (js.type = "Long") scheduleId
   60: optional i32 scheduleToStartTimeoutSeconds
+  70: optional string fairnessKey
 }
 
 struct QueryWorkflowRequest {
@@ -91,6 +93,11 @@ struct DescribeTaskListRequest {
   20: optional shared.DescribeTaskListRequest descRequest
 }
 
+struct ListTaskListsRequest {
+  10: optional string domainUUID
+  20: optional shared.ListTaskListsRequest listRequest
+}
+
 /**
 * MatchingService API is exposed to provide support for polling from long running applications.
 * Such applications are expected to have a worker which regularly polls for DecisionTask and ActivityTask.  For each
@@ -201,4 +208,15 @@ service MatchingService {
         3: shared.EntityNotExistsError entityNotExistError,
         4: shared.ServiceBusyError serviceBusyError,
       )
+
+  /**
+  * ListTaskLists returns the task lists of a domain along with their ack level, last update time and whether
+  * they are currently loaded by a matching host.
+  **/
+  shared.ListTaskListsResponse ListTaskLists(1: ListTaskListsRequest request)
+    throws (
+        1: shared.BadRequestError badRequestError,
+        2: shared.InternalServiceError internalServiceError,
+        3: shared.ServiceBusyError serviceBusyError,
+      )
 }
@@ -21,7 +21,6 @@
 namespace java com.uber.cadence.replicator
 
 include "shared.thrift"
-include "history.thrift"
 
 enum ReplicationTaskType {
   Domain
@@ -42,6 +41,7 @@ struct DomainTaskAttributes {
   40: optional shared.DomainReplicationConfiguration replicationConfig
   50: optional i64 (js.type = "Long") configVersion
   60: optional i64 (js.type = "Long") failoverVersion
+  70: optional list<shared.DomainFailoverEvent> failoverHistory
 }
 
 struct HistoryTaskAttributes {
@@ -52,7 +52,7 @@ struct HistoryTaskAttributes {
   40: optional i64 (js.type = "Long") firstEventId
   50: optional i64 (js.type = "Long") nextEventId
   60: optional i64 (js.type = "Long") version
-  70: optional map<string, history.ReplicationInfo> replicationInfo
+  70: optional map<string, shared.ReplicationInfo> replicationInfo
   80: optional shared.History history
   90: optional shared.History newRunHistory
 }
@@ -68,5 +68,84 @@ struct ReplicationTask {
   20: optional DomainTaskAttributes domainTaskAttributes
   30: optional HistoryTaskAttributes historyTaskAttributes
   40: optional SyncShardStatusTaskAttributes syncShardStatusTaskAttributes
+  50: optional i64 (js.type = "Long") sourceTaskId
 }
 
+struct ReplicationToken {
+  10: optional i32 shardID
+  // lastRetrievedMessageId is where the next fetch should begin with
+  20: optional i64 (js.type = "Long") lastRetrievedMessageId
+  // lastProcessedMessageId is the last messageId that has been applied on the polling side
+  30: optional i64 (js.type = "Long") lastProcessedMessageId
+}
+
+struct ReplicationMessages {
+  10: optional list<ReplicationTask> replicationTasks
+  // lastRetrievedMessageId can be larger than the last sourceTaskId above, as tasks not targeting the polling cluster are skipped
+  20: optional i64 (js.type = "Long") lastRetrievedMessageId
+  30: optional bool hasMore
+}
+
+struct GetReplicationMessagesRequest {
+  10: optional list<ReplicationToken> tokens
+  20: optional string clusterName
+}
+
+struct GetReplicationMessagesResponse {
+  10: optional map<i32, ReplicationMessages> messagesByShard
+}
+
+struct RemoteClusterReplicationStatus {
+  // ackLevel is the last replication task of the shard applied by, or published to, the remote cluster
+  10: optional i64 (js.type = "Long") ackLevel
+  // oldestUnackedTaskAgeInSeconds is how long ago the events of the first replication task after ackLevel were written
+  20: optional i64 (js.type = "Long") oldestUnackedTaskAgeInSeconds
+  // hasUnackedTasks is whether any replication task after ackLevel is still waiting for the remote cluster
+  30: optional bool hasUnackedTasks
+}
+
+struct ShardReplicationStatus {
+  10: optional i32 shardID
+  20: optional i64 (js.type = "Long") replicatorAckLevel
+  30: optional i64 (js.type = "Long") maxReadLevel
+  40: optional map<string, RemoteClusterReplicationStatus> remoteClusters
+}
+
+struct DescribeReplicationStatusRequest {
+  10: optional list<i32> shardIDs
+  // domainID limits hasUnackedTasks to the replication tasks of the domain
+  20: optional string domainID
+  // maxReadLevelByShard limits hasUnackedTasks to the replication tasks up to the level, the current max read level by default
+  30: optional map<i32, i64> maxReadLevelByShard
+}
+
+struct DescribeReplicationStatusResponse {
+  10: optional map<i32, ShardReplicationStatus> statusByShard
+}
+
+struct ConsumerPartitionOffset {
+  10: optional i32 partition
+  20: optional i64 (js.type = "Long") committedOffset
+  30: optional i64 (js.type = "Long") latestOffset
+}
+
+
+// DLQReplicationTask is published to the replication DLQ topic once a replication task exhausts all retries
+struct DLQReplicationTask {
+  10: optional ReplicationTask task
+  20: optional string sourceCluster
+  30: optional string lastError
+  40: optional i32 attempts
+  50: optional i64 (js.type = "Long") timestamp
+}
+
+struct DLQMessageID {
+  10: optional i32 partition
+  20: optional i64 (js.type = "Long") offset
+}
+
+struct DLQMessage {
+  10: optional DLQMessageID id
+  20: optional i32 shardID
+  30: optional DLQReplicationTask replicationTask
+}
@@ -71,6 +71,17 @@ exception AccessDeniedError {
 
 exception RetryTaskError {
   1: required string message
+  // the workflow run and the next event ID expected by the current cluster, so the missing events can be
+  // re-replicated from the source cluster
+  2: optional string domainId
+  3: optional string workflowId
+  4: optional string runId
+  5: optional i64 (js.type = "Long") nextEventId
+}
+
+enum EncodingType {
+  ThriftRW,
+  JSON,
 }
 
 enum WorkflowIdReusePolicy {
@@ -121,6 +132,7 @@ enum DecisionType {
   ContinueAsNewWorkflowExecution,
   StartChildWorkflowExecution,
   SignalExternalWorkflowExecution,
+  RespondWorkflowUpdate,
 }
 
 enum EventType {
@@ -165,6 +177,8 @@ enum EventType {
   SignalExternalWorkflowExecutionInitiated,
   SignalExternalWorkflowExecutionFailed,
   ExternalWorkflowExecutionSignaled,
+  ActivityTaskRedirected,
+  WorkflowExecutionUpdateAccepted,
 }
 
 enum DecisionTaskFailedCause {
@@ -187,6 +201,7 @@ enum DecisionTaskFailedCause {
   FORCE_CLOSE_DECISION,
   FAILOVER_CLOSE_DECISION,
   BAD_SIGNAL_INPUT_SIZE,
+  BAD_RESPOND_WORKFLOW_UPDATE_ATTRIBUTES,
 }
 
 enum CancelExternalWorkflowExecutionFailedCause {
@@ -221,6 +236,20 @@ enum QueryTaskCompletedType {
   FAILED,
 }
 
+enum QueryRejectCondition {
+  // NOT_OPEN indicates that query should be rejected if workflow is not open
+  NOT_OPEN,
+  // NOT_COMPLETED_CLEANLY indicates that query should be rejected if workflow did not complete cleanly
+  NOT_COMPLETED_CLEANLY,
+}
+
+enum QueryConsistencyLevel {
+  // EVENTUAL indicates that query should be eventually consistent
+  EVENTUAL,
+  // STRONG indicates that any events that came before query should be reflected in workflow state before running query
+  STRONG,
+}
+
 enum PendingActivityState {
   SCHEDULED,
   STARTED,
@@ -295,6 +324,8 @@ struct ScheduleActivityTaskDecisionAttributes {
   55: optional i32 startToCloseTimeoutSeconds
   60: optional i32 heartbeatTimeoutSeconds
   70: optional RetryPolicy retryPolicy
+  80: optional string fairnessKey
+  90: optional list<TaskList> fallbackTaskLists
 }
 
 struct RequestCancelActivityTaskDecisionAttributes {
@@ -340,6 +371,13 @@ struct SignalExternalWorkflowExecutionDecisionAttributes {
   60: optional bool childWorkflowOnly
 }
 
+struct RespondWorkflowUpdateDecisionAttributes {
+  10: optional string updateId
+  20: optional bool accepted
+  30: optional binary result
+  40: optional string rejectionReason
+}
+
 struct RecordMarkerDecisionAttributes {
   10: optional string markerName
   20: optional binary details
@@ -384,6 +422,7 @@ struct Decision {
   90:  optional ContinueAsNewWorkflowExecutionDecisionAttributes continueAsNewWorkflowExecutionDecisionAttributes
   100: optional StartChildWorkflowExecutionDecisionAttributes startChildWorkflowExecutionDecisionAttributes
   110: optional SignalExternalWorkflowExecutionDecisionAttributes signalExternalWorkflowExecutionDecisionAttributes
+  120: optional RespondWorkflowUpdateDecisionAttributes respondWorkflowUpdateDecisionAttributes
 }
 
 struct WorkflowExecutionStartedEventAttributes {
@@ -474,6 +513,8 @@ struct ActivityTaskScheduledEventAttributes {
   60: optional i32 heartbeatTimeoutSeconds
   90: optional i64 (js.type = "Long") decisionTaskCompletedEventId
   110: optional RetryPolicy retryPolicy
+  120: optional string fairnessKey
+  130: optional list<TaskList> fallbackTaskLists
 }
 
 struct ActivityTaskStartedEventAttributes {
@@ -505,6 +546,13 @@ struct ActivityTaskTimedOutEventAttributes {
   30: optional TimeoutType timeoutType
 }
 
+struct ActivityTaskRedirectedEventAttributes {
+  10: optional i64 (js.type = "Long") scheduledEventId
+  20: optional TaskList previousTaskList
+  30: optional TaskList taskList
+  40: optional TimeoutType timeoutType
+}
+
 struct ActivityTaskCancelRequestedEventAttributes {
   10: optional string activityId
   20: optional i64 (js.type = "Long") decisionTaskCompletedEventId
@@ -574,6 +622,14 @@ struct WorkflowExecutionSignaledEventAttributes {
   30: optional string identity
 }
 
+struct WorkflowExecutionUpdateAcceptedEventAttributes {
+  10: optional string updateId
+  20: optional string updateName
+  30: optional binary input
+  40: optional binary result
+  50: optional i64 (js.type = "Long") decisionTaskCompletedEventId
+}
+
 struct WorkflowExecutionTerminatedEventAttributes {
   10: optional string reason
   20: optional binary details
@@ -752,6 +808,8 @@ struct HistoryEvent {
   420: optional SignalExternalWorkflowExecutionInitiatedEventAttributes signalExternalWorkflowExecutionInitiatedEventAttributes
   430: optional SignalExternalWorkflowExecutionFailedEventAttributes signalExternalWorkflowExecutionFailedEventAttributes
   440: optional ExternalWorkflowExecutionSignaledEventAttributes externalWorkflowExecutionSignaledEventAttributes
+  450: optional ActivityTaskRedirectedEventAttributes activityTaskRedirectedEventAttributes
+  460: optional WorkflowExecutionUpdateAcceptedEventAttributes workflowExecutionUpdateAcceptedEventAttributes
 }
 
 struct History {
@@ -801,6 +859,30 @@ struct DomainReplicationConfiguration {
  20: optional list<ClusterReplicationConfiguration> clusters
 }
 
+struct FailoverInfo {
+  10: optional string pendingActiveClusterName
+  20: optional i64 (js.type = "Long") failoverEndTimestamp
+}
+
+struct DomainFailoverEvent {
+  10: optional i64 (js.type = "Long") failoverTimestamp
+  20: optional string fromCluster
+  30: optional string toCluster
+  40: optional i64 (js.type = "Long") failoverVersion
+  50: optional string identity
+  60: optional string reason
+}
+
+struct ReplicationInfo {
+  10: optional i64 (js.type = "Long") version
+  20: optional i64 (js.type = "Long") lastEventId
+}
+
+struct DataBlob {
+  10: optional EncodingType EncodingType
+  20: optional binary Data
+}
+
 struct RegisterDomainRequest {
   10: optional string name
   20: optional string description
@@ -833,6 +915,8 @@ struct DescribeDomainResponse {
   30: optional DomainReplicationConfiguration replicationConfiguration
   40: optional i64 (js.type = "Long") failoverVersion
   50: optional bool isGlobalDomain
+  60: optional FailoverInfo failoverInfo
+  70: optional list<DomainFailoverEvent> failoverHistory
 }
 
 struct UpdateDomainRequest {
@@ -840,6 +924,9 @@ struct UpdateDomainRequest {
  20: optional UpdateDomainInfo updatedInfo
  30: optional DomainConfiguration configuration
  40: optional DomainReplicationConfiguration replicationConfiguration
+ 50: optional i32 failoverTimeoutInSeconds
+ 60: optional string failoverReason
+ 70: optional string identity
 }
 
 struct UpdateDomainResponse {
@@ -848,6 +935,8 @@ struct UpdateDomainResponse {
   30: optional DomainReplicationConfiguration replicationConfiguration
   40: optional i64 (js.type = "Long") failoverVersion
   50: optional bool isGlobalDomain
+  60: optional FailoverInfo failoverInfo
+  70: optional list<DomainFailoverEvent> failoverHistory
 }
 
 struct DeprecateDomainRequest {
@@ -891,6 +980,7 @@ struct PollForDecisionTaskResponse {
   70: optional binary nextPageToken
   80: optional WorkflowQuery query
   90: optional TaskList WorkflowExecutionTaskList
+  100: optional list<WorkflowUpdate> updates
 }
 
 struct StickyExecutionAttributes {
@@ -1022,11 +1112,35 @@ struct GetWorkflowExecutionHistoryRequest {
   40: optional binary nextPageToken
   50: optional bool waitForNewEvent
   60: optional HistoryEventFilterType HistoryEventFilterType
+  // returns the events from the newest one, cannot be combined with waitForNewEvent or the close event filter
+  70: optional bool reverseOrder
+  // returns only the last lastEventCount events in a single page, in reverse order if reverseOrder is set
+  80: optional i32 lastEventCount
 }
 
 struct GetWorkflowExecutionHistoryResponse {
   10: optional History history
   20: optional binary nextPageToken
+  30: optional StaleReadInfo staleReadInfo
+}
+
+struct GetWorkflowExecutionResultRequest {
+  10: optional string domain
+  20: optional WorkflowExecution execution
+  // when set, continue-as-new and retry chains are followed to the final run
+  30: optional bool followRuns
+}
+
+struct GetWorkflowExecutionResultResponse {
+  // the run the result belongs to, which differs from the requested run when following runs
+  10: optional WorkflowExecution execution
+  // true when the long poll expired before the run closed, the caller is expected to poll again
+  20: optional bool isWorkflowRunning
+  30: optional WorkflowExecutionCloseStatus closeStatus
+  40: optional binary result
+  50: optional string failureReason
+  60: optional binary failureDetails
+  70: optional HistoryEvent closeEvent
 }
 
 struct SignalWorkflowExecutionRequest {
@@ -1039,6 +1153,30 @@ struct SignalWorkflowExecutionRequest {
   70: optional binary control
 }
 
+struct UpdateWorkflowExecutionRequest {
+  10: optional string domain
+  20: optional WorkflowExecution workflowExecution
+  30: optional string updateName
+  40: optional binary input
+  50: optional string identity
+  60: optional string requestId
+}
+
+struct UpdateWorkflowExecutionResponse {
+  10: optional binary result
+  20: optional WorkflowUpdateRejected rejected
+}
+
+struct WorkflowUpdate {
+  10: optional string updateId
+  20: optional string updateName
+  30: optional binary input
+}
+
+struct WorkflowUpdateRejected {
+  10: optional string reason
+}
+
 struct SignalWithStartWorkflowExecutionRequest {
   10: optional string domain
   20: optional string workflowId
@@ -1097,10 +1235,27 @@ struct QueryWorkflowRequest {
   10: optional string domain
   20: optional WorkflowExecution execution
   30: optional WorkflowQuery query
+  // QueryRejectCondition can be used to reject the query if workflow state does not satisfy condition
+  40: optional QueryRejectCondition queryRejectCondition
+  50: optional QueryConsistencyLevel queryConsistencyLevel
+}
+
+// StaleReadInfo is set on the responses of the read APIs served by a cluster in which the global domain is
+// not active, the data is replicated from the active cluster and may be behind it
+struct StaleReadInfo {
+  10: optional string activeClusterName
+  // replicationLagInSeconds is how far the data replicated from the active cluster is behind, not set if unknown
+  20: optional i64 (js.type = "Long") replicationLagInSeconds
+}
+
+struct QueryRejected {
+  10: optional WorkflowExecutionCloseStatus closeStatus
 }
 
 struct QueryWorkflowResponse {
   10: optional binary queryResult
+  20: optional QueryRejected queryRejected
+  30: optional StaleReadInfo staleReadInfo
 }
 
 struct WorkflowQuery {
@@ -1142,6 +1297,7 @@ struct DescribeWorkflowExecutionResponse {
   10: optional WorkflowExecutionConfiguration executionConfiguration
   20: optional WorkflowExecutionInfo workflowExecutionInfo
   30: optional list<PendingActivityInfo> pendingActivities
+  40: optional StaleReadInfo staleReadInfo
 }
 
 struct DescribeTaskListRequest {
@@ -1152,6 +1308,28 @@ struct DescribeTaskListRequest {
 
 struct DescribeTaskListResponse {
   10: optional list<PollerInfo> pollers
+  // task lists are not replicated, the pollers are the ones polling the cluster serving the request
+  20: optional StaleReadInfo staleReadInfo
+}
+
+struct ListTaskListsRequest {
+  10: optional string domain
+  20: optional i32 pageSize
+  30: optional binary nextPageToken
+}
+
+struct TaskListStatus {
+  10: optional TaskList taskList
+  20: optional TaskListType taskListType
+  30: optional i64 (js.type = "Long") ackLevel
+  // Unix Nano
+  40: optional i64 (js.type = "Long") lastUpdateTime
+  50: optional bool loaded
+}
+
+struct ListTaskListsResponse {
+  10: optional list<TaskListStatus> taskLists
+  20: optional binary nextPageToken
 }
 
 //At least one of the parameters needs to be provided
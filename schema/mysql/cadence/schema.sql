@@ -17,8 +17,11 @@ CREATE TABLE domains(
   is_global_domain TINYINT(1) NOT NULL,
 /* domain_replication_config */
   active_cluster_name VARCHAR(255) NOT NULL,
-  clusters BLOB
+  clusters BLOB,
 /* end domain_replication_config */
+  pending_active_cluster_name VARCHAR(255) NOT NULL DEFAULT '',
+  failover_end_time BIGINT NOT NULL DEFAULT 0,
+  failover_history BLOB
 ) DEFAULT CHARACTER SET utf8 COLLATE utf8_unicode_ci;
 
 CREATE TABLE domain_metadata (
@@ -27,6 +30,16 @@ CREATE TABLE domain_metadata (
 
 INSERT INTO domain_metadata (notification_version) VALUES (0);
 
+CREATE TABLE clusters (
+  cluster_name VARCHAR(255) NOT NULL,
+  initial_failover_version BIGINT NOT NULL,
+  enabled TINYINT(1) NOT NULL,
+  rpc_name VARCHAR(255) NOT NULL,
+  rpc_address VARCHAR(255) NOT NULL,
+  replication_topic VARCHAR(255) NOT NULL,
+  PRIMARY KEY (cluster_name)
+);
+
 CREATE TABLE shards (
 	shard_id INT NOT NULL,
 	owner VARCHAR(255) NOT NULL,
@@ -38,6 +51,7 @@ CREATE TABLE shards (
 	timer_ack_level TIMESTAMP(3) NOT NULL,
 	cluster_transfer_ack_level BLOB NOT NULL,
 	cluster_timer_ack_level BLOB NOT NULL,
+	cluster_replication_level BLOB NOT NULL,
 	domain_notification_version BIGINT NOT NULL,
 	PRIMARY KEY (shard_id)
 );
@@ -82,6 +96,7 @@ CREATE TABLE executions(
   last_write_version BIGINT,
   last_write_event_id BIGINT,
   last_replication_info BLOB,
+  version_history BLOB,
   -- replication_state members end
 	last_first_event_id BIGINT NOT NULL,
 	next_event_id BIGINT NOT NULL, -- very important! for conditional updates of all the dependent tables.
@@ -131,6 +146,7 @@ CREATE TABLE tasks (
   task_list_type TINYINT NOT NULL,
   task_id BIGINT NOT NULL,
   expiry_ts TIMESTAMP NOT NULL,
+  fairness_key VARCHAR(255) NOT NULL,
   PRIMARY KEY (domain_id, task_list_name, task_list_type, task_id)
 );
 
@@ -141,7 +157,9 @@ CREATE TABLE task_lists (
 	task_type TINYINT NOT NULL, -- {Activity, Decision}
 	ack_level BIGINT NOT NULL DEFAULT 0,
 	kind TINYINT NOT NULL, -- {Normal, Sticky}
+	last_updated TIMESTAMP NOT NULL,
 	expiry_ts TIMESTAMP NOT NULL,
+	released BOOLEAN NOT NULL DEFAULT FALSE, -- set once the owner gracefully handed the task list over
 	PRIMARY KEY (domain_id, name, task_type)
 );
 
@@ -200,6 +218,7 @@ CREATE TABLE activity_info_maps (
 version                   BIGINT NOT NULL,
 scheduled_event           BLOB,
 scheduled_time            TIMESTAMP NOT NULL,
+redirected_time           TIMESTAMP NOT NULL, -- when the activity was last moved to a fallback task list
 started_id                BIGINT NOT NULL,
 started_event             BLOB,
 started_time              TIMESTAMP NOT NULL,
@@ -309,3 +328,14 @@ CREATE TABLE signals_requested_sets (
 	PRIMARY KEY (shard_id, domain_id, workflow_id, run_id, signal_id)
 );
 
+CREATE TABLE accepted_updates_maps (
+	shard_id INT NOT NULL,
+	domain_id VARCHAR(64) NOT NULL,
+	workflow_id VARCHAR(255) NOT NULL,
+	run_id VARCHAR(64) NOT NULL,
+	update_id VARCHAR(255) NOT NULL,
+	--
+	event_id BIGINT NOT NULL,
+	PRIMARY KEY (shard_id, domain_id, workflow_id, run_id, update_id)
+);
+
@@ -22,19 +22,25 @@ package frontend
 
 import (
 	"context"
-
+	"fmt"
+	"sort"
 	"strconv"
 
+	"github.com/uber-common/bark"
 	"github.com/uber/cadence/.gen/go/admin"
 	"github.com/uber/cadence/.gen/go/admin/adminserviceserver"
 	hist "github.com/uber/cadence/.gen/go/history"
+	"github.com/uber/cadence/.gen/go/replicator"
 	gen "github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/client/history"
 	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/authorization"
 	"github.com/uber/cadence/common/cache"
 	"github.com/uber/cadence/common/logging"
+	"github.com/uber/cadence/common/messaging"
 	"github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/service"
+	"github.com/uber/cadence/common/service/config"
 )
 
 var _ adminserviceserver.Interface = (*AdminHandler)(nil)
@@ -46,17 +52,46 @@ type (
 		service.Service
 		history     history.Client
 		domainCache cache.DomainCache
+		metadataMgr persistence.MetadataManager
+		historyMgr  persistence.HistoryManager
+		authorizer  *requestAuthorizer
+		dlq         messaging.DLQ
 	}
 )
 
+const (
+	defaultDLQPageSize        = 100
+	defaultRawHistoryPageSize = 100
+	defaultDomainPageSize     = 100
+)
+
+var (
+	errDLQNotAvailable       = &gen.BadRequestError{Message: "Replication DLQ is not available on this cluster."}
+	errInvalidPageSize       = &gen.BadRequestError{Message: "Invalid MaximumPageSize."}
+	errDLQPartitionNotSet    = &gen.BadRequestError{Message: "Partition is not set on request."}
+	errDLQOffsetNotSet       = &gen.BadRequestError{Message: "InclusiveEndOffset is not set on request."}
+	errDLQMessageIDsNotSet   = &gen.BadRequestError{Message: "MessageIDs is not set on request."}
+	errInvalidDLQMessage     = &gen.BadRequestError{Message: "DLQ message does not contain a valid replication task."}
+	errDomainTaskNotMergable = &gen.BadRequestError{Message: "Domain replication task cannot be merged, update the domain instead."}
+	errInvalidEventIDRange   = &gen.BadRequestError{Message: "NextEventId must be greater than FirstEventId."}
+	errHistoryBatchesNotSet  = &gen.BadRequestError{Message: "HistoryBatches is not set on request."}
+	errInvalidShardID        = &gen.BadRequestError{Message: "Invalid ShardID."}
+
+	errInitialFailoverVersionNotSet = &gen.BadRequestError{Message: "InitialFailoverVersion is not set on request."}
+)
+
 // NewAdminHandler creates a thrift handler for the cadence admin service
 func NewAdminHandler(
-	sVice service.Service, numberOfHistoryShards int, metadataMgr persistence.MetadataManager) *AdminHandler {
+	sVice service.Service, numberOfHistoryShards int, metadataMgr persistence.MetadataManager,
+	historyMgr persistence.HistoryManager, authorizationCfg config.Authorization) *AdminHandler {
 	handler := &AdminHandler{
 		numberOfHistoryShards: numberOfHistoryShards,
 		Service:               sVice,
+		metadataMgr:           metadataMgr,
+		historyMgr:            historyMgr,
 		domainCache:           cache.NewDomainCache(metadataMgr, sVice.GetClusterMetadata(), sVice.GetMetricsClient(), sVice.GetLogger()),
 	}
+	handler.authorizer = newAuthorizer(authorizationCfg, handler.domainCache)
 	return handler
 }
 
@@ -70,6 +105,16 @@ func (adh *AdminHandler) Start() error {
 	if err != nil {
 		return err
 	}
+
+	clusterMetadata := adh.GetClusterMetadata()
+	if clusterMetadata.IsGlobalDomainEnabled() {
+		adh.dlq, err = adh.GetMessagingClient().NewDLQ(clusterMetadata.GetCurrentClusterName())
+		if err != nil {
+			adh.GetLogger().WithFields(bark.Fields{
+				logging.TagErr: err,
+			}).Warn("Failed to create replication DLQ, DLQ admin APIs are disabled.")
+		}
+	}
 	return nil
 }
 
@@ -77,6 +122,9 @@ func (adh *AdminHandler) Start() error {
 func (adh *AdminHandler) Stop() {
 	adh.Service.Stop()
 	adh.domainCache.Stop()
+	if adh.dlq != nil {
+		adh.dlq.Close()
+	}
 }
 
 // DescribeWorkflowExecution returns information about the specified workflow execution.
@@ -89,6 +137,10 @@ func (adh *AdminHandler) DescribeWorkflowExecution(ctx context.Context, request
 		return nil, adh.error(err)
 	}
 
+	if err := adh.authorize(ctx, "DescribeWorkflowExecution", request.GetDomain(), request.Execution); err != nil {
+		return nil, adh.error(err)
+	}
+
 	shardID := common.WorkflowIDToHistoryShard(*request.Execution.WorkflowId, adh.numberOfHistoryShards)
 	shardIDstr := string(shardID)
 	shardIDForOutput := strconv.Itoa(shardID)
@@ -128,10 +180,658 @@ func (adh *AdminHandler) DescribeHistoryHost(ctx context.Context, request *gen.D
 		}
 	}
 
+	if err := adh.authorize(ctx, "DescribeHistoryHost", "", request.ExecutionForHost); err != nil {
+		return nil, adh.error(err)
+	}
+
 	resp, err := adh.history.DescribeHistoryHost(ctx, request)
 	return resp, err
 }
 
+// GetReplicationMessages returns new replication tasks of the requested shards for the polling cluster
+func (adh *AdminHandler) GetReplicationMessages(ctx context.Context, request *replicator.GetReplicationMessagesRequest) (*replicator.GetReplicationMessagesResponse, error) {
+	if request == nil {
+		return nil, adh.error(errRequestNotSet)
+	}
+	if request.GetClusterName() == "" {
+		return nil, adh.error(errClusterNameNotSet)
+	}
+
+	if err := adh.authorize(ctx, "GetReplicationMessages", "", nil); err != nil {
+		return nil, adh.error(err)
+	}
+
+	resp, err := adh.history.GetReplicationMessages(ctx, request)
+	if err != nil {
+		return nil, adh.error(err)
+	}
+	return resp, nil
+}
+
+// GetWorkflowExecutionRawHistory returns the serialized history batches of the workflow run along with its
+// replication info, it is used by remote clusters to re-replicate the events they are missing
+func (adh *AdminHandler) GetWorkflowExecutionRawHistory(ctx context.Context, request *admin.GetWorkflowExecutionRawHistoryRequest) (*admin.GetWorkflowExecutionRawHistoryResponse, error) {
+	if request == nil {
+		return nil, adh.error(errRequestNotSet)
+	}
+	if request.GetDomain() == "" {
+		return nil, adh.error(errDomainNotSet)
+	}
+	if err := validateExecution(request.Execution); err != nil {
+		return nil, adh.error(err)
+	}
+
+	pageSize := int(request.GetMaximumPageSize())
+	if request.MaximumPageSize == nil {
+		pageSize = defaultRawHistoryPageSize
+	}
+	if pageSize <= 0 {
+		return nil, adh.error(errInvalidPageSize)
+	}
+
+	if err := adh.authorize(ctx, "GetWorkflowExecutionRawHistory", request.GetDomain(), request.Execution); err != nil {
+		return nil, adh.error(err)
+	}
+
+	domainID, err := adh.domainCache.GetDomainID(request.GetDomain())
+	if err != nil {
+		return nil, adh.error(err)
+	}
+
+	// mutable state resolves the current run if no run ID is given and provides the replication info
+	msResp, err := adh.history.GetMutableState(ctx, &hist.GetMutableStateRequest{
+		DomainUUID: common.StringPtr(domainID),
+		Execution:  request.Execution,
+	})
+	if err != nil {
+		return nil, adh.error(err)
+	}
+
+	firstEventID := common.FirstEventID
+	if request.FirstEventId != nil {
+		firstEventID = request.GetFirstEventId()
+	}
+	nextEventID := msResp.GetNextEventId()
+	if request.NextEventId != nil && request.GetNextEventId() < nextEventID {
+		nextEventID = request.GetNextEventId()
+	}
+	if nextEventID <= firstEventID {
+		return nil, adh.error(errInvalidEventIDRange)
+	}
+
+	historyResp, err := adh.historyMgr.GetWorkflowExecutionRawHistory(&persistence.GetWorkflowExecutionHistoryRequest{
+		DomainID: domainID,
+		Execution: gen.WorkflowExecution{
+			WorkflowId: request.Execution.WorkflowId,
+			RunId:      msResp.Execution.RunId,
+		},
+		FirstEventID:  firstEventID,
+		NextEventID:   nextEventID,
+		PageSize:      pageSize,
+		NextPageToken: request.NextPageToken,
+	})
+	if err != nil {
+		return nil, adh.error(err)
+	}
+
+	batches := make([]*gen.DataBlob, 0, len(historyResp.HistoryBatches))
+	for _, blob := range historyResp.HistoryBatches {
+		batches = append(batches, convertDataBlob(blob))
+	}
+	return &admin.GetWorkflowExecutionRawHistoryResponse{
+		NextPageToken:   historyResp.NextPageToken,
+		HistoryBatches:  batches,
+		ReplicationInfo: msResp.ReplicationInfo,
+	}, nil
+}
+
+// ImportWorkflowExecution recreates a workflow run from the history batches exported by GetWorkflowExecutionRawHistory
+func (adh *AdminHandler) ImportWorkflowExecution(ctx context.Context, request *admin.ImportWorkflowExecutionRequest) error {
+	if request == nil {
+		return adh.error(errRequestNotSet)
+	}
+	if request.GetDomain() == "" {
+		return adh.error(errDomainNotSet)
+	}
+	if err := validateExecution(request.Execution); err != nil {
+		return adh.error(err)
+	}
+	if request.Execution.GetRunId() == "" {
+		return adh.error(errRunIDNotSet)
+	}
+	if len(request.HistoryBatches) == 0 {
+		return adh.error(errHistoryBatchesNotSet)
+	}
+
+	if err := adh.authorize(ctx, "ImportWorkflowExecution", request.GetDomain(), request.Execution); err != nil {
+		return adh.error(err)
+	}
+
+	domainID, err := adh.domainCache.GetDomainID(request.GetDomain())
+	if err != nil {
+		return adh.error(err)
+	}
+
+	err = adh.history.ImportWorkflowExecution(ctx, &hist.ImportWorkflowExecutionRequest{
+		DomainUUID:     common.StringPtr(domainID),
+		Execution:      request.Execution,
+		HistoryBatches: request.HistoryBatches,
+	})
+	if err != nil {
+		return adh.error(err)
+	}
+	return nil
+}
+
+// ReadDLQMessages returns the replication tasks in the DLQ which have not been purged yet
+func (adh *AdminHandler) ReadDLQMessages(ctx context.Context, request *admin.ReadDLQMessagesRequest) (*admin.ReadDLQMessagesResponse, error) {
+	if request == nil {
+		return nil, adh.error(errRequestNotSet)
+	}
+	if adh.dlq == nil {
+		return nil, adh.error(errDLQNotAvailable)
+	}
+
+	pageSize := int(request.GetMaximumPageSize())
+	if request.MaximumPageSize == nil {
+		pageSize = defaultDLQPageSize
+	}
+	if pageSize <= 0 {
+		return nil, adh.error(errInvalidPageSize)
+	}
+
+	if err := adh.authorize(ctx, "ReadDLQMessages", request.GetDomain(), nil); err != nil {
+		return nil, adh.error(err)
+	}
+
+	domainID := ""
+	if request.GetDomain() != "" {
+		var err error
+		domainID, err = adh.domainCache.GetDomainID(request.GetDomain())
+		if err != nil {
+			return nil, adh.error(err)
+		}
+	}
+
+	// messages not matching the filters are skipped, so keep reading until the page is filled
+	var result []*replicator.DLQMessage
+	pageToken := request.NextPageToken
+	for {
+		messages, nextPageToken, err := adh.dlq.ReadMessages(pageToken, pageSize-len(result))
+		if err != nil {
+			if err == messaging.ErrInvalidDLQPageToken {
+				return nil, adh.error(errInvalidNextPageToken)
+			}
+			return nil, adh.error(err)
+		}
+
+		for _, message := range messages {
+			adh.setDLQMessageShardID(message)
+			if request.ShardID != nil && message.GetShardID() != request.GetShardID() {
+				continue
+			}
+			if domainID != "" && getDLQMessageDomainID(message) != domainID {
+				continue
+			}
+			result = append(result, message)
+		}
+
+		pageToken = nextPageToken
+		if len(pageToken) == 0 || len(result) >= pageSize {
+			break
+		}
+	}
+
+	return &admin.ReadDLQMessagesResponse{
+		Messages:      result,
+		NextPageToken: pageToken,
+	}, nil
+}
+
+// PurgeDLQMessages discards the DLQ messages of a partition up to and including the given offset
+func (adh *AdminHandler) PurgeDLQMessages(ctx context.Context, request *admin.PurgeDLQMessagesRequest) error {
+	if request == nil {
+		return adh.error(errRequestNotSet)
+	}
+	if request.Partition == nil {
+		return adh.error(errDLQPartitionNotSet)
+	}
+	if request.InclusiveEndOffset == nil || request.GetInclusiveEndOffset() < 0 {
+		return adh.error(errDLQOffsetNotSet)
+	}
+	if adh.dlq == nil {
+		return adh.error(errDLQNotAvailable)
+	}
+
+	if err := adh.authorize(ctx, "PurgeDLQMessages", "", nil); err != nil {
+		return adh.error(err)
+	}
+
+	if err := adh.dlq.Purge(request.GetPartition(), request.GetInclusiveEndOffset()); err != nil {
+		return adh.error(err)
+	}
+	return nil
+}
+
+// MergeDLQMessages re-applies the given DLQ messages through the history replication path, merged messages
+// are not purged from the DLQ
+func (adh *AdminHandler) MergeDLQMessages(ctx context.Context, request *admin.MergeDLQMessagesRequest) error {
+	if request == nil {
+		return adh.error(errRequestNotSet)
+	}
+	if len(request.MessageIDs) == 0 {
+		return adh.error(errDLQMessageIDsNotSet)
+	}
+	if adh.dlq == nil {
+		return adh.error(errDLQNotAvailable)
+	}
+
+	if err := adh.authorize(ctx, "MergeDLQMessages", "", nil); err != nil {
+		return adh.error(err)
+	}
+
+	for _, id := range request.MessageIDs {
+		message, err := adh.dlq.ReadMessage(id)
+		if err != nil {
+			if err == messaging.ErrDLQMessageNotFound {
+				return adh.error(&gen.EntityNotExistsError{
+					Message: fmt.Sprintf("DLQ message %v:%v does not exist or was already purged.", id.GetPartition(), id.GetOffset()),
+				})
+			}
+			return adh.error(err)
+		}
+
+		if err := adh.mergeDLQMessage(ctx, message); err != nil {
+			return adh.error(err)
+		}
+	}
+	return nil
+}
+
+func (adh *AdminHandler) mergeDLQMessage(ctx context.Context, message *replicator.DLQMessage) error {
+	task := message.GetReplicationTask().GetTask()
+	if task == nil {
+		return errInvalidDLQMessage
+	}
+
+	switch task.GetTaskType() {
+	case replicator.ReplicationTaskTypeHistory:
+		attr := task.HistoryTaskAttributes
+		clusterMetadata := adh.GetClusterMetadata()
+		targeted := false
+		for _, cluster := range attr.TargetClusters {
+			if cluster == clusterMetadata.GetCurrentClusterName() {
+				targeted = true
+				break
+			}
+		}
+		if !targeted {
+			// same as the replicator, history tasks not targeting the current cluster are dropped
+			return nil
+		}
+
+		sourceCluster := message.GetReplicationTask().GetSourceCluster()
+		if sourceCluster == "" {
+			// messages nack'ed by the consumer do not carry the source cluster, the events are generated
+			// by the cluster owning their version
+			var err error
+			sourceCluster, err = clusterMetadata.ClusterNameForFailoverVersion(attr.GetVersion())
+			if err != nil {
+				return err
+			}
+		}
+
+		return adh.history.ReplicateEvents(ctx, &hist.ReplicateEventsRequest{
+			SourceCluster: common.StringPtr(sourceCluster),
+			DomainUUID:    attr.DomainId,
+			WorkflowExecution: &gen.WorkflowExecution{
+				WorkflowId: attr.WorkflowId,
+				RunId:      attr.RunId,
+			},
+			FirstEventId:      attr.FirstEventId,
+			NextEventId:       attr.NextEventId,
+			Version:           attr.Version,
+			ReplicationInfo:   attr.ReplicationInfo,
+			History:           attr.History,
+			NewRunHistory:     attr.NewRunHistory,
+			ForceBufferEvents: common.BoolPtr(false),
+		})
+	case replicator.ReplicationTaskTypeSyncShardStatus:
+		// shard status is refreshed by the following sync shard tasks, nothing to re-apply
+		return nil
+	case replicator.ReplicationTaskTypeDomain:
+		return errDomainTaskNotMergable
+	default:
+		return errInvalidDLQMessage
+	}
+}
+
+func (adh *AdminHandler) setDLQMessageShardID(message *replicator.DLQMessage) {
+	task := message.GetReplicationTask().GetTask()
+	switch {
+	case task.GetHistoryTaskAttributes() != nil:
+		shardID := common.WorkflowIDToHistoryShard(task.HistoryTaskAttributes.GetWorkflowId(), adh.numberOfHistoryShards)
+		message.ShardID = common.Int32Ptr(int32(shardID))
+	case task.GetSyncShardStatusTaskAttributes() != nil:
+		message.ShardID = common.Int32Ptr(int32(task.SyncShardStatusTaskAttributes.GetShardId()))
+	}
+}
+
+func getDLQMessageDomainID(message *replicator.DLQMessage) string {
+	task := message.GetReplicationTask().GetTask()
+	switch {
+	case task.GetHistoryTaskAttributes() != nil:
+		return task.HistoryTaskAttributes.GetDomainId()
+	case task.GetDomainTaskAttributes() != nil:
+		return task.DomainTaskAttributes.GetID()
+	}
+	return ""
+}
+
+func convertDataBlob(blob *persistence.DataBlob) *gen.DataBlob {
+	encoding := gen.EncodingTypeThriftRW
+	if blob.Encoding == common.EncodingTypeJSON {
+		encoding = gen.EncodingTypeJSON
+	}
+	return &gen.DataBlob{
+		EncodingType: encoding.Ptr(),
+		Data:         blob.Data,
+	}
+}
+
+// DescribeReplicationStatus returns the replication levels of the requested shards, all shards by default, for each
+// remote cluster along with the offsets of the kafka consumers receiving the replication tasks of the remote clusters
+func (adh *AdminHandler) DescribeReplicationStatus(ctx context.Context, request *admin.DescribeReplicationStatusRequest) (*admin.DescribeReplicationStatusResponse, error) {
+	if request == nil {
+		return nil, adh.error(errRequestNotSet)
+	}
+
+	shardIDs := request.ShardIDs
+	if len(shardIDs) == 0 {
+		for shardID := 0; shardID < adh.numberOfHistoryShards; shardID++ {
+			shardIDs = append(shardIDs, int32(shardID))
+		}
+	}
+	for _, shardID := range shardIDs {
+		if shardID < 0 || int(shardID) >= adh.numberOfHistoryShards {
+			return nil, adh.error(errInvalidShardID)
+		}
+	}
+
+	if err := adh.authorize(ctx, "DescribeReplicationStatus", "", nil); err != nil {
+		return nil, adh.error(err)
+	}
+
+	resp, err := adh.history.DescribeReplicationStatus(ctx, &replicator.DescribeReplicationStatusRequest{ShardIDs: shardIDs})
+	if err != nil {
+		return nil, adh.error(err)
+	}
+
+	return &admin.DescribeReplicationStatusResponse{
+		StatusByShard:            resp.StatusByShard,
+		ConsumersBySourceCluster: adh.getReplicationConsumers(),
+	}, nil
+}
+
+// getReplicationConsumers returns the offsets of the consumers receiving the replication tasks of every remote
+// cluster, the consumers which cannot be described are left out
+func (adh *AdminHandler) getReplicationConsumers() map[string]*admin.ReplicationConsumerStatus {
+	clusterMetadata := adh.GetClusterMetadata()
+	if !clusterMetadata.IsGlobalDomainEnabled() {
+		return nil
+	}
+
+	currentCluster := clusterMetadata.GetCurrentClusterName()
+	consumers := make(map[string]*admin.ReplicationConsumerStatus)
+	for cluster := range clusterMetadata.GetAllClusterFailoverVersions() {
+		if cluster == currentCluster {
+			continue
+		}
+
+		consumerName := messaging.GetReplicationConsumerName(currentCluster, cluster)
+		offsets, err := adh.GetMessagingClient().GetConsumerOffsets(cluster, consumerName)
+		if err != nil {
+			adh.GetLogger().WithFields(bark.Fields{
+				logging.TagSourceCluster: cluster,
+				logging.TagErr:           err,
+			}).Warn("Failed to get replication consumer offsets.")
+			continue
+		}
+		consumers[cluster] = &admin.ReplicationConsumerStatus{
+			ConsumerName: common.StringPtr(consumerName),
+			Partitions:   offsets,
+		}
+	}
+	return consumers
+}
+
+// AddCluster registers a cluster in the metadata store of the current cluster, the cluster metadata of the
+// current cluster picks it up within its refresh interval
+func (adh *AdminHandler) AddCluster(ctx context.Context, request *admin.AddClusterRequest) error {
+	if request == nil {
+		return adh.error(errRequestNotSet)
+	}
+	if request.GetClusterName() == "" {
+		return adh.error(errClusterNameNotSet)
+	}
+	if request.InitialFailoverVersion == nil {
+		return adh.error(errInitialFailoverVersionNotSet)
+	}
+
+	if err := adh.authorize(ctx, "AddCluster", "", nil); err != nil {
+		return adh.error(err)
+	}
+
+	registered, err := adh.getRegisteredCluster(request.GetClusterName())
+	if err != nil {
+		return adh.error(err)
+	}
+	if _, ok := adh.GetClusterMetadata().GetAllClusterFailoverVersions()[request.GetClusterName()]; ok || registered != nil {
+		return adh.error(&gen.BadRequestError{
+			Message: fmt.Sprintf("Cluster %v already exists, update it instead.", request.GetClusterName()),
+		})
+	}
+
+	return adh.upsertCluster(&persistence.ClusterInfo{
+		ClusterName:            request.GetClusterName(),
+		InitialFailoverVersion: request.GetInitialFailoverVersion(),
+		Enabled:                true,
+		RPCName:                request.GetRpcName(),
+		RPCAddress:             request.GetRpcAddress(),
+		ReplicationTopic:       request.GetReplicationTopic(),
+	})
+}
+
+// UpdateCluster enables or disables a cluster, or changes its frontend address or replication topic, a cluster
+// which is only in the static config is registered in the metadata store with the changes
+func (adh *AdminHandler) UpdateCluster(ctx context.Context, request *admin.UpdateClusterRequest) error {
+	if request == nil {
+		return adh.error(errRequestNotSet)
+	}
+	if request.GetClusterName() == "" {
+		return adh.error(errClusterNameNotSet)
+	}
+
+	if err := adh.authorize(ctx, "UpdateCluster", "", nil); err != nil {
+		return adh.error(err)
+	}
+
+	cluster, err := adh.getRegisteredCluster(request.GetClusterName())
+	if err != nil {
+		return adh.error(err)
+	}
+	if cluster == nil {
+		clusterMetadata := adh.GetClusterMetadata()
+		initialFailoverVersion, ok := clusterMetadata.GetAllClusterFailoverVersions()[request.GetClusterName()]
+		if !ok {
+			return adh.error(&gen.EntityNotExistsError{
+				Message: fmt.Sprintf("Cluster %v does not exist.", request.GetClusterName()),
+			})
+		}
+		address := clusterMetadata.GetAllClientAddress()[request.GetClusterName()]
+		cluster = &persistence.ClusterInfo{
+			ClusterName:            request.GetClusterName(),
+			InitialFailoverVersion: initialFailoverVersion,
+			Enabled:                clusterMetadata.IsClusterEnabled(request.GetClusterName()),
+			RPCName:                address.RPCName,
+			RPCAddress:             address.RPCAddress,
+			ReplicationTopic:       clusterMetadata.GetReplicationTopic(request.GetClusterName()),
+		}
+	}
+
+	if request.Enabled != nil {
+		cluster.Enabled = request.GetEnabled()
+	}
+	if request.RpcName != nil {
+		cluster.RPCName = request.GetRpcName()
+	}
+	if request.RpcAddress != nil {
+		cluster.RPCAddress = request.GetRpcAddress()
+	}
+	if request.ReplicationTopic != nil {
+		cluster.ReplicationTopic = request.GetReplicationTopic()
+	}
+	return adh.upsertCluster(cluster)
+}
+
+// ListClusters returns the clusters known to the current cluster along with the registered ones, which are not
+// picked up yet or are skipped for being invalid
+func (adh *AdminHandler) ListClusters(ctx context.Context, request *admin.ListClustersRequest) (*admin.ListClustersResponse, error) {
+	if request == nil {
+		return nil, adh.error(errRequestNotSet)
+	}
+
+	if err := adh.authorize(ctx, "ListClusters", "", nil); err != nil {
+		return nil, adh.error(err)
+	}
+
+	resp, err := adh.metadataMgr.ListClusters()
+	if err != nil {
+		return nil, adh.error(err)
+	}
+	registered := make(map[string]*persistence.ClusterInfo)
+	for _, cluster := range resp.Clusters {
+		registered[cluster.ClusterName] = cluster
+	}
+
+	clusterMetadata := adh.GetClusterMetadata()
+	addresses := clusterMetadata.GetAllClientAddress()
+	var clusters []*admin.ClusterInfo
+	for clusterName, initialFailoverVersion := range clusterMetadata.GetAllClusterFailoverVersions() {
+		address, hasAddress := addresses[clusterName]
+		cluster, isRegistered := registered[clusterName]
+		if !hasAddress && isRegistered {
+			// disabled clusters have no address in the cluster metadata
+			address = config.Address{RPCName: cluster.RPCName, RPCAddress: cluster.RPCAddress}
+		}
+		delete(registered, clusterName)
+		clusters = append(clusters, &admin.ClusterInfo{
+			ClusterName:            common.StringPtr(clusterName),
+			InitialFailoverVersion: common.Int64Ptr(initialFailoverVersion),
+			Enabled:                common.BoolPtr(clusterMetadata.IsClusterEnabled(clusterName)),
+			RpcName:                common.StringPtr(address.RPCName),
+			RpcAddress:             common.StringPtr(address.RPCAddress),
+			ReplicationTopic:       common.StringPtr(clusterMetadata.GetReplicationTopic(clusterName)),
+			IsCurrentCluster:       common.BoolPtr(clusterName == clusterMetadata.GetCurrentClusterName()),
+			IsRegistered:           common.BoolPtr(isRegistered),
+		})
+	}
+	for _, cluster := range registered {
+		clusters = append(clusters, &admin.ClusterInfo{
+			ClusterName:            common.StringPtr(cluster.ClusterName),
+			InitialFailoverVersion: common.Int64Ptr(cluster.InitialFailoverVersion),
+			Enabled:                common.BoolPtr(cluster.Enabled),
+			RpcName:                common.StringPtr(cluster.RPCName),
+			RpcAddress:             common.StringPtr(cluster.RPCAddress),
+			ReplicationTopic:       common.StringPtr(cluster.ReplicationTopic),
+			IsCurrentCluster:       common.BoolPtr(false),
+			IsRegistered:           common.BoolPtr(true),
+		})
+	}
+	sort.Slice(clusters, func(i, j int) bool { return clusters[i].GetClusterName() < clusters[j].GetClusterName() })
+	return &admin.ListClustersResponse{Clusters: clusters}, nil
+}
+
+// ListGlobalDomains returns the records of the global domains stored in the current cluster as domain update
+// replication tasks, so the records of another cluster can be reconciled by applying them
+func (adh *AdminHandler) ListGlobalDomains(ctx context.Context, request *admin.ListGlobalDomainsRequest) (*admin.ListGlobalDomainsResponse, error) {
+	if request == nil {
+		return nil, adh.error(errRequestNotSet)
+	}
+	pageSize := defaultDomainPageSize
+	if request.PageSize != nil {
+		if request.GetPageSize() <= 0 {
+			return nil, adh.error(errInvalidPageSize)
+		}
+		pageSize = int(request.GetPageSize())
+	}
+
+	if err := adh.authorize(ctx, "ListGlobalDomains", "", nil); err != nil {
+		return nil, adh.error(err)
+	}
+
+	resp, err := adh.metadataMgr.ListDomains(&persistence.ListDomainsRequest{
+		PageSize:      pageSize,
+		NextPageToken: request.NextPageToken,
+	})
+	if err != nil {
+		return nil, adh.error(err)
+	}
+
+	domains := []*replicator.DomainTaskAttributes{}
+	for _, domain := range resp.Domains {
+		if !domain.IsGlobalDomain {
+			continue
+		}
+		task, err := newDomainTaskAttributes(replicator.DomainOperationUpdate, domain.Info, domain.Config,
+			domain.ReplicationConfig, domain.ConfigVersion, domain.FailoverVersion, domain.FailoverHistory)
+		if err != nil {
+			return nil, adh.error(&gen.InternalServiceError{
+				Message: fmt.Sprintf("Domain %v cannot be converted: %v.", domain.Info.Name, err),
+			})
+		}
+		domains = append(domains, task)
+	}
+	return &admin.ListGlobalDomainsResponse{
+		Domains:       domains,
+		NextPageToken: resp.NextPageToken,
+	}, nil
+}
+
+// getRegisteredCluster returns the cluster registered in the metadata store, nil if the cluster is not registered
+func (adh *AdminHandler) getRegisteredCluster(clusterName string) (*persistence.ClusterInfo, error) {
+	resp, err := adh.metadataMgr.ListClusters()
+	if err != nil {
+		return nil, err
+	}
+	for _, cluster := range resp.Clusters {
+		if cluster.ClusterName == clusterName {
+			return cluster, nil
+		}
+	}
+	return nil, nil
+}
+
+func (adh *AdminHandler) upsertCluster(cluster *persistence.ClusterInfo) error {
+	if err := adh.GetClusterMetadata().ValidateClusterRegistration(cluster); err != nil {
+		return adh.error(&gen.BadRequestError{Message: fmt.Sprintf("Invalid cluster: %v.", err)})
+	}
+	if err := adh.metadataMgr.UpsertCluster(&persistence.UpsertClusterRequest{Cluster: cluster}); err != nil {
+		return adh.error(err)
+	}
+	return nil
+}
+
+// authorize checks that the caller is an administrator, all admin APIs require the admin permission
+func (adh *AdminHandler) authorize(ctx context.Context, api string, domain string, execution *gen.WorkflowExecution) error {
+	return adh.authorizer.authorize(ctx, adh.GetLogger(), &authorization.Attributes{
+		APIName:    api,
+		Permission: authorization.PermissionAdmin,
+		DomainName: domain,
+		Execution:  execution,
+	})
+}
+
 func (adh *AdminHandler) error(err error) error {
 	switch err.(type) {
 	case *gen.InternalServiceError:
@@ -143,6 +843,12 @@ func (adh *AdminHandler) error(err error) error {
 		return err
 	case *gen.EntityNotExistsError:
 		return err
+	case *gen.AccessDeniedError:
+		return err
+	case *gen.WorkflowExecutionAlreadyStartedError:
+		return err
+	case *gen.RetryTaskError:
+		return err
 	default:
 		logging.LogUncategorizedError(adh.Service.GetLogger(), err)
 		return &gen.InternalServiceError{Message: err.Error()}
@@ -0,0 +1,286 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/uber-common/bark"
+	"github.com/uber/cadence/.gen/go/cadence"
+	"github.com/uber/cadence/.gen/go/cadence/workflowserviceserver"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/authorization"
+	"github.com/uber/cadence/common/codec/thriftproto"
+	"github.com/uber/cadence/common/service/config"
+	"go.uber.org/thriftrw/compile"
+	"go.uber.org/thriftrw/protocol"
+	"go.uber.org/thriftrw/wire"
+	"go.uber.org/yarpc/api/transport"
+	"go.uber.org/yarpc/yarpcerrors"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+)
+
+const (
+	workflowServiceName = "WorkflowService"
+	// gatewayCallerName is the caller reported for gateway requests which do not name themselves
+	gatewayCallerName = "cadence-api-gateway"
+	// gatewayCallerHeader is the header gRPC and HTTP callers can use to name themselves
+	gatewayCallerHeader = "rpc-caller"
+
+	gatewayShutdownTimeout = 10 * time.Second
+)
+
+type (
+	// apiGateway serves the WorkflowService to gRPC and HTTP/JSON callers. Requests are mapped to
+	// thrift and dispatched to the same thrift procedures as the TChannel inbound, so all the
+	// endpoints share the WorkflowHandler.
+	apiGateway struct {
+		schema   *thriftproto.Schema
+		handlers map[string]transport.UnaryHandler
+		logger   bark.Logger
+		servers  []*http.Server
+	}
+
+	// gatewayResult is the outcome of a call, either the response or the exception thrown
+	gatewayResult struct {
+		responseSpec compile.TypeSpec
+		response     wire.Value
+		exception    *gatewayException
+	}
+
+	// gatewayException is a thrift exception thrown by the handler
+	gatewayException struct {
+		spec    *compile.StructSpec
+		value   wire.Value
+		code    yarpcerrors.Code
+		message string
+	}
+
+	// gatewayResponseWriter captures the thrift response of a procedure
+	gatewayResponseWriter struct {
+		body bytes.Buffer
+	}
+)
+
+// exceptionCodes maps the thrift exceptions to the gRPC status codes, which yarpc codes mirror
+var exceptionCodes = map[string]yarpcerrors.Code{
+	"BadRequestError":                      yarpcerrors.CodeInvalidArgument,
+	"InternalServiceError":                 yarpcerrors.CodeInternal,
+	"DomainAlreadyExistsError":             yarpcerrors.CodeAlreadyExists,
+	"WorkflowExecutionAlreadyStartedError": yarpcerrors.CodeAlreadyExists,
+	"EntityNotExistsError":                 yarpcerrors.CodeNotFound,
+	"ServiceBusyError":                     yarpcerrors.CodeResourceExhausted,
+	"CancellationAlreadyRequestedError":    yarpcerrors.CodeAlreadyExists,
+	"QueryFailedError":                     yarpcerrors.CodeFailedPrecondition,
+	"DomainNotActiveError":                 yarpcerrors.CodeFailedPrecondition,
+	"LimitExceededError":                   yarpcerrors.CodeResourceExhausted,
+	"AccessDeniedError":                    yarpcerrors.CodePermissionDenied,
+}
+
+var _ transport.ResponseWriter = (*gatewayResponseWriter)(nil)
+
+func newAPIGateway(handler workflowserviceserver.Interface, logger bark.Logger) (*apiGateway, error) {
+	schema, err := thriftproto.NewSchema(cadence.ThriftModule, workflowServiceName)
+	if err != nil {
+		return nil, err
+	}
+	handlers := make(map[string]transport.UnaryHandler)
+	for _, procedure := range workflowserviceserver.New(handler) {
+		if procedure.HandlerSpec.Type() == transport.Unary {
+			handlers[procedure.Name] = procedure.HandlerSpec.Unary()
+		}
+	}
+	return &apiGateway{
+		schema:   schema,
+		handlers: handlers,
+		logger:   logger,
+	}, nil
+}
+
+// Start serves gRPC and HTTP/JSON on the listeners, nil listeners are skipped
+func (g *apiGateway) Start(grpcListener net.Listener, httpListener net.Listener) {
+	if grpcListener != nil {
+		// gRPC is HTTP/2 only, plaintext callers speak it with prior knowledge (h2c) and TLS callers
+		// negotiate it during the handshake
+		h2Server := &http2.Server{}
+		server := &http.Server{Handler: h2c.NewHandler(http.HandlerFunc(g.serveGRPC), h2Server)}
+		if err := http2.ConfigureServer(server, h2Server); err != nil {
+			g.logger.WithField("error", err).Fatal("Configuring HTTP/2 for the gRPC endpoint failed")
+		}
+		g.serve(server, grpcListener, "gRPC")
+	}
+	if httpListener != nil {
+		g.serve(&http.Server{Handler: http.HandlerFunc(g.serveHTTP)}, httpListener, "HTTP/JSON")
+	}
+}
+
+// Stop stops accepting requests and waits for the outstanding ones to finish
+func (g *apiGateway) Stop() {
+	ctx, cancel := context.WithTimeout(context.Background(), gatewayShutdownTimeout)
+	defer cancel()
+	for _, server := range g.servers {
+		server.Shutdown(ctx)
+	}
+}
+
+func (g *apiGateway) serve(server *http.Server, listener net.Listener, name string) {
+	g.servers = append(g.servers, server)
+	go func() {
+		if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			g.logger.WithField("error", err).Errorf("%v endpoint stopped", name)
+		}
+	}()
+	g.logger.Infof("Serving %v %v at '%v'", workflowServiceName, name, listener.Addr())
+}
+
+// invoke calls the thrift procedure of the method with the request
+func (g *apiGateway) invoke(ctx context.Context, method string, caller string, headers map[string]string,
+	request func(compile.TypeSpec) (wire.Value, error)) (*gatewayResult, error) {
+	spec, ok := g.schema.Method(method)
+	if !ok {
+		return nil, yarpcerrors.UnimplementedErrorf("unknown method %v", method)
+	}
+	procedure := procedureName(method)
+	handler, ok := g.handlers[procedure]
+	if !ok {
+		return nil, yarpcerrors.UnimplementedErrorf("unknown method %v", method)
+	}
+	argSpec, err := thriftproto.RequestSpec(spec)
+	if err != nil {
+		return nil, yarpcerrors.InternalErrorf("%v", err)
+	}
+	value, err := request(argSpec.Type)
+	if err != nil {
+		return nil, yarpcerrors.InvalidArgumentErrorf("failed to decode %v request: %v", method, err)
+	}
+
+	var body bytes.Buffer
+	args := wire.NewValueStruct(wire.Struct{Fields: []wire.Field{{ID: argSpec.ID, Value: value}}})
+	if err := protocol.Binary.Encode(args, &body); err != nil {
+		return nil, yarpcerrors.InvalidArgumentErrorf("invalid %v request: %v", method, err)
+	}
+	if caller == "" {
+		caller = gatewayCallerName
+	}
+	rw := &gatewayResponseWriter{}
+	err = handler.Handle(ctx, &transport.Request{
+		Caller:    caller,
+		Service:   common.FrontendServiceName,
+		Encoding:  transport.Encoding("thrift"),
+		Procedure: procedure,
+		Headers:   transport.HeadersFromMap(headers),
+		Body:      &body,
+	}, rw)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := protocol.Binary.Decode(bytes.NewReader(rw.body.Bytes()), wire.TStruct)
+	if err != nil {
+		return nil, yarpcerrors.InternalErrorf("failed to decode %v response: %v", method, err)
+	}
+	result := &gatewayResult{
+		responseSpec: thriftproto.ResponseSpec(spec),
+		response:     wire.NewValueStruct(wire.Struct{}),
+	}
+	for _, field := range response.GetStruct().Fields {
+		if field.ID == 0 {
+			result.response = field.Value
+			continue
+		}
+		exceptionSpec, ok := thriftproto.ExceptionSpec(spec, field.ID)
+		if !ok {
+			return nil, yarpcerrors.InternalErrorf("unknown exception %v thrown by %v", field.ID, method)
+		}
+		result.exception = newGatewayException(exceptionSpec.Type, field.Value)
+	}
+	return result, nil
+}
+
+func newGatewayException(spec compile.TypeSpec, value wire.Value) *gatewayException {
+	s := compile.RootTypeSpec(spec).(*compile.StructSpec)
+	exception := &gatewayException{
+		spec:  s,
+		value: value,
+		code:  yarpcerrors.CodeUnknown,
+	}
+	if code, ok := exceptionCodes[s.Name]; ok {
+		exception.code = code
+	}
+	if messageSpec, err := s.Fields.FindByName("message"); err == nil {
+		for _, field := range value.GetStruct().Fields {
+			if field.ID == messageSpec.ID {
+				exception.message = field.Value.GetString()
+			}
+		}
+	}
+	if exception.message == "" {
+		exception.message = s.Name
+	}
+	return exception
+}
+
+// gatewayHeaders returns the headers of the request which are passed on to the handler
+func gatewayHeaders(header http.Header, reserved func(string) bool) map[string]string {
+	headers := make(map[string]string)
+	for key, values := range header {
+		key = strings.ToLower(key)
+		if len(values) == 0 || reserved(key) {
+			continue
+		}
+		headers[key] = values[0]
+	}
+	return headers
+}
+
+// withGatewayPeerIdentity attaches the identity of the verified TLS client certificate of the request to the
+// context, as the TChannel inbound does, so that the identity header is only honored for delegates. Callers
+// connected over TLS without a verified certificate cannot claim an identity and their identity header is dropped.
+func withGatewayPeerIdentity(ctx context.Context, r *http.Request, headers map[string]string) context.Context {
+	if r.TLS == nil {
+		return ctx
+	}
+	identity := config.TLSConnectionIdentity(*r.TLS)
+	if identity == "" {
+		delete(headers, authorization.CallerIdentityHeader)
+		return ctx
+	}
+	return authorization.WithPeerIdentity(ctx, identity)
+}
+
+func procedureName(method string) string {
+	return fmt.Sprintf("%v::%v", workflowServiceName, method)
+}
+
+func (w *gatewayResponseWriter) Write(p []byte) (int, error) {
+	return w.body.Write(p)
+}
+
+func (w *gatewayResponseWriter) AddHeaders(transport.Headers) {}
+
+func (w *gatewayResponseWriter) SetApplicationError() {}
@@ -0,0 +1,258 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/binary"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-common/bark"
+	"github.com/uber/cadence/.gen/go/cadence/workflowserviceserver"
+	"github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/authorization"
+	"github.com/uber/cadence/common/codec/thriftproto"
+	"go.uber.org/thriftrw/compile"
+	"go.uber.org/thriftrw/wire"
+	"go.uber.org/yarpc"
+	"golang.org/x/net/http2"
+)
+
+type (
+	apiGatewaySuite struct {
+		suite.Suite
+		handler  *gatewayTestHandler
+		gateway  *apiGateway
+		grpcAddr string
+		httpAddr string
+	}
+
+	// gatewayTestHandler implements the calls used by the tests, the others panic
+	gatewayTestHandler struct {
+		workflowserviceserver.Interface
+		startRequest *shared.StartWorkflowExecutionRequest
+		caller       string
+		identity     string
+		identityErr  error
+	}
+)
+
+func TestAPIGatewaySuite(t *testing.T) {
+	suite.Run(t, new(apiGatewaySuite))
+}
+
+func (s *apiGatewaySuite) SetupTest() {
+	s.handler = &gatewayTestHandler{}
+	gateway, err := newAPIGateway(s.handler, bark.NewLoggerFromLogrus(logrus.New()))
+	s.NoError(err)
+	s.gateway = gateway
+
+	grpcListener, err := net.Listen("tcp", "127.0.0.1:0")
+	s.NoError(err)
+	httpListener, err := net.Listen("tcp", "127.0.0.1:0")
+	s.NoError(err)
+	s.grpcAddr = grpcListener.Addr().String()
+	s.httpAddr = httpListener.Addr().String()
+	s.gateway.Start(grpcListener, httpListener)
+}
+
+func (s *apiGatewaySuite) TearDownTest() {
+	s.gateway.Stop()
+}
+
+func (s *apiGatewaySuite) TestGRPC_Success() {
+	request := &shared.StartWorkflowExecutionRequest{
+		Domain:     common.StringPtr("orders"),
+		WorkflowId: common.StringPtr("order-42"),
+		Input:      []byte{1, 2, 3},
+	}
+	value, err := request.ToWire()
+	s.NoError(err)
+	message, err := thriftproto.EncodeProto(s.requestSpec("StartWorkflowExecution"), value)
+	s.NoError(err)
+
+	resp, body := s.callGRPC("StartWorkflowExecution", message)
+	s.Equal("0", resp.Trailer.Get("Grpc-Status"))
+	s.True(request.Equals(s.handler.startRequest))
+	s.Equal("grpc-test", s.handler.caller)
+
+	s.Equal(byte(0), body[0])
+	s.Equal(uint32(len(body)-5), binary.BigEndian.Uint32(body[1:5]))
+	method, _ := s.gateway.schema.Method("StartWorkflowExecution")
+	decoded, err := thriftproto.DecodeProto(thriftproto.ResponseSpec(method), body[5:])
+	s.NoError(err)
+	var response shared.StartWorkflowExecutionResponse
+	s.NoError(response.FromWire(decoded))
+	s.Equal("run-1", response.GetRunId())
+}
+
+func (s *apiGatewaySuite) TestGRPC_Exception() {
+	resp, _ := s.callGRPC("DescribeDomain", nil)
+	// failed calls carry no message and are sent as trailers-only responses
+	s.Equal("5", resp.Header.Get("Grpc-Status"))
+	s.Equal("domain missing: orders", resp.Header.Get("Grpc-Message"))
+	s.NotEmpty(resp.Header.Get("Grpc-Status-Details-Bin"))
+}
+
+func (s *apiGatewaySuite) TestGRPC_UnknownMethod() {
+	resp, _ := s.callGRPC("NoSuchMethod", nil)
+	s.Equal("12", resp.Header.Get("Grpc-Status"))
+}
+
+func (s *apiGatewaySuite) TestHTTP_Success() {
+	resp, body := s.callHTTP("StartWorkflowExecution", `{"domain": "orders", "input": "AQID"}`)
+	s.Equal(http.StatusOK, resp.StatusCode)
+	s.JSONEq(`{"runId": "run-1"}`, string(body))
+	s.Equal("orders", s.handler.startRequest.GetDomain())
+	s.Equal([]byte{1, 2, 3}, s.handler.startRequest.Input)
+	s.Equal("http-test", s.handler.caller)
+}
+
+func (s *apiGatewaySuite) TestHTTP_Exception() {
+	resp, body := s.callHTTP("DescribeDomain", "")
+	s.Equal(http.StatusNotFound, resp.StatusCode)
+	var result map[string]interface{}
+	s.NoError(json.Unmarshal(body, &result))
+	s.Equal("EntityNotExistsError", result["error"])
+	s.Equal("domain missing: orders", result["message"])
+	s.Equal(map[string]interface{}{"message": "domain missing: orders"}, result["details"])
+}
+
+func (s *apiGatewaySuite) TestHTTP_BadRequest() {
+	resp, _ := s.callHTTP("StartWorkflowExecution", `{"noSuchField": true}`)
+	s.Equal(http.StatusBadRequest, resp.StatusCode)
+
+	resp, _ = s.callHTTP("NoSuchMethod", `{}`)
+	s.Equal(http.StatusNotImplemented, resp.StatusCode)
+}
+
+func (s *apiGatewaySuite) TestPeerIdentity() {
+	request, err := http.NewRequest(http.MethodPost, "https://"+s.httpAddr+httpAPIPath+"StartWorkflowExecution", nil)
+	s.NoError(err)
+	request.Header.Set(authorization.CallerIdentityHeader, "admin")
+
+	// plaintext callers are identified by the header, as on TChannel
+	identity, err := s.invokeWithIdentity(request)
+	s.NoError(err)
+	s.Equal("admin", identity)
+
+	// TLS callers without a verified certificate cannot claim an identity
+	request.TLS = &tls.ConnectionState{}
+	identity, err = s.invokeWithIdentity(request)
+	s.NoError(err)
+	s.Equal("", identity)
+
+	// the identity of the verified certificate is bound to the call, the header is only honored for delegates
+	request.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{
+		{{Subject: pkix.Name{CommonName: "worker"}}},
+	}}
+	_, err = s.invokeWithIdentity(request)
+	s.Equal(authorization.ErrCallerIdentityMismatch, err)
+	request.Header.Del(authorization.CallerIdentityHeader)
+	identity, err = s.invokeWithIdentity(request)
+	s.NoError(err)
+	s.Equal("worker", identity)
+}
+
+// invokeWithIdentity calls StartWorkflowExecution as the gateway does for the request and returns the
+// caller identity seen by the handler
+func (s *apiGatewaySuite) invokeWithIdentity(request *http.Request) (string, error) {
+	headers := gatewayHeaders(request.Header, isReservedHTTPHeader)
+	ctx := withGatewayPeerIdentity(context.Background(), request, headers)
+	_, err := s.gateway.invoke(ctx, "StartWorkflowExecution", "", headers,
+		func(spec compile.TypeSpec) (wire.Value, error) {
+			return thriftproto.DecodeJSON(spec, []byte("{}"))
+		})
+	s.NoError(err)
+	return s.handler.identity, s.handler.identityErr
+}
+
+func (s *apiGatewaySuite) callGRPC(method string, message []byte) (*http.Response, []byte) {
+	frame := make([]byte, 5, 5+len(message))
+	binary.BigEndian.PutUint32(frame[1:], uint32(len(message)))
+	request, err := http.NewRequest(http.MethodPost, "http://"+s.grpcAddr+grpcServicePath+method,
+		bytes.NewReader(append(frame, message...)))
+	s.NoError(err)
+	request.Header.Set("Content-Type", grpcContentType)
+	request.Header.Set("Grpc-Timeout", "5S")
+	request.Header.Set(gatewayCallerHeader, "grpc-test")
+
+	// speak HTTP/2 over plaintext with prior knowledge, as gRPC clients do
+	client := &http.Client{Transport: &http2.Transport{
+		AllowHTTP: true,
+		DialTLS: func(network, addr string, cfg *tls.Config) (net.Conn, error) {
+			return net.Dial(network, addr)
+		},
+	}}
+	resp, err := client.Do(request)
+	s.NoError(err)
+	defer resp.Body.Close()
+	s.Equal(2, resp.ProtoMajor)
+	// trailers are populated once the body is read
+	body, err := ioutil.ReadAll(resp.Body)
+	s.NoError(err)
+	return resp, body
+}
+
+func (s *apiGatewaySuite) callHTTP(method string, body string) (*http.Response, []byte) {
+	request, err := http.NewRequest(http.MethodPost, "http://"+s.httpAddr+httpAPIPath+method, bytes.NewBufferString(body))
+	s.NoError(err)
+	request.Header.Set("Content-Type", httpContentType)
+	request.Header.Set(gatewayCallerHeader, "http-test")
+	resp, err := http.DefaultClient.Do(request)
+	s.NoError(err)
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	s.NoError(err)
+	return resp, data
+}
+
+func (s *apiGatewaySuite) requestSpec(name string) compile.TypeSpec {
+	method, ok := s.gateway.schema.Method(name)
+	s.True(ok)
+	arg, err := thriftproto.RequestSpec(method)
+	s.NoError(err)
+	return arg.Type
+}
+
+func (h *gatewayTestHandler) StartWorkflowExecution(ctx context.Context,
+	request *shared.StartWorkflowExecutionRequest) (*shared.StartWorkflowExecutionResponse, error) {
+	h.startRequest = request
+	h.caller = yarpc.CallFromContext(ctx).Caller()
+	h.identity, h.identityErr = authorization.GetCallerIdentity(ctx, nil)
+	return &shared.StartWorkflowExecutionResponse{RunId: common.StringPtr("run-1")}, nil
+}
+
+func (h *gatewayTestHandler) DescribeDomain(ctx context.Context,
+	request *shared.DescribeDomainRequest) (*shared.DescribeDomainResponse, error) {
+	return nil, &shared.EntityNotExistsError{Message: "domain missing: orders"}
+}
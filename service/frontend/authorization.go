@@ -0,0 +1,81 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"context"
+
+	"github.com/uber-common/bark"
+	gen "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common/authorization"
+	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/logging"
+	"github.com/uber/cadence/common/service/config"
+)
+
+type (
+	// requestAuthorizer identifies the callers of the frontend and admin APIs and checks their calls
+	requestAuthorizer struct {
+		enabled    bool
+		delegates  []string
+		authorizer authorization.Authorizer
+	}
+)
+
+// newAuthorizer uses the ACL authorizer if authorization is enabled and allows every call otherwise
+func newAuthorizer(cfg config.Authorization, domainCache cache.DomainCache) *requestAuthorizer {
+	if !cfg.Enable {
+		return &requestAuthorizer{}
+	}
+	return &requestAuthorizer{
+		enabled:    true,
+		delegates:  cfg.Delegates,
+		authorizer: authorization.NewACLAuthorizer(cfg, domainCache),
+	}
+}
+
+// authorize identifies the caller and checks the call with the authorizer. Denials, including callers
+// whose identity header disagrees with their verified identity, are written to the audit log and
+// returned as AccessDeniedError.
+func (a *requestAuthorizer) authorize(ctx context.Context, logger bark.Logger,
+	attributes *authorization.Attributes) error {
+	if !a.enabled {
+		return nil
+	}
+	identity, err := authorization.GetCallerIdentity(ctx, a.delegates)
+	if err != nil {
+		logging.LogAuthorizationDeniedEvent(logger, "", attributes.APIName, attributes.DomainName,
+			attributes.Execution.GetWorkflowId(), attributes.Execution.GetRunId(), err.Error())
+		return &gen.AccessDeniedError{Message: err.Error()}
+	}
+	attributes.Identity = identity
+	result, err := a.authorizer.Authorize(ctx, attributes)
+	if err != nil {
+		return err
+	}
+	if result.Decision == authorization.DecisionAllow {
+		return nil
+	}
+
+	logging.LogAuthorizationDeniedEvent(logger, attributes.Identity, attributes.APIName, attributes.DomainName,
+		attributes.Execution.GetWorkflowId(), attributes.Execution.GetRunId(), result.Reason)
+	return &gen.AccessDeniedError{Message: result.Reason}
+}
@@ -0,0 +1,187 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"context"
+	"sync"
+
+	"github.com/uber/cadence/.gen/go/cadence/workflowserviceclient"
+	"github.com/uber/cadence/client"
+	"github.com/uber/cadence/common/authorization"
+	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/cluster"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/service/config"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+	"go.uber.org/yarpc"
+)
+
+const (
+	// forwardedFromHeader names the cluster which forwarded the request
+	forwardedFromHeader = "cadence-forwarded-from"
+)
+
+type (
+	// clusterForwarder forwards the calls of global domains which are active in another cluster to
+	// the frontend of the active cluster, so that clients do not have to route the calls themselves
+	clusterForwarder struct {
+		currentClusterName string
+		clusterMetadata    cluster.Metadata
+		clientFactory      client.Factory
+		domainCache        cache.DomainCache
+		metricsClient      metrics.Client
+		// enabled tells whether forwarding is enabled for the API group of a domain
+		enabled [numQuotaTypes]dynamicconfig.BoolPropertyFnWithDomainFilter
+
+		sync.RWMutex
+		// clients are the frontend clients of the other clusters, created on the first forwarded call and
+		// recreated when the address of the cluster changes
+		clients map[string]*remoteFrontendClient
+	}
+
+	remoteFrontendClient struct {
+		address config.Address
+		client  workflowserviceclient.Interface
+	}
+
+	// forwardFn makes the call against the frontend of the active cluster
+	forwardFn func(client workflowserviceclient.Interface, opts ...yarpc.CallOption) error
+)
+
+func newClusterForwarder(config *Config, clusterMetadata cluster.Metadata, domainCache cache.DomainCache,
+	clientFactory client.Factory, metricsClient metrics.Client) *clusterForwarder {
+	f := &clusterForwarder{
+		currentClusterName: clusterMetadata.GetCurrentClusterName(),
+		clusterMetadata:    clusterMetadata,
+		clientFactory:      clientFactory,
+		domainCache:        domainCache,
+		metricsClient:      metricsClient,
+		clients:            make(map[string]*remoteFrontendClient),
+	}
+	f.enabled[quotaPoll] = config.ForwardPoll
+	f.enabled[quotaStart] = config.ForwardStart
+	f.enabled[quotaSignal] = config.ForwardSignal
+	f.enabled[quotaAPI] = config.ForwardAPI
+	return f
+}
+
+// forward makes the call against the active cluster of the domain if the domain is active in another
+// cluster and forwarding is enabled for the API group. It returns false if the call is to be handled
+// locally, otherwise the outcome of the forwarded call.
+func (f *clusterForwarder) forward(ctx context.Context, group quotaType, domainName string, scope int,
+	call forwardFn) (bool, error) {
+	entry, err := f.domainCache.GetDomain(domainName)
+	if err != nil {
+		// the local handling reports the error
+		return false, nil
+	}
+	return f.forwardToActiveCluster(ctx, group, entry, scope, call)
+}
+
+// forwardByDomainID is forward for the calls which identify the domain by its id, like the task
+// completions
+func (f *clusterForwarder) forwardByDomainID(ctx context.Context, group quotaType, domainID string, scope int,
+	call forwardFn) (bool, error) {
+	entry, err := f.domainCache.GetDomainByID(domainID)
+	if err != nil {
+		return false, nil
+	}
+	return f.forwardToActiveCluster(ctx, group, entry, scope, call)
+}
+
+func (f *clusterForwarder) forwardToActiveCluster(ctx context.Context, group quotaType, entry *cache.DomainCacheEntry,
+	scope int, call forwardFn) (bool, error) {
+	if entry.IsDomainActive() || f.enabled[group] == nil || !f.enabled[group](entry.GetInfo().Name) {
+		return false, nil
+	}
+	// requests are forwarded once, clusters which disagree about the active cluster during a failover
+	// must not bounce a request between them
+	if yarpc.CallFromContext(ctx).Header(forwardedFromHeader) != "" {
+		return false, nil
+	}
+	client, ok := f.getClient(entry.GetReplicationConfig().ActiveClusterName)
+	if !ok {
+		return false, nil
+	}
+
+	f.metricsClient.IncCounter(scope, metrics.ForwardedRequests)
+	sw := f.metricsClient.StartTimer(scope, metrics.ForwardedRequestLatency)
+	defer sw.Stop()
+	if err := call(client, forwardedHeaders(ctx, f.currentClusterName)...); err != nil {
+		f.metricsClient.IncCounter(scope, metrics.ForwardedRequestFailures)
+		return true, err
+	}
+	return true, nil
+}
+
+// forwardedHeaders passes the application headers of the call on to the active cluster, so that it
+// sees the same caller, and marks the request as forwarded from the current cluster
+func forwardedHeaders(ctx context.Context, currentClusterName string) []yarpc.CallOption {
+	headers := make(map[string]string)
+	if call := yarpc.CallFromContext(ctx); call != nil {
+		for _, name := range call.HeaderNames() {
+			headers[name] = call.Header(name)
+		}
+	}
+	// the identity verified by the transport is lost on the hop between the clusters
+	if identity, err := authorization.GetCallerIdentity(ctx, nil); err == nil && identity != "" {
+		headers[authorization.CallerIdentityHeader] = identity
+	}
+	headers[forwardedFromHeader] = currentClusterName
+
+	opts := make([]yarpc.CallOption, 0, len(headers))
+	for name, value := range headers {
+		opts = append(opts, yarpc.WithHeader(name, value))
+	}
+	return opts
+}
+
+// getClient returns the frontend client of the cluster, false if the cluster is the current one, disabled
+// or has no address
+func (f *clusterForwarder) getClient(clusterName string) (workflowserviceclient.Interface, bool) {
+	if clusterName == f.currentClusterName {
+		return nil, false
+	}
+	address, ok := f.clusterMetadata.GetAllClientAddress()[clusterName]
+	if !ok {
+		return nil, false
+	}
+
+	f.RLock()
+	remote, ok := f.clients[clusterName]
+	f.RUnlock()
+	if ok && remote.address == address {
+		return remote.client, true
+	}
+
+	f.Lock()
+	defer f.Unlock()
+	if remote, ok := f.clients[clusterName]; ok && remote.address == address {
+		return remote.client, true
+	}
+	remote = &remoteFrontendClient{
+		address: address,
+		client:  f.clientFactory.NewRemoteFrontendClient(address.RPCName, address.RPCAddress),
+	}
+	f.clients[clusterName] = remote
+	return remote.client, true
+}
@@ -0,0 +1,208 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-go/tally"
+	"github.com/uber/cadence/.gen/go/cadence/workflowserviceclient"
+	gen "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/client"
+	"github.com/uber/cadence/client/frontend"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/authorization"
+	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/cluster"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+	"go.uber.org/yarpc"
+	"go.uber.org/yarpc/api/encoding"
+	"go.uber.org/yarpc/api/transport"
+)
+
+type (
+	clusterForwarderSuite struct {
+		suite.Suite
+		domainCache *cache.DomainCacheMock
+		scope       tally.TestScope
+		remote      *forwarderTestClient
+		enabled     map[string]bool
+		forwarder   *clusterForwarder
+	}
+
+	// forwarderTestClient records the signals it receives, the other calls panic
+	forwarderTestClient struct {
+		workflowserviceclient.Interface
+		signals []*gen.SignalWorkflowExecutionRequest
+		err     error
+	}
+
+	forwarderTestFactory struct {
+		client.Factory
+		remote *forwarderTestClient
+	}
+)
+
+func TestClusterForwarderSuite(t *testing.T) {
+	suite.Run(t, new(clusterForwarderSuite))
+}
+
+func (s *clusterForwarderSuite) SetupTest() {
+	s.domainCache = &cache.DomainCacheMock{}
+	s.scope = tally.NewTestScope("", nil)
+	s.remote = &forwarderTestClient{}
+	s.enabled = map[string]bool{"global": true}
+
+	config := NewConfig(dynamicconfig.NewNopCollection(), 1)
+	config.ForwardSignal = func(domain string) bool { return s.enabled[domain] }
+	clusterMetadata := cluster.GetTestClusterMetadata(true, true)
+	s.forwarder = newClusterForwarder(config, clusterMetadata, s.domainCache,
+		&forwarderTestFactory{remote: s.remote}, metrics.NewClient(s.scope, metrics.Frontend))
+
+	for _, name := range []string{"global", "disabled"} {
+		entry := cache.NewGlobalDomainCacheEntryForTest(
+			&persistence.DomainInfo{ID: name + "-id", Name: name},
+			&persistence.DomainReplicationConfig{ActiveClusterName: cluster.TestAlternativeClusterName},
+			clusterMetadata,
+		)
+		s.domainCache.On("GetDomain", name).Return(entry, nil)
+		s.domainCache.On("GetDomainByID", name+"-id").Return(entry, nil)
+	}
+	s.domainCache.On("GetDomain", "active-here").Return(cache.NewGlobalDomainCacheEntryForTest(
+		&persistence.DomainInfo{ID: "active-here-id", Name: "active-here"},
+		&persistence.DomainReplicationConfig{ActiveClusterName: cluster.TestCurrentClusterName},
+		clusterMetadata,
+	), nil)
+	s.domainCache.On("GetDomain", "local").Return(cache.NewDomainCacheEntryWithInfo(
+		&persistence.DomainInfo{ID: "local-id", Name: "local"}), nil)
+	s.domainCache.On("GetDomain", "unknown").Return(nil, &gen.EntityNotExistsError{Message: "unknown"})
+}
+
+func (s *clusterForwarderSuite) TestForward() {
+	forwarded, err := s.signal(context.Background(), "global")
+	s.True(forwarded)
+	s.NoError(err)
+	s.Len(s.remote.signals, 1)
+	s.Equal(int64(1), s.counter("forwarded.requests"))
+	s.Equal(int64(0), s.counter("forwarded.errors"))
+
+	s.remote.err = &gen.ServiceBusyError{Message: "busy"}
+	forwarded, err = s.signal(context.Background(), "global")
+	s.True(forwarded)
+	s.Equal(s.remote.err, err)
+	s.Equal(int64(1), s.counter("forwarded.errors"))
+}
+
+func (s *clusterForwarderSuite) TestForwardByDomainID() {
+	forwarded, err := s.forwarder.forwardByDomainID(context.Background(), quotaSignal, "global-id",
+		metrics.FrontendSignalWorkflowExecutionScope, s.signalFn(context.Background()))
+	s.True(forwarded)
+	s.NoError(err)
+	s.Len(s.remote.signals, 1)
+}
+
+func (s *clusterForwarderSuite) TestHandledLocally() {
+	for _, domain := range []string{"local", "active-here", "disabled", "unknown"} {
+		forwarded, err := s.signal(context.Background(), domain)
+		s.False(forwarded, domain)
+		s.NoError(err, domain)
+	}
+	// forwarding is enabled for another API group only
+	forwarded, _ := s.forwarder.forward(context.Background(), quotaStart, "global",
+		metrics.FrontendStartWorkflowExecutionScope, s.signalFn(context.Background()))
+	s.False(forwarded)
+	s.Empty(s.remote.signals)
+}
+
+func (s *clusterForwarderSuite) TestForwardedRequestIsNotForwardedAgain() {
+	ctx, call := encoding.NewInboundCall(context.Background())
+	s.NoError(call.ReadFromRequest(&transport.Request{
+		Headers: transport.NewHeaders().With(forwardedFromHeader, cluster.TestAlternativeClusterName),
+	}))
+	forwarded, err := s.signal(ctx, "global")
+	s.False(forwarded)
+	s.NoError(err)
+}
+
+func (s *clusterForwarderSuite) TestForwardedHeaders() {
+	ctx, call := encoding.NewInboundCall(context.Background())
+	s.NoError(call.ReadFromRequest(&transport.Request{
+		Headers: transport.NewHeaders().With(authorization.CallerIdentityHeader, "alice").With("custom", "value"),
+	}))
+	s.Equal(map[string]string{
+		authorization.CallerIdentityHeader: "alice",
+		"custom":                           "value",
+		forwardedFromHeader:                cluster.TestCurrentClusterName,
+	}, s.outboundHeaders(forwardedHeaders(ctx, cluster.TestCurrentClusterName)))
+
+	// the verified identity of the caller replaces the header
+	ctx = authorization.WithPeerIdentity(ctx, "bob")
+	s.NoError(call.ReadFromRequest(&transport.Request{Headers: transport.NewHeaders().With("custom", "value")}))
+	s.Equal("bob", s.outboundHeaders(forwardedHeaders(ctx, cluster.TestCurrentClusterName))[authorization.CallerIdentityHeader])
+}
+
+func (s *clusterForwarderSuite) outboundHeaders(opts []yarpc.CallOption) map[string]string {
+	options := make([]encoding.CallOption, 0, len(opts))
+	for _, opt := range opts {
+		options = append(options, encoding.CallOption(opt))
+	}
+	request := &transport.Request{}
+	_, err := encoding.NewOutboundCall(options...).WriteToRequest(context.Background(), request)
+	s.NoError(err)
+	return request.Headers.Items()
+}
+
+func (s *clusterForwarderSuite) signal(ctx context.Context, domain string) (bool, error) {
+	return s.forwarder.forward(ctx, quotaSignal, domain, metrics.FrontendSignalWorkflowExecutionScope, s.signalFn(ctx))
+}
+
+func (s *clusterForwarderSuite) signalFn(ctx context.Context) forwardFn {
+	return func(client workflowserviceclient.Interface, opts ...yarpc.CallOption) error {
+		s.NotEmpty(opts)
+		return client.SignalWorkflowExecution(ctx, &gen.SignalWorkflowExecutionRequest{
+			SignalName: common.StringPtr("signal"),
+		}, opts...)
+	}
+}
+
+func (s *clusterForwarderSuite) counter(name string) int64 {
+	var value int64
+	for _, c := range s.scope.Snapshot().Counters() {
+		if c.Name() == name {
+			value += c.Value()
+		}
+	}
+	return value
+}
+
+func (c *forwarderTestClient) SignalWorkflowExecution(ctx context.Context, request *gen.SignalWorkflowExecutionRequest,
+	opts ...yarpc.CallOption) error {
+	c.signals = append(c.signals, request)
+	return c.err
+}
+
+func (f *forwarderTestFactory) NewRemoteFrontendClient(rpcName string, rpcAddress string) frontend.Client {
+	return f.remote
+}
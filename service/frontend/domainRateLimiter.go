@@ -0,0 +1,281 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/uber-common/bark"
+	gen "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/logging"
+	"github.com/uber/cadence/common/membership"
+	"github.com/uber/cadence/common/metrics"
+)
+
+const (
+	domainRateLimiterListenerName = "DomainRateLimiter"
+
+	// domainQuotasCacheMaxSize bounds the number of domains whose buckets are kept by a frontend host
+	domainQuotasCacheMaxSize = 10000
+	// domainQuotasCacheTTL is how long the buckets of a domain are kept before they are recreated
+	domainQuotasCacheTTL = time.Hour
+)
+
+const (
+	// quotaPoll limits PollForDecisionTask and PollForActivityTask
+	quotaPoll quotaType = iota
+	// quotaStart limits StartWorkflowExecution and SignalWithStartWorkflowExecution
+	quotaStart
+	// quotaSignal limits SignalWorkflowExecution
+	quotaSignal
+	// quotaVisibility limits the visibility queries
+	quotaVisibility
+	// quotaAPI limits all other APIs which are subject to rate limiting
+	quotaAPI
+
+	numQuotaTypes
+)
+
+var quotaTypeNames = [numQuotaTypes]string{"poll", "start", "signal", "visibility", "api"}
+
+var quotaThrottledCounters = [numQuotaTypes]int{
+	metrics.DomainPollThrottledCounter,
+	metrics.DomainStartThrottledCounter,
+	metrics.DomainSignalThrottledCounter,
+	metrics.DomainVisibilityThrottledCounter,
+	metrics.DomainAPIThrottledCounter,
+}
+
+type (
+	quotaType int
+
+	// domainRateLimiter enforces the quota of the frontend host as well as the quotas of every domain,
+	// so that a single domain cannot use up the budget of the whole host
+	domainRateLimiter struct {
+		config        *Config
+		metricsClient metrics.Client
+		timeSource    common.TimeSource
+		domainCache   cache.DomainCache
+		hostBucket    *dynamicTokenBucket
+
+		// memberCount is the number of live frontend hosts, used in global rate limit mode
+		memberCount        int32
+		resolver           membership.ServiceResolver
+		membershipUpdateCh chan *membership.ChangedEvent
+		shutdownCh         chan struct{}
+		shutdownWG         sync.WaitGroup
+		logger             bark.Logger
+
+		// domains holds the *domainQuotas of the recently used domains, keyed by domain name
+		domains cache.Cache
+	}
+
+	domainQuotas struct {
+		buckets [numQuotaTypes]*dynamicTokenBucket
+		// metricsClient is tagged with the domain name
+		metricsClient metrics.Client
+	}
+
+	// dynamicTokenBucket re-creates its token bucket whenever the configured rps or the number of
+	// frontend hosts changes
+	dynamicTokenBucket struct {
+		sync.Mutex
+		rpsFn      func() int
+		rps        int
+		tb         common.TokenBucket
+		timeSource common.TimeSource
+	}
+)
+
+func (q quotaType) String() string {
+	return quotaTypeNames[q]
+}
+
+func newDomainRateLimiter(config *Config, metricsClient metrics.Client, timeSource common.TimeSource,
+	domainCache cache.DomainCache) *domainRateLimiter {
+	r := &domainRateLimiter{
+		config:             config,
+		metricsClient:      metricsClient,
+		timeSource:         timeSource,
+		domainCache:        domainCache,
+		memberCount:        1,
+		membershipUpdateCh: make(chan *membership.ChangedEvent, 10),
+		shutdownCh:         make(chan struct{}),
+		domains:            cache.New(domainQuotasCacheMaxSize, &cache.Options{TTL: domainQuotasCacheTTL}),
+	}
+	r.hostBucket = newDynamicTokenBucket(func() int { return r.effectiveRPS(config.RPS()) }, timeSource)
+	return r
+}
+
+// Start keeps track of the number of frontend hosts so that the limits can be divided among them
+// in global rate limit mode
+func (r *domainRateLimiter) Start(resolver membership.ServiceResolver, logger bark.Logger) error {
+	r.resolver = resolver
+	r.logger = logger
+	if err := resolver.AddListener(domainRateLimiterListenerName, r.membershipUpdateCh); err != nil {
+		return err
+	}
+	r.updateMemberCount()
+
+	r.shutdownWG.Add(1)
+	go r.membershipUpdatePump()
+	return nil
+}
+
+// Stop stops tracking the frontend hosts
+func (r *domainRateLimiter) Stop() {
+	if r.resolver == nil {
+		return
+	}
+	if err := r.resolver.RemoveListener(domainRateLimiterListenerName); err != nil {
+		logging.LogOperationFailedEvent(r.logger, "Error removing membership update listener", err)
+	}
+	close(r.shutdownCh)
+	r.shutdownWG.Wait()
+}
+
+func (r *domainRateLimiter) membershipUpdatePump() {
+	defer r.shutdownWG.Done()
+
+	for {
+		select {
+		case <-r.shutdownCh:
+			return
+		case <-r.membershipUpdateCh:
+			r.updateMemberCount()
+		}
+	}
+}
+
+func (r *domainRateLimiter) updateMemberCount() {
+	count := r.resolver.MemberCount()
+	if count < 1 {
+		// this host is serving requests so it is a member, even if the ring has not caught up yet
+		count = 1
+	}
+	atomic.StoreInt32(&r.memberCount, int32(count))
+}
+
+// effectiveRPS returns the share of this host of the configured rps. In global rate limit mode the
+// configured rps applies to the whole cluster and is divided evenly between the frontend hosts.
+func (r *domainRateLimiter) effectiveRPS(rps int) int {
+	if !r.config.EnableGlobalRateLimit() {
+		return rps
+	}
+	count := int(atomic.LoadInt32(&r.memberCount))
+	// round up so that a low global limit does not block a domain entirely
+	return (rps + count - 1) / count
+}
+
+// Allow consumes a token from the domain bucket of the quota and from the host bucket. Returns
+// a ServiceBusyError naming the exceeded quota if either of them is exhausted.
+func (r *domainRateLimiter) Allow(quota quotaType, domain string, scope int) error {
+	// Domain is validated by the API itself, requests without a known one only count against the host quota
+	if quotas := r.getDomainQuotas(domain); quotas != nil {
+		if !quotas.buckets[quota].TryConsume() {
+			quotas.metricsClient.IncCounter(scope, quotaThrottledCounters[quota])
+			return createDomainQuotaExceededError(domain, quota)
+		}
+	}
+
+	if !r.hostBucket.TryConsume() {
+		r.metricsClient.IncCounter(scope, metrics.HostThrottledCounter)
+		return createServiceBusyError()
+	}
+	return nil
+}
+
+// Consume takes a token from the host bucket without enforcing any quota. It is used by the APIs
+// completing tasks, which are never throttled but still count against the host budget.
+func (r *domainRateLimiter) Consume() {
+	r.hostBucket.TryConsume()
+}
+
+// getDomainQuotas returns the buckets of the domain, or nil if the domain does not exist. The domain is
+// looked up before any state is created for it, so that arbitrary domain names cannot grow the cache
+// and the metric tags.
+func (r *domainRateLimiter) getDomainQuotas(domain string) *domainQuotas {
+	if domain == "" {
+		return nil
+	}
+	if quotas, ok := r.domains.Get(domain).(*domainQuotas); ok {
+		return quotas
+	}
+	if _, err := r.domainCache.GetDomain(domain); err != nil {
+		return nil
+	}
+
+	rpsFns := [numQuotaTypes]func(string) int{
+		r.config.DomainPollRPS,
+		r.config.DomainStartRPS,
+		r.config.DomainSignalRPS,
+		r.config.DomainVisibilityRPS,
+		r.config.DomainRPS,
+	}
+	quotas := &domainQuotas{
+		metricsClient: r.metricsClient.Tagged(map[string]string{metrics.DomainTagName: domain}),
+	}
+	for i, rpsFn := range rpsFns {
+		fn := rpsFn
+		quotas.buckets[i] = newDynamicTokenBucket(func() int { return r.effectiveRPS(fn(domain)) }, r.timeSource)
+	}
+	existing, err := r.domains.PutIfNotExist(domain, quotas)
+	if err != nil {
+		return quotas
+	}
+	return existing.(*domainQuotas)
+}
+
+func newDynamicTokenBucket(rpsFn func() int, timeSource common.TimeSource) *dynamicTokenBucket {
+	rps := rpsFn()
+	return &dynamicTokenBucket{
+		rpsFn:      rpsFn,
+		rps:        rps,
+		tb:         common.NewTokenBucket(rps, timeSource),
+		timeSource: timeSource,
+	}
+}
+
+// TryConsume takes one token from the bucket, picking up any change of the configured rps first
+func (b *dynamicTokenBucket) TryConsume() bool {
+	rps := b.rpsFn()
+	b.Lock()
+	if rps != b.rps {
+		b.rps = rps
+		b.tb = common.NewTokenBucket(rps, b.timeSource)
+	}
+	tb := b.tb
+	b.Unlock()
+
+	ok, _ := tb.TryConsume(1)
+	return ok
+}
+
+func createDomainQuotaExceededError(domain string, quota quotaType) *gen.ServiceBusyError {
+	return &gen.ServiceBusyError{
+		Message: fmt.Sprintf("Domain %v exceeded its %v rate limit", domain, quota),
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/uber-common/bark"
+	"github.com/uber-go/tally"
+	gen "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/membership"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/mocks"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
+
+func TestDomainRateLimiter(t *testing.T) {
+	pollRPS := map[string]int{"noisy": 1200, "quiet": 1200}
+	config := NewConfig(dynamicconfig.NewNopCollection(), 1)
+	config.DomainPollRPS = func(domain string) int { return pollRPS[domain] }
+	scope := tally.NewTestScope("", nil)
+	limiter := newDomainRateLimiter(config, metrics.NewClient(scope, metrics.Frontend), common.NewRealTimeSource(),
+		newTestDomainCache("noisy", "quiet"))
+
+	require.NoError(t, limiter.Allow(quotaPoll, "noisy", metrics.FrontendPollForDecisionTaskScope))
+
+	// the new rate is picked up without recreating the limiter
+	pollRPS["noisy"] = 0
+	err := limiter.Allow(quotaPoll, "noisy", metrics.FrontendPollForDecisionTaskScope)
+	require.IsType(t, &gen.ServiceBusyError{}, err)
+	require.Contains(t, err.Error(), "poll")
+
+	// other domains and other quotas of the same domain are not affected
+	require.NoError(t, limiter.Allow(quotaPoll, "quiet", metrics.FrontendPollForDecisionTaskScope))
+	require.NoError(t, limiter.Allow(quotaStart, "noisy", metrics.FrontendStartWorkflowExecutionScope))
+
+	var throttled int64
+	for _, c := range scope.Snapshot().Counters() {
+		if c.Name() == "domain-throttled.poll" && c.Tags()[metrics.DomainTagName] == "noisy" {
+			throttled += c.Value()
+		}
+	}
+	require.Equal(t, int64(1), throttled)
+}
+
+func TestDomainRateLimiter_HostQuota(t *testing.T) {
+	config := NewConfig(dynamicconfig.NewNopCollection(), 1)
+	config.RPS = dynamicconfig.GetIntPropertyFn(0)
+	limiter := newDomainRateLimiter(config, metrics.NewClient(tally.NoopScope, metrics.Frontend), common.NewRealTimeSource(),
+		newTestDomainCache("domain"))
+
+	err := limiter.Allow(quotaAPI, "domain", metrics.FrontendDescribeTaskListScope)
+	require.IsType(t, &gen.ServiceBusyError{}, err)
+	require.Equal(t, createServiceBusyError().Message, err.(*gen.ServiceBusyError).Message)
+}
+
+func TestDomainRateLimiter_GlobalMode(t *testing.T) {
+	config := NewConfig(dynamicconfig.NewNopCollection(), 1)
+	limiter := newDomainRateLimiter(config, metrics.NewClient(tally.NoopScope, metrics.Frontend), common.NewRealTimeSource(),
+		newTestDomainCache())
+
+	hosts := int32(4)
+	resolver := &mocks.ServiceResolver{}
+	resolver.On("AddListener", domainRateLimiterListenerName, mock.Anything).Return(nil)
+	resolver.On("RemoveListener", domainRateLimiterListenerName).Return(nil)
+	resolver.On("MemberCount").Return(func() int { return int(atomic.LoadInt32(&hosts)) })
+	require.NoError(t, limiter.Start(resolver, bark.NewNopLogger()))
+	defer limiter.Stop()
+
+	// limits are per host unless global mode is enabled
+	require.Equal(t, 1200, limiter.effectiveRPS(1200))
+	config.EnableGlobalRateLimit = dynamicconfig.GetBoolPropertyFn(true)
+	require.Equal(t, 300, limiter.effectiveRPS(1200))
+	require.Equal(t, 1, limiter.effectiveRPS(1))
+	require.Equal(t, 0, limiter.effectiveRPS(0))
+
+	// the share is recomputed when the ring changes
+	atomic.StoreInt32(&hosts, 3)
+	limiter.membershipUpdateCh <- &membership.ChangedEvent{}
+	for deadline := time.Now().Add(time.Second); limiter.effectiveRPS(1200) != 400 && time.Now().Before(deadline); {
+		time.Sleep(10 * time.Millisecond)
+	}
+	require.Equal(t, 400, limiter.effectiveRPS(1200))
+}
+
+func TestDomainRateLimiter_UnknownDomain(t *testing.T) {
+	config := NewConfig(dynamicconfig.NewNopCollection(), 1)
+	config.DomainPollRPS = dynamicconfig.GetIntPropertyFilteredByDomain(0)
+	limiter := newDomainRateLimiter(config, metrics.NewClient(tally.NoopScope, metrics.Frontend), common.NewRealTimeSource(),
+		newTestDomainCache("known"))
+
+	// unknown domains only count against the host quota and leave no state behind
+	require.NoError(t, limiter.Allow(quotaPoll, "unknown", metrics.FrontendPollForDecisionTaskScope))
+	require.Equal(t, 0, limiter.domains.Size())
+
+	err := limiter.Allow(quotaPoll, "known", metrics.FrontendPollForDecisionTaskScope)
+	require.IsType(t, &gen.ServiceBusyError{}, err)
+	require.Equal(t, 1, limiter.domains.Size())
+}
+
+func newTestDomainCache(domains ...string) *cache.DomainCacheMock {
+	domainCache := &cache.DomainCacheMock{}
+	for _, domain := range domains {
+		domainCache.On("GetDomain", domain).Return(
+			cache.NewDomainCacheEntryWithInfo(&persistence.DomainInfo{Name: domain}), nil)
+	}
+	domainCache.On("GetDomain", mock.Anything).Return(nil, &gen.EntityNotExistsError{})
+	return domainCache
+}
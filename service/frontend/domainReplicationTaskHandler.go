@@ -43,7 +43,7 @@ type (
 	DomainReplicator interface {
 		HandleTransmissionTask(domainOperation replicator.DomainOperation, info *persistence.DomainInfo,
 			config *persistence.DomainConfig, replicationConfig *persistence.DomainReplicationConfig,
-			configVersion int64, failoverVersion int64) error
+			configVersion int64, failoverVersion int64, failoverHistory []*persistence.DomainFailoverEvent) error
 	}
 
 	domainReplicatorImpl struct {
@@ -63,14 +63,30 @@ func NewDomainReplicator(kafka messaging.Producer, logger bark.Logger) DomainRep
 // HandleTransmissionTask handle transmission of the domain replication task
 func (domainReplicator *domainReplicatorImpl) HandleTransmissionTask(domainOperation replicator.DomainOperation,
 	info *persistence.DomainInfo, config *persistence.DomainConfig, replicationConfig *persistence.DomainReplicationConfig,
-	configVersion int64, failoverVersion int64) error {
-	status, err := domainReplicator.convertDomainStatusToThrift(info.Status)
+	configVersion int64, failoverVersion int64, failoverHistory []*persistence.DomainFailoverEvent) error {
+	task, err := newDomainTaskAttributes(domainOperation, info, config, replicationConfig, configVersion,
+		failoverVersion, failoverHistory)
 	if err != nil {
 		return err
 	}
 
 	taskType := replicator.ReplicationTaskTypeDomain
-	task := &replicator.DomainTaskAttributes{
+	return domainReplicator.kafka.Publish(&replicator.ReplicationTask{
+		TaskType:             &taskType,
+		DomainTaskAttributes: task,
+	})
+}
+
+// newDomainTaskAttributes converts the domain record to the domain replication task
+func newDomainTaskAttributes(domainOperation replicator.DomainOperation, info *persistence.DomainInfo,
+	config *persistence.DomainConfig, replicationConfig *persistence.DomainReplicationConfig, configVersion int64,
+	failoverVersion int64, failoverHistory []*persistence.DomainFailoverEvent) (*replicator.DomainTaskAttributes, error) {
+	status, err := convertDomainStatusToThrift(info.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	return &replicator.DomainTaskAttributes{
 		DomainOperation: &domainOperation,
 		ID:              common.StringPtr(info.ID),
 		Info: &shared.DomainInfo{
@@ -86,19 +102,15 @@ func (domainReplicator *domainReplicatorImpl) HandleTransmissionTask(domainOpera
 		},
 		ReplicationConfig: &shared.DomainReplicationConfiguration{
 			ActiveClusterName: common.StringPtr(replicationConfig.ActiveClusterName),
-			Clusters:          domainReplicator.convertClusterReplicationConfigToThrift(replicationConfig.Clusters),
+			Clusters:          convertClusterReplicationConfigToThrift(replicationConfig.Clusters),
 		},
 		ConfigVersion:   common.Int64Ptr(configVersion),
 		FailoverVersion: common.Int64Ptr(failoverVersion),
-	}
-
-	return domainReplicator.kafka.Publish(&replicator.ReplicationTask{
-		TaskType:             &taskType,
-		DomainTaskAttributes: task,
-	})
+		FailoverHistory: convertFailoverHistoryToThrift(failoverHistory),
+	}, nil
 }
 
-func (domainReplicator *domainReplicatorImpl) convertClusterReplicationConfigToThrift(
+func convertClusterReplicationConfigToThrift(
 	input []*persistence.ClusterReplicationConfig) []*shared.ClusterReplicationConfiguration {
 	output := []*shared.ClusterReplicationConfiguration{}
 	for _, cluster := range input {
@@ -108,7 +120,22 @@ func (domainReplicator *domainReplicatorImpl) convertClusterReplicationConfigToT
 	return output
 }
 
-func (domainReplicator *domainReplicatorImpl) convertDomainStatusToThrift(input int) (*shared.DomainStatus, error) {
+func convertFailoverHistoryToThrift(input []*persistence.DomainFailoverEvent) []*shared.DomainFailoverEvent {
+	output := []*shared.DomainFailoverEvent{}
+	for _, event := range input {
+		output = append(output, &shared.DomainFailoverEvent{
+			FailoverTimestamp: common.Int64Ptr(event.FailoverTime),
+			FromCluster:       common.StringPtr(event.FromCluster),
+			ToCluster:         common.StringPtr(event.ToCluster),
+			FailoverVersion:   common.Int64Ptr(event.FailoverVersion),
+			Identity:          common.StringPtr(event.Identity),
+			Reason:            common.StringPtr(event.Reason),
+		})
+	}
+	return output
+}
+
+func convertDomainStatusToThrift(input int) (*shared.DomainStatus, error) {
 	switch input {
 	case persistence.DomainStatusRegistered:
 		output := shared.DomainStatusRegistered
@@ -24,6 +24,7 @@ import (
 	"log"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/pborman/uuid"
 	"github.com/sirupsen/logrus"
@@ -130,14 +131,15 @@ func (s *domainReplicatorSuite) TestHandleTransmissionTask_RegisterDomainTask()
 			},
 			ReplicationConfig: &shared.DomainReplicationConfiguration{
 				ActiveClusterName: common.StringPtr(clusterActive),
-				Clusters:          s.domainReplicator.convertClusterReplicationConfigToThrift(clusters),
+				Clusters:          convertClusterReplicationConfigToThrift(clusters),
 			},
 			ConfigVersion:   common.Int64Ptr(configVersion),
 			FailoverVersion: common.Int64Ptr(failoverVersion),
+			FailoverHistory: []*shared.DomainFailoverEvent{},
 		},
 	}).Return(nil).Once()
 
-	err := s.domainReplicator.HandleTransmissionTask(domainOperation, info, config, replicationConfig, configVersion, failoverVersion)
+	err := s.domainReplicator.HandleTransmissionTask(domainOperation, info, config, replicationConfig, configVersion, failoverVersion, nil)
 	s.Nil(err)
 }
 
@@ -182,6 +184,18 @@ func (s *domainReplicatorSuite) TestHandleTransmissionTask_UpdateDomainTask() {
 		Clusters:          clusters,
 	}
 
+	failoverTime := time.Now().UnixNano()
+	failoverHistory := []*p.DomainFailoverEvent{
+		{
+			FailoverTime:    failoverTime,
+			FromCluster:     clusterStandby,
+			ToCluster:       clusterActive,
+			FailoverVersion: failoverVersion,
+			Identity:        "some random identity",
+			Reason:          "some random reason",
+		},
+	}
+
 	s.kafkaProducer.On("Publish", &replicator.ReplicationTask{
 		TaskType: &taskType,
 		DomainTaskAttributes: &replicator.DomainTaskAttributes{
@@ -200,13 +214,23 @@ func (s *domainReplicatorSuite) TestHandleTransmissionTask_UpdateDomainTask() {
 			},
 			ReplicationConfig: &shared.DomainReplicationConfiguration{
 				ActiveClusterName: common.StringPtr(clusterActive),
-				Clusters:          s.domainReplicator.convertClusterReplicationConfigToThrift(clusters),
+				Clusters:          convertClusterReplicationConfigToThrift(clusters),
 			},
 			ConfigVersion:   common.Int64Ptr(configVersion),
 			FailoverVersion: common.Int64Ptr(failoverVersion),
+			FailoverHistory: []*shared.DomainFailoverEvent{
+				{
+					FailoverTimestamp: common.Int64Ptr(failoverTime),
+					FromCluster:       common.StringPtr(clusterStandby),
+					ToCluster:         common.StringPtr(clusterActive),
+					FailoverVersion:   common.Int64Ptr(failoverVersion),
+					Identity:          common.StringPtr("some random identity"),
+					Reason:            common.StringPtr("some random reason"),
+				},
+			},
 		},
 	}).Return(nil).Once()
 
-	err := s.domainReplicator.HandleTransmissionTask(domainOperation, info, config, replicationConfig, configVersion, failoverVersion)
+	err := s.domainReplicator.HandleTransmissionTask(domainOperation, info, config, replicationConfig, configVersion, failoverVersion, failoverHistory)
 	s.Nil(err)
 }
@@ -0,0 +1,231 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/uber/cadence/common/codec/thriftproto"
+	"go.uber.org/thriftrw/compile"
+	"go.uber.org/thriftrw/wire"
+	"go.uber.org/yarpc/yarpcerrors"
+)
+
+const (
+	// grpcServicePath is the path prefix of the methods of the WorkflowService in cadence.proto
+	grpcServicePath    = "/uber.cadence.WorkflowService/"
+	grpcTypeURLPrefix  = "type.googleapis.com/uber.cadence."
+	grpcContentType    = "application/grpc"
+	grpcMaxMessageSize = 64 * 1024 * 1024
+
+	// gatewayDefaultTimeout bounds the calls which do not carry a deadline
+	gatewayDefaultTimeout = time.Minute
+)
+
+// serveGRPC handles a unary gRPC call of the WorkflowService
+func (g *apiGateway) serveGRPC(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || !strings.HasPrefix(r.Header.Get("Content-Type"), grpcContentType) {
+		http.Error(w, "gRPC requests must be POSTed as "+grpcContentType, http.StatusUnsupportedMediaType)
+		return
+	}
+	w.Header().Set("Content-Type", grpcContentType)
+
+	if !strings.HasPrefix(r.URL.Path, grpcServicePath) {
+		writeGRPCStatus(w, yarpcerrors.CodeUnimplemented, "unknown service "+r.URL.Path, nil)
+		return
+	}
+	method := strings.TrimPrefix(r.URL.Path, grpcServicePath)
+
+	timeout := gatewayDefaultTimeout
+	if value := r.Header.Get("Grpc-Timeout"); value != "" {
+		var err error
+		if timeout, err = parseGRPCTimeout(value); err != nil {
+			writeGRPCStatus(w, yarpcerrors.CodeInvalidArgument, err.Error(), nil)
+			return
+		}
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), timeout)
+	defer cancel()
+
+	message, err := readGRPCMessage(r.Body)
+	if err != nil {
+		writeGRPCStatus(w, yarpcerrors.CodeInvalidArgument, err.Error(), nil)
+		return
+	}
+	headers := gatewayHeaders(r.Header, isReservedGRPCHeader)
+	ctx = withGatewayPeerIdentity(ctx, r, headers)
+	result, err := g.invoke(ctx, method, headers[gatewayCallerHeader], headers,
+		func(spec compile.TypeSpec) (wire.Value, error) {
+			return thriftproto.DecodeProto(spec, message)
+		})
+	if err != nil {
+		code, message := gatewayErrorStatus(ctx, err)
+		writeGRPCStatus(w, code, message, nil)
+		return
+	}
+	if result.exception != nil {
+		details, err := encodeGRPCStatusDetails(result.exception)
+		if err != nil {
+			g.logger.WithField("error", err).Warn("Failed to encode gRPC error details")
+		}
+		writeGRPCStatus(w, result.exception.code, result.exception.message, details)
+		return
+	}
+
+	var response []byte
+	if result.responseSpec != nil {
+		if response, err = thriftproto.EncodeProto(result.responseSpec, result.response); err != nil {
+			writeGRPCStatus(w, yarpcerrors.CodeInternal, err.Error(), nil)
+			return
+		}
+	}
+	frame := make([]byte, 5, 5+len(response))
+	binary.BigEndian.PutUint32(frame[1:], uint32(len(response)))
+	w.Write(append(frame, response...))
+	// the status follows the response message as trailers
+	setGRPCStatus(w.Header(), http.TrailerPrefix, yarpcerrors.CodeOK, "", nil)
+}
+
+// readGRPCMessage reads the single length prefixed message of a unary call
+func readGRPCMessage(body io.Reader) ([]byte, error) {
+	var prefix [5]byte
+	if _, err := io.ReadFull(body, prefix[:]); err != nil {
+		return nil, fmt.Errorf("failed to read gRPC message: %v", err)
+	}
+	if prefix[0] != 0 {
+		return nil, fmt.Errorf("compressed gRPC messages are not supported")
+	}
+	length := binary.BigEndian.Uint32(prefix[1:])
+	if length > grpcMaxMessageSize {
+		return nil, fmt.Errorf("gRPC message of %v bytes exceeds the limit of %v", length, grpcMaxMessageSize)
+	}
+	message := make([]byte, length)
+	if _, err := io.ReadFull(body, message); err != nil {
+		return nil, fmt.Errorf("failed to read gRPC message: %v", err)
+	}
+	return message, nil
+}
+
+// writeGRPCStatus sends the status of a failed call, which carries no message, as a trailers-only
+// response whose status is in the headers
+func writeGRPCStatus(w http.ResponseWriter, code yarpcerrors.Code, message string, details []byte) {
+	setGRPCStatus(w.Header(), "", code, message, details)
+	w.WriteHeader(http.StatusOK)
+}
+
+func setGRPCStatus(header http.Header, prefix string, code yarpcerrors.Code, message string, details []byte) {
+	header.Set(prefix+"Grpc-Status", strconv.Itoa(int(code)))
+	if message != "" {
+		header.Set(prefix+"Grpc-Message", encodeGRPCMessage(message))
+	}
+	if len(details) > 0 {
+		header.Set(prefix+"Grpc-Status-Details-Bin", base64.RawStdEncoding.EncodeToString(details))
+	}
+}
+
+// encodeGRPCStatusDetails encodes the exception as a google.rpc.Status carrying the exception
+// message as its single detail
+func encodeGRPCStatusDetails(exception *gatewayException) ([]byte, error) {
+	value, err := thriftproto.EncodeProto(exception.spec, exception.value)
+	if err != nil {
+		return nil, err
+	}
+	detail := proto.NewBuffer(nil)
+	detail.EncodeVarint(1<<3 | proto.WireBytes)
+	detail.EncodeStringBytes(grpcTypeURLPrefix + exception.spec.Name)
+	detail.EncodeVarint(2<<3 | proto.WireBytes)
+	detail.EncodeRawBytes(value)
+
+	status := proto.NewBuffer(nil)
+	status.EncodeVarint(1<<3 | proto.WireVarint)
+	status.EncodeVarint(uint64(exception.code))
+	status.EncodeVarint(2<<3 | proto.WireBytes)
+	status.EncodeStringBytes(exception.message)
+	status.EncodeVarint(3<<3 | proto.WireBytes)
+	status.EncodeRawBytes(detail.Bytes())
+	return status.Bytes(), nil
+}
+
+// encodeGRPCMessage percent encodes the message as required for the grpc-message trailer
+func encodeGRPCMessage(message string) string {
+	var encoded strings.Builder
+	for i := 0; i < len(message); i++ {
+		c := message[i]
+		if c >= ' ' && c <= '~' && c != '%' {
+			encoded.WriteByte(c)
+		} else {
+			fmt.Fprintf(&encoded, "%%%02X", c)
+		}
+	}
+	return encoded.String()
+}
+
+func parseGRPCTimeout(value string) (time.Duration, error) {
+	if len(value) < 2 {
+		return 0, fmt.Errorf("invalid grpc-timeout %v", value)
+	}
+	amount, err := strconv.ParseInt(value[:len(value)-1], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid grpc-timeout %v", value)
+	}
+	units := map[byte]time.Duration{
+		'H': time.Hour,
+		'M': time.Minute,
+		'S': time.Second,
+		'm': time.Millisecond,
+		'u': time.Microsecond,
+		'n': time.Nanosecond,
+	}
+	unit, ok := units[value[len(value)-1]]
+	if !ok {
+		return 0, fmt.Errorf("invalid grpc-timeout %v", value)
+	}
+	return time.Duration(amount) * unit, nil
+}
+
+func isReservedGRPCHeader(key string) bool {
+	switch key {
+	case "content-type", "te", "user-agent":
+		return true
+	}
+	return strings.HasPrefix(key, "grpc-")
+}
+
+// gatewayErrorStatus returns the status code and message of a failed call
+func gatewayErrorStatus(ctx context.Context, err error) (yarpcerrors.Code, string) {
+	if ctx.Err() == context.DeadlineExceeded {
+		return yarpcerrors.CodeDeadlineExceeded, err.Error()
+	}
+	if ctx.Err() == context.Canceled {
+		return yarpcerrors.CodeCancelled, err.Error()
+	}
+	status := yarpcerrors.FromError(err)
+	return status.Code(), status.Message()
+}
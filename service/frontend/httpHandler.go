@@ -0,0 +1,157 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/uber/cadence/common/codec/thriftproto"
+	"go.uber.org/thriftrw/compile"
+	"go.uber.org/thriftrw/wire"
+	"go.uber.org/yarpc/yarpcerrors"
+)
+
+const (
+	// httpAPIPath is the path prefix of the methods of the WorkflowService, e.g. /api/v1/StartWorkflowExecution
+	httpAPIPath        = "/api/v1/"
+	httpContentType    = "application/json"
+	httpMaxRequestSize = 64 * 1024 * 1024
+)
+
+// httpStatusCodes maps the status codes of failed calls to HTTP status codes
+var httpStatusCodes = map[yarpcerrors.Code]int{
+	yarpcerrors.CodeCancelled:          499,
+	yarpcerrors.CodeInvalidArgument:    http.StatusBadRequest,
+	yarpcerrors.CodeDeadlineExceeded:   http.StatusGatewayTimeout,
+	yarpcerrors.CodeNotFound:           http.StatusNotFound,
+	yarpcerrors.CodeAlreadyExists:      http.StatusConflict,
+	yarpcerrors.CodePermissionDenied:   http.StatusForbidden,
+	yarpcerrors.CodeResourceExhausted:  http.StatusTooManyRequests,
+	yarpcerrors.CodeFailedPrecondition: http.StatusBadRequest,
+	yarpcerrors.CodeAborted:            http.StatusConflict,
+	yarpcerrors.CodeOutOfRange:         http.StatusBadRequest,
+	yarpcerrors.CodeUnimplemented:      http.StatusNotImplemented,
+	yarpcerrors.CodeUnavailable:        http.StatusServiceUnavailable,
+	yarpcerrors.CodeUnauthenticated:    http.StatusUnauthorized,
+}
+
+// serveHTTP handles a WorkflowService call POSTed as JSON. The JSON objects use the field and
+// enum names of the thrift IDL, i64 values are strings and binary values are base64.
+func (g *apiGateway) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", httpContentType)
+	if !strings.HasPrefix(r.URL.Path, httpAPIPath) {
+		writeHTTPError(w, yarpcerrors.CodeNotFound, "", "unknown path "+r.URL.Path, nil)
+		return
+	}
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeHTTPStatus(w, http.StatusMethodNotAllowed, "", "requests must be POSTed", nil)
+		return
+	}
+	method := strings.TrimPrefix(r.URL.Path, httpAPIPath)
+
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, httpMaxRequestSize))
+	if err != nil {
+		writeHTTPError(w, yarpcerrors.CodeInvalidArgument, "", err.Error(), nil)
+		return
+	}
+	if len(bytes.TrimSpace(body)) == 0 {
+		body = []byte("{}")
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), gatewayDefaultTimeout)
+	defer cancel()
+	headers := gatewayHeaders(r.Header, isReservedHTTPHeader)
+	ctx = withGatewayPeerIdentity(ctx, r, headers)
+	result, err := g.invoke(ctx, method, headers[gatewayCallerHeader], headers,
+		func(spec compile.TypeSpec) (wire.Value, error) {
+			return thriftproto.DecodeJSON(spec, body)
+		})
+	if err != nil {
+		code, message := gatewayErrorStatus(ctx, err)
+		writeHTTPError(w, code, "", message, nil)
+		return
+	}
+	if result.exception != nil {
+		details, err := thriftproto.EncodeJSON(result.exception.spec, result.exception.value)
+		if err != nil {
+			g.logger.WithField("error", err).Warn("Failed to encode HTTP error details")
+		}
+		writeHTTPError(w, result.exception.code, result.exception.spec.Name, result.exception.message, details)
+		return
+	}
+
+	response := []byte("{}")
+	if result.responseSpec != nil {
+		if response, err = thriftproto.EncodeJSON(result.responseSpec, result.response); err != nil {
+			writeHTTPError(w, yarpcerrors.CodeInternal, "", err.Error(), nil)
+			return
+		}
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(response)
+}
+
+func writeHTTPError(w http.ResponseWriter, code yarpcerrors.Code, name string, message string, details []byte) {
+	status, ok := httpStatusCodes[code]
+	if !ok {
+		status = http.StatusInternalServerError
+	}
+	if name == "" {
+		name = code.String()
+	}
+	writeHTTPStatus(w, status, name, message, details)
+}
+
+// writeHTTPStatus writes {"error": name, "message": message, "details": details}
+func writeHTTPStatus(w http.ResponseWriter, status int, name string, message string, details []byte) {
+	var buf bytes.Buffer
+	buf.WriteString(`{"error":`)
+	writeJSONString(&buf, name)
+	buf.WriteString(`,"message":`)
+	writeJSONString(&buf, message)
+	if len(details) > 0 {
+		buf.WriteString(`,"details":`)
+		buf.Write(details)
+	}
+	buf.WriteString("}")
+	w.WriteHeader(status)
+	w.Write(buf.Bytes())
+}
+
+func writeJSONString(buf *bytes.Buffer, s string) {
+	// marshaling a string cannot fail
+	encoded, _ := json.Marshal(s)
+	buf.Write(encoded)
+}
+
+func isReservedHTTPHeader(key string) bool {
+	switch key {
+	case "accept", "accept-encoding", "connection", "content-length", "content-type", "host", "user-agent":
+		return true
+	}
+	return false
+}
@@ -21,6 +21,9 @@
 package frontend
 
 import (
+	"time"
+
+	"github.com/uber-common/bark"
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/messaging"
 	"github.com/uber/cadence/common/mocks"
@@ -36,22 +39,65 @@ type Config struct {
 	VisibilityMaxPageSize dynamicconfig.IntPropertyFnWithDomainFilter
 	HistoryMaxPageSize    dynamicconfig.IntPropertyFnWithDomainFilter
 	RPS                   dynamicconfig.IntPropertyFn
+	// HistoryMaxLastEventCount caps the number of last events read for GetWorkflowExecutionHistory
+	HistoryMaxLastEventCount dynamicconfig.IntPropertyFnWithDomainFilter
+
+	// Per domain rate limits, each domain gets its own bucket for every quota
+	DomainRPS           dynamicconfig.IntPropertyFnWithDomainFilter
+	DomainPollRPS       dynamicconfig.IntPropertyFnWithDomainFilter
+	DomainStartRPS      dynamicconfig.IntPropertyFnWithDomainFilter
+	DomainSignalRPS     dynamicconfig.IntPropertyFnWithDomainFilter
+	DomainVisibilityRPS dynamicconfig.IntPropertyFnWithDomainFilter
+	// EnableGlobalRateLimit divides all the rate limits above by the number of frontend hosts
+	EnableGlobalRateLimit dynamicconfig.BoolPropertyFn
+
+	// Forwarding of the calls of domains which are active in another cluster, for each API group
+	ForwardPoll   dynamicconfig.BoolPropertyFnWithDomainFilter
+	ForwardStart  dynamicconfig.BoolPropertyFnWithDomainFilter
+	ForwardSignal dynamicconfig.BoolPropertyFnWithDomainFilter
+	ForwardAPI    dynamicconfig.BoolPropertyFnWithDomainFilter
+
+	// Graceful domain failover settings
+	GracefulFailoverPollInterval dynamicconfig.DurationPropertyFn
+	GracefulFailoverMaxTimeout   dynamicconfig.DurationPropertyFn
+	// GracefulFailoverResumeInterval is how often the persisted graceful failovers without a drain are resumed
+	GracefulFailoverResumeInterval dynamicconfig.DurationPropertyFn
+	// DomainFailoverHistoryMaxSize is how many of the most recent failovers are kept per domain
+	DomainFailoverHistoryMaxSize dynamicconfig.IntPropertyFn
 
 	// Persistence settings
 	HistoryMgrNumConns dynamicconfig.IntPropertyFn
 
 	MaxDecisionStartToCloseTimeout dynamicconfig.IntPropertyFnWithDomainFilter
+
+	NumHistoryShards int
 }
 
 // NewConfig returns new service config with default values
-func NewConfig(dc *dynamicconfig.Collection) *Config {
+func NewConfig(dc *dynamicconfig.Collection, numHistoryShards int) *Config {
 	return &Config{
 		PersistenceMaxQPS:              dc.GetIntProperty(dynamicconfig.FrontendPersistenceMaxQPS, 2000),
 		VisibilityMaxPageSize:          dc.GetIntPropertyFilteredByDomain(dynamicconfig.FrontendVisibilityMaxPageSize, 1000),
 		HistoryMaxPageSize:             dc.GetIntPropertyFilteredByDomain(dynamicconfig.FrontendHistoryMaxPageSize, 1000),
 		RPS:                            dc.GetIntProperty(dynamicconfig.FrontendRPS, 1200),
+		HistoryMaxLastEventCount:       dc.GetIntPropertyFilteredByDomain(dynamicconfig.FrontendHistoryMaxLastEventCount, 1000),
+		DomainRPS:                      dc.GetIntPropertyFilteredByDomain(dynamicconfig.FrontendDomainRPS, 1200),
+		DomainPollRPS:                  dc.GetIntPropertyFilteredByDomain(dynamicconfig.FrontendDomainPollRPS, 1200),
+		DomainStartRPS:                 dc.GetIntPropertyFilteredByDomain(dynamicconfig.FrontendDomainStartRPS, 1200),
+		DomainSignalRPS:                dc.GetIntPropertyFilteredByDomain(dynamicconfig.FrontendDomainSignalRPS, 1200),
+		DomainVisibilityRPS:            dc.GetIntPropertyFilteredByDomain(dynamicconfig.FrontendDomainVisibilityRPS, 1200),
+		EnableGlobalRateLimit:          dc.GetBoolProperty(dynamicconfig.FrontendEnableGlobalRateLimit, false),
+		ForwardPoll:                    dc.GetBoolPropertyFilteredByDomain(dynamicconfig.FrontendForwardPoll, false),
+		ForwardStart:                   dc.GetBoolPropertyFilteredByDomain(dynamicconfig.FrontendForwardStart, false),
+		ForwardSignal:                  dc.GetBoolPropertyFilteredByDomain(dynamicconfig.FrontendForwardSignal, false),
+		ForwardAPI:                     dc.GetBoolPropertyFilteredByDomain(dynamicconfig.FrontendForwardAPI, false),
+		GracefulFailoverPollInterval:   dc.GetDurationProperty(dynamicconfig.FrontendGracefulFailoverPollInterval, 5*time.Second),
+		GracefulFailoverMaxTimeout:     dc.GetDurationProperty(dynamicconfig.FrontendGracefulFailoverMaxTimeout, 10*time.Minute),
+		GracefulFailoverResumeInterval: dc.GetDurationProperty(dynamicconfig.FrontendGracefulFailoverResumeInterval, time.Minute),
+		DomainFailoverHistoryMaxSize:   dc.GetIntProperty(dynamicconfig.FrontendDomainFailoverHistoryMaxSize, 20),
 		HistoryMgrNumConns:             dc.GetIntProperty(dynamicconfig.FrontendHistoryMgrNumConns, 10),
 		MaxDecisionStartToCloseTimeout: dc.GetIntPropertyFilteredByDomain(dynamicconfig.MaxDecisionStartToCloseTimeout, 600),
+		NumHistoryShards:               numHistoryShards,
 	}
 }
 
@@ -67,7 +113,7 @@ func NewService(params *service.BootstrapParams) common.Daemon {
 	params.UpdateLoggerWithServiceName(common.FrontendServiceName)
 	return &Service{
 		params: params,
-		config: NewConfig(dynamicconfig.NewCollection(params.DynamicConfig, params.Logger)),
+		config: NewConfig(dynamicconfig.NewCollection(params.DynamicConfig, params.Logger), params.CassandraConfig.NumHistoryShards),
 		stopC:  make(chan struct{}),
 	}
 }
@@ -141,16 +187,24 @@ func (s *Service) Start() {
 		kafkaProducer = &mocks.KafkaProducer{}
 	}
 
-	wfHandler := NewWorkflowHandler(base, s.config, metadata, history, visibility, kafkaProducer)
+	wfHandler := NewWorkflowHandler(base, s.config, metadata, history, visibility, kafkaProducer, p.Authorization)
 	wfHandler.Start()
 
-	adminHandler := NewAdminHandler(base, p.CassandraConfig.NumHistoryShards, metadata)
+	adminHandler := NewAdminHandler(base, p.CassandraConfig.NumHistoryShards, metadata, history, p.Authorization)
 	adminHandler.Start()
 
+	var gateway *apiGateway
+	if listenerFactory, ok := p.RPCFactory.(common.GatewayListenerFactory); ok {
+		gateway = startAPIGateway(wfHandler, listenerFactory, log)
+	}
+
 	log.Infof("%v started", common.FrontendServiceName)
 
 	<-s.stopC
 
+	if gateway != nil {
+		gateway.Stop()
+	}
 	base.Stop()
 }
 
@@ -162,3 +216,25 @@ func (s *Service) Stop() {
 	}
 	s.params.Logger.Infof("%v stopped", common.FrontendServiceName)
 }
+
+// startAPIGateway serves the WorkflowHandler over gRPC and HTTP/JSON when their ports are configured
+func startAPIGateway(wfHandler *WorkflowHandler, listenerFactory common.GatewayListenerFactory,
+	log bark.Logger) *apiGateway {
+	grpcListener, err := listenerFactory.CreateGRPCListener()
+	if err != nil {
+		log.Fatalf("Creating gRPC listener failed: %v", err)
+	}
+	httpListener, err := listenerFactory.CreateHTTPListener()
+	if err != nil {
+		log.Fatalf("Creating HTTP listener failed: %v", err)
+	}
+	if grpcListener == nil && httpListener == nil {
+		return nil
+	}
+	gateway, err := newAPIGateway(wfHandler, log)
+	if err != nil {
+		log.Fatalf("Creating API gateway failed: %v", err)
+	}
+	gateway.Start(grpcListener, httpListener)
+	return gateway
+}
@@ -33,6 +33,7 @@ import (
 	"github.com/pborman/uuid"
 	"github.com/uber-common/bark"
 	"github.com/uber-go/tally"
+	"github.com/uber/cadence/.gen/go/cadence/workflowserviceclient"
 	"github.com/uber/cadence/.gen/go/cadence/workflowserviceserver"
 	"github.com/uber/cadence/.gen/go/health"
 	"github.com/uber/cadence/.gen/go/health/metaserver"
@@ -43,6 +44,7 @@ import (
 	"github.com/uber/cadence/client/history"
 	"github.com/uber/cadence/client/matching"
 	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/authorization"
 	"github.com/uber/cadence/common/backoff"
 	"github.com/uber/cadence/common/cache"
 	"github.com/uber/cadence/common/client"
@@ -50,11 +52,21 @@ import (
 	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/service"
+	"github.com/uber/cadence/common/service/config"
+	"go.uber.org/yarpc"
 	"go.uber.org/yarpc/yarpcerrors"
 )
 
 var _ workflowserviceserver.Interface = (*WorkflowHandler)(nil)
 
+const (
+	gracefulFailoverResumePageSize = 100
+	// gracefulFailoverResumeIdentity and gracefulFailoverResumeReason are recorded in the failover history of the
+	// graceful failovers completed by a frontend other than the one which started them
+	gracefulFailoverResumeIdentity = "cadence-frontend"
+	gracefulFailoverResumeReason   = "graceful failover resumed from the persisted domain state"
+)
+
 type (
 	// WorkflowHandler - Thrift handler inteface for workflow service
 	WorkflowHandler struct {
@@ -68,9 +80,15 @@ type (
 		tokenSerializer   common.TaskTokenSerializer
 		metricsClient     metrics.Client
 		startWG           sync.WaitGroup
-		rateLimiter       common.TokenBucket
+		rateLimiter       *domainRateLimiter
 		config            *Config
 		domainReplicator  DomainReplicator
+		authorizer        *requestAuthorizer
+		forwarder         *clusterForwarder
+		shutdownCh        chan struct{}
+		// failoverDrainsLock guards failoverDrains, the IDs of the domains this frontend drains for a graceful failover
+		failoverDrainsLock sync.Mutex
+		failoverDrains     map[string]struct{}
 		service.Service
 	}
 
@@ -81,11 +99,14 @@ type (
 		IsWorkflowRunning bool
 		PersistenceToken  []byte
 		TransientDecision *gen.TransientDecisionInfo
+		// VersionHistory is only set for reverse reads, to tell the event batches shadowed by an overwrite
+		VersionHistory []*persistence.ReplicationInfo
 	}
 )
 
 var (
 	errDomainNotSet               = &gen.BadRequestError{Message: "Domain not set on request."}
+	errReverseHistoryNotSupported = &gen.BadRequestError{Message: "ReverseOrder and LastEventCount cannot be used with WaitForNewEvent or the close event filter."}
 	errTaskTokenNotSet            = &gen.BadRequestError{Message: "Task token not set on request."}
 	errInvalidTaskToken           = &gen.BadRequestError{Message: "Invalid TaskToken."}
 	errInvalidRequestType         = &gen.BadRequestError{Message: "Invalid request type."}
@@ -101,6 +122,7 @@ var (
 	errQueryNotSet                = &gen.BadRequestError{Message: "WorkflowQuery is not set on request."}
 	errQueryTypeNotSet            = &gen.BadRequestError{Message: "QueryType is not set on request."}
 	errRequestNotSet              = &gen.BadRequestError{Message: "Request is nil."}
+	errClusterNameNotSet          = &gen.BadRequestError{Message: "Cluster name is not set on request."}
 
 	// err indicating that this cluster is not the master, so cannot do domain registration or update
 	errNotMasterCluster                = &gen.BadRequestError{Message: "Cluster is not master cluster, cannot do domain registration or domain update."}
@@ -108,6 +130,11 @@ var (
 	errCannotRemoveClustersFromDomain  = &gen.BadRequestError{Message: "Cannot remove existing replicated clusters from a domain."}
 	errActiveClusterNotInClusters      = &gen.BadRequestError{Message: "Active cluster is not contained in all clusters."}
 	errCannotDoDomainFailoverAndUpdate = &gen.BadRequestError{Message: "Cannot set active cluster to current cluster when other parameters are set."}
+	errGracefulFailoverWithoutFailover = &gen.BadRequestError{Message: "Graceful failover requires a new active cluster."}
+	errInvalidGracefulFailoverTimeout  = &gen.BadRequestError{Message: "Graceful failover timeout is not within the allowed range."}
+	errGracefulFailoverNotSupported    = &gen.BadRequestError{Message: "Graceful failover is only supported for global domains in the v2 domain table."}
+	errGracefulFailoverNotFromActive   = &gen.BadRequestError{Message: "Graceful failover must be requested on the current active cluster of the domain."}
+	errDomainFailoverInProgress        = &gen.BadRequestError{Message: "Domain already has a graceful failover in progress."}
 
 	frontendServiceRetryPolicy = common.CreateFrontendServiceRetryPolicy()
 )
@@ -115,7 +142,8 @@ var (
 // NewWorkflowHandler creates a thrift handler for the cadence service
 func NewWorkflowHandler(sVice service.Service, config *Config, metadataMgr persistence.MetadataManager,
 	historyMgr persistence.HistoryManager, visibilityMgr persistence.VisibilityManager,
-	kafkaProducer messaging.Producer) *WorkflowHandler {
+	kafkaProducer messaging.Producer, authorizationCfg config.Authorization) *WorkflowHandler {
+	domainCache := cache.NewDomainCache(metadataMgr, sVice.GetClusterMetadata(), sVice.GetMetricsClient(), sVice.GetLogger())
 	handler := &WorkflowHandler{
 		Service:          sVice,
 		config:           config,
@@ -123,10 +151,13 @@ func NewWorkflowHandler(sVice service.Service, config *Config, metadataMgr persi
 		historyMgr:       historyMgr,
 		visibitiltyMgr:   visibilityMgr,
 		tokenSerializer:  common.NewJSONTaskTokenSerializer(),
-		domainCache:      cache.NewDomainCache(metadataMgr, sVice.GetClusterMetadata(), sVice.GetMetricsClient(), sVice.GetLogger()),
-		rateLimiter:      common.NewTokenBucket(config.RPS(), common.NewRealTimeSource()),
+		domainCache:      domainCache,
+		rateLimiter:      newDomainRateLimiter(config, sVice.GetMetricsClient(), common.NewRealTimeSource(), domainCache),
 		domainReplicator: NewDomainReplicator(kafkaProducer, sVice.GetLogger()),
+		shutdownCh:       make(chan struct{}),
+		failoverDrains:   make(map[string]struct{}),
 	}
+	handler.authorizer = newAuthorizer(authorizationCfg, handler.domainCache)
 	// prevent us from trying to serve requests before handler's Start() is complete
 	handler.startWG.Add(1)
 	return handler
@@ -150,12 +181,24 @@ func (wh *WorkflowHandler) Start() error {
 	wh.matching = matching.NewRetryableClient(wh.matchingRawClient, common.CreateMatchingRetryPolicy(),
 		common.IsWhitelistServiceTransientError)
 	wh.metricsClient = wh.Service.GetMetricsClient()
+	wh.forwarder = newClusterForwarder(wh.config, wh.GetClusterMetadata(), wh.domainCache,
+		wh.Service.GetClientFactory(), wh.metricsClient)
+	resolver, err := wh.GetMembershipMonitor().GetResolver(common.FrontendServiceName)
+	if err != nil {
+		return err
+	}
+	if err := wh.rateLimiter.Start(resolver, wh.GetLogger()); err != nil {
+		return err
+	}
+	go wh.resumeGracefulFailoversLoop()
 	wh.startWG.Done()
 	return nil
 }
 
 // Stop stops the handler
 func (wh *WorkflowHandler) Stop() {
+	close(wh.shutdownCh)
+	wh.rateLimiter.Stop()
 	wh.domainCache.Stop()
 	wh.metadataMgr.Close()
 	wh.visibitiltyMgr.Close()
@@ -184,6 +227,10 @@ func (wh *WorkflowHandler) RegisterDomain(ctx context.Context, registerRequest *
 		return wh.error(errRequestNotSet, scope)
 	}
 
+	if err := wh.authorize(ctx, "RegisterDomain", authorization.PermissionAdmin, registerRequest.GetName(), nil); err != nil {
+		return wh.error(err, scope)
+	}
+
 	clusterMetadata := wh.GetClusterMetadata()
 	// TODO remove the IsGlobalDomainEnabled check once cross DC is public
 	if clusterMetadata.IsGlobalDomainEnabled() && !clusterMetadata.IsMasterCluster() {
@@ -257,7 +304,7 @@ func (wh *WorkflowHandler) RegisterDomain(ctx context.Context, registerRequest *
 	// TODO remove the IsGlobalDomainEnabled check once cross DC is public
 	if clusterMetadata.IsGlobalDomainEnabled() {
 		err = wh.domainReplicator.HandleTransmissionTask(replicator.DomainOperationCreate,
-			domainRequest.Info, domainRequest.Config, domainRequest.ReplicationConfig, 0, domainRequest.FailoverVersion)
+			domainRequest.Info, domainRequest.Config, domainRequest.ReplicationConfig, 0, domainRequest.FailoverVersion, nil)
 		if err != nil {
 			return wh.error(err, scope)
 		}
@@ -280,6 +327,10 @@ func (wh *WorkflowHandler) ListDomains(ctx context.Context,
 		return nil, wh.error(errRequestNotSet, scope)
 	}
 
+	if err := wh.authorize(ctx, "ListDomains", authorization.PermissionRead, "", nil); err != nil {
+		return nil, wh.error(err, scope)
+	}
+
 	pageSize := 100
 	if listRequest.GetPageSize() != 0 {
 		pageSize = int(listRequest.GetPageSize())
@@ -299,6 +350,8 @@ func (wh *WorkflowHandler) ListDomains(ctx context.Context,
 		desc := &gen.DescribeDomainResponse{
 			IsGlobalDomain:  common.BoolPtr(d.IsGlobalDomain),
 			FailoverVersion: common.Int64Ptr(d.FailoverVersion),
+			FailoverInfo:    createFailoverInfo(d.PendingActiveClusterName, d.FailoverEndTime),
+			FailoverHistory: convertFailoverHistoryToThrift(d.FailoverHistory),
 		}
 		desc.DomainInfo, desc.Configuration, desc.ReplicationConfiguration = createDomainResponse(d.Info, d.Config, d.ReplicationConfig)
 		domains = append(domains, desc)
@@ -324,6 +377,10 @@ func (wh *WorkflowHandler) DescribeDomain(ctx context.Context,
 		return nil, wh.error(errRequestNotSet, scope)
 	}
 
+	if err := wh.authorize(ctx, "DescribeDomain", authorization.PermissionRead, describeRequest.GetName(), nil); err != nil {
+		return nil, wh.error(err, scope)
+	}
+
 	if describeRequest.GetName() == "" {
 		return nil, wh.error(errDomainNotSet, scope)
 	}
@@ -337,6 +394,8 @@ func (wh *WorkflowHandler) DescribeDomain(ctx context.Context,
 	response := &gen.DescribeDomainResponse{
 		IsGlobalDomain:  common.BoolPtr(resp.IsGlobalDomain),
 		FailoverVersion: common.Int64Ptr(resp.FailoverVersion),
+		FailoverInfo:    createFailoverInfo(resp.PendingActiveClusterName, resp.FailoverEndTime),
+		FailoverHistory: convertFailoverHistoryToThrift(resp.FailoverHistory),
 	}
 	response.DomainInfo, response.Configuration, response.ReplicationConfiguration = createDomainResponse(
 		resp.Info, resp.Config, resp.ReplicationConfig)
@@ -356,6 +415,10 @@ func (wh *WorkflowHandler) UpdateDomain(ctx context.Context,
 		return nil, wh.error(errRequestNotSet, scope)
 	}
 
+	if err := wh.authorize(ctx, "UpdateDomain", authorization.PermissionAdmin, updateRequest.GetName(), nil); err != nil {
+		return nil, wh.error(err, scope)
+	}
+
 	clusterMetadata := wh.GetClusterMetadata()
 	// TODO remove the IsGlobalDomainEnabled check once cross DC is public
 	if !clusterMetadata.IsGlobalDomainEnabled() {
@@ -386,6 +449,10 @@ func (wh *WorkflowHandler) UpdateDomain(ctx context.Context,
 	configVersion := getResponse.ConfigVersion
 	failoverVersion := getResponse.FailoverVersion
 	failoverNotificationVersion := getResponse.FailoverNotificationVersion
+	previousActiveClusterName := replicationConfig.ActiveClusterName
+	pendingActiveClusterName := getResponse.PendingActiveClusterName
+	failoverEndTime := getResponse.FailoverEndTime
+	failoverHistory := getResponse.FailoverHistory
 
 	// whether active cluster is changed
 	activeClusterChanged := false
@@ -476,6 +543,11 @@ func (wh *WorkflowHandler) UpdateDomain(ctx context.Context,
 		}
 	}
 
+	gracefulFailover := updateRequest.FailoverTimeoutInSeconds != nil
+	if gracefulFailover && !activeClusterChanged {
+		return nil, wh.error(errGracefulFailoverWithoutFailover, scope)
+	}
+
 	if configurationChanged && activeClusterChanged {
 		return nil, wh.error(errCannotDoDomainFailoverAndUpdate, scope)
 	} else if configurationChanged || activeClusterChanged {
@@ -484,6 +556,32 @@ func (wh *WorkflowHandler) UpdateDomain(ctx context.Context,
 			return nil, wh.error(errNotMasterCluster, scope)
 		}
 
+		if gracefulFailover {
+			timeout := time.Duration(updateRequest.GetFailoverTimeoutInSeconds()) * time.Second
+			targetClusterName := replicationConfig.ActiveClusterName
+			if err := wh.validateGracefulFailover(getResponse, previousActiveClusterName, targetClusterName,
+				timeout); err != nil {
+				return nil, wh.error(err, scope)
+			}
+			// the domain stays active in the current cluster until the drain in the background switches it
+			failoverEndTime, err := wh.startGracefulFailover(getResponse, previousActiveClusterName, targetClusterName,
+				timeout, notificationVersion, updateRequest.GetIdentity(), updateRequest.GetFailoverReason())
+			if err != nil {
+				return nil, wh.error(err, scope)
+			}
+			replicationConfig.ActiveClusterName = previousActiveClusterName
+
+			response := &gen.UpdateDomainResponse{
+				IsGlobalDomain:  common.BoolPtr(getResponse.IsGlobalDomain),
+				FailoverVersion: common.Int64Ptr(failoverVersion),
+				FailoverInfo:    createFailoverInfo(targetClusterName, failoverEndTime),
+				FailoverHistory: convertFailoverHistoryToThrift(failoverHistory),
+			}
+			response.DomainInfo, response.Configuration, response.ReplicationConfiguration = createDomainResponse(
+				info, config, replicationConfig)
+			return response, nil
+		}
+
 		// set the versions
 		if configurationChanged {
 			configVersion++
@@ -491,6 +589,17 @@ func (wh *WorkflowHandler) UpdateDomain(ctx context.Context,
 		if activeClusterChanged {
 			failoverVersion = clusterMetadata.GetNextFailoverVersion(replicationConfig.ActiveClusterName, failoverVersion)
 			failoverNotificationVersion = notificationVersion
+			// a failover, graceful or not, ends any drain in progress
+			pendingActiveClusterName = ""
+			failoverEndTime = 0
+			failoverHistory = appendFailoverHistory(failoverHistory, &persistence.DomainFailoverEvent{
+				FailoverTime:    time.Now().UnixNano(),
+				FromCluster:     previousActiveClusterName,
+				ToCluster:       replicationConfig.ActiveClusterName,
+				FailoverVersion: failoverVersion,
+				Identity:        updateRequest.GetIdentity(),
+				Reason:          updateRequest.GetFailoverReason(),
+			}, wh.config.DomainFailoverHistoryMaxSize())
 		}
 
 		updateReq := &persistence.UpdateDomainRequest{
@@ -500,6 +609,9 @@ func (wh *WorkflowHandler) UpdateDomain(ctx context.Context,
 			ConfigVersion:               configVersion,
 			FailoverVersion:             failoverVersion,
 			FailoverNotificationVersion: failoverNotificationVersion,
+			PendingActiveClusterName:    pendingActiveClusterName,
+			FailoverEndTime:             failoverEndTime,
+			FailoverHistory:             failoverHistory,
 		}
 
 		switch getResponse.TableVersion {
@@ -520,7 +632,7 @@ func (wh *WorkflowHandler) UpdateDomain(ctx context.Context,
 		// TODO remove the IsGlobalDomainEnabled check once cross DC is public
 		if clusterMetadata.IsGlobalDomainEnabled() {
 			err = wh.domainReplicator.HandleTransmissionTask(replicator.DomainOperationUpdate,
-				info, config, replicationConfig, configVersion, failoverVersion)
+				info, config, replicationConfig, configVersion, failoverVersion, failoverHistory)
 			if err != nil {
 				return nil, wh.error(err, scope)
 			}
@@ -534,12 +646,346 @@ func (wh *WorkflowHandler) UpdateDomain(ctx context.Context,
 	response := &gen.UpdateDomainResponse{
 		IsGlobalDomain:  common.BoolPtr(getResponse.IsGlobalDomain),
 		FailoverVersion: common.Int64Ptr(failoverVersion),
+		FailoverInfo:    createFailoverInfo(pendingActiveClusterName, failoverEndTime),
+		FailoverHistory: convertFailoverHistoryToThrift(failoverHistory),
 	}
 	response.DomainInfo, response.Configuration, response.ReplicationConfiguration = createDomainResponse(
 		info, config, replicationConfig)
 	return response, nil
 }
 
+func (wh *WorkflowHandler) validateGracefulFailover(domain *persistence.GetDomainResponse,
+	activeClusterName string, targetClusterName string, timeout time.Duration) error {
+
+	if !domain.IsGlobalDomain || domain.TableVersion != persistence.DomainTableVersionV2 {
+		return errGracefulFailoverNotSupported
+	}
+	if activeClusterName != wh.GetClusterMetadata().GetCurrentClusterName() {
+		return errGracefulFailoverNotFromActive
+	}
+	if activeClusterName == targetClusterName {
+		return errGracefulFailoverWithoutFailover
+	}
+	if timeout <= 0 || timeout > wh.config.GracefulFailoverMaxTimeout() {
+		return errInvalidGracefulFailoverTimeout
+	}
+	if domain.PendingActiveClusterName != "" && time.Now().UnixNano() < domain.FailoverEndTime {
+		return errDomainFailoverInProgress
+	}
+	return nil
+}
+
+// startGracefulFailover marks the domain as failing over, which makes the domain caches of this cluster reject new
+// mutations, then drains the domain in the background and returns the unix nano time the drain times out at
+func (wh *WorkflowHandler) startGracefulFailover(domain *persistence.GetDomainResponse, activeClusterName string,
+	targetClusterName string, timeout time.Duration, notificationVersion int64, identity string,
+	reason string) (int64, error) {
+
+	startTime := time.Now()
+	endTime := startTime.Add(timeout)
+	err := wh.metadataMgr.UpdateDomain(&persistence.UpdateDomainRequest{
+		Info:   domain.Info,
+		Config: domain.Config,
+		ReplicationConfig: &persistence.DomainReplicationConfig{
+			ActiveClusterName: activeClusterName,
+			Clusters:          domain.ReplicationConfig.Clusters,
+		},
+		ConfigVersion:               domain.ConfigVersion,
+		FailoverVersion:             domain.FailoverVersion,
+		FailoverNotificationVersion: domain.FailoverNotificationVersion,
+		PendingActiveClusterName:    targetClusterName,
+		FailoverEndTime:             endTime.UnixNano(),
+		FailoverHistory:             domain.FailoverHistory,
+		NotificationVersion:         notificationVersion,
+		TableVersion:                persistence.DomainTableVersionV2,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	// the drain outlives the request, a drain stopped by a shutdown is resumed by resumeGracefulFailovers
+	if wh.addFailoverDrain(domain.Info.ID) {
+		go wh.drainDomainForFailover(domain.Info, activeClusterName, targetClusterName, startTime, endTime, identity,
+			reason)
+	}
+	return endTime.UnixNano(), nil
+}
+
+// resumeGracefulFailoversLoop periodically resumes the graceful failovers of the domains active in the current
+// cluster which no frontend of this host drains, e.g. because the frontend which started the failover stopped
+func (wh *WorkflowHandler) resumeGracefulFailoversLoop() {
+	timer := time.NewTimer(wh.config.GracefulFailoverResumeInterval())
+	defer timer.Stop()
+	for {
+		select {
+		case <-wh.shutdownCh:
+			return
+		case <-timer.C:
+			if err := wh.resumeGracefulFailovers(); err != nil {
+				wh.GetLogger().WithField(logging.TagErr, err).Warn("Failed to resume graceful domain failovers.")
+			}
+			timer.Reset(wh.config.GracefulFailoverResumeInterval())
+		}
+	}
+}
+
+// resumeGracefulFailovers drives the persisted graceful failovers of the domains active in the current cluster to
+// completion. A failover past its end time is completed right away, any other one is drained again from now on, a
+// resumed drain waits for at least the replication tasks the original drain waited for. Every frontend of the
+// cluster resumes the failovers, the notification version check of UpdateDomain lets only one of them complete it.
+func (wh *WorkflowHandler) resumeGracefulFailovers() error {
+	currentClusterName := wh.GetClusterMetadata().GetCurrentClusterName()
+	var pageToken []byte
+	for {
+		resp, err := wh.metadataMgr.ListDomains(&persistence.ListDomainsRequest{
+			PageSize:      gracefulFailoverResumePageSize,
+			NextPageToken: pageToken,
+		})
+		if err != nil {
+			return err
+		}
+		for _, domain := range resp.Domains {
+			if domain.PendingActiveClusterName == "" ||
+				domain.ReplicationConfig.ActiveClusterName != currentClusterName {
+				continue
+			}
+			wh.resumeGracefulFailover(domain)
+		}
+		if len(resp.NextPageToken) == 0 {
+			return nil
+		}
+		pageToken = resp.NextPageToken
+	}
+}
+
+// resumeGracefulFailover resumes the graceful failover of the domain, unless this frontend drains it already
+func (wh *WorkflowHandler) resumeGracefulFailover(domain *persistence.GetDomainResponse) {
+	activeClusterName := domain.ReplicationConfig.ActiveClusterName
+	targetClusterName := domain.PendingActiveClusterName
+	if !wh.addFailoverDrain(domain.Info.ID) {
+		return
+	}
+
+	endTime := time.Unix(0, domain.FailoverEndTime)
+	if !time.Now().Before(endTime) {
+		defer wh.removeFailoverDrain(domain.Info.ID)
+		logger := wh.GetLogger().WithFields(bark.Fields{
+			logging.TagDomainName:        domain.Info.Name,
+			logging.TagPrevActiveCluster: activeClusterName,
+			logging.TagTargetCluster:     targetClusterName,
+		})
+		logger.Warn("Graceful domain failover was not completed before its end time, failing over now.")
+		if err := wh.completeGracefulFailover(domain.Info.Name, targetClusterName, domain.FailoverEndTime,
+			gracefulFailoverResumeIdentity, gracefulFailoverResumeReason, logger); err != nil {
+			logger.WithField(logging.TagErr, err).Error("Failed to fail over domain after graceful domain failover end time.")
+		}
+		return
+	}
+	go wh.drainDomainForFailover(domain.Info, activeClusterName, targetClusterName, time.Now(), endTime,
+		gracefulFailoverResumeIdentity, gracefulFailoverResumeReason)
+}
+
+// addFailoverDrain records that this frontend drains the domain, it returns false if the domain is drained already
+func (wh *WorkflowHandler) addFailoverDrain(domainID string) bool {
+	wh.failoverDrainsLock.Lock()
+	defer wh.failoverDrainsLock.Unlock()
+	if _, ok := wh.failoverDrains[domainID]; ok {
+		return false
+	}
+	wh.failoverDrains[domainID] = struct{}{}
+	return true
+}
+
+func (wh *WorkflowHandler) removeFailoverDrain(domainID string) {
+	wh.failoverDrainsLock.Lock()
+	defer wh.failoverDrainsLock.Unlock()
+	delete(wh.failoverDrains, domainID)
+}
+
+// drainDomainForFailover waits until the remote cluster acked the replication tasks of the domain written on every
+// history shard before the domain caches of this cluster rejected its mutations, or until endTime, then switches
+// the active cluster of the domain to the target cluster
+func (wh *WorkflowHandler) drainDomainForFailover(info *persistence.DomainInfo, activeClusterName string,
+	targetClusterName string, startTime time.Time, endTime time.Time, identity string, reason string) {
+
+	defer wh.removeFailoverDrain(info.ID)
+	logger := wh.GetLogger().WithFields(bark.Fields{
+		logging.TagDomainName:        info.Name,
+		logging.TagPrevActiveCluster: activeClusterName,
+		logging.TagTargetCluster:     targetClusterName,
+	})
+	logger.Info("Graceful domain failover started, draining in flight mutations.")
+
+	ctx, cancel := context.WithDeadline(context.Background(), endTime)
+	defer cancel()
+
+	shardIDs := make([]int32, wh.config.NumHistoryShards)
+	for shardID := range shardIDs {
+		shardIDs[shardID] = int32(shardID)
+	}
+
+	// mutations are only rejected once every host refreshed its domain cache, the max read level of each shard at
+	// that point bounds the replication tasks of the domain
+	var maxReadLevelByShard map[int32]int64
+	checkTime := startTime.Add(cache.DomainCacheRefreshInterval)
+	drained := false
+DrainLoop:
+	for {
+		if waitTime := time.Until(checkTime); waitTime > 0 {
+			timer := time.NewTimer(waitTime)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				break DrainLoop
+			case <-wh.shutdownCh:
+				timer.Stop()
+				logger.Warn("Graceful domain failover stopped by shutdown, another frontend resumes it.")
+				return
+			case <-timer.C:
+			}
+		}
+		if ctx.Err() != nil {
+			break DrainLoop
+		}
+
+		resp, err := wh.history.DescribeReplicationStatus(ctx, &replicator.DescribeReplicationStatusRequest{
+			ShardIDs:            shardIDs,
+			DomainID:            common.StringPtr(info.ID),
+			MaxReadLevelByShard: maxReadLevelByShard,
+		})
+		if err != nil {
+			logger.WithField(logging.TagErr, err).Warn("Failed to describe replication status during graceful domain failover.")
+		} else {
+			if maxReadLevelByShard == nil {
+				maxReadLevelByShard = getMaxReadLevelByShard(resp.StatusByShard, shardIDs)
+			}
+			if isReplicationDrained(resp.StatusByShard, shardIDs, targetClusterName) {
+				drained = true
+				break DrainLoop
+			}
+		}
+		checkTime = time.Now().Add(wh.config.GracefulFailoverPollInterval())
+	}
+
+	if drained {
+		logger.Infof("Graceful domain failover drained in %v.", time.Since(startTime))
+	} else {
+		logger.Warn("Graceful domain failover timed out before replication caught up, failing over anyway.")
+	}
+	if err := wh.completeGracefulFailover(info.Name, targetClusterName, endTime.UnixNano(), identity, reason,
+		logger); err != nil {
+		logger.WithField(logging.TagErr, err).Error("Failed to fail over domain after graceful domain failover drain.")
+	}
+}
+
+// completeGracefulFailover switches the active cluster of the domain to the target cluster, unless another update
+// of the domain ended the graceful failover in the meantime
+func (wh *WorkflowHandler) completeGracefulFailover(domainName string, targetClusterName string, failoverEndTime int64,
+	identity string, reason string, logger bark.Logger) error {
+
+	clusterMetadata := wh.GetClusterMetadata()
+	metadata, err := wh.metadataMgr.GetMetadata()
+	if err != nil {
+		return err
+	}
+	domain, err := wh.metadataMgr.GetDomain(&persistence.GetDomainRequest{Name: domainName})
+	if err != nil {
+		return err
+	}
+	if domain.PendingActiveClusterName != targetClusterName || domain.FailoverEndTime != failoverEndTime {
+		logger.Warn("Graceful domain failover was ended by another domain update.")
+		return nil
+	}
+
+	replicationConfig := domain.ReplicationConfig
+	previousActiveClusterName := replicationConfig.ActiveClusterName
+	replicationConfig.ActiveClusterName = targetClusterName
+	failoverVersion := clusterMetadata.GetNextFailoverVersion(targetClusterName, domain.FailoverVersion)
+	failoverHistory := appendFailoverHistory(domain.FailoverHistory, &persistence.DomainFailoverEvent{
+		FailoverTime:    time.Now().UnixNano(),
+		FromCluster:     previousActiveClusterName,
+		ToCluster:       targetClusterName,
+		FailoverVersion: failoverVersion,
+		Identity:        identity,
+		Reason:          reason,
+	}, wh.config.DomainFailoverHistoryMaxSize())
+
+	err = wh.metadataMgr.UpdateDomain(&persistence.UpdateDomainRequest{
+		Info:                        domain.Info,
+		Config:                      domain.Config,
+		ReplicationConfig:           replicationConfig,
+		ConfigVersion:               domain.ConfigVersion,
+		FailoverVersion:             failoverVersion,
+		FailoverNotificationVersion: metadata.NotificationVersion,
+		FailoverHistory:             failoverHistory,
+		NotificationVersion:         metadata.NotificationVersion,
+		TableVersion:                persistence.DomainTableVersionV2,
+	})
+	if err != nil {
+		return err
+	}
+
+	// TODO remove the IsGlobalDomainEnabled check once cross DC is public
+	if clusterMetadata.IsGlobalDomainEnabled() {
+		return wh.domainReplicator.HandleTransmissionTask(replicator.DomainOperationUpdate, domain.Info, domain.Config,
+			replicationConfig, domain.ConfigVersion, failoverVersion, failoverHistory)
+	}
+	return nil
+}
+
+// getMaxReadLevelByShard returns the max read level of each of the given shards
+func getMaxReadLevelByShard(statusByShard map[int32]*replicator.ShardReplicationStatus,
+	shardIDs []int32) map[int32]int64 {
+
+	maxReadLevelByShard := make(map[int32]int64, len(shardIDs))
+	for _, shardID := range shardIDs {
+		if status, ok := statusByShard[shardID]; ok {
+			maxReadLevelByShard[shardID] = status.GetMaxReadLevel()
+		}
+	}
+	return maxReadLevelByShard
+}
+
+// isReplicationDrained returns whether all the given shards have no replication task left for the cluster, the
+// tasks are the ones of the domain and up to the max read levels given to DescribeReplicationStatus
+func isReplicationDrained(statusByShard map[int32]*replicator.ShardReplicationStatus, shardIDs []int32,
+	clusterName string) bool {
+
+	for _, shardID := range shardIDs {
+		status, ok := statusByShard[shardID]
+		if !ok {
+			return false
+		}
+		clusterStatus, ok := status.RemoteClusters[clusterName]
+		if !ok || clusterStatus.GetHasUnackedTasks() {
+			return false
+		}
+	}
+	return true
+}
+
+// appendFailoverHistory appends the failover to the history, dropping the oldest failovers beyond maxSize
+func appendFailoverHistory(history []*persistence.DomainFailoverEvent, event *persistence.DomainFailoverEvent,
+	maxSize int) []*persistence.DomainFailoverEvent {
+
+	history = append(history, event)
+	if maxSize > 0 && len(history) > maxSize {
+		history = history[len(history)-maxSize:]
+	}
+	return history
+}
+
+// createFailoverInfo returns the state of the graceful failover in progress, nil if there is none
+func createFailoverInfo(pendingActiveClusterName string, failoverEndTime int64) *gen.FailoverInfo {
+	if pendingActiveClusterName == "" || time.Now().UnixNano() >= failoverEndTime {
+		return nil
+	}
+	return &gen.FailoverInfo{
+		PendingActiveClusterName: common.StringPtr(pendingActiveClusterName),
+		FailoverEndTimestamp:     common.Int64Ptr(failoverEndTime),
+	}
+}
+
 func (wh *WorkflowHandler) mergeDomainData(old map[string]string, new map[string]string) map[string]string {
 	if old == nil {
 		old = map[string]string{}
@@ -563,6 +1009,10 @@ func (wh *WorkflowHandler) DeprecateDomain(ctx context.Context, deprecateRequest
 		return wh.error(errRequestNotSet, scope)
 	}
 
+	if err := wh.authorize(ctx, "DeprecateDomain", authorization.PermissionAdmin, deprecateRequest.GetName(), nil); err != nil {
+		return wh.error(err, scope)
+	}
+
 	clusterMetadata := wh.GetClusterMetadata()
 	// TODO remove the IsGlobalDomainEnabled check once cross DC is public
 	if clusterMetadata.IsGlobalDomainEnabled() && !clusterMetadata.IsMasterCluster() {
@@ -590,11 +1040,14 @@ func (wh *WorkflowHandler) DeprecateDomain(ctx context.Context, deprecateRequest
 	getResponse.ConfigVersion = getResponse.ConfigVersion + 1
 	getResponse.Info.Status = persistence.DomainStatusDeprecated
 	updateReq := &persistence.UpdateDomainRequest{
-		Info:              getResponse.Info,
-		Config:            getResponse.Config,
-		ReplicationConfig: getResponse.ReplicationConfig,
-		ConfigVersion:     getResponse.ConfigVersion,
-		FailoverVersion:   getResponse.FailoverVersion,
+		Info:                     getResponse.Info,
+		Config:                   getResponse.Config,
+		ReplicationConfig:        getResponse.ReplicationConfig,
+		ConfigVersion:            getResponse.ConfigVersion,
+		FailoverVersion:          getResponse.FailoverVersion,
+		PendingActiveClusterName: getResponse.PendingActiveClusterName,
+		FailoverEndTime:          getResponse.FailoverEndTime,
+		FailoverHistory:          getResponse.FailoverHistory,
 	}
 
 	switch getResponse.TableVersion {
@@ -632,8 +1085,12 @@ func (wh *WorkflowHandler) PollForActivityTask(
 		return nil, wh.error(errRequestNotSet, scope)
 	}
 
-	if ok, _ := wh.rateLimiter.TryConsume(1); !ok {
-		return nil, wh.error(createServiceBusyError(), scope)
+	if err := wh.authorize(ctx, "PollForActivityTask", authorization.PermissionWrite, pollRequest.GetDomain(), nil); err != nil {
+		return nil, wh.error(err, scope)
+	}
+
+	if err := wh.rateLimiter.Allow(quotaPoll, pollRequest.GetDomain(), scope); err != nil {
+		return nil, wh.error(err, scope)
 	}
 
 	wh.Service.GetLogger().Debug("Received PollForActivityTask")
@@ -645,6 +1102,18 @@ func (wh *WorkflowHandler) PollForActivityTask(
 		return nil, err
 	}
 
+	var forwardedResp *gen.PollForActivityTaskResponse
+	if forwarded, err := wh.forwarder.forward(ctx, quotaPoll, pollRequest.GetDomain(), scope,
+		func(client workflowserviceclient.Interface, opts ...yarpc.CallOption) (err error) {
+			forwardedResp, err = client.PollForActivityTask(ctx, pollRequest, opts...)
+			return err
+		}); forwarded {
+		if err != nil {
+			return nil, wh.error(err, scope)
+		}
+		return forwardedResp, nil
+	}
+
 	domainID, err := wh.domainCache.GetDomainID(pollRequest.GetDomain())
 	if err != nil {
 		return nil, wh.error(err, scope)
@@ -688,8 +1157,12 @@ func (wh *WorkflowHandler) PollForDecisionTask(
 		return nil, wh.error(errRequestNotSet, scope)
 	}
 
-	if ok, _ := wh.rateLimiter.TryConsume(1); !ok {
-		return nil, wh.error(createServiceBusyError(), scope)
+	if err := wh.authorize(ctx, "PollForDecisionTask", authorization.PermissionWrite, pollRequest.GetDomain(), nil); err != nil {
+		return nil, wh.error(err, scope)
+	}
+
+	if err := wh.rateLimiter.Allow(quotaPoll, pollRequest.GetDomain(), scope); err != nil {
+		return nil, wh.error(err, scope)
 	}
 
 	wh.Service.GetLogger().Debug("Received PollForDecisionTask")
@@ -702,6 +1175,19 @@ func (wh *WorkflowHandler) PollForDecisionTask(
 	}
 
 	domainName := pollRequest.GetDomain()
+
+	var forwardedResp *gen.PollForDecisionTaskResponse
+	if forwarded, err := wh.forwarder.forward(ctx, quotaPoll, domainName, scope,
+		func(client workflowserviceclient.Interface, opts ...yarpc.CallOption) (err error) {
+			forwardedResp, err = client.PollForDecisionTask(ctx, pollRequest, opts...)
+			return err
+		}); forwarded {
+		if err != nil {
+			return nil, wh.error(err, scope)
+		}
+		return forwardedResp, nil
+	}
+
 	domainID, err := wh.domainCache.GetDomainID(domainName)
 	if err != nil {
 		return nil, wh.error(err, scope)
@@ -781,7 +1267,7 @@ func (wh *WorkflowHandler) RecordActivityTaskHeartbeat(
 	}
 
 	// Count the request in the RPS, but we still accept it even if RPS is exceeded
-	wh.rateLimiter.TryConsume(1)
+	wh.rateLimiter.Consume()
 
 	wh.Service.GetLogger().Debug("Received RecordActivityTaskHeartbeat")
 	if heartbeatRequest.TaskToken == nil {
@@ -794,6 +1280,21 @@ func (wh *WorkflowHandler) RecordActivityTaskHeartbeat(
 	if taskToken.DomainID == "" {
 		return nil, wh.error(errDomainNotSet, scope)
 	}
+	if err := wh.authorizeTaskToken(ctx, "RecordActivityTaskHeartbeat", taskToken); err != nil {
+		return nil, wh.error(err, scope)
+	}
+
+	var forwardedResp *gen.RecordActivityTaskHeartbeatResponse
+	if forwarded, err := wh.forwarder.forwardByDomainID(ctx, quotaAPI, taskToken.DomainID, scope,
+		func(client workflowserviceclient.Interface, opts ...yarpc.CallOption) (err error) {
+			forwardedResp, err = client.RecordActivityTaskHeartbeat(ctx, heartbeatRequest, opts...)
+			return err
+		}); forwarded {
+		if err != nil {
+			return nil, wh.error(err, scope)
+		}
+		return forwardedResp, nil
+	}
 
 	resp, err := wh.history.RecordActivityTaskHeartbeat(ctx, &h.RecordActivityTaskHeartbeatRequest{
 		DomainUUID:       common.StringPtr(taskToken.DomainID),
@@ -818,10 +1319,27 @@ func (wh *WorkflowHandler) RecordActivityTaskHeartbeatByID(
 		return nil, wh.error(errRequestNotSet, scope)
 	}
 
+	if err := wh.authorize(ctx, "RecordActivityTaskHeartbeatByID", authorization.PermissionWrite, heartbeatRequest.GetDomain(), &gen.WorkflowExecution{WorkflowId: heartbeatRequest.WorkflowID, RunId: heartbeatRequest.RunID}); err != nil {
+		return nil, wh.error(err, scope)
+	}
+
 	// Count the request in the RPS, but we still accept it even if RPS is exceeded
-	wh.rateLimiter.TryConsume(1)
+	wh.rateLimiter.Consume()
 
 	wh.Service.GetLogger().Debug("Received RecordActivityTaskHeartbeatByID")
+
+	var forwardedResp *gen.RecordActivityTaskHeartbeatResponse
+	if forwarded, err := wh.forwarder.forward(ctx, quotaAPI, heartbeatRequest.GetDomain(), scope,
+		func(client workflowserviceclient.Interface, opts ...yarpc.CallOption) (err error) {
+			forwardedResp, err = client.RecordActivityTaskHeartbeatByID(ctx, heartbeatRequest, opts...)
+			return err
+		}); forwarded {
+		if err != nil {
+			return nil, wh.error(err, scope)
+		}
+		return forwardedResp, nil
+	}
+
 	domainID, err := wh.domainCache.GetDomainID(heartbeatRequest.GetDomain())
 	if err != nil {
 		return nil, wh.error(err, scope)
@@ -882,7 +1400,7 @@ func (wh *WorkflowHandler) RespondActivityTaskCompleted(
 	}
 
 	// Count the request in the RPS, but we still accept it even if RPS is exceeded
-	wh.rateLimiter.TryConsume(1)
+	wh.rateLimiter.Consume()
 
 	if completeRequest.TaskToken == nil {
 		return wh.error(errTaskTokenNotSet, scope)
@@ -894,6 +1412,19 @@ func (wh *WorkflowHandler) RespondActivityTaskCompleted(
 	if taskToken.DomainID == "" {
 		return wh.error(errDomainNotSet, scope)
 	}
+	if err := wh.authorizeTaskToken(ctx, "RespondActivityTaskCompleted", taskToken); err != nil {
+		return wh.error(err, scope)
+	}
+
+	if forwarded, err := wh.forwarder.forwardByDomainID(ctx, quotaAPI, taskToken.DomainID, scope,
+		func(client workflowserviceclient.Interface, opts ...yarpc.CallOption) error {
+			return client.RespondActivityTaskCompleted(ctx, completeRequest, opts...)
+		}); forwarded {
+		if err != nil {
+			return wh.error(err, scope)
+		}
+		return nil
+	}
 
 	err = wh.history.RespondActivityTaskCompleted(ctx, &h.RespondActivityTaskCompletedRequest{
 		DomainUUID:      common.StringPtr(taskToken.DomainID),
@@ -918,8 +1449,22 @@ func (wh *WorkflowHandler) RespondActivityTaskCompletedByID(
 		return wh.error(errRequestNotSet, scope)
 	}
 
+	if err := wh.authorize(ctx, "RespondActivityTaskCompletedByID", authorization.PermissionWrite, completeRequest.GetDomain(), &gen.WorkflowExecution{WorkflowId: completeRequest.WorkflowID, RunId: completeRequest.RunID}); err != nil {
+		return wh.error(err, scope)
+	}
+
 	// Count the request in the RPS, but we still accept it even if RPS is exceeded
-	wh.rateLimiter.TryConsume(1)
+	wh.rateLimiter.Consume()
+
+	if forwarded, err := wh.forwarder.forward(ctx, quotaAPI, completeRequest.GetDomain(), scope,
+		func(client workflowserviceclient.Interface, opts ...yarpc.CallOption) error {
+			return client.RespondActivityTaskCompletedByID(ctx, completeRequest, opts...)
+		}); forwarded {
+		if err != nil {
+			return wh.error(err, scope)
+		}
+		return nil
+	}
 
 	domainID, err := wh.domainCache.GetDomainID(completeRequest.GetDomain())
 	if err != nil {
@@ -981,7 +1526,7 @@ func (wh *WorkflowHandler) RespondActivityTaskFailed(
 	}
 
 	// Count the request in the RPS, but we still accept it even if RPS is exceeded
-	wh.rateLimiter.TryConsume(1)
+	wh.rateLimiter.Consume()
 
 	if failedRequest.TaskToken == nil {
 		return wh.error(errTaskTokenNotSet, scope)
@@ -993,6 +1538,19 @@ func (wh *WorkflowHandler) RespondActivityTaskFailed(
 	if taskToken.DomainID == "" {
 		return wh.error(errDomainNotSet, scope)
 	}
+	if err := wh.authorizeTaskToken(ctx, "RespondActivityTaskFailed", taskToken); err != nil {
+		return wh.error(err, scope)
+	}
+
+	if forwarded, err := wh.forwarder.forwardByDomainID(ctx, quotaAPI, taskToken.DomainID, scope,
+		func(client workflowserviceclient.Interface, opts ...yarpc.CallOption) error {
+			return client.RespondActivityTaskFailed(ctx, failedRequest, opts...)
+		}); forwarded {
+		if err != nil {
+			return wh.error(err, scope)
+		}
+		return nil
+	}
 
 	err = wh.history.RespondActivityTaskFailed(ctx, &h.RespondActivityTaskFailedRequest{
 		DomainUUID:    common.StringPtr(taskToken.DomainID),
@@ -1017,8 +1575,22 @@ func (wh *WorkflowHandler) RespondActivityTaskFailedByID(
 		return wh.error(errRequestNotSet, scope)
 	}
 
+	if err := wh.authorize(ctx, "RespondActivityTaskFailedByID", authorization.PermissionWrite, failedRequest.GetDomain(), &gen.WorkflowExecution{WorkflowId: failedRequest.WorkflowID, RunId: failedRequest.RunID}); err != nil {
+		return wh.error(err, scope)
+	}
+
 	// Count the request in the RPS, but we still accept it even if RPS is exceeded
-	wh.rateLimiter.TryConsume(1)
+	wh.rateLimiter.Consume()
+
+	if forwarded, err := wh.forwarder.forward(ctx, quotaAPI, failedRequest.GetDomain(), scope,
+		func(client workflowserviceclient.Interface, opts ...yarpc.CallOption) error {
+			return client.RespondActivityTaskFailedByID(ctx, failedRequest, opts...)
+		}); forwarded {
+		if err != nil {
+			return wh.error(err, scope)
+		}
+		return nil
+	}
 
 	domainID, err := wh.domainCache.GetDomainID(failedRequest.GetDomain())
 	if err != nil {
@@ -1081,7 +1653,7 @@ func (wh *WorkflowHandler) RespondActivityTaskCanceled(
 	}
 
 	// Count the request in the RPS, but we still accept it even if RPS is exceeded
-	wh.rateLimiter.TryConsume(1)
+	wh.rateLimiter.Consume()
 
 	if cancelRequest.TaskToken == nil {
 		return wh.error(errTaskTokenNotSet, scope)
@@ -1093,6 +1665,19 @@ func (wh *WorkflowHandler) RespondActivityTaskCanceled(
 	if taskToken.DomainID == "" {
 		return wh.error(errDomainNotSet, scope)
 	}
+	if err := wh.authorizeTaskToken(ctx, "RespondActivityTaskCanceled", taskToken); err != nil {
+		return wh.error(err, scope)
+	}
+
+	if forwarded, err := wh.forwarder.forwardByDomainID(ctx, quotaAPI, taskToken.DomainID, scope,
+		func(client workflowserviceclient.Interface, opts ...yarpc.CallOption) error {
+			return client.RespondActivityTaskCanceled(ctx, cancelRequest, opts...)
+		}); forwarded {
+		if err != nil {
+			return wh.error(err, scope)
+		}
+		return nil
+	}
 
 	err = wh.history.RespondActivityTaskCanceled(ctx, &h.RespondActivityTaskCanceledRequest{
 		DomainUUID:    common.StringPtr(taskToken.DomainID),
@@ -1117,8 +1702,22 @@ func (wh *WorkflowHandler) RespondActivityTaskCanceledByID(
 		return wh.error(errRequestNotSet, scope)
 	}
 
+	if err := wh.authorize(ctx, "RespondActivityTaskCanceledByID", authorization.PermissionWrite, cancelRequest.GetDomain(), &gen.WorkflowExecution{WorkflowId: cancelRequest.WorkflowID, RunId: cancelRequest.RunID}); err != nil {
+		return wh.error(err, scope)
+	}
+
 	// Count the request in the RPS, but we still accept it even if RPS is exceeded
-	wh.rateLimiter.TryConsume(1)
+	wh.rateLimiter.Consume()
+
+	if forwarded, err := wh.forwarder.forward(ctx, quotaAPI, cancelRequest.GetDomain(), scope,
+		func(client workflowserviceclient.Interface, opts ...yarpc.CallOption) error {
+			return client.RespondActivityTaskCanceledByID(ctx, cancelRequest, opts...)
+		}); forwarded {
+		if err != nil {
+			return wh.error(err, scope)
+		}
+		return nil
+	}
 
 	domainID, err := wh.domainCache.GetDomainID(cancelRequest.GetDomain())
 	if err != nil {
@@ -1180,7 +1779,7 @@ func (wh *WorkflowHandler) RespondDecisionTaskCompleted(
 	}
 
 	// Count the request in the RPS, but we still accept it even if RPS is exceeded
-	wh.rateLimiter.TryConsume(1)
+	wh.rateLimiter.Consume()
 
 	if completeRequest.TaskToken == nil {
 		return nil, wh.error(errTaskTokenNotSet, scope)
@@ -1192,6 +1791,21 @@ func (wh *WorkflowHandler) RespondDecisionTaskCompleted(
 	if taskToken.DomainID == "" {
 		return nil, wh.error(errDomainNotSet, scope)
 	}
+	if err := wh.authorizeTaskToken(ctx, "RespondDecisionTaskCompleted", taskToken); err != nil {
+		return nil, wh.error(err, scope)
+	}
+
+	var forwardedResp *gen.RespondDecisionTaskCompletedResponse
+	if forwarded, err := wh.forwarder.forwardByDomainID(ctx, quotaAPI, taskToken.DomainID, scope,
+		func(client workflowserviceclient.Interface, opts ...yarpc.CallOption) (err error) {
+			forwardedResp, err = client.RespondDecisionTaskCompleted(ctx, completeRequest, opts...)
+			return err
+		}); forwarded {
+		if err != nil {
+			return nil, wh.error(err, scope)
+		}
+		return forwardedResp, nil
+	}
 
 	histResp, err := wh.history.RespondDecisionTaskCompleted(ctx, &h.RespondDecisionTaskCompletedRequest{
 		DomainUUID:      common.StringPtr(taskToken.DomainID),
@@ -1241,7 +1855,7 @@ func (wh *WorkflowHandler) RespondDecisionTaskFailed(
 	}
 
 	// Count the request in the RPS, but we still accept it even if RPS is exceeded
-	wh.rateLimiter.TryConsume(1)
+	wh.rateLimiter.Consume()
 
 	if failedRequest.TaskToken == nil {
 		return wh.error(errTaskTokenNotSet, scope)
@@ -1253,6 +1867,19 @@ func (wh *WorkflowHandler) RespondDecisionTaskFailed(
 	if taskToken.DomainID == "" {
 		return wh.error(errDomainNotSet, scope)
 	}
+	if err := wh.authorizeTaskToken(ctx, "RespondDecisionTaskFailed", taskToken); err != nil {
+		return wh.error(err, scope)
+	}
+
+	if forwarded, err := wh.forwarder.forwardByDomainID(ctx, quotaAPI, taskToken.DomainID, scope,
+		func(client workflowserviceclient.Interface, opts ...yarpc.CallOption) error {
+			return client.RespondDecisionTaskFailed(ctx, failedRequest, opts...)
+		}); forwarded {
+		if err != nil {
+			return wh.error(err, scope)
+		}
+		return nil
+	}
 
 	err = wh.history.RespondDecisionTaskFailed(ctx, &h.RespondDecisionTaskFailedRequest{
 		DomainUUID:    common.StringPtr(taskToken.DomainID),
@@ -1278,7 +1905,7 @@ func (wh *WorkflowHandler) RespondQueryTaskCompleted(
 	}
 
 	// Count the request in the RPS, but we still accept it even if RPS is exceeded
-	wh.rateLimiter.TryConsume(1)
+	wh.rateLimiter.Consume()
 
 	if completeRequest.TaskToken == nil {
 		return wh.error(errTaskTokenNotSet, scope)
@@ -1290,6 +1917,19 @@ func (wh *WorkflowHandler) RespondQueryTaskCompleted(
 	if queryTaskToken.DomainID == "" || queryTaskToken.TaskList == "" || queryTaskToken.TaskID == "" {
 		return wh.error(errInvalidTaskToken, scope)
 	}
+	if err := wh.authorizeDomainID(ctx, "RespondQueryTaskCompleted", authorization.PermissionWrite, queryTaskToken.DomainID, nil); err != nil {
+		return wh.error(err, scope)
+	}
+
+	if forwarded, err := wh.forwarder.forwardByDomainID(ctx, quotaAPI, queryTaskToken.DomainID, scope,
+		func(client workflowserviceclient.Interface, opts ...yarpc.CallOption) error {
+			return client.RespondQueryTaskCompleted(ctx, completeRequest, opts...)
+		}); forwarded {
+		if err != nil {
+			return wh.error(err, scope)
+		}
+		return nil
+	}
 
 	matchingRequest := &m.RespondQueryTaskCompletedRequest{
 		DomainUUID:       common.StringPtr(queryTaskToken.DomainID),
@@ -1318,8 +1958,12 @@ func (wh *WorkflowHandler) StartWorkflowExecution(
 		return nil, wh.error(errRequestNotSet, scope)
 	}
 
-	if ok, _ := wh.rateLimiter.TryConsume(1); !ok {
-		return nil, wh.error(createServiceBusyError(), scope)
+	if err := wh.authorize(ctx, "StartWorkflowExecution", authorization.PermissionWrite, startRequest.GetDomain(), &gen.WorkflowExecution{WorkflowId: startRequest.WorkflowId, RunId: nil}); err != nil {
+		return nil, wh.error(err, scope)
+	}
+
+	if err := wh.rateLimiter.Allow(quotaStart, startRequest.GetDomain(), scope); err != nil {
+		return nil, wh.error(err, scope)
 	}
 
 	if startRequest.GetDomain() == "" {
@@ -1384,6 +2028,19 @@ func (wh *WorkflowHandler) StartWorkflowExecution(
 
 	domainName := startRequest.GetDomain()
 	wh.Service.GetLogger().Debugf("Start workflow execution request domain: %v", domainName)
+
+	var forwardedResp *gen.StartWorkflowExecutionResponse
+	if forwarded, err := wh.forwarder.forward(ctx, quotaStart, domainName, scope,
+		func(client workflowserviceclient.Interface, opts ...yarpc.CallOption) (err error) {
+			forwardedResp, err = client.StartWorkflowExecution(ctx, startRequest, opts...)
+			return err
+		}); forwarded {
+		if err != nil {
+			return nil, wh.error(err, scope)
+		}
+		return forwardedResp, nil
+	}
+
 	domainID, err := wh.domainCache.GetDomainID(domainName)
 	if err != nil {
 		return nil, wh.error(err, scope)
@@ -1412,8 +2069,12 @@ func (wh *WorkflowHandler) GetWorkflowExecutionHistory(
 		return nil, wh.error(errRequestNotSet, scope)
 	}
 
-	if ok, _ := wh.rateLimiter.TryConsume(1); !ok {
-		return nil, wh.error(createServiceBusyError(), scope)
+	if err := wh.authorize(ctx, "GetWorkflowExecutionHistory", authorization.PermissionRead, getRequest.GetDomain(), getRequest.Execution); err != nil {
+		return nil, wh.error(err, scope)
+	}
+
+	if err := wh.rateLimiter.Allow(quotaAPI, getRequest.GetDomain(), scope); err != nil {
+		return nil, wh.error(err, scope)
 	}
 
 	if getRequest.GetDomain() == "" {
@@ -1428,10 +2089,15 @@ func (wh *WorkflowHandler) GetWorkflowExecutionHistory(
 		getRequest.MaximumPageSize = common.Int32Ptr(int32(wh.config.HistoryMaxPageSize(getRequest.GetDomain())))
 	}
 
-	domainID, err := wh.domainCache.GetDomainID(getRequest.GetDomain())
+	domainEntry, err := wh.domainCache.GetDomain(getRequest.GetDomain())
 	if err != nil {
 		return nil, wh.error(err, scope)
 	}
+	domainID := domainEntry.GetInfo().ID
+	// the replication lag is only known when the mutable state is read, the pages read without it only tell
+	// the domain is active in another cluster
+	staleReadInfo := getStaleReadInfo(domainEntry)
+	var versionHistory []*persistence.ReplicationInfo
 
 	// this function return the following 5 things,
 	// 1. the workflow run ID
@@ -1449,6 +2115,10 @@ func (wh *WorkflowHandler) GetWorkflowExecutionHistory(
 		if err != nil {
 			return "", 0, 0, false, err
 		}
+		if response.StaleReadInfo != nil {
+			staleReadInfo = response.StaleReadInfo
+		}
+		versionHistory = convertVersionHistory(response.GetVersionHistory())
 		return response.Execution.GetRunId(), response.GetLastFirstEventId(), response.GetNextEventId(), response.GetIsWorkflowRunning(), nil
 	}
 
@@ -1457,6 +2127,61 @@ func (wh *WorkflowHandler) GetWorkflowExecutionHistory(
 	execution := getRequest.Execution
 	token := &getHistoryContinuationToken{}
 
+	if getRequest.GetReverseOrder() || getRequest.GetLastEventCount() > 0 {
+		if isLongPoll || isCloseEventOnly {
+			return nil, wh.error(errReverseHistoryNotSupported, scope)
+		}
+		if getRequest.NextPageToken != nil {
+			token, err = deserializeHistoryToken(getRequest.NextPageToken)
+			if err != nil {
+				return nil, wh.error(errInvalidNextPageToken, scope)
+			}
+			if execution.RunId != nil && execution.GetRunId() != token.RunID {
+				return nil, wh.error(errNextPageTokenRunIDMismatch, scope)
+			}
+			execution.RunId = common.StringPtr(token.RunID)
+		} else {
+			runID, _, nextEventID, _, err := queryHistory(domainID, execution, common.FirstEventID)
+			if err != nil {
+				return nil, wh.error(err, scope)
+			}
+			execution.RunId = common.StringPtr(runID)
+
+			token.RunID = runID
+			token.FirstEventID = common.FirstEventID
+			token.NextEventID = nextEventID
+			token.VersionHistory = versionHistory
+		}
+
+		if getRequest.GetLastEventCount() > 0 {
+			count := getRequest.GetLastEventCount()
+			if maxCount := int32(wh.config.HistoryMaxLastEventCount(getRequest.GetDomain())); count > maxCount {
+				count = maxCount
+			}
+			history, err := wh.getLastEvents(domainID, *execution, token.FirstEventID, token.NextEventID,
+				getRequest.GetMaximumPageSize(), count, getRequest.GetReverseOrder(), token.VersionHistory)
+			if err != nil {
+				return nil, wh.error(err, scope)
+			}
+			return createGetWorkflowExecutionHistoryResponse(history, nil, staleReadInfo), nil
+		}
+
+		history, persistenceToken, err := wh.getHistoryReverse(domainID, *execution, token.FirstEventID,
+			token.NextEventID, getRequest.GetMaximumPageSize(), token.PersistenceToken, token.VersionHistory)
+		if err != nil {
+			return nil, wh.error(err, scope)
+		}
+		token.PersistenceToken = persistenceToken
+		if len(persistenceToken) == 0 {
+			token = nil
+		}
+		nextToken, err := serializeHistoryToken(token)
+		if err != nil {
+			return nil, wh.error(err, scope)
+		}
+		return createGetWorkflowExecutionHistoryResponse(history, nextToken, staleReadInfo), nil
+	}
+
 	var runID string
 	lastFirstEventID := common.FirstEventID
 	var nextEventID int64
@@ -1554,7 +2279,87 @@ func (wh *WorkflowHandler) GetWorkflowExecutionHistory(
 	if err != nil {
 		return nil, wh.error(err, scope)
 	}
-	return createGetWorkflowExecutionHistoryResponse(history, nextToken), nil
+	return createGetWorkflowExecutionHistoryResponse(history, nextToken, staleReadInfo), nil
+}
+
+// GetWorkflowExecutionResult long polls until the workflow execution is closed and returns its close status
+// together with the result or failure. When requested, continue-as-new and retry chains are followed to the
+// final run. If the long poll expires before the run is closed, the response has IsWorkflowRunning set.
+func (wh *WorkflowHandler) GetWorkflowExecutionResult(
+	ctx context.Context,
+	getRequest *gen.GetWorkflowExecutionResultRequest) (*gen.GetWorkflowExecutionResultResponse, error) {
+
+	scope := metrics.FrontendGetWorkflowExecutionResultScope
+	sw := wh.startRequestProfile(scope)
+	defer sw.Stop()
+
+	if getRequest == nil {
+		return nil, wh.error(errRequestNotSet, scope)
+	}
+
+	if err := wh.authorize(ctx, "GetWorkflowExecutionResult", authorization.PermissionRead, getRequest.GetDomain(), getRequest.Execution); err != nil {
+		return nil, wh.error(err, scope)
+	}
+
+	if err := wh.rateLimiter.Allow(quotaAPI, getRequest.GetDomain(), scope); err != nil {
+		return nil, wh.error(err, scope)
+	}
+
+	if getRequest.GetDomain() == "" {
+		return nil, wh.error(errDomainNotSet, scope)
+	}
+
+	if err := wh.validateExecutionAndEmitMetrics(getRequest.Execution, scope); err != nil {
+		return nil, err
+	}
+
+	domainID, err := wh.domainCache.GetDomainID(getRequest.GetDomain())
+	if err != nil {
+		return nil, wh.error(err, scope)
+	}
+
+	execution := &gen.WorkflowExecution{
+		WorkflowId: getRequest.Execution.WorkflowId,
+		RunId:      getRequest.Execution.RunId,
+	}
+	for {
+		// history blocks on the event notifier until the run is closed or the long poll expires
+		response, err := wh.history.GetMutableState(ctx, &h.GetMutableStateRequest{
+			DomainUUID:          common.StringPtr(domainID),
+			Execution:           execution,
+			ExpectedNextEventId: common.Int64Ptr(common.EndEventID),
+		})
+		if err != nil {
+			return nil, wh.error(err, scope)
+		}
+		execution.RunId = response.Execution.RunId
+
+		if response.GetIsWorkflowRunning() {
+			return &gen.GetWorkflowExecutionResultResponse{
+				Execution:         execution,
+				IsWorkflowRunning: common.BoolPtr(true),
+			}, nil
+		}
+
+		history, _, err := wh.getHistory(domainID, *execution, response.GetLastFirstEventId(), response.GetNextEventId(),
+			int32(wh.config.HistoryMaxPageSize(getRequest.GetDomain())), nil, nil)
+		if err != nil {
+			return nil, wh.error(err, scope)
+		}
+		// getHistory never returns an empty history, the last event is the close event
+		closeEvent := history.Events[len(history.Events)-1]
+
+		if getRequest.GetFollowRuns() && closeEvent.GetEventType() == gen.EventTypeWorkflowExecutionContinuedAsNew {
+			// retries and cron runs are also chained through continue-as-new
+			execution = &gen.WorkflowExecution{
+				WorkflowId: execution.WorkflowId,
+				RunId:      closeEvent.WorkflowExecutionContinuedAsNewEventAttributes.NewExecutionRunId,
+			}
+			continue
+		}
+
+		return createGetWorkflowExecutionResultResponse(execution, closeEvent), nil
+	}
 }
 
 // SignalWorkflowExecution is used to send a signal event to running workflow execution.  This results in
@@ -1570,8 +2375,12 @@ func (wh *WorkflowHandler) SignalWorkflowExecution(ctx context.Context,
 		return wh.error(errRequestNotSet, scope)
 	}
 
-	if ok, _ := wh.rateLimiter.TryConsume(1); !ok {
-		return wh.error(createServiceBusyError(), scope)
+	if err := wh.authorize(ctx, "SignalWorkflowExecution", authorization.PermissionWrite, signalRequest.GetDomain(), signalRequest.WorkflowExecution); err != nil {
+		return wh.error(err, scope)
+	}
+
+	if err := wh.rateLimiter.Allow(quotaSignal, signalRequest.GetDomain(), scope); err != nil {
+		return wh.error(err, scope)
 	}
 
 	if signalRequest.GetDomain() == "" {
@@ -1586,6 +2395,16 @@ func (wh *WorkflowHandler) SignalWorkflowExecution(ctx context.Context,
 		return wh.error(&gen.BadRequestError{Message: "SignalName is not set on request."}, scope)
 	}
 
+	if forwarded, err := wh.forwarder.forward(ctx, quotaSignal, signalRequest.GetDomain(), scope,
+		func(client workflowserviceclient.Interface, opts ...yarpc.CallOption) error {
+			return client.SignalWorkflowExecution(ctx, signalRequest, opts...)
+		}); forwarded {
+		if err != nil {
+			return wh.error(err, scope)
+		}
+		return nil
+	}
+
 	domainID, err := wh.domainCache.GetDomainID(signalRequest.GetDomain())
 	if err != nil {
 		return wh.error(err, scope)
@@ -1602,6 +2421,68 @@ func (wh *WorkflowHandler) SignalWorkflowExecution(ctx context.Context,
 	return nil
 }
 
+// UpdateWorkflowExecution is used to send an update request to a running workflow execution. The update is
+// delivered to the workflow as part of a decision task, and the call blocks until a decision accepts or rejects
+// it. Accepted updates result in WorkflowExecutionUpdateAccepted event recorded in the history.
+func (wh *WorkflowHandler) UpdateWorkflowExecution(ctx context.Context,
+	updateRequest *gen.UpdateWorkflowExecutionRequest) (*gen.UpdateWorkflowExecutionResponse, error) {
+
+	scope := metrics.FrontendUpdateWorkflowExecutionScope
+	sw := wh.startRequestProfile(scope)
+	defer sw.Stop()
+
+	if updateRequest == nil {
+		return nil, wh.error(errRequestNotSet, scope)
+	}
+
+	if err := wh.authorize(ctx, "UpdateWorkflowExecution", authorization.PermissionWrite, updateRequest.GetDomain(), updateRequest.WorkflowExecution); err != nil {
+		return nil, wh.error(err, scope)
+	}
+
+	if err := wh.rateLimiter.Allow(quotaSignal, updateRequest.GetDomain(), scope); err != nil {
+		return nil, wh.error(err, scope)
+	}
+
+	if updateRequest.GetDomain() == "" {
+		return nil, wh.error(errDomainNotSet, scope)
+	}
+
+	if err := wh.validateExecutionAndEmitMetrics(updateRequest.WorkflowExecution, scope); err != nil {
+		return nil, err
+	}
+
+	if updateRequest.GetUpdateName() == "" {
+		return nil, wh.error(&gen.BadRequestError{Message: "UpdateName is not set on request."}, scope)
+	}
+
+	var forwardedResp *gen.UpdateWorkflowExecutionResponse
+	if forwarded, err := wh.forwarder.forward(ctx, quotaSignal, updateRequest.GetDomain(), scope,
+		func(client workflowserviceclient.Interface, opts ...yarpc.CallOption) (err error) {
+			forwardedResp, err = client.UpdateWorkflowExecution(ctx, updateRequest, opts...)
+			return err
+		}); forwarded {
+		if err != nil {
+			return nil, wh.error(err, scope)
+		}
+		return forwardedResp, nil
+	}
+
+	domainID, err := wh.domainCache.GetDomainID(updateRequest.GetDomain())
+	if err != nil {
+		return nil, wh.error(err, scope)
+	}
+
+	resp, err := wh.history.UpdateWorkflowExecution(ctx, &h.UpdateWorkflowExecutionRequest{
+		DomainUUID:    common.StringPtr(domainID),
+		UpdateRequest: updateRequest,
+	})
+	if err != nil {
+		return nil, wh.error(err, scope)
+	}
+
+	return resp, nil
+}
+
 // SignalWithStartWorkflowExecution is used to ensure sending a signal event to a workflow execution.
 // If workflow is running, this results in WorkflowExecutionSignaled event recorded in the history
 // and a decision task being created for the execution.
@@ -1618,8 +2499,12 @@ func (wh *WorkflowHandler) SignalWithStartWorkflowExecution(ctx context.Context,
 		return nil, wh.error(errRequestNotSet, scope)
 	}
 
-	if ok, _ := wh.rateLimiter.TryConsume(1); !ok {
-		return nil, wh.error(createServiceBusyError(), scope)
+	if err := wh.authorize(ctx, "SignalWithStartWorkflowExecution", authorization.PermissionWrite, signalWithStartRequest.GetDomain(), &gen.WorkflowExecution{WorkflowId: signalWithStartRequest.WorkflowId, RunId: nil}); err != nil {
+		return nil, wh.error(err, scope)
+	}
+
+	if err := wh.rateLimiter.Allow(quotaStart, signalWithStartRequest.GetDomain(), scope); err != nil {
+		return nil, wh.error(err, scope)
 	}
 
 	if signalWithStartRequest.GetDomain() == "" {
@@ -1682,6 +2567,18 @@ func (wh *WorkflowHandler) SignalWithStartWorkflowExecution(ctx context.Context,
 			Message: fmt.Sprintf("TaskStartToCloseTimeoutSeconds is larger than ExecutionStartToCloseTimeout or MaxDecisionStartToCloseTimeout (%ds).", maxDecisionTimeout)}, scope)
 	}
 
+	var forwardedResp *gen.StartWorkflowExecutionResponse
+	if forwarded, err := wh.forwarder.forward(ctx, quotaStart, signalWithStartRequest.GetDomain(), scope,
+		func(client workflowserviceclient.Interface, opts ...yarpc.CallOption) (err error) {
+			forwardedResp, err = client.SignalWithStartWorkflowExecution(ctx, signalWithStartRequest, opts...)
+			return err
+		}); forwarded {
+		if err != nil {
+			return nil, wh.error(err, scope)
+		}
+		return forwardedResp, nil
+	}
+
 	domainID, err := wh.domainCache.GetDomainID(signalWithStartRequest.GetDomain())
 	if err != nil {
 		return nil, wh.error(err, scope)
@@ -1711,8 +2608,12 @@ func (wh *WorkflowHandler) TerminateWorkflowExecution(ctx context.Context,
 		return wh.error(errRequestNotSet, scope)
 	}
 
-	if ok, _ := wh.rateLimiter.TryConsume(1); !ok {
-		return wh.error(createServiceBusyError(), scope)
+	if err := wh.authorize(ctx, "TerminateWorkflowExecution", authorization.PermissionWrite, terminateRequest.GetDomain(), terminateRequest.WorkflowExecution); err != nil {
+		return wh.error(err, scope)
+	}
+
+	if err := wh.rateLimiter.Allow(quotaAPI, terminateRequest.GetDomain(), scope); err != nil {
+		return wh.error(err, scope)
 	}
 
 	if terminateRequest.GetDomain() == "" {
@@ -1723,6 +2624,16 @@ func (wh *WorkflowHandler) TerminateWorkflowExecution(ctx context.Context,
 		return err
 	}
 
+	if forwarded, err := wh.forwarder.forward(ctx, quotaAPI, terminateRequest.GetDomain(), scope,
+		func(client workflowserviceclient.Interface, opts ...yarpc.CallOption) error {
+			return client.TerminateWorkflowExecution(ctx, terminateRequest, opts...)
+		}); forwarded {
+		if err != nil {
+			return wh.error(err, scope)
+		}
+		return nil
+	}
+
 	domainID, err := wh.domainCache.GetDomainID(terminateRequest.GetDomain())
 	if err != nil {
 		return wh.error(err, scope)
@@ -1752,8 +2663,12 @@ func (wh *WorkflowHandler) RequestCancelWorkflowExecution(
 		return wh.error(errRequestNotSet, scope)
 	}
 
-	if ok, _ := wh.rateLimiter.TryConsume(1); !ok {
-		return wh.error(createServiceBusyError(), scope)
+	if err := wh.authorize(ctx, "RequestCancelWorkflowExecution", authorization.PermissionWrite, cancelRequest.GetDomain(), cancelRequest.WorkflowExecution); err != nil {
+		return wh.error(err, scope)
+	}
+
+	if err := wh.rateLimiter.Allow(quotaAPI, cancelRequest.GetDomain(), scope); err != nil {
+		return wh.error(err, scope)
 	}
 
 	if cancelRequest.GetDomain() == "" {
@@ -1764,6 +2679,16 @@ func (wh *WorkflowHandler) RequestCancelWorkflowExecution(
 		return err
 	}
 
+	if forwarded, err := wh.forwarder.forward(ctx, quotaAPI, cancelRequest.GetDomain(), scope,
+		func(client workflowserviceclient.Interface, opts ...yarpc.CallOption) error {
+			return client.RequestCancelWorkflowExecution(ctx, cancelRequest, opts...)
+		}); forwarded {
+		if err != nil {
+			return wh.error(err, scope)
+		}
+		return nil
+	}
+
 	domainID, err := wh.domainCache.GetDomainID(cancelRequest.GetDomain())
 	if err != nil {
 		return wh.error(err, scope)
@@ -1792,8 +2717,12 @@ func (wh *WorkflowHandler) ListOpenWorkflowExecutions(ctx context.Context,
 		return nil, wh.error(errRequestNotSet, scope)
 	}
 
-	if ok, _ := wh.rateLimiter.TryConsume(1); !ok {
-		return nil, wh.error(createServiceBusyError(), scope)
+	if err := wh.authorize(ctx, "ListOpenWorkflowExecutions", authorization.PermissionRead, listRequest.GetDomain(), nil); err != nil {
+		return nil, wh.error(err, scope)
+	}
+
+	if err := wh.rateLimiter.Allow(quotaVisibility, listRequest.GetDomain(), scope); err != nil {
+		return nil, wh.error(err, scope)
 	}
 
 	if listRequest.GetDomain() == "" {
@@ -1872,8 +2801,12 @@ func (wh *WorkflowHandler) ListClosedWorkflowExecutions(ctx context.Context,
 		return nil, wh.error(errRequestNotSet, scope)
 	}
 
-	if ok, _ := wh.rateLimiter.TryConsume(1); !ok {
-		return nil, wh.error(createServiceBusyError(), scope)
+	if err := wh.authorize(ctx, "ListClosedWorkflowExecutions", authorization.PermissionRead, listRequest.GetDomain(), nil); err != nil {
+		return nil, wh.error(err, scope)
+	}
+
+	if err := wh.rateLimiter.Allow(quotaVisibility, listRequest.GetDomain(), scope); err != nil {
+		return nil, wh.error(err, scope)
 	}
 
 	if listRequest.GetDomain() == "" {
@@ -1966,6 +2899,10 @@ func (wh *WorkflowHandler) ResetStickyTaskList(ctx context.Context, resetRequest
 		return nil, wh.error(errRequestNotSet, scope)
 	}
 
+	if err := wh.authorize(ctx, "ResetStickyTaskList", authorization.PermissionWrite, resetRequest.GetDomain(), resetRequest.Execution); err != nil {
+		return nil, wh.error(err, scope)
+	}
+
 	if resetRequest.GetDomain() == "" {
 		return nil, wh.error(errDomainNotSet, scope)
 	}
@@ -1974,6 +2911,18 @@ func (wh *WorkflowHandler) ResetStickyTaskList(ctx context.Context, resetRequest
 		return nil, err
 	}
 
+	var forwardedResp *gen.ResetStickyTaskListResponse
+	if forwarded, err := wh.forwarder.forward(ctx, quotaAPI, resetRequest.GetDomain(), scope,
+		func(client workflowserviceclient.Interface, opts ...yarpc.CallOption) (err error) {
+			forwardedResp, err = client.ResetStickyTaskList(ctx, resetRequest, opts...)
+			return err
+		}); forwarded {
+		if err != nil {
+			return nil, wh.error(err, scope)
+		}
+		return forwardedResp, nil
+	}
+
 	domainID, err := wh.domainCache.GetDomainID(resetRequest.GetDomain())
 	if err != nil {
 		return nil, wh.error(err, scope)
@@ -2001,6 +2950,10 @@ func (wh *WorkflowHandler) QueryWorkflow(ctx context.Context,
 		return nil, wh.error(errRequestNotSet, scope)
 	}
 
+	if err := wh.authorize(ctx, "QueryWorkflow", authorization.PermissionRead, queryRequest.GetDomain(), queryRequest.Execution); err != nil {
+		return nil, wh.error(err, scope)
+	}
+
 	if queryRequest.GetDomain() == "" {
 		return nil, wh.error(errDomainNotSet, scope)
 	}
@@ -2017,24 +2970,46 @@ func (wh *WorkflowHandler) QueryWorkflow(ctx context.Context,
 		return nil, wh.error(errQueryTypeNotSet, scope)
 	}
 
-	domainID, err := wh.domainCache.GetDomainID(queryRequest.GetDomain())
+	var forwardedResp *gen.QueryWorkflowResponse
+	if forwarded, err := wh.forwarder.forward(ctx, quotaAPI, queryRequest.GetDomain(), scope,
+		func(client workflowserviceclient.Interface, opts ...yarpc.CallOption) (err error) {
+			forwardedResp, err = client.QueryWorkflow(ctx, queryRequest, opts...)
+			return err
+		}); forwarded {
+		if err != nil {
+			return nil, wh.error(err, scope)
+		}
+		return forwardedResp, nil
+	}
+
+	domainEntry, err := wh.domainCache.GetDomain(queryRequest.GetDomain())
 	if err != nil {
 		return nil, wh.error(err, scope)
 	}
+	domainID := domainEntry.GetInfo().ID
+	// a domain active in another cluster is queried against the replicated state through the workers polling
+	// the current cluster, the decision in flight and the sticky task list belong to the active cluster
+	isStandby := !domainEntry.IsDomainActive()
 
 	matchingRequest := &m.QueryWorkflowRequest{
 		DomainUUID:   common.StringPtr(domainID),
 		QueryRequest: queryRequest,
 	}
 
-	// we should always use the mutable state, since it contains the sticky tasklist information
+	// we should always use the mutable state, since it contains the sticky tasklist information.
+	// for a strongly consistent query, history holds the call until the in-flight decision completes,
+	// so the query task is dispatched with the latest events.
 	response, err := wh.history.GetMutableState(ctx, &h.GetMutableStateRequest{
-		DomainUUID: common.StringPtr(domainID),
-		Execution:  queryRequest.Execution,
+		DomainUUID:                common.StringPtr(domainID),
+		Execution:                 queryRequest.Execution,
+		WaitForDecisionCompletion: common.BoolPtr(!isStandby && queryRequest.GetQueryConsistencyLevel() == gen.QueryConsistencyLevelStrong),
 	})
 	if err != nil {
 		return nil, wh.error(err, scope)
 	}
+	if rejected := queryRejected(queryRequest.QueryRejectCondition, response.CloseStatus); rejected != nil {
+		return &gen.QueryWorkflowResponse{QueryRejected: rejected, StaleReadInfo: response.StaleReadInfo}, nil
+	}
 	clientFeature := client.NewFeatureImpl(
 		response.GetClientLibraryVersion(),
 		response.GetClientFeatureVersion(),
@@ -2042,7 +3017,8 @@ func (wh *WorkflowHandler) QueryWorkflow(ctx context.Context,
 	)
 
 	queryRequest.Execution.RunId = response.Execution.RunId
-	if len(response.StickyTaskList.GetName()) != 0 && clientFeature.SupportStickyQuery() {
+	// a closed workflow is queried through the normal tasklist with the full history
+	if !isStandby && len(response.StickyTaskList.GetName()) != 0 && clientFeature.SupportStickyQuery() && response.GetIsWorkflowRunning() {
 		matchingRequest.TaskList = response.StickyTaskList
 		stickyDecisionTimeout := response.GetStickyTaskListScheduleToStartTimeout()
 		// using a clean new context in case customer provide a context which has
@@ -2051,6 +3027,7 @@ func (wh *WorkflowHandler) QueryWorkflow(ctx context.Context,
 		matchingResp, err := wh.matchingRawClient.QueryWorkflow(stickyContext, matchingRequest)
 		cancel()
 		if err == nil {
+			matchingResp.StaleReadInfo = response.StaleReadInfo
 			return matchingResp, nil
 		}
 		if yarpcError, ok := err.(*yarpcerrors.Status); !ok || yarpcError.Code() != yarpcerrors.CodeDeadlineExceeded {
@@ -2084,9 +3061,27 @@ func (wh *WorkflowHandler) QueryWorkflow(ctx context.Context,
 		return nil, wh.error(err, scope)
 	}
 
+	matchingResp.StaleReadInfo = response.StaleReadInfo
 	return matchingResp, nil
 }
 
+// queryRejected returns the rejection for a query whose workflow close status does not satisfy
+// the reject condition, or nil if the query should be dispatched
+func queryRejected(condition *gen.QueryRejectCondition, closeStatus *gen.WorkflowExecutionCloseStatus) *gen.QueryRejected {
+	if condition == nil || closeStatus == nil {
+		return nil
+	}
+	switch *condition {
+	case gen.QueryRejectConditionNotOpen:
+		return &gen.QueryRejected{CloseStatus: closeStatus}
+	case gen.QueryRejectConditionNotCompletedCleanly:
+		if *closeStatus != gen.WorkflowExecutionCloseStatusCompleted {
+			return &gen.QueryRejected{CloseStatus: closeStatus}
+		}
+	}
+	return nil
+}
+
 // DescribeWorkflowExecution returns information about the specified workflow execution.
 func (wh *WorkflowHandler) DescribeWorkflowExecution(ctx context.Context, request *gen.DescribeWorkflowExecutionRequest) (*gen.DescribeWorkflowExecutionResponse, error) {
 
@@ -2098,8 +3093,12 @@ func (wh *WorkflowHandler) DescribeWorkflowExecution(ctx context.Context, reques
 		return nil, wh.error(errRequestNotSet, scope)
 	}
 
-	if ok, _ := wh.rateLimiter.TryConsume(1); !ok {
-		return nil, wh.error(createServiceBusyError(), scope)
+	if err := wh.authorize(ctx, "DescribeWorkflowExecution", authorization.PermissionRead, request.GetDomain(), request.Execution); err != nil {
+		return nil, wh.error(err, scope)
+	}
+
+	if err := wh.rateLimiter.Allow(quotaAPI, request.GetDomain(), scope); err != nil {
+		return nil, wh.error(err, scope)
 	}
 
 	if request.GetDomain() == "" {
@@ -2138,17 +3137,22 @@ func (wh *WorkflowHandler) DescribeTaskList(ctx context.Context, request *gen.De
 		return nil, wh.error(errRequestNotSet, scope)
 	}
 
-	if ok, _ := wh.rateLimiter.TryConsume(1); !ok {
-		return nil, wh.error(createServiceBusyError(), scope)
+	if err := wh.authorize(ctx, "DescribeTaskList", authorization.PermissionRead, request.GetDomain(), nil); err != nil {
+		return nil, wh.error(err, scope)
+	}
+
+	if err := wh.rateLimiter.Allow(quotaAPI, request.GetDomain(), scope); err != nil {
+		return nil, wh.error(err, scope)
 	}
 
 	if request.GetDomain() == "" {
 		return nil, wh.error(errDomainNotSet, scope)
 	}
-	domainID, err := wh.domainCache.GetDomainID(request.GetDomain())
+	domainEntry, err := wh.domainCache.GetDomain(request.GetDomain())
 	if err != nil {
 		return nil, wh.error(err, scope)
 	}
+	domainID := domainEntry.GetInfo().ID
 
 	if err := wh.validateTaskList(request.TaskList, scope); err != nil {
 		return nil, err
@@ -2173,9 +3177,95 @@ func (wh *WorkflowHandler) DescribeTaskList(ctx context.Context, request *gen.De
 		return nil, wh.error(err, scope)
 	}
 
+	response.StaleReadInfo = getStaleReadInfo(domainEntry)
 	return response, nil
 }
 
+// ListTaskLists returns the task lists of a domain along with their kind, ack level, last update time and
+// whether they are currently loaded by a matching host.
+func (wh *WorkflowHandler) ListTaskLists(ctx context.Context, request *gen.ListTaskListsRequest) (*gen.ListTaskListsResponse, error) {
+
+	scope := metrics.FrontendListTaskListsScope
+	sw := wh.startRequestProfile(scope)
+	defer sw.Stop()
+
+	if request == nil {
+		return nil, wh.error(errRequestNotSet, scope)
+	}
+
+	if err := wh.authorize(ctx, "ListTaskLists", authorization.PermissionRead, request.GetDomain(), nil); err != nil {
+		return nil, wh.error(err, scope)
+	}
+
+	if err := wh.rateLimiter.Allow(quotaAPI, request.GetDomain(), scope); err != nil {
+		return nil, wh.error(err, scope)
+	}
+
+	if request.GetDomain() == "" {
+		return nil, wh.error(errDomainNotSet, scope)
+	}
+	domainID, err := wh.domainCache.GetDomainID(request.GetDomain())
+	if err != nil {
+		return nil, wh.error(err, scope)
+	}
+
+	if request.GetPageSize() <= 0 {
+		request.PageSize = common.Int32Ptr(100)
+	}
+
+	var response *gen.ListTaskListsResponse
+	op := func() error {
+		var err error
+		response, err = wh.matching.ListTaskLists(ctx, &m.ListTaskListsRequest{
+			DomainUUID:  common.StringPtr(domainID),
+			ListRequest: request,
+		})
+		return err
+	}
+
+	err = backoff.Retry(op, frontendServiceRetryPolicy, common.IsServiceTransientError)
+	if err != nil {
+		return nil, wh.error(err, scope)
+	}
+
+	return response, nil
+}
+
+func createGetWorkflowExecutionResultResponse(execution *gen.WorkflowExecution,
+	closeEvent *gen.HistoryEvent) *gen.GetWorkflowExecutionResultResponse {
+
+	response := &gen.GetWorkflowExecutionResultResponse{
+		Execution:         execution,
+		IsWorkflowRunning: common.BoolPtr(false),
+		CloseEvent:        closeEvent,
+	}
+	switch closeEvent.GetEventType() {
+	case gen.EventTypeWorkflowExecutionCompleted:
+		response.CloseStatus = gen.WorkflowExecutionCloseStatusCompleted.Ptr()
+		response.Result = closeEvent.WorkflowExecutionCompletedEventAttributes.Result
+	case gen.EventTypeWorkflowExecutionFailed:
+		attributes := closeEvent.WorkflowExecutionFailedEventAttributes
+		response.CloseStatus = gen.WorkflowExecutionCloseStatusFailed.Ptr()
+		response.FailureReason = attributes.Reason
+		response.FailureDetails = attributes.Details
+	case gen.EventTypeWorkflowExecutionTimedOut:
+		response.CloseStatus = gen.WorkflowExecutionCloseStatusTimedOut.Ptr()
+		response.FailureReason = common.StringPtr(
+			closeEvent.WorkflowExecutionTimedOutEventAttributes.GetTimeoutType().String())
+	case gen.EventTypeWorkflowExecutionCanceled:
+		response.CloseStatus = gen.WorkflowExecutionCloseStatusCanceled.Ptr()
+		response.FailureDetails = closeEvent.WorkflowExecutionCanceledEventAttributes.Details
+	case gen.EventTypeWorkflowExecutionTerminated:
+		attributes := closeEvent.WorkflowExecutionTerminatedEventAttributes
+		response.CloseStatus = gen.WorkflowExecutionCloseStatusTerminated.Ptr()
+		response.FailureReason = attributes.Reason
+		response.FailureDetails = attributes.Details
+	case gen.EventTypeWorkflowExecutionContinuedAsNew:
+		response.CloseStatus = gen.WorkflowExecutionCloseStatusContinuedAsNew.Ptr()
+	}
+	return response
+}
+
 func (wh *WorkflowHandler) getHistory(domainID string, execution gen.WorkflowExecution,
 	firstEventID, nextEventID int64, pageSize int32, nextPageToken []byte,
 	transientDecision *gen.TransientDecisionInfo) (*gen.History, []byte, error) {
@@ -2207,6 +3297,69 @@ func (wh *WorkflowHandler) getHistory(domainID string, execution gen.WorkflowExe
 	return executionHistory, nextPageToken, nil
 }
 
+// getHistoryReverse returns a page of history events from the newest one
+func (wh *WorkflowHandler) getHistoryReverse(domainID string, execution gen.WorkflowExecution,
+	firstEventID, nextEventID int64, pageSize int32, nextPageToken []byte,
+	versionHistory []*persistence.ReplicationInfo) (*gen.History, []byte, error) {
+
+	response, err := wh.historyMgr.GetWorkflowExecutionHistory(&persistence.GetWorkflowExecutionHistoryRequest{
+		DomainID:       domainID,
+		Execution:      execution,
+		FirstEventID:   firstEventID,
+		NextEventID:    nextEventID,
+		PageSize:       int(pageSize),
+		NextPageToken:  nextPageToken,
+		Reverse:        true,
+		VersionHistory: versionHistory,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return response.History, response.NextPageToken, nil
+}
+
+// getLastEvents returns the last count events of the history, in ascending order of event ID unless reverse is set
+func (wh *WorkflowHandler) getLastEvents(domainID string, execution gen.WorkflowExecution,
+	firstEventID, nextEventID int64, pageSize int32, count int32, reverse bool,
+	versionHistory []*persistence.ReplicationInfo) (*gen.History, error) {
+
+	historyEvents := []*gen.HistoryEvent{}
+	var nextPageToken []byte
+	for int32(len(historyEvents)) < count {
+		history, token, err := wh.getHistoryReverse(domainID, execution, firstEventID, nextEventID, pageSize,
+			nextPageToken, versionHistory)
+		if err != nil {
+			return nil, err
+		}
+		historyEvents = append(historyEvents, history.Events...)
+		if len(token) == 0 {
+			break
+		}
+		nextPageToken = token
+	}
+
+	if int32(len(historyEvents)) > count {
+		historyEvents = historyEvents[:count]
+	}
+	if !reverse {
+		for i, j := 0, len(historyEvents)-1; i < j; i, j = i+1, j-1 {
+			historyEvents[i], historyEvents[j] = historyEvents[j], historyEvents[i]
+		}
+	}
+	return &gen.History{Events: historyEvents}, nil
+}
+
+func convertVersionHistory(history []*gen.ReplicationInfo) []*persistence.ReplicationInfo {
+	versionHistory := make([]*persistence.ReplicationInfo, 0, len(history))
+	for _, item := range history {
+		versionHistory = append(versionHistory, &persistence.ReplicationInfo{
+			Version:     item.GetVersion(),
+			LastEventID: item.GetLastEventId(),
+		})
+	}
+	return versionHistory
+}
+
 func (wh *WorkflowHandler) getLoggerForTask(taskToken []byte) bark.Logger {
 	logger := wh.Service.GetLogger()
 	task, err := wh.tokenSerializer.Deserialize(taskToken)
@@ -2245,6 +3398,9 @@ func (wh *WorkflowHandler) error(err error, scope int) error {
 	case *gen.ServiceBusyError:
 		wh.metricsClient.IncCounter(scope, metrics.CadenceErrServiceBusyCounter)
 		return err
+	case *gen.AccessDeniedError:
+		wh.metricsClient.IncCounter(scope, metrics.CadenceErrAccessDeniedCounter)
+		return err
 	case *gen.EntityNotExistsError:
 		wh.metricsClient.IncCounter(scope, metrics.CadenceErrEntityNotExistsCounter)
 		return err
@@ -2433,19 +3589,32 @@ func (wh *WorkflowHandler) createPollForDecisionTaskResponse(ctx context.Context
 		History:                   history,
 		NextPageToken:             continuation,
 		WorkflowExecutionTaskList: matchingResp.WorkflowExecutionTaskList,
+		Updates:                   matchingResp.Updates,
 	}
 
 	return resp, nil
 }
 
-func createGetWorkflowExecutionHistoryResponse(
-	history *gen.History, nextPageToken []byte) *gen.GetWorkflowExecutionHistoryResponse {
+func createGetWorkflowExecutionHistoryResponse(history *gen.History, nextPageToken []byte,
+	staleReadInfo *gen.StaleReadInfo) *gen.GetWorkflowExecutionHistoryResponse {
 	resp := &gen.GetWorkflowExecutionHistoryResponse{}
 	resp.History = history
 	resp.NextPageToken = nextPageToken
+	resp.StaleReadInfo = staleReadInfo
 	return resp
 }
 
+// getStaleReadInfo returns the stale read info without the replication lag if the domain is active in another
+// cluster, nil otherwise
+func getStaleReadInfo(domainEntry *cache.DomainCacheEntry) *gen.StaleReadInfo {
+	if domainEntry.IsDomainActive() {
+		return nil
+	}
+	return &gen.StaleReadInfo{
+		ActiveClusterName: common.StringPtr(domainEntry.GetReplicationConfig().ActiveClusterName),
+	}
+}
+
 func deserializeHistoryToken(bytes []byte) (*getHistoryContinuationToken, error) {
 	token := &getHistoryContinuationToken{}
 	err := json.Unmarshal(bytes, token)
@@ -2461,6 +3630,35 @@ func serializeHistoryToken(token *getHistoryContinuationToken) ([]byte, error) {
 	return bytes, err
 }
 
+// authorize checks whether the caller may invoke the API on the domain and the execution
+func (wh *WorkflowHandler) authorize(ctx context.Context, api string, permission authorization.Permission,
+	domain string, execution *gen.WorkflowExecution) error {
+	return wh.authorizer.authorize(ctx, wh.GetLogger(), &authorization.Attributes{
+		APIName:    api,
+		Permission: permission,
+		DomainName: domain,
+		Execution:  execution,
+	})
+}
+
+// authorizeDomainID is authorize for the APIs which only carry the ID of the domain
+func (wh *WorkflowHandler) authorizeDomainID(ctx context.Context, api string, permission authorization.Permission,
+	domainID string, execution *gen.WorkflowExecution) error {
+	entry, err := wh.domainCache.GetDomainByID(domainID)
+	if err != nil {
+		return err
+	}
+	return wh.authorize(ctx, api, permission, entry.GetInfo().Name, execution)
+}
+
+// authorizeTaskToken authorizes the APIs which complete or heartbeat the task identified by the token
+func (wh *WorkflowHandler) authorizeTaskToken(ctx context.Context, api string, token *common.TaskToken) error {
+	return wh.authorizeDomainID(ctx, api, authorization.PermissionWrite, token.DomainID, &gen.WorkflowExecution{
+		WorkflowId: common.StringPtr(token.WorkflowID),
+		RunId:      common.StringPtr(token.RunID),
+	})
+}
+
 func createServiceBusyError() *gen.ServiceBusyError {
 	err := &gen.ServiceBusyError{}
 	err.Message = "Too many outstanding requests to the cadence service"
@@ -2469,8 +3667,8 @@ func createServiceBusyError() *gen.ServiceBusyError {
 
 func (wh *WorkflowHandler) validateClusterName(clusterName string) error {
 	clusterMetadata := wh.GetClusterMetadata()
-	if _, ok := clusterMetadata.GetAllClusterFailoverVersions()[clusterName]; !ok {
-		errMsg := "Invalid cluster name: %s"
+	if !clusterMetadata.IsClusterEnabled(clusterName) {
+		errMsg := "Invalid or disabled cluster name: %s"
 		return &gen.BadRequestError{Message: fmt.Sprintf(errMsg, clusterName)}
 	}
 	return nil
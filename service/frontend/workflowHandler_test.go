@@ -22,8 +22,22 @@ package frontend
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/uber-common/bark"
+	"github.com/uber-go/tally"
+	"github.com/uber/cadence/.gen/go/replicator"
+	gen "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/cluster"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/mocks"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/service"
+	"github.com/uber/cadence/common/service/dynamicconfig"
 )
 
 func TestMergeDomainData_Overriding(t *testing.T) {
@@ -92,3 +106,340 @@ func TestMergeDomainData_Nil(t *testing.T) {
 		"k1": "v2",
 	}, out)
 }
+
+func TestQueryRejected(t *testing.T) {
+	notOpen := gen.QueryRejectConditionNotOpen.Ptr()
+	notCompletedCleanly := gen.QueryRejectConditionNotCompletedCleanly.Ptr()
+	completed := gen.WorkflowExecutionCloseStatusCompleted.Ptr()
+	failed := gen.WorkflowExecutionCloseStatusFailed.Ptr()
+
+	assert.Nil(t, queryRejected(nil, failed))
+	assert.Nil(t, queryRejected(notOpen, nil))
+	assert.Nil(t, queryRejected(notCompletedCleanly, nil))
+	assert.Nil(t, queryRejected(notCompletedCleanly, completed))
+	assert.Equal(t, &gen.QueryRejected{CloseStatus: completed}, queryRejected(notOpen, completed))
+	assert.Equal(t, &gen.QueryRejected{CloseStatus: failed}, queryRejected(notCompletedCleanly, failed))
+}
+
+func TestCreateGetWorkflowExecutionResultResponse(t *testing.T) {
+	execution := &gen.WorkflowExecution{WorkflowId: common.StringPtr("wid"), RunId: common.StringPtr("rid")}
+
+	completed := &gen.HistoryEvent{
+		EventType: gen.EventTypeWorkflowExecutionCompleted.Ptr(),
+		WorkflowExecutionCompletedEventAttributes: &gen.WorkflowExecutionCompletedEventAttributes{
+			Result: []byte("result"),
+		},
+	}
+	response := createGetWorkflowExecutionResultResponse(execution, completed)
+	assert.Equal(t, execution, response.Execution)
+	assert.False(t, response.GetIsWorkflowRunning())
+	assert.Equal(t, gen.WorkflowExecutionCloseStatusCompleted, response.GetCloseStatus())
+	assert.Equal(t, []byte("result"), response.Result)
+	assert.Equal(t, completed, response.CloseEvent)
+
+	failed := &gen.HistoryEvent{
+		EventType: gen.EventTypeWorkflowExecutionFailed.Ptr(),
+		WorkflowExecutionFailedEventAttributes: &gen.WorkflowExecutionFailedEventAttributes{
+			Reason:  common.StringPtr("reason"),
+			Details: []byte("details"),
+		},
+	}
+	response = createGetWorkflowExecutionResultResponse(execution, failed)
+	assert.Equal(t, gen.WorkflowExecutionCloseStatusFailed, response.GetCloseStatus())
+	assert.Nil(t, response.Result)
+	assert.Equal(t, "reason", response.GetFailureReason())
+	assert.Equal(t, []byte("details"), response.FailureDetails)
+
+	timedOut := &gen.HistoryEvent{
+		EventType: gen.EventTypeWorkflowExecutionTimedOut.Ptr(),
+		WorkflowExecutionTimedOutEventAttributes: &gen.WorkflowExecutionTimedOutEventAttributes{
+			TimeoutType: gen.TimeoutTypeStartToClose.Ptr(),
+		},
+	}
+	response = createGetWorkflowExecutionResultResponse(execution, timedOut)
+	assert.Equal(t, gen.WorkflowExecutionCloseStatusTimedOut, response.GetCloseStatus())
+	assert.Equal(t, gen.TimeoutTypeStartToClose.String(), response.GetFailureReason())
+
+	continuedAsNew := &gen.HistoryEvent{
+		EventType: gen.EventTypeWorkflowExecutionContinuedAsNew.Ptr(),
+		WorkflowExecutionContinuedAsNewEventAttributes: &gen.WorkflowExecutionContinuedAsNewEventAttributes{
+			NewExecutionRunId: common.StringPtr("new-rid"),
+		},
+	}
+	response = createGetWorkflowExecutionResultResponse(execution, continuedAsNew)
+	assert.Equal(t, gen.WorkflowExecutionCloseStatusContinuedAsNew, response.GetCloseStatus())
+	assert.Equal(t, continuedAsNew, response.CloseEvent)
+}
+
+func TestGetLastEvents(t *testing.T) {
+	historyEvents := func(eventIDs ...int64) *gen.History {
+		history := &gen.History{}
+		for _, eventID := range eventIDs {
+			history.Events = append(history.Events, &gen.HistoryEvent{EventId: common.Int64Ptr(eventID)})
+		}
+		return history
+	}
+	mockHistoryMgr := &mocks.HistoryManager{}
+	mockHistoryMgr.On("GetWorkflowExecutionHistory", mock.MatchedBy(func(request *persistence.GetWorkflowExecutionHistoryRequest) bool {
+		return request.Reverse && len(request.NextPageToken) == 0
+	})).Return(&persistence.GetWorkflowExecutionHistoryResponse{
+		History:       historyEvents(9, 8, 7),
+		NextPageToken: []byte("token"),
+	}, nil)
+	mockHistoryMgr.On("GetWorkflowExecutionHistory", mock.MatchedBy(func(request *persistence.GetWorkflowExecutionHistoryRequest) bool {
+		return request.Reverse && string(request.NextPageToken) == "token"
+	})).Return(&persistence.GetWorkflowExecutionHistoryResponse{
+		History: historyEvents(6, 5, 4),
+	}, nil)
+	wh := &WorkflowHandler{historyMgr: mockHistoryMgr}
+	execution := gen.WorkflowExecution{WorkflowId: common.StringPtr("wid"), RunId: common.StringPtr("rid")}
+
+	history, err := wh.getLastEvents("domain-id", execution, common.FirstEventID, 10, 2, 2, false, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, historyEvents(8, 9), history)
+
+	history, err = wh.getLastEvents("domain-id", execution, common.FirstEventID, 10, 2, 5, true, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, historyEvents(9, 8, 7, 6, 5), history)
+
+	history, err = wh.getLastEvents("domain-id", execution, common.FirstEventID, 10, 2, 100, false, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, historyEvents(4, 5, 6, 7, 8, 9), history)
+}
+
+func TestValidateGracefulFailover(t *testing.T) {
+	clusterMetadata := cluster.GetTestClusterMetadata(true, true)
+	wh := &WorkflowHandler{
+		Service: service.NewTestService(clusterMetadata, nil, metrics.NewClient(tally.NoopScope, metrics.Frontend),
+			bark.NewNopLogger()),
+		config: NewConfig(dynamicconfig.NewNopCollection(), 1),
+	}
+	domain := func() *persistence.GetDomainResponse {
+		return &persistence.GetDomainResponse{
+			Info:           &persistence.DomainInfo{Name: "domain"},
+			IsGlobalDomain: true,
+			TableVersion:   persistence.DomainTableVersionV2,
+		}
+	}
+	active := cluster.TestCurrentClusterName
+	standby := cluster.TestAlternativeClusterName
+
+	assert.NoError(t, wh.validateGracefulFailover(domain(), active, standby, time.Minute))
+	assert.Equal(t, errGracefulFailoverNotFromActive, wh.validateGracefulFailover(domain(), standby, active, time.Minute))
+	assert.Equal(t, errGracefulFailoverWithoutFailover, wh.validateGracefulFailover(domain(), active, active, time.Minute))
+	assert.Equal(t, errInvalidGracefulFailoverTimeout, wh.validateGracefulFailover(domain(), active, standby, 0))
+	assert.Equal(t, errInvalidGracefulFailoverTimeout, wh.validateGracefulFailover(domain(), active, standby, 24*time.Hour))
+
+	localDomain := domain()
+	localDomain.IsGlobalDomain = false
+	assert.Equal(t, errGracefulFailoverNotSupported, wh.validateGracefulFailover(localDomain, active, standby, time.Minute))
+	v1Domain := domain()
+	v1Domain.TableVersion = persistence.DomainTableVersionV1
+	assert.Equal(t, errGracefulFailoverNotSupported, wh.validateGracefulFailover(v1Domain, active, standby, time.Minute))
+
+	drainingDomain := domain()
+	drainingDomain.PendingActiveClusterName = standby
+	drainingDomain.FailoverEndTime = time.Now().Add(time.Minute).UnixNano()
+	assert.Equal(t, errDomainFailoverInProgress, wh.validateGracefulFailover(drainingDomain, active, standby, time.Minute))
+	drainingDomain.FailoverEndTime = time.Now().Add(-time.Minute).UnixNano()
+	assert.NoError(t, wh.validateGracefulFailover(drainingDomain, active, standby, time.Minute))
+}
+
+func TestIsReplicationDrained(t *testing.T) {
+	shardStatus := func(hasUnackedTasks bool) *replicator.ShardReplicationStatus {
+		return &replicator.ShardReplicationStatus{
+			RemoteClusters: map[string]*replicator.RemoteClusterReplicationStatus{
+				"standby": {HasUnackedTasks: common.BoolPtr(hasUnackedTasks)},
+				"other":   {HasUnackedTasks: common.BoolPtr(true)},
+			},
+		}
+	}
+	shardIDs := []int32{0, 1}
+
+	assert.True(t, isReplicationDrained(map[int32]*replicator.ShardReplicationStatus{
+		0: shardStatus(false),
+		1: shardStatus(false),
+	}, shardIDs, "standby"))
+	assert.False(t, isReplicationDrained(map[int32]*replicator.ShardReplicationStatus{
+		0: shardStatus(false),
+		1: shardStatus(true),
+	}, shardIDs, "standby"))
+	assert.False(t, isReplicationDrained(map[int32]*replicator.ShardReplicationStatus{
+		0: shardStatus(false),
+	}, shardIDs, "standby"))
+	assert.False(t, isReplicationDrained(map[int32]*replicator.ShardReplicationStatus{
+		0: shardStatus(false),
+		1: shardStatus(false),
+	}, shardIDs, "unknown"))
+}
+
+func TestGetMaxReadLevelByShard(t *testing.T) {
+	maxReadLevelByShard := getMaxReadLevelByShard(map[int32]*replicator.ShardReplicationStatus{
+		0: {MaxReadLevel: common.Int64Ptr(10)},
+		1: {MaxReadLevel: common.Int64Ptr(20)},
+		2: {MaxReadLevel: common.Int64Ptr(30)},
+	}, []int32{0, 1})
+	assert.Equal(t, map[int32]int64{0: 10, 1: 20}, maxReadLevelByShard)
+}
+
+func TestCompleteGracefulFailover(t *testing.T) {
+	clusterMetadata := cluster.GetTestClusterMetadata(true, true)
+	logger := bark.NewNopLogger()
+	mockMetadataMgr := &mocks.MetadataManager{}
+	mockProducer := &mocks.KafkaProducer{}
+	wh := &WorkflowHandler{
+		Service:          service.NewTestService(clusterMetadata, nil, metrics.NewClient(tally.NoopScope, metrics.Frontend), logger),
+		config:           NewConfig(dynamicconfig.NewNopCollection(), 1),
+		metadataMgr:      mockMetadataMgr,
+		domainReplicator: NewDomainReplicator(mockProducer, logger),
+	}
+	standby := cluster.TestAlternativeClusterName
+	endTime := time.Now().Add(time.Minute).UnixNano()
+	domain := func(failoverEndTime int64) *persistence.GetDomainResponse {
+		return &persistence.GetDomainResponse{
+			Info:   &persistence.DomainInfo{ID: "domain-id", Name: "domain"},
+			Config: &persistence.DomainConfig{},
+			ReplicationConfig: &persistence.DomainReplicationConfig{
+				ActiveClusterName: cluster.TestCurrentClusterName,
+				Clusters: []*persistence.ClusterReplicationConfig{
+					{ClusterName: cluster.TestCurrentClusterName},
+					{ClusterName: standby},
+				},
+			},
+			IsGlobalDomain:           true,
+			TableVersion:             persistence.DomainTableVersionV2,
+			PendingActiveClusterName: standby,
+			FailoverEndTime:          failoverEndTime,
+		}
+	}
+
+	mockMetadataMgr.On("GetMetadata").Return(&persistence.GetMetadataResponse{NotificationVersion: 5}, nil)
+	mockMetadataMgr.On("GetDomain", &persistence.GetDomainRequest{Name: "domain"}).Return(domain(endTime), nil).Once()
+	mockMetadataMgr.On("UpdateDomain", mock.MatchedBy(func(request *persistence.UpdateDomainRequest) bool {
+		return request.ReplicationConfig.ActiveClusterName == standby &&
+			request.PendingActiveClusterName == "" &&
+			request.FailoverEndTime == 0 &&
+			request.FailoverNotificationVersion == 5 &&
+			request.NotificationVersion == 5 &&
+			len(request.FailoverHistory) == 1 &&
+			request.FailoverHistory[0].Identity == "identity"
+	})).Return(nil).Once()
+	mockProducer.On("Publish", mock.Anything).Return(nil).Once()
+	assert.NoError(t, wh.completeGracefulFailover("domain", standby, endTime, "identity", "reason", logger))
+
+	// another update of the domain ended the graceful failover
+	mockMetadataMgr.On("GetDomain", &persistence.GetDomainRequest{Name: "domain"}).Return(domain(endTime+1), nil).Once()
+	assert.NoError(t, wh.completeGracefulFailover("domain", standby, endTime, "identity", "reason", logger))
+
+	mockMetadataMgr.AssertExpectations(t)
+	mockProducer.AssertExpectations(t)
+}
+
+func TestResumeGracefulFailovers(t *testing.T) {
+	clusterMetadata := cluster.GetTestClusterMetadata(true, true)
+	logger := bark.NewNopLogger()
+	mockMetadataMgr := &mocks.MetadataManager{}
+	mockProducer := &mocks.KafkaProducer{}
+	wh := &WorkflowHandler{
+		Service:          service.NewTestService(clusterMetadata, nil, metrics.NewClient(tally.NoopScope, metrics.Frontend), logger),
+		config:           NewConfig(dynamicconfig.NewNopCollection(), 1),
+		metadataMgr:      mockMetadataMgr,
+		domainReplicator: NewDomainReplicator(mockProducer, logger),
+		failoverDrains:   make(map[string]struct{}),
+	}
+	standby := cluster.TestAlternativeClusterName
+	endTime := time.Now().Add(-time.Minute).UnixNano()
+	domain := func(id string, activeClusterName string, pendingActiveClusterName string) *persistence.GetDomainResponse {
+		return &persistence.GetDomainResponse{
+			Info:   &persistence.DomainInfo{ID: id, Name: id},
+			Config: &persistence.DomainConfig{},
+			ReplicationConfig: &persistence.DomainReplicationConfig{
+				ActiveClusterName: activeClusterName,
+				Clusters: []*persistence.ClusterReplicationConfig{
+					{ClusterName: cluster.TestCurrentClusterName},
+					{ClusterName: standby},
+				},
+			},
+			IsGlobalDomain:           true,
+			TableVersion:             persistence.DomainTableVersionV2,
+			PendingActiveClusterName: pendingActiveClusterName,
+			FailoverEndTime:          endTime,
+		}
+	}
+
+	// only the expired failover of a domain active in the current cluster and not drained by this frontend resumes
+	assert.True(t, wh.addFailoverDrain("draining"))
+	mockMetadataMgr.On("ListDomains", &persistence.ListDomainsRequest{PageSize: gracefulFailoverResumePageSize}).
+		Return(&persistence.ListDomainsResponse{
+			Domains: []*persistence.GetDomainResponse{
+				domain("expired", cluster.TestCurrentClusterName, standby),
+				domain("draining", cluster.TestCurrentClusterName, standby),
+				domain("remote", standby, cluster.TestCurrentClusterName),
+				domain("idle", cluster.TestCurrentClusterName, ""),
+			},
+			NextPageToken: []byte("token"),
+		}, nil).Once()
+	mockMetadataMgr.On("ListDomains", &persistence.ListDomainsRequest{
+		PageSize:      gracefulFailoverResumePageSize,
+		NextPageToken: []byte("token"),
+	}).Return(&persistence.ListDomainsResponse{}, nil).Once()
+	mockMetadataMgr.On("GetMetadata").Return(&persistence.GetMetadataResponse{NotificationVersion: 5}, nil)
+	mockMetadataMgr.On("GetDomain", &persistence.GetDomainRequest{Name: "expired"}).
+		Return(domain("expired", cluster.TestCurrentClusterName, standby), nil).Once()
+	mockMetadataMgr.On("UpdateDomain", mock.MatchedBy(func(request *persistence.UpdateDomainRequest) bool {
+		return request.Info.ID == "expired" &&
+			request.ReplicationConfig.ActiveClusterName == standby &&
+			request.PendingActiveClusterName == "" &&
+			len(request.FailoverHistory) == 1 &&
+			request.FailoverHistory[0].Identity == gracefulFailoverResumeIdentity
+	})).Return(nil).Once()
+	mockProducer.On("Publish", mock.Anything).Return(nil).Once()
+	assert.NoError(t, wh.resumeGracefulFailovers())
+
+	// the completed failover is no longer drained, the drain in progress still is
+	assert.True(t, wh.addFailoverDrain("expired"))
+	assert.False(t, wh.addFailoverDrain("draining"))
+	mockMetadataMgr.AssertExpectations(t)
+	mockProducer.AssertExpectations(t)
+}
+
+func TestCreateFailoverInfo(t *testing.T) {
+	assert.Nil(t, createFailoverInfo("", 0))
+	assert.Nil(t, createFailoverInfo("standby", time.Now().Add(-time.Minute).UnixNano()))
+
+	endTime := time.Now().Add(time.Minute).UnixNano()
+	info := createFailoverInfo("standby", endTime)
+	assert.Equal(t, "standby", info.GetPendingActiveClusterName())
+	assert.Equal(t, endTime, info.GetFailoverEndTimestamp())
+}
+
+func TestAppendFailoverHistory(t *testing.T) {
+	event := func(version int64) *persistence.DomainFailoverEvent {
+		return &persistence.DomainFailoverEvent{FailoverVersion: version}
+	}
+
+	history := appendFailoverHistory(nil, event(1), 2)
+	assert.Equal(t, []*persistence.DomainFailoverEvent{event(1)}, history)
+	history = appendFailoverHistory(history, event(2), 2)
+	assert.Equal(t, []*persistence.DomainFailoverEvent{event(1), event(2)}, history)
+	history = appendFailoverHistory(history, event(3), 2)
+	assert.Equal(t, []*persistence.DomainFailoverEvent{event(2), event(3)}, history)
+	history = appendFailoverHistory(history, event(4), 0)
+	assert.Equal(t, []*persistence.DomainFailoverEvent{event(2), event(3), event(4)}, history)
+}
+
+func TestGetStaleReadInfo(t *testing.T) {
+	clusterMetadata := cluster.GetTestClusterMetadata(true, true)
+	info := &persistence.DomainInfo{ID: "some-id", Name: "some-domain"}
+
+	assert.Nil(t, getStaleReadInfo(cache.NewDomainCacheEntryWithInfo(info)))
+	assert.Nil(t, getStaleReadInfo(cache.NewGlobalDomainCacheEntryForTest(info, &persistence.DomainReplicationConfig{
+		ActiveClusterName: cluster.TestCurrentClusterName,
+	}, clusterMetadata)))
+
+	staleReadInfo := getStaleReadInfo(cache.NewGlobalDomainCacheEntryForTest(info, &persistence.DomainReplicationConfig{
+		ActiveClusterName: cluster.TestAlternativeClusterName,
+	}, clusterMetadata))
+	assert.Equal(t, cluster.TestAlternativeClusterName, staleReadInfo.GetActiveClusterName())
+	assert.Nil(t, staleReadInfo.ReplicationLagInSeconds)
+}
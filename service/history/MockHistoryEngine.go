@@ -25,6 +25,7 @@ import (
 
 	"github.com/stretchr/testify/mock"
 	gohistory "github.com/uber/cadence/.gen/go/history"
+	"github.com/uber/cadence/.gen/go/replicator"
 	"github.com/uber/cadence/.gen/go/shared"
 )
 
@@ -334,6 +335,30 @@ func (_m *MockHistoryEngine) SignalWorkflowExecution(ctx context.Context, reques
 	return r0
 }
 
+// UpdateWorkflowExecution is mock implementation for UpdateWorkflowExecution of HistoryEngine
+func (_m *MockHistoryEngine) UpdateWorkflowExecution(ctx context.Context, request *gohistory.UpdateWorkflowExecutionRequest) (
+	*shared.UpdateWorkflowExecutionResponse, error) {
+	ret := _m.Called(request)
+
+	var r0 *shared.UpdateWorkflowExecutionResponse
+	if rf, ok := ret.Get(0).(func(*gohistory.UpdateWorkflowExecutionRequest) *shared.UpdateWorkflowExecutionResponse); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shared.UpdateWorkflowExecutionResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*gohistory.UpdateWorkflowExecutionRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SignalWithStartWorkflowExecution is mock implementation for SignalWithStartWorkflowExecution of HistoryEngine
 func (_m *MockHistoryEngine) SignalWithStartWorkflowExecution(ctx context.Context, request *gohistory.SignalWithStartWorkflowExecutionRequest) (
 	*shared.StartWorkflowExecutionResponse, error) {
@@ -428,6 +453,20 @@ func (_m *MockHistoryEngine) ReplicateEvents(ctx context.Context, request *gohis
 	return r0
 }
 
+// ImportWorkflowExecution is mock implementation for ImportWorkflowExecution of HistoryEngine
+func (_m *MockHistoryEngine) ImportWorkflowExecution(ctx context.Context, request *gohistory.ImportWorkflowExecutionRequest) error {
+	ret := _m.Called(request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*gohistory.ImportWorkflowExecutionRequest) error); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SyncShardStatus is mock implementation for SyncShardStatus of HistoryEngine
 func (_m *MockHistoryEngine) SyncShardStatus(ctx context.Context, request *gohistory.SyncShardStatusRequest) error {
 	ret := _m.Called(request)
@@ -442,4 +481,52 @@ func (_m *MockHistoryEngine) SyncShardStatus(ctx context.Context, request *gohis
 	return r0
 }
 
+// GetReplicationMessages is mock implementation for GetReplicationMessages of HistoryEngine
+func (_m *MockHistoryEngine) GetReplicationMessages(ctx context.Context, pollingCluster string, lastReadMessageID int64,
+	lastProcessedMessageID int64) (*replicator.ReplicationMessages, error) {
+	ret := _m.Called(pollingCluster, lastReadMessageID, lastProcessedMessageID)
+
+	var r0 *replicator.ReplicationMessages
+	if rf, ok := ret.Get(0).(func(string, int64, int64) *replicator.ReplicationMessages); ok {
+		r0 = rf(pollingCluster, lastReadMessageID, lastProcessedMessageID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*replicator.ReplicationMessages)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int64, int64) error); ok {
+		r1 = rf(pollingCluster, lastReadMessageID, lastProcessedMessageID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 var _ Engine = (*MockHistoryEngine)(nil)
+
+// DescribeReplicationStatus is mock implementation for DescribeReplicationStatus of HistoryEngine
+func (_m *MockHistoryEngine) DescribeReplicationStatus(ctx context.Context, domainID string,
+	maxReadLevel int64) (*replicator.ShardReplicationStatus, error) {
+	ret := _m.Called(domainID, maxReadLevel)
+
+	var r0 *replicator.ShardReplicationStatus
+	if rf, ok := ret.Get(0).(func(string, int64) *replicator.ShardReplicationStatus); ok {
+		r0 = rf(domainID, maxReadLevel)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*replicator.ShardReplicationStatus)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int64) error); ok {
+		r1 = rf(domainID, maxReadLevel)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -117,6 +117,22 @@ func (_m *mockMutableState) AddActivityTaskFailedEvent(_a0 int64, _a1 int64, _a2
 	return r0
 }
 
+// AddActivityTaskRedirectedEvent provides a mock function with given fields: _a0, _a1, _a2
+func (_m *mockMutableState) AddActivityTaskRedirectedEvent(_a0 int64, _a1 string, _a2 shared.TimeoutType) *shared.HistoryEvent {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 *shared.HistoryEvent
+	if rf, ok := ret.Get(0).(func(int64, string, shared.TimeoutType) *shared.HistoryEvent); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shared.HistoryEvent)
+		}
+	}
+
+	return r0
+}
+
 // AddActivityTaskScheduledEvent provides a mock function with given fields: _a0, _a1
 func (_m *mockMutableState) AddActivityTaskScheduledEvent(_a0 int64, _a1 *shared.ScheduleActivityTaskDecisionAttributes) (*shared.HistoryEvent, *persistence.ActivityInfo) {
 	ret := _m.Called(_a0, _a1)
@@ -768,6 +784,22 @@ func (_m *mockMutableState) AddWorkflowExecutionSignaled(_a0 *shared.SignalWorkf
 	return r0
 }
 
+// AddWorkflowExecutionUpdateAcceptedEvent provides a mock function with given fields: _a0, _a1, _a2
+func (_m *mockMutableState) AddWorkflowExecutionUpdateAcceptedEvent(_a0 int64, _a1 *shared.WorkflowUpdate, _a2 *shared.RespondWorkflowUpdateDecisionAttributes) *shared.HistoryEvent {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 *shared.HistoryEvent
+	if rf, ok := ret.Get(0).(func(int64, *shared.WorkflowUpdate, *shared.RespondWorkflowUpdateDecisionAttributes) *shared.HistoryEvent); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shared.HistoryEvent)
+		}
+	}
+
+	return r0
+}
+
 // AddWorkflowExecutionStartedEvent provides a mock function with given fields: _a0, _a1
 func (_m *mockMutableState) AddWorkflowExecutionStartedEvent(_a0 shared.WorkflowExecution, _a1 *h.StartWorkflowExecutionRequest) *shared.HistoryEvent {
 	ret := _m.Called(_a0, _a1)
@@ -1721,6 +1753,27 @@ func (_m *mockMutableState) IsCancelRequested() (bool, string) {
 	return r0, r1
 }
 
+// GetAcceptedUpdate provides a mock function with given fields: updateID
+func (_m *mockMutableState) GetAcceptedUpdate(updateID string) (int64, bool) {
+	ret := _m.Called(updateID)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(string) int64); ok {
+		r0 = rf(updateID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 bool
+	if rf, ok := ret.Get(1).(func(string) bool); ok {
+		r1 = rf(updateID)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
 // IsSignalRequested provides a mock function with given fields: requestID
 func (_m *mockMutableState) IsSignalRequested(requestID string) bool {
 	ret := _m.Called(requestID)
@@ -1815,6 +1868,20 @@ func (_m *mockMutableState) ReplicateActivityTaskFailedEvent(_a0 *shared.History
 	return r0
 }
 
+// ReplicateActivityTaskRedirectedEvent provides a mock function with given fields: _a0
+func (_m *mockMutableState) ReplicateActivityTaskRedirectedEvent(_a0 *shared.HistoryEvent) error {
+	ret := _m.Called(_a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*shared.HistoryEvent) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ReplicateActivityTaskScheduledEvent provides a mock function with given fields: _a0
 func (_m *mockMutableState) ReplicateActivityTaskScheduledEvent(_a0 *shared.HistoryEvent) *persistence.ActivityInfo {
 	ret := _m.Called(_a0)
@@ -2065,6 +2132,11 @@ func (_m *mockMutableState) ReplicateWorkflowExecutionStartedEvent(_a0 string, _
 	_m.Called(_a0, _a1, _a2, _a3, _a4)
 }
 
+// ReplicateWorkflowExecutionUpdateAcceptedEvent provides a mock function with given fields: _a0
+func (_m *mockMutableState) ReplicateWorkflowExecutionUpdateAcceptedEvent(_a0 *shared.HistoryEvent) {
+	_m.Called(_a0)
+}
+
 // ReplicateWorkflowExecutionTerminatedEvent provides a mock function with given fields: _a0
 func (_m *mockMutableState) ReplicateWorkflowExecutionTerminatedEvent(_a0 *shared.HistoryEvent) {
 	_m.Called(_a0)
@@ -51,3 +51,8 @@ func (_m *MockTimerQueueProcessor) FailoverDomain(domainID string) {
 func (_m *MockTimerQueueProcessor) NotifyNewTimers(clusterName string, currentTime time.Time, timerTask []persistence.Task) {
 	_m.Called(clusterName, currentTime, timerTask)
 }
+
+// RefreshStandbyProcessors is mock implementation for RefreshStandbyProcessors of Processor
+func (_m *MockTimerQueueProcessor) RefreshStandbyProcessors() {
+	_m.Called()
+}
@@ -49,3 +49,8 @@ func (_m *MockTransferQueueProcessor) FailoverDomain(domainID string) {
 func (_m *MockTransferQueueProcessor) NotifyNewTask(clusterName string, transferTask []persistence.Task) {
 	_m.Called(clusterName, transferTask)
 }
+
+// RefreshStandbyProcessors is mock implementation for RefreshStandbyProcessors of Processor
+func (_m *MockTransferQueueProcessor) RefreshStandbyProcessors() {
+	_m.Called()
+}
@@ -65,6 +65,7 @@ func (r *conflictResolverImpl) reset(prevRunID string, requestID string, replayE
 	var resetMutableStateBuilder *mutableStateBuilder
 	var sBuilder stateBuilder
 	var lastEvent *shared.HistoryEvent
+	var versionHistory []*persistence.ReplicationInfo
 	eventsToApply := replayNextEventID - common.FirstEventID
 	for hasMore := true; hasMore; hasMore = len(nextPageToken) > 0 {
 		response, err := r.getHistory(domainID, execution, common.FirstEventID, replayNextEventID, nextPageToken)
@@ -110,6 +111,9 @@ func (r *conflictResolverImpl) reset(prevRunID string, requestID string, replayE
 		}
 		resetMutableStateBuilder.executionInfo.LastFirstEventID = lastFirstEventID
 		resetMutableStateBuilder.IncrementHistorySize(response.Size)
+		for _, event := range history.Events {
+			versionHistory = persistence.UpdateVersionHistory(versionHistory, event.GetVersion(), event.GetEventId())
+		}
 	}
 
 	// Applying events to mutableState does not move the nextEventID.  Explicitly set nextEventID to new value
@@ -118,7 +122,13 @@ func (r *conflictResolverImpl) reset(prevRunID string, requestID string, replayE
 	// the last updated time is not important here, since this should be updated with event time afterwards
 	resetMutableStateBuilder.executionInfo.LastUpdatedTimestamp = startTime
 
-	sourceCluster := r.clusterMetadata.ClusterNameForFailoverVersion(lastEvent.GetVersion())
+	// the history replayed is the beginning of the current one, so its versions are known from the first event
+	resetMutableStateBuilder.replicationState.VersionHistory = versionHistory
+	sourceCluster, err := r.clusterMetadata.ClusterNameForFailoverVersion(lastEvent.GetVersion())
+	if err != nil {
+		r.logError("Conflict resolution err resolving source cluster.", err)
+		return nil, err
+	}
 	resetMutableStateBuilder.UpdateReplicationStateLastEventID(sourceCluster, lastEvent.GetVersion(), replayEventID)
 
 	r.logger.WithField(logging.TagResetNextEventID, resetMutableStateBuilder.GetNextEventID()).Info("All events applied for execution.")
@@ -210,7 +210,7 @@ func (s *conflictResolverSuite) TestReset() {
 		DecisionTaskScheduledEventAttributes: &shared.DecisionTaskScheduledEventAttributes{},
 	}
 
-	s.mockClusterMetadata.On("ClusterNameForFailoverVersion", event1.GetVersion()).Return(sourceCluster).Once()
+	s.mockClusterMetadata.On("ClusterNameForFailoverVersion", event1.GetVersion()).Return(sourceCluster, nil).Once()
 	s.mockHistoryMgr.On("GetWorkflowExecutionHistory", &persistence.GetWorkflowExecutionHistoryRequest{
 		DomainID:      domainID,
 		Execution:     execution,
@@ -266,6 +266,9 @@ func (s *conflictResolverSuite) TestReset() {
 					LastEventID: event1.GetEventId(),
 				},
 			},
+			VersionHistory: []*persistence.ReplicationInfo{
+				{Version: event1.GetVersion(), LastEventID: event1.GetEventId()},
+			},
 		},
 		Condition:                 s.mockContext.updateCondition,
 		RangeID:                   s.mockShard.shardInfo.RangeID,
@@ -275,6 +278,7 @@ func (s *conflictResolverSuite) TestReset() {
 		InsertRequestCancelInfos:  []*persistence.RequestCancelInfo{},
 		InsertSignalInfos:         []*persistence.SignalInfo{},
 		InsertSignalRequestedIDs:  []string{},
+		InsertAcceptedUpdates:     map[string]int64{},
 		Encoding:                  common.EncodingType("json"),
 	}).Return(nil).Once()
 	s.mockExecutionMgr.On("GetWorkflowExecution", &persistence.GetWorkflowExecutionRequest{
@@ -30,7 +30,9 @@ import (
 	"github.com/uber/cadence/.gen/go/health/metaserver"
 	hist "github.com/uber/cadence/.gen/go/history"
 	"github.com/uber/cadence/.gen/go/history/historyserviceserver"
+	r "github.com/uber/cadence/.gen/go/replicator"
 	gen "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/client/admin"
 	hc "github.com/uber/cadence/client/history"
 	"github.com/uber/cadence/client/matching"
 	"github.com/uber/cadence/common"
@@ -40,6 +42,7 @@ import (
 	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/service"
+	"github.com/uber/cadence/common/service/config"
 	"go.uber.org/yarpc/yarpcerrors"
 )
 
@@ -63,7 +66,11 @@ type (
 		config                *Config
 		historyEventNotifier  historyEventNotifier
 		publisher             messaging.Producer
+		replicationDLQ        messaging.DLQ
 		rateLimiter           common.TokenBucket
+		// remoteAdminClients caches the admin clients of the remote clusters by their address
+		remoteAdminClientsLock sync.Mutex
+		remoteAdminClients     map[config.Address]admin.Client
 		service.Service
 	}
 )
@@ -80,6 +87,7 @@ var (
 	errSourceClusterNotSet     = &gen.BadRequestError{Message: "Source Cluster not set on request."}
 	errShardIDNotSet           = &gen.BadRequestError{Message: "Shard ID not set on request."}
 	errTimestampNotSet         = &gen.BadRequestError{Message: "Timestamp not set on request."}
+	errClusterNameNotSet       = &gen.BadRequestError{Message: "Cluster name not set on request."}
 	errHistoryHostThrottle     = &gen.ServiceBusyError{Message: "History host rps exceeded"}
 )
 
@@ -136,6 +144,15 @@ func (h *Handler) Start() error {
 		if err != nil {
 			h.GetLogger().Fatalf("Creating kafka producer failed: %v", err)
 		}
+
+		// remote clients used to pull replication tasks when RPC replication is enabled
+		h.remoteAdminClients = make(map[config.Address]admin.Client)
+		h.replicationDLQ, err = h.GetMessagingClient().NewDLQ(h.GetClusterMetadata().GetCurrentClusterName())
+		if err != nil {
+			// pulled replication tasks which keep failing are retried instead of moved to the DLQ
+			h.GetLogger().Warnf("Failed to create DLQ for replication task pollers: %v", err)
+			h.replicationDLQ = nil
+		}
 	}
 
 	h.domainCache = cache.NewDomainCache(h.metadataMgr, h.GetClusterMetadata(), h.GetMetricsClient(), h.GetLogger())
@@ -155,6 +172,9 @@ func (h *Handler) Start() error {
 func (h *Handler) Stop() {
 	h.domainCache.Stop()
 	h.controller.Stop()
+	if h.replicationDLQ != nil {
+		h.replicationDLQ.Close()
+	}
 	h.shardManager.Close()
 	h.historyMgr.Close()
 	h.executionMgrFactory.Close()
@@ -166,7 +186,32 @@ func (h *Handler) Stop() {
 
 // CreateEngine is implementation for HistoryEngineFactory used for creating the engine instance for shard
 func (h *Handler) CreateEngine(context ShardContext) Engine {
-	return NewEngineWithShardContext(context, h.visibilityMgr, h.matchingServiceClient, h.historyServiceClient, h.historyEventNotifier, h.publisher)
+	return NewEngineWithShardContext(context, h.visibilityMgr, h.matchingServiceClient, h.historyServiceClient, h.historyEventNotifier,
+		h.publisher, h.getRemoteAdminClients, h.replicationDLQ)
+}
+
+// getRemoteAdminClients returns the admin clients of the enabled remote clusters, the engines call it again
+// whenever the clusters change
+func (h *Handler) getRemoteAdminClients() map[string]admin.Client {
+	if h.remoteAdminClients == nil {
+		return nil
+	}
+
+	h.remoteAdminClientsLock.Lock()
+	defer h.remoteAdminClientsLock.Unlock()
+	clients := make(map[string]admin.Client)
+	for clusterName, address := range h.GetClusterMetadata().GetAllClientAddress() {
+		if clusterName == h.GetClusterMetadata().GetCurrentClusterName() {
+			continue
+		}
+		client, ok := h.remoteAdminClients[address]
+		if !ok {
+			client = h.GetClientFactory().NewRemoteAdminClient(address.RPCName, address.RPCAddress)
+			h.remoteAdminClients[address] = client
+		}
+		clients[clusterName] = client
+	}
+	return clients
 }
 
 // Health is for health check
@@ -791,6 +836,42 @@ func (h *Handler) SignalWorkflowExecution(ctx context.Context,
 	return nil
 }
 
+// UpdateWorkflowExecution is used to deliver an update request to a running workflow execution as part of a
+// decision task. It blocks until a decision accepts or rejects the update.
+func (h *Handler) UpdateWorkflowExecution(ctx context.Context,
+	wrappedRequest *hist.UpdateWorkflowExecutionRequest) (*gen.UpdateWorkflowExecutionResponse, error) {
+	h.startWG.Wait()
+
+	scope := metrics.HistoryUpdateWorkflowExecutionScope
+	h.metricsClient.IncCounter(scope, metrics.CadenceRequests)
+	sw := h.metricsClient.StartTimer(scope, metrics.CadenceLatency)
+	defer sw.Stop()
+
+	if ok, _ := h.rateLimiter.TryConsume(1); !ok {
+		h.updateErrorMetric(scope, errHistoryHostThrottle)
+		return nil, errHistoryHostThrottle
+	}
+
+	if wrappedRequest.GetDomainUUID() == "" {
+		return nil, errDomainNotSet
+	}
+
+	workflowExecution := wrappedRequest.UpdateRequest.WorkflowExecution
+	engine, err1 := h.controller.GetEngine(workflowExecution.GetWorkflowId())
+	if err1 != nil {
+		h.updateErrorMetric(scope, err1)
+		return nil, err1
+	}
+
+	resp, err2 := engine.UpdateWorkflowExecution(ctx, wrappedRequest)
+	if err2 != nil {
+		h.updateErrorMetric(scope, h.convertError(err2))
+		return nil, h.convertError(err2)
+	}
+
+	return resp, nil
+}
+
 // SignalWithStartWorkflowExecution is used to ensure sending a signal event to a workflow execution.
 // If workflow is running, this results in WorkflowExecutionSignaled event recorded in the history
 // and a decision task being created for the execution.
@@ -1052,6 +1133,49 @@ func (h *Handler) ReplicateEvents(ctx context.Context, replicateRequest *hist.Re
 	return nil
 }
 
+// ImportWorkflowExecution recreates a workflow run from the history batches exported from another cluster
+func (h *Handler) ImportWorkflowExecution(ctx context.Context, importRequest *hist.ImportWorkflowExecutionRequest) error {
+	h.startWG.Wait()
+
+	scope := metrics.HistoryImportWorkflowExecutionScope
+	h.metricsClient.IncCounter(scope, metrics.CadenceRequests)
+	sw := h.metricsClient.StartTimer(scope, metrics.CadenceLatency)
+	defer sw.Stop()
+
+	if ok, _ := h.rateLimiter.TryConsume(1); !ok {
+		h.updateErrorMetric(scope, errHistoryHostThrottle)
+		return errHistoryHostThrottle
+	}
+
+	if importRequest.DomainUUID == nil {
+		return errDomainNotSet
+	}
+	workflowExecution := importRequest.Execution
+	if workflowExecution == nil {
+		return errWorkflowExecutionNotSet
+	}
+	if workflowExecution.GetWorkflowId() == "" {
+		return errWorkflowIDNotSet
+	}
+	if uuid.Parse(workflowExecution.GetRunId()) == nil {
+		return errRunIDNotValid
+	}
+
+	engine, err1 := h.controller.GetEngine(workflowExecution.GetWorkflowId())
+	if err1 != nil {
+		h.updateErrorMetric(scope, err1)
+		return err1
+	}
+
+	err2 := engine.ImportWorkflowExecution(ctx, importRequest)
+	if err2 != nil {
+		h.updateErrorMetric(scope, h.convertError(err2))
+		return h.convertError(err2)
+	}
+
+	return nil
+}
+
 // SyncShardStatus is called by processor to sync history shrad information from another cluster
 func (h *Handler) SyncShardStatus(ctx context.Context, syncShardStatusRequest *hist.SyncShardStatusRequest) error {
 	h.startWG.Wait()
@@ -1094,6 +1218,84 @@ func (h *Handler) SyncShardStatus(ctx context.Context, syncShardStatusRequest *h
 	return nil
 }
 
+// GetReplicationMessages returns new replication tasks of the requested shards owned by this host
+func (h *Handler) GetReplicationMessages(ctx context.Context,
+	request *r.GetReplicationMessagesRequest) (*r.GetReplicationMessagesResponse, error) {
+	h.startWG.Wait()
+
+	scope := metrics.HistoryGetReplicationMessagesScope
+	h.metricsClient.IncCounter(scope, metrics.CadenceRequests)
+	sw := h.metricsClient.StartTimer(scope, metrics.CadenceLatency)
+	defer sw.Stop()
+
+	if ok, _ := h.rateLimiter.TryConsume(1); !ok {
+		h.updateErrorMetric(scope, errHistoryHostThrottle)
+		return nil, errHistoryHostThrottle
+	}
+
+	if request.ClusterName == nil {
+		return nil, errClusterNameNotSet
+	}
+
+	messagesByShard := make(map[int32]*r.ReplicationMessages)
+	for _, token := range request.Tokens {
+		if token.ShardID == nil {
+			return nil, errShardIDNotSet
+		}
+
+		engine, err := h.controller.getEngineForShard(int(token.GetShardID()))
+		if err != nil {
+			h.updateErrorMetric(scope, h.convertError(err))
+			return nil, h.convertError(err)
+		}
+
+		messages, err := engine.GetReplicationMessages(ctx, request.GetClusterName(),
+			token.GetLastRetrievedMessageId(), token.GetLastProcessedMessageId())
+		if err != nil {
+			h.updateErrorMetric(scope, h.convertError(err))
+			return nil, h.convertError(err)
+		}
+		messagesByShard[token.GetShardID()] = messages
+	}
+
+	return &r.GetReplicationMessagesResponse{MessagesByShard: messagesByShard}, nil
+}
+
+// DescribeReplicationStatus returns the replication levels of the requested shards owned by this host
+func (h *Handler) DescribeReplicationStatus(ctx context.Context,
+	request *r.DescribeReplicationStatusRequest) (*r.DescribeReplicationStatusResponse, error) {
+	h.startWG.Wait()
+
+	scope := metrics.HistoryDescribeReplicationStatusScope
+	h.metricsClient.IncCounter(scope, metrics.CadenceRequests)
+	sw := h.metricsClient.StartTimer(scope, metrics.CadenceLatency)
+	defer sw.Stop()
+
+	if ok, _ := h.rateLimiter.TryConsume(1); !ok {
+		h.updateErrorMetric(scope, errHistoryHostThrottle)
+		return nil, errHistoryHostThrottle
+	}
+
+	statusByShard := make(map[int32]*r.ShardReplicationStatus)
+	for _, shardID := range request.ShardIDs {
+		engine, err := h.controller.getEngineForShard(int(shardID))
+		if err != nil {
+			h.updateErrorMetric(scope, h.convertError(err))
+			return nil, h.convertError(err)
+		}
+
+		status, err := engine.DescribeReplicationStatus(ctx, request.GetDomainID(),
+			request.GetMaxReadLevelByShard()[shardID])
+		if err != nil {
+			h.updateErrorMetric(scope, h.convertError(err))
+			return nil, h.convertError(err)
+		}
+		statusByShard[shardID] = status
+	}
+
+	return &r.DescribeReplicationStatusResponse{StatusByShard: statusByShard}, nil
+}
+
 // convertError is a helper method to convert ShardOwnershipLostError from persistence layer returned by various
 // HistoryEngine API calls to ShardOwnershipLost error return by HistoryService for client to be redirected to the
 // correct shard.
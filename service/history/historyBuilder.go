@@ -161,6 +161,13 @@ func (b *historyBuilder) AddActivityTaskTimedOutEvent(scheduleEventID, startedEv
 	return b.addEventToHistory(event)
 }
 
+func (b *historyBuilder) AddActivityTaskRedirectedEvent(scheduleEventID int64, previousTaskList,
+	taskList string, timeoutType workflow.TimeoutType) *workflow.HistoryEvent {
+	event := b.newActivityTaskRedirectedEvent(scheduleEventID, previousTaskList, taskList, timeoutType)
+
+	return b.addEventToHistory(event)
+}
+
 func (b *historyBuilder) AddCompletedWorkflowEvent(decisionCompletedEventID int64,
 	attributes *workflow.CompleteWorkflowExecutionDecisionAttributes) *workflow.HistoryEvent {
 	event := b.newCompleteWorkflowExecutionEvent(decisionCompletedEventID, attributes)
@@ -369,6 +376,13 @@ func (b *historyBuilder) AddWorkflowExecutionSignaledEvent(
 	return b.addEventToHistory(event)
 }
 
+func (b *historyBuilder) AddWorkflowExecutionUpdateAcceptedEvent(decisionCompletedEventID int64,
+	update *workflow.WorkflowUpdate, attributes *workflow.RespondWorkflowUpdateDecisionAttributes) *workflow.HistoryEvent {
+	event := b.newWorkflowExecutionUpdateAcceptedEvent(decisionCompletedEventID, update, attributes)
+
+	return b.addEventToHistory(event)
+}
+
 func (b *historyBuilder) AddStartChildWorkflowExecutionInitiatedEvent(decisionCompletedEventID int64,
 	attributes *workflow.StartChildWorkflowExecutionDecisionAttributes) *workflow.HistoryEvent {
 	event := b.newStartChildWorkflowExecutionInitiatedEvent(decisionCompletedEventID, attributes)
@@ -554,6 +568,8 @@ func (b *historyBuilder) newActivityTaskScheduledEvent(decisionTaskCompletedEven
 	attributes.HeartbeatTimeoutSeconds = common.Int32Ptr(common.Int32Default(scheduleAttributes.HeartbeatTimeoutSeconds))
 	attributes.DecisionTaskCompletedEventId = common.Int64Ptr(decisionTaskCompletedEventID)
 	attributes.RetryPolicy = scheduleAttributes.RetryPolicy
+	attributes.FairnessKey = scheduleAttributes.FairnessKey
+	attributes.FallbackTaskLists = scheduleAttributes.FallbackTaskLists
 	historyEvent.ActivityTaskScheduledEventAttributes = attributes
 
 	return historyEvent
@@ -598,6 +614,19 @@ func (b *historyBuilder) newActivityTaskTimedOutEvent(scheduleEventID, startedEv
 	return historyEvent
 }
 
+func (b *historyBuilder) newActivityTaskRedirectedEvent(scheduleEventID int64, previousTaskList, taskList string,
+	timeoutType workflow.TimeoutType) *workflow.HistoryEvent {
+	historyEvent := b.msBuilder.CreateNewHistoryEvent(workflow.EventTypeActivityTaskRedirected)
+	attributes := &workflow.ActivityTaskRedirectedEventAttributes{}
+	attributes.ScheduledEventId = common.Int64Ptr(scheduleEventID)
+	attributes.PreviousTaskList = &workflow.TaskList{Name: common.StringPtr(previousTaskList)}
+	attributes.TaskList = &workflow.TaskList{Name: common.StringPtr(taskList)}
+	attributes.TimeoutType = common.TimeoutTypePtr(timeoutType)
+	historyEvent.ActivityTaskRedirectedEventAttributes = attributes
+
+	return historyEvent
+}
+
 func (b *historyBuilder) newActivityTaskFailedEvent(scheduleEventID, startedEventID int64,
 	request *workflow.RespondActivityTaskFailedRequest) *workflow.HistoryEvent {
 	historyEvent := b.msBuilder.CreateNewHistoryEvent(workflow.EventTypeActivityTaskFailed)
@@ -656,6 +685,22 @@ func (b *historyBuilder) newWorkflowExecutionSignaledEvent(
 	return historyEvent
 }
 
+func (b *historyBuilder) newWorkflowExecutionUpdateAcceptedEvent(decisionCompletedEventID int64,
+	update *workflow.WorkflowUpdate, request *workflow.RespondWorkflowUpdateDecisionAttributes) *workflow.HistoryEvent {
+	historyEvent := b.msBuilder.CreateNewHistoryEvent(workflow.EventTypeWorkflowExecutionUpdateAccepted)
+	attributes := &workflow.WorkflowExecutionUpdateAcceptedEventAttributes{}
+	attributes.UpdateId = common.StringPtr(request.GetUpdateId())
+	if update != nil {
+		attributes.UpdateName = common.StringPtr(update.GetUpdateName())
+		attributes.Input = update.Input
+	}
+	attributes.Result = request.Result
+	attributes.DecisionTaskCompletedEventId = common.Int64Ptr(decisionCompletedEventID)
+	historyEvent.WorkflowExecutionUpdateAcceptedEventAttributes = attributes
+
+	return historyEvent
+}
+
 func (b *historyBuilder) newWorkflowExecutionTerminatedEvent(
 	request *workflow.TerminateWorkflowExecutionRequest) *workflow.HistoryEvent {
 	historyEvent := b.msBuilder.CreateNewHistoryEvent(workflow.EventTypeWorkflowExecutionTerminated)
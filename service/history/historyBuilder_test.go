@@ -567,6 +567,78 @@ func (s *historyBuilderSuite) TestHistoryBuilderWorkflowCancellationRequested()
 	s.Equal(int64(7), s.getNextEventID())
 }
 
+func (s *historyBuilderSuite) TestHistoryBuilderActivityTaskRedirected() {
+	workflowType := "some random workflow type"
+	tasklist := "some random tasklist"
+	identity := "some random identity"
+	input := []byte("some random workflow input")
+	execTimeout := int32(60)
+	taskTimeout := int32(10)
+	workflowExecution := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("some random workflow ID"),
+		RunId:      common.StringPtr(uuid.New()),
+	}
+
+	s.addWorkflowExecutionStartedEvent(workflowExecution, workflowType, tasklist, input, execTimeout, taskTimeout, identity)
+	s.addDecisionTaskScheduledEvent()
+	s.addDecisionTaskStartedEvent(2, tasklist, identity)
+	s.addDecisionTaskCompletedEvent(2, 3, nil, identity)
+	s.Equal(int64(5), s.getNextEventID())
+
+	activityTaskList := "some random activity tasklist"
+	scheduledEvent, _ := s.msBuilder.AddActivityTaskScheduledEvent(4,
+		&workflow.ScheduleActivityTaskDecisionAttributes{
+			ActivityId:                    common.StringPtr("some random activity ID"),
+			ActivityType:                  &workflow.ActivityType{Name: common.StringPtr("some random activity type")},
+			TaskList:                      &workflow.TaskList{Name: common.StringPtr(activityTaskList)},
+			ScheduleToCloseTimeoutSeconds: common.Int32Ptr(60),
+			ScheduleToStartTimeoutSeconds: common.Int32Ptr(10),
+			StartToCloseTimeoutSeconds:    common.Int32Ptr(1),
+			FallbackTaskLists: []*workflow.TaskList{
+				{Name: common.StringPtr("fallback-1")},
+				{Name: common.StringPtr("fallback-2")},
+			},
+		})
+	s.Equal(int64(5), scheduledEvent.GetEventId())
+	attributes := scheduledEvent.ActivityTaskScheduledEventAttributes
+	s.Equal(2, len(attributes.FallbackTaskLists))
+
+	ai, _ := s.msBuilder.GetActivityInfo(5)
+	scheduledTime := ai.ScheduledTime
+
+	taskList, ok := getNextFallbackTaskList(attributes, activityTaskList)
+	s.True(ok)
+	s.Equal("fallback-1", taskList)
+	redirectedEvent := s.msBuilder.AddActivityTaskRedirectedEvent(5, taskList, workflow.TimeoutTypeScheduleToStart)
+	s.NotNil(redirectedEvent)
+	s.Equal(workflow.EventTypeActivityTaskRedirected, redirectedEvent.GetEventType())
+	s.Equal(common.BufferedEventID, redirectedEvent.GetEventId())
+	s.Nil(s.msBuilder.FlushBufferedEvents())
+	s.Equal(int64(6), redirectedEvent.GetEventId())
+	redirectedAttributes := redirectedEvent.ActivityTaskRedirectedEventAttributes
+	s.Equal(int64(5), redirectedAttributes.GetScheduledEventId())
+	s.Equal(activityTaskList, redirectedAttributes.PreviousTaskList.GetName())
+	s.Equal("fallback-1", redirectedAttributes.TaskList.GetName())
+	s.Equal(workflow.TimeoutTypeScheduleToStart, redirectedAttributes.GetTimeoutType())
+	ai, running := s.msBuilder.GetActivityInfo(5)
+	s.True(running)
+	s.Equal("fallback-1", ai.TaskList)
+	s.Equal(scheduledTime, ai.ScheduledTime)
+	s.Equal(redirectedEvent.GetTimestamp(), ai.RedirectedTime.UnixNano())
+	s.Equal(int64(7), s.getNextEventID())
+
+	taskList, ok = getNextFallbackTaskList(attributes, ai.TaskList)
+	s.True(ok)
+	s.Equal("fallback-2", taskList)
+	s.NotNil(s.msBuilder.AddActivityTaskRedirectedEvent(5, taskList, workflow.TimeoutTypeScheduleToStart))
+	_, ok = getNextFallbackTaskList(attributes, "fallback-2")
+	s.False(ok)
+
+	// a started activity can no longer be redirected
+	s.addActivityTaskStartedEvent(5, "fallback-2", identity)
+	s.Nil(s.msBuilder.AddActivityTaskRedirectedEvent(5, "fallback-1", workflow.TimeoutTypeScheduleToStart))
+}
+
 func (s *historyBuilderSuite) TestHistoryBuilderWorkflowCancellationFailed() {
 	workflowType := "some random workflow type"
 	tasklist := "some random tasklist"
@@ -24,6 +24,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"encoding/json"
@@ -33,7 +34,9 @@ import (
 	"github.com/pborman/uuid"
 	"github.com/uber-common/bark"
 	h "github.com/uber/cadence/.gen/go/history"
+	r "github.com/uber/cadence/.gen/go/replicator"
 	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/client/admin"
 	hc "github.com/uber/cadence/client/history"
 	"github.com/uber/cadence/client/matching"
 	"github.com/uber/cadence/common"
@@ -52,6 +55,7 @@ const (
 	activityCancelationMsgActivityIDUnknown  = "ACTIVITY_ID_UNKNOWN"
 	activityCancelationMsgActivityNotStarted = "ACTIVITY_ID_NOT_STARTED"
 	timerCancelationMsgTimerIDUnknown        = "TIMER_ID_UNKNOWN"
+	activityFairnessKeyLengthLimit           = 255
 )
 
 type (
@@ -63,12 +67,21 @@ type (
 		txProcessor          transferQueueProcessor
 		timerProcessor       timerQueueProcessor
 		replicator           *historyReplicator
-		replicatorProcessor  queueProcessor
+		importer             *historyImporter
+		replicatorProcessor  replicatorQueueProcessor
 		historyEventNotifier historyEventNotifier
 		tokenSerializer      common.TaskTokenSerializer
 		historyCache         *historyCache
+		workflowUpdates      *workflowUpdateRegistry
 		metricsClient        metrics.Client
 		logger               bark.Logger
+		// remoteAdminClients returns the admin clients of the remote clusters, replicationPollers holds the
+		// replication task poller of each of them and is refreshed when the clusters change
+		remoteAdminClients     func() map[string]admin.Client
+		replicationPollersLock sync.Mutex
+		replicationPollers     map[string]*replicationTaskPoller
+		// replicationDLQ receives the replication tasks the pollers fail to apply, nil if there is no DLQ
+		replicationDLQ messaging.DLQ
 	}
 
 	// shardContextWrapper wraps ShardContext to notify transferQueueProcessor on new tasks.
@@ -111,6 +124,8 @@ var (
 	ErrCancellationAlreadyRequested = &workflow.CancellationAlreadyRequestedError{Message: "Cancellation already requested for this workflow execution."}
 	// ErrBufferedEventsLimitExceeded is the error indicating limit reached for maximum number of buffered events
 	ErrBufferedEventsLimitExceeded = &workflow.LimitExceededError{Message: "Exceeded workflow execution limit for buffered events"}
+	// ErrReplicationNotEnabled is error indicating that this cluster does not replicate its workflows
+	ErrReplicationNotEnabled = &workflow.BadRequestError{Message: "Replication is not enabled on this cluster."}
 	// FailedWorkflowCloseState is a set of failed workflow close states, used for start workflow policy
 	// for start workflow execution API
 	FailedWorkflowCloseState = map[int]bool{
@@ -123,7 +138,8 @@ var (
 
 // NewEngineWithShardContext creates an instance of history engine
 func NewEngineWithShardContext(shard ShardContext, visibilityMgr persistence.VisibilityManager,
-	matching matching.Client, historyClient hc.Client, historyEventNotifier historyEventNotifier, publisher messaging.Producer) Engine {
+	matching matching.Client, historyClient hc.Client, historyEventNotifier historyEventNotifier, publisher messaging.Producer,
+	remoteAdminClients func() map[string]admin.Client, replicationDLQ messaging.DLQ) Engine {
 	currentClusterName := shard.GetService().GetClusterMetadata().GetCurrentClusterName()
 	shardWrapper := &shardContextWrapper{
 		currentClusterName:   currentClusterName,
@@ -142,6 +158,7 @@ func NewEngineWithShardContext(shard ShardContext, visibilityMgr persistence.Vis
 		executionManager:   executionManager,
 		tokenSerializer:    common.NewJSONTaskTokenSerializer(),
 		historyCache:       historyCache,
+		workflowUpdates:    newWorkflowUpdateRegistry(),
 		logger: logger.WithFields(bark.Fields{
 			logging.TagWorkflowComponent: logging.TagValueHistoryEngineComponent,
 		}),
@@ -151,6 +168,7 @@ func NewEngineWithShardContext(shard ShardContext, visibilityMgr persistence.Vis
 	txProcessor := newTransferQueueProcessor(shard, historyEngImpl, visibilityMgr, matching, historyClient, logger)
 	historyEngImpl.timerProcessor = newTimerQueueProcessor(shard, historyEngImpl, matching, logger)
 	historyEngImpl.txProcessor = txProcessor
+	historyEngImpl.importer = newHistoryImporter(shard, historyEngImpl, historyCache, visibilityMgr, logger)
 	shardWrapper.txProcessor = txProcessor
 
 	// Only start the replicator processor if valid publisher is passed in
@@ -160,6 +178,9 @@ func NewEngineWithShardContext(shard ShardContext, visibilityMgr persistence.Vis
 		shardWrapper.replcatorProcessor = replicatorProcessor
 		historyEngImpl.replicator = newHistoryReplicator(shard, historyEngImpl, historyCache, shard.GetDomainCache(), historyManager,
 			logger)
+		historyEngImpl.remoteAdminClients = remoteAdminClients
+		historyEngImpl.replicationPollers = make(map[string]*replicationTaskPoller)
+		historyEngImpl.replicationDLQ = replicationDLQ
 	}
 
 	return historyEngImpl
@@ -179,6 +200,10 @@ func (e *historyEngineImpl) Start() {
 	if e.replicatorProcessor != nil {
 		e.replicatorProcessor.Start()
 	}
+	// the clusters registered or disabled at runtime are picked up without recreating the engine,
+	// including the ones changed since the engine was created
+	e.shard.GetService().GetClusterMetadata().RegisterClusterChangeCallback(e.shard.GetShardID(), e.refreshClusters)
+	e.refreshClusters()
 }
 
 // Stop the service.
@@ -186,16 +211,58 @@ func (e *historyEngineImpl) Stop() {
 	logging.LogHistoryEngineShuttingDownEvent(e.logger)
 	defer logging.LogHistoryEngineShutdownEvent(e.logger)
 
+	e.shard.GetService().GetClusterMetadata().UnregisterClusterChangeCallback(e.shard.GetShardID())
 	e.txProcessor.Stop()
 	e.timerProcessor.Stop()
 	if e.replicatorProcessor != nil {
 		e.replicatorProcessor.Stop()
 	}
+	if e.remoteAdminClients != nil {
+		e.replicationPollersLock.Lock()
+		for clusterName, poller := range e.replicationPollers {
+			poller.Stop()
+			delete(e.replicationPollers, clusterName)
+		}
+		e.replicationPollersLock.Unlock()
+	}
 
 	// unset the failover callback
 	e.shard.GetDomainCache().UnregisterDomainChangeCallback(e.shard.GetShardID())
 }
 
+// refreshClusters creates the standby queue processors and the replication task pollers of the clusters registered
+// at runtime, and stops the ones of the clusters disabled or removed
+func (e *historyEngineImpl) refreshClusters() {
+	e.txProcessor.RefreshStandbyProcessors()
+	e.timerProcessor.RefreshStandbyProcessors()
+	if e.remoteAdminClients != nil {
+		e.refreshReplicationPollers()
+	}
+}
+
+// refreshReplicationPollers starts a replication task poller for each enabled remote cluster without one and
+// stops the pollers of the clusters disabled or moved to another address
+func (e *historyEngineImpl) refreshReplicationPollers() {
+	e.replicationPollersLock.Lock()
+	defer e.replicationPollersLock.Unlock()
+
+	remoteAdminClients := e.remoteAdminClients()
+	for clusterName, poller := range e.replicationPollers {
+		if remoteAdmin, ok := remoteAdminClients[clusterName]; !ok || remoteAdmin != poller.remoteAdmin {
+			poller.Stop()
+			delete(e.replicationPollers, clusterName)
+		}
+	}
+	for clusterName, remoteAdmin := range remoteAdminClients {
+		if _, ok := e.replicationPollers[clusterName]; ok {
+			continue
+		}
+		poller := newReplicationTaskPoller(e.shard, e, clusterName, remoteAdmin, e.replicationDLQ, e.shard.GetLogger())
+		e.replicationPollers[clusterName] = poller
+		poller.Start()
+	}
+}
+
 func (e *historyEngineImpl) registerDomainFailoverCallback() {
 
 	failoverPredicate := func(nextDomain *cache.DomainCacheEntry, action func()) {
@@ -481,10 +548,14 @@ func (e *historyEngineImpl) StartWorkflowExecution(startRequest *h.StartWorkflow
 	// if err still non nil, see if retry
 	if errExist, ok := err.(*persistence.WorkflowExecutionAlreadyStartedError); ok {
 		if msBuilder.GetCurrentVersion() < errExist.LastWriteVersion {
+			activeCluster, err := clusterMetadata.ClusterNameForFailoverVersion(errExist.LastWriteVersion)
+			if err != nil {
+				return nil, err
+			}
 			return nil, ce.NewDomainNotActiveError(
 				domainEntry.GetInfo().Name,
 				clusterMetadata.GetCurrentClusterName(),
-				clusterMetadata.ClusterNameForFailoverVersion(errExist.LastWriteVersion),
+				activeCluster,
 			)
 		}
 
@@ -525,6 +596,16 @@ func (e *historyEngineImpl) GetMutableState(ctx context.Context,
 	// set the run id in case query the current running workflow
 	execution.RunId = response.Execution.RunId
 
+	// caller (e.g. a strongly consistent query) wants the state after the current decision completes
+	if request.GetWaitForDecisionCompletion() && response.GetIsWorkflowRunning() {
+		if err := e.waitForDecisionCompletion(ctx, domainID, execution); err != nil {
+			return nil, err
+		}
+		if response, err = e.getMutableState(ctx, domainID, execution); err != nil {
+			return nil, err
+		}
+	}
+
 	// expectedNextEventID is 0 when caller want to get the current next event ID without blocking
 	expectedNextEventID := common.FirstEventID
 	if request.ExpectedNextEventId != nil {
@@ -576,6 +657,51 @@ func (e *historyEngineImpl) GetMutableState(ctx context.Context,
 	return response, nil
 }
 
+// waitForDecisionCompletion blocks until the workflow has no decision task in flight, the workflow
+// is closed, or the context is done
+func (e *historyEngineImpl) waitForDecisionCompletion(ctx context.Context,
+	domainID string, execution workflow.WorkflowExecution) error {
+
+	identifier := newWorkflowIdentifier(domainID, &execution)
+	subscriberID, channel, err := e.historyEventNotifier.WatchHistoryEvent(identifier)
+	if err != nil {
+		return err
+	}
+	defer e.historyEventNotifier.UnwatchHistoryEvent(identifier, subscriberID)
+
+	for {
+		inFlight, err := e.hasInFlightDecision(ctx, domainID, execution)
+		if err != nil || !inFlight {
+			return err
+		}
+		select {
+		case event := <-channel:
+			if !event.isWorkflowRunning {
+				return nil
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (e *historyEngineImpl) hasInFlightDecision(ctx context.Context,
+	domainID string, execution workflow.WorkflowExecution) (inFlight bool, retError error) {
+
+	context, release, retError := e.historyCache.getOrCreateWorkflowExecutionWithTimeout(ctx, domainID, execution)
+	if retError != nil {
+		return
+	}
+	defer func() { release(retError) }()
+
+	msBuilder, retError := context.loadWorkflowExecution()
+	if retError != nil {
+		return
+	}
+	inFlight = msBuilder.IsWorkflowExecutionRunning() && msBuilder.HasInFlightDecisionTask()
+	return
+}
+
 func (e *historyEngineImpl) getMutableState(ctx context.Context,
 	domainID string, execution workflow.WorkflowExecution) (retResp *h.GetMutableStateResponse, retError error) {
 
@@ -605,10 +731,46 @@ func (e *historyEngineImpl) getMutableState(ctx context.Context,
 		IsWorkflowRunning:                    common.BoolPtr(msBuilder.IsWorkflowExecutionRunning()),
 		StickyTaskListScheduleToStartTimeout: common.Int32Ptr(executionInfo.StickyScheduleToStartTimeout),
 	}
+	if executionInfo.CloseStatus != persistence.WorkflowCloseStatusNone {
+		closeStatus := getWorkflowExecutionCloseStatus(executionInfo.CloseStatus)
+		retResp.CloseStatus = &closeStatus
+	}
+	if replicationState := msBuilder.GetReplicationState(); replicationState != nil {
+		retResp.ReplicationInfo = convertLastReplicationInfo(replicationState.LastReplicationInfo)
+		retResp.VersionHistory = convertVersionHistory(replicationState.VersionHistory)
+		// only the workflows of global domains are replicated
+		retResp.StaleReadInfo, retError = e.getStaleReadInfo(domainID)
+	}
 
 	return
 }
 
+// getStaleReadInfo returns the stale read info if the domain is active in another cluster, nil otherwise. The
+// replication lag is how far the standby time of the shard, advanced by the events and the shard status replicated
+// from the active cluster, is behind the current time.
+func (e *historyEngineImpl) getStaleReadInfo(domainID string) (*workflow.StaleReadInfo, error) {
+	domainEntry, err := e.shard.GetDomainCache().GetDomainByID(domainID)
+	if err != nil {
+		return nil, err
+	}
+	if domainEntry.IsDomainActive() {
+		return nil, nil
+	}
+
+	activeClusterName := domainEntry.GetReplicationConfig().ActiveClusterName
+	staleReadInfo := &workflow.StaleReadInfo{
+		ActiveClusterName: common.StringPtr(activeClusterName),
+	}
+	if standbyTime := e.shard.GetCurrentTime(activeClusterName); !standbyTime.IsZero() {
+		lag := time.Since(standbyTime)
+		if lag < 0 {
+			lag = 0
+		}
+		staleReadInfo.ReplicationLagInSeconds = common.Int64Ptr(int64(lag / time.Second))
+	}
+	return staleReadInfo, nil
+}
+
 func (e *historyEngineImpl) DescribeMutableState(ctx context.Context,
 	request *h.DescribeMutableStateRequest) (retResp *h.DescribeMutableStateResponse, retError error) {
 
@@ -744,6 +906,12 @@ func (e *historyEngineImpl) DescribeWorkflowExecution(ctx context.Context,
 		}
 	}
 
+	if msBuilder.GetReplicationState() != nil {
+		if result.StaleReadInfo, err = e.getStaleReadInfo(domainID); err != nil {
+			return nil, err
+		}
+	}
+
 	return result, nil
 }
 
@@ -1013,6 +1181,8 @@ Update_History_Loop:
 		var continueAsNewBuilder mutableState
 		var continueAsNewTimerTasks []persistence.Task
 		hasDecisionScheduleActivityTask := false
+		identifier := newWorkflowIdentifier(domainID, &workflowExecution)
+		updateResponses := map[string]*workflow.UpdateWorkflowExecutionResponse{}
 
 		if request.StickyAttributes == nil || request.StickyAttributes.WorkerTaskList == nil {
 			e.metricsClient.IncCounter(metrics.HistoryRespondDecisionTaskCompletedScope, metrics.CompleteDecisionWithStickyDisabledCounter)
@@ -1235,6 +1405,28 @@ Update_History_Loop:
 				}
 				msBuilder.AddRecordMarkerEvent(completedID, attributes)
 
+			case workflow.DecisionTypeRespondWorkflowUpdate:
+				e.metricsClient.IncCounter(metrics.HistoryRespondDecisionTaskCompletedScope,
+					metrics.DecisionTypeRespondWorkflowUpdateCounter)
+				attributes := d.RespondWorkflowUpdateDecisionAttributes
+				if err = validateRespondWorkflowUpdateAttributes(attributes, msBuilder, updateResponses); err != nil {
+					failDecision = true
+					failCause = workflow.DecisionTaskFailedCauseBadRespondWorkflowUpdateAttributes
+					break Process_Decision_Loop
+				}
+
+				updateResponse := &workflow.UpdateWorkflowExecutionResponse{}
+				if attributes.GetAccepted() {
+					update := e.workflowUpdates.get(identifier, attributes.GetUpdateId())
+					if msBuilder.AddWorkflowExecutionUpdateAcceptedEvent(completedID, update, attributes) == nil {
+						return nil, &workflow.InternalServiceError{Message: "Unable to add workflow update accepted event."}
+					}
+					updateResponse.Result = attributes.Result
+				} else {
+					updateResponse.Rejected = &workflow.WorkflowUpdateRejected{Reason: attributes.RejectionReason}
+				}
+				updateResponses[attributes.GetUpdateId()] = updateResponse
+
 			case workflow.DecisionTypeRequestCancelExternalWorkflowExecution:
 				e.metricsClient.IncCounter(metrics.HistoryRespondDecisionTaskCompletedScope,
 					metrics.DecisionTypeCancelExternalWorkflowCounter)
@@ -1408,6 +1600,7 @@ Update_History_Loop:
 			isComplete = false
 			hasUnhandledEvents = true
 			continueAsNewBuilder = nil
+			updateResponses = nil
 		}
 
 		if tt := tBuilder.GetUserTimerTaskIfNeeded(msBuilder); tt != nil {
@@ -1419,8 +1612,10 @@ Update_History_Loop:
 			}
 		}
 
-		// Schedule another decision task if new events came in during this decision or if request forced to
-		createNewDecisionTask := hasUnhandledEvents || request.GetForceCreateNewDecisionTask()
+		// Schedule another decision task if new events came in during this decision or if request forced to,
+		// as well as for updates which arrived after this decision was started
+		createNewDecisionTask := hasUnhandledEvents || request.GetForceCreateNewDecisionTask() ||
+			(!isComplete && e.workflowUpdates.hasUndelivered(identifier, scheduleID))
 
 		var newDecisionTaskScheduledID int64
 		if createNewDecisionTask {
@@ -1493,6 +1688,9 @@ Update_History_Loop:
 		// Inform timer about the new ones.
 		e.timerProcessor.NotifyNewTimers(e.currentClusterName, e.shard.GetCurrentTime(e.currentClusterName), timerTasks)
 
+		// answer the callers of the updates now that the decision is persisted
+		e.completeWorkflowUpdates(identifier, scheduleID, updateResponses, failDecision, isComplete)
+
 		response = &h.RespondDecisionTaskCompletedResponse{}
 		if request.GetReturnNewDecisionTask() && createNewDecisionTask {
 			di, _ := msBuilder.GetPendingDecision(newDecisionTaskScheduledID)
@@ -1905,6 +2103,154 @@ func (e *historyEngineImpl) SignalWorkflowExecution(ctx context.Context, signalR
 		})
 }
 
+// UpdateWorkflowExecution registers an update for the workflow, makes sure a decision task is scheduled to deliver
+// it and waits for a decision to accept or reject the update.
+func (e *historyEngineImpl) UpdateWorkflowExecution(ctx context.Context,
+	updateRequest *h.UpdateWorkflowExecutionRequest) (*workflow.UpdateWorkflowExecutionResponse, error) {
+
+	domainEntry, err := e.getActiveDomainEntry(updateRequest.DomainUUID)
+	if err != nil {
+		return nil, err
+	}
+	domainID := domainEntry.GetInfo().ID
+
+	request := updateRequest.UpdateRequest
+	execution := workflow.WorkflowExecution{
+		WorkflowId: request.WorkflowExecution.WorkflowId,
+		RunId:      request.WorkflowExecution.RunId,
+	}
+
+	// the request ID identifies the update, so that retried requests are deduplicated while the update is
+	// among the last history.maximumAcceptedUpdates updates accepted by the run
+	updateID := request.GetRequestId()
+	if updateID == "" {
+		updateID = uuid.New()
+	}
+	updateInfo := &workflow.WorkflowUpdate{
+		UpdateId:   common.StringPtr(updateID),
+		UpdateName: request.UpdateName,
+		Input:      request.Input,
+	}
+
+	var identifier workflowIdentifier
+	var update *workflowUpdate
+	acceptedEventID := common.EmptyEventID
+	nextEventID := common.EmptyEventID
+	var versionHistory []*persistence.ReplicationInfo
+	err = e.updateWorkflowExecutionWithAction(ctx, domainID, execution,
+		func(msBuilder mutableState, tBuilder *timerBuilder) (*updateWorkflowAction, error) {
+			if !msBuilder.IsWorkflowExecutionRunning() {
+				return nil, ErrWorkflowCompleted
+			}
+
+			executionInfo := msBuilder.GetExecutionInfo()
+			identifier = newWorkflowIdentifier(domainID, &workflow.WorkflowExecution{
+				WorkflowId: common.StringPtr(executionInfo.WorkflowID),
+				RunId:      common.StringPtr(executionInfo.RunID),
+			})
+			if eventID, ok := msBuilder.GetAcceptedUpdate(updateID); ok {
+				acceptedEventID = eventID
+				nextEventID = msBuilder.GetNextEventID()
+				if replicationState := msBuilder.GetReplicationState(); replicationState != nil {
+					versionHistory = replicationState.VersionHistory
+				}
+				return &updateWorkflowAction{}, nil
+			}
+
+			// the update is registered while holding the workflow lock, so it is either delivered with a
+			// decision which is yet to start, or picked up when the in-flight decision completes
+			if update == nil {
+				update = e.workflowUpdates.register(identifier, updateInfo)
+			}
+			return &updateWorkflowAction{createDecision: true}, nil
+		})
+	if err != nil {
+		if update != nil {
+			e.workflowUpdates.release(identifier, update)
+		}
+		return nil, err
+	}
+
+	if acceptedEventID != common.EmptyEventID {
+		if response := e.workflowUpdates.getAccepted(identifier, updateID); response != nil {
+			return response, nil
+		}
+		return e.getAcceptedUpdateResponse(identifier, updateID, acceptedEventID, nextEventID, versionHistory)
+	}
+
+	defer e.workflowUpdates.release(identifier, update)
+	select {
+	case <-update.doneCh:
+		return update.response, update.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// getAcceptedUpdateResponse answers a retried update from the WorkflowExecutionUpdateAccepted event recorded for it
+func (e *historyEngineImpl) getAcceptedUpdateResponse(identifier workflowIdentifier, updateID string,
+	eventID, nextEventID int64, versionHistory []*persistence.ReplicationInfo) (*workflow.UpdateWorkflowExecutionResponse,
+	error) {
+	// the history is read back from its end, as the batch holding the event may hold the events after it too
+	var nextPageToken []byte
+	for {
+		response, err := e.historyMgr.GetWorkflowExecutionHistory(&persistence.GetWorkflowExecutionHistoryRequest{
+			DomainID: identifier.domainID,
+			Execution: workflow.WorkflowExecution{
+				WorkflowId: common.StringPtr(identifier.workflowID),
+				RunId:      common.StringPtr(identifier.runID),
+			},
+			FirstEventID:   common.FirstEventID,
+			NextEventID:    nextEventID,
+			PageSize:       defaultHistoryPageSize,
+			NextPageToken:  nextPageToken,
+			Reverse:        true,
+			VersionHistory: versionHistory,
+		})
+		if err != nil {
+			return nil, err
+		}
+		events := response.History.Events
+		for _, event := range events {
+			attributes := event.WorkflowExecutionUpdateAcceptedEventAttributes
+			if event.GetEventId() == eventID && attributes != nil && attributes.GetUpdateId() == updateID {
+				return &workflow.UpdateWorkflowExecutionResponse{Result: attributes.Result}, nil
+			}
+		}
+		if len(response.NextPageToken) == 0 || (len(events) > 0 && events[len(events)-1].GetEventId() <= eventID) {
+			break
+		}
+		nextPageToken = response.NextPageToken
+	}
+	return nil, &workflow.InternalServiceError{
+		Message: fmt.Sprintf("Accepted event %v of workflow update %v not found.", eventID, updateID),
+	}
+}
+
+// completeWorkflowUpdates answers the updates responded by a completed decision. Updates delivered with the decision
+// but not responded are rejected, and all pending updates fail if the workflow is closed by the decision.
+func (e *historyEngineImpl) completeWorkflowUpdates(identifier workflowIdentifier, scheduleID int64,
+	updateResponses map[string]*workflow.UpdateWorkflowExecutionResponse, failDecision, isComplete bool) {
+	if failDecision {
+		// updates delivered with a failed decision are delivered again with the next one
+		return
+	}
+
+	for updateID, response := range updateResponses {
+		if response.Rejected == nil {
+			e.metricsClient.IncCounter(metrics.HistoryRespondDecisionTaskCompletedScope, metrics.WorkflowUpdateAcceptedCounter)
+		} else {
+			e.metricsClient.IncCounter(metrics.HistoryRespondDecisionTaskCompletedScope, metrics.WorkflowUpdateRejectedCounter)
+		}
+		e.workflowUpdates.complete(identifier, updateID, response)
+	}
+	if isComplete {
+		e.workflowUpdates.failAll(identifier, ErrWorkflowCompleted)
+		return
+	}
+	e.workflowUpdates.rejectDelivered(identifier, scheduleID, "Update is not handled by the decision.")
+}
+
 func (e *historyEngineImpl) SignalWithStartWorkflowExecution(ctx context.Context, signalWithStartRequest *h.SignalWithStartWorkflowExecutionRequest) (
 	retResp *workflow.StartWorkflowExecutionResponse, retError error) {
 
@@ -2148,10 +2494,14 @@ func (e *historyEngineImpl) SignalWithStartWorkflowExecution(ctx context.Context
 
 	// try to create the workflow execution
 	if !isBrandNew && msBuilder.GetCurrentVersion() < prevLastWriteVersion {
+		activeCluster, err := clusterMetadata.ClusterNameForFailoverVersion(prevLastWriteVersion)
+		if err != nil {
+			return nil, err
+		}
 		return nil, ce.NewDomainNotActiveError(
 			domainEntry.GetInfo().Name,
 			clusterMetadata.GetCurrentClusterName(),
-			clusterMetadata.ClusterNameForFailoverVersion(prevLastWriteVersion),
+			activeCluster,
 		)
 	}
 	resultRunID, err := createWorkflow(isBrandNew, prevRunID, prevLastWriteVersion) // (true, "", 0) or (false, "prevRunID", "prevLastWriteVersion")
@@ -2307,6 +2657,11 @@ func (e *historyEngineImpl) ReplicateEvents(ctx context.Context, replicateReques
 	return e.replicator.ApplyEvents(ctx, replicateRequest)
 }
 
+// ImportWorkflowExecution recreates a workflow run from its exported history batches
+func (e *historyEngineImpl) ImportWorkflowExecution(ctx context.Context, request *h.ImportWorkflowExecutionRequest) error {
+	return e.importer.importWorkflowExecution(ctx, request)
+}
+
 func (e *historyEngineImpl) SyncShardStatus(ctx context.Context, request *h.SyncShardStatusRequest) error {
 	clusterName := request.GetSourceCluster()
 	now := time.Unix(0, request.GetTimestamp())
@@ -2321,6 +2676,22 @@ func (e *historyEngineImpl) SyncShardStatus(ctx context.Context, request *h.Sync
 	return nil
 }
 
+func (e *historyEngineImpl) GetReplicationMessages(ctx context.Context, pollingCluster string, lastReadMessageID int64,
+	lastProcessedMessageID int64) (*r.ReplicationMessages, error) {
+	if e.replicatorProcessor == nil {
+		return nil, ErrReplicationNotEnabled
+	}
+	return e.replicatorProcessor.getTasks(pollingCluster, lastReadMessageID, lastProcessedMessageID)
+}
+
+func (e *historyEngineImpl) DescribeReplicationStatus(ctx context.Context, domainID string,
+	maxReadLevel int64) (*r.ShardReplicationStatus, error) {
+	if e.replicatorProcessor == nil {
+		return nil, ErrReplicationNotEnabled
+	}
+	return e.replicatorProcessor.getReplicationStatus(domainID, maxReadLevel)
+}
+
 type updateWorkflowAction struct {
 	deleteWorkflow bool
 	createDecision bool
@@ -2483,6 +2854,12 @@ func (e *historyEngineImpl) createRecordDecisionTaskStartedResponse(domainID str
 		response.DecisionInfo.StartedEvent = startedEvent
 	}
 
+	// deliver the pending updates of the workflow with this decision, sticky or not
+	response.Updates = e.workflowUpdates.deliver(newWorkflowIdentifier(domainID, &workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr(executionInfo.WorkflowID),
+		RunId:      common.StringPtr(executionInfo.RunID),
+	}), di.ScheduleID)
+
 	return response
 }
 
@@ -2573,6 +2950,21 @@ func validateActivityScheduleAttributes(attributes *workflow.ScheduleActivityTas
 		return err
 	}
 
+	if len(attributes.GetFairnessKey()) > activityFairnessKeyLengthLimit {
+		return &workflow.BadRequestError{Message: "FairnessKey exceeds length limit."}
+	}
+
+	taskLists := map[string]struct{}{attributes.TaskList.GetName(): {}}
+	for _, fallback := range attributes.FallbackTaskLists {
+		if fallback == nil || fallback.GetName() == "" {
+			return &workflow.BadRequestError{Message: "FallbackTaskLists contains a task list without name."}
+		}
+		if _, ok := taskLists[fallback.GetName()]; ok {
+			return &workflow.BadRequestError{Message: "FallbackTaskLists contains a duplicate task list."}
+		}
+		taskLists[fallback.GetName()] = struct{}{}
+	}
+
 	// Only attempt to deduce and fill in unspecified timeouts only when all timeouts are non-negative.
 	if attributes.GetScheduleToCloseTimeoutSeconds() < 0 || attributes.GetScheduleToStartTimeoutSeconds() < 0 ||
 		attributes.GetStartToCloseTimeoutSeconds() < 0 || attributes.GetHeartbeatTimeoutSeconds() < 0 {
@@ -2671,6 +3063,23 @@ func validateRecordMarkerAttributes(attributes *workflow.RecordMarkerDecisionAtt
 	return nil
 }
 
+func validateRespondWorkflowUpdateAttributes(attributes *workflow.RespondWorkflowUpdateDecisionAttributes,
+	msBuilder mutableState, updateResponses map[string]*workflow.UpdateWorkflowExecutionResponse) error {
+	if attributes == nil {
+		return &workflow.BadRequestError{Message: "RespondWorkflowUpdateDecisionAttributes is not set on decision."}
+	}
+	if attributes.GetUpdateId() == "" {
+		return &workflow.BadRequestError{Message: "UpdateId is not set on decision."}
+	}
+	if _, ok := updateResponses[attributes.GetUpdateId()]; ok {
+		return &workflow.BadRequestError{Message: "Update is already responded."}
+	}
+	if _, ok := msBuilder.GetAcceptedUpdate(attributes.GetUpdateId()); ok {
+		return &workflow.BadRequestError{Message: "Update is already responded."}
+	}
+	return nil
+}
+
 func validateCompleteWorkflowExecutionAttributes(attributes *workflow.CompleteWorkflowExecutionDecisionAttributes) error {
 	if attributes == nil {
 		return &workflow.BadRequestError{Message: "CompleteWorkflowExecutionDecisionAttributes is not set on decision."}
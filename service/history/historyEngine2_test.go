@@ -26,6 +26,7 @@ import (
 	"errors"
 	"os"
 	"testing"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/mock"
@@ -138,6 +139,7 @@ func (s *engine2Suite) SetupTest() {
 		executionManager:   s.mockExecutionMgr,
 		historyMgr:         s.mockHistoryMgr,
 		historyCache:       historyCache,
+		workflowUpdates:    newWorkflowUpdateRegistry(),
 		logger:             s.logger,
 		metricsClient:      metrics.NewClient(tally.NoopScope, metrics.History),
 		tokenSerializer:    common.NewJSONTaskTokenSerializer(),
@@ -1486,3 +1488,81 @@ func (s *engine2Suite) getBuilder(domainID string, we workflow.WorkflowExecution
 
 	return context.msBuilder
 }
+
+func (s *engine2Suite) TestSyncShardStatus_ClusterRegisteredAtRuntime() {
+	registeredCluster := "other"
+	mockClusterStore := &mocks.MetadataManager{}
+	mockClusterStore.On("ListClusters").Return(&p.ListClustersResponse{}, nil).Once()
+	mockClusterStore.On("ListClusters").Return(&p.ListClustersResponse{Clusters: []*p.ClusterInfo{
+		{
+			ClusterName:            registeredCluster,
+			InitialFailoverVersion: 2,
+			Enabled:                true,
+			RPCName:                "cadence-frontend",
+			RPCAddress:             "127.0.0.1:9933",
+		},
+	}}, nil)
+	clusterMetadata := cluster.NewDynamicMetadata(
+		dynamicconfig.GetBoolPropertyFn(true),
+		cluster.TestFailoverVersionIncrement,
+		cluster.TestCurrentClusterName,
+		cluster.TestCurrentClusterName,
+		cluster.TestAllClusterFailoverVersions,
+		cluster.TestAllClusterAddress,
+		mockClusterStore,
+		dynamicconfig.GetDurationPropertyFn(time.Millisecond),
+		s.logger,
+	)
+	metricsClient := metrics.NewClient(tally.NoopScope, metrics.History)
+	shard := &shardContextImpl{
+		service:                   service.NewTestService(clusterMetadata, s.mockMessagingClient, metricsClient, s.logger),
+		shardInfo:                 &p.ShardInfo{ShardID: 1, RangeID: 1},
+		executionManager:          s.mockExecutionMgr,
+		historyMgr:                s.mockHistoryMgr,
+		domainCache:               s.mockDomainCache,
+		shardManager:              s.mockShardManager,
+		config:                    s.config,
+		logger:                    s.logger,
+		metricsClient:             metricsClient,
+		standbyClusterCurrentTime: make(map[string]time.Time),
+	}
+	engine := &historyEngineImpl{
+		currentClusterName: cluster.TestCurrentClusterName,
+		shard:              shard,
+		logger:             s.logger,
+		metricsClient:      metricsClient,
+	}
+	txProcessor := newTransferQueueProcessor(shard, engine, s.mockVisibilityMgr, s.mockMatchingClient, s.mockHistoryClient, s.logger)
+	timerProcessor := newTimerQueueProcessor(shard, engine, s.mockMatchingClient, s.logger).(*timerQueueProcessorImpl)
+	engine.txProcessor = txProcessor
+	engine.timerProcessor = timerProcessor
+	// the engine registers the callback when it starts, its queue processors are not started here
+	clusterMetadata.RegisterClusterChangeCallback(shard.GetShardID(), engine.refreshClusters)
+	clusterMetadata.Start()
+	defer clusterMetadata.Stop()
+
+	hasStandbyProcessors := func() bool {
+		txProcessor.standbyTaskProcessorsLock.RLock()
+		defer txProcessor.standbyTaskProcessorsLock.RUnlock()
+		timerProcessor.standbyTimerProcessorsLock.RLock()
+		defer timerProcessor.standbyTimerProcessorsLock.RUnlock()
+		_, hasTransferProcessor := txProcessor.standbyTaskProcessors[registeredCluster]
+		_, hasTimerProcessor := timerProcessor.standbyTimerProcessors[registeredCluster]
+		return hasTransferProcessor && hasTimerProcessor
+	}
+	for deadline := time.Now().Add(5 * time.Second); !hasStandbyProcessors(); time.Sleep(time.Millisecond) {
+		s.True(time.Now().Before(deadline), "standby processors of the registered cluster are not created")
+	}
+
+	sourceCluster, err := clusterMetadata.ClusterNameForFailoverVersion(cluster.TestFailoverVersionIncrement + 2)
+	s.NoError(err)
+	s.Equal(registeredCluster, sourceCluster)
+	now := time.Now()
+	err = engine.SyncShardStatus(context.Background(), &h.SyncShardStatusRequest{
+		SourceCluster: common.StringPtr(sourceCluster),
+		ShardId:       common.Int64Ptr(1),
+		Timestamp:     common.Int64Ptr(now.UnixNano()),
+	})
+	s.NoError(err)
+	s.Equal(now.UnixNano(), shard.GetCurrentTime(registeredCluster).UnixNano())
+}
@@ -25,6 +25,7 @@ import (
 	"time"
 
 	h "github.com/uber/cadence/.gen/go/history"
+	r "github.com/uber/cadence/.gen/go/replicator"
 	workflow "github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/persistence"
@@ -66,6 +67,8 @@ type (
 		RecordActivityTaskHeartbeat(ctx context.Context, request *h.RecordActivityTaskHeartbeatRequest) (*workflow.RecordActivityTaskHeartbeatResponse, error)
 		RequestCancelWorkflowExecution(ctx context.Context, request *h.RequestCancelWorkflowExecutionRequest) error
 		SignalWorkflowExecution(ctx context.Context, request *h.SignalWorkflowExecutionRequest) error
+		UpdateWorkflowExecution(ctx context.Context, request *h.UpdateWorkflowExecutionRequest) (
+			*workflow.UpdateWorkflowExecutionResponse, error)
 		SignalWithStartWorkflowExecution(ctx context.Context, request *h.SignalWithStartWorkflowExecutionRequest) (
 			*workflow.StartWorkflowExecutionResponse, error)
 		RemoveSignalMutableState(ctx context.Context, request *h.RemoveSignalMutableStateRequest) error
@@ -73,7 +76,11 @@ type (
 		ScheduleDecisionTask(ctx context.Context, request *h.ScheduleDecisionTaskRequest) error
 		RecordChildExecutionCompleted(ctx context.Context, request *h.RecordChildExecutionCompletedRequest) error
 		ReplicateEvents(ctx context.Context, request *h.ReplicateEventsRequest) error
+		ImportWorkflowExecution(ctx context.Context, request *h.ImportWorkflowExecutionRequest) error
 		SyncShardStatus(ctx context.Context, request *h.SyncShardStatusRequest) error
+		GetReplicationMessages(ctx context.Context, pollingCluster string, lastReadMessageID int64,
+			lastProcessedMessageID int64) (*r.ReplicationMessages, error)
+		DescribeReplicationStatus(ctx context.Context, domainID string, maxReadLevel int64) (*r.ShardReplicationStatus, error)
 	}
 
 	// EngineFactory is used to create an instance of sharded history engine
@@ -86,6 +93,12 @@ type (
 		notifyNewTask()
 	}
 
+	replicatorQueueProcessor interface {
+		queueProcessor
+		getTasks(pollingCluster string, lastReadTaskID int64, lastProcessedTaskID int64) (*r.ReplicationMessages, error)
+		getReplicationStatus(domainID string, maxReadLevel int64) (*r.ShardReplicationStatus, error)
+	}
+
 	queueAckMgr interface {
 		getFinishedChan() <-chan struct{}
 		readQueueTasks() ([]queueTaskInfo, bool, error)
@@ -113,6 +126,7 @@ type (
 		common.Daemon
 		FailoverDomain(domainID string)
 		NotifyNewTask(clusterName string, transferTasks []persistence.Task)
+		RefreshStandbyProcessors()
 	}
 
 	// TODO the timer queue processor and the one below, timer processor
@@ -122,6 +136,7 @@ type (
 		common.Daemon
 		FailoverDomain(domainID string)
 		NotifyNewTimers(clusterName string, currentTime time.Time, timerTask []persistence.Task)
+		RefreshStandbyProcessors()
 	}
 
 	timerProcessor interface {
@@ -156,6 +156,7 @@ func (s *engineSuite) SetupTest() {
 		executionManager:     s.mockExecutionMgr,
 		historyMgr:           s.mockHistoryMgr,
 		historyCache:         historyCache,
+		workflowUpdates:      newWorkflowUpdateRegistry(),
 		logger:               s.logger,
 		metricsClient:        metrics.NewClient(tally.NoopScope, metrics.History),
 		tokenSerializer:      common.NewJSONTaskTokenSerializer(),
@@ -222,6 +223,53 @@ func (s *engineSuite) TestGetMutableState_InvalidRunID() {
 	s.Equal(errRunIDNotValid, err)
 }
 
+func (s *engineSuite) TestGetMutableState_StandbyDomain() {
+	ctx := context.Background()
+	domainID := validDomainID
+	execution := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("test-get-workflow-execution-standby"),
+		RunId:      common.StringPtr(validRunID),
+	}
+	tasklist := "testTaskList"
+	identity := "testIdentity"
+
+	msBuilder := newMutableStateBuilderWithReplicationState(cluster.TestAlternativeClusterName, s.config,
+		bark.NewLoggerFromLogrus(log.New()), cluster.TestAlternativeClusterInitialFailoverVersion)
+	addWorkflowExecutionStartedEvent(msBuilder, execution, "wType", tasklist, []byte("input"), 100, 200, identity)
+	ms := createMutableState(msBuilder)
+	gweResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gweResponse, nil).Once()
+	s.mockMetadataMgr.On("GetDomain", mock.Anything).Return(
+		&persistence.GetDomainResponse{
+			Info:   &persistence.DomainInfo{ID: domainID, Name: "testDomain"},
+			Config: &persistence.DomainConfig{Retention: 1},
+			ReplicationConfig: &persistence.DomainReplicationConfig{
+				ActiveClusterName: cluster.TestAlternativeClusterName,
+				Clusters: []*persistence.ClusterReplicationConfig{
+					{ClusterName: cluster.TestCurrentClusterName},
+					{ClusterName: cluster.TestAlternativeClusterName},
+				},
+			},
+			IsGlobalDomain:  true,
+			FailoverVersion: cluster.TestAlternativeClusterInitialFailoverVersion,
+			TableVersion:    persistence.DomainTableVersionV1,
+		},
+		nil,
+	).Once()
+	shard := s.mockHistoryEngine.shard.(*shardContextWrapper).ShardContext.(*shardContextImpl)
+	shard.standbyClusterCurrentTime = map[string]time.Time{
+		cluster.TestAlternativeClusterName: time.Now().Add(-time.Minute),
+	}
+
+	response, err := s.mockHistoryEngine.GetMutableState(ctx, &history.GetMutableStateRequest{
+		DomainUUID: common.StringPtr(domainID),
+		Execution:  &execution,
+	})
+	s.Nil(err)
+	s.Equal(cluster.TestAlternativeClusterName, response.StaleReadInfo.GetActiveClusterName())
+	s.True(response.StaleReadInfo.GetReplicationLagInSeconds() >= 60)
+}
+
 func (s *engineSuite) TestGetMutableState_EmptyRunID() {
 	ctx := context.Background()
 	domainID := validDomainID
@@ -373,6 +421,73 @@ func (s *engineSuite) TestGetMutableStateLongPollTimeout() {
 	s.Equal(int64(4), *response.NextEventId)
 }
 
+func (s *engineSuite) TestGetMutableStateWaitForDecisionCompletion() {
+	ctx := context.Background()
+	domainID := validDomainID
+	execution := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("test-get-workflow-execution-event-id"),
+		RunId:      common.StringPtr(validRunID),
+	}
+	tasklist := "testTaskList"
+	identity := "testIdentity"
+
+	msBuilder := newMutableStateBuilder(s.mockClusterMetadata.GetCurrentClusterName(), s.config, bark.NewLoggerFromLogrus(log.New()))
+	addWorkflowExecutionStartedEvent(msBuilder, execution, "wType", tasklist, []byte("input"), 100, 200, identity)
+	di := addDecisionTaskScheduledEvent(msBuilder)
+	addDecisionTaskStartedEvent(msBuilder, di.ScheduleID, tasklist, identity)
+	ms := createMutableState(msBuilder)
+	gweResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+	// right now the next event ID is 4, and the decision is in flight
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gweResponse, nil).Once()
+	s.mockMetadataMgr.On("GetDomain", mock.Anything).Return(
+		&persistence.GetDomainResponse{
+			Info:   &persistence.DomainInfo{ID: domainID, Name: "testDomain"},
+			Config: &persistence.DomainConfig{Retention: 1},
+			ReplicationConfig: &persistence.DomainReplicationConfig{
+				ActiveClusterName: cluster.TestCurrentClusterName,
+				Clusters: []*persistence.ClusterReplicationConfig{
+					&persistence.ClusterReplicationConfig{ClusterName: cluster.TestCurrentClusterName},
+				},
+			},
+			TableVersion: persistence.DomainTableVersionV1,
+		},
+		nil,
+	)
+
+	asyncDecisionCompletion := func(delay time.Duration) {
+		taskToken, _ := json.Marshal(&common.TaskToken{
+			WorkflowID: *execution.WorkflowId,
+			RunID:      *execution.RunId,
+			ScheduleID: 2,
+		})
+		s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything).Return(&p.AppendHistoryEventsResponse{Size: 0}, nil).Once()
+		s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(&p.UpdateWorkflowExecutionResponse{MutableStateUpdateSessionStats: &p.MutableStateUpdateSessionStats{}}, nil).Once()
+
+		<-time.After(delay)
+		s.mockHistoryEngine.RespondDecisionTaskCompleted(context.Background(), &history.RespondDecisionTaskCompletedRequest{
+			DomainUUID: common.StringPtr(domainID),
+			CompleteRequest: &workflow.RespondDecisionTaskCompletedRequest{
+				TaskToken: taskToken,
+				Identity:  &identity,
+			},
+		})
+		// right now the next event ID is 5
+	}
+
+	go asyncDecisionCompletion(time.Second * 2)
+	start := time.Now()
+	response, err := s.mockHistoryEngine.GetMutableState(ctx, &history.GetMutableStateRequest{
+		DomainUUID:                common.StringPtr(domainID),
+		Execution:                 &execution,
+		WaitForDecisionCompletion: common.BoolPtr(true),
+	})
+	s.True(time.Now().After(start.Add(time.Second * 1)))
+	s.Nil(err)
+	s.Equal(int64(5), response.GetNextEventId())
+	s.True(response.GetIsWorkflowRunning())
+	s.Nil(response.CloseStatus)
+}
+
 func (s *engineSuite) TestRespondDecisionTaskCompletedInvalidToken() {
 	domainID := validDomainID
 	invalidToken, _ := json.Marshal("bad token")
@@ -4408,6 +4523,270 @@ func (s *engineSuite) TestValidateSignalExternalWorkflowExecutionAttributes() {
 	s.Nil(err)
 }
 
+func (s *engineSuite) TestUpdateWorkflowExecution_Accepted() {
+	domainID := validDomainID
+	we := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr(validRunID),
+	}
+	tl := "testTaskList"
+	identity := "testIdentity"
+	updateID := "update-request-id"
+
+	msBuilder := newMutableStateBuilder(s.mockClusterMetadata.GetCurrentClusterName(), s.config, bark.NewLoggerFromLogrus(log.New()))
+	addWorkflowExecutionStartedEvent(msBuilder, we, "wType", tl, []byte("input"), 100, 200, identity)
+	di := addDecisionTaskScheduledEvent(msBuilder)
+	ms := createMutableState(msBuilder)
+	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything).Return(&p.AppendHistoryEventsResponse{Size: 0}, nil)
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(&p.UpdateWorkflowExecutionResponse{MutableStateUpdateSessionStats: &p.MutableStateUpdateSessionStats{}}, nil)
+	s.mockMetadataMgr.On("GetDomain", mock.Anything).Return(
+		&persistence.GetDomainResponse{
+			Info:   &persistence.DomainInfo{ID: domainID},
+			Config: &persistence.DomainConfig{Retention: 1},
+			ReplicationConfig: &persistence.DomainReplicationConfig{
+				ActiveClusterName: cluster.TestCurrentClusterName,
+				Clusters: []*persistence.ClusterReplicationConfig{
+					&persistence.ClusterReplicationConfig{ClusterName: cluster.TestCurrentClusterName},
+				},
+			},
+			TableVersion: persistence.DomainTableVersionV1,
+		},
+		nil,
+	)
+
+	updateRequest := &history.UpdateWorkflowExecutionRequest{
+		DomainUUID: common.StringPtr(domainID),
+		UpdateRequest: &workflow.UpdateWorkflowExecutionRequest{
+			Domain:            common.StringPtr(domainID),
+			WorkflowExecution: &we,
+			UpdateName:        common.StringPtr("reserve-seat"),
+			Input:             []byte("seat-1"),
+			Identity:          common.StringPtr(identity),
+			RequestId:         common.StringPtr(updateID),
+		},
+	}
+	respCh := make(chan *workflow.UpdateWorkflowExecutionResponse, 1)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		resp, err := s.mockHistoryEngine.UpdateWorkflowExecution(ctx, updateRequest)
+		s.Nil(err)
+		respCh <- resp
+	}()
+	identifier := newWorkflowIdentifier(domainID, &we)
+	s.waitForWorkflowUpdate(identifier, updateID)
+
+	// the update is delivered with the pending decision
+	startedResp, err := s.mockHistoryEngine.RecordDecisionTaskStarted(context.Background(), &history.RecordDecisionTaskStartedRequest{
+		DomainUUID:        common.StringPtr(domainID),
+		WorkflowExecution: &we,
+		ScheduleId:        common.Int64Ptr(di.ScheduleID),
+		TaskId:            common.Int64Ptr(100),
+		RequestId:         common.StringPtr("reqId"),
+		PollRequest: &workflow.PollForDecisionTaskRequest{
+			TaskList: &workflow.TaskList{Name: common.StringPtr(tl)},
+			Identity: common.StringPtr(identity),
+		},
+	})
+	s.Nil(err)
+	s.Equal(1, len(startedResp.Updates))
+	s.Equal(updateID, startedResp.Updates[0].GetUpdateId())
+	s.Equal("reserve-seat", startedResp.Updates[0].GetUpdateName())
+	s.Equal([]byte("seat-1"), startedResp.Updates[0].Input)
+
+	taskToken, _ := json.Marshal(&common.TaskToken{
+		WorkflowID: *we.WorkflowId,
+		RunID:      *we.RunId,
+		ScheduleID: di.ScheduleID,
+	})
+	_, err = s.mockHistoryEngine.RespondDecisionTaskCompleted(context.Background(), &history.RespondDecisionTaskCompletedRequest{
+		DomainUUID: common.StringPtr(domainID),
+		CompleteRequest: &workflow.RespondDecisionTaskCompletedRequest{
+			TaskToken: taskToken,
+			Decisions: []*workflow.Decision{{
+				DecisionType: common.DecisionTypePtr(workflow.DecisionTypeRespondWorkflowUpdate),
+				RespondWorkflowUpdateDecisionAttributes: &workflow.RespondWorkflowUpdateDecisionAttributes{
+					UpdateId: common.StringPtr(updateID),
+					Accepted: common.BoolPtr(true),
+					Result:   []byte("reserved"),
+				},
+			}},
+			Identity: &identity,
+		},
+	})
+	s.Nil(err)
+
+	resp := <-respCh
+	s.Equal([]byte("reserved"), resp.Result)
+	s.Nil(resp.Rejected)
+
+	executionBuilder := s.getBuilder(domainID, we)
+	acceptedEventID, ok := executionBuilder.GetAcceptedUpdate(updateID)
+	s.True(ok)
+	s.False(executionBuilder.IsSignalRequested(updateID))
+	s.False(executionBuilder.HasPendingDecisionTask())
+
+	// a retried request gets the result of the accepted update
+	resp, err = s.mockHistoryEngine.UpdateWorkflowExecution(context.Background(), updateRequest)
+	s.Nil(err)
+	s.Equal([]byte("reserved"), resp.Result)
+
+	// once the result is no longer cached, for instance after the shard moved, it is read from the history
+	s.mockHistoryEngine.workflowUpdates = newWorkflowUpdateRegistry()
+	// the batch holding the accepted event ends after it, and is only read after the one following it
+	nextEventID := executionBuilder.GetNextEventID()
+	s.mockHistoryMgr.On("GetWorkflowExecutionHistory", mock.MatchedBy(func(request *p.GetWorkflowExecutionHistoryRequest) bool {
+		return request.Reverse && request.NextEventID == nextEventID && len(request.NextPageToken) == 0
+	})).Return(&p.GetWorkflowExecutionHistoryResponse{
+		History: &workflow.History{Events: []*workflow.HistoryEvent{{
+			EventId:   common.Int64Ptr(acceptedEventID + 2),
+			EventType: common.EventTypePtr(workflow.EventTypeDecisionTaskScheduled),
+		}}},
+		NextPageToken: []byte("token"),
+	}, nil).Once()
+	s.mockHistoryMgr.On("GetWorkflowExecutionHistory", mock.MatchedBy(func(request *p.GetWorkflowExecutionHistoryRequest) bool {
+		return request.Reverse && string(request.NextPageToken) == "token"
+	})).Return(&p.GetWorkflowExecutionHistoryResponse{History: &workflow.History{Events: []*workflow.HistoryEvent{
+		{
+			EventId:   common.Int64Ptr(acceptedEventID + 1),
+			EventType: common.EventTypePtr(workflow.EventTypeMarkerRecorded),
+		},
+		{
+			EventId:   common.Int64Ptr(acceptedEventID),
+			EventType: common.EventTypePtr(workflow.EventTypeWorkflowExecutionUpdateAccepted),
+			WorkflowExecutionUpdateAcceptedEventAttributes: &workflow.WorkflowExecutionUpdateAcceptedEventAttributes{
+				UpdateId: common.StringPtr(updateID),
+				Result:   []byte("reserved"),
+			},
+		},
+	}}}, nil).Once()
+	resp, err = s.mockHistoryEngine.UpdateWorkflowExecution(context.Background(), updateRequest)
+	s.Nil(err)
+	s.Equal([]byte("reserved"), resp.Result)
+}
+
+func (s *engineSuite) TestUpdateWorkflowExecution_NotHandled() {
+	domainID := validDomainID
+	we := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr(validRunID),
+	}
+	tl := "testTaskList"
+	identity := "testIdentity"
+	updateID := "update-request-id"
+
+	msBuilder := newMutableStateBuilder(s.mockClusterMetadata.GetCurrentClusterName(), s.config, bark.NewLoggerFromLogrus(log.New()))
+	addWorkflowExecutionStartedEvent(msBuilder, we, "wType", tl, []byte("input"), 100, 200, identity)
+	di := addDecisionTaskScheduledEvent(msBuilder)
+	addDecisionTaskStartedEvent(msBuilder, di.ScheduleID, tl, identity)
+	ms := createMutableState(msBuilder)
+	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything).Return(&p.AppendHistoryEventsResponse{Size: 0}, nil)
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(&p.UpdateWorkflowExecutionResponse{MutableStateUpdateSessionStats: &p.MutableStateUpdateSessionStats{}}, nil)
+	s.mockMetadataMgr.On("GetDomain", mock.Anything).Return(
+		&persistence.GetDomainResponse{
+			Info:   &persistence.DomainInfo{ID: domainID},
+			Config: &persistence.DomainConfig{Retention: 1},
+			ReplicationConfig: &persistence.DomainReplicationConfig{
+				ActiveClusterName: cluster.TestCurrentClusterName,
+				Clusters: []*persistence.ClusterReplicationConfig{
+					&persistence.ClusterReplicationConfig{ClusterName: cluster.TestCurrentClusterName},
+				},
+			},
+			TableVersion: persistence.DomainTableVersionV1,
+		},
+		nil,
+	)
+
+	respCh := make(chan *workflow.UpdateWorkflowExecutionResponse, 1)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		resp, err := s.mockHistoryEngine.UpdateWorkflowExecution(ctx, &history.UpdateWorkflowExecutionRequest{
+			DomainUUID: common.StringPtr(domainID),
+			UpdateRequest: &workflow.UpdateWorkflowExecutionRequest{
+				Domain:            common.StringPtr(domainID),
+				WorkflowExecution: &we,
+				UpdateName:        common.StringPtr("reserve-seat"),
+				RequestId:         common.StringPtr(updateID),
+			},
+		})
+		s.Nil(err)
+		respCh <- resp
+	}()
+	identifier := newWorkflowIdentifier(domainID, &we)
+	s.waitForWorkflowUpdate(identifier, updateID)
+
+	// the update arrived while the decision was in flight, so completing the decision schedules a new one
+	taskToken, _ := json.Marshal(&common.TaskToken{
+		WorkflowID: *we.WorkflowId,
+		RunID:      *we.RunId,
+		ScheduleID: di.ScheduleID,
+	})
+	_, err := s.mockHistoryEngine.RespondDecisionTaskCompleted(context.Background(), &history.RespondDecisionTaskCompletedRequest{
+		DomainUUID: common.StringPtr(domainID),
+		CompleteRequest: &workflow.RespondDecisionTaskCompletedRequest{
+			TaskToken: taskToken,
+			Identity:  &identity,
+		},
+	})
+	s.Nil(err)
+	executionBuilder := s.getBuilder(domainID, we)
+	s.True(executionBuilder.HasPendingDecisionTask())
+	newDecision, ok := executionBuilder.GetPendingDecision(executionBuilder.GetExecutionInfo().DecisionScheduleID)
+	s.True(ok)
+
+	startedResp, err := s.mockHistoryEngine.RecordDecisionTaskStarted(context.Background(), &history.RecordDecisionTaskStartedRequest{
+		DomainUUID:        common.StringPtr(domainID),
+		WorkflowExecution: &we,
+		ScheduleId:        common.Int64Ptr(newDecision.ScheduleID),
+		TaskId:            common.Int64Ptr(100),
+		RequestId:         common.StringPtr("reqId"),
+		PollRequest: &workflow.PollForDecisionTaskRequest{
+			TaskList: &workflow.TaskList{Name: common.StringPtr(tl)},
+			Identity: common.StringPtr(identity),
+		},
+	})
+	s.Nil(err)
+	s.Equal(1, len(startedResp.Updates))
+
+	// the decision the update is delivered with does not respond to it
+	taskToken, _ = json.Marshal(&common.TaskToken{
+		WorkflowID: *we.WorkflowId,
+		RunID:      *we.RunId,
+		ScheduleID: newDecision.ScheduleID,
+	})
+	_, err = s.mockHistoryEngine.RespondDecisionTaskCompleted(context.Background(), &history.RespondDecisionTaskCompletedRequest{
+		DomainUUID: common.StringPtr(domainID),
+		CompleteRequest: &workflow.RespondDecisionTaskCompletedRequest{
+			TaskToken: taskToken,
+			Identity:  &identity,
+		},
+	})
+	s.Nil(err)
+
+	resp := <-respCh
+	s.Nil(resp.Result)
+	s.NotNil(resp.Rejected)
+	_, accepted := s.getBuilder(domainID, we).GetAcceptedUpdate(updateID)
+	s.False(accepted)
+}
+
+func (s *engineSuite) waitForWorkflowUpdate(identifier workflowIdentifier, updateID string) {
+	for i := 0; i < 100; i++ {
+		if s.mockHistoryEngine.workflowUpdates.get(identifier, updateID) != nil {
+			return
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	s.Fail("workflow update is not registered")
+}
+
 func (s *engineSuite) getBuilder(domainID string, we workflow.WorkflowExecution) mutableState {
 	context, release, err := s.mockHistoryEngine.historyCache.getOrCreateWorkflowExecution(domainID, we)
 	if err != nil {
@@ -0,0 +1,389 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pborman/uuid"
+	"github.com/uber-common/bark"
+	h "github.com/uber/cadence/.gen/go/history"
+	"github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/backoff"
+	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/logging"
+	"github.com/uber/cadence/common/persistence"
+)
+
+type (
+	// historyImporter recreates a workflow run from the history batches exported from another cluster,
+	// the mutable state is rebuilt with the state builder and the tasks generated for all batches are written
+	// with the last batch, a run which fails to import is rolled back so the import can be retried. Until the
+	// last transaction the run is marked with importPendingRequestID, so a retry also rolls back a run whose
+	// import was interrupted by a crash.
+	historyImporter struct {
+		shard         ShardContext
+		historyEngine *historyEngineImpl
+		historyCache  *historyCache
+		domainCache   cache.DomainCache
+		visibilityMgr persistence.VisibilityManager
+		serializer    persistence.HistorySerializer
+		logger        bark.Logger
+	}
+)
+
+var (
+	errImportHistoryNotSet     = &shared.BadRequestError{Message: "HistoryBatches is not set on request."}
+	errImportMissingStartEvent = &shared.BadRequestError{Message: "The first imported event must be WorkflowExecutionStarted."}
+	errImportContinuedAsNew    = &shared.BadRequestError{Message: "Importing a run which continued as new is not supported."}
+	errImportGlobalDomain      = &shared.BadRequestError{Message: "Importing into a global domain is not supported."}
+	errImportWorkflowExists    = &shared.WorkflowExecutionAlreadyStartedError{Message: common.StringPtr("Workflow run to import already exists.")}
+)
+
+const (
+	// importPendingRequestID is the create request ID of a run until its import completes
+	importPendingRequestID = "9e3b1a5c-6f2d-4c8e-a7b0-1d4f5e6a7b8c"
+)
+
+func newHistoryImporter(shard ShardContext, historyEngine *historyEngineImpl, historyCache *historyCache,
+	visibilityMgr persistence.VisibilityManager, logger bark.Logger) *historyImporter {
+	return &historyImporter{
+		shard:         shard,
+		historyEngine: historyEngine,
+		historyCache:  historyCache,
+		domainCache:   shard.GetDomainCache(),
+		visibilityMgr: visibilityMgr,
+		serializer:    persistence.NewHistorySerializer(),
+		logger: logger.WithFields(bark.Fields{
+			logging.TagWorkflowComponent: logging.TagValueHistoryImporterComponent,
+		}),
+	}
+}
+
+func (i *historyImporter) importWorkflowExecution(ctx context.Context, request *h.ImportWorkflowExecutionRequest) (retError error) {
+	domainID, err := validateDomainUUID(request.DomainUUID)
+	if err != nil {
+		return err
+	}
+	domainEntry, err := i.domainCache.GetDomainByID(domainID)
+	if err != nil {
+		return err
+	}
+	// event versions of the exported history belong to the source cluster topology, so they are only
+	// meaningful without replication state
+	if domainEntry.IsGlobalDomain() {
+		return errImportGlobalDomain
+	}
+	batches, err := i.deserializeBatches(request.HistoryBatches)
+	if err != nil {
+		return err
+	}
+
+	execution := *request.Execution
+	context, release, err := i.historyCache.getOrCreateWorkflowExecutionWithTimeout(ctx, domainID, execution)
+	if err != nil {
+		return err
+	}
+	defer func() { release(retError) }()
+
+	logger := i.logger.WithFields(bark.Fields{
+		logging.TagDomainID:            domainID,
+		logging.TagWorkflowExecutionID: execution.GetWorkflowId(),
+		logging.TagWorkflowRunID:       execution.GetRunId(),
+	})
+
+	if msBuilder, err := context.loadWorkflowExecution(); err == nil {
+		if msBuilder.GetExecutionInfo().CreateRequestID != importPendingRequestID {
+			return errImportWorkflowExists
+		}
+		logger.Warn("Rolling back workflow run left over from an interrupted import.")
+		if err := i.rollbackImport(domainID, execution, logger); err != nil {
+			return err
+		}
+	} else if _, ok := err.(*shared.EntityNotExistsError); !ok {
+		return err
+	}
+	context.clear()
+
+	// the first batch creates the execution, the rest are applied on top of the persisted mutable state
+	currentCluster := i.shard.GetService().GetClusterMetadata().GetCurrentClusterName()
+	var msBuilder mutableState = newMutableStateBuilder(currentCluster, i.shard.GetConfig(), logger)
+	requestID := uuid.New()
+	sBuilder := newStateBuilder(i.shard, msBuilder, logger)
+	_, di, _, err := sBuilder.applyEvents(domainID, requestID, execution, &shared.History{Events: batches[0]}, nil)
+	if err != nil {
+		return err
+	}
+	// tasks are only written by the last transaction, so a run which fails to import never has its tasks processed
+	transferTasks := sBuilder.getTransferTasks()
+	timerTasks := sBuilder.getTimerTasks()
+	msBuilder.GetExecutionInfo().CreateRequestID = importPendingRequestID
+	if err := i.createWorkflow(msBuilder, di, execution, batches[0]); err != nil {
+		return err
+	}
+	defer func() {
+		if retError != nil {
+			i.rollbackImport(domainID, execution, logger)
+		}
+	}()
+
+	for _, events := range batches[1:] {
+		msBuilder, err = context.loadWorkflowExecution()
+		if err != nil {
+			return err
+		}
+		sBuilder := newStateBuilder(i.shard, msBuilder, logger)
+		if _, _, _, err := sBuilder.applyEvents(domainID, requestID, execution, &shared.History{Events: events},
+			nil); err != nil {
+			return err
+		}
+		transferTasks = append(transferTasks, sBuilder.getTransferTasks()...)
+		timerTasks = append(timerTasks, sBuilder.getTimerTasks()...)
+
+		transactionID, err := i.shard.GetNextTransferTaskID()
+		if err != nil {
+			return err
+		}
+		lastEvent := events[len(events)-1]
+		msBuilder.GetExecutionInfo().NextEventID = lastEvent.GetEventId() + 1
+		if err := context.updateHelper(nil, nil, transactionID, time.Unix(0, lastEvent.GetTimestamp()), false,
+			newHistoryBuilderFromEvents(events, logger), currentCluster); err != nil {
+			return err
+		}
+	}
+
+	// the last transaction restores the start time of the run, which visibility records are keyed by,
+	// writes the tasks of all batches and clears the pending import mark of the run
+	msBuilder, err = context.loadWorkflowExecution()
+	if err != nil {
+		return err
+	}
+	lastBatch := batches[len(batches)-1]
+	msBuilder.GetExecutionInfo().StartTimestamp = time.Unix(0, batches[0][0].GetTimestamp())
+	msBuilder.GetExecutionInfo().CreateRequestID = requestID
+	transactionID, err := i.shard.GetNextTransferTaskID()
+	if err != nil {
+		return err
+	}
+	if err := context.updateHelper(transferTasks, timerTasks, transactionID,
+		time.Unix(0, lastBatch[len(lastBatch)-1].GetTimestamp()), false, nil, currentCluster); err != nil {
+		return err
+	}
+
+	// a closed run is recorded as closed by its close execution transfer task
+	if msBuilder.IsWorkflowExecutionRunning() {
+		if err := i.recordWorkflowStarted(domainEntry, execution, msBuilder.GetExecutionInfo()); err != nil {
+			return err
+		}
+	}
+
+	i.historyEngine.txProcessor.NotifyNewTask(currentCluster, transferTasks)
+	i.historyEngine.timerProcessor.NotifyNewTimers(currentCluster, i.shard.GetCurrentTime(currentCluster), timerTasks)
+	logger.Infof("Imported workflow run with %v history batches.", len(batches))
+	return nil
+}
+
+func (i *historyImporter) deserializeBatches(blobs []*shared.DataBlob) ([][]*shared.HistoryEvent, error) {
+	var batches [][]*shared.HistoryEvent
+	expectedEventID := common.FirstEventID
+	for _, blob := range blobs {
+		encoding := common.EncodingType(common.EncodingTypeThriftRW)
+		if blob.GetEncodingType() == shared.EncodingTypeJSON {
+			encoding = common.EncodingTypeJSON
+		}
+		events, err := i.serializer.DeserializeBatchEvents(&persistence.DataBlob{Encoding: encoding, Data: blob.Data})
+		if err != nil {
+			return nil, &shared.BadRequestError{Message: fmt.Sprintf("Failed to deserialize history batch: %v", err)}
+		}
+		if len(events) == 0 {
+			continue
+		}
+
+		for _, event := range events {
+			if event.GetEventId() != expectedEventID {
+				return nil, &shared.BadRequestError{Message: fmt.Sprintf(
+					"Imported events are not contiguous, expected event %v but got %v.", expectedEventID, event.GetEventId())}
+			}
+			if event.GetEventType() == shared.EventTypeWorkflowExecutionContinuedAsNew {
+				return nil, errImportContinuedAsNew
+			}
+			expectedEventID++
+		}
+		batches = append(batches, events)
+	}
+
+	if len(batches) == 0 {
+		return nil, errImportHistoryNotSet
+	}
+	if batches[0][0].GetEventType() != shared.EventTypeWorkflowExecutionStarted {
+		return nil, errImportMissingStartEvent
+	}
+	return batches, nil
+}
+
+func (i *historyImporter) createWorkflow(msBuilder mutableState, di *decisionInfo, execution shared.WorkflowExecution,
+	events []*shared.HistoryEvent) error {
+	executionInfo := msBuilder.GetExecutionInfo()
+	firstEvent := events[0]
+	lastEvent := events[len(events)-1]
+
+	transactionID, err := i.shard.GetNextTransferTaskID()
+	if err != nil {
+		return err
+	}
+	historySize, err := i.shard.AppendHistoryEvents(&persistence.AppendHistoryEventsRequest{
+		DomainID:          executionInfo.DomainID,
+		Execution:         execution,
+		TransactionID:     transactionID,
+		FirstEventID:      firstEvent.GetEventId(),
+		EventBatchVersion: firstEvent.GetVersion(),
+		Events:            events,
+	})
+	if err != nil {
+		return err
+	}
+
+	executionInfo.LastFirstEventID = firstEvent.GetEventId()
+	executionInfo.NextEventID = lastEvent.GetEventId() + 1
+
+	var parentExecution *shared.WorkflowExecution
+	initiatedID := common.EmptyEventID
+	if executionInfo.ParentDomainID != "" {
+		initiatedID = executionInfo.InitiatedID
+		parentExecution = &shared.WorkflowExecution{
+			WorkflowId: common.StringPtr(executionInfo.ParentWorkflowID),
+			RunId:      common.StringPtr(executionInfo.ParentRunID),
+		}
+	}
+	decisionVersion := common.EmptyVersion
+	decisionScheduleID := common.EmptyEventID
+	decisionStartID := common.EmptyEventID
+	decisionTimeout := int32(0)
+	if di != nil {
+		decisionVersion = di.Version
+		decisionScheduleID = di.ScheduleID
+		decisionStartID = di.StartedID
+		decisionTimeout = di.DecisionTimeout
+	}
+
+	createRequest := &persistence.CreateWorkflowExecutionRequest{
+		RequestID:                   executionInfo.CreateRequestID,
+		DomainID:                    executionInfo.DomainID,
+		Execution:                   execution,
+		ParentDomainID:              executionInfo.ParentDomainID,
+		ParentExecution:             parentExecution,
+		InitiatedID:                 initiatedID,
+		TaskList:                    executionInfo.TaskList,
+		WorkflowTypeName:            executionInfo.WorkflowTypeName,
+		WorkflowTimeout:             executionInfo.WorkflowTimeout,
+		DecisionTimeoutValue:        executionInfo.DecisionTimeoutValue,
+		NextEventID:                 msBuilder.GetNextEventID(),
+		LastProcessedEvent:          common.EmptyEventID,
+		HistorySize:                 int64(historySize),
+		DecisionVersion:             decisionVersion,
+		DecisionScheduleID:          decisionScheduleID,
+		DecisionStartedID:           decisionStartID,
+		DecisionStartToCloseTimeout: decisionTimeout,
+		CreateWorkflowMode:          persistence.CreateWorkflowModeBrandNew,
+	}
+	_, err = i.shard.CreateWorkflowExecution(createRequest)
+	if errExist, ok := err.(*persistence.WorkflowExecutionAlreadyStartedError); ok && errExist.RunID == execution.GetRunId() {
+		// the run does not exist, so the current run record is left over from a roll back of an import of the same
+		// run which failed to delete it
+		createRequest.CreateWorkflowMode = persistence.CreateWorkflowModeContinueAsNew
+		createRequest.PreviousRunID = errExist.RunID
+		createRequest.PreviousLastWriteVersion = errExist.LastWriteVersion
+		_, err = i.shard.CreateWorkflowExecution(createRequest)
+	}
+	if err != nil {
+		// the run is not created, so the appended history is not referenced by any execution
+		i.shard.GetHistoryManager().DeleteWorkflowExecutionHistory(&persistence.DeleteWorkflowExecutionHistoryRequest{
+			DomainID:  executionInfo.DomainID,
+			Execution: execution,
+		})
+		if _, ok := err.(*persistence.WorkflowExecutionAlreadyStartedError); ok {
+			return errImportWorkflowExists
+		}
+	}
+	return err
+}
+
+// rollbackImport deletes a partially imported run, its current run record and its history. A brand new run is
+// imported only if the workflow has no current run, so deleting the current run record restores the workflow to
+// its state before the import.
+func (i *historyImporter) rollbackImport(domainID string, execution shared.WorkflowExecution, logger bark.Logger) error {
+	op := func() error {
+		return i.shard.GetExecutionManager().DeleteCurrentWorkflowExecution(&persistence.DeleteCurrentWorkflowExecutionRequest{
+			DomainID:   domainID,
+			WorkflowID: execution.GetWorkflowId(),
+			RunID:      execution.GetRunId(),
+		})
+	}
+	if err := backoff.Retry(op, persistenceOperationRetryPolicy, common.IsPersistenceTransientError); err != nil {
+		logger.WithField(logging.TagErr, err).Error("Failed to roll back current run record of imported workflow run.")
+		return err
+	}
+
+	op = func() error {
+		return i.shard.GetExecutionManager().DeleteWorkflowExecution(&persistence.DeleteWorkflowExecutionRequest{
+			DomainID:   domainID,
+			WorkflowID: execution.GetWorkflowId(),
+			RunID:      execution.GetRunId(),
+		})
+	}
+	if err := backoff.Retry(op, persistenceOperationRetryPolicy, common.IsPersistenceTransientError); err != nil {
+		logger.WithField(logging.TagErr, err).Error("Failed to roll back imported workflow run.")
+		return err
+	}
+
+	op = func() error {
+		return i.shard.GetHistoryManager().DeleteWorkflowExecutionHistory(&persistence.DeleteWorkflowExecutionHistoryRequest{
+			DomainID:  domainID,
+			Execution: execution,
+		})
+	}
+	if err := backoff.Retry(op, persistenceOperationRetryPolicy, common.IsPersistenceTransientError); err != nil {
+		logger.WithField(logging.TagErr, err).Error("Failed to roll back history of imported workflow run.")
+		return err
+	}
+	return nil
+}
+
+func (i *historyImporter) recordWorkflowStarted(domainEntry *cache.DomainCacheEntry, execution shared.WorkflowExecution,
+	executionInfo *persistence.WorkflowExecutionInfo) error {
+	// if sampled for longer retention is enabled, only record those sampled runs
+	wid := execution.GetWorkflowId()
+	if domainEntry.IsSampledForLongerRetentionEnabled(wid) && !domainEntry.IsSampledForLongerRetention(wid) {
+		return nil
+	}
+
+	return i.visibilityMgr.RecordWorkflowExecutionStarted(&persistence.RecordWorkflowExecutionStartedRequest{
+		DomainUUID:       executionInfo.DomainID,
+		Domain:           domainEntry.GetInfo().Name,
+		Execution:        execution,
+		WorkflowTypeName: executionInfo.WorkflowTypeName,
+		StartTimestamp:   executionInfo.StartTimestamp.UnixNano(),
+		WorkflowTimeout:  int64(executionInfo.WorkflowTimeout),
+	})
+}
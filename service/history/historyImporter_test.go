@@ -0,0 +1,436 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-common/bark"
+	"github.com/uber-go/tally"
+	h "github.com/uber/cadence/.gen/go/history"
+	"github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/cluster"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/mocks"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/service"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
+
+type (
+	historyImporterSuite struct {
+		suite.Suite
+		logger              bark.Logger
+		mockExecutionMgr    *mocks.ExecutionManager
+		mockHistoryMgr      *mocks.HistoryManager
+		mockShardManager    *mocks.ShardManager
+		mockClusterMetadata *mocks.ClusterMetadata
+		mockDomainCache     *cache.DomainCacheMock
+		mockVisibilityMgr   *mocks.VisibilityManager
+		mockTxProcessor     *MockTransferQueueProcessor
+		mockTimerProcessor  *MockTimerQueueProcessor
+		mockShard           *shardContextImpl
+		serializer          persistence.HistorySerializer
+		execution           shared.WorkflowExecution
+
+		importer *historyImporter
+	}
+)
+
+func TestHistoryImporterSuite(t *testing.T) {
+	s := new(historyImporterSuite)
+	suite.Run(t, s)
+}
+
+func (s *historyImporterSuite) SetupSuite() {
+	if testing.Verbose() {
+		log.SetOutput(os.Stdout)
+	}
+}
+
+func (s *historyImporterSuite) SetupTest() {
+	s.logger = bark.NewLoggerFromLogrus(log.New())
+	s.mockExecutionMgr = &mocks.ExecutionManager{}
+	s.mockHistoryMgr = &mocks.HistoryManager{}
+	s.mockShardManager = &mocks.ShardManager{}
+	s.mockClusterMetadata = &mocks.ClusterMetadata{}
+	s.mockDomainCache = &cache.DomainCacheMock{}
+	s.mockVisibilityMgr = &mocks.VisibilityManager{}
+	s.mockTxProcessor = &MockTransferQueueProcessor{}
+	s.mockTimerProcessor = &MockTimerQueueProcessor{}
+	metricsClient := metrics.NewClient(tally.NoopScope, metrics.History)
+	mockService := service.NewTestService(s.mockClusterMetadata, nil, metricsClient, s.logger)
+
+	s.mockShard = &shardContextImpl{
+		service:                   mockService,
+		shardInfo:                 &persistence.ShardInfo{ShardID: 0, RangeID: 1, TransferAckLevel: 0},
+		transferSequenceNumber:    1,
+		executionManager:          s.mockExecutionMgr,
+		shardManager:              s.mockShardManager,
+		historyMgr:                s.mockHistoryMgr,
+		maxTransferSequenceNumber: 100000,
+		closeCh:                   make(chan int, 100),
+		config:                    NewConfig(dynamicconfig.NewNopCollection(), 1),
+		logger:                    s.logger,
+		domainCache:               s.mockDomainCache,
+		metricsClient:             metricsClient,
+		standbyClusterCurrentTime: make(map[string]time.Time),
+	}
+	s.mockClusterMetadata.On("GetCurrentClusterName").Return(cluster.TestCurrentClusterName)
+	s.mockClusterMetadata.On("IsGlobalDomainEnabled").Return(false)
+	s.serializer = persistence.NewHistorySerializer()
+	s.execution = shared.WorkflowExecution{
+		WorkflowId: common.StringPtr("some random workflow ID"),
+		RunId:      common.StringPtr(validRunID),
+	}
+	historyCache := newHistoryCache(s.mockShard)
+	h := &historyEngineImpl{
+		currentClusterName: cluster.TestCurrentClusterName,
+		shard:              s.mockShard,
+		historyMgr:         s.mockHistoryMgr,
+		executionManager:   s.mockExecutionMgr,
+		historyCache:       historyCache,
+		logger:             s.logger,
+		metricsClient:      metricsClient,
+		txProcessor:        s.mockTxProcessor,
+		timerProcessor:     s.mockTimerProcessor,
+	}
+	s.importer = newHistoryImporter(s.mockShard, h, historyCache, s.mockVisibilityMgr, s.logger)
+}
+
+func (s *historyImporterSuite) TearDownTest() {
+	s.mockExecutionMgr.AssertExpectations(s.T())
+	s.mockHistoryMgr.AssertExpectations(s.T())
+	s.mockVisibilityMgr.AssertExpectations(s.T())
+	s.mockTxProcessor.AssertExpectations(s.T())
+	s.mockTimerProcessor.AssertExpectations(s.T())
+}
+
+func (s *historyImporterSuite) TestImportWorkflowExecution_SingleBatch() {
+	s.mockDomainCache.On("GetDomainByID", validDomainID).Return(s.localDomain(), nil)
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(nil, &shared.EntityNotExistsError{}).Once()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.MatchedBy(func(request *persistence.AppendHistoryEventsRequest) bool {
+		return request.FirstEventID == common.FirstEventID && len(request.Events) == 2
+	})).Return(&persistence.AppendHistoryEventsResponse{Size: 100}, nil).Once()
+	s.mockExecutionMgr.On("CreateWorkflowExecution", mock.MatchedBy(func(request *persistence.CreateWorkflowExecutionRequest) bool {
+		return request.Execution.GetRunId() == validRunID &&
+			request.TaskList == "some random tasklist" &&
+			request.NextEventID == 3 &&
+			request.DecisionScheduleID == 2 &&
+			request.HistorySize == 100 &&
+			request.ReplicationState == nil &&
+			len(request.TransferTasks) == 0 &&
+			len(request.TimerTasks) == 0 &&
+			request.RequestID == importPendingRequestID &&
+			request.CreateWorkflowMode == persistence.CreateWorkflowModeBrandNew
+	})).Return(&persistence.CreateWorkflowExecutionResponse{}, nil).Once()
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(
+		&persistence.GetWorkflowExecutionResponse{State: s.createdMutableState()}, nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.MatchedBy(func(request *persistence.UpdateWorkflowExecutionRequest) bool {
+		return request.ExecutionInfo.StartTimestamp.Equal(time.Unix(0, 1000)) &&
+			request.ExecutionInfo.CreateRequestID != importPendingRequestID &&
+			len(request.TransferTasks) == 1 &&
+			request.TransferTasks[0].GetType() == persistence.TransferTaskTypeDecisionTask &&
+			len(request.TimerTasks) == 1 &&
+			request.TimerTasks[0].GetType() == persistence.TaskTypeWorkflowTimeout
+	})).Return(&persistence.UpdateWorkflowExecutionResponse{MutableStateUpdateSessionStats: &persistence.MutableStateUpdateSessionStats{}}, nil).Once()
+	s.mockVisibilityMgr.On("RecordWorkflowExecutionStarted", mock.MatchedBy(func(request *persistence.RecordWorkflowExecutionStartedRequest) bool {
+		return request.Domain == "some random domain" && request.StartTimestamp == 1000
+	})).Return(nil).Once()
+	s.mockTxProcessor.On("NotifyNewTask", cluster.TestCurrentClusterName, mock.Anything).Once()
+	s.mockTimerProcessor.On("NotifyNewTimers", cluster.TestCurrentClusterName, mock.Anything, mock.Anything).Once()
+
+	err := s.importer.importWorkflowExecution(context.Background(), &h.ImportWorkflowExecutionRequest{
+		DomainUUID:     common.StringPtr(validDomainID),
+		Execution:      &s.execution,
+		HistoryBatches: []*shared.DataBlob{s.dataBlob(s.startedEvent(), s.decisionScheduledEvent(2))},
+	})
+	s.NoError(err)
+}
+
+func (s *historyImporterSuite) TestImportWorkflowExecution_MultipleBatches() {
+	s.mockDomainCache.On("GetDomainByID", validDomainID).Return(s.localDomain(), nil)
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(nil, &shared.EntityNotExistsError{}).Once()
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(
+		&persistence.GetWorkflowExecutionResponse{State: s.createdMutableState()}, nil).Once()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.MatchedBy(func(request *persistence.AppendHistoryEventsRequest) bool {
+		return request.FirstEventID == common.FirstEventID
+	})).Return(&persistence.AppendHistoryEventsResponse{Size: 100}, nil).Once()
+	s.mockExecutionMgr.On("CreateWorkflowExecution", mock.Anything).Return(&persistence.CreateWorkflowExecutionResponse{}, nil).Once()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.MatchedBy(func(request *persistence.AppendHistoryEventsRequest) bool {
+		return request.FirstEventID == 3 && len(request.Events) == 1
+	})).Return(&persistence.AppendHistoryEventsResponse{Size: 50}, nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.MatchedBy(func(request *persistence.UpdateWorkflowExecutionRequest) bool {
+		return request.ExecutionInfo.NextEventID == 4 &&
+			request.ExecutionInfo.DecisionStartedID == 3 &&
+			len(request.TransferTasks) == 0 &&
+			len(request.TimerTasks) == 0 &&
+			len(request.ReplicationTasks) == 0
+	})).Return(&persistence.UpdateWorkflowExecutionResponse{MutableStateUpdateSessionStats: &persistence.MutableStateUpdateSessionStats{}}, nil).Once()
+	// the tasks of both batches are written with the last transaction
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.MatchedBy(func(request *persistence.UpdateWorkflowExecutionRequest) bool {
+		return request.ExecutionInfo.NextEventID == 4 &&
+			request.ExecutionInfo.StartTimestamp.Equal(time.Unix(0, 1000)) &&
+			len(request.TransferTasks) == 1 &&
+			len(request.TimerTasks) == 2 &&
+			request.TimerTasks[1].GetType() == persistence.TaskTypeDecisionTimeout
+	})).Return(&persistence.UpdateWorkflowExecutionResponse{MutableStateUpdateSessionStats: &persistence.MutableStateUpdateSessionStats{}}, nil).Once()
+	s.mockVisibilityMgr.On("RecordWorkflowExecutionStarted", mock.Anything).Return(nil).Once()
+	s.mockTxProcessor.On("NotifyNewTask", cluster.TestCurrentClusterName, mock.Anything).Once()
+	s.mockTimerProcessor.On("NotifyNewTimers", cluster.TestCurrentClusterName, mock.Anything, mock.Anything).Once()
+
+	err := s.importer.importWorkflowExecution(context.Background(), &h.ImportWorkflowExecutionRequest{
+		DomainUUID: common.StringPtr(validDomainID),
+		Execution:  &s.execution,
+		HistoryBatches: []*shared.DataBlob{
+			s.dataBlob(s.startedEvent(), s.decisionScheduledEvent(2)),
+			s.dataBlob(s.decisionStartedEvent(3)),
+		},
+	})
+	s.NoError(err)
+}
+
+func (s *historyImporterSuite) TestImportWorkflowExecution_RollbackOnFailure() {
+	s.mockDomainCache.On("GetDomainByID", validDomainID).Return(s.localDomain(), nil)
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(nil, &shared.EntityNotExistsError{}).Once()
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(
+		&persistence.GetWorkflowExecutionResponse{State: s.createdMutableState()}, nil).Once()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything).Return(&persistence.AppendHistoryEventsResponse{Size: 100}, nil).Once()
+	s.mockExecutionMgr.On("CreateWorkflowExecution", mock.Anything).Return(&persistence.CreateWorkflowExecutionResponse{}, nil).Once()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything).Return(nil, &shared.BadRequestError{}).Once()
+	s.mockExecutionMgr.On("DeleteCurrentWorkflowExecution", &persistence.DeleteCurrentWorkflowExecutionRequest{
+		DomainID:   validDomainID,
+		WorkflowID: s.execution.GetWorkflowId(),
+		RunID:      s.execution.GetRunId(),
+	}).Return(nil).Once()
+	s.mockExecutionMgr.On("DeleteWorkflowExecution", &persistence.DeleteWorkflowExecutionRequest{
+		DomainID:   validDomainID,
+		WorkflowID: s.execution.GetWorkflowId(),
+		RunID:      s.execution.GetRunId(),
+	}).Return(nil).Once()
+	s.mockHistoryMgr.On("DeleteWorkflowExecutionHistory", &persistence.DeleteWorkflowExecutionHistoryRequest{
+		DomainID:  validDomainID,
+		Execution: s.execution,
+	}).Return(nil).Once()
+
+	err := s.importer.importWorkflowExecution(context.Background(), &h.ImportWorkflowExecutionRequest{
+		DomainUUID: common.StringPtr(validDomainID),
+		Execution:  &s.execution,
+		HistoryBatches: []*shared.DataBlob{
+			s.dataBlob(s.startedEvent(), s.decisionScheduledEvent(2)),
+			s.dataBlob(s.decisionStartedEvent(3)),
+		},
+	})
+	s.IsType(&shared.BadRequestError{}, err)
+}
+
+func (s *historyImporterSuite) TestImportWorkflowExecution_RetryAfterRollback() {
+	s.mockDomainCache.On("GetDomainByID", validDomainID).Return(s.localDomain(), nil)
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(nil, &shared.EntityNotExistsError{}).Once()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything).Return(&persistence.AppendHistoryEventsResponse{Size: 100}, nil).Once()
+	s.mockExecutionMgr.On("CreateWorkflowExecution", mock.MatchedBy(func(request *persistence.CreateWorkflowExecutionRequest) bool {
+		return request.CreateWorkflowMode == persistence.CreateWorkflowModeBrandNew
+	})).Return(nil, &persistence.WorkflowExecutionAlreadyStartedError{RunID: validRunID, LastWriteVersion: common.EmptyVersion}).Once()
+	s.mockExecutionMgr.On("CreateWorkflowExecution", mock.MatchedBy(func(request *persistence.CreateWorkflowExecutionRequest) bool {
+		return request.CreateWorkflowMode == persistence.CreateWorkflowModeContinueAsNew &&
+			request.PreviousRunID == validRunID
+	})).Return(&persistence.CreateWorkflowExecutionResponse{}, nil).Once()
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(
+		&persistence.GetWorkflowExecutionResponse{State: s.createdMutableState()}, nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(
+		&persistence.UpdateWorkflowExecutionResponse{MutableStateUpdateSessionStats: &persistence.MutableStateUpdateSessionStats{}}, nil).Once()
+	s.mockVisibilityMgr.On("RecordWorkflowExecutionStarted", mock.Anything).Return(nil).Once()
+	s.mockTxProcessor.On("NotifyNewTask", cluster.TestCurrentClusterName, mock.Anything).Once()
+	s.mockTimerProcessor.On("NotifyNewTimers", cluster.TestCurrentClusterName, mock.Anything, mock.Anything).Once()
+
+	err := s.importer.importWorkflowExecution(context.Background(), &h.ImportWorkflowExecutionRequest{
+		DomainUUID:     common.StringPtr(validDomainID),
+		Execution:      &s.execution,
+		HistoryBatches: []*shared.DataBlob{s.dataBlob(s.startedEvent(), s.decisionScheduledEvent(2))},
+	})
+	s.NoError(err)
+}
+
+func (s *historyImporterSuite) TestImportWorkflowExecution_RetryAfterInterruptedImport() {
+	s.mockDomainCache.On("GetDomainByID", validDomainID).Return(s.localDomain(), nil)
+	// the run left over by an import which crashed before its last transaction is rolled back and imported again
+	pendingState := s.createdMutableState()
+	pendingState.ExecutionInfo.CreateRequestID = importPendingRequestID
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(
+		&persistence.GetWorkflowExecutionResponse{State: pendingState}, nil).Once()
+	s.mockExecutionMgr.On("DeleteCurrentWorkflowExecution", &persistence.DeleteCurrentWorkflowExecutionRequest{
+		DomainID:   validDomainID,
+		WorkflowID: s.execution.GetWorkflowId(),
+		RunID:      s.execution.GetRunId(),
+	}).Return(nil).Once()
+	s.mockExecutionMgr.On("DeleteWorkflowExecution", &persistence.DeleteWorkflowExecutionRequest{
+		DomainID:   validDomainID,
+		WorkflowID: s.execution.GetWorkflowId(),
+		RunID:      s.execution.GetRunId(),
+	}).Return(nil).Once()
+	s.mockHistoryMgr.On("DeleteWorkflowExecutionHistory", &persistence.DeleteWorkflowExecutionHistoryRequest{
+		DomainID:  validDomainID,
+		Execution: s.execution,
+	}).Return(nil).Once()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything).Return(&persistence.AppendHistoryEventsResponse{Size: 100}, nil).Once()
+	s.mockExecutionMgr.On("CreateWorkflowExecution", mock.MatchedBy(func(request *persistence.CreateWorkflowExecutionRequest) bool {
+		return request.CreateWorkflowMode == persistence.CreateWorkflowModeBrandNew
+	})).Return(&persistence.CreateWorkflowExecutionResponse{}, nil).Once()
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(
+		&persistence.GetWorkflowExecutionResponse{State: s.createdMutableState()}, nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(
+		&persistence.UpdateWorkflowExecutionResponse{MutableStateUpdateSessionStats: &persistence.MutableStateUpdateSessionStats{}}, nil).Once()
+	s.mockVisibilityMgr.On("RecordWorkflowExecutionStarted", mock.Anything).Return(nil).Once()
+	s.mockTxProcessor.On("NotifyNewTask", cluster.TestCurrentClusterName, mock.Anything).Once()
+	s.mockTimerProcessor.On("NotifyNewTimers", cluster.TestCurrentClusterName, mock.Anything, mock.Anything).Once()
+
+	err := s.importer.importWorkflowExecution(context.Background(), &h.ImportWorkflowExecutionRequest{
+		DomainUUID:     common.StringPtr(validDomainID),
+		Execution:      &s.execution,
+		HistoryBatches: []*shared.DataBlob{s.dataBlob(s.startedEvent(), s.decisionScheduledEvent(2))},
+	})
+	s.NoError(err)
+}
+
+func (s *historyImporterSuite) TestImportWorkflowExecution_AlreadyExists() {
+	s.mockDomainCache.On("GetDomainByID", validDomainID).Return(s.localDomain(), nil)
+	msBuilder := newMutableStateBuilder(cluster.TestCurrentClusterName, s.mockShard.GetConfig(), s.logger)
+	addWorkflowExecutionStartedEvent(msBuilder, s.execution, "wType", "some random tasklist", nil, 100, 10, "identity")
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(
+		&persistence.GetWorkflowExecutionResponse{State: createMutableState(msBuilder)}, nil).Once()
+
+	err := s.importer.importWorkflowExecution(context.Background(), &h.ImportWorkflowExecutionRequest{
+		DomainUUID:     common.StringPtr(validDomainID),
+		Execution:      &s.execution,
+		HistoryBatches: []*shared.DataBlob{s.dataBlob(s.startedEvent())},
+	})
+	s.Equal(errImportWorkflowExists, err)
+}
+
+func (s *historyImporterSuite) TestImportWorkflowExecution_GlobalDomain() {
+	s.mockDomainCache.On("GetDomainByID", validDomainID).Return(cache.NewGlobalDomainCacheEntryForTest(
+		&persistence.DomainInfo{ID: validDomainID},
+		&persistence.DomainReplicationConfig{ActiveClusterName: cluster.TestCurrentClusterName},
+		cluster.GetTestClusterMetadata(true, true),
+	), nil)
+
+	err := s.importer.importWorkflowExecution(context.Background(), &h.ImportWorkflowExecutionRequest{
+		DomainUUID:     common.StringPtr(validDomainID),
+		Execution:      &s.execution,
+		HistoryBatches: []*shared.DataBlob{s.dataBlob(s.startedEvent())},
+	})
+	s.Equal(errImportGlobalDomain, err)
+}
+
+func (s *historyImporterSuite) TestDeserializeBatches() {
+	batches, err := s.importer.deserializeBatches([]*shared.DataBlob{
+		s.dataBlob(s.startedEvent(), s.decisionScheduledEvent(2)),
+		s.dataBlob(s.decisionScheduledEvent(3)),
+	})
+	s.NoError(err)
+	s.Len(batches, 2)
+
+	_, err = s.importer.deserializeBatches(nil)
+	s.Equal(errImportHistoryNotSet, err)
+
+	_, err = s.importer.deserializeBatches([]*shared.DataBlob{s.dataBlob(s.decisionScheduledEvent(1))})
+	s.Equal(errImportMissingStartEvent, err)
+
+	_, err = s.importer.deserializeBatches([]*shared.DataBlob{
+		s.dataBlob(s.startedEvent()),
+		s.dataBlob(s.decisionScheduledEvent(3)),
+	})
+	s.IsType(&shared.BadRequestError{}, err)
+
+	_, err = s.importer.deserializeBatches([]*shared.DataBlob{
+		s.dataBlob(s.startedEvent(), &shared.HistoryEvent{
+			EventId:   common.Int64Ptr(2),
+			EventType: shared.EventTypeWorkflowExecutionContinuedAsNew.Ptr(),
+		}),
+	})
+	s.Equal(errImportContinuedAsNew, err)
+}
+
+func (s *historyImporterSuite) createdMutableState() *persistence.WorkflowMutableState {
+	msBuilder := newMutableStateBuilder(cluster.TestCurrentClusterName, s.mockShard.GetConfig(), s.logger)
+	addWorkflowExecutionStartedEvent(msBuilder, s.execution, "wType", "some random tasklist", nil, 100, 10, "identity")
+	addDecisionTaskScheduledEvent(msBuilder)
+	return createMutableState(msBuilder)
+}
+
+func (s *historyImporterSuite) localDomain() *cache.DomainCacheEntry {
+	return cache.NewDomainCacheEntryWithInfo(&persistence.DomainInfo{ID: validDomainID, Name: "some random domain"})
+}
+
+func (s *historyImporterSuite) startedEvent() *shared.HistoryEvent {
+	return &shared.HistoryEvent{
+		EventId:   common.Int64Ptr(common.FirstEventID),
+		Timestamp: common.Int64Ptr(1000),
+		EventType: shared.EventTypeWorkflowExecutionStarted.Ptr(),
+		WorkflowExecutionStartedEventAttributes: &shared.WorkflowExecutionStartedEventAttributes{
+			WorkflowType:                        &shared.WorkflowType{Name: common.StringPtr("wType")},
+			TaskList:                            &shared.TaskList{Name: common.StringPtr("some random tasklist")},
+			ExecutionStartToCloseTimeoutSeconds: common.Int32Ptr(100),
+			TaskStartToCloseTimeoutSeconds:      common.Int32Ptr(10),
+		},
+	}
+}
+
+func (s *historyImporterSuite) decisionScheduledEvent(eventID int64) *shared.HistoryEvent {
+	return &shared.HistoryEvent{
+		EventId:   common.Int64Ptr(eventID),
+		Timestamp: common.Int64Ptr(1000),
+		EventType: shared.EventTypeDecisionTaskScheduled.Ptr(),
+		DecisionTaskScheduledEventAttributes: &shared.DecisionTaskScheduledEventAttributes{
+			TaskList:                   &shared.TaskList{Name: common.StringPtr("some random tasklist")},
+			StartToCloseTimeoutSeconds: common.Int32Ptr(10),
+		},
+	}
+}
+
+func (s *historyImporterSuite) decisionStartedEvent(eventID int64) *shared.HistoryEvent {
+	return &shared.HistoryEvent{
+		EventId:   common.Int64Ptr(eventID),
+		Timestamp: common.Int64Ptr(2000),
+		EventType: shared.EventTypeDecisionTaskStarted.Ptr(),
+		DecisionTaskStartedEventAttributes: &shared.DecisionTaskStartedEventAttributes{
+			ScheduledEventId: common.Int64Ptr(2),
+			RequestId:        common.StringPtr("some random request ID"),
+		},
+	}
+}
+
+func (s *historyImporterSuite) dataBlob(events ...*shared.HistoryEvent) *shared.DataBlob {
+	blob, err := s.serializer.SerializeBatchEvents(events, common.EncodingTypeThriftRW)
+	s.NoError(err)
+	return &shared.DataBlob{
+		EncodingType: shared.EncodingTypeThriftRW.Ptr(),
+		Data:         blob.Data,
+	}
+}
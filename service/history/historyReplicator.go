@@ -143,7 +143,8 @@ func (r *historyReplicator) ApplyEvents(ctx context.Context, request *h.Replicat
 			switch retError.(type) {
 			case *shared.EntityNotExistsError:
 				logger.Debugf("Encounter EntityNotExistsError: %v", retError)
-				retError = ErrRetryEntityNotExists
+				retError = newRetryTaskErrorWithHint(ErrRetryEntityNotExists.Message, request.GetDomainUUID(),
+					request.WorkflowExecution.GetWorkflowId(), request.WorkflowExecution.GetRunId(), common.FirstEventID)
 			case *shared.WorkflowExecutionAlreadyStartedError:
 				logger.Debugf("Encounter WorkflowExecutionAlreadyStartedError: %v", retError)
 				retError = ErrRetryExecutionAlreadyStarted
@@ -175,7 +176,7 @@ func (r *historyReplicator) ApplyEvents(ctx context.Context, request *h.Replicat
 		return err
 	}
 	defer func() {
-		if retError == ErrRetryBufferEvents {
+		if isRetryBufferEventsError(retError) {
 			release(nil)
 		} else {
 			release(retError)
@@ -210,7 +211,7 @@ func (r *historyReplicator) ApplyEvents(ctx context.Context, request *h.Replicat
 			// we need to check the existing workflow ID
 			release(err)
 			return r.ApplyOtherEventsMissingMutableState(ctx, domainID, request.WorkflowExecution.GetWorkflowId(),
-				firstEvent.GetVersion(), logger)
+				request.WorkflowExecution.GetRunId(), firstEvent.GetVersion(), logger)
 		}
 
 		logger.WithField(logging.TagCurrentVersion, msBuilder.GetReplicationState().LastWriteVersion)
@@ -236,7 +237,7 @@ func (r *historyReplicator) ApplyStartEvent(ctx context.Context, context *workfl
 }
 
 func (r *historyReplicator) ApplyOtherEventsMissingMutableState(ctx context.Context, domainID string, workflowID string,
-	incomingVersion int64, logger bark.Logger) error {
+	runID string, incomingVersion int64, logger bark.Logger) error {
 	// we need to check the current workflow execution
 	_, currentMutableState, currentRelease, err := r.getCurrentWorkflowMutableState(ctx, domainID, workflowID)
 	if err != nil {
@@ -261,7 +262,7 @@ func (r *historyReplicator) ApplyOtherEventsMissingMutableState(ctx context.Cont
 	if err != nil {
 		return err
 	}
-	return ErrRetryEntityNotExists
+	return newRetryTaskErrorWithHint(ErrRetryEntityNotExists.Message, domainID, workflowID, runID, common.FirstEventID)
 }
 
 func (r *historyReplicator) ApplyOtherEventsVersionChecking(ctx context.Context, context *workflowExecutionContext,
@@ -290,7 +291,10 @@ func (r *historyReplicator) ApplyOtherEventsVersionChecking(ctx context.Context,
 	// for multiple data center cases, wait for #840
 
 	// Check if this is the first event after failover
-	previousActiveCluster := r.clusterMetadata.ClusterNameForFailoverVersion(rState.LastWriteVersion)
+	previousActiveCluster, err := r.clusterMetadata.ClusterNameForFailoverVersion(rState.LastWriteVersion)
+	if err != nil {
+		return nil, err
+	}
 	logger.WithFields(bark.Fields{
 		logging.TagPrevActiveCluster: previousActiveCluster,
 		logging.TagReplicationInfo:   request.ReplicationInfo,
@@ -390,7 +394,8 @@ func (r *historyReplicator) ApplyOtherEvents(ctx context.Context, context *workf
 			msBuilder.GetNextEventID(), firstEventID)
 
 		if !request.GetForceBufferEvents() {
-			return ErrRetryBufferEvents
+			return newRetryTaskErrorWithHint(ErrRetryBufferEvents.Message, request.GetDomainUUID(),
+				request.WorkflowExecution.GetWorkflowId(), request.WorkflowExecution.GetRunId(), msBuilder.GetNextEventID())
 		}
 
 		r.metricsClient.RecordTimer(
@@ -530,7 +535,10 @@ func (r *historyReplicator) FlushBuffer(ctx context.Context, context *workflowEx
 			return r.updateBufferedReplicationTask(context, msBuilder)
 		}
 
-		sourceCluster := r.clusterMetadata.ClusterNameForFailoverVersion(bt.Version)
+		sourceCluster, err := r.clusterMetadata.ClusterNameForFailoverVersion(bt.Version)
+		if err != nil {
+			return err
+		}
 		req := &h.ReplicateEventsRequest{
 			SourceCluster:     common.StringPtr(sourceCluster),
 			DomainUUID:        common.StringPtr(domainID),
@@ -842,7 +850,10 @@ func (r *historyReplicator) terminateWorkflow(ctx context.Context, domainID stri
 	}
 
 	nextEventID := msBuilder.GetNextEventID()
-	sourceCluster := r.clusterMetadata.ClusterNameForFailoverVersion(incomingVersion)
+	sourceCluster, err := r.clusterMetadata.ClusterNameForFailoverVersion(incomingVersion)
+	if err != nil {
+		return err
+	}
 	terminationEvent := &shared.HistoryEvent{
 		EventId:   common.Int64Ptr(nextEventID),
 		Timestamp: common.Int64Ptr(incomingTimestamp),
@@ -869,7 +880,7 @@ func (r *historyReplicator) terminateWorkflow(ctx context.Context, domainID stri
 	return r.ApplyReplicationTask(ctx, context, msBuilder, req, logger)
 }
 
-func (r *historyReplicator) getLatestCheckpoint(replicationInfoRemote map[string]*h.ReplicationInfo,
+func (r *historyReplicator) getLatestCheckpoint(replicationInfoRemote map[string]*shared.ReplicationInfo,
 	replicationInfoLocal map[string]*persistence.ReplicationInfo) (int64, int64) {
 
 	// this only applies to 2 data center case
@@ -925,7 +936,10 @@ func (r *historyReplicator) updateBufferedReplicationTask(context *workflowExecu
 	// since this is a persisting the buffer replication task,
 	// so nothing on the replication state should be changed
 	lastWriteVersion := msBuilder.GetLastWriteVersion()
-	sourceCluster := r.clusterMetadata.ClusterNameForFailoverVersion(lastWriteVersion)
+	sourceCluster, err := r.clusterMetadata.ClusterNameForFailoverVersion(lastWriteVersion)
+	if err != nil {
+		return err
+	}
 	return context.updateHelper(nil, nil, transactionID, time.Time{}, false, nil, sourceCluster)
 }
 
@@ -942,3 +956,21 @@ func logError(logger bark.Logger, msg string, err error) {
 		logging.TagErr: err,
 	}).Error(msg)
 }
+
+// newRetryTaskErrorWithHint returns a RetryTaskError carrying the next event ID expected for the workflow run,
+// so the caller can re-replicate the missing events from the source cluster
+func newRetryTaskErrorWithHint(msg string, domainID string, workflowID string, runID string,
+	nextEventID int64) *shared.RetryTaskError {
+	return &shared.RetryTaskError{
+		Message:     msg,
+		DomainId:    common.StringPtr(domainID),
+		WorkflowId:  common.StringPtr(workflowID),
+		RunId:       common.StringPtr(runID),
+		NextEventId: common.Int64Ptr(nextEventID),
+	}
+}
+
+func isRetryBufferEventsError(err error) bool {
+	retryErr, ok := err.(*shared.RetryTaskError)
+	return ok && retryErr.Message == ErrRetryBufferEvents.Message
+}
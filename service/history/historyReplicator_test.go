@@ -145,6 +145,7 @@ func (s *historyReplicatorSuite) TestApplyOtherEventsMissingMutableState_Incomin
 	domainName := "some random domain name"
 	domainID := validDomainID
 	workflowID := "some random workflow ID"
+	runID := uuid.New()
 	version := int64(123)
 	currentRunID := uuid.New()
 	currentVersion := version - 100
@@ -184,15 +185,16 @@ func (s *historyReplicatorSuite) TestApplyOtherEventsMissingMutableState_Incomin
 		},
 	}, nil)
 
-	err := s.historyReplicator.ApplyOtherEventsMissingMutableState(ctx.Background(), domainID, workflowID, version,
+	err := s.historyReplicator.ApplyOtherEventsMissingMutableState(ctx.Background(), domainID, workflowID, runID, version,
 		s.logger)
-	s.Equal(ErrRetryEntityNotExists, err)
+	s.Equal(newRetryTaskErrorWithHint(ErrRetryEntityNotExists.Message, domainID, workflowID, runID, common.FirstEventID), err)
 }
 
 func (s *historyReplicatorSuite) TestApplyOtherEventsMissingMutableState_IncomingLessThanCurrent() {
 	domainName := "some random domain name"
 	domainID := validDomainID
 	workflowID := "some random workflow ID"
+	runID := uuid.New()
 	version := int64(123)
 	currentRunID := uuid.New()
 	currentVersion := version + 100
@@ -232,7 +234,7 @@ func (s *historyReplicatorSuite) TestApplyOtherEventsMissingMutableState_Incomin
 		},
 	}, nil)
 
-	err := s.historyReplicator.ApplyOtherEventsMissingMutableState(ctx.Background(), domainID, workflowID, version,
+	err := s.historyReplicator.ApplyOtherEventsMissingMutableState(ctx.Background(), domainID, workflowID, runID, version,
 		s.logger)
 	s.Nil(err)
 }
@@ -315,7 +317,7 @@ func (s *historyReplicatorSuite) TestApplyOtherEventsVersionChecking_IncomingGre
 	msBuilderIn.On("GetReplicationState").Return(&persistence.ReplicationState{
 		LastWriteVersion: currentLastWriteVersion,
 	})
-	s.mockClusterMetadata.On("ClusterNameForFailoverVersion", currentLastWriteVersion).Return(prevActiveCluster)
+	s.mockClusterMetadata.On("ClusterNameForFailoverVersion", currentLastWriteVersion).Return(prevActiveCluster, nil)
 	s.mockClusterMetadata.On("IsVersionFromSameCluster", incomingVersion, currentLastWriteVersion).Return(true)
 
 	msBuilderOut, err := s.historyReplicator.ApplyOtherEventsVersionChecking(ctx.Background(), context, msBuilderIn,
@@ -349,7 +351,7 @@ func (s *historyReplicatorSuite) TestApplyOtherEventsVersionChecking_IncomingGre
 	msBuilderIn.On("GetReplicationState").Return(&persistence.ReplicationState{
 		LastWriteVersion: currentLastWriteVersion,
 	})
-	s.mockClusterMetadata.On("ClusterNameForFailoverVersion", currentLastWriteVersion).Return(prevActiveCluster)
+	s.mockClusterMetadata.On("ClusterNameForFailoverVersion", currentLastWriteVersion).Return(prevActiveCluster, nil)
 	s.mockClusterMetadata.On("IsVersionFromSameCluster", incomingVersion, currentLastWriteVersion).Return(false)
 
 	msBuilderOut, err := s.historyReplicator.ApplyOtherEventsVersionChecking(ctx.Background(), context, msBuilderIn,
@@ -380,7 +382,7 @@ func (s *historyReplicatorSuite) TestApplyOtherEventsVersionChecking_IncomingGre
 
 	request := &h.ReplicateEventsRequest{
 		Version:         common.Int64Ptr(incomingVersion),
-		ReplicationInfo: map[string]*h.ReplicationInfo{},
+		ReplicationInfo: map[string]*shared.ReplicationInfo{},
 		History: &shared.History{Events: []*shared.HistoryEvent{
 			&shared.HistoryEvent{Timestamp: common.Int64Ptr(time.Now().UnixNano())},
 		}},
@@ -401,7 +403,7 @@ func (s *historyReplicatorSuite) TestApplyOtherEventsVersionChecking_IncomingGre
 		RunID:          runID,
 	})
 	msBuilderIn.On("IsWorkflowExecutionRunning").Return(true)
-	s.mockClusterMetadata.On("ClusterNameForFailoverVersion", currentLastWriteVersion).Return(prevActiveCluster)
+	s.mockClusterMetadata.On("ClusterNameForFailoverVersion", currentLastWriteVersion).Return(prevActiveCluster, nil)
 
 	mockConflictResolver := &mockConflictResolver{}
 	s.historyReplicator.getNewConflictResolver = func(context *workflowExecutionContext, logger bark.Logger) conflictResolver {
@@ -439,8 +441,8 @@ func (s *historyReplicatorSuite) TestApplyOtherEventsVersionChecking_IncomingGre
 
 	request := &h.ReplicateEventsRequest{
 		Version: common.Int64Ptr(incomingVersion),
-		ReplicationInfo: map[string]*h.ReplicationInfo{
-			prevActiveCluster: &h.ReplicationInfo{
+		ReplicationInfo: map[string]*shared.ReplicationInfo{
+			prevActiveCluster: &shared.ReplicationInfo{
 				Version:     common.Int64Ptr(incomingReplicationInfoLastWriteVersion),
 				LastEventId: common.Int64Ptr(incomingReplicationInfoLastEventID),
 			},
@@ -465,7 +467,7 @@ func (s *historyReplicatorSuite) TestApplyOtherEventsVersionChecking_IncomingGre
 		RunID:          runID,
 	})
 	msBuilderIn.On("IsWorkflowExecutionRunning").Return(true)
-	s.mockClusterMetadata.On("ClusterNameForFailoverVersion", currentLastWriteVersion).Return(prevActiveCluster)
+	s.mockClusterMetadata.On("ClusterNameForFailoverVersion", currentLastWriteVersion).Return(prevActiveCluster, nil)
 
 	mockConflictResolver := &mockConflictResolver{}
 	s.historyReplicator.getNewConflictResolver = func(context *workflowExecutionContext, logger bark.Logger) conflictResolver {
@@ -500,8 +502,8 @@ func (s *historyReplicatorSuite) TestApplyOtherEventsVersionChecking_IncomingGre
 
 	request := &h.ReplicateEventsRequest{
 		Version: common.Int64Ptr(incomingVersion),
-		ReplicationInfo: map[string]*h.ReplicationInfo{
-			prevActiveCluster: &h.ReplicationInfo{
+		ReplicationInfo: map[string]*shared.ReplicationInfo{
+			prevActiveCluster: &shared.ReplicationInfo{
 				Version:     common.Int64Ptr(incomingReplicationInfoLastWriteVersion),
 				LastEventId: common.Int64Ptr(incomingReplicationInfoLastEventID),
 			},
@@ -517,7 +519,7 @@ func (s *historyReplicatorSuite) TestApplyOtherEventsVersionChecking_IncomingGre
 	})
 	msBuilderIn.On("GetExecutionInfo").Return(&persistence.WorkflowExecutionInfo{StartTimestamp: startTimeStamp})
 	msBuilderIn.On("IsWorkflowExecutionRunning").Return(true)
-	s.mockClusterMetadata.On("ClusterNameForFailoverVersion", currentLastWriteVersion).Return(prevActiveCluster)
+	s.mockClusterMetadata.On("ClusterNameForFailoverVersion", currentLastWriteVersion).Return(prevActiveCluster, nil)
 
 	msBuilderOut, err := s.historyReplicator.ApplyOtherEventsVersionChecking(ctx.Background(), context, msBuilderIn, request, s.logger)
 	s.Nil(msBuilderOut)
@@ -545,8 +547,8 @@ func (s *historyReplicatorSuite) TestApplyOtherEventsVersionChecking_IncomingGre
 
 	request := &h.ReplicateEventsRequest{
 		Version: common.Int64Ptr(incomingVersion),
-		ReplicationInfo: map[string]*h.ReplicationInfo{
-			prevActiveCluster: &h.ReplicationInfo{
+		ReplicationInfo: map[string]*shared.ReplicationInfo{
+			prevActiveCluster: &shared.ReplicationInfo{
 				Version:     common.Int64Ptr(incomingReplicationInfoLastWriteVersion),
 				LastEventId: common.Int64Ptr(incomingReplicationInfoLastEventID),
 			},
@@ -565,7 +567,7 @@ func (s *historyReplicatorSuite) TestApplyOtherEventsVersionChecking_IncomingGre
 		RunID:          runID,
 	})
 	msBuilderIn.On("IsWorkflowExecutionRunning").Return(true)
-	s.mockClusterMetadata.On("ClusterNameForFailoverVersion", currentLastWriteVersion).Return(prevActiveCluster)
+	s.mockClusterMetadata.On("ClusterNameForFailoverVersion", currentLastWriteVersion).Return(prevActiveCluster, nil)
 
 	mockConflictResolver := &mockConflictResolver{}
 	s.historyReplicator.getNewConflictResolver = func(context *workflowExecutionContext, logger bark.Logger) conflictResolver {
@@ -600,8 +602,8 @@ func (s *historyReplicatorSuite) TestApplyOtherEventsVersionChecking_IncomingGre
 
 	request := &h.ReplicateEventsRequest{
 		Version: common.Int64Ptr(incomingVersion),
-		ReplicationInfo: map[string]*h.ReplicationInfo{
-			prevActiveCluster: &h.ReplicationInfo{
+		ReplicationInfo: map[string]*shared.ReplicationInfo{
+			prevActiveCluster: &shared.ReplicationInfo{
 				Version:     common.Int64Ptr(incomingReplicationInfoLastWriteVersion),
 				LastEventId: common.Int64Ptr(incomingReplicationInfoLastEventID),
 			},
@@ -617,7 +619,7 @@ func (s *historyReplicatorSuite) TestApplyOtherEventsVersionChecking_IncomingGre
 	})
 	msBuilderIn.On("GetExecutionInfo").Return(&persistence.WorkflowExecutionInfo{StartTimestamp: startTimeStamp})
 	msBuilderIn.On("IsWorkflowExecutionRunning").Return(true)
-	s.mockClusterMetadata.On("ClusterNameForFailoverVersion", currentLastWriteVersion).Return(prevActiveCluster)
+	s.mockClusterMetadata.On("ClusterNameForFailoverVersion", currentLastWriteVersion).Return(prevActiveCluster, nil)
 
 	msBuilderOut, err := s.historyReplicator.ApplyOtherEventsVersionChecking(ctx.Background(), context, msBuilderIn, request, s.logger)
 	s.Nil(msBuilderOut)
@@ -649,8 +651,8 @@ func (s *historyReplicatorSuite) TestApplyOtherEventsVersionChecking_IncomingGre
 
 	request := &h.ReplicateEventsRequest{
 		Version: common.Int64Ptr(incomingVersion),
-		ReplicationInfo: map[string]*h.ReplicationInfo{
-			prevActiveCluster: &h.ReplicationInfo{
+		ReplicationInfo: map[string]*shared.ReplicationInfo{
+			prevActiveCluster: &shared.ReplicationInfo{
 				Version:     common.Int64Ptr(incomingReplicationInfoLastWriteVersion),
 				LastEventId: common.Int64Ptr(incomingReplicationInfoLastEventID),
 			},
@@ -666,7 +668,7 @@ func (s *historyReplicatorSuite) TestApplyOtherEventsVersionChecking_IncomingGre
 		LastWriteEventID: currentLastEventID,
 	})
 	msBuilderIn.On("GetExecutionInfo").Return(&persistence.WorkflowExecutionInfo{StartTimestamp: startTimeStamp})
-	s.mockClusterMetadata.On("ClusterNameForFailoverVersion", currentLastWriteVersion).Return(prevActiveCluster)
+	s.mockClusterMetadata.On("ClusterNameForFailoverVersion", currentLastWriteVersion).Return(prevActiveCluster, nil)
 
 	msBuilderOut, err := s.historyReplicator.ApplyOtherEventsVersionChecking(ctx.Background(), context, msBuilderIn,
 		request, s.logger)
@@ -695,8 +697,8 @@ func (s *historyReplicatorSuite) TestApplyOtherEventsVersionChecking_IncomingGre
 
 	request := &h.ReplicateEventsRequest{
 		Version: common.Int64Ptr(incomingVersion),
-		ReplicationInfo: map[string]*h.ReplicationInfo{
-			prevActiveCluster: &h.ReplicationInfo{
+		ReplicationInfo: map[string]*shared.ReplicationInfo{
+			prevActiveCluster: &shared.ReplicationInfo{
 				Version:     common.Int64Ptr(incomingReplicationInfoLastWriteVersion),
 				LastEventId: common.Int64Ptr(incomingReplicationInfoLastEventID),
 			},
@@ -716,7 +718,7 @@ func (s *historyReplicatorSuite) TestApplyOtherEventsVersionChecking_IncomingGre
 		RunID:          runID,
 	})
 	msBuilderIn.On("IsWorkflowExecutionRunning").Return(true)
-	s.mockClusterMetadata.On("ClusterNameForFailoverVersion", currentLastWriteVersion).Return(prevActiveCluster)
+	s.mockClusterMetadata.On("ClusterNameForFailoverVersion", currentLastWriteVersion).Return(prevActiveCluster, nil)
 
 	mockConflictResolver := &mockConflictResolver{}
 	s.historyReplicator.getNewConflictResolver = func(context *workflowExecutionContext, logger bark.Logger) conflictResolver {
@@ -784,17 +786,23 @@ func (s *historyReplicatorSuite) TestApplyOtherEvents_IncomingGreaterThanCurrent
 
 	request := &h.ReplicateEventsRequest{
 		SourceCluster: common.StringPtr(incomingSourceCluster),
-		Version:       common.Int64Ptr(incomingVersion),
-		FirstEventId:  common.Int64Ptr(incomingFirstEventID),
-		NextEventId:   common.Int64Ptr(incomingNextEventID),
-		History:       &shared.History{},
+		DomainUUID:    common.StringPtr(domainID),
+		WorkflowExecution: &shared.WorkflowExecution{
+			WorkflowId: common.StringPtr(workflowID),
+			RunId:      common.StringPtr(runID),
+		},
+		Version:      common.Int64Ptr(incomingVersion),
+		FirstEventId: common.Int64Ptr(incomingFirstEventID),
+		NextEventId:  common.Int64Ptr(incomingNextEventID),
+		History:      &shared.History{},
 	}
 
 	msBuilder.On("GetNextEventID").Return(currentNextEventID)
 	msBuilder.On("IsWorkflowExecutionRunning").Return(true)
 
 	err := s.historyReplicator.ApplyOtherEvents(ctx.Background(), context, msBuilder, request, s.logger)
-	s.Equal(ErrRetryBufferEvents, err)
+	s.Equal(newRetryTaskErrorWithHint(ErrRetryBufferEvents.Message, domainID, workflowID, runID, currentNextEventID), err)
+	s.True(isRetryBufferEventsError(err))
 }
 
 func (s *historyReplicatorSuite) TestApplyOtherEvents_IncomingGreaterThanCurrent_ForceBuffer_NoExistingBuffer() {
@@ -847,7 +855,7 @@ func (s *historyReplicatorSuite) TestApplyOtherEvents_IncomingGreaterThanCurrent
 		LastWriteEventID: currentNextEventID - 1,
 	}
 
-	s.mockClusterMetadata.On("ClusterNameForFailoverVersion", currentVersion).Return(currentSourceCluster)
+	s.mockClusterMetadata.On("ClusterNameForFailoverVersion", currentVersion).Return(currentSourceCluster, nil)
 	msBuilder.On("GetBufferedReplicationTask", incomingFirstEventID).Return(nil, false).Once()
 	msBuilder.On("GetCurrentVersion").Return(currentVersion)
 	msBuilder.On("GetLastWriteVersion").Return(currentVersion)
@@ -961,7 +969,7 @@ func (s *historyReplicatorSuite) TestApplyOtherEvents_IncomingGreaterThanCurrent
 		LastWriteEventID: currentNextEventID - 1,
 	}
 
-	s.mockClusterMetadata.On("ClusterNameForFailoverVersion", currentVersion).Return(currentSourceCluster)
+	s.mockClusterMetadata.On("ClusterNameForFailoverVersion", currentVersion).Return(currentSourceCluster, nil)
 	msBuilder.On("GetBufferedReplicationTask", incomingFirstEventID).Return(nil, false).Once()
 	msBuilder.On("GetCurrentVersion").Return(currentVersion)
 	msBuilder.On("GetLastWriteVersion").Return(currentVersion)
@@ -1041,7 +1049,7 @@ func (s *historyReplicatorSuite) TestApplyOtherEvents_IncomingGreaterThanCurrent
 		Version:      request.GetVersion() - 1,
 	}
 
-	s.mockClusterMetadata.On("ClusterNameForFailoverVersion", currentVersion).Return(currentSourceCluster)
+	s.mockClusterMetadata.On("ClusterNameForFailoverVersion", currentVersion).Return(currentSourceCluster, nil)
 	msBuilder.On("GetBufferedReplicationTask", incomingFirstEventID).Return(staleBufferReplicationTask, true).Once()
 	msBuilder.On("GetCurrentVersion").Return(currentVersion)
 	msBuilder.On("GetLastWriteVersion").Return(currentVersion)
@@ -2376,7 +2384,7 @@ func (s *historyReplicatorSuite) TestConflictResolutionTerminateCurrentRunningIf
 	incomingVersion := int64(4096)
 	incomingTimestamp := int64(11238)
 	incomingCluster := cluster.TestAlternativeClusterName
-	s.mockClusterMetadata.On("ClusterNameForFailoverVersion", incomingVersion).Return(incomingCluster)
+	s.mockClusterMetadata.On("ClusterNameForFailoverVersion", incomingVersion).Return(incomingCluster, nil)
 
 	domainVersion := int64(4081)
 	domainName := "some random domain name"
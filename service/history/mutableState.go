@@ -51,6 +51,7 @@ type (
 		AddActivityTaskScheduledEvent(int64, *workflow.ScheduleActivityTaskDecisionAttributes) (*workflow.HistoryEvent, *persistence.ActivityInfo)
 		AddActivityTaskStartedEvent(*persistence.ActivityInfo, int64, string, string) *workflow.HistoryEvent
 		AddActivityTaskTimedOutEvent(int64, int64, workflow.TimeoutType, []uint8) *workflow.HistoryEvent
+		AddActivityTaskRedirectedEvent(int64, string, workflow.TimeoutType) *workflow.HistoryEvent
 		AddCancelTimerFailedEvent(int64, *workflow.CancelTimerDecisionAttributes, string) *workflow.HistoryEvent
 		AddChildWorkflowExecutionCanceledEvent(int64, *workflow.WorkflowExecution, *workflow.WorkflowExecutionCanceledEventAttributes) *workflow.HistoryEvent
 		AddChildWorkflowExecutionCompletedEvent(int64, *workflow.WorkflowExecution, *workflow.WorkflowExecutionCompletedEventAttributes) *workflow.HistoryEvent
@@ -88,6 +89,7 @@ type (
 		AddWorkflowExecutionStartedEvent(workflow.WorkflowExecution, *h.StartWorkflowExecutionRequest) *workflow.HistoryEvent
 		AddWorkflowExecutionStartedEventForContinueAsNew(string, *h.ParentExecutionInfo, workflow.WorkflowExecution, mutableState, *workflow.ContinueAsNewWorkflowExecutionDecisionAttributes) *workflow.HistoryEvent
 		AddWorkflowExecutionTerminatedEvent(*workflow.TerminateWorkflowExecutionRequest) *workflow.HistoryEvent
+		AddWorkflowExecutionUpdateAcceptedEvent(int64, *workflow.WorkflowUpdate, *workflow.RespondWorkflowUpdateDecisionAttributes) *workflow.HistoryEvent
 		AfterAddDecisionTaskCompletedEvent(int64)
 		BeforeAddDecisionTaskCompletedEvent()
 		BufferReplicationTask(*h.ReplicateEventsRequest) error
@@ -148,6 +150,7 @@ type (
 		GetHistorySize() int64
 		IsCancelRequested() (bool, string)
 		IsSignalRequested(requestID string) bool
+		GetAcceptedUpdate(updateID string) (int64, bool)
 		IsStickyTaskListEnabled() bool
 		IsWorkflowExecutionRunning() bool
 		Load(*persistence.WorkflowMutableState)
@@ -158,6 +161,7 @@ type (
 		ReplicateActivityTaskScheduledEvent(*workflow.HistoryEvent) *persistence.ActivityInfo
 		ReplicateActivityTaskStartedEvent(*workflow.HistoryEvent)
 		ReplicateActivityTaskTimedOutEvent(*workflow.HistoryEvent) error
+		ReplicateActivityTaskRedirectedEvent(*workflow.HistoryEvent) error
 		ReplicateChildWorkflowExecutionCanceledEvent(*workflow.HistoryEvent)
 		ReplicateChildWorkflowExecutionCompletedEvent(*workflow.HistoryEvent)
 		ReplicateChildWorkflowExecutionFailedEvent(*workflow.HistoryEvent)
@@ -188,6 +192,7 @@ type (
 		ReplicateWorkflowExecutionStartedEvent(string, *string, workflow.WorkflowExecution, string, *workflow.WorkflowExecutionStartedEventAttributes)
 		ReplicateWorkflowExecutionTerminatedEvent(*workflow.HistoryEvent)
 		ReplicateWorkflowExecutionTimedoutEvent(*workflow.HistoryEvent)
+		ReplicateWorkflowExecutionUpdateAcceptedEvent(*workflow.HistoryEvent)
 		ResetSnapshot(string) *persistence.ResetMutableStateRequest
 		SetHistoryBuilder(hBuilder *historyBuilder)
 		SetNewRunSize(size int)
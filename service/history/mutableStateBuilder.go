@@ -67,6 +67,10 @@ type (
 		updateSignalRequestedIDs  map[string]struct{} // Set of signaled requestIds since last update
 		deleteSignalRequestedID   string              // Deleted signaled requestId
 
+		pendingAcceptedUpdates map[string]int64 // Update ID -> event ID of its UpdateAccepted event
+		updateAcceptedUpdates  map[string]int64 // Accepted updates since last update
+		deleteAcceptedUpdates  []string         // Evicted accepted updates since last update
+
 		bufferedEvents       []*workflow.HistoryEvent // buffered history events that are already persisted
 		updateBufferedEvents []*workflow.HistoryEvent // buffered history events that needs to be persisted
 		clearBufferedEvents  bool                     // delete buffered events from persistence
@@ -113,6 +117,9 @@ func newMutableStateBuilder(currentCluster string, config *Config, logger bark.L
 		pendingSignalRequestedIDs: make(map[string]struct{}),
 		deleteSignalRequestedID:   "",
 
+		pendingAcceptedUpdates: make(map[string]int64),
+		updateAcceptedUpdates:  make(map[string]int64),
+
 		currentCluster: currentCluster,
 		config:         config,
 		logger:         logger,
@@ -149,6 +156,7 @@ func (e *mutableStateBuilder) CopyToPersistence() *persistence.WorkflowMutableSt
 	state.RequestCancelInfos = e.pendingRequestCancelInfoIDs
 	state.SignalInfos = e.pendingSignalInfoIDs
 	state.SignalRequestedIDs = e.pendingSignalRequestedIDs
+	state.AcceptedUpdates = e.pendingAcceptedUpdates
 	state.ExecutionInfo = e.executionInfo
 	state.ReplicationState = e.replicationState
 	state.BufferedEvents = e.bufferedEvents
@@ -164,6 +172,7 @@ func (e *mutableStateBuilder) Load(state *persistence.WorkflowMutableState) {
 	e.pendingRequestCancelInfoIDs = state.RequestCancelInfos
 	e.pendingSignalInfoIDs = state.SignalInfos
 	e.pendingSignalRequestedIDs = state.SignalRequestedIDs
+	e.pendingAcceptedUpdates = state.AcceptedUpdates
 	e.executionInfo = state.ExecutionInfo
 
 	e.replicationState = state.ReplicationState
@@ -247,6 +256,7 @@ func (e *mutableStateBuilder) ResetSnapshot(prevRunID string) *persistence.Reset
 		InsertRequestCancelInfos:  insertRequestCancels,
 		InsertSignalInfos:         insertSignals,
 		InsertSignalRequestedIDs:  insertSignalRequested,
+		InsertAcceptedUpdates:     e.pendingAcceptedUpdates,
 	}
 }
 
@@ -319,6 +329,9 @@ func (e *mutableStateBuilder) GetLastWriteVersion() int64 {
 }
 
 func (e *mutableStateBuilder) UpdateReplicationStateVersion(version int64, forceUpdate bool) {
+	if e.replicationState == nil {
+		return
+	}
 	if version > e.replicationState.CurrentVersion || forceUpdate {
 		e.replicationState.CurrentVersion = version
 	}
@@ -327,6 +340,11 @@ func (e *mutableStateBuilder) UpdateReplicationStateVersion(version int64, force
 // Assumption: It is expected CurrentVersion on replication state is updated at the start of transaction when
 // mutableState is loaded for this workflow execution.
 func (e *mutableStateBuilder) UpdateReplicationStateLastEventID(clusterName string, lastWriteVersion, lastEventID int64) {
+	// the version history is only recorded when it covers the history from the first event
+	if len(e.replicationState.VersionHistory) > 0 || e.replicationState.LastWriteEventID == common.EmptyEventID {
+		e.replicationState.VersionHistory = persistence.UpdateVersionHistory(e.replicationState.VersionHistory,
+			lastWriteVersion, lastEventID)
+	}
 	e.replicationState.LastWriteVersion = lastWriteVersion
 	// TODO: Rename this to NextEventID to stay consistent naming convention with rest of code base
 	e.replicationState.LastWriteEventID = lastEventID
@@ -364,6 +382,8 @@ func (e *mutableStateBuilder) CloseUpdateSession() (*mutableStateSessionUpdates,
 		deleteSignalInfo:                 e.deleteSignalInfo,
 		updateSignalRequestedIDs:         convertSignalRequestedIDs(e.updateSignalRequestedIDs),
 		deleteSignalRequestedID:          e.deleteSignalRequestedID,
+		updateAcceptedUpdates:            e.updateAcceptedUpdates,
+		deleteAcceptedUpdates:            e.deleteAcceptedUpdates,
 		continueAsNew:                    e.continueAsNew,
 		newBufferedEvents:                e.updateBufferedEvents,
 		clearBufferedEvents:              e.clearBufferedEvents,
@@ -385,6 +405,8 @@ func (e *mutableStateBuilder) CloseUpdateSession() (*mutableStateSessionUpdates,
 	e.deleteSignalInfo = nil
 	e.updateSignalRequestedIDs = make(map[string]struct{})
 	e.deleteSignalRequestedID = ""
+	e.updateAcceptedUpdates = make(map[string]int64)
+	e.deleteAcceptedUpdates = nil
 	e.continueAsNew = nil
 	e.clearBufferedEvents = false
 	if e.updateBufferedEvents != nil {
@@ -647,6 +669,7 @@ func (e *mutableStateBuilder) shouldBufferEvent(eventType workflow.EventType) bo
 		workflow.EventTypeCancelTimerFailed,
 		workflow.EventTypeRequestCancelExternalWorkflowExecutionInitiated,
 		workflow.EventTypeMarkerRecorded,
+		workflow.EventTypeWorkflowExecutionUpdateAccepted,
 		workflow.EventTypeStartChildWorkflowExecutionInitiated,
 		workflow.EventTypeSignalExternalWorkflowExecutionInitiated:
 		// do not buffer event if event is directly generated from a corresponding decision
@@ -1040,6 +1063,14 @@ func (e *mutableStateBuilder) DeleteSignalRequested(requestID string) {
 	e.deleteSignalRequestedID = requestID
 }
 
+// GetAcceptedUpdate returns the event ID of the WorkflowExecutionUpdateAccepted event of the update, false if
+// the update was not accepted in this run. Only the last history.maximumAcceptedUpdates updates accepted by the
+// run are kept, so a retried update is deduplicated as long as fewer updates were accepted after it.
+func (e *mutableStateBuilder) GetAcceptedUpdate(updateID string) (int64, bool) {
+	eventID, ok := e.pendingAcceptedUpdates[updateID]
+	return eventID, ok
+}
+
 func (e *mutableStateBuilder) AddWorkflowExecutionStartedEventForContinueAsNew(domainID string,
 	parentExecutionInfo *h.ParentExecutionInfo, execution workflow.WorkflowExecution, previousExecutionState mutableState,
 	attributes *workflow.ContinueAsNewWorkflowExecutionDecisionAttributes) *workflow.HistoryEvent {
@@ -1572,6 +1603,43 @@ func (e *mutableStateBuilder) ReplicateActivityTaskTimedOutEvent(event *workflow
 	return e.DeleteActivity(scheduleID)
 }
 
+func (e *mutableStateBuilder) AddActivityTaskRedirectedEvent(scheduleEventID int64, taskList string,
+	timeoutType workflow.TimeoutType) *workflow.HistoryEvent {
+	ai, ok := e.GetActivityInfo(scheduleEventID)
+	if !ok || ai.StartedID != common.EmptyEventID {
+		logging.LogInvalidHistoryActionEvent(e.logger, logging.TagValueActionActivityTaskRedirected, e.GetNextEventID(), fmt.Sprintf(
+			"{ScheduleID: %v, TaskList: %v, TimeOutType: %v, Exist: %v}", scheduleEventID, taskList, timeoutType, ok))
+		return nil
+	}
+
+	event := e.hBuilder.AddActivityTaskRedirectedEvent(scheduleEventID, ai.TaskList, taskList, timeoutType)
+	if err := e.ReplicateActivityTaskRedirectedEvent(event); err != nil {
+		return nil
+	}
+
+	return event
+}
+
+func (e *mutableStateBuilder) ReplicateActivityTaskRedirectedEvent(event *workflow.HistoryEvent) error {
+	attributes := event.ActivityTaskRedirectedEventAttributes
+	scheduleID := attributes.GetScheduledEventId()
+	ai, ok := e.GetActivityInfo(scheduleID)
+	if !ok {
+		errorMsg := fmt.Sprintf("Unable to find activity with schedule event id: %v in mutable state", scheduleID)
+		logging.LogMutableStateInvalidAction(e.logger, errorMsg)
+		return errors.NewInternalFailureError(errorMsg)
+	}
+
+	// the activity is scheduled anew on the fallback task list, which restarts its schedule to start timeout
+	// from the redirect time while the schedule to close timeout still counts from the original schedule time
+	ai.TaskList = attributes.TaskList.GetName()
+	ai.RedirectedTime = time.Unix(0, event.GetTimestamp())
+	ai.TimerTaskStatus = ai.TimerTaskStatus &^ TimerTaskStatusCreatedScheduleToStart
+	e.updateActivityInfos[ai] = struct{}{}
+
+	return nil
+}
+
 func (e *mutableStateBuilder) AddActivityTaskCancelRequestedEvent(decisionCompletedEventID int64,
 	activityID, identity string) (*workflow.HistoryEvent, *persistence.ActivityInfo, bool) {
 	actCancelReqEvent := e.hBuilder.AddActivityTaskCancelRequestedEvent(decisionCompletedEventID, activityID)
@@ -2017,6 +2085,57 @@ func (e *mutableStateBuilder) AddWorkflowExecutionSignaled(
 	return e.hBuilder.AddWorkflowExecutionSignaledEvent(request)
 }
 
+func (e *mutableStateBuilder) AddWorkflowExecutionUpdateAcceptedEvent(decisionCompletedEventID int64,
+	update *workflow.WorkflowUpdate, attributes *workflow.RespondWorkflowUpdateDecisionAttributes) *workflow.HistoryEvent {
+	if e.executionInfo.State == persistence.WorkflowStateCompleted {
+		logging.LogInvalidHistoryActionEvent(e.logger, logging.TagValueActionWorkflowUpdateAccepted, e.GetNextEventID(), fmt.Sprintf(
+			"{State: %v}", e.executionInfo.State))
+		return nil
+	}
+
+	event := e.hBuilder.AddWorkflowExecutionUpdateAcceptedEvent(decisionCompletedEventID, update, attributes)
+	e.ReplicateWorkflowExecutionUpdateAcceptedEvent(event)
+
+	return event
+}
+
+func (e *mutableStateBuilder) ReplicateWorkflowExecutionUpdateAcceptedEvent(event *workflow.HistoryEvent) {
+	// accepted updates are kept in mutable state so that retried updates are deduplicated across history hosts
+	// and clusters, their results are read back from the event
+	if e.pendingAcceptedUpdates == nil {
+		e.pendingAcceptedUpdates = make(map[string]int64)
+	}
+	if e.updateAcceptedUpdates == nil {
+		e.updateAcceptedUpdates = make(map[string]int64)
+	}
+	updateID := event.WorkflowExecutionUpdateAcceptedEventAttributes.GetUpdateId()
+	e.pendingAcceptedUpdates[updateID] = event.GetEventId()
+	e.updateAcceptedUpdates[updateID] = event.GetEventId()
+	e.evictAcceptedUpdates()
+}
+
+// evictAcceptedUpdates drops the updates accepted first once the run keeps more than the maximum number of
+// accepted updates, retries of the evicted updates are no longer deduplicated
+func (e *mutableStateBuilder) evictAcceptedUpdates() {
+	maxAcceptedUpdates := e.config.MaximumAcceptedUpdates()
+	if maxAcceptedUpdates < 1 {
+		maxAcceptedUpdates = 1
+	}
+	for len(e.pendingAcceptedUpdates) > maxAcceptedUpdates {
+		oldestUpdateID := ""
+		oldestEventID := int64(0)
+		for updateID, eventID := range e.pendingAcceptedUpdates {
+			if oldestUpdateID == "" || eventID < oldestEventID {
+				oldestUpdateID = updateID
+				oldestEventID = eventID
+			}
+		}
+		delete(e.pendingAcceptedUpdates, oldestUpdateID)
+		delete(e.updateAcceptedUpdates, oldestUpdateID)
+		e.deleteAcceptedUpdates = append(e.deleteAcceptedUpdates, oldestUpdateID)
+	}
+}
+
 func (e *mutableStateBuilder) AddContinueAsNewEvent(decisionCompletedEventID int64, domainEntry *cache.DomainCacheEntry,
 	parentDomainName string, attributes *workflow.ContinueAsNewWorkflowExecutionDecisionAttributes) (*workflow.HistoryEvent, mutableState,
 	error) {
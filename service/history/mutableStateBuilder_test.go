@@ -28,7 +28,9 @@ import (
 	"github.com/stretchr/testify/suite"
 	"github.com/uber-common/bark"
 	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/cluster"
+	"github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/service/dynamicconfig"
 )
 
@@ -99,6 +101,7 @@ func (s *mutableStateSuite) TestShouldBufferEvent() {
 		workflow.EventTypeCancelTimerFailed:                               true,
 		workflow.EventTypeRequestCancelExternalWorkflowExecutionInitiated: true,
 		workflow.EventTypeMarkerRecorded:                                  true,
+		workflow.EventTypeWorkflowExecutionUpdateAccepted:                 true,
 		workflow.EventTypeStartChildWorkflowExecutionInitiated:            true,
 		workflow.EventTypeSignalExternalWorkflowExecutionInitiated:        true,
 	}
@@ -139,3 +142,68 @@ OtherEventsLoop:
 	s.Equal(len(workflow.DecisionType_Values())+1, len(decisionEvents),
 		"This assertaion will be broken a new decision is added and no corresponding logic added to shouldBufferEvent()")
 }
+
+func (s *mutableStateSuite) TestUpdateReplicationStateVersionHistory() {
+	msBuilder := newMutableStateBuilderWithReplicationState(cluster.TestCurrentClusterName,
+		NewConfig(dynamicconfig.NewNopCollection(), 1), s.logger, 10)
+	msBuilder.UpdateReplicationStateLastEventID(cluster.TestCurrentClusterName, 10, 3)
+	msBuilder.UpdateReplicationStateLastEventID(cluster.TestCurrentClusterName, 10, 5)
+	msBuilder.UpdateReplicationStateLastEventID(cluster.TestAlternativeClusterName, 11, 8)
+	s.Equal([]*persistence.ReplicationInfo{
+		{Version: 10, LastEventID: 5},
+		{Version: 11, LastEventID: 8},
+	}, msBuilder.GetReplicationState().VersionHistory)
+
+	version, ok := persistence.GetVersionForEventID(msBuilder.GetReplicationState().VersionHistory, 5)
+	s.True(ok)
+	s.Equal(int64(10), version)
+	version, ok = persistence.GetVersionForEventID(msBuilder.GetReplicationState().VersionHistory, 6)
+	s.True(ok)
+	s.Equal(int64(11), version)
+	_, ok = persistence.GetVersionForEventID(msBuilder.GetReplicationState().VersionHistory, 9)
+	s.False(ok)
+
+	// the version history is not started for the executions written before it was recorded
+	msBuilder.GetReplicationState().VersionHistory = nil
+	msBuilder.UpdateReplicationStateLastEventID(cluster.TestCurrentClusterName, 12, 10)
+	s.Empty(msBuilder.GetReplicationState().VersionHistory)
+}
+
+func (s *mutableStateSuite) TestAcceptedUpdatesEviction() {
+	config := NewConfig(dynamicconfig.NewNopCollection(), 1)
+	config.MaximumAcceptedUpdates = dynamicconfig.GetIntPropertyFn(2)
+	msBuilder := newMutableStateBuilder(cluster.TestCurrentClusterName, config, s.logger)
+	msBuilder.Load(&persistence.WorkflowMutableState{
+		ExecutionInfo:   &persistence.WorkflowExecutionInfo{},
+		AcceptedUpdates: map[string]int64{"update-1": 5, "update-2": 8},
+	})
+
+	msBuilder.ReplicateWorkflowExecutionUpdateAcceptedEvent(s.newUpdateAcceptedEvent("update-3", 12))
+	_, ok := msBuilder.GetAcceptedUpdate("update-1")
+	s.False(ok)
+	eventID, ok := msBuilder.GetAcceptedUpdate("update-2")
+	s.True(ok)
+	s.Equal(int64(8), eventID)
+	eventID, ok = msBuilder.GetAcceptedUpdate("update-3")
+	s.True(ok)
+	s.Equal(int64(12), eventID)
+
+	updates, err := msBuilder.CloseUpdateSession()
+	s.NoError(err)
+	s.Equal(map[string]int64{"update-3": 12}, updates.updateAcceptedUpdates)
+	s.Equal([]string{"update-1"}, updates.deleteAcceptedUpdates)
+
+	updates, err = msBuilder.CloseUpdateSession()
+	s.NoError(err)
+	s.Empty(updates.deleteAcceptedUpdates)
+}
+
+func (s *mutableStateSuite) newUpdateAcceptedEvent(updateID string, eventID int64) *workflow.HistoryEvent {
+	return &workflow.HistoryEvent{
+		EventId:   common.Int64Ptr(eventID),
+		EventType: workflow.EventTypeWorkflowExecutionUpdateAccepted.Ptr(),
+		WorkflowExecutionUpdateAcceptedEventAttributes: &workflow.WorkflowExecutionUpdateAcceptedEventAttributes{
+			UpdateId: common.StringPtr(updateID),
+		},
+	}
+}
@@ -41,6 +41,8 @@ type (
 		deleteSignalInfo                 *int64
 		updateSignalRequestedIDs         []string
 		deleteSignalRequestedID          string
+		updateAcceptedUpdates            map[string]int64
+		deleteAcceptedUpdates            []string
 		continueAsNew                    *persistence.CreateWorkflowExecutionRequest
 		newBufferedEvents                []*workflow.HistoryEvent
 		clearBufferedEvents              bool
@@ -0,0 +1,354 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/uber-common/bark"
+	h "github.com/uber/cadence/.gen/go/history"
+	r "github.com/uber/cadence/.gen/go/replicator"
+	"github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/client/admin"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/backoff"
+	"github.com/uber/cadence/common/logging"
+	"github.com/uber/cadence/common/messaging"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/xdc"
+)
+
+const (
+	replicationTaskPollTimeout        = 30 * time.Second
+	replicationTaskApplyTimeout       = 30 * time.Second
+	replicationTaskBufferRetryCount   = 8
+	replicationTaskRetryTaskErrorWait = 50 * time.Millisecond
+	replicationTaskFailureMaxWait     = time.Minute
+)
+
+type (
+	// replicationTaskPoller pulls the replication tasks of one shard from a remote cluster
+	// and applies them to the local shard, it is the RPC alternative to the kafka consumer
+	// running in the worker service. A task which keeps failing is retried with a growing wait
+	// and then moved to the DLQ, so it does not block the replication of the shard.
+	replicationTaskPoller struct {
+		currentCluster string
+		sourceCluster  string
+		shard          ShardContext
+		historyEngine  Engine
+		remoteAdmin    admin.Client
+		rereplicator   xdc.HistoryRereplicator
+		dlq            messaging.DLQ
+		config         *Config
+		metricsClient  metrics.Client
+		logger         bark.Logger
+
+		status     int32
+		shutdownCh chan struct{}
+		shutdownWG sync.WaitGroup
+
+		// lastRetrievedMessageID is where the next poll starts, lastProcessedMessageID is the last task
+		// applied locally and is reported back so that the remote cluster can purge its replication queue
+		lastRetrievedMessageID int64
+		lastProcessedMessageID int64
+		// failedTaskID is the source task ID of the last task which failed to apply, failedTaskAttempts
+		// is how many times in a row it failed
+		failedTaskID       int64
+		failedTaskAttempts int
+	}
+)
+
+func newReplicationTaskPoller(shard ShardContext, historyEngine Engine, sourceCluster string,
+	remoteAdmin admin.Client, dlq messaging.DLQ, logger bark.Logger) *replicationTaskPoller {
+	rereplicator := xdc.NewHistoryRereplicator(shard.GetDomainCache(), map[string]admin.Client{sourceCluster: remoteAdmin},
+		historyEngine.ReplicateEvents, persistence.NewHistorySerializer(), logger)
+	return &replicationTaskPoller{
+		currentCluster: shard.GetService().GetClusterMetadata().GetCurrentClusterName(),
+		sourceCluster:  sourceCluster,
+		shard:          shard,
+		historyEngine:  historyEngine,
+		remoteAdmin:    remoteAdmin,
+		rereplicator:   rereplicator,
+		dlq:            dlq,
+		config:         shard.GetConfig(),
+		metricsClient:  shard.GetMetricsClient(),
+		logger: logger.WithFields(bark.Fields{
+			logging.TagWorkflowComponent: logging.TagValueReplicationTaskPollerComponent,
+			logging.TagSourceCluster:     sourceCluster,
+		}),
+		status:                 common.DaemonStatusInitialized,
+		shutdownCh:             make(chan struct{}),
+		lastRetrievedMessageID: emptyMessageID,
+		lastProcessedMessageID: emptyMessageID,
+	}
+}
+
+func (p *replicationTaskPoller) Start() {
+	if !atomic.CompareAndSwapInt32(&p.status, common.DaemonStatusInitialized, common.DaemonStatusStarted) {
+		return
+	}
+
+	p.shutdownWG.Add(1)
+	go p.pollLoop()
+	p.logger.Info("Replication task poller started.")
+}
+
+func (p *replicationTaskPoller) Stop() {
+	if !atomic.CompareAndSwapInt32(&p.status, common.DaemonStatusStarted, common.DaemonStatusStopped) {
+		return
+	}
+
+	close(p.shutdownCh)
+	if success := common.AwaitWaitGroup(&p.shutdownWG, time.Minute); !success {
+		p.logger.Warn("Replication task poller timed out on shutdown.")
+	}
+	p.logger.Info("Replication task poller stopped.")
+}
+
+func (p *replicationTaskPoller) pollLoop() {
+	defer p.shutdownWG.Done()
+
+	for {
+		select {
+		case <-p.shutdownCh:
+			return
+		default:
+		}
+
+		if !p.config.EnableRPCReplication() {
+			p.wait(p.config.ReplicationTaskPollerWaitInterval())
+			continue
+		}
+
+		messages, err := p.poll()
+		if err != nil {
+			p.logger.Warnf("Failed to poll replication tasks: %v", err)
+			p.wait(backoff.NewJitter().JitDuration(p.config.ReplicationTaskPollerWaitInterval(), 0.5))
+			continue
+		}
+
+		if err := p.applyTasks(messages); err != nil {
+			// poll again from the last applied task, applying the same events again is a no-op
+			p.logger.Warnf("Failed to apply replication tasks: %v", err)
+			p.lastRetrievedMessageID = p.lastProcessedMessageID
+			p.wait(p.failedTaskWait())
+			continue
+		}
+
+		if !messages.GetHasMore() {
+			p.wait(p.config.ReplicationTaskPollerWaitInterval())
+		}
+	}
+}
+
+func (p *replicationTaskPoller) poll() (*r.ReplicationMessages, error) {
+	shardID := int32(p.shard.GetShardID())
+	ctx, cancel := context.WithTimeout(context.Background(), replicationTaskPollTimeout)
+	defer cancel()
+
+	response, err := p.remoteAdmin.GetReplicationMessages(ctx, &r.GetReplicationMessagesRequest{
+		Tokens: []*r.ReplicationToken{{
+			ShardID:                common.Int32Ptr(shardID),
+			LastRetrievedMessageId: common.Int64Ptr(p.lastRetrievedMessageID),
+			LastProcessedMessageId: common.Int64Ptr(p.lastProcessedMessageID),
+		}},
+		ClusterName: common.StringPtr(p.currentCluster),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	messages, ok := response.MessagesByShard[shardID]
+	if !ok {
+		return &r.ReplicationMessages{}, nil
+	}
+	return messages, nil
+}
+
+func (p *replicationTaskPoller) applyTasks(messages *r.ReplicationMessages) error {
+	for _, task := range messages.ReplicationTasks {
+		if err := p.applyTask(task); err != nil && !p.moveFailedTaskToDLQ(task, err) {
+			return err
+		}
+		if task.SourceTaskId != nil {
+			p.lastProcessedMessageID = task.GetSourceTaskId()
+		}
+	}
+
+	if messages.LastRetrievedMessageId != nil {
+		p.lastRetrievedMessageID = messages.GetLastRetrievedMessageId()
+		// tasks not targeting this cluster are skipped by the remote cluster, so everything
+		// up to the retrieved level is processed once all returned tasks are applied
+		p.lastProcessedMessageID = p.lastRetrievedMessageID
+	}
+	p.failedTaskAttempts = 0
+	return nil
+}
+
+// moveFailedTaskToDLQ records the failure of the task and moves the task to the DLQ once it failed
+// ReplicationTaskPollerMaxRetryCount times in a row, it returns whether the task was moved. Without a
+// DLQ the task is retried until it applies.
+func (p *replicationTaskPoller) moveFailedTaskToDLQ(task *r.ReplicationTask, lastErr error) bool {
+	if task.GetSourceTaskId() != p.failedTaskID {
+		p.failedTaskID = task.GetSourceTaskId()
+		p.failedTaskAttempts = 0
+	}
+	p.failedTaskAttempts++
+	if p.failedTaskAttempts < p.config.ReplicationTaskPollerMaxRetryCount() {
+		return false
+	}
+
+	logger := p.logger.WithFields(bark.Fields{
+		logging.TagErr:          lastErr,
+		logging.TagTaskID:       task.GetSourceTaskId(),
+		logging.TagAttemptCount: p.failedTaskAttempts,
+	})
+	if p.dlq == nil {
+		p.metricsClient.IncCounter(metrics.ReplicationTaskPollerScope, metrics.ReplicationTaskBlockedCounter)
+		logger.Error("Replication task keeps failing and there is no DLQ to move it to.")
+		return false
+	}
+	err := p.dlq.Publish(&r.DLQReplicationTask{
+		Task:          task,
+		SourceCluster: common.StringPtr(p.sourceCluster),
+		LastError:     common.StringPtr(lastErr.Error()),
+		Attempts:      common.Int32Ptr(int32(p.failedTaskAttempts)),
+		Timestamp:     common.Int64Ptr(time.Now().UnixNano()),
+	})
+	if err != nil {
+		p.metricsClient.IncCounter(metrics.ReplicationTaskPollerScope, metrics.ReplicationTaskBlockedCounter)
+		logger.WithField(logging.TagErr, err).Error("Failed to move replication task which keeps failing to the DLQ.")
+		return false
+	}
+
+	p.metricsClient.IncCounter(metrics.ReplicationTaskPollerScope, metrics.ReplicationTaskDLQCounter)
+	logger.Error("Moved replication task which keeps failing to the DLQ.")
+	p.failedTaskAttempts = 0
+	return true
+}
+
+// failedTaskWait returns the wait before the task which failed to apply is retried, it doubles with
+// every failure in a row up to replicationTaskFailureMaxWait
+func (p *replicationTaskPoller) failedTaskWait() time.Duration {
+	policy := backoff.NewExponentialRetryPolicy(p.config.ReplicationTaskPollerWaitInterval())
+	policy.SetMaximumInterval(replicationTaskFailureMaxWait)
+	policy.SetExpirationInterval(backoff.NoInterval)
+	attempt := p.failedTaskAttempts - 1
+	if attempt < 0 {
+		attempt = 0
+	}
+	return policy.ComputeNextDelay(0, attempt)
+}
+
+func (p *replicationTaskPoller) applyTask(task *r.ReplicationTask) error {
+	p.metricsClient.IncCounter(metrics.ReplicationTaskPollerScope, metrics.TaskRequests)
+	sw := p.metricsClient.StartTimer(metrics.ReplicationTaskPollerScope, metrics.TaskLatency)
+	defer sw.Stop()
+
+	var err error
+	switch task.GetTaskType() {
+	case r.ReplicationTaskTypeHistory:
+		err = p.applyHistoryTask(task.HistoryTaskAttributes)
+	case r.ReplicationTaskTypeSyncShardStatus:
+		err = p.applySyncShardTask(task.SyncShardStatusTaskAttributes)
+	default:
+		p.logger.Warnf("Skipping unsupported replication task type %v.", task.GetTaskType())
+	}
+
+	if err != nil {
+		p.metricsClient.IncCounter(metrics.ReplicationTaskPollerScope, metrics.TaskFailures)
+	}
+	return err
+}
+
+func (p *replicationTaskPoller) applyHistoryTask(attr *r.HistoryTaskAttributes) error {
+	request := &h.ReplicateEventsRequest{
+		SourceCluster: common.StringPtr(p.sourceCluster),
+		DomainUUID:    attr.DomainId,
+		WorkflowExecution: &shared.WorkflowExecution{
+			WorkflowId: attr.WorkflowId,
+			RunId:      attr.RunId,
+		},
+		FirstEventId:      attr.FirstEventId,
+		NextEventId:       attr.NextEventId,
+		Version:           attr.Version,
+		ReplicationInfo:   attr.ReplicationInfo,
+		History:           attr.History,
+		NewRunHistory:     attr.NewRunHistory,
+		ForceBufferEvents: common.BoolPtr(false),
+	}
+
+	// replication tasks could be slightly out of order for a workflow execution, so the events are
+	// first applied without buffering, and only buffered once the retries are exhausted
+	resent := false
+	for attempt := 0; attempt < replicationTaskBufferRetryCount; attempt++ {
+		err := p.replicateEvents(request)
+		retryErr, ok := err.(*shared.RetryTaskError)
+		if !ok {
+			return err
+		}
+
+		// the events before this task could be missing locally, fetch them from the source cluster once
+		if !resent {
+			resent = true
+			resendErr := p.rereplicator.SendMissingHistory(p.sourceCluster, attr, retryErr)
+			if resendErr == nil {
+				continue
+			}
+			if resendErr != xdc.ErrNoMissingHistory {
+				p.logger.WithField(logging.TagErr, resendErr).Warn("Failed to re-replicate missing history.")
+			}
+		}
+		p.wait(replicationTaskRetryTaskErrorWait)
+	}
+
+	request.ForceBufferEvents = common.BoolPtr(true)
+	return p.replicateEvents(request)
+}
+
+func (p *replicationTaskPoller) replicateEvents(request *h.ReplicateEventsRequest) error {
+	ctx, cancel := context.WithTimeout(context.Background(), replicationTaskApplyTimeout)
+	defer cancel()
+	return p.historyEngine.ReplicateEvents(ctx, request)
+}
+
+func (p *replicationTaskPoller) applySyncShardTask(attr *r.SyncShardStatusTaskAttributes) error {
+	ctx, cancel := context.WithTimeout(context.Background(), replicationTaskApplyTimeout)
+	defer cancel()
+	return p.historyEngine.SyncShardStatus(ctx, &h.SyncShardStatusRequest{
+		SourceCluster: attr.SourceCluster,
+		ShardId:       attr.ShardId,
+		Timestamp:     attr.Timestamp,
+	})
+}
+
+func (p *replicationTaskPoller) wait(duration time.Duration) {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case <-p.shutdownCh:
+	case <-timer.C:
+	}
+}
@@ -0,0 +1,270 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-common/bark"
+	"github.com/uber-go/tally"
+	"github.com/uber/cadence/.gen/go/admin/adminservicetest"
+	h "github.com/uber/cadence/.gen/go/history"
+	r "github.com/uber/cadence/.gen/go/replicator"
+	"github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/client/admin"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/cluster"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/mocks"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/service"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+	"github.com/uber/cadence/common/xdc"
+)
+
+type (
+	replicationTaskPollerSuite struct {
+		suite.Suite
+		mockEngine       *MockHistoryEngine
+		mockRereplicator *xdc.HistoryRereplicatorMock
+		mockDLQ          *mocks.DLQ
+		poller           *replicationTaskPoller
+	}
+)
+
+func TestReplicationTaskPollerSuite(t *testing.T) {
+	s := new(replicationTaskPollerSuite)
+	suite.Run(t, s)
+}
+
+func (s *replicationTaskPollerSuite) SetupTest() {
+	s.mockEngine = &MockHistoryEngine{}
+	s.mockRereplicator = &xdc.HistoryRereplicatorMock{}
+	s.mockDLQ = &mocks.DLQ{}
+	s.poller = &replicationTaskPoller{
+		currentCluster:         cluster.TestCurrentClusterName,
+		sourceCluster:          cluster.TestAlternativeClusterName,
+		historyEngine:          s.mockEngine,
+		rereplicator:           s.mockRereplicator,
+		dlq:                    s.mockDLQ,
+		config:                 NewConfig(dynamicconfig.NewNopCollection(), 1),
+		metricsClient:          metrics.NewClient(tally.NoopScope, metrics.History),
+		logger:                 bark.NewLoggerFromLogrus(logrus.New()),
+		shutdownCh:             make(chan struct{}),
+		lastRetrievedMessageID: emptyMessageID,
+		lastProcessedMessageID: emptyMessageID,
+	}
+}
+
+func (s *replicationTaskPollerSuite) TearDownTest() {
+	s.mockEngine.AssertExpectations(s.T())
+	s.mockRereplicator.AssertExpectations(s.T())
+	s.mockDLQ.AssertExpectations(s.T())
+}
+
+func (s *replicationTaskPollerSuite) TestApplyTasks() {
+	s.mockEngine.On("ReplicateEvents", mock.MatchedBy(func(request *h.ReplicateEventsRequest) bool {
+		return request.GetSourceCluster() == cluster.TestAlternativeClusterName && !request.GetForceBufferEvents()
+	})).Return(nil).Once()
+	s.mockEngine.On("SyncShardStatus", mock.Anything).Return(nil).Once()
+
+	err := s.poller.applyTasks(&r.ReplicationMessages{
+		ReplicationTasks: []*r.ReplicationTask{
+			s.historyTask(5),
+			{
+				TaskType: r.ReplicationTaskTypeSyncShardStatus.Ptr(),
+				SyncShardStatusTaskAttributes: &r.SyncShardStatusTaskAttributes{
+					SourceCluster: common.StringPtr(cluster.TestAlternativeClusterName),
+					ShardId:       common.Int64Ptr(1),
+					Timestamp:     common.Int64Ptr(100),
+				},
+			},
+		},
+		LastRetrievedMessageId: common.Int64Ptr(8),
+	})
+	s.NoError(err)
+	s.Equal(int64(8), s.poller.lastRetrievedMessageID)
+	s.Equal(int64(8), s.poller.lastProcessedMessageID)
+}
+
+func (s *replicationTaskPollerSuite) TestApplyTasks_StopsAtFailedTask() {
+	s.mockEngine.On("ReplicateEvents", mock.Anything).Return(nil).Once()
+	s.mockEngine.On("ReplicateEvents", mock.Anything).Return(&shared.InternalServiceError{}).Once()
+
+	err := s.poller.applyTasks(&r.ReplicationMessages{
+		ReplicationTasks:       []*r.ReplicationTask{s.historyTask(5), s.historyTask(6)},
+		LastRetrievedMessageId: common.Int64Ptr(6),
+	})
+	s.Error(err)
+	s.Equal(emptyMessageID, s.poller.lastRetrievedMessageID)
+	s.Equal(int64(5), s.poller.lastProcessedMessageID)
+}
+
+func (s *replicationTaskPollerSuite) TestApplyTasks_MovesTaskWhichKeepsFailingToDLQ() {
+	s.poller.config.ReplicationTaskPollerMaxRetryCount = dynamicconfig.GetIntPropertyFn(2)
+	s.mockEngine.On("ReplicateEvents", mock.Anything).Return(&shared.InternalServiceError{Message: "apply failed"}).Twice()
+	s.mockDLQ.On("Publish", mock.MatchedBy(func(msg *r.DLQReplicationTask) bool {
+		return msg.Task.GetSourceTaskId() == 5 &&
+			msg.GetSourceCluster() == cluster.TestAlternativeClusterName &&
+			msg.GetLastError() == (&shared.InternalServiceError{Message: "apply failed"}).Error() &&
+			msg.GetAttempts() == 2
+	})).Return(nil).Once()
+	s.mockEngine.On("ReplicateEvents", mock.Anything).Return(nil).Once()
+	messages := &r.ReplicationMessages{
+		ReplicationTasks:       []*r.ReplicationTask{s.historyTask(5), s.historyTask(6)},
+		LastRetrievedMessageId: common.Int64Ptr(6),
+	}
+
+	s.Error(s.poller.applyTasks(messages))
+	s.Equal(int64(5), s.poller.failedTaskID)
+	s.Equal(1, s.poller.failedTaskAttempts)
+	firstWait := s.poller.failedTaskWait()
+
+	// the second failure in a row moves the task to the DLQ so the tasks after it are applied
+	s.NoError(s.poller.applyTasks(messages))
+	s.Equal(int64(6), s.poller.lastProcessedMessageID)
+	s.Equal(0, s.poller.failedTaskAttempts)
+	s.True(firstWait <= s.poller.config.ReplicationTaskPollerWaitInterval())
+}
+
+func (s *replicationTaskPollerSuite) TestApplyTasks_RetriesTaskWithoutDLQ() {
+	s.poller.dlq = nil
+	s.poller.config.ReplicationTaskPollerMaxRetryCount = dynamicconfig.GetIntPropertyFn(1)
+	s.mockEngine.On("ReplicateEvents", mock.Anything).Return(&shared.InternalServiceError{}).Times(3)
+	messages := &r.ReplicationMessages{
+		ReplicationTasks:       []*r.ReplicationTask{s.historyTask(5)},
+		LastRetrievedMessageId: common.Int64Ptr(5),
+	}
+
+	var waits []time.Duration
+	for i := 0; i < 3; i++ {
+		s.Error(s.poller.applyTasks(messages))
+		waits = append(waits, s.poller.failedTaskWait())
+	}
+	s.Equal(3, s.poller.failedTaskAttempts)
+	s.Equal(emptyMessageID, s.poller.lastProcessedMessageID)
+	// the wait doubles with every failure, the jitter takes up to a fifth of it
+	s.True(waits[2] > waits[0])
+	s.True(waits[2] <= 4*s.poller.config.ReplicationTaskPollerWaitInterval())
+}
+
+func (s *replicationTaskPollerSuite) TestApplyHistoryTask_BuffersAfterRetryTaskErrors() {
+	s.mockEngine.On("ReplicateEvents", mock.MatchedBy(func(request *h.ReplicateEventsRequest) bool {
+		return !request.GetForceBufferEvents()
+	})).Return(&shared.RetryTaskError{}).Times(replicationTaskBufferRetryCount)
+	s.mockEngine.On("ReplicateEvents", mock.MatchedBy(func(request *h.ReplicateEventsRequest) bool {
+		return request.GetForceBufferEvents()
+	})).Return(nil).Once()
+	s.mockRereplicator.On("SendMissingHistory", cluster.TestAlternativeClusterName, mock.Anything, mock.Anything).
+		Return(xdc.ErrNoMissingHistory).Once()
+
+	s.NoError(s.poller.applyHistoryTask(s.historyTask(5).HistoryTaskAttributes))
+}
+
+func (s *replicationTaskPollerSuite) TestApplyHistoryTask_ResendsMissingHistory() {
+	retryErr := &shared.RetryTaskError{
+		DomainId:    common.StringPtr("domainID"),
+		WorkflowId:  common.StringPtr("workflowID"),
+		RunId:       common.StringPtr("runID"),
+		NextEventId: common.Int64Ptr(common.FirstEventID),
+	}
+	attr := s.historyTask(5).HistoryTaskAttributes
+	s.mockEngine.On("ReplicateEvents", mock.Anything).Return(retryErr).Once()
+	s.mockRereplicator.On("SendMissingHistory", cluster.TestAlternativeClusterName, attr, retryErr).Return(nil).Once()
+	s.mockEngine.On("ReplicateEvents", mock.MatchedBy(func(request *h.ReplicateEventsRequest) bool {
+		return !request.GetForceBufferEvents()
+	})).Return(nil).Once()
+
+	s.NoError(s.poller.applyHistoryTask(attr))
+}
+
+func (s *replicationTaskPollerSuite) historyTask(taskID int64) *r.ReplicationTask {
+	return &r.ReplicationTask{
+		TaskType: r.ReplicationTaskTypeHistory.Ptr(),
+		HistoryTaskAttributes: &r.HistoryTaskAttributes{
+			TargetClusters: []string{cluster.TestCurrentClusterName, cluster.TestAlternativeClusterName},
+			DomainId:       common.StringPtr("domainID"),
+			WorkflowId:     common.StringPtr("workflowID"),
+			RunId:          common.StringPtr("runID"),
+			FirstEventId:   common.Int64Ptr(common.FirstEventID),
+			NextEventId:    common.Int64Ptr(common.FirstEventID + 1),
+			Version:        common.Int64Ptr(1),
+		},
+		SourceTaskId: common.Int64Ptr(taskID),
+	}
+}
+
+func (s *replicationTaskPollerSuite) TestRefreshReplicationPollers() {
+	logger := bark.NewLoggerFromLogrus(logrus.New())
+	metricsClient := metrics.NewClient(tally.NoopScope, metrics.History)
+	mockClusterMetadata := &mocks.ClusterMetadata{}
+	mockClusterMetadata.On("GetCurrentClusterName").Return(cluster.TestCurrentClusterName)
+	shard := &shardContextImpl{
+		service:       service.NewTestService(mockClusterMetadata, nil, metricsClient, logger),
+		shardInfo:     &persistence.ShardInfo{ShardID: 1},
+		config:        NewConfig(dynamicconfig.NewNopCollection(), 1),
+		logger:        logger,
+		domainCache:   &cache.DomainCacheMock{},
+		metricsClient: metricsClient,
+	}
+	mockCtrl := gomock.NewController(s.T())
+	defer mockCtrl.Finish()
+	remoteAdminClients := map[string]admin.Client{
+		cluster.TestAlternativeClusterName: adminservicetest.NewMockClient(mockCtrl),
+	}
+	engine := &historyEngineImpl{
+		shard:              shard,
+		logger:             logger,
+		remoteAdminClients: func() map[string]admin.Client { return remoteAdminClients },
+		replicationPollers: make(map[string]*replicationTaskPoller),
+	}
+
+	engine.refreshReplicationPollers()
+	s.Len(engine.replicationPollers, 1)
+	poller := engine.replicationPollers[cluster.TestAlternativeClusterName]
+
+	// a cluster added at runtime gets a poller, the existing ones are kept
+	remoteAdminClients["other"] = adminservicetest.NewMockClient(mockCtrl)
+	engine.refreshReplicationPollers()
+	s.Len(engine.replicationPollers, 2)
+	s.Equal(poller, engine.replicationPollers[cluster.TestAlternativeClusterName])
+
+	// a cluster moved to another address gets a new poller
+	remoteAdminClients["other"] = adminservicetest.NewMockClient(mockCtrl)
+	engine.refreshReplicationPollers()
+	s.Equal(remoteAdminClients["other"], engine.replicationPollers["other"].remoteAdmin)
+
+	// a disabled cluster has its poller stopped
+	delete(remoteAdminClients, cluster.TestAlternativeClusterName)
+	engine.refreshReplicationPollers()
+	s.Len(engine.replicationPollers, 1)
+	s.Equal(int32(common.DaemonStatusStopped), poller.status)
+
+	for _, poller := range engine.replicationPollers {
+		poller.Stop()
+	}
+}
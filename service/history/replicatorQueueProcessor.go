@@ -22,11 +22,11 @@ package history
 
 import (
 	"errors"
+	"math"
 	"sync"
 	"time"
 
 	"github.com/uber-common/bark"
-	h "github.com/uber/cadence/.gen/go/history"
 	"github.com/uber/cadence/.gen/go/replicator"
 	"github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/common"
@@ -51,6 +51,9 @@ type (
 
 		sync.Mutex
 		lastShardSyncTimestamp time.Time
+
+		// purgeLock serializes the deletion of tasks replicated to all remote clusters
+		purgeLock sync.Mutex
 	}
 )
 
@@ -58,10 +61,12 @@ var (
 	errUnknownReplicationTask = errors.New("Unknown replication task")
 	errHistoryNotFoundTask    = errors.New("History not found")
 	defaultHistoryPageSize    = 1000
+	// emptyMessageID is used by a polling cluster which has no read or processed level for a shard yet
+	emptyMessageID int64 = -1
 )
 
 func newReplicatorQueueProcessor(shard ShardContext, replicator messaging.Producer,
-	executionMgr persistence.ExecutionManager, historyMgr persistence.HistoryManager, logger bark.Logger) queueProcessor {
+	executionMgr persistence.ExecutionManager, historyMgr persistence.HistoryManager, logger bark.Logger) replicatorQueueProcessor {
 
 	currentClusterNamer := shard.GetService().GetClusterMetadata().GetCurrentClusterName()
 
@@ -129,10 +134,25 @@ func (p *replicatorQueueProcessorImpl) queueShutdown() error {
 }
 
 func (p *replicatorQueueProcessorImpl) processHistoryReplicationTask(task *persistence.ReplicationTaskInfo) error {
+	replicationTask, err := p.getHistoryReplicationTask(task)
+	if err != nil {
+		return err
+	}
+
+	err = p.replicator.Publish(replicationTask)
+	if err == nil {
+		p.Lock()
+		p.lastShardSyncTimestamp = common.NewRealTimeSource().Now()
+		p.Unlock()
+		emitReplicationTaskLag(p.metricsClient, replicationTask)
+	}
+	return err
+}
 
+func (p *replicatorQueueProcessorImpl) getHistoryReplicationTask(task *persistence.ReplicationTaskInfo) (*replicator.ReplicationTask, error) {
 	domainEntry, err := p.shard.GetDomainCache().GetDomainByID(task.DomainID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	targetClusters := []string{}
 	for _, cluster := range domainEntry.GetReplicationConfig().Clusters {
@@ -141,7 +161,7 @@ func (p *replicatorQueueProcessorImpl) processHistoryReplicationTask(task *persi
 
 	history, err := p.getHistory(task.DomainID, task.WorkflowID, task.RunID, task.FirstEventID, task.NextEventID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Check if this is replication task for ContinueAsNew event, then retrieve the history for new execution
@@ -153,7 +173,7 @@ func (p *replicatorQueueProcessorImpl) processHistoryReplicationTask(task *persi
 			newRunID := lastEvent.WorkflowExecutionContinuedAsNewEventAttributes.GetNewExecutionRunId()
 			newRunHistory, err = p.getHistory(task.DomainID, task.WorkflowID, newRunID, common.FirstEventID, int64(3))
 			if err != nil {
-				return err
+				return nil, err
 			}
 		}
 	}
@@ -172,18 +192,17 @@ func (p *replicatorQueueProcessorImpl) processHistoryReplicationTask(task *persi
 			History:         history,
 			NewRunHistory:   newRunHistory,
 		},
+		SourceTaskId: common.Int64Ptr(task.TaskID),
 	}
-
-	err = p.replicator.Publish(replicationTask)
-	if err == nil {
-		p.Lock()
-		p.lastShardSyncTimestamp = common.NewRealTimeSource().Now()
-		p.Unlock()
-	}
-	return err
+	return replicationTask, nil
 }
 
 func (p *replicatorQueueProcessorImpl) readTasks(readLevel int64) ([]queueTaskInfo, bool, error) {
+	if p.shard.GetConfig().EnableRPCReplication() {
+		// remote clusters pull the replication tasks, nothing is published to kafka
+		return nil, false, nil
+	}
+
 	response, err := p.executionMgr.GetReplicationTasks(&persistence.GetReplicationTasksRequest{
 		ReadLevel:    readLevel,
 		MaxReadLevel: p.shard.GetTransferMaxReadLevel(),
@@ -203,6 +222,11 @@ func (p *replicatorQueueProcessorImpl) readTasks(readLevel int64) ([]queueTaskIn
 }
 
 func (p *replicatorQueueProcessorImpl) updateAckLevel(ackLevel int64) error {
+	if p.shard.GetConfig().EnableRPCReplication() {
+		// the replicator ack level is moved by purgeReplicatedTasks once all remote clusters applied the tasks
+		return nil
+	}
+
 	err := p.shard.UpdateReplicatorAckLevel(ackLevel)
 
 	// this is a hack, since there is not dedicated ticker on the queue processor
@@ -232,6 +256,253 @@ func (p *replicatorQueueProcessorImpl) updateAckLevel(ackLevel int64) error {
 	return err
 }
 
+// getTasks returns the replication tasks after lastReadTaskID for the polling cluster. The polling
+// cluster also reports the last task it applied, which becomes its replication level for this shard.
+func (p *replicatorQueueProcessorImpl) getTasks(pollingCluster string, lastReadTaskID int64,
+	lastProcessedTaskID int64) (*replicator.ReplicationMessages, error) {
+
+	if lastProcessedTaskID != emptyMessageID && lastProcessedTaskID > p.shard.GetClusterReplicationLevel(pollingCluster) {
+		if err := p.shard.UpdateClusterReplicationLevel(pollingCluster, lastProcessedTaskID); err != nil {
+			p.logger.Warnf("Error updating replication level for cluster %v: %v", pollingCluster, err)
+		} else if err := p.purgeReplicatedTasks(); err != nil {
+			p.logger.Warnf("Error purging replicated tasks: %v", err)
+		}
+	}
+
+	if lastReadTaskID == emptyMessageID {
+		lastReadTaskID = p.shard.GetClusterReplicationLevel(pollingCluster)
+	}
+
+	response, err := p.executionMgr.GetReplicationTasks(&persistence.GetReplicationTasksRequest{
+		ReadLevel:    lastReadTaskID,
+		MaxReadLevel: p.shard.GetTransferMaxReadLevel(),
+		BatchSize:    p.options.BatchSize(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	metricsClient := p.metricsClient.Tagged(map[string]string{metrics.TargetClusterTagName: pollingCluster})
+	metricsClient.RecordTimer(metrics.ReplicatorQueueProcessorScope, metrics.ReplicationTasksLagTimer,
+		time.Duration(p.shard.GetTransferMaxReadLevel()-p.shard.GetClusterReplicationLevel(pollingCluster)))
+
+	readLevel := lastReadTaskID
+	hasMore := len(response.NextPageToken) != 0
+	replicationTasks := []*replicator.ReplicationTask{}
+taskLoop:
+	for _, task := range response.Tasks {
+		switch task.TaskType {
+		case persistence.ReplicationTaskTypeHistory:
+			replicationTask, err := p.getHistoryReplicationTask(task)
+			if err != nil {
+				if _, ok := err.(*shared.EntityNotExistsError); ok {
+					// the history is gone, e.g. the workflow was deleted after retention, so there is nothing to replicate
+					readLevel = task.TaskID
+					continue taskLoop
+				}
+				if len(replicationTasks) == 0 {
+					return nil, err
+				}
+				// return what is already converted, the polling cluster retries from here
+				hasMore = true
+				break taskLoop
+			}
+			if isReplicationTaskTargeting(replicationTask, pollingCluster) {
+				replicationTasks = append(replicationTasks, replicationTask)
+				emitReplicationTaskLag(metricsClient, replicationTask)
+			}
+		default:
+			p.logger.Warnf("Skipping unknown replication task type %v, task ID %v", task.TaskType, task.TaskID)
+		}
+		readLevel = task.TaskID
+	}
+
+	if !hasMore {
+		// everything up to now is returned, let the polling cluster move its view of this shard forward
+		replicationTasks = append(replicationTasks, &replicator.ReplicationTask{
+			TaskType: replicator.ReplicationTaskType.Ptr(replicator.ReplicationTaskTypeSyncShardStatus),
+			SyncShardStatusTaskAttributes: &replicator.SyncShardStatusTaskAttributes{
+				SourceCluster: common.StringPtr(p.currentClusterNamer),
+				ShardId:       common.Int64Ptr(int64(p.shard.GetShardID())),
+				Timestamp:     common.Int64Ptr(common.NewRealTimeSource().Now().UnixNano()),
+			},
+		})
+	}
+
+	return &replicator.ReplicationMessages{
+		ReplicationTasks:       replicationTasks,
+		LastRetrievedMessageId: common.Int64Ptr(readLevel),
+		HasMore:                common.BoolPtr(hasMore),
+	}, nil
+}
+
+// purgeReplicatedTasks deletes the replication tasks applied by all remote clusters
+// and moves the replicator ack level to the lowest remote cluster replication level. Only the
+// enabled remote clusters with an address poll for tasks, so the disabled clusters and the
+// clusters which cannot poll do not hold the purge back.
+func (p *replicatorQueueProcessorImpl) purgeReplicatedTasks() error {
+	if !p.shard.GetConfig().EnableRPCReplication() {
+		// the kafka publisher owns the replicator ack level and deletes the tasks it published
+		return nil
+	}
+
+	p.purgeLock.Lock()
+	defer p.purgeLock.Unlock()
+
+	clusterMetadata := p.shard.GetService().GetClusterMetadata()
+	ackLevel := p.shard.GetReplicatorAckLevel()
+	purgeLevel := int64(math.MaxInt64)
+	for cluster := range clusterMetadata.GetAllClientAddress() {
+		if cluster == p.currentClusterNamer || !clusterMetadata.IsClusterEnabled(cluster) {
+			continue
+		}
+		if level := p.shard.GetClusterReplicationLevel(cluster); level < purgeLevel {
+			purgeLevel = level
+		}
+	}
+	if purgeLevel == math.MaxInt64 || purgeLevel <= ackLevel {
+		return nil
+	}
+
+	for readLevel := ackLevel; readLevel < purgeLevel; {
+		response, err := p.executionMgr.GetReplicationTasks(&persistence.GetReplicationTasksRequest{
+			ReadLevel:    readLevel,
+			MaxReadLevel: purgeLevel,
+			BatchSize:    p.options.BatchSize(),
+		})
+		if err != nil {
+			return err
+		}
+		for _, task := range response.Tasks {
+			if err := p.executionMgr.CompleteReplicationTask(&persistence.CompleteReplicationTaskRequest{TaskID: task.TaskID}); err != nil {
+				return err
+			}
+			readLevel = task.TaskID
+		}
+		if len(response.NextPageToken) == 0 {
+			break
+		}
+	}
+
+	return p.shard.UpdateReplicatorAckLevel(purgeLevel)
+}
+
+// getReplicationStatus returns the replication levels of the shard for every remote cluster. The remote clusters
+// share the replicator ack level when the tasks are published to kafka, otherwise each remote cluster has the
+// level of the last task it applied. The unacked tasks are limited to the ones of the domain when domainID is set,
+// and to the ones up to maxReadLevel when it is positive.
+func (p *replicatorQueueProcessorImpl) getReplicationStatus(domainID string,
+	maxReadLevel int64) (*replicator.ShardReplicationStatus, error) {
+	ackLevel := p.shard.GetReplicatorAckLevel()
+	currentMaxReadLevel := p.shard.GetTransferMaxReadLevel()
+	if maxReadLevel <= 0 || maxReadLevel > currentMaxReadLevel {
+		maxReadLevel = currentMaxReadLevel
+	}
+	now := common.NewRealTimeSource().Now()
+
+	remoteClusters := make(map[string]*replicator.RemoteClusterReplicationStatus)
+	for cluster := range p.shard.GetService().GetClusterMetadata().GetAllClusterFailoverVersions() {
+		if cluster == p.currentClusterNamer {
+			continue
+		}
+
+		clusterAckLevel := ackLevel
+		if p.shard.GetConfig().EnableRPCReplication() {
+			clusterAckLevel = p.shard.GetClusterReplicationLevel(cluster)
+		}
+		hasUnackedTasks, oldestTaskTime, err := p.getOldestUnackedTask(domainID, clusterAckLevel, maxReadLevel)
+		if err != nil {
+			return nil, err
+		}
+		var oldestTaskAge time.Duration
+		if !oldestTaskTime.IsZero() && oldestTaskTime.Before(now) {
+			oldestTaskAge = now.Sub(oldestTaskTime)
+		}
+
+		remoteClusters[cluster] = &replicator.RemoteClusterReplicationStatus{
+			AckLevel:                      common.Int64Ptr(clusterAckLevel),
+			OldestUnackedTaskAgeInSeconds: common.Int64Ptr(int64(oldestTaskAge / time.Second)),
+			HasUnackedTasks:               common.BoolPtr(hasUnackedTasks),
+		}
+	}
+
+	return &replicator.ShardReplicationStatus{
+		ShardID:            common.Int32Ptr(int32(p.shard.GetShardID())),
+		ReplicatorAckLevel: common.Int64Ptr(ackLevel),
+		MaxReadLevel:       common.Int64Ptr(currentMaxReadLevel),
+		RemoteClusters:     remoteClusters,
+	}, nil
+}
+
+// getOldestUnackedTask returns whether there is a replication task of the domain, any domain if domainID is empty,
+// after the ack level, and when the events of the first such task were written, the zero time is returned if its
+// history is already deleted
+func (p *replicatorQueueProcessorImpl) getOldestUnackedTask(domainID string, ackLevel int64,
+	maxReadLevel int64) (bool, time.Time, error) {
+	batchSize := 1
+	if domainID != "" {
+		batchSize = p.shard.GetConfig().ReplicatorTaskBatchSize()
+	}
+
+	var task *persistence.ReplicationTaskInfo
+	request := &persistence.GetReplicationTasksRequest{
+		ReadLevel:    ackLevel,
+		MaxReadLevel: maxReadLevel,
+		BatchSize:    batchSize,
+	}
+ScanLoop:
+	for {
+		response, err := p.executionMgr.GetReplicationTasks(request)
+		if err != nil {
+			return false, time.Time{}, err
+		}
+		for _, t := range response.Tasks {
+			if domainID == "" || t.DomainID == domainID {
+				task = t
+				break ScanLoop
+			}
+		}
+		if len(response.NextPageToken) == 0 {
+			break ScanLoop
+		}
+		request.NextPageToken = response.NextPageToken
+	}
+	if task == nil {
+		return false, time.Time{}, nil
+	}
+
+	history, err := p.getHistory(task.DomainID, task.WorkflowID, task.RunID, task.FirstEventID, task.FirstEventID+1)
+	if err != nil {
+		if _, ok := err.(*shared.EntityNotExistsError); ok {
+			return true, time.Time{}, nil
+		}
+		return false, time.Time{}, err
+	}
+	if len(history.Events) == 0 {
+		return true, time.Time{}, nil
+	}
+	return true, time.Unix(0, history.Events[0].GetTimestamp()), nil
+}
+
+// emitReplicationTaskLag records how long ago the events of the replication task were written
+func emitReplicationTaskLag(metricsClient metrics.Client, task *replicator.ReplicationTask) {
+	events := task.HistoryTaskAttributes.History.GetEvents()
+	if len(events) == 0 {
+		return
+	}
+	metricsClient.RecordTimer(metrics.ReplicatorQueueProcessorScope, metrics.ReplicationTaskLagTimer,
+		time.Since(time.Unix(0, events[0].GetTimestamp())))
+}
+
+func isReplicationTaskTargeting(task *replicator.ReplicationTask, cluster string) bool {
+	for _, targetCluster := range task.HistoryTaskAttributes.TargetClusters {
+		if targetCluster == cluster {
+			return true
+		}
+	}
+	return false
+}
+
 func (p *replicatorQueueProcessorImpl) getHistory(domainID, workflowID, runID string, firstEventID,
 	nextEventID int64) (*shared.History, error) {
 
@@ -263,10 +534,10 @@ func (p *replicatorQueueProcessorImpl) getHistory(domainID, workflowID, runID st
 	return executionHistory, nil
 }
 
-func convertLastReplicationInfo(info map[string]*persistence.ReplicationInfo) map[string]*h.ReplicationInfo {
-	replicationInfoMap := make(map[string]*h.ReplicationInfo)
+func convertLastReplicationInfo(info map[string]*persistence.ReplicationInfo) map[string]*shared.ReplicationInfo {
+	replicationInfoMap := make(map[string]*shared.ReplicationInfo)
 	for k, v := range info {
-		replicationInfoMap[k] = &h.ReplicationInfo{
+		replicationInfoMap[k] = &shared.ReplicationInfo{
 			Version:     common.Int64Ptr(v.Version),
 			LastEventId: common.Int64Ptr(v.LastEventID),
 		}
@@ -274,3 +545,15 @@ func convertLastReplicationInfo(info map[string]*persistence.ReplicationInfo) ma
 
 	return replicationInfoMap
 }
+
+func convertVersionHistory(history []*persistence.ReplicationInfo) []*shared.ReplicationInfo {
+	versionHistory := make([]*shared.ReplicationInfo, 0, len(history))
+	for _, item := range history {
+		versionHistory = append(versionHistory, &shared.ReplicationInfo{
+			Version:     common.Int64Ptr(item.Version),
+			LastEventId: common.Int64Ptr(item.LastEventID),
+		})
+	}
+
+	return versionHistory
+}
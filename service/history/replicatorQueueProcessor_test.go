@@ -0,0 +1,329 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-common/bark"
+	"github.com/uber-go/tally"
+	"github.com/uber/cadence/.gen/go/replicator"
+	"github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/cluster"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/mocks"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/service"
+	"github.com/uber/cadence/common/service/config"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
+
+type (
+	replicatorQueueProcessorSuite struct {
+		suite.Suite
+		logger              bark.Logger
+		mockExecutionMgr    *mocks.ExecutionManager
+		mockHistoryMgr      *mocks.HistoryManager
+		mockShardManager    *mocks.ShardManager
+		mockClusterMetadata *mocks.ClusterMetadata
+		mockProducer        *mocks.KafkaProducer
+		mockMetadataMgr     *mocks.MetadataManager
+		mockShard           *shardContextImpl
+
+		// clusterFailoverVersions are the clusters known to the cluster metadata
+		clusterFailoverVersions map[string]int64
+		processor               *replicatorQueueProcessorImpl
+	}
+)
+
+func TestReplicatorQueueProcessorSuite(t *testing.T) {
+	s := new(replicatorQueueProcessorSuite)
+	suite.Run(t, s)
+}
+
+func (s *replicatorQueueProcessorSuite) SetupSuite() {
+	if testing.Verbose() {
+		log.SetOutput(os.Stdout)
+	}
+}
+
+func (s *replicatorQueueProcessorSuite) SetupTest() {
+	log2 := log.New()
+	log2.Level = log.DebugLevel
+	s.logger = bark.NewLoggerFromLogrus(log2)
+	s.mockExecutionMgr = &mocks.ExecutionManager{}
+	s.mockHistoryMgr = &mocks.HistoryManager{}
+	s.mockShardManager = &mocks.ShardManager{}
+	s.mockClusterMetadata = &mocks.ClusterMetadata{}
+	s.mockProducer = &mocks.KafkaProducer{}
+	s.mockMetadataMgr = &mocks.MetadataManager{}
+	s.mockClusterMetadata.On("GetCurrentClusterName").Return(cluster.TestCurrentClusterName)
+	s.clusterFailoverVersions = make(map[string]int64)
+	for clusterName, version := range cluster.TestAllClusterFailoverVersions {
+		s.clusterFailoverVersions[clusterName] = version
+	}
+	s.mockClusterMetadata.On("GetAllClusterFailoverVersions").Return(s.clusterFailoverVersions)
+	s.mockClusterMetadata.On("IsGlobalDomainEnabled").Return(true)
+	metricsClient := metrics.NewClient(tally.NoopScope, metrics.History)
+	mockService := service.NewTestService(s.mockClusterMetadata, mocks.NewMockMessagingClient(s.mockProducer, nil), metricsClient, s.logger)
+
+	s.mockShard = &shardContextImpl{
+		service:                   mockService,
+		shardInfo:                 &persistence.ShardInfo{ShardID: 1, RangeID: 1, ReplicationAckLevel: 10},
+		executionManager:          s.mockExecutionMgr,
+		shardManager:              s.mockShardManager,
+		historyMgr:                s.mockHistoryMgr,
+		transferMaxReadLevel:      100,
+		lastUpdated:               time.Now(),
+		closeCh:                   make(chan int, 100),
+		config:                    NewConfig(dynamicconfig.NewNopCollection(), 1),
+		logger:                    s.logger,
+		domainCache:               cache.NewDomainCache(s.mockMetadataMgr, s.mockClusterMetadata, metricsClient, s.logger),
+		metricsClient:             metricsClient,
+		standbyClusterCurrentTime: make(map[string]time.Time),
+	}
+	s.processor = newReplicatorQueueProcessor(s.mockShard, s.mockProducer, s.mockExecutionMgr, s.mockHistoryMgr,
+		s.logger).(*replicatorQueueProcessorImpl)
+}
+
+func (s *replicatorQueueProcessorSuite) TearDownTest() {
+	s.mockExecutionMgr.AssertExpectations(s.T())
+	s.mockHistoryMgr.AssertExpectations(s.T())
+	s.mockProducer.AssertExpectations(s.T())
+}
+
+func (s *replicatorQueueProcessorSuite) TestGetTasks_StartsFromReplicatorAckLevel() {
+	s.mockDomain("domainID", cluster.TestCurrentClusterName, cluster.TestAlternativeClusterName)
+	s.mockExecutionMgr.On("GetReplicationTasks", &persistence.GetReplicationTasksRequest{
+		ReadLevel:    10,
+		MaxReadLevel: 100,
+		BatchSize:    s.processor.options.BatchSize(),
+	}).Return(&persistence.GetReplicationTasksResponse{
+		Tasks: []*persistence.ReplicationTaskInfo{s.historyTask(11, "domainID"), s.historyTask(12, "domainID")},
+	}, nil).Once()
+	s.mockHistory()
+
+	messages, err := s.processor.getTasks(cluster.TestAlternativeClusterName, emptyMessageID, emptyMessageID)
+	s.NoError(err)
+	s.Equal(int64(12), messages.GetLastRetrievedMessageId())
+	s.False(messages.GetHasMore())
+	s.Equal(3, len(messages.ReplicationTasks))
+	s.Equal(int64(11), messages.ReplicationTasks[0].GetSourceTaskId())
+	s.Equal(int64(12), messages.ReplicationTasks[1].GetSourceTaskId())
+	s.Equal(replicator.ReplicationTaskTypeSyncShardStatus, messages.ReplicationTasks[2].GetTaskType())
+}
+
+func (s *replicatorQueueProcessorSuite) TestGetTasks_SkipsTasksNotTargetingPollingCluster() {
+	s.mockDomain("localDomainID", cluster.TestCurrentClusterName)
+	s.mockExecutionMgr.On("GetReplicationTasks", mock.Anything).Return(&persistence.GetReplicationTasksResponse{
+		Tasks:         []*persistence.ReplicationTaskInfo{s.historyTask(21, "localDomainID")},
+		NextPageToken: []byte{1},
+	}, nil).Once()
+	s.mockHistory()
+
+	messages, err := s.processor.getTasks(cluster.TestAlternativeClusterName, 20, emptyMessageID)
+	s.NoError(err)
+	s.Equal(int64(21), messages.GetLastRetrievedMessageId())
+	s.True(messages.GetHasMore())
+	s.Empty(messages.ReplicationTasks)
+}
+
+func (s *replicatorQueueProcessorSuite) TestGetTasks_PurgesTasksAppliedByAllClusters() {
+	s.mockShard.config.EnableRPCReplication = dynamicconfig.GetBoolPropertyFn(true)
+	s.mockClusterMetadata.On("GetAllClientAddress").Return(cluster.TestAllClusterAddress)
+	s.mockClusterMetadata.On("IsClusterEnabled", cluster.TestAlternativeClusterName).Return(true)
+	s.mockExecutionMgr.On("GetReplicationTasks", &persistence.GetReplicationTasksRequest{
+		ReadLevel:    10,
+		MaxReadLevel: 15,
+		BatchSize:    s.processor.options.BatchSize(),
+	}).Return(&persistence.GetReplicationTasksResponse{
+		Tasks: []*persistence.ReplicationTaskInfo{s.historyTask(11, "domainID"), s.historyTask(15, "domainID")},
+	}, nil).Once()
+	s.mockExecutionMgr.On("CompleteReplicationTask", &persistence.CompleteReplicationTaskRequest{TaskID: 11}).Return(nil).Once()
+	s.mockExecutionMgr.On("CompleteReplicationTask", &persistence.CompleteReplicationTaskRequest{TaskID: 15}).Return(nil).Once()
+	s.mockExecutionMgr.On("GetReplicationTasks", &persistence.GetReplicationTasksRequest{
+		ReadLevel:    15,
+		MaxReadLevel: 100,
+		BatchSize:    s.processor.options.BatchSize(),
+	}).Return(&persistence.GetReplicationTasksResponse{}, nil).Once()
+
+	messages, err := s.processor.getTasks(cluster.TestAlternativeClusterName, 15, 15)
+	s.NoError(err)
+	s.Equal(int64(15), messages.GetLastRetrievedMessageId())
+	s.Equal(int64(15), s.mockShard.GetClusterReplicationLevel(cluster.TestAlternativeClusterName))
+	s.Equal(int64(15), s.mockShard.GetReplicatorAckLevel())
+}
+
+func (s *replicatorQueueProcessorSuite) TestGetTasks_PurgeIgnoresDisabledClusters() {
+	s.mockShard.config.EnableRPCReplication = dynamicconfig.GetBoolPropertyFn(true)
+	// the disabled cluster and the cluster without an address never polled, so their replication
+	// level is still the replicator ack level
+	s.clusterFailoverVersions["disabled"] = 3
+	s.clusterFailoverVersions["no-address"] = 4
+	clientAddress := map[string]config.Address{"disabled": {RPCName: "disabled", RPCAddress: "disabled:7933"}}
+	for clusterName, address := range cluster.TestAllClusterAddress {
+		clientAddress[clusterName] = address
+	}
+	s.mockClusterMetadata.On("GetAllClientAddress").Return(clientAddress)
+	s.mockClusterMetadata.On("IsClusterEnabled", cluster.TestAlternativeClusterName).Return(true)
+	s.mockClusterMetadata.On("IsClusterEnabled", "disabled").Return(false)
+	s.mockExecutionMgr.On("GetReplicationTasks", &persistence.GetReplicationTasksRequest{
+		ReadLevel:    10,
+		MaxReadLevel: 15,
+		BatchSize:    s.processor.options.BatchSize(),
+	}).Return(&persistence.GetReplicationTasksResponse{
+		Tasks: []*persistence.ReplicationTaskInfo{s.historyTask(15, "domainID")},
+	}, nil).Once()
+	s.mockExecutionMgr.On("CompleteReplicationTask", &persistence.CompleteReplicationTaskRequest{TaskID: 15}).Return(nil).Once()
+	s.mockExecutionMgr.On("GetReplicationTasks", &persistence.GetReplicationTasksRequest{
+		ReadLevel:    15,
+		MaxReadLevel: 100,
+		BatchSize:    s.processor.options.BatchSize(),
+	}).Return(&persistence.GetReplicationTasksResponse{}, nil).Once()
+
+	_, err := s.processor.getTasks(cluster.TestAlternativeClusterName, 15, 15)
+	s.NoError(err)
+	s.Equal(int64(15), s.mockShard.GetReplicatorAckLevel())
+}
+
+func (s *replicatorQueueProcessorSuite) TestGetTasks_KafkaModeKeepsTasks() {
+	s.mockExecutionMgr.On("GetReplicationTasks", mock.Anything).Return(&persistence.GetReplicationTasksResponse{}, nil).Once()
+
+	messages, err := s.processor.getTasks(cluster.TestAlternativeClusterName, 15, 15)
+	s.NoError(err)
+	s.Equal(int64(15), messages.GetLastRetrievedMessageId())
+	s.Equal(int64(15), s.mockShard.GetClusterReplicationLevel(cluster.TestAlternativeClusterName))
+	s.Equal(int64(10), s.mockShard.GetReplicatorAckLevel())
+}
+
+func (s *replicatorQueueProcessorSuite) TestGetReplicationStatus_KafkaMode() {
+	s.mockExecutionMgr.On("GetReplicationTasks", &persistence.GetReplicationTasksRequest{
+		ReadLevel:    10,
+		MaxReadLevel: 100,
+		BatchSize:    1,
+	}).Return(&persistence.GetReplicationTasksResponse{
+		Tasks: []*persistence.ReplicationTaskInfo{s.historyTask(11, "domainID")},
+	}, nil).Once()
+	s.mockHistoryMgr.On("GetWorkflowExecutionHistory", mock.Anything).Return(&persistence.GetWorkflowExecutionHistoryResponse{
+		History: &shared.History{Events: []*shared.HistoryEvent{{
+			EventId:   common.Int64Ptr(common.FirstEventID),
+			EventType: shared.EventTypeWorkflowExecutionStarted.Ptr(),
+			Timestamp: common.Int64Ptr(time.Now().Add(-time.Minute).UnixNano()),
+		}}},
+	}, nil).Once()
+
+	status, err := s.processor.getReplicationStatus("", 0)
+	s.NoError(err)
+	s.Equal(int32(s.mockShard.GetShardID()), status.GetShardID())
+	s.Equal(int64(10), status.GetReplicatorAckLevel())
+	s.Equal(int64(100), status.GetMaxReadLevel())
+	s.Equal(1, len(status.RemoteClusters))
+	clusterStatus := status.RemoteClusters[cluster.TestAlternativeClusterName]
+	s.Equal(int64(10), clusterStatus.GetAckLevel())
+	s.True(clusterStatus.GetOldestUnackedTaskAgeInSeconds() >= 60)
+	s.True(clusterStatus.GetHasUnackedTasks())
+}
+
+func (s *replicatorQueueProcessorSuite) TestGetReplicationStatus_RPCModeUsesClusterReplicationLevel() {
+	s.mockShard.config.EnableRPCReplication = dynamicconfig.GetBoolPropertyFn(true)
+	s.mockShard.shardInfo.ClusterReplicationLevel = map[string]int64{cluster.TestAlternativeClusterName: 100}
+	s.mockExecutionMgr.On("GetReplicationTasks", &persistence.GetReplicationTasksRequest{
+		ReadLevel:    100,
+		MaxReadLevel: 100,
+		BatchSize:    1,
+	}).Return(&persistence.GetReplicationTasksResponse{}, nil).Once()
+
+	status, err := s.processor.getReplicationStatus("", 0)
+	s.NoError(err)
+	s.Equal(int64(10), status.GetReplicatorAckLevel())
+	clusterStatus := status.RemoteClusters[cluster.TestAlternativeClusterName]
+	s.Equal(int64(100), clusterStatus.GetAckLevel())
+	s.Equal(int64(0), clusterStatus.GetOldestUnackedTaskAgeInSeconds())
+	s.False(clusterStatus.GetHasUnackedTasks())
+}
+
+func (s *replicatorQueueProcessorSuite) TestGetReplicationStatus_DomainUpToMaxReadLevel() {
+	s.mockExecutionMgr.On("GetReplicationTasks", &persistence.GetReplicationTasksRequest{
+		ReadLevel:    10,
+		MaxReadLevel: 50,
+		BatchSize:    s.mockShard.GetConfig().ReplicatorTaskBatchSize(),
+	}).Return(&persistence.GetReplicationTasksResponse{
+		Tasks:         []*persistence.ReplicationTaskInfo{s.historyTask(11, "otherDomainID")},
+		NextPageToken: []byte("token"),
+	}, nil).Once()
+	s.mockExecutionMgr.On("GetReplicationTasks", &persistence.GetReplicationTasksRequest{
+		ReadLevel:     10,
+		MaxReadLevel:  50,
+		BatchSize:     s.mockShard.GetConfig().ReplicatorTaskBatchSize(),
+		NextPageToken: []byte("token"),
+	}).Return(&persistence.GetReplicationTasksResponse{
+		Tasks: []*persistence.ReplicationTaskInfo{s.historyTask(12, "otherDomainID")},
+	}, nil).Once()
+
+	status, err := s.processor.getReplicationStatus("domainID", 50)
+	s.NoError(err)
+	s.Equal(int64(100), status.GetMaxReadLevel())
+	s.False(status.RemoteClusters[cluster.TestAlternativeClusterName].GetHasUnackedTasks())
+}
+
+func (s *replicatorQueueProcessorSuite) historyTask(taskID int64, domainID string) *persistence.ReplicationTaskInfo {
+	return &persistence.ReplicationTaskInfo{
+		DomainID:     domainID,
+		WorkflowID:   "workflowID",
+		RunID:        "runID",
+		TaskID:       taskID,
+		TaskType:     persistence.ReplicationTaskTypeHistory,
+		FirstEventID: common.FirstEventID,
+		NextEventID:  common.FirstEventID + 1,
+		Version:      1,
+	}
+}
+
+func (s *replicatorQueueProcessorSuite) mockDomain(domainID string, clusters ...string) {
+	replicationConfig := &persistence.DomainReplicationConfig{ActiveClusterName: cluster.TestCurrentClusterName}
+	for _, clusterName := range clusters {
+		replicationConfig.Clusters = append(replicationConfig.Clusters, &persistence.ClusterReplicationConfig{ClusterName: clusterName})
+	}
+	s.mockMetadataMgr.On("GetDomain", &persistence.GetDomainRequest{ID: domainID}).Return(
+		&persistence.GetDomainResponse{
+			Info:              &persistence.DomainInfo{ID: domainID},
+			Config:            &persistence.DomainConfig{Retention: 1},
+			ReplicationConfig: replicationConfig,
+			IsGlobalDomain:    true,
+			TableVersion:      persistence.DomainTableVersionV1,
+		}, nil)
+}
+
+func (s *replicatorQueueProcessorSuite) mockHistory() {
+	s.mockHistoryMgr.On("GetWorkflowExecutionHistory", mock.Anything).Return(&persistence.GetWorkflowExecutionHistoryResponse{
+		History: &shared.History{Events: []*shared.HistoryEvent{{
+			EventId:   common.Int64Ptr(common.FirstEventID),
+			EventType: shared.EventTypeWorkflowExecutionStarted.Ptr(),
+		}}},
+	}, nil)
+}
@@ -47,6 +47,7 @@ func prepareNextRetryWithNowTime(a *persistence.ActivityInfo, errReason string,
 	// a retry is needed, update activity info for next retry
 	a.Attempt++
 	a.ScheduledTime = nextScheduleTime // update to next schedule time
+	a.RedirectedTime = time.Time{}
 	a.StartedID = common.EmptyEventID
 	a.RequestID = ""
 	a.StartedTime = time.Time{}
@@ -103,12 +103,23 @@ type Config struct {
 
 	// System Limits
 	MaximumBufferedEventsBatch dynamicconfig.IntPropertyFn
+	MaximumAcceptedUpdates     dynamicconfig.IntPropertyFn
 
 	// ShardUpdateMinInterval the minimal time interval which the shard info can be updated
 	ShardUpdateMinInterval dynamicconfig.DurationPropertyFn
 	// ShardSyncMinInterval the minimal time interval which the shard info should be sync to remote
 	ShardSyncMinInterval dynamicconfig.DurationPropertyFn
 
+	// EnableRPCReplication stops publishing replication tasks to kafka, remote clusters pull them
+	// with GetReplicationMessages instead. Both clusters should be switched together, the worker
+	// keeps consuming kafka so tasks published before the switch are still applied.
+	EnableRPCReplication dynamicconfig.BoolPropertyFn
+	// ReplicationTaskPollerWaitInterval is the wait before polling a remote shard which had no more tasks
+	ReplicationTaskPollerWaitInterval dynamicconfig.DurationPropertyFn
+	// ReplicationTaskPollerMaxRetryCount is how many times in a row a failing task is applied before it is moved
+	// to the DLQ, the poller waits longer after every failure
+	ReplicationTaskPollerMaxRetryCount dynamicconfig.IntPropertyFn
+
 	// Time to hold a poll request before returning an empty response
 	// right now only used by GetMutableState
 	LongPollExpirationInterval dynamicconfig.DurationPropertyFnWithDomainFilter
@@ -172,8 +183,12 @@ func NewConfig(dc *dynamicconfig.Collection, numberOfShards int) *Config {
 		ExecutionMgrNumConns:                                  dc.GetIntProperty(dynamicconfig.ExecutionMgrNumConns, 50),
 		HistoryMgrNumConns:                                    dc.GetIntProperty(dynamicconfig.HistoryMgrNumConns, 50),
 		MaximumBufferedEventsBatch:                            dc.GetIntProperty(dynamicconfig.MaximumBufferedEventsBatch, 100),
+		MaximumAcceptedUpdates:                                dc.GetIntProperty(dynamicconfig.MaximumAcceptedUpdates, 1000),
 		ShardUpdateMinInterval:                                dc.GetDurationProperty(dynamicconfig.ShardUpdateMinInterval, 5*time.Minute),
 		ShardSyncMinInterval:                                  dc.GetDurationProperty(dynamicconfig.ShardSyncMinInterval, 5*time.Minute),
+		EnableRPCReplication:                                  dc.GetBoolProperty(dynamicconfig.EnableRPCReplication, false),
+		ReplicationTaskPollerWaitInterval:                     dc.GetDurationProperty(dynamicconfig.ReplicationTaskPollerWaitInterval, 2*time.Second),
+		ReplicationTaskPollerMaxRetryCount:                    dc.GetIntProperty(dynamicconfig.ReplicationTaskPollerMaxRetryCount, 10),
 
 		// history client: client/history/client.go set the client timeout 30s
 		LongPollExpirationInterval: dc.GetDurationPropertyFilteredByDomain(
@@ -55,6 +55,8 @@ type (
 		UpdateTransferClusterAckLevel(cluster string, ackLevel int64) error
 		GetReplicatorAckLevel() int64
 		UpdateReplicatorAckLevel(ackLevel int64) error
+		GetClusterReplicationLevel(cluster string) int64
+		UpdateClusterReplicationLevel(cluster string, lastTaskID int64) error
 		GetTimerAckLevel() time.Time
 		UpdateTimerAckLevel(ackLevel time.Time) error
 		GetTimerClusterAckLevel(cluster string) time.Time
@@ -205,6 +207,31 @@ func (s *shardContextImpl) UpdateReplicatorAckLevel(ackLevel int64) error {
 	return s.updateShardInfoLocked()
 }
 
+func (s *shardContextImpl) GetClusterReplicationLevel(cluster string) int64 {
+	s.RLock()
+	defer s.RUnlock()
+
+	// if we can find corresponding replication level
+	if replicationLevel, ok := s.shardInfo.ClusterReplicationLevel[cluster]; ok {
+		return replicationLevel
+	}
+	// otherwise, the remote cluster has never pulled from this shard, so it starts
+	// from the replicator ack level, which is also where the kafka based replication stopped
+	return s.shardInfo.ReplicationAckLevel
+}
+
+func (s *shardContextImpl) UpdateClusterReplicationLevel(cluster string, lastTaskID int64) error {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.shardInfo.ClusterReplicationLevel == nil {
+		s.shardInfo.ClusterReplicationLevel = make(map[string]int64)
+	}
+	s.shardInfo.ClusterReplicationLevel[cluster] = lastTaskID
+	s.shardInfo.StolenSinceRenew = 0
+	return s.updateShardInfoLocked()
+}
+
 func (s *shardContextImpl) GetTimerAckLevel() time.Time {
 	s.RLock()
 	defer s.RUnlock()
@@ -956,6 +983,10 @@ func copyShardInfo(shardInfo *persistence.ShardInfo) *persistence.ShardInfo {
 	for k, v := range shardInfo.ClusterTimerAckLevel {
 		clusterTimerAckLevel[k] = v
 	}
+	clusterReplicationLevel := make(map[string]int64)
+	for k, v := range shardInfo.ClusterReplicationLevel {
+		clusterReplicationLevel[k] = v
+	}
 	shardInfoCopy := &persistence.ShardInfo{
 		ShardID:                   shardInfo.ShardID,
 		Owner:                     shardInfo.Owner,
@@ -968,6 +999,7 @@ func copyShardInfo(shardInfo *persistence.ShardInfo) *persistence.ShardInfo {
 		TimerFailoverLevels:       timerFailoverLevels,
 		ClusterTransferAckLevel:   clusterTransferAckLevel,
 		ClusterTimerAckLevel:      clusterTimerAckLevel,
+		ClusterReplicationLevel:   clusterReplicationLevel,
 		DomainNotificationVersion: shardInfo.DomainNotificationVersion,
 	}
 
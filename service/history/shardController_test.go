@@ -159,8 +159,9 @@ func (s *shardControllerSuite) TestAcquireShardSuccess() {
 						cluster.TestCurrentClusterName:     currentClusterTimerAck,
 						cluster.TestAlternativeClusterName: alternativeClusterTimerAck,
 					},
-					TransferFailoverLevels: map[string]persistence.TransferFailoverLevel{},
-					TimerFailoverLevels:    map[string]persistence.TimerFailoverLevel{},
+					ClusterReplicationLevel: map[string]int64{},
+					TransferFailoverLevels:  map[string]persistence.TransferFailoverLevel{},
+					TimerFailoverLevels:     map[string]persistence.TimerFailoverLevel{},
 				},
 				PreviousRangeID: 5,
 			}).Return(nil).Once()
@@ -249,8 +250,9 @@ func (s *shardControllerSuite) TestAcquireShardRenewSuccess() {
 					cluster.TestCurrentClusterName:     currentClusterTimerAck,
 					cluster.TestAlternativeClusterName: alternativeClusterTimerAck,
 				},
-				TransferFailoverLevels: map[string]persistence.TransferFailoverLevel{},
-				TimerFailoverLevels:    map[string]persistence.TimerFailoverLevel{},
+				ClusterReplicationLevel: map[string]int64{},
+				TransferFailoverLevels:  map[string]persistence.TransferFailoverLevel{},
+				TimerFailoverLevels:     map[string]persistence.TimerFailoverLevel{},
 			},
 			PreviousRangeID: 5,
 		}).Return(nil).Once()
@@ -324,8 +326,9 @@ func (s *shardControllerSuite) TestAcquireShardRenewLookupFailed() {
 					cluster.TestCurrentClusterName:     currentClusterTimerAck,
 					cluster.TestAlternativeClusterName: alternativeClusterTimerAck,
 				},
-				TransferFailoverLevels: map[string]persistence.TransferFailoverLevel{},
-				TimerFailoverLevels:    map[string]persistence.TimerFailoverLevel{},
+				ClusterReplicationLevel: map[string]int64{},
+				TransferFailoverLevels:  map[string]persistence.TransferFailoverLevel{},
+				TimerFailoverLevels:     map[string]persistence.TimerFailoverLevel{},
 			},
 			PreviousRangeID: 5,
 		}).Return(nil).Once()
@@ -620,8 +623,9 @@ func (s *shardControllerSuite) setupMocksForAcquireShard(shardID int, mockEngine
 				cluster.TestCurrentClusterName:     currentClusterTimerAck,
 				cluster.TestAlternativeClusterName: alternativeClusterTimerAck,
 			},
-			TransferFailoverLevels: map[string]persistence.TransferFailoverLevel{},
-			TimerFailoverLevels:    map[string]persistence.TimerFailoverLevel{},
+			ClusterReplicationLevel: map[string]int64{},
+			TransferFailoverLevels:  map[string]persistence.TransferFailoverLevel{},
+			TimerFailoverLevels:     map[string]persistence.TimerFailoverLevel{},
 		},
 		PreviousRangeID: currentRangeID,
 	}).Return(nil).Once()
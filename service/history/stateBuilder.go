@@ -184,6 +184,16 @@ func (b *stateBuilderImpl) applyEvents(domainID, requestID string, execution sha
 				b.timerTasks = append(b.timerTasks, timerTask)
 			}
 
+		case shared.EventTypeActivityTaskRedirected:
+			if err := b.msBuilder.ReplicateActivityTaskRedirectedEvent(event); err != nil {
+				return nil, nil, nil, err
+			}
+			b.transferTasks = append(b.transferTasks, b.scheduleActivityTransferTask(domainID, b.getTaskList(b.msBuilder),
+				event.ActivityTaskRedirectedEventAttributes.GetScheduledEventId()))
+			if timerTask := b.scheduleActivityTimerTask(event, b.msBuilder); timerTask != nil {
+				b.timerTasks = append(b.timerTasks, timerTask)
+			}
+
 		case shared.EventTypeActivityTaskCancelRequested:
 			b.msBuilder.ReplicateActivityTaskCancelRequestedEvent(event)
 
@@ -309,6 +319,9 @@ func (b *stateBuilderImpl) applyEvents(domainID, requestID string, execution sha
 		case shared.EventTypeWorkflowExecutionSignaled:
 			// No mutable state action is needed
 
+		case shared.EventTypeWorkflowExecutionUpdateAccepted:
+			b.msBuilder.ReplicateWorkflowExecutionUpdateAcceptedEvent(event)
+
 		case shared.EventTypeWorkflowExecutionCancelRequested:
 			b.msBuilder.ReplicateWorkflowExecutionCancelRequestedEvent(event)
 
@@ -406,7 +419,10 @@ func (b *stateBuilderImpl) applyEvents(domainID, requestID string, execution sha
 			newRunExecutionInfo.LastFirstEventID = startedEvent.GetEventId()
 			// Set the history from replication task on the newStateBuilder
 			newRunStateBuilder.SetHistoryBuilder(newHistoryBuilderFromEvents(newRunHistory.Events, b.logger))
-			sourceClusterName := b.clusterMetadata.ClusterNameForFailoverVersion(startedEvent.GetVersion())
+			sourceClusterName, err := b.clusterMetadata.ClusterNameForFailoverVersion(startedEvent.GetVersion())
+			if err != nil {
+				return nil, nil, nil, err
+			}
 			newRunStateBuilder.UpdateReplicationStateLastEventID(sourceClusterName, startedEvent.GetVersion(), nextEventID-1)
 
 			if startedAttributes.GetAttempt() == 0 {
@@ -337,7 +337,12 @@ func (tb *timerBuilder) loadActivityTimers(msBuilder mutableState) {
 					tb.activityTimers = append(tb.activityTimers, td)
 				}
 			} else {
-				scheduleToStartExpiry := v.ScheduledTime.Add(time.Duration(v.ScheduleToStartTimeout) * time.Second)
+				scheduleToStartTime := v.ScheduledTime
+				if v.RedirectedTime.After(scheduleToStartTime) {
+					// redirected to a fallback task list, the schedule to start timeout restarts from the redirect
+					scheduleToStartTime = v.RedirectedTime
+				}
+				scheduleToStartExpiry := scheduleToStartTime.Add(time.Duration(v.ScheduleToStartTimeout) * time.Second)
 				td := &timerDetails{
 					TimerSequenceID: TimerSequenceID{VisibilityTimestamp: scheduleToStartExpiry},
 					ActivityID:      v.ScheduleID,
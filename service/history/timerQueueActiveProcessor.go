@@ -287,7 +287,7 @@ Update_History_Loop:
 
 		// We apply the update to execution using optimistic concurrency.  If it fails due to a conflict than reload
 		// the history and try the operation again.
-		err = t.updateWorkflowExecution(context, msBuilder, scheduleNewDecision, false, timerTasks, nil)
+		err = t.updateWorkflowExecution(context, msBuilder, scheduleNewDecision, false, nil, timerTasks, nil)
 		if err != nil {
 			if err == ErrConflict {
 				continue Update_History_Loop
@@ -317,6 +317,7 @@ Update_History_Loop:
 		}
 		tBuilder := t.historyService.getTimerBuilder(&context.workflowExecution)
 
+		var transferTasks []persistence.Task
 		var timerTasks []persistence.Task
 		updateHistory := false
 		updateState := false
@@ -405,6 +406,15 @@ Update_History_Loop:
 					{
 						t.metricsClient.IncCounter(metrics.TimerActiveTaskActivityTimeoutScope, metrics.ScheduleToStartTimeoutCounter)
 						if ai.StartedID == common.EmptyEventID {
+							transferTask, err := t.redirectActivityToFallbackTaskList(msBuilder, ai, timeoutType)
+							if err != nil {
+								return err
+							}
+							if transferTask != nil {
+								transferTasks = append(transferTasks, transferTask)
+								updateState = true
+								continue
+							}
 							if msBuilder.AddActivityTaskTimedOutEvent(ai.ScheduleID, ai.StartedID, timeoutType, nil) == nil {
 								return errFailedToAddTimeoutEvent
 							}
@@ -435,11 +445,18 @@ Update_History_Loop:
 			}
 		}
 
+		if len(transferTasks) != 0 {
+			// redirected activities restart their schedule to start timeout, which might now be the earliest timer
+			if tt := t.historyService.getTimerBuilder(&context.workflowExecution).GetActivityTimerTaskIfNeeded(msBuilder); tt != nil {
+				timerTasks = append(timerTasks, tt)
+			}
+		}
+
 		if updateHistory || updateState {
 			// We apply the update to execution using optimistic concurrency.  If it fails due to a conflict than reload
 			// the history and try the operation again.
 			scheduleNewDecision := updateHistory && !msBuilder.HasPendingDecisionTask()
-			err := t.updateWorkflowExecution(context, msBuilder, scheduleNewDecision, false, timerTasks, nil)
+			err := t.updateWorkflowExecution(context, msBuilder, scheduleNewDecision, false, transferTasks, timerTasks, nil)
 			if err != nil {
 				if err == ErrConflict {
 					continue Update_History_Loop
@@ -456,6 +473,59 @@ Update_History_Loop:
 	return ErrMaxAttemptsExceeded
 }
 
+// redirectActivityToFallbackTaskList moves an activity which was not picked up before its schedule to start
+// timeout onto the next of its fallback task lists, and returns the transfer task dispatching it there. Returns
+// nil if the activity has no fallback task list left.
+func (t *timerQueueActiveProcessorImpl) redirectActivityToFallbackTaskList(msBuilder mutableState,
+	ai *persistence.ActivityInfo, timeoutType workflow.TimeoutType) (persistence.Task, error) {
+	scheduledEvent, ok := msBuilder.GetActivityScheduledEvent(ai.ScheduleID)
+	if !ok {
+		return nil, nil
+	}
+	attributes := scheduledEvent.ActivityTaskScheduledEventAttributes
+	taskList, ok := getNextFallbackTaskList(attributes, ai.TaskList)
+	if !ok {
+		return nil, nil
+	}
+
+	targetDomainID := msBuilder.GetExecutionInfo().DomainID
+	if attributes.Domain != nil {
+		domainEntry, err := t.shard.GetDomainCache().GetDomain(attributes.GetDomain())
+		if err != nil {
+			return nil, &workflow.InternalServiceError{Message: "Unable to re-schedule activity across domain."}
+		}
+		targetDomainID = domainEntry.GetInfo().ID
+	}
+
+	if msBuilder.AddActivityTaskRedirectedEvent(ai.ScheduleID, taskList, timeoutType) == nil {
+		return nil, errFailedToAddRedirectEvent
+	}
+	t.metricsClient.IncCounter(metrics.TimerActiveTaskActivityTimeoutScope, metrics.ActivityRedirectedCounter)
+
+	return &persistence.ActivityTask{
+		DomainID:   targetDomainID,
+		TaskList:   taskList,
+		ScheduleID: ai.ScheduleID,
+	}, nil
+}
+
+// getNextFallbackTaskList returns the fallback task list following the one the activity is currently scheduled on
+func getNextFallbackTaskList(attributes *workflow.ActivityTaskScheduledEventAttributes, current string) (string, bool) {
+	next := 0
+	if current != attributes.TaskList.GetName() {
+		for i, taskList := range attributes.FallbackTaskLists {
+			if taskList.GetName() == current {
+				next = i + 1
+				break
+			}
+		}
+	}
+	if next >= len(attributes.FallbackTaskLists) {
+		return "", false
+	}
+	return attributes.FallbackTaskLists[next].GetName(), true
+}
+
 func (t *timerQueueActiveProcessorImpl) processDecisionTimeout(task *persistence.TimerTaskInfo) (retError error) {
 
 	context, release, err0 := t.cache.getOrCreateWorkflowExecution(t.timerQueueProcessorBase.getDomainIDAndWorkflowExecution(task))
@@ -514,7 +584,7 @@ Update_History_Loop:
 		if scheduleNewDecision {
 			// We apply the update to execution using optimistic concurrency.  If it fails due to a conflict than reload
 			// the history and try the operation again.
-			err := t.updateWorkflowExecution(context, msBuilder, scheduleNewDecision, false, nil, nil)
+			err := t.updateWorkflowExecution(context, msBuilder, scheduleNewDecision, false, nil, nil, nil)
 			if err != nil {
 				if err == ErrConflict {
 					continue Update_History_Loop
@@ -552,7 +622,7 @@ Update_History_Loop:
 		}
 
 		// schedule first decision task
-		err = t.updateWorkflowExecution(context, msBuilder, true, false, nil, nil)
+		err = t.updateWorkflowExecution(context, msBuilder, true, false, nil, nil, nil)
 		if err != nil {
 			if err == ErrConflict {
 				continue Update_History_Loop
@@ -619,6 +689,7 @@ func (t *timerQueueActiveProcessorImpl) processActivityRetryTimer(task *persiste
 			TaskList:                      taskList,
 			ScheduleId:                    &scheduledID,
 			ScheduleToStartTimeoutSeconds: common.Int32Ptr(scheduleToStartTimeout),
+			FairnessKey:                   scheduledEvent.ActivityTaskScheduledEventAttributes.FairnessKey,
 		})
 
 		t.logger.Debugf("Adding ActivityTask for retry, WorkflowID: %v, RunID: %v, ScheduledID: %v, TaskList: %v, Attempt: %v, Err: %v",
@@ -674,7 +745,7 @@ Update_History_Loop:
 
 			// We apply the update to execution using optimistic concurrency.  If it fails due to a conflict than reload
 			// the history and try the operation again.
-			err = t.updateWorkflowExecution(context, msBuilder, false, true, nil, nil)
+			err = t.updateWorkflowExecution(context, msBuilder, false, true, nil, nil, nil)
 			if err != nil {
 				if err == ErrConflict {
 					continue Update_History_Loop
@@ -742,11 +813,11 @@ func (t *timerQueueActiveProcessorImpl) updateWorkflowExecution(
 	msBuilder mutableState,
 	scheduleNewDecision bool,
 	createDeletionTask bool,
+	transferTasks []persistence.Task,
 	timerTasks []persistence.Task,
 	clearTimerTask persistence.Task,
 ) error {
 	executionInfo := msBuilder.GetExecutionInfo()
-	var transferTasks []persistence.Task
 	var err error
 	if scheduleNewDecision {
 		// Schedule a new decision.
@@ -23,6 +23,7 @@ package history
 import (
 	"errors"
 	"fmt"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -43,20 +44,22 @@ type (
 	timerQueueShutdown      func() error
 	timerTaskFilter         func(timer *persistence.TimerTaskInfo) (bool, error)
 	timerQueueProcessorImpl struct {
-		isGlobalDomainEnabled  bool
-		currentClusterName     string
-		shard                  ShardContext
-		config                 *Config
-		metricsClient          metrics.Client
-		historyService         *historyEngineImpl
-		ackLevel               TimerSequenceID
-		logger                 bark.Logger
-		matchingClient         matching.Client
-		isStarted              int32
-		isStopped              int32
-		shutdownChan           chan struct{}
-		activeTimerProcessor   *timerQueueActiveProcessorImpl
-		standbyTimerProcessors map[string]*timerQueueStandbyProcessorImpl
+		isGlobalDomainEnabled bool
+		currentClusterName    string
+		shard                 ShardContext
+		config                *Config
+		metricsClient         metrics.Client
+		historyService        *historyEngineImpl
+		ackLevel              TimerSequenceID
+		logger                bark.Logger
+		matchingClient        matching.Client
+		isStarted             int32
+		isStopped             int32
+		shutdownChan          chan struct{}
+		activeTimerProcessor  *timerQueueActiveProcessorImpl
+		// standbyTimerProcessors is refreshed when the clusters change
+		standbyTimerProcessorsLock sync.RWMutex
+		standbyTimerProcessors     map[string]*timerQueueStandbyProcessorImpl
 	}
 )
 
@@ -94,9 +97,11 @@ func (t *timerQueueProcessorImpl) Start() {
 	}
 	t.activeTimerProcessor.Start()
 	if t.isGlobalDomainEnabled {
+		t.standbyTimerProcessorsLock.RLock()
 		for _, standbyTimerProcessor := range t.standbyTimerProcessors {
 			standbyTimerProcessor.Start()
 		}
+		t.standbyTimerProcessorsLock.RUnlock()
 	}
 	go t.completeTimersLoop()
 }
@@ -107,13 +112,49 @@ func (t *timerQueueProcessorImpl) Stop() {
 	}
 	t.activeTimerProcessor.Stop()
 	if t.isGlobalDomainEnabled {
+		t.standbyTimerProcessorsLock.RLock()
 		for _, standbyTimerProcessor := range t.standbyTimerProcessors {
 			standbyTimerProcessor.Stop()
 		}
+		t.standbyTimerProcessorsLock.RUnlock()
 	}
 	close(t.shutdownChan)
 }
 
+// RefreshStandbyProcessors creates a standby processor for each cluster registered since the processor was created
+// and stops the ones of the clusters no longer known
+func (t *timerQueueProcessorImpl) RefreshStandbyProcessors() {
+	t.standbyTimerProcessorsLock.Lock()
+	defer t.standbyTimerProcessorsLock.Unlock()
+
+	if atomic.LoadInt32(&t.isStopped) == 1 {
+		return
+	}
+	isStarted := atomic.LoadInt32(&t.isStarted) == 1 && t.isGlobalDomainEnabled
+
+	allClusters := t.shard.GetService().GetClusterMetadata().GetAllClusterFailoverVersions()
+	for clusterName, standbyTimerProcessor := range t.standbyTimerProcessors {
+		if _, ok := allClusters[clusterName]; !ok {
+			if isStarted {
+				standbyTimerProcessor.Stop()
+			}
+			delete(t.standbyTimerProcessors, clusterName)
+			t.logger.Infof("Timer standby processor removed for cluster %v.", clusterName)
+		}
+	}
+	for clusterName := range allClusters {
+		if _, ok := t.standbyTimerProcessors[clusterName]; ok || clusterName == t.currentClusterName {
+			continue
+		}
+		standbyTimerProcessor := newTimerQueueStandbyProcessor(t.shard, t.historyService, clusterName, t.logger)
+		t.standbyTimerProcessors[clusterName] = standbyTimerProcessor
+		if isStarted {
+			standbyTimerProcessor.Start()
+		}
+		t.logger.Infof("Timer standby processor added for cluster %v.", clusterName)
+	}
+}
+
 // NotifyNewTimers - Notify the processor about the new active / standby timer arrival.
 // This should be called each time new timer arrives, otherwise timers maybe fired unexpected.
 func (t *timerQueueProcessorImpl) NotifyNewTimers(clusterName string, currentTime time.Time, timerTasks []persistence.Task) {
@@ -122,9 +163,14 @@ func (t *timerQueueProcessorImpl) NotifyNewTimers(clusterName string, currentTim
 		return
 	}
 
+	t.standbyTimerProcessorsLock.RLock()
 	standbyTimerProcessor, ok := t.standbyTimerProcessors[clusterName]
+	t.standbyTimerProcessorsLock.RUnlock()
 	if !ok {
-		panic(fmt.Sprintf("Cannot find timer processor for %s.", clusterName))
+		// the cluster is registered but its processor is not created yet, the processor reads the persisted
+		// timers from its ack level once created
+		t.logger.Warnf("Cannot find timer processor for %s.", clusterName)
+		return
 	}
 	standbyTimerProcessor.setCurrentTime(currentTime)
 	standbyTimerProcessor.notifyNewTimers(timerTasks)
@@ -132,9 +178,12 @@ func (t *timerQueueProcessorImpl) NotifyNewTimers(clusterName string, currentTim
 }
 
 func (t *timerQueueProcessorImpl) FailoverDomain(domainID string) {
+	t.standbyTimerProcessorsLock.RLock()
+	defer t.standbyTimerProcessorsLock.RUnlock()
+
 	minLevel := t.shard.GetTimerClusterAckLevel(t.currentClusterName)
 	standbyClusterName := t.currentClusterName
-	for cluster := range t.shard.GetService().GetClusterMetadata().GetAllClusterFailoverVersions() {
+	for cluster := range t.standbyTimerProcessors {
 		ackLevel := t.shard.GetTimerClusterAckLevel(cluster)
 		if ackLevel.Before(minLevel) {
 			minLevel = ackLevel
@@ -171,7 +220,9 @@ func (t *timerQueueProcessorImpl) getTimerFiredCount(clusterName string) uint64
 		return t.activeTimerProcessor.getTimerFiredCount()
 	}
 
+	t.standbyTimerProcessorsLock.RLock()
 	standbyTimerProcessor, ok := t.standbyTimerProcessors[clusterName]
+	t.standbyTimerProcessorsLock.RUnlock()
 	if !ok {
 		panic(fmt.Sprintf("Cannot find timer processor for %s.", clusterName))
 	}
@@ -209,12 +260,14 @@ func (t *timerQueueProcessorImpl) completeTimers() error {
 	upperAckLevel := t.activeTimerProcessor.timerQueueAckMgr.getAckLevel()
 
 	if t.isGlobalDomainEnabled {
+		t.standbyTimerProcessorsLock.RLock()
 		for _, standbyTimerProcessor := range t.standbyTimerProcessors {
 			ackLevel := standbyTimerProcessor.timerQueueAckMgr.getAckLevel()
 			if !compareTimerIDLess(&upperAckLevel, &ackLevel) {
 				upperAckLevel = ackLevel
 			}
 		}
+		t.standbyTimerProcessorsLock.RUnlock()
 
 		for _, failoverInfo := range t.shard.GetAllTimerFailoverLevels() {
 			if !upperAckLevel.VisibilityTimestamp.Before(failoverInfo.MinLevel) {
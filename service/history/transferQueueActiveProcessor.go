@@ -251,10 +251,11 @@ func (t *transferQueueActiveProcessorImpl) processActivityTask(task *persistence
 	}
 
 	timeout := common.MinInt32(ai.ScheduleToStartTimeout, common.MaxTaskTimeout)
+	fairnessKey := getActivityFairnessKey(msBuilder, task.ScheduleID)
 	// release the context lock since we no longer need mutable state builder and
 	// the rest of logic is making RPC call, which takes time.
 	release(nil)
-	err = t.pushActivity(task, timeout)
+	err = t.pushActivity(task, timeout, fairnessKey)
 	return err
 }
 
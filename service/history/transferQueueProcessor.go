@@ -22,7 +22,7 @@ package history
 
 import (
 	"errors"
-	"fmt"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -58,7 +58,9 @@ type (
 		isStopped             int32
 		shutdownChan          chan struct{}
 		activeTaskProcessor   *transferQueueActiveProcessorImpl
-		standbyTaskProcessors map[string]*transferQueueStandbyProcessorImpl
+		// standbyTaskProcessors is refreshed when the clusters change
+		standbyTaskProcessorsLock sync.RWMutex
+		standbyTaskProcessors     map[string]*transferQueueStandbyProcessorImpl
 	}
 )
 
@@ -101,9 +103,11 @@ func (t *transferQueueProcessorImpl) Start() {
 	}
 	t.activeTaskProcessor.Start()
 	if t.isGlobalDomainEnabled {
+		t.standbyTaskProcessorsLock.RLock()
 		for _, standbyTaskProcessor := range t.standbyTaskProcessors {
 			standbyTaskProcessor.Start()
 		}
+		t.standbyTaskProcessorsLock.RUnlock()
 	}
 
 	go t.completeTransferLoop()
@@ -115,13 +119,51 @@ func (t *transferQueueProcessorImpl) Stop() {
 	}
 	t.activeTaskProcessor.Stop()
 	if t.isGlobalDomainEnabled {
+		t.standbyTaskProcessorsLock.RLock()
 		for _, standbyTaskProcessor := range t.standbyTaskProcessors {
 			standbyTaskProcessor.Stop()
 		}
+		t.standbyTaskProcessorsLock.RUnlock()
 	}
 	close(t.shutdownChan)
 }
 
+// RefreshStandbyProcessors creates a standby processor for each cluster registered since the processor was created
+// and stops the ones of the clusters no longer known
+func (t *transferQueueProcessorImpl) RefreshStandbyProcessors() {
+	t.standbyTaskProcessorsLock.Lock()
+	defer t.standbyTaskProcessorsLock.Unlock()
+
+	if atomic.LoadInt32(&t.isStopped) == 1 {
+		return
+	}
+	isStarted := atomic.LoadInt32(&t.isStarted) == 1 && t.isGlobalDomainEnabled
+
+	allClusters := t.shard.GetService().GetClusterMetadata().GetAllClusterFailoverVersions()
+	for clusterName, standbyTaskProcessor := range t.standbyTaskProcessors {
+		if _, ok := allClusters[clusterName]; !ok {
+			if isStarted {
+				standbyTaskProcessor.Stop()
+			}
+			delete(t.standbyTaskProcessors, clusterName)
+			t.logger.Infof("Transfer standby processor removed for cluster %v.", clusterName)
+		}
+	}
+	for clusterName := range allClusters {
+		if _, ok := t.standbyTaskProcessors[clusterName]; ok || clusterName == t.currentClusterName {
+			continue
+		}
+		standbyTaskProcessor := newTransferQueueStandbyProcessor(
+			clusterName, t.shard, t.historyService, t.visibilityMgr, t.matchingClient, t.logger,
+		)
+		t.standbyTaskProcessors[clusterName] = standbyTaskProcessor
+		if isStarted {
+			standbyTaskProcessor.Start()
+		}
+		t.logger.Infof("Transfer standby processor added for cluster %v.", clusterName)
+	}
+}
+
 // NotifyNewTask - Notify the processor about the new active / standby transfer task arrival.
 // This should be called each time new transfer task arrives, otherwise tasks maybe delayed.
 func (t *transferQueueProcessorImpl) NotifyNewTask(clusterName string, transferTasks []persistence.Task) {
@@ -133,9 +175,14 @@ func (t *transferQueueProcessorImpl) NotifyNewTask(clusterName string, transferT
 		return
 	}
 
+	t.standbyTaskProcessorsLock.RLock()
 	standbyTaskProcessor, ok := t.standbyTaskProcessors[clusterName]
+	t.standbyTaskProcessorsLock.RUnlock()
 	if !ok {
-		panic(fmt.Sprintf("Cannot find transfer processor for %s.", clusterName))
+		// the cluster is registered but its processor is not created yet, the processor reads the persisted
+		// tasks from its ack level once created
+		t.logger.Warnf("Cannot find transfer processor for %s.", clusterName)
+		return
 	}
 	if len(transferTasks) != 0 {
 		standbyTaskProcessor.notifyNewTask()
@@ -144,9 +191,12 @@ func (t *transferQueueProcessorImpl) NotifyNewTask(clusterName string, transferT
 }
 
 func (t *transferQueueProcessorImpl) FailoverDomain(domainID string) {
+	t.standbyTaskProcessorsLock.RLock()
+	defer t.standbyTaskProcessorsLock.RUnlock()
+
 	minLevel := t.shard.GetTransferClusterAckLevel(t.currentClusterName)
 	standbyClusterName := t.currentClusterName
-	for cluster := range t.shard.GetService().GetClusterMetadata().GetAllClusterFailoverVersions() {
+	for cluster := range t.standbyTaskProcessors {
 		ackLevel := t.shard.GetTransferClusterAckLevel(cluster)
 		if ackLevel < minLevel {
 			minLevel = ackLevel
@@ -212,12 +262,14 @@ func (t *transferQueueProcessorImpl) completeTransfer() error {
 	upperAckLevel := t.activeTaskProcessor.queueAckMgr.getQueueAckLevel()
 
 	if t.isGlobalDomainEnabled {
+		t.standbyTaskProcessorsLock.RLock()
 		for _, standbyTaskProcessor := range t.standbyTaskProcessors {
 			ackLevel := standbyTaskProcessor.queueAckMgr.getQueueAckLevel()
 			if upperAckLevel > ackLevel {
 				upperAckLevel = ackLevel
 			}
 		}
+		t.standbyTaskProcessorsLock.RUnlock()
 
 		for _, failoverInfo := range t.shard.GetAllTransferFailoverLevels() {
 			if upperAckLevel > failoverInfo.MinLevel {
@@ -95,7 +95,8 @@ func (t *transferQueueProcessorBase) queueShutdown() error {
 	return t.transferQueueShutdown()
 }
 
-func (t *transferQueueProcessorBase) pushActivity(task *persistence.TransferTaskInfo, activityScheduleToStartTimeout int32) error {
+func (t *transferQueueProcessorBase) pushActivity(task *persistence.TransferTaskInfo, activityScheduleToStartTimeout int32,
+	fairnessKey *string) error {
 	if task.TaskType != persistence.TransferTaskTypeActivityTask {
 		t.logger.WithField(logging.TagTaskType, task.GetTaskType()).Fatal("Cannnot process non activity task")
 	}
@@ -110,11 +111,21 @@ func (t *transferQueueProcessorBase) pushActivity(task *persistence.TransferTask
 		TaskList:                      &workflow.TaskList{Name: &task.TaskList},
 		ScheduleId:                    &task.ScheduleID,
 		ScheduleToStartTimeoutSeconds: common.Int32Ptr(activityScheduleToStartTimeout),
+		FairnessKey:                   fairnessKey,
 	})
 
 	return err
 }
 
+// getActivityFairnessKey returns the fairness key the activity was scheduled with, nil if none was supplied
+func getActivityFairnessKey(msBuilder mutableState, scheduleID int64) *string {
+	scheduledEvent, ok := msBuilder.GetActivityScheduledEvent(scheduleID)
+	if !ok {
+		return nil
+	}
+	return scheduledEvent.ActivityTaskScheduledEventAttributes.FairnessKey
+}
+
 func (t *transferQueueProcessorBase) pushDecision(task *persistence.TransferTaskInfo, tasklist *workflow.TaskList, decisionScheduleToStartTimeout int32) error {
 	if task.TaskType != persistence.TransferTaskTypeDecisionTask {
 		t.logger.WithField(logging.TagTaskType, task.GetTaskType()).Fatal("Cannnot process non decision task")
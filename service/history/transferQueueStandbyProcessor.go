@@ -149,6 +149,7 @@ func (t *transferQueueStandbyProcessorImpl) process(qTask queueTaskInfo) (int, e
 func (t *transferQueueStandbyProcessorImpl) processActivityTask(transferTask *persistence.TransferTaskInfo) error {
 
 	var activityScheduleToStartTimeout *int32
+	var fairnessKey *string
 	processTaskIfClosed := false
 	return t.processTransfer(processTaskIfClosed, transferTask, func(msBuilder mutableState) error {
 		activityInfo, isPending := msBuilder.GetActivityInfo(transferTask.ScheduleID)
@@ -171,6 +172,7 @@ func (t *transferQueueStandbyProcessorImpl) processActivityTask(transferTask *pe
 			}
 
 			activityScheduleToStartTimeout = common.Int32Ptr(common.MinInt32(activityInfo.ScheduleToStartTimeout, common.MaxTaskTimeout))
+			fairnessKey = getActivityFairnessKey(msBuilder, transferTask.ScheduleID)
 			return nil
 		}
 
@@ -181,7 +183,7 @@ func (t *transferQueueStandbyProcessorImpl) processActivityTask(transferTask *pe
 		}
 
 		timeout := common.MinInt32(*activityScheduleToStartTimeout, common.MaxTaskTimeout)
-		err := t.pushActivity(transferTask, timeout)
+		err := t.pushActivity(transferTask, timeout, fairnessKey)
 		return err
 	})
 }
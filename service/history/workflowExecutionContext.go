@@ -185,7 +185,11 @@ func (c *workflowExecutionContext) updateWorkflowExecution(transferTasks []persi
 	if c.msBuilder.GetReplicationState() != nil {
 		currentVersion := c.msBuilder.GetCurrentVersion()
 
-		activeCluster := c.clusterMetadata.ClusterNameForFailoverVersion(currentVersion)
+		activeCluster, err := c.clusterMetadata.ClusterNameForFailoverVersion(currentVersion)
+		if err != nil {
+			c.clear()
+			return err
+		}
 		currentCluster := c.clusterMetadata.GetCurrentClusterName()
 		if activeCluster != currentCluster {
 			domainID := c.msBuilder.GetExecutionInfo().DomainID
@@ -364,6 +368,8 @@ func (c *workflowExecutionContext) updateHelper(transferTasks []persistence.Task
 		DeleteSignalInfo:              updates.deleteSignalInfo,
 		UpsertSignalRequestedIDs:      updates.updateSignalRequestedIDs,
 		DeleteSignalRequestedID:       updates.deleteSignalRequestedID,
+		UpsertAcceptedUpdates:         updates.updateAcceptedUpdates,
+		DeleteAcceptedUpdates:         updates.deleteAcceptedUpdates,
 		NewBufferedEvents:             updates.newBufferedEvents,
 		ClearBufferedEvents:           updates.clearBufferedEvents,
 		NewBufferedReplicationTask:    updates.newBufferedReplicationEventsInfo,
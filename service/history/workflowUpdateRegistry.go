@@ -0,0 +1,206 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"sync"
+	"time"
+
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/cache"
+)
+
+const (
+	workflowUpdateResultCacheSize = 10000
+	workflowUpdateResultCacheTTL  = 10 * time.Minute
+)
+
+type (
+	// workflowUpdate is an update request waiting for a decision of the workflow to accept or reject it
+	workflowUpdate struct {
+		request *workflow.WorkflowUpdate
+		// scheduleID of the decision the update was last delivered with, EmptyEventID if not yet delivered
+		deliveredScheduleID int64
+		// number of callers waiting on the update, more than one when a request is retried
+		waiters int
+
+		doneCh   chan struct{}
+		response *workflow.UpdateWorkflowExecutionResponse
+		err      error
+	}
+
+	// workflowUpdateRegistry holds the pending updates of the workflows of a shard while their callers wait.
+	// Updates are only kept in memory since the caller of a synchronous update does not outlive the history host.
+	workflowUpdateRegistry struct {
+		sync.Mutex
+		updates map[workflowIdentifier]map[string]*workflowUpdate
+		// responses of accepted updates keyed by workflowUpdateKey, used to answer retried requests
+		accepted cache.Cache
+	}
+
+	workflowUpdateKey struct {
+		workflowIdentifier
+		updateID string
+	}
+)
+
+func newWorkflowUpdateRegistry() *workflowUpdateRegistry {
+	return &workflowUpdateRegistry{
+		updates:  make(map[workflowIdentifier]map[string]*workflowUpdate),
+		accepted: cache.New(workflowUpdateResultCacheSize, &cache.Options{TTL: workflowUpdateResultCacheTTL}),
+	}
+}
+
+// register adds an update for the workflow, or joins the pending update with the same ID
+func (r *workflowUpdateRegistry) register(identifier workflowIdentifier, request *workflow.WorkflowUpdate) *workflowUpdate {
+	r.Lock()
+	defer r.Unlock()
+
+	updates, ok := r.updates[identifier]
+	if !ok {
+		updates = make(map[string]*workflowUpdate)
+		r.updates[identifier] = updates
+	}
+	update, ok := updates[request.GetUpdateId()]
+	if !ok {
+		update = &workflowUpdate{
+			request:             request,
+			deliveredScheduleID: common.EmptyEventID,
+			doneCh:              make(chan struct{}),
+		}
+		updates[request.GetUpdateId()] = update
+	}
+	update.waiters++
+	return update
+}
+
+// release is called when a caller stops waiting on the update, the update is dropped once nobody waits on it
+func (r *workflowUpdateRegistry) release(identifier workflowIdentifier, update *workflowUpdate) {
+	r.Lock()
+	defer r.Unlock()
+
+	update.waiters--
+	if update.waiters > 0 {
+		return
+	}
+	updates := r.updates[identifier]
+	if updates[update.request.GetUpdateId()] == update {
+		r.deleteLocked(identifier, update.request.GetUpdateId())
+	}
+}
+
+// getAccepted returns the response of an update accepted recently, or nil if it is not known
+func (r *workflowUpdateRegistry) getAccepted(identifier workflowIdentifier, updateID string) *workflow.UpdateWorkflowExecutionResponse {
+	if response, ok := r.accepted.Get(workflowUpdateKey{identifier, updateID}).(*workflow.UpdateWorkflowExecutionResponse); ok {
+		return response
+	}
+	return nil
+}
+
+// get returns the request of a pending update, or nil if it is not known
+func (r *workflowUpdateRegistry) get(identifier workflowIdentifier, updateID string) *workflow.WorkflowUpdate {
+	r.Lock()
+	defer r.Unlock()
+
+	if update, ok := r.updates[identifier][updateID]; ok {
+		return update.request
+	}
+	return nil
+}
+
+// deliver returns all pending updates of the workflow and marks them as delivered with the given decision
+func (r *workflowUpdateRegistry) deliver(identifier workflowIdentifier, scheduleID int64) []*workflow.WorkflowUpdate {
+	r.Lock()
+	defer r.Unlock()
+
+	var requests []*workflow.WorkflowUpdate
+	for _, update := range r.updates[identifier] {
+		update.deliveredScheduleID = scheduleID
+		requests = append(requests, update.request)
+	}
+	return requests
+}
+
+// hasUndelivered returns true if the workflow has pending updates that were not delivered with the given decision
+func (r *workflowUpdateRegistry) hasUndelivered(identifier workflowIdentifier, scheduleID int64) bool {
+	r.Lock()
+	defer r.Unlock()
+
+	for _, update := range r.updates[identifier] {
+		if update.deliveredScheduleID != scheduleID {
+			return true
+		}
+	}
+	return false
+}
+
+// complete answers the callers waiting on an update, it is a no-op if the update is not known
+func (r *workflowUpdateRegistry) complete(identifier workflowIdentifier, updateID string,
+	response *workflow.UpdateWorkflowExecutionResponse) {
+	r.Lock()
+	defer r.Unlock()
+
+	if response.Rejected == nil {
+		r.accepted.Put(workflowUpdateKey{identifier, updateID}, response)
+	}
+	if update, ok := r.updates[identifier][updateID]; ok {
+		update.response = response
+		r.deleteLocked(identifier, updateID)
+		close(update.doneCh)
+	}
+}
+
+// rejectDelivered rejects the pending updates delivered with the given decision which the decision did not answer
+func (r *workflowUpdateRegistry) rejectDelivered(identifier workflowIdentifier, scheduleID int64, reason string) {
+	r.Lock()
+	defer r.Unlock()
+
+	for updateID, update := range r.updates[identifier] {
+		if update.deliveredScheduleID == scheduleID {
+			update.response = &workflow.UpdateWorkflowExecutionResponse{
+				Rejected: &workflow.WorkflowUpdateRejected{Reason: common.StringPtr(reason)},
+			}
+			r.deleteLocked(identifier, updateID)
+			close(update.doneCh)
+		}
+	}
+}
+
+// failAll fails all pending updates of the workflow with the given error
+func (r *workflowUpdateRegistry) failAll(identifier workflowIdentifier, err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	for updateID, update := range r.updates[identifier] {
+		update.err = err
+		r.deleteLocked(identifier, updateID)
+		close(update.doneCh)
+	}
+}
+
+func (r *workflowUpdateRegistry) deleteLocked(identifier workflowIdentifier, updateID string) {
+	updates := r.updates[identifier]
+	delete(updates, updateID)
+	if len(updates) == 0 {
+		delete(r.updates, identifier)
+	}
+}
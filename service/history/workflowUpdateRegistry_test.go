@@ -0,0 +1,104 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	gen "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+)
+
+type (
+	workflowUpdateRegistrySuite struct {
+		suite.Suite
+		registry   *workflowUpdateRegistry
+		identifier workflowIdentifier
+	}
+)
+
+func TestWorkflowUpdateRegistrySuite(t *testing.T) {
+	s := new(workflowUpdateRegistrySuite)
+	suite.Run(t, s)
+}
+
+func (s *workflowUpdateRegistrySuite) SetupTest() {
+	s.registry = newWorkflowUpdateRegistry()
+	s.identifier = workflowIdentifier{domainID: "domain ID", workflowID: "workflow ID", runID: "run ID"}
+}
+
+func (s *workflowUpdateRegistrySuite) newUpdate(updateID string) *gen.WorkflowUpdate {
+	return &gen.WorkflowUpdate{
+		UpdateId:   common.StringPtr(updateID),
+		UpdateName: common.StringPtr("update name"),
+	}
+}
+
+func (s *workflowUpdateRegistrySuite) TestRegisterDeduplicates() {
+	update1 := s.registry.register(s.identifier, s.newUpdate("update ID"))
+	update2 := s.registry.register(s.identifier, s.newUpdate("update ID"))
+	s.Equal(update1, update2)
+	s.Equal(2, update1.waiters)
+
+	// the update stays pending until the last caller stops waiting
+	s.registry.release(s.identifier, update1)
+	s.NotNil(s.registry.get(s.identifier, "update ID"))
+	s.registry.release(s.identifier, update2)
+	s.Nil(s.registry.get(s.identifier, "update ID"))
+	s.Empty(s.registry.updates)
+}
+
+func (s *workflowUpdateRegistrySuite) TestDeliverAndComplete() {
+	update1 := s.registry.register(s.identifier, s.newUpdate("update ID 1"))
+	s.True(s.registry.hasUndelivered(s.identifier, 5))
+
+	s.Equal(1, len(s.registry.deliver(s.identifier, 5)))
+	s.False(s.registry.hasUndelivered(s.identifier, 5))
+
+	update2 := s.registry.register(s.identifier, s.newUpdate("update ID 2"))
+	s.True(s.registry.hasUndelivered(s.identifier, 5))
+
+	response := &gen.UpdateWorkflowExecutionResponse{Result: []byte("result")}
+	s.registry.complete(s.identifier, "update ID 1", response)
+	<-update1.doneCh
+	s.Equal(response, update1.response)
+	s.Equal(response, s.registry.getAccepted(s.identifier, "update ID 1"))
+
+	// update 2 was not delivered with the decision, so it is not rejected with it
+	s.registry.rejectDelivered(s.identifier, 5, "not handled")
+	s.NotNil(s.registry.get(s.identifier, "update ID 2"))
+
+	s.registry.failAll(s.identifier, ErrWorkflowCompleted)
+	<-update2.doneCh
+	s.Equal(ErrWorkflowCompleted, update2.err)
+	s.Empty(s.registry.updates)
+}
+
+func (s *workflowUpdateRegistrySuite) TestRejectDelivered() {
+	update := s.registry.register(s.identifier, s.newUpdate("update ID"))
+	s.registry.deliver(s.identifier, 5)
+
+	s.registry.rejectDelivered(s.identifier, 5, "not handled")
+	<-update.doneCh
+	s.Equal("not handled", update.response.Rejected.GetReason())
+	s.Nil(s.registry.getAccepted(s.identifier, "update ID"))
+}
@@ -0,0 +1,146 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package matching
+
+import (
+	"container/list"
+	"time"
+
+	"github.com/uber/cadence/common/persistence"
+)
+
+type (
+	// fairTaskQueue buffers backlog tasks per fairness key and hands them out in round-robin order,
+	// each key dispatching up to quantum tasks per turn. This keeps a single workflow with a large
+	// backlog from starving the other workflows sharing the task list. Not thread safe, it is only
+	// accessed by the task list's buffer delivery goroutine.
+	fairTaskQueue struct {
+		queues map[string]*list.List // fairness key -> FIFO of *fairTask
+		keys   *list.List            // round-robin ring of keys with pending tasks
+		// current is the key whose turn it is, served is the number of tasks it dispatched this turn
+		current *list.Element
+		served  int
+		size    int
+		// arrivals tracks buffered task IDs in arrival order to detect tasks dispatched ahead of older ones
+		arrivals   *list.List
+		dispatched map[int64]struct{}
+	}
+
+	fairTask struct {
+		task        *persistence.TaskInfo
+		enqueueTime time.Time
+	}
+)
+
+func newFairTaskQueue() *fairTaskQueue {
+	return &fairTaskQueue{
+		queues:     make(map[string]*list.List),
+		keys:       list.New(),
+		arrivals:   list.New(),
+		dispatched: make(map[int64]struct{}),
+	}
+}
+
+// fairnessKey returns the key tasks are interleaved by, the workflow ID unless the caller supplied one
+func fairnessKey(task *persistence.TaskInfo) string {
+	if task.FairnessKey != "" {
+		return task.FairnessKey
+	}
+	return task.WorkflowID
+}
+
+func (q *fairTaskQueue) add(task *persistence.TaskInfo) {
+	key := fairnessKey(task)
+	queue, ok := q.queues[key]
+	if !ok {
+		queue = list.New()
+		q.queues[key] = queue
+		// new keys join at the end of the current round
+		if q.current == nil {
+			q.current = q.keys.PushBack(key)
+		} else {
+			q.keys.InsertBefore(key, q.current)
+		}
+	}
+	queue.PushBack(&fairTask{task: task, enqueueTime: time.Now()})
+	q.arrivals.PushBack(task.TaskID)
+	q.size++
+}
+
+// peek returns the next task to dispatch without removing it, nil if the queue is empty
+func (q *fairTaskQueue) peek(quantum int) *fairTask {
+	if q.size == 0 {
+		return nil
+	}
+	if q.served >= quantum {
+		q.advance()
+	}
+	return q.queues[q.current.Value.(string)].Front().Value.(*fairTask)
+}
+
+// pop removes the task returned by the last peek and reports whether it was dispatched ahead of
+// an older buffered task
+func (q *fairTaskQueue) pop() (task *fairTask, reordered bool) {
+	key := q.current.Value.(string)
+	queue := q.queues[key]
+	task = queue.Remove(queue.Front()).(*fairTask)
+	q.size--
+	q.served++
+
+	reordered = q.arrivals.Front().Value.(int64) != task.task.TaskID
+	q.dispatched[task.task.TaskID] = struct{}{}
+	for e := q.arrivals.Front(); e != nil; e = q.arrivals.Front() {
+		taskID := e.Value.(int64)
+		if _, ok := q.dispatched[taskID]; !ok {
+			break
+		}
+		delete(q.dispatched, taskID)
+		q.arrivals.Remove(e)
+	}
+
+	if queue.Len() == 0 {
+		delete(q.queues, key)
+		next := q.current.Next()
+		q.keys.Remove(q.current)
+		q.current = next
+		if q.current == nil {
+			q.current = q.keys.Front()
+		}
+		q.served = 0
+	}
+	return task, reordered
+}
+
+func (q *fairTaskQueue) advance() {
+	q.current = q.current.Next()
+	if q.current == nil {
+		q.current = q.keys.Front()
+	}
+	q.served = 0
+}
+
+func (q *fairTaskQueue) len() int {
+	return q.size
+}
+
+func (q *fairTaskQueue) numKeys() int {
+	return len(q.queues)
+}
@@ -41,14 +41,15 @@ var _ matchingserviceserver.Interface = (*Handler)(nil)
 
 // Handler - Thrift handler inteface for history service
 type Handler struct {
-	taskPersistence persistence.TaskManager
-	metadataMgr     persistence.MetadataManager
-	engine          Engine
-	config          *Config
-	metricsClient   metrics.Client
-	startWG         sync.WaitGroup
-	domainCache     cache.DomainCache
-	rateLimiter     common.TokenBucket
+	taskPersistence  persistence.TaskManager
+	metadataMgr      persistence.MetadataManager
+	engine           Engine
+	config           *Config
+	metricsClient    metrics.Client
+	startWG          sync.WaitGroup
+	domainCache      cache.DomainCache
+	rateLimiter      common.TokenBucket
+	ownershipMonitor *taskListOwnershipMonitor
 	service.Service
 }
 
@@ -84,12 +85,24 @@ func (h *Handler) Start() error {
 	h.engine = NewEngine(
 		h.taskPersistence, history, h.config, h.Service.GetLogger(), h.Service.GetMetricsClient(), h.domainCache,
 	)
+	host, err := h.GetMembershipMonitor().WhoAmI()
+	if err != nil {
+		return err
+	}
+	resolver, err := h.GetMembershipMonitor().GetResolver(common.MatchingServiceName)
+	if err != nil {
+		return err
+	}
+	h.ownershipMonitor = newTaskListOwnershipMonitor(h.engine, host, resolver, h.GetLogger())
+	h.ownershipMonitor.Start()
 	h.startWG.Done()
 	return nil
 }
 
-// Stop stops the handler
+// Stop stops the handler. Task lists are released before the host leaves the ring, so that
+// their new owners can take over without waiting for the handoff timeout.
 func (h *Handler) Stop() {
+	h.ownershipMonitor.Stop()
 	h.engine.Stop()
 	h.domainCache.Stop()
 	h.taskPersistence.Close()
@@ -240,6 +253,21 @@ func (h *Handler) DescribeTaskList(ctx context.Context, request *m.DescribeTaskL
 	return response, h.handleErr(err, scope)
 }
 
+// ListTaskLists returns the task lists of a domain along with their ack level, last update time and whether
+// they are currently loaded by a matching host.
+func (h *Handler) ListTaskLists(ctx context.Context, request *m.ListTaskListsRequest) (*gen.ListTaskListsResponse, error) {
+	scope := metrics.MatchingListTaskListsScope
+	sw := h.startRequestProfile("ListTaskLists", scope)
+	defer sw.Stop()
+
+	if ok, _ := h.rateLimiter.TryConsume(1); !ok {
+		return nil, h.handleErr(errMatchingHostThrottle, scope)
+	}
+
+	response, err := h.engine.ListTaskLists(ctx, request)
+	return response, h.handleErr(err, scope)
+}
+
 func (h *Handler) handleErr(err error, scope int) error {
 
 	if err == nil {
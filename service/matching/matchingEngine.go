@@ -23,8 +23,9 @@ package matching
 import (
 	"context"
 	"errors"
-	"math"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	h "github.com/uber/cadence/.gen/go/history"
 	m "github.com/uber/cadence/.gen/go/matching"
@@ -59,6 +60,8 @@ type matchingEngineImpl struct {
 	// unblock QueryWorkflow() call.
 	queryTaskMap map[string]chan *workflow.RespondQueryTaskCompletedRequest
 	domainCache  cache.DomainCache
+	// draining is set once the engine starts handing its task lists over on shutdown
+	draining int32
 }
 
 type taskListID struct {
@@ -82,6 +85,8 @@ var (
 	ErrNoTasks    = errors.New("No tasks")
 	errPumpClosed = errors.New("Task list pump closed its channel")
 
+	errMatchingHostDraining = &workflow.ServiceBusyError{Message: "Matching host is handing off its task lists"}
+
 	pollerIDKey pollerIDCtxKey = "pollerID"
 	identityKey identityCtxKey = "identity"
 )
@@ -130,10 +135,33 @@ func (e *matchingEngineImpl) Start() {
 }
 
 func (e *matchingEngineImpl) Stop() {
-	// Executes Stop() on each task list outside of lock
-	for _, l := range e.getTaskLists(math.MaxInt32) {
-		l.Stop()
+	// Reject new task lists so that nothing gets loaded while the existing ones are handed over
+	atomic.StoreInt32(&e.draining, 1)
+	e.ReleaseTaskLists(func(string) bool { return true })
+}
+
+// ReleaseTaskLists gracefully hands over the loaded task lists whose name matches the filter.
+// Task lists are released in parallel and the call returns once all of them are released.
+func (e *matchingEngineImpl) ReleaseTaskLists(filter func(taskListName string) bool) {
+	// Executes Release() on each task list outside of lock
+	var lists []taskListManager
+	e.taskListsLock.RLock()
+	for id, tlMgr := range e.taskLists {
+		if filter(id.taskListName) {
+			lists = append(lists, tlMgr)
+		}
+	}
+	e.taskListsLock.RUnlock()
+
+	var wg sync.WaitGroup
+	wg.Add(len(lists))
+	for _, l := range lists {
+		go func(tlMgr taskListManager) {
+			defer wg.Done()
+			tlMgr.Release()
+		}(l)
 	}
+	wg.Wait()
 }
 
 func (e *matchingEngineImpl) getTaskLists(maxCount int) (lists []taskListManager) {
@@ -173,6 +201,9 @@ func (e *matchingEngineImpl) getTaskListManager(taskList *taskListID,
 		return result, nil
 	}
 	e.taskListsLock.RUnlock()
+	if atomic.LoadInt32(&e.draining) == 1 {
+		return nil, errMatchingHostDraining
+	}
 	// If it gets here, write lock and check again in case a task list is created between the two locks
 	e.taskListsLock.Lock()
 	if result, ok := e.taskLists[*taskList]; ok {
@@ -252,6 +283,7 @@ func (e *matchingEngineImpl) AddActivityTask(addRequest *m.AddActivityTaskReques
 		WorkflowID:             addRequest.Execution.GetWorkflowId(),
 		ScheduleID:             addRequest.GetScheduleId(),
 		ScheduleToStartTimeout: addRequest.GetScheduleToStartTimeoutSeconds(),
+		FairnessKey:            addRequest.GetFairnessKey(),
 	}
 	return tlMgr.AddTask(addRequest.Execution, taskInfo)
 }
@@ -311,8 +343,10 @@ pollLoop:
 				mutableStateResp.GetClientImpl(),
 			)
 
+			// a closed workflow is no longer cached by any sticky worker, so the full history is sent
 			isStickyEnabled := false
-			if len(mutableStateResp.StickyTaskList.GetName()) != 0 && clientFeature.SupportStickyQuery() {
+			if len(mutableStateResp.StickyTaskList.GetName()) != 0 && clientFeature.SupportStickyQuery() &&
+				mutableStateResp.GetIsWorkflowRunning() {
 				isStickyEnabled = true
 			}
 			resp := &h.RecordDecisionTaskStartedResponse{
@@ -512,6 +546,63 @@ func (e *matchingEngineImpl) DescribeTaskList(ctx context.Context, request *m.De
 	return &workflow.DescribeTaskListResponse{Pollers: pollers}, nil
 }
 
+func (e *matchingEngineImpl) ListTaskLists(ctx context.Context, request *m.ListTaskListsRequest) (*workflow.ListTaskListsResponse, error) {
+	domainID := request.GetDomainUUID()
+	domainEntry, err := e.domainCache.GetDomainByID(domainID)
+	if err != nil {
+		return nil, err
+	}
+	domainName := domainEntry.GetInfo().Name
+
+	resp, err := e.taskManager.ListTaskList(&persistence.ListTaskListRequest{
+		DomainID:  domainID,
+		PageSize:  int(request.ListRequest.GetPageSize()),
+		PageToken: request.ListRequest.NextPageToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	taskLists := make([]*workflow.TaskListStatus, 0, len(resp.Items))
+	for _, info := range resp.Items {
+		taskListType := workflow.TaskListTypeDecision
+		if info.TaskType == persistence.TaskListTypeActivity {
+			taskListType = workflow.TaskListTypeActivity
+		}
+		taskLists = append(taskLists, &workflow.TaskListStatus{
+			TaskList: &workflow.TaskList{
+				Name: common.StringPtr(info.Name),
+				Kind: common.TaskListKindPtr(workflow.TaskListKind(info.Kind)),
+			},
+			TaskListType:   common.TaskListTypePtr(taskListType),
+			AckLevel:       common.Int64Ptr(info.AckLevel),
+			LastUpdateTime: common.Int64Ptr(info.LastUpdated.UnixNano()),
+			Loaded:         common.BoolPtr(e.isTaskListLoaded(domainName, info)),
+		})
+	}
+	return &workflow.ListTaskListsResponse{
+		TaskLists:     taskLists,
+		NextPageToken: resp.NextPageToken,
+	}, nil
+}
+
+// isTaskListLoaded checks whether the task list is currently owned by a matching host. Task lists owned
+// by other hosts are not visible here, but an owner persists the ack level every UpdateAckInterval, so a
+// recent update means the task list is loaded somewhere.
+func (e *matchingEngineImpl) isTaskListLoaded(domainName string, info *persistence.TaskListInfo) bool {
+	e.taskListsLock.RLock()
+	_, ok := e.taskLists[*newTaskListID(info.DomainID, info.Name, info.TaskType)]
+	e.taskListsLock.RUnlock()
+	if ok {
+		return true
+	}
+	if info.Released {
+		return false
+	}
+	updateAckInterval := e.config.UpdateAckInterval(domainName, info.Name, info.TaskType)
+	return time.Since(info.LastUpdated) < 2*updateAckInterval
+}
+
 // Loads a task from persistence and wraps it in a task context
 func (e *matchingEngineImpl) getTask(
 	ctx context.Context, taskList *taskListID, maxDispatchPerSecond *float64, taskListKind *workflow.TaskListKind,
@@ -1343,6 +1343,46 @@ func (s *matchingEngineSuite) TestAddTaskAfterStartFailure() {
 	s.EqualValues(0, s.taskManager.getTaskCount(tlID))
 }
 
+func (s *matchingEngineSuite) TestListTaskLists() {
+	domainID := "domainId"
+	loadedTaskList := newTaskListID(domainID, "loadedTaskList", persistence.TaskListTypeActivity)
+	_, err := s.matchingEngine.getTaskListManager(loadedTaskList, nil)
+	s.NoError(err)
+
+	idleTaskList := newTaskListID(domainID, "idleTaskList", persistence.TaskListTypeDecision)
+	_, err = s.taskManager.LeaseTaskList(&persistence.LeaseTaskListRequest{
+		DomainID: idleTaskList.domainID,
+		TaskList: idleTaskList.taskListName,
+		TaskType: idleTaskList.taskType,
+	})
+	s.NoError(err)
+	s.taskManager.getTaskListManager(idleTaskList).lastUpdated = time.Now().Add(-time.Hour)
+
+	otherDomainTaskList := newTaskListID("otherDomainId", "loadedTaskList", persistence.TaskListTypeActivity)
+	_, err = s.matchingEngine.getTaskListManager(otherDomainTaskList, nil)
+	s.NoError(err)
+
+	resp, err := s.matchingEngine.ListTaskLists(s.callContext, &matching.ListTaskListsRequest{
+		DomainUUID:  common.StringPtr(domainID),
+		ListRequest: &workflow.ListTaskListsRequest{PageSize: common.Int32Ptr(10)},
+	})
+	s.NoError(err)
+	s.Equal(2, len(resp.TaskLists))
+	for _, tl := range resp.TaskLists {
+		switch tl.TaskList.GetName() {
+		case loadedTaskList.taskListName:
+			s.Equal(workflow.TaskListTypeActivity, tl.GetTaskListType())
+			s.True(tl.GetLoaded())
+		case idleTaskList.taskListName:
+			s.Equal(workflow.TaskListTypeDecision, tl.GetTaskListType())
+			s.False(tl.GetLoaded())
+		default:
+			s.Fail("unexpected task list", tl.TaskList.GetName())
+		}
+		s.Equal(workflow.TaskListKindNormal, tl.TaskList.GetKind())
+	}
+}
+
 func (s *matchingEngineSuite) TestTaskListManagerGetTaskBatch() {
 	runID := "run1"
 	workflowID := "workflow1"
@@ -1556,6 +1596,8 @@ type testTaskListManager struct {
 	sync.Mutex
 	rangeID         int64
 	ackLevel        int64
+	released        bool
+	lastUpdated     time.Time
 	createTaskCount int
 	tasks           *treemap.Map
 }
@@ -1583,16 +1625,19 @@ func (m *testTaskManager) LeaseTaskList(request *persistence.LeaseTaskListReques
 	tlm.Lock()
 	defer tlm.Unlock()
 	tlm.rangeID++
+	tlm.released = false
+	tlm.lastUpdated = time.Now()
 	m.logger.Debugf("LeaseTaskList rangeID=%v", tlm.rangeID)
 
 	return &persistence.LeaseTaskListResponse{
 		TaskListInfo: &persistence.TaskListInfo{
-			AckLevel: tlm.ackLevel,
-			DomainID: request.DomainID,
-			Name:     request.TaskList,
-			TaskType: request.TaskType,
-			RangeID:  tlm.rangeID,
-			Kind:     request.TaskListKind,
+			AckLevel:    tlm.ackLevel,
+			DomainID:    request.DomainID,
+			Name:        request.TaskList,
+			TaskType:    request.TaskType,
+			RangeID:     tlm.rangeID,
+			Kind:        request.TaskListKind,
+			LastUpdated: tlm.lastUpdated,
 		},
 	}, nil
 }
@@ -1612,9 +1657,36 @@ func (m *testTaskManager) UpdateTaskList(request *persistence.UpdateTaskListRequ
 		}
 	}
 	tlm.ackLevel = tli.AckLevel
+	tlm.released = tli.Released
+	tlm.lastUpdated = time.Now()
 	return &persistence.UpdateTaskListResponse{}, nil
 }
 
+// GetTaskList provides a mock function with given fields: request
+func (m *testTaskManager) GetTaskList(request *persistence.GetTaskListRequest) (*persistence.GetTaskListResponse, error) {
+	m.Lock()
+	tlm, ok := m.taskLists[*newTaskListID(request.DomainID, request.TaskList, request.TaskType)]
+	m.Unlock()
+	if !ok {
+		return nil, &workflow.EntityNotExistsError{Message: "task list does not exist"}
+	}
+
+	tlm.Lock()
+	defer tlm.Unlock()
+	return &persistence.GetTaskListResponse{
+		TaskListInfo: &persistence.TaskListInfo{
+			DomainID:    request.DomainID,
+			Name:        request.TaskList,
+			TaskType:    request.TaskType,
+			RangeID:     tlm.rangeID,
+			AckLevel:    tlm.ackLevel,
+			Kind:        persistence.TaskListKindNormal,
+			Released:    tlm.released,
+			LastUpdated: tlm.lastUpdated,
+		},
+	}, nil
+}
+
 // CompleteTask provides a mock function with given fields: request
 func (m *testTaskManager) CompleteTask(request *persistence.CompleteTaskRequest) error {
 	m.logger.Debugf("CompleteTask taskID=%v, ackLevel=%v", request.TaskID, request.TaskList.AckLevel)
@@ -1632,6 +1704,57 @@ func (m *testTaskManager) CompleteTask(request *persistence.CompleteTaskRequest)
 	return nil
 }
 
+// CompleteTasksLessThan provides a mock function with given fields: request
+func (m *testTaskManager) CompleteTasksLessThan(request *persistence.CompleteTasksLessThanRequest) (int, error) {
+	tlm := m.getTaskListManager(newTaskListID(request.DomainID, request.TaskListName, request.TaskType))
+	tlm.Lock()
+	defer tlm.Unlock()
+	count := 0
+	for _, key := range tlm.tasks.Keys() {
+		taskID := key.(int64)
+		if taskID > request.TaskID || count == request.Limit {
+			break
+		}
+		tlm.tasks.Remove(taskID)
+		count++
+	}
+	return count, nil
+}
+
+// ListTaskList provides a mock function with given fields: request
+func (m *testTaskManager) ListTaskList(request *persistence.ListTaskListRequest) (*persistence.ListTaskListResponse, error) {
+	m.Lock()
+	defer m.Unlock()
+	response := &persistence.ListTaskListResponse{}
+	for id, tlm := range m.taskLists {
+		if request.DomainID != "" && id.domainID != request.DomainID {
+			continue
+		}
+		tlm.Lock()
+		response.Items = append(response.Items, &persistence.TaskListInfo{
+			DomainID:    id.domainID,
+			Name:        id.taskListName,
+			TaskType:    id.taskType,
+			RangeID:     tlm.rangeID,
+			AckLevel:    tlm.ackLevel,
+			Kind:        persistence.TaskListKindNormal,
+			Released:    tlm.released,
+			LastUpdated: tlm.lastUpdated,
+		})
+		tlm.Unlock()
+	}
+	return response, nil
+}
+
+// DeleteTaskList provides a mock function with given fields: request
+func (m *testTaskManager) DeleteTaskList(request *persistence.DeleteTaskListRequest) error {
+	m.Lock()
+	defer m.Unlock()
+	key := newTaskListID(request.DomainID, request.TaskListName, request.TaskListType)
+	delete(m.taskLists, *key)
+	return nil
+}
+
 // CreateTask provides a mock function with given fields: request
 func (m *testTaskManager) CreateTasks(request *persistence.CreateTasksRequest) (*persistence.CreateTasksResponse, error) {
 	domainID := request.TaskListInfo.DomainID
@@ -1768,5 +1891,7 @@ func validateTimeRange(t time.Time, expectedDuration time.Duration) bool {
 func defaultTestConfig() *Config {
 	config := NewConfig(dynamicconfig.NewNopCollection())
 	config.LongPollExpirationInterval = dynamicconfig.GetDurationPropertyFnFilteredByTaskListInfo(100 * time.Millisecond)
+	// engines in range stealing tests share task lists without releasing them
+	config.TaskListHandoffTimeout = dynamicconfig.GetDurationPropertyFnFilteredByTaskListInfo(10 * time.Millisecond)
 	return config
 }
@@ -50,6 +50,14 @@ type Config struct {
 	// taskWriter configuration
 	OutstandingTaskAppendsThreshold dynamicconfig.IntPropertyFnWithTaskListInfoFilters
 	MaxTaskBatchSize                dynamicconfig.IntPropertyFnWithTaskListInfoFilters
+
+	// fair dispatch configuration
+	EnableFairDispatch     dynamicconfig.BoolPropertyFnWithTaskListInfoFilters
+	FairDispatchQuantum    dynamicconfig.IntPropertyFnWithTaskListInfoFilters
+	FairDispatchBufferSize dynamicconfig.IntPropertyFnWithTaskListInfoFilters
+
+	// handoff configuration
+	TaskListHandoffTimeout dynamicconfig.DurationPropertyFnWithTaskListInfoFilters
 }
 
 // NewConfig returns new service config with default values
@@ -67,6 +75,10 @@ func NewConfig(dc *dynamicconfig.Collection) *Config {
 		MinTaskThrottlingBurstSize:      dc.GetIntPropertyFilteredByTaskListInfo(dynamicconfig.MatchingMinTaskThrottlingBurstSize, 1),
 		OutstandingTaskAppendsThreshold: dc.GetIntPropertyFilteredByTaskListInfo(dynamicconfig.MatchingOutstandingTaskAppendsThreshold, 250),
 		MaxTaskBatchSize:                dc.GetIntPropertyFilteredByTaskListInfo(dynamicconfig.MatchingMaxTaskBatchSize, 100),
+		EnableFairDispatch:              dc.GetBoolPropertyFilteredByTaskListInfo(dynamicconfig.MatchingEnableFairDispatch, false),
+		FairDispatchQuantum:             dc.GetIntPropertyFilteredByTaskListInfo(dynamicconfig.MatchingFairDispatchQuantum, 1),
+		FairDispatchBufferSize:          dc.GetIntPropertyFilteredByTaskListInfo(dynamicconfig.MatchingFairDispatchBufferSize, 10000),
+		TaskListHandoffTimeout:          dc.GetDurationPropertyFilteredByTaskListInfo(dynamicconfig.MatchingTaskListHandoffTimeout, 10*time.Second),
 	}
 }
 
@@ -134,7 +146,7 @@ func (s *Service) Start() {
 
 	log.Infof("%v started", common.MatchingServiceName)
 	<-s.stopC
-	base.Stop()
+	handler.Stop()
 }
 
 // Stop stops the service
@@ -53,11 +53,18 @@ const (
 	_defaultTaskDispatchRPSTTL = 60 * time.Second
 )
 
+// handoffPollInterval is how often a new owner checks whether the previous owner released a task list
+const handoffPollInterval = 100 * time.Millisecond
+
 var errAddTasklistThrottled = errors.New("cannot add to tasklist, limit exceeded")
 
 type taskListManager interface {
 	Start() error
 	Stop()
+	// Release gracefully hands the task list over to its next owner. Unlike Stop, it drains pending
+	// appends and persists the ack level with the released flag so the next owner can take over the
+	// lease immediately.
+	Release()
 	AddTask(execution *s.WorkflowExecution, taskInfo *persistence.TaskInfo) (syncMatch bool, err error)
 	GetTaskContext(ctx context.Context, maxDispatchPerSecond *float64) (*taskContext, error)
 	SyncMatchQueryTask(ctx context.Context, queryTask *queryTaskInfo) error
@@ -79,6 +86,12 @@ type taskListConfig struct {
 	// taskWriter configuration
 	OutstandingTaskAppendsThreshold func() int
 	MaxTaskBatchSize                func() int
+	// fair dispatch configuration
+	EnableFairDispatch     func() bool
+	FairDispatchQuantum    func() int
+	FairDispatchBufferSize func() int
+	// handoff configuration
+	TaskListHandoffTimeout func() time.Duration
 }
 
 func newTaskListConfig(id *taskListID, config *Config, domainCache cache.DomainCache) (*taskListConfig, error) {
@@ -119,6 +132,18 @@ func newTaskListConfig(id *taskListID, config *Config, domainCache cache.DomainC
 		MaxTaskBatchSize: func() int {
 			return config.MaxTaskBatchSize(domain, taskListName, taskType)
 		},
+		EnableFairDispatch: func() bool {
+			return config.EnableFairDispatch(domain, taskListName, taskType)
+		},
+		FairDispatchQuantum: func() int {
+			return config.FairDispatchQuantum(domain, taskListName, taskType)
+		},
+		FairDispatchBufferSize: func() int {
+			return config.FairDispatchBufferSize(domain, taskListName, taskType)
+		},
+		TaskListHandoffTimeout: func() time.Duration {
+			return config.TaskListHandoffTimeout(domain, taskListName, taskType)
+		},
 	}, nil
 }
 
@@ -334,6 +359,11 @@ type syncMatchResponse struct {
 func (c *taskListManagerImpl) Start() error {
 	defer c.startWG.Done()
 
+	if err := c.waitForHandoff(); err != nil {
+		c.Stop()
+		return err
+	}
+
 	// Make sure to grab the range first before starting task writer, as it needs the range to initialize maxReadLevel
 	err := c.updateRangeIfNeeded() // Grabs a new range and updates read and ackLevels
 	if err != nil {
@@ -361,6 +391,88 @@ func (c *taskListManagerImpl) Stop() {
 	logging.LogTaskListUnloadedEvent(c.logger)
 }
 
+// Release stops accepting polls, flushes the taskWriter and persists the ack level with the released
+// flag set, so the host the task list moves to does not have to wait for the lease to expire.
+func (c *taskListManagerImpl) Release() {
+	if !atomic.CompareAndSwapInt32(&c.stopped, 0, 1) {
+		return
+	}
+	// Abort a pending handoff wait before waiting for Start to finish
+	close(c.shutdownCh)
+	c.startWG.Wait()
+	close(c.deliverBufferShutdownCh)
+	c.cancelFunc()
+	c.cancelOutstandingPolls()
+	c.taskWriter.Drain()
+
+	if c.getRangeID() != 0 {
+		if err := c.persistRelease(); err != nil {
+			c.metricsClient.IncCounter(metrics.MatchingTaskListMgrScope, metrics.HandoffReleaseFailureCounter)
+			logging.LogPersistantStoreErrorEvent(c.logger, logging.TagValueStoreOperationUpdateTaskList, err,
+				"Persist release of task list failed")
+		} else {
+			c.metricsClient.IncCounter(metrics.MatchingTaskListMgrScope, metrics.HandoffReleaseCounter)
+		}
+	}
+	c.engine.removeTaskListManager(c.taskListID)
+	logging.LogTaskListUnloadedEvent(c.logger)
+}
+
+// waitForHandoff delays taking the lease while the task list is still owned by another host, until the
+// previous owner releases it, stops renewing it, or TaskListHandoffTimeout expires.
+func (c *taskListManagerImpl) waitForHandoff() error {
+	sw := c.metricsClient.StartTimer(metrics.MatchingTaskListMgrScope, metrics.HandoffWaitLatency)
+	defer sw.Stop()
+
+	deadline := time.NewTimer(c.config.TaskListHandoffTimeout())
+	defer deadline.Stop()
+	for {
+		resp, err := c.engine.taskManager.GetTaskList(&persistence.GetTaskListRequest{
+			DomainID: c.taskListID.domainID,
+			TaskList: c.taskListID.taskListName,
+			TaskType: c.taskListID.taskType,
+		})
+		if err != nil {
+			// Either the task list was never leased, or persistence is unavailable in which case the
+			// lease below fails anyway
+			return nil
+		}
+		tli := resp.TaskListInfo
+		if tli.Released || time.Since(tli.LastUpdated) >= 2*c.config.UpdateAckInterval() {
+			return nil
+		}
+
+		select {
+		case <-time.After(handoffPollInterval):
+		case <-deadline.C:
+			c.metricsClient.IncCounter(metrics.MatchingTaskListMgrScope, metrics.HandoffTimeoutCounter)
+			return nil
+		case <-c.shutdownCh:
+			return errShutdown
+		}
+	}
+}
+
+func (c *taskListManagerImpl) persistRelease() error {
+	c.Lock()
+	updateTaskListRequest := &persistence.UpdateTaskListRequest{
+		TaskListInfo: &persistence.TaskListInfo{
+			DomainID: c.taskListID.domainID,
+			Name:     c.taskListID.taskListName,
+			TaskType: c.taskListID.taskType,
+			AckLevel: c.taskAckManager.getAckLevel(),
+			RangeID:  c.rangeID,
+			Kind:     c.getTaskListKind(),
+			Released: true,
+		},
+	}
+	c.Unlock()
+	c.persistenceLock.Lock()
+	defer c.persistenceLock.Unlock()
+	_, err := c.engine.taskManager.UpdateTaskList(updateTaskListRequest)
+	return err
+}
+
 func (c *taskListManagerImpl) AddTask(execution *s.WorkflowExecution, taskInfo *persistence.TaskInfo) (syncMatch bool, err error) {
 	c.startWG.Wait()
 	_, err = c.executeWithRetry(func(rangeID int64) (interface{}, error) {
@@ -586,6 +698,18 @@ func (c *taskListManagerImpl) CancelPoller(pollerID string) {
 	}
 }
 
+// cancelOutstandingPolls unblocks all pollers waiting on this task list so they return an empty
+// response and poll again on the new owner
+func (c *taskListManagerImpl) cancelOutstandingPolls() {
+	c.outstandingPollsLock.Lock()
+	defer c.outstandingPollsLock.Unlock()
+	for _, cancel := range c.outstandingPollsMap {
+		if cancel != nil {
+			cancel()
+		}
+	}
+}
+
 // Returns a batch of tasks from persistence starting form current read level.
 // Also return a number that can be used to update readLevel
 // Also return a bool to indicate whether read is finished
@@ -736,6 +860,7 @@ func (c *taskListManagerImpl) trySyncMatch(task *persistence.TaskInfo) (*persist
 }
 
 func (c *taskListManagerImpl) deliverBufferTasksForPoll() {
+	fairQueue := newFairTaskQueue()
 deliverBufferTasksLoop:
 	for {
 		err := c.rateLimiter.Wait(c.cancelCtx)
@@ -753,6 +878,13 @@ deliverBufferTasksLoop:
 			runtime.Gosched()
 			continue
 		}
+		// keep draining the fair queue even if fair dispatch got disabled in the meantime
+		if c.config.EnableFairDispatch() || fairQueue.len() > 0 {
+			if !c.deliverFairBufferTask(fairQueue) {
+				break deliverBufferTasksLoop
+			}
+			continue
+		}
 		select {
 		case task, ok := <-c.taskBuffer:
 			if !ok { // Task list getTasks pump is shutdown
@@ -769,6 +901,58 @@ deliverBufferTasksLoop:
 	}
 }
 
+// deliverFairBufferTask reads ahead of the task buffer into the fair queue, so that backlog tasks of
+// other workflows can be interleaved, and dispatches the task whose turn it is to a poller. Returns
+// false once the task list is shutting down.
+func (c *taskListManagerImpl) deliverFairBufferTask(fairQueue *fairTaskQueue) bool {
+	for {
+		if fairQueue.len() == 0 {
+			select {
+			case task, ok := <-c.taskBuffer:
+				if !ok { // Task list getTasks pump is shutdown
+					return false
+				}
+				fairQueue.add(task)
+			case <-c.deliverBufferShutdownCh:
+				return false
+			}
+			continue
+		}
+
+		// stop reading ahead once the fair queue is full, a nil channel blocks forever
+		var taskBuffer chan *persistence.TaskInfo
+		if fairQueue.len() < c.config.FairDispatchBufferSize() {
+			taskBuffer = c.taskBuffer
+		}
+		quantum := c.config.FairDispatchQuantum()
+		if quantum < 1 {
+			quantum = 1
+		}
+		next := fairQueue.peek(quantum)
+		select {
+		case task, ok := <-taskBuffer:
+			if !ok { // Task list getTasks pump is shutdown
+				return false
+			}
+			fairQueue.add(task)
+		case c.tasksForPoll <- &getTaskResult{task: next.task}:
+			_, reordered := fairQueue.pop()
+			if reordered {
+				c.metricsClient.IncCounter(metrics.MatchingTaskListMgrScope, metrics.FairDispatchReorderedCounter)
+			}
+			c.metricsClient.RecordTimer(metrics.MatchingTaskListMgrScope, metrics.FairDispatchBufferLatency,
+				time.Since(next.enqueueTime))
+			c.metricsClient.UpdateGauge(metrics.MatchingTaskListMgrScope, metrics.FairDispatchBufferedTasksGauge,
+				float64(fairQueue.len()))
+			c.metricsClient.UpdateGauge(metrics.MatchingTaskListMgrScope, metrics.FairDispatchActiveKeysGauge,
+				float64(fairQueue.numKeys()))
+			return true
+		case <-c.deliverBufferShutdownCh:
+			return false
+		}
+	}
+}
+
 func (c *taskListManagerImpl) getTasksPump() {
 	defer close(c.taskBuffer)
 	c.startWG.Wait()
@@ -835,7 +1019,7 @@ getTasksPumpLoop:
 		case <-checkIdleTaskListTimer.C:
 			{
 				if !c.isTaskAddedRecently(lastTimeWriteTask) && len(c.GetAllPollerInfo()) == 0 {
-					c.Stop()
+					c.Release()
 				}
 				checkIdleTaskListTimer = time.NewTimer(c.config.IdleTasklistCheckInterval())
 			}
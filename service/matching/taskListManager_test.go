@@ -152,3 +152,124 @@ func TestCheckIdleTaskList(t *testing.T) {
 	tlm.Stop()
 	require.Equal(t, int32(1), tlm.stopped)
 }
+
+func TestReleaseTaskList(t *testing.T) {
+	cfg := defaultTestConfig()
+	cfg.TaskListHandoffTimeout = dynamicconfig.GetDurationPropertyFnFilteredByTaskListInfo(time.Minute)
+	tlm := createTestTaskListManagerWithConfig(cfg)
+	require.NoError(t, tlm.Start())
+
+	tlm.Release()
+	require.Equal(t, int32(1), tlm.stopped)
+	require.True(t, tlm.taskWriter.isStopped())
+	resp, err := tlm.engine.taskManager.GetTaskList(&persistence.GetTaskListRequest{
+		DomainID: tlm.taskListID.domainID,
+		TaskList: tlm.taskListID.taskListName,
+		TaskType: tlm.taskListID.taskType,
+	})
+	require.NoError(t, err)
+	require.True(t, resp.TaskListInfo.Released)
+
+	// the next owner takes the released task list over without waiting for the handoff timeout
+	next, err := newTaskListManager(tlm.engine, tlm.taskListID, tlm.taskListKind, cfg)
+	require.NoError(t, err)
+	start := time.Now()
+	require.NoError(t, next.Start())
+	require.True(t, time.Since(start) < time.Second)
+	require.Equal(t, int64(2), next.(*taskListManagerImpl).getRangeID())
+	next.Stop()
+}
+
+func TestWaitForHandoff_Timeout(t *testing.T) {
+	cfg := defaultTestConfig()
+	cfg.TaskListHandoffTimeout = dynamicconfig.GetDurationPropertyFnFilteredByTaskListInfo(200 * time.Millisecond)
+	tlm := createTestTaskListManagerWithConfig(cfg)
+	require.NoError(t, tlm.Start())
+
+	// the previous owner is still renewing the lease, so the lease is only stolen after the timeout
+	next, err := newTaskListManager(tlm.engine, tlm.taskListID, tlm.taskListKind, cfg)
+	require.NoError(t, err)
+	start := time.Now()
+	require.NoError(t, next.Start())
+	require.True(t, time.Since(start) >= 200*time.Millisecond)
+	require.Equal(t, int64(2), next.(*taskListManagerImpl).getRangeID())
+	next.Stop()
+	tlm.Stop()
+}
+
+func TestFairTaskQueue(t *testing.T) {
+	q := newFairTaskQueue()
+	require.Nil(t, q.peek(1))
+
+	// wf1 has a backlog of three tasks ahead of a single task of wf2 and of a caller supplied key
+	q.add(&persistence.TaskInfo{TaskID: 1, WorkflowID: "wf1"})
+	q.add(&persistence.TaskInfo{TaskID: 2, WorkflowID: "wf1"})
+	q.add(&persistence.TaskInfo{TaskID: 3, WorkflowID: "wf1"})
+	q.add(&persistence.TaskInfo{TaskID: 4, WorkflowID: "wf2"})
+	q.add(&persistence.TaskInfo{TaskID: 5, WorkflowID: "wf3", FairnessKey: "wf1"})
+	q.add(&persistence.TaskInfo{TaskID: 6, WorkflowID: "wf4", FairnessKey: "tenant"})
+	require.Equal(t, 6, q.len())
+	require.Equal(t, 3, q.numKeys())
+
+	var order []int64
+	var reorderedCount int
+	for q.len() > 0 {
+		next := q.peek(1)
+		task, reordered := q.pop()
+		require.Equal(t, next, task)
+		order = append(order, task.task.TaskID)
+		if reordered {
+			reorderedCount++
+		}
+	}
+	require.Equal(t, []int64{1, 4, 6, 2, 3, 5}, order)
+	require.Equal(t, 2, reorderedCount)
+	require.Equal(t, 0, q.numKeys())
+}
+
+func TestFairTaskQueue_Quantum(t *testing.T) {
+	q := newFairTaskQueue()
+	for i := int64(1); i <= 3; i++ {
+		q.add(&persistence.TaskInfo{TaskID: i, WorkflowID: "wf1"})
+	}
+	for i := int64(4); i <= 6; i++ {
+		q.add(&persistence.TaskInfo{TaskID: i, WorkflowID: "wf2"})
+	}
+
+	var order []int64
+	for q.len() > 0 {
+		q.peek(2)
+		task, _ := q.pop()
+		order = append(order, task.task.TaskID)
+	}
+	require.Equal(t, []int64{1, 2, 4, 5, 3, 6}, order)
+}
+
+func TestDeliverBufferTasks_FairDispatch(t *testing.T) {
+	tlm := createTestTaskListManager()
+	tlm.config.EnableFairDispatch = func() bool { return true }
+	for i := int64(1); i <= 4; i++ {
+		tlm.taskBuffer <- &persistence.TaskInfo{TaskID: i, WorkflowID: "wf1"}
+	}
+	tlm.taskBuffer <- &persistence.TaskInfo{TaskID: 5, WorkflowID: "wf2"}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		tlm.deliverBufferTasksForPoll()
+	}()
+
+	delivered := make(map[int64]struct{})
+	for i := 0; i < 5; i++ {
+		select {
+		case result := <-tlm.tasksForPoll:
+			delivered[result.task.TaskID] = struct{}{}
+		case <-time.After(time.Second):
+			require.FailNow(t, "timed out waiting for buffered task")
+		}
+	}
+	require.Equal(t, 5, len(delivered))
+	close(tlm.deliverBufferShutdownCh)
+	wg.Wait()
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package matching
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/uber-common/bark"
+	"github.com/uber/cadence/common/logging"
+	"github.com/uber/cadence/common/membership"
+)
+
+const (
+	taskListOwnershipMonitorListenerName = "TaskListOwnershipMonitor"
+)
+
+type (
+	// taskListOwnershipMonitor watches the matching ring and hands over the task lists
+	// which moved to another host, before the new owner steals their leases
+	taskListOwnershipMonitor struct {
+		engine             Engine
+		host               *membership.HostInfo
+		resolver           membership.ServiceResolver
+		membershipUpdateCh chan *membership.ChangedEvent
+		isStarted          int32
+		isStopped          int32
+		shutdownWG         sync.WaitGroup
+		shutdownCh         chan struct{}
+		logger             bark.Logger
+	}
+)
+
+func newTaskListOwnershipMonitor(engine Engine, host *membership.HostInfo, resolver membership.ServiceResolver,
+	logger bark.Logger) *taskListOwnershipMonitor {
+	return &taskListOwnershipMonitor{
+		engine:             engine,
+		host:               host,
+		resolver:           resolver,
+		membershipUpdateCh: make(chan *membership.ChangedEvent, 10),
+		shutdownCh:         make(chan struct{}),
+		logger:             logger,
+	}
+}
+
+func (m *taskListOwnershipMonitor) Start() {
+	if !atomic.CompareAndSwapInt32(&m.isStarted, 0, 1) {
+		return
+	}
+
+	m.shutdownWG.Add(1)
+	go m.membershipUpdatePump()
+
+	if err := m.resolver.AddListener(taskListOwnershipMonitorListenerName, m.membershipUpdateCh); err != nil {
+		logging.LogOperationFailedEvent(m.logger, "Error adding membership update listener", err)
+	}
+}
+
+func (m *taskListOwnershipMonitor) Stop() {
+	if !atomic.CompareAndSwapInt32(&m.isStopped, 0, 1) {
+		return
+	}
+
+	if atomic.LoadInt32(&m.isStarted) == 1 {
+		if err := m.resolver.RemoveListener(taskListOwnershipMonitorListenerName); err != nil {
+			logging.LogOperationFailedEvent(m.logger, "Error removing membership update listener", err)
+		}
+		close(m.shutdownCh)
+	}
+	m.shutdownWG.Wait()
+}
+
+func (m *taskListOwnershipMonitor) membershipUpdatePump() {
+	defer m.shutdownWG.Done()
+
+	for {
+		select {
+		case <-m.shutdownCh:
+			return
+		case changedEvent := <-m.membershipUpdateCh:
+			logging.LogRingMembershipChangedEvent(m.logger, m.host.Identity(), len(changedEvent.HostsAdded),
+				len(changedEvent.HostsRemoved), len(changedEvent.HostsUpdated))
+			m.engine.ReleaseTaskLists(m.isNotOwned)
+		}
+	}
+}
+
+// isNotOwned returns true for the task lists which the ring assigns to another host
+func (m *taskListOwnershipMonitor) isNotOwned(taskListName string) bool {
+	info, err := m.resolver.Lookup(taskListName)
+	if err != nil {
+		logging.LogOperationFailedEvent(m.logger, fmt.Sprintf("Error looking up host for task list: %v", taskListName), err)
+		return false
+	}
+	return info.Identity() != m.host.Identity()
+}
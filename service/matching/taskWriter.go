@@ -53,8 +53,11 @@ type (
 		appendCh     chan *writeTaskRequest
 		maxReadLevel int64
 		stopped      int64 // set to 1 if the writer is stopped or is shutting down
+		started      int32 // set to 1 once the writer loop is running
 		logger       bark.Logger
 		stopCh       chan struct{} // shutdown signal for all routines in this class
+		drainCh      chan struct{} // signals the writer loop to flush pending appends and exit
+		doneCh       chan struct{} // closed when the writer loop exits
 	}
 )
 
@@ -68,6 +71,8 @@ func newTaskWriter(tlMgr *taskListManagerImpl) *taskWriter {
 		taskListID:  tlMgr.taskListID,
 		taskManager: tlMgr.engine.taskManager,
 		stopCh:      make(chan struct{}),
+		drainCh:     make(chan struct{}),
+		doneCh:      make(chan struct{}),
 		appendCh:    make(chan *writeTaskRequest, tlMgr.config.OutstandingTaskAppendsThreshold()),
 		logger:      tlMgr.logger,
 	}
@@ -75,6 +80,7 @@ func newTaskWriter(tlMgr *taskListManagerImpl) *taskWriter {
 
 func (w *taskWriter) Start() {
 	w.maxReadLevel = w.tlMgr.getTaskSequenceNumber() - 1
+	atomic.StoreInt32(&w.started, 1)
 	go w.taskWriterLoop()
 }
 
@@ -85,6 +91,22 @@ func (w *taskWriter) Stop() {
 	}
 }
 
+// Drain stops accepting new appends and blocks until the appends already queued are written to persistence.
+// Must not be called concurrently with Start.
+func (w *taskWriter) Drain() {
+	if !atomic.CompareAndSwapInt64(&w.stopped, 0, 1) {
+		return
+	}
+	if atomic.LoadInt32(&w.started) == 0 {
+		close(w.stopCh)
+		return
+	}
+	close(w.drainCh)
+	<-w.doneCh
+	// fail appends which raced with the drain and never made it into a batch
+	close(w.stopCh)
+}
+
 func (w *taskWriter) isStopped() bool {
 	return atomic.LoadInt64(&w.stopped) == 1
 }
@@ -124,68 +146,21 @@ func (w *taskWriter) GetMaxReadLevel() int64 {
 }
 
 func (w *taskWriter) taskWriterLoop() {
+	defer close(w.doneCh)
 writerLoop:
 	for {
 		select {
 		case request := <-w.appendCh:
-			{
-				// read a batch of requests from the channel
-				reqs := []*writeTaskRequest{request}
-				reqs = w.getWriteBatch(reqs)
-				batchSize := len(reqs)
-
-				maxReadLevel := int64(0)
-
-				taskIDs, err := w.tlMgr.newTaskIDs(batchSize)
-				if err != nil {
-					w.sendWriteResponse(reqs, err, nil)
-					continue writerLoop
-				}
-
-				tasks := []*persistence.CreateTaskInfo{}
-				rangeID := int64(0)
-				for i, req := range reqs {
-					tasks = append(tasks, &persistence.CreateTaskInfo{
-						TaskID:    taskIDs[i],
-						Execution: *req.execution,
-						Data:      req.taskInfo,
-					})
-					if req.rangeID > rangeID {
-						rangeID = req.rangeID // use the maximum rangeID provided for the write operation
-					}
-					maxReadLevel = taskIDs[i]
+			w.writeBatch(request)
+		case <-w.drainCh:
+			// flush whatever is still queued before handing the task list over
+			for {
+				select {
+				case request := <-w.appendCh:
+					w.writeBatch(request)
+				default:
+					break writerLoop
 				}
-
-				tlInfo := &persistence.TaskListInfo{
-					DomainID: w.taskListID.domainID,
-					Name:     w.taskListID.taskListName,
-					TaskType: w.taskListID.taskType,
-					// Note that newTaskID could increment range, so rangeID parameter
-					// might be out of sync. This is OK as caller can just retry.
-					RangeID:  rangeID,
-					AckLevel: w.tlMgr.getAckLevel(),
-					Kind:     w.tlMgr.getTaskListKind(),
-				}
-
-				w.tlMgr.persistenceLock.Lock()
-				r, err := w.taskManager.CreateTasks(&persistence.CreateTasksRequest{
-					TaskListInfo: tlInfo,
-					Tasks:        tasks,
-				})
-				w.tlMgr.persistenceLock.Unlock()
-
-				if err != nil {
-					logging.LogPersistantStoreErrorEvent(w.logger, logging.TagValueStoreOperationCreateTask, err,
-						fmt.Sprintf("{taskID: [%v, %v], taskType: %v, taskList: %v}",
-							taskIDs[0], taskIDs[batchSize-1], w.taskListID.taskType, w.taskListID.taskListName))
-				}
-
-				// Update the maxReadLevel after the writes are completed.
-				if maxReadLevel > 0 {
-					atomic.StoreInt64(&w.maxReadLevel, maxReadLevel)
-				}
-
-				w.sendWriteResponse(reqs, err, r)
 			}
 		case <-w.stopCh:
 			// we don't close the appendCh here
@@ -196,6 +171,66 @@ writerLoop:
 	}
 }
 
+func (w *taskWriter) writeBatch(request *writeTaskRequest) {
+	// read a batch of requests from the channel
+	reqs := []*writeTaskRequest{request}
+	reqs = w.getWriteBatch(reqs)
+	batchSize := len(reqs)
+
+	maxReadLevel := int64(0)
+
+	taskIDs, err := w.tlMgr.newTaskIDs(batchSize)
+	if err != nil {
+		w.sendWriteResponse(reqs, err, nil)
+		return
+	}
+
+	tasks := []*persistence.CreateTaskInfo{}
+	rangeID := int64(0)
+	for i, req := range reqs {
+		tasks = append(tasks, &persistence.CreateTaskInfo{
+			TaskID:    taskIDs[i],
+			Execution: *req.execution,
+			Data:      req.taskInfo,
+		})
+		if req.rangeID > rangeID {
+			rangeID = req.rangeID // use the maximum rangeID provided for the write operation
+		}
+		maxReadLevel = taskIDs[i]
+	}
+
+	tlInfo := &persistence.TaskListInfo{
+		DomainID: w.taskListID.domainID,
+		Name:     w.taskListID.taskListName,
+		TaskType: w.taskListID.taskType,
+		// Note that newTaskID could increment range, so rangeID parameter
+		// might be out of sync. This is OK as caller can just retry.
+		RangeID:  rangeID,
+		AckLevel: w.tlMgr.getAckLevel(),
+		Kind:     w.tlMgr.getTaskListKind(),
+	}
+
+	w.tlMgr.persistenceLock.Lock()
+	r, err := w.taskManager.CreateTasks(&persistence.CreateTasksRequest{
+		TaskListInfo: tlInfo,
+		Tasks:        tasks,
+	})
+	w.tlMgr.persistenceLock.Unlock()
+
+	if err != nil {
+		logging.LogPersistantStoreErrorEvent(w.logger, logging.TagValueStoreOperationCreateTask, err,
+			fmt.Sprintf("{taskID: [%v, %v], taskType: %v, taskList: %v}",
+				taskIDs[0], taskIDs[batchSize-1], w.taskListID.taskType, w.taskListID.taskListName))
+	}
+
+	// Update the maxReadLevel after the writes are completed.
+	if maxReadLevel > 0 {
+		atomic.StoreInt64(&w.maxReadLevel, maxReadLevel)
+	}
+
+	w.sendWriteResponse(reqs, err, r)
+}
+
 func (w *taskWriter) getWriteBatch(reqs []*writeTaskRequest) []*writeTaskRequest {
 readLoop:
 	for i := 0; i < w.config.MaxTaskBatchSize(); i++ {
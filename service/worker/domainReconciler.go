@@ -0,0 +1,385 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package worker
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/uber-common/bark"
+	adminGen "github.com/uber/cadence/.gen/go/admin"
+	"github.com/uber/cadence/.gen/go/replicator"
+	"github.com/uber/cadence/client"
+	"github.com/uber/cadence/client/admin"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/cluster"
+	"github.com/uber/cadence/common/logging"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/service/config"
+)
+
+type (
+	// DomainReconciler periodically compares the global domain records of the current cluster with the ones of
+	// each remote cluster, and reports the differences. When repair is enabled, a remote record with a higher
+	// config or failover version is applied to the current cluster the same way a domain replication task is,
+	// the remote clusters repair their own records when they run the reconciler. Notification versions are
+	// local to each cluster and are not compared.
+	DomainReconciler struct {
+		clusterMetadata   cluster.Metadata
+		metadataManagerV2 persistence.MetadataManager
+		domainReplicator  DomainReplicator
+		clientFactory     client.Factory
+		config            *Config
+		logger            bark.Logger
+		metricsClient     metrics.Client
+		// adminClients are keyed by the frontend address of the remote clusters, they are only used by the pump
+		adminClients map[config.Address]admin.Client
+		isStarted    int32
+		isStopped    int32
+		shutdownWG   sync.WaitGroup
+		shutdownCh   chan struct{}
+	}
+)
+
+const (
+	domainReconcilerListPageSize = 100
+	domainReconcilerRPCTimeout   = 10 * time.Second
+)
+
+// NewDomainReconciler creates a new reconciler for the global domain records
+func NewDomainReconciler(clusterMetadata cluster.Metadata, metadataManagerV2 persistence.MetadataManager,
+	clientFactory client.Factory, config *Config, logger bark.Logger, metricsClient metrics.Client) *DomainReconciler {
+	logger = logger.WithFields(bark.Fields{
+		logging.TagWorkflowComponent: logging.TagValueDomainReconcilerComponent,
+	})
+	return &DomainReconciler{
+		clusterMetadata:   clusterMetadata,
+		metadataManagerV2: metadataManagerV2,
+		domainReplicator:  NewDomainReplicator(metadataManagerV2, logger),
+		clientFactory:     clientFactory,
+		config:            config,
+		logger:            logger,
+		metricsClient:     metricsClient,
+		shutdownCh:        make(chan struct{}),
+	}
+}
+
+// Start is called to start the reconciler
+func (r *DomainReconciler) Start() {
+	if !atomic.CompareAndSwapInt32(&r.isStarted, 0, 1) {
+		return
+	}
+
+	r.adminClients = make(map[config.Address]admin.Client)
+	r.shutdownWG.Add(1)
+	go r.reconcilerPump()
+
+	r.logger.Info("Domain reconciler started.")
+}
+
+// Stop is called to stop the reconciler
+func (r *DomainReconciler) Stop() {
+	if !atomic.CompareAndSwapInt32(&r.isStopped, 0, 1) {
+		return
+	}
+
+	if atomic.LoadInt32(&r.isStarted) == 1 {
+		close(r.shutdownCh)
+	}
+
+	if success := common.AwaitWaitGroup(&r.shutdownWG, time.Minute); !success {
+		r.logger.Warn("Domain reconciler timed out on shutdown.")
+	}
+	r.logger.Info("Domain reconciler stopped.")
+}
+
+func (r *DomainReconciler) reconcilerPump() {
+	defer r.shutdownWG.Done()
+
+	timer := time.NewTimer(r.config.DomainReconcilerInterval())
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-r.shutdownCh:
+			return
+		case <-timer.C:
+			r.runPass()
+			timer.Reset(r.config.DomainReconcilerInterval())
+		}
+	}
+}
+
+// runPass reconciles the global domain records with every enabled remote cluster once
+func (r *DomainReconciler) runPass() {
+	sw := r.metricsClient.StartTimer(metrics.DomainReconcilerScope, metrics.DomainReconcilerPassLatency)
+	defer sw.Stop()
+
+	currentClusterName := r.clusterMetadata.GetCurrentClusterName()
+	for clusterName, address := range r.clusterMetadata.GetAllClientAddress() {
+		if clusterName == currentClusterName {
+			continue
+		}
+		select {
+		case <-r.shutdownCh:
+			return
+		default:
+		}
+
+		adminClient, ok := r.adminClients[address]
+		if !ok {
+			adminClient = r.clientFactory.NewRemoteAdminClient(address.RPCName, address.RPCAddress)
+			r.adminClients[address] = adminClient
+		}
+		if err := r.reconcileCluster(clusterName, adminClient); err != nil {
+			r.metricsClient.Tagged(map[string]string{metrics.RemoteClusterTagName: clusterName}).
+				IncCounter(metrics.DomainReconcilerScope, metrics.DomainReconcilerFailures)
+			r.logger.WithFields(bark.Fields{
+				logging.TagClusterName: clusterName,
+				logging.TagErr:         err,
+			}).Warn("Failed to reconcile domains with remote cluster.")
+		}
+	}
+}
+
+// reconcileCluster compares the global domain records of the current cluster with the ones of the remote cluster,
+// only the domains replicated to the other cluster by either record are expected to exist in both clusters
+func (r *DomainReconciler) reconcileCluster(clusterName string, adminClient admin.Client) error {
+	metricsClient := r.metricsClient.Tagged(map[string]string{metrics.RemoteClusterTagName: clusterName})
+	currentClusterName := r.clusterMetadata.GetCurrentClusterName()
+
+	localDomains, err := r.listLocalDomains()
+	if err != nil {
+		return err
+	}
+	remoteDomains, err := r.listRemoteDomains(adminClient)
+	if err != nil {
+		return err
+	}
+
+	for name, local := range localDomains {
+		remote, ok := remoteDomains[name]
+		if !ok {
+			if local.IsGlobalDomain && containsCluster(getLocalClusterNames(local), clusterName) {
+				metricsClient.IncCounter(metrics.DomainReconcilerScope, metrics.DomainReconcilerMismatchCount)
+				r.logger.WithFields(bark.Fields{
+					logging.TagClusterName: clusterName,
+					logging.TagDomainName:  name,
+				}).Warn("Global domain is missing in remote cluster.")
+			}
+			continue
+		}
+
+		metricsClient.IncCounter(metrics.DomainReconcilerScope, metrics.DomainReconcilerComparedCount)
+		differences := diffDomain(local, remote)
+		if len(differences) == 0 {
+			continue
+		}
+		metricsClient.IncCounter(metrics.DomainReconcilerScope, metrics.DomainReconcilerMismatchCount)
+		r.logger.WithFields(bark.Fields{
+			logging.TagClusterName:       clusterName,
+			logging.TagDomainName:        name,
+			logging.TagDomainDifferences: differences,
+		}).Warn("Global domain record differs from remote cluster.")
+
+		if local.Info.ID != remote.GetID() || !local.IsGlobalDomain {
+			// the domains only share the name, applying the remote record would corrupt the local one
+			continue
+		}
+		if remote.GetConfigVersion() > local.ConfigVersion || remote.GetFailoverVersion() > local.FailoverVersion {
+			if err := r.repair(metricsClient, clusterName, remote); err != nil {
+				return err
+			}
+		}
+	}
+
+	for name, remote := range remoteDomains {
+		if _, ok := localDomains[name]; ok || !containsCluster(getRemoteClusterNames(remote), currentClusterName) {
+			continue
+		}
+		metricsClient.IncCounter(metrics.DomainReconcilerScope, metrics.DomainReconcilerMismatchCount)
+		r.logger.WithFields(bark.Fields{
+			logging.TagClusterName: clusterName,
+			logging.TagDomainName:  name,
+		}).Warn("Global domain of remote cluster is missing in current cluster.")
+		if err := r.repair(metricsClient, clusterName, remote); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// repair applies the remote record to the current cluster if repair is enabled, only the parts of the record
+// with a higher version than the local record are taken
+func (r *DomainReconciler) repair(metricsClient metrics.Client, clusterName string,
+	remote *replicator.DomainTaskAttributes) error {
+	if !r.config.EnableDomainReconcilerRepair() {
+		return nil
+	}
+
+	if err := r.domainReplicator.HandleReceivingTask(remote); err != nil {
+		return err
+	}
+	metricsClient.IncCounter(metrics.DomainReconcilerScope, metrics.DomainReconcilerRepairedCount)
+	r.logger.WithFields(bark.Fields{
+		logging.TagClusterName: clusterName,
+		logging.TagDomainName:  remote.Info.GetName(),
+	}).Info("Repaired global domain with the record of remote cluster.")
+	return nil
+}
+
+// listLocalDomains returns all the domains of the current cluster, local domains are included to detect the
+// global domains of the remote cluster with the same name
+func (r *DomainReconciler) listLocalDomains() (map[string]*persistence.GetDomainResponse, error) {
+	domains := make(map[string]*persistence.GetDomainResponse)
+	var pageToken []byte
+	for {
+		resp, err := r.metadataManagerV2.ListDomains(&persistence.ListDomainsRequest{
+			PageSize:      domainReconcilerListPageSize,
+			NextPageToken: pageToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, domain := range resp.Domains {
+			domains[domain.Info.Name] = domain
+		}
+		if len(resp.NextPageToken) == 0 {
+			return domains, nil
+		}
+		pageToken = resp.NextPageToken
+	}
+}
+
+func (r *DomainReconciler) listRemoteDomains(adminClient admin.Client) (map[string]*replicator.DomainTaskAttributes, error) {
+	domains := make(map[string]*replicator.DomainTaskAttributes)
+	var pageToken []byte
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), domainReconcilerRPCTimeout)
+		resp, err := adminClient.ListGlobalDomains(ctx, &adminGen.ListGlobalDomainsRequest{
+			PageSize:      common.Int32Ptr(domainReconcilerListPageSize),
+			NextPageToken: pageToken,
+		})
+		cancel()
+		if err != nil {
+			return nil, err
+		}
+		for _, domain := range resp.Domains {
+			if err := r.validateRemoteDomain(domain); err != nil {
+				return nil, err
+			}
+			domains[domain.Info.GetName()] = domain
+		}
+		if len(resp.NextPageToken) == 0 {
+			return domains, nil
+		}
+		pageToken = resp.NextPageToken
+	}
+}
+
+func (r *DomainReconciler) validateRemoteDomain(domain *replicator.DomainTaskAttributes) error {
+	if domain == nil {
+		return ErrEmptyDomainReplicationTask
+	} else if domain.Info == nil {
+		return ErrInvalidDomainInfo
+	} else if domain.Config == nil {
+		return ErrInvalidDomainConfig
+	} else if domain.ReplicationConfig == nil {
+		return ErrInvalidDomainReplicationConfig
+	}
+	return nil
+}
+
+// diffDomain returns the names of the fields which differ between the local and the remote domain records
+func diffDomain(local *persistence.GetDomainResponse, remote *replicator.DomainTaskAttributes) []string {
+	var differences []string
+	if local.Info.ID != remote.GetID() {
+		differences = append(differences, "id")
+	}
+	if !local.IsGlobalDomain {
+		differences = append(differences, "isGlobalDomain")
+	}
+	if status, err := convertDomainStatusFromThrift(remote.Info.Status); err != nil || local.Info.Status != status {
+		differences = append(differences, "status")
+	}
+	if local.Info.Description != remote.Info.GetDescription() {
+		differences = append(differences, "description")
+	}
+	if local.Info.OwnerEmail != remote.Info.GetOwnerEmail() {
+		differences = append(differences, "ownerEmail")
+	}
+	if (len(local.Info.Data) > 0 || len(remote.Info.Data) > 0) && !reflect.DeepEqual(local.Info.Data, remote.Info.Data) {
+		differences = append(differences, "data")
+	}
+	if local.Config.Retention != remote.Config.GetWorkflowExecutionRetentionPeriodInDays() {
+		differences = append(differences, "retention")
+	}
+	if local.Config.EmitMetric != remote.Config.GetEmitMetric() {
+		differences = append(differences, "emitMetric")
+	}
+	if local.ReplicationConfig.ActiveClusterName != remote.ReplicationConfig.GetActiveClusterName() {
+		differences = append(differences, "activeClusterName")
+	}
+	if !reflect.DeepEqual(getLocalClusterNames(local), getRemoteClusterNames(remote)) {
+		differences = append(differences, "clusters")
+	}
+	if local.ConfigVersion != remote.GetConfigVersion() {
+		differences = append(differences, "configVersion")
+	}
+	if local.FailoverVersion != remote.GetFailoverVersion() {
+		differences = append(differences, "failoverVersion")
+	}
+	return differences
+}
+
+// getLocalClusterNames returns the sorted names of the clusters the local domain record is replicated to
+func getLocalClusterNames(domain *persistence.GetDomainResponse) []string {
+	clusterNames := []string{}
+	for _, cluster := range domain.ReplicationConfig.Clusters {
+		clusterNames = append(clusterNames, cluster.ClusterName)
+	}
+	sort.Strings(clusterNames)
+	return clusterNames
+}
+
+// getRemoteClusterNames returns the sorted names of the clusters the remote domain record is replicated to
+func getRemoteClusterNames(domain *replicator.DomainTaskAttributes) []string {
+	clusterNames := []string{}
+	for _, cluster := range domain.ReplicationConfig.Clusters {
+		clusterNames = append(clusterNames, cluster.GetClusterName())
+	}
+	sort.Strings(clusterNames)
+	return clusterNames
+}
+
+func containsCluster(clusterNames []string, clusterName string) bool {
+	for _, name := range clusterNames {
+		if name == clusterName {
+			return true
+		}
+	}
+	return false
+}
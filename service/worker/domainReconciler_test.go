@@ -0,0 +1,268 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package worker
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-common/bark"
+	"github.com/uber-go/tally"
+	"github.com/uber/cadence/.gen/go/admin"
+	"github.com/uber/cadence/.gen/go/admin/adminservicetest"
+	"github.com/uber/cadence/.gen/go/replicator"
+	"github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/cluster"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/mocks"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
+
+type (
+	domainReconcilerSuite struct {
+		suite.Suite
+		mockCtrl            *gomock.Controller
+		mockAdminClient     *adminservicetest.MockClient
+		mockMetadataManager *mocks.MetadataManager
+		config              *Config
+		reconciler          *DomainReconciler
+	}
+)
+
+func TestDomainReconcilerSuite(t *testing.T) {
+	s := new(domainReconcilerSuite)
+	suite.Run(t, s)
+}
+
+func (s *domainReconcilerSuite) SetupTest() {
+	s.mockCtrl = gomock.NewController(s.T())
+	s.mockAdminClient = adminservicetest.NewMockClient(s.mockCtrl)
+	s.mockMetadataManager = &mocks.MetadataManager{}
+	s.config = NewConfig(dynamicconfig.NewNopCollection())
+	s.config.EnableDomainReconcilerRepair = dynamicconfig.GetBoolPropertyFn(true)
+	s.reconciler = NewDomainReconciler(cluster.GetTestClusterMetadata(true, true), s.mockMetadataManager, nil,
+		s.config, bark.NewLoggerFromLogrus(logrus.New()), metrics.NewClient(tally.NoopScope, metrics.Worker))
+}
+
+func (s *domainReconcilerSuite) TearDownTest() {
+	s.mockCtrl.Finish()
+	s.mockMetadataManager.AssertExpectations(s.T())
+}
+
+func (s *domainReconcilerSuite) TestReconcileCluster_NoDifference() {
+	local := s.newLocalDomain("some-domain", "some-id", 1, 10)
+	s.mockListDomains(local)
+	s.mockListGlobalDomains(s.newRemoteDomain(local))
+
+	s.NoError(s.reconciler.reconcileCluster(cluster.TestAlternativeClusterName, s.mockAdminClient))
+}
+
+func (s *domainReconcilerSuite) TestReconcileCluster_RepairWithHigherConfigVersion() {
+	local := s.newLocalDomain("some-domain", "some-id", 1, 10)
+	remote := s.newRemoteDomain(local)
+	remote.ConfigVersion = common.Int64Ptr(2)
+	remote.Info.Description = common.StringPtr("updated description")
+	remote.Config.WorkflowExecutionRetentionPeriodInDays = common.Int32Ptr(7)
+	s.mockListDomains(local)
+	s.mockListGlobalDomains(remote)
+
+	s.mockMetadataManager.On("GetMetadata").Return(&persistence.GetMetadataResponse{NotificationVersion: 5}, nil).Once()
+	s.mockMetadataManager.On("GetDomain", &persistence.GetDomainRequest{Name: "some-domain"}).Return(local, nil).Once()
+	s.mockMetadataManager.On("UpdateDomain", mock.MatchedBy(func(r *persistence.UpdateDomainRequest) bool {
+		return r.ConfigVersion == 2 && r.Info.Description == "updated description" && r.Config.Retention == 7 &&
+			r.FailoverVersion == 10 && r.NotificationVersion == 5
+	})).Return(nil).Once()
+
+	s.NoError(s.reconciler.reconcileCluster(cluster.TestAlternativeClusterName, s.mockAdminClient))
+}
+
+func (s *domainReconcilerSuite) TestReconcileCluster_RepairDisabled() {
+	s.config.EnableDomainReconcilerRepair = dynamicconfig.GetBoolPropertyFn(false)
+	local := s.newLocalDomain("some-domain", "some-id", 1, 10)
+	remote := s.newRemoteDomain(local)
+	remote.ConfigVersion = common.Int64Ptr(2)
+	remote.Info.Description = common.StringPtr("updated description")
+	s.mockListDomains(local)
+	s.mockListGlobalDomains(remote)
+
+	s.NoError(s.reconciler.reconcileCluster(cluster.TestAlternativeClusterName, s.mockAdminClient))
+}
+
+func (s *domainReconcilerSuite) TestReconcileCluster_LowerRemoteVersionNotApplied() {
+	local := s.newLocalDomain("some-domain", "some-id", 2, 20)
+	remote := s.newRemoteDomain(local)
+	remote.ConfigVersion = common.Int64Ptr(1)
+	remote.FailoverVersion = common.Int64Ptr(10)
+	remote.Info.Description = common.StringPtr("stale description")
+	s.mockListDomains(local)
+	s.mockListGlobalDomains(remote)
+
+	s.NoError(s.reconciler.reconcileCluster(cluster.TestAlternativeClusterName, s.mockAdminClient))
+}
+
+func (s *domainReconcilerSuite) TestReconcileCluster_DifferentDomainIDNotApplied() {
+	local := s.newLocalDomain("some-domain", "some-id", 1, 10)
+	remote := s.newRemoteDomain(local)
+	remote.ID = common.StringPtr("other-id")
+	remote.ConfigVersion = common.Int64Ptr(2)
+	s.mockListDomains(local)
+	s.mockListGlobalDomains(remote)
+
+	s.NoError(s.reconciler.reconcileCluster(cluster.TestAlternativeClusterName, s.mockAdminClient))
+}
+
+func (s *domainReconcilerSuite) TestReconcileCluster_CreateMissingDomain() {
+	remote := s.newRemoteDomain(s.newLocalDomain("some-domain", "some-id", 1, 10))
+	s.mockListDomains()
+	s.mockListGlobalDomains(remote)
+
+	s.mockMetadataManager.On("GetMetadata").Return(&persistence.GetMetadataResponse{NotificationVersion: 5}, nil).Once()
+	s.mockMetadataManager.On("GetDomain", &persistence.GetDomainRequest{Name: "some-domain"}).
+		Return(nil, &shared.EntityNotExistsError{}).Once()
+	s.mockMetadataManager.On("CreateDomain", mock.MatchedBy(func(r *persistence.CreateDomainRequest) bool {
+		return r.Info.ID == "some-id" && r.Info.Name == "some-domain" && r.IsGlobalDomain &&
+			r.ConfigVersion == 1 && r.FailoverVersion == 10
+	})).Return(&persistence.CreateDomainResponse{ID: "some-id"}, nil).Once()
+
+	s.NoError(s.reconciler.reconcileCluster(cluster.TestAlternativeClusterName, s.mockAdminClient))
+}
+
+func (s *domainReconcilerSuite) TestReconcileCluster_DomainNotReplicatedToRemoteCluster() {
+	local := s.newLocalDomain("some-domain", "some-id", 1, 10)
+	local.IsGlobalDomain = false
+	local.ReplicationConfig.Clusters = []*persistence.ClusterReplicationConfig{
+		{ClusterName: cluster.TestCurrentClusterName},
+	}
+	s.mockListDomains(local)
+	s.mockListGlobalDomains()
+
+	s.NoError(s.reconciler.reconcileCluster(cluster.TestAlternativeClusterName, s.mockAdminClient))
+}
+
+func (s *domainReconcilerSuite) TestReconcileCluster_ListRemoteDomainsFailed() {
+	s.mockListDomains()
+	s.mockAdminClient.EXPECT().ListGlobalDomains(gomock.Any(), gomock.Any()).Return(nil, errors.New("some error"))
+
+	s.Error(s.reconciler.reconcileCluster(cluster.TestAlternativeClusterName, s.mockAdminClient))
+}
+
+func (s *domainReconcilerSuite) TestDiffDomain() {
+	local := s.newLocalDomain("some-domain", "some-id", 1, 10)
+	remote := s.newRemoteDomain(local)
+	s.Empty(diffDomain(local, remote))
+
+	// the order of the clusters and an empty data map do not matter
+	remote.ReplicationConfig.Clusters[0], remote.ReplicationConfig.Clusters[1] =
+		remote.ReplicationConfig.Clusters[1], remote.ReplicationConfig.Clusters[0]
+	local.Info.Data = nil
+	remote.Info.Data = map[string]string{}
+	s.Empty(diffDomain(local, remote))
+
+	remote.Info.OwnerEmail = common.StringPtr("other@uber.com")
+	remote.Config.EmitMetric = common.BoolPtr(false)
+	remote.ReplicationConfig.ActiveClusterName = common.StringPtr(cluster.TestAlternativeClusterName)
+	remote.ReplicationConfig.Clusters = remote.ReplicationConfig.Clusters[:1]
+	remote.FailoverVersion = common.Int64Ptr(11)
+	s.Equal([]string{"ownerEmail", "emitMetric", "activeClusterName", "clusters", "failoverVersion"},
+		diffDomain(local, remote))
+}
+
+func (s *domainReconcilerSuite) mockListDomains(domains ...*persistence.GetDomainResponse) {
+	s.mockMetadataManager.On("ListDomains", mock.Anything).Return(&persistence.ListDomainsResponse{
+		Domains: domains,
+	}, nil).Once()
+}
+
+func (s *domainReconcilerSuite) mockListGlobalDomains(domains ...*replicator.DomainTaskAttributes) {
+	s.mockAdminClient.EXPECT().ListGlobalDomains(gomock.Any(), &admin.ListGlobalDomainsRequest{
+		PageSize: common.Int32Ptr(domainReconcilerListPageSize),
+	}).Return(&admin.ListGlobalDomainsResponse{Domains: domains}, nil)
+}
+
+func (s *domainReconcilerSuite) newLocalDomain(name string, id string, configVersion int64,
+	failoverVersion int64) *persistence.GetDomainResponse {
+	return &persistence.GetDomainResponse{
+		Info: &persistence.DomainInfo{
+			ID:          id,
+			Name:        name,
+			Status:      persistence.DomainStatusRegistered,
+			Description: "some description",
+			OwnerEmail:  "some@uber.com",
+			Data:        map[string]string{"k": "v"},
+		},
+		Config: &persistence.DomainConfig{
+			Retention:  3,
+			EmitMetric: true,
+		},
+		ReplicationConfig: &persistence.DomainReplicationConfig{
+			ActiveClusterName: cluster.TestCurrentClusterName,
+			Clusters: []*persistence.ClusterReplicationConfig{
+				{ClusterName: cluster.TestCurrentClusterName},
+				{ClusterName: cluster.TestAlternativeClusterName},
+			},
+		},
+		IsGlobalDomain:  true,
+		ConfigVersion:   configVersion,
+		FailoverVersion: failoverVersion,
+	}
+}
+
+func (s *domainReconcilerSuite) newRemoteDomain(local *persistence.GetDomainResponse) *replicator.DomainTaskAttributes {
+	operation := replicator.DomainOperationUpdate
+	status := shared.DomainStatusRegistered
+	data := make(map[string]string)
+	for k, v := range local.Info.Data {
+		data[k] = v
+	}
+	clusters := []*shared.ClusterReplicationConfiguration{}
+	for _, clusterConfig := range local.ReplicationConfig.Clusters {
+		clusters = append(clusters, &shared.ClusterReplicationConfiguration{
+			ClusterName: common.StringPtr(clusterConfig.ClusterName),
+		})
+	}
+	return &replicator.DomainTaskAttributes{
+		DomainOperation: &operation,
+		ID:              common.StringPtr(local.Info.ID),
+		Info: &shared.DomainInfo{
+			Name:        common.StringPtr(local.Info.Name),
+			Status:      &status,
+			Description: common.StringPtr(local.Info.Description),
+			OwnerEmail:  common.StringPtr(local.Info.OwnerEmail),
+			Data:        data,
+		},
+		Config: &shared.DomainConfiguration{
+			WorkflowExecutionRetentionPeriodInDays: common.Int32Ptr(local.Config.Retention),
+			EmitMetric:                             common.BoolPtr(local.Config.EmitMetric),
+		},
+		ReplicationConfig: &shared.DomainReplicationConfiguration{
+			ActiveClusterName: common.StringPtr(local.ReplicationConfig.ActiveClusterName),
+			Clusters:          clusters,
+		},
+		ConfigVersion:   common.Int64Ptr(local.ConfigVersion),
+		FailoverVersion: common.Int64Ptr(local.FailoverVersion),
+	}
+}
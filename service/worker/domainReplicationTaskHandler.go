@@ -86,7 +86,7 @@ func (domainReplicator *domainReplicatorImpl) HandleReceivingTask(task *replicat
 // handleDomainCreationReplicationTask handle the domain creation replication task
 func (domainReplicator *domainReplicatorImpl) handleDomainCreationReplicationTask(task *replicator.DomainTaskAttributes) error {
 	// task already validated
-	status, err := domainReplicator.convertDomainStatusFromThrift(task.Info.Status)
+	status, err := convertDomainStatusFromThrift(task.Info.Status)
 	if err != nil {
 		return err
 	}
@@ -111,6 +111,7 @@ func (domainReplicator *domainReplicatorImpl) handleDomainCreationReplicationTas
 		IsGlobalDomain:  true, // local domain will not be replicated
 		ConfigVersion:   task.GetConfigVersion(),
 		FailoverVersion: task.GetFailoverVersion(),
+		FailoverHistory: domainReplicator.convertFailoverHistoryFromThrift(task.FailoverHistory),
 	}
 
 	_, err = domainReplicator.metadataManagerV2.CreateDomain(request)
@@ -120,7 +121,7 @@ func (domainReplicator *domainReplicatorImpl) handleDomainCreationReplicationTas
 // handleDomainUpdateReplicationTask handle the domain update replication task
 func (domainReplicator *domainReplicatorImpl) handleDomainUpdateReplicationTask(task *replicator.DomainTaskAttributes) error {
 	// task already validated
-	status, err := domainReplicator.convertDomainStatusFromThrift(task.Info.Status)
+	status, err := convertDomainStatusFromThrift(task.Info.Status)
 	if err != nil {
 		return err
 	}
@@ -155,6 +156,9 @@ func (domainReplicator *domainReplicatorImpl) handleDomainUpdateReplicationTask(
 		FailoverVersion:             resp.FailoverVersion,
 		FailoverNotificationVersion: resp.FailoverNotificationVersion,
 		NotificationVersion:         notificationVersion,
+		PendingActiveClusterName:    resp.PendingActiveClusterName,
+		FailoverEndTime:             resp.FailoverEndTime,
+		FailoverHistory:             resp.FailoverHistory,
 	}
 
 	if resp.ConfigVersion < task.GetConfigVersion() {
@@ -179,6 +183,13 @@ func (domainReplicator *domainReplicatorImpl) handleDomainUpdateReplicationTask(
 		request.ReplicationConfig.ActiveClusterName = task.ReplicationConfig.GetActiveClusterName()
 		request.FailoverVersion = task.GetFailoverVersion()
 		request.FailoverNotificationVersion = notificationVersion
+		// the failover is done, any graceful failover still draining in this cluster is superseded
+		request.PendingActiveClusterName = ""
+		request.FailoverEndTime = 0
+		// tasks sent by clusters which do not record failovers yet carry no history
+		if task.FailoverHistory != nil {
+			request.FailoverHistory = domainReplicator.convertFailoverHistoryFromThrift(task.FailoverHistory)
+		}
 	}
 
 	if !recordUpdated {
@@ -221,7 +232,23 @@ func (domainReplicator *domainReplicatorImpl) convertClusterReplicationConfigFro
 	return output
 }
 
-func (domainReplicator *domainReplicatorImpl) convertDomainStatusFromThrift(input *shared.DomainStatus) (int, error) {
+func (domainReplicator *domainReplicatorImpl) convertFailoverHistoryFromThrift(
+	input []*shared.DomainFailoverEvent) []*persistence.DomainFailoverEvent {
+	output := []*persistence.DomainFailoverEvent{}
+	for _, event := range input {
+		output = append(output, &persistence.DomainFailoverEvent{
+			FailoverTime:    event.GetFailoverTimestamp(),
+			FromCluster:     event.GetFromCluster(),
+			ToCluster:       event.GetToCluster(),
+			FailoverVersion: event.GetFailoverVersion(),
+			Identity:        event.GetIdentity(),
+			Reason:          event.GetReason(),
+		})
+	}
+	return output
+}
+
+func convertDomainStatusFromThrift(input *shared.DomainStatus) (int, error) {
 	if input == nil {
 		return 0, ErrInvalidDomainStatus
 	}
@@ -25,6 +25,7 @@ import (
 	"log"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/pborman/uuid"
 	"github.com/sirupsen/logrus"
@@ -280,6 +281,16 @@ func (s *domainReplicatorSuite) TestHandleReceivingTask_UpdateDomainTask_UpdateC
 			ClusterName: common.StringPtr(updateClusterStandby),
 		},
 	}
+	updateFailoverHistory := []*shared.DomainFailoverEvent{
+		&shared.DomainFailoverEvent{
+			FailoverTimestamp: common.Int64Ptr(time.Now().UnixNano()),
+			FromCluster:       common.StringPtr(clusterActive),
+			ToCluster:         common.StringPtr(updateClusterActive),
+			FailoverVersion:   common.Int64Ptr(updateFailoverVersion),
+			Identity:          common.StringPtr("some random identity"),
+			Reason:            common.StringPtr("some random reason"),
+		},
+	}
 	updateTask := &replicator.DomainTaskAttributes{
 		DomainOperation: &updateOperation,
 		ID:              common.StringPtr(id),
@@ -300,6 +311,7 @@ func (s *domainReplicatorSuite) TestHandleReceivingTask_UpdateDomainTask_UpdateC
 		},
 		ConfigVersion:   common.Int64Ptr(updateConfigVersion),
 		FailoverVersion: common.Int64Ptr(updateFailoverVersion),
+		FailoverHistory: updateFailoverHistory,
 	}
 	metadata, err := s.MetadataManagerV2.GetMetadata()
 	s.Nil(err)
@@ -322,6 +334,7 @@ func (s *domainReplicatorSuite) TestHandleReceivingTask_UpdateDomainTask_UpdateC
 	s.Equal(updateConfigVersion, resp.ConfigVersion)
 	s.Equal(updateFailoverVersion, resp.FailoverVersion)
 	s.Equal(notificationVersion, resp.FailoverNotificationVersion)
+	s.Equal(s.domainReplicator.convertFailoverHistoryFromThrift(updateFailoverHistory), resp.FailoverHistory)
 	s.Equal(notificationVersion, resp.NotificationVersion)
 }
 
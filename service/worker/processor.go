@@ -37,6 +37,7 @@ import (
 	"github.com/uber/cadence/common/logging"
 	"github.com/uber/cadence/common/messaging"
 	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/xdc"
 	"go.uber.org/yarpc/yarpcerrors"
 )
 
@@ -53,6 +54,7 @@ type (
 		consumerName     string
 		client           messaging.Client
 		consumer         messaging.Consumer
+		dlq              messaging.DLQ
 		isStarted        int32
 		isStopped        int32
 		shutdownWG       sync.WaitGroup
@@ -62,6 +64,7 @@ type (
 		metricsClient    metrics.Client
 		domainReplicator DomainReplicator
 		historyClient    history.Client
+		rereplicator     xdc.HistoryRereplicator
 		msgEncoder       codec.BinaryEncoder
 	}
 )
@@ -87,9 +90,10 @@ var (
 	replicationTaskRetryPolicy = createReplicatorRetryPolicy()
 )
 
-func newReplicationTaskProcessor(currentCluster, sourceCluster, consumer string, client messaging.Client, config *Config,
+func newReplicationTaskProcessor(currentCluster, sourceCluster, consumer string, client messaging.Client,
+	dlq messaging.DLQ, config *Config,
 	logger bark.Logger, metricsClient metrics.Client, domainReplicator DomainReplicator,
-	historyClient history.Client) *replicationTaskProcessor {
+	historyClient history.Client, rereplicator xdc.HistoryRereplicator) *replicationTaskProcessor {
 
 	retryableHistoryClient := history.NewRetryableClient(historyClient, common.CreateHistoryServiceRetryPolicy(),
 		common.IsWhitelistServiceTransientError)
@@ -99,6 +103,7 @@ func newReplicationTaskProcessor(currentCluster, sourceCluster, consumer string,
 		sourceCluster:  sourceCluster,
 		consumerName:   consumer,
 		client:         client,
+		dlq:            dlq,
 		shutdownCh:     make(chan struct{}),
 		config:         config,
 		logger: logger.WithFields(bark.Fields{
@@ -109,6 +114,7 @@ func newReplicationTaskProcessor(currentCluster, sourceCluster, consumer string,
 		metricsClient:    metricsClient,
 		domainReplicator: domainReplicator,
 		historyClient:    retryableHistoryClient,
+		rereplicator:     rereplicator,
 		msgEncoder:       codec.NewThriftRWEncoder(),
 	}
 }
@@ -258,14 +264,46 @@ ProcessRetryLoop:
 		msg.Ack()
 	} else {
 		// Task still failed after all retries.  This is most probably due to a bug in replication code.
-		// Nack the task to move it to DLQ to not block replication for other workflow executions.
+		// Move the task to DLQ to not block replication for other workflow executions.
 		logger.WithFields(bark.Fields{
 			logging.TagErr:          err,
 			logging.TagAttemptCount: attempt,
 			logging.TagAttemptEnd:   time.Now(),
 		}).Error("Error processing replication task.")
+		p.moveToDLQ(msg, err, attempt, logger)
+	}
+}
+
+// moveToDLQ publishes the task along with the last error to DLQ so it can be inspected and re-driven later,
+// the message is nack'ed to the DLQ by the consumer if publishing fails
+func (p *replicationTaskProcessor) moveToDLQ(msg messaging.Message, lastErr error, attempt int, logger bark.Logger) {
+	p.metricsClient.IncCounter(metrics.ReplicatorScope, metrics.ReplicatorDLQMessages)
+	if p.dlq == nil {
+		msg.Nack()
+		return
+	}
+
+	task, err := p.deserialize(msg.Value())
+	if err != nil {
 		msg.Nack()
+		return
 	}
+
+	err = p.dlq.Publish(&replicator.DLQReplicationTask{
+		Task:          task,
+		SourceCluster: common.StringPtr(p.sourceCluster),
+		LastError:     common.StringPtr(lastErr.Error()),
+		Attempts:      common.Int32Ptr(int32(attempt)),
+		Timestamp:     common.Int64Ptr(time.Now().UnixNano()),
+	})
+	if err != nil {
+		logger.WithFields(bark.Fields{
+			logging.TagErr: err,
+		}).Warn("Failed to publish replication task to DLQ, nack the message instead.")
+		msg.Nack()
+		return
+	}
+	msg.Ack()
 }
 
 func (p *replicationTaskProcessor) process(msg messaging.Message, logger bark.Logger, inRetry bool) (bark.Logger, error) {
@@ -391,12 +429,26 @@ Loop:
 		ForceBufferEvents: common.BoolPtr(inRetry),
 	}
 
+	resent := false
 RetryLoop:
 	for i := 0; i < p.config.ReplicatorBufferRetryCount; i++ {
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		err = p.historyClient.ReplicateEvents(ctx, req)
 		cancel()
 
+		// The current cluster could be missing events before this task, fetch them from the source cluster
+		// once and apply the task again right away
+		if retryErr, ok := err.(*shared.RetryTaskError); ok && !resent && p.rereplicator != nil {
+			resent = true
+			resendErr := p.rereplicator.SendMissingHistory(p.sourceCluster, attr, retryErr)
+			if resendErr == nil {
+				continue RetryLoop
+			}
+			if resendErr != xdc.ErrNoMissingHistory {
+				logger.WithField(logging.TagErr, resendErr).Warn("Failed to re-replicate missing history.")
+			}
+		}
+
 		// Replication tasks could be slightly out of order for a particular workflow execution
 		// We first try to apply the events without buffering enabled with a small delay to account for such delays
 		// Caller should try to apply the event with buffering enabled once we return RetryTaskError after all retries
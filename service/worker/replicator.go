@@ -21,15 +21,24 @@
 package worker
 
 import (
-	"fmt"
+	"context"
+	"sync"
+	"time"
 
 	"github.com/uber-common/bark"
+	h "github.com/uber/cadence/.gen/go/history"
+	"github.com/uber/cadence/client"
+	"github.com/uber/cadence/client/admin"
 	"github.com/uber/cadence/client/history"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/cache"
 	"github.com/uber/cadence/common/cluster"
 	"github.com/uber/cadence/common/logging"
 	"github.com/uber/cadence/common/messaging"
 	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/service/config"
+	"github.com/uber/cadence/common/xdc"
 )
 
 type (
@@ -38,18 +47,32 @@ type (
 		clusterMetadata  cluster.Metadata
 		domainReplicator DomainReplicator
 		historyClient    history.Client
+		clientFactory    client.Factory
+		domainCache      cache.DomainCache
 		config           *Config
 		client           messaging.Client
-		processors       []*replicationTaskProcessor
-		logger           bark.Logger
-		metricsClient    metrics.Client
+		dlq              messaging.DLQ
+		// processors are keyed by the source cluster, they are only changed by Start, the sync loop and Stop
+		processors    map[string]*clusterProcessor
+		logger        bark.Logger
+		metricsClient metrics.Client
+		shutdownC     chan struct{}
+		shutdownWG    sync.WaitGroup
+	}
+
+	// clusterProcessor is the replication task processor of a remote cluster, along with the address and
+	// replication topic of the cluster it was started with
+	clusterProcessor struct {
+		processor        *replicationTaskProcessor
+		address          config.Address
+		replicationTopic string
 	}
 )
 
 // NewReplicator creates a new replicator for processing replication tasks
 func NewReplicator(clusterMetadata cluster.Metadata, metadataManagerV2 persistence.MetadataManager,
-	historyClient history.Client, config *Config, client messaging.Client, logger bark.Logger,
-	metricsClient metrics.Client) *Replicator {
+	historyClient history.Client, clientFactory client.Factory, config *Config, client messaging.Client,
+	logger bark.Logger, metricsClient metrics.Client) *Replicator {
 	logger = logger.WithFields(bark.Fields{
 		logging.TagWorkflowComponent: logging.TagValueReplicatorComponent,
 	})
@@ -57,40 +80,120 @@ func NewReplicator(clusterMetadata cluster.Metadata, metadataManagerV2 persisten
 		clusterMetadata:  clusterMetadata,
 		domainReplicator: NewDomainReplicator(metadataManagerV2, logger),
 		historyClient:    historyClient,
+		clientFactory:    clientFactory,
+		domainCache:      cache.NewDomainCache(metadataManagerV2, clusterMetadata, metricsClient, logger),
 		config:           config,
 		client:           client,
+		processors:       make(map[string]*clusterProcessor),
 		logger:           logger,
 		metricsClient:    metricsClient,
+		shutdownC:        make(chan struct{}),
 	}
 }
 
 // Start is called to start replicator
 func (r *Replicator) Start() error {
 	currentClusterName := r.clusterMetadata.GetCurrentClusterName()
-	for cluster := range r.clusterMetadata.GetAllClusterFailoverVersions() {
-		if cluster != currentClusterName {
-			consumerName := getConsumerName(currentClusterName, cluster)
-			r.processors = append(r.processors, newReplicationTaskProcessor(currentClusterName, cluster, consumerName, r.client,
-				r.config, r.logger, r.metricsClient, r.domainReplicator, r.historyClient))
-		}
+	dlq, err := r.client.NewDLQ(currentClusterName)
+	if err != nil {
+		// replication tasks which failed processing are nack'ed to the DLQ by the consumer without the error details
+		r.logger.WithFields(bark.Fields{
+			logging.TagErr: err,
+		}).Warn("Failed to create DLQ for replicator.")
 	}
+	r.dlq = dlq
 
-	for _, processor := range r.processors {
-		if err := processor.Start(); err != nil {
-			return err
-		}
+	// events missing on the current cluster are fetched from the source cluster when a history task cannot be applied
+	r.domainCache.Start()
+	if err := r.syncProcessors(); err != nil {
+		return err
 	}
 
+	r.shutdownWG.Add(1)
+	go r.syncLoop()
 	return nil
 }
 
 // Stop is called to stop replicator
 func (r *Replicator) Stop() {
+	close(r.shutdownC)
+	if success := common.AwaitWaitGroup(&r.shutdownWG, time.Minute); !success {
+		r.logger.Warn("Timed out waiting for the cluster sync of replicator to stop.")
+	}
 	for _, processor := range r.processors {
-		processor.Stop()
+		processor.processor.Stop()
+	}
+	if r.dlq != nil {
+		r.dlq.Close()
+	}
+	r.domainCache.Stop()
+}
+
+// syncLoop picks up the remote clusters which are added, enabled, disabled or updated at runtime
+func (r *Replicator) syncLoop() {
+	defer r.shutdownWG.Done()
+
+	timer := time.NewTimer(r.config.ReplicatorClusterSyncInterval())
+	defer timer.Stop()
+	for {
+		select {
+		case <-r.shutdownC:
+			return
+		case <-timer.C:
+			if err := r.syncProcessors(); err != nil {
+				r.logger.WithFields(bark.Fields{
+					logging.TagErr: err,
+				}).Warn("Failed to sync replication task processors with the remote clusters.")
+			}
+			timer.Reset(r.config.ReplicatorClusterSyncInterval())
+		}
 	}
 }
 
-func getConsumerName(currentCluster, remoteCluster string) string {
-	return fmt.Sprintf("%v_consumer_for_%v", currentCluster, remoteCluster)
+// syncProcessors stops the processors of the remote clusters which are disabled or whose address or replication
+// topic changed, and starts the processors of the enabled remote clusters which have none
+func (r *Replicator) syncProcessors() error {
+	currentClusterName := r.clusterMetadata.GetCurrentClusterName()
+	addresses := r.clusterMetadata.GetAllClientAddress()
+	for cluster, processor := range r.processors {
+		if r.clusterMetadata.IsClusterEnabled(cluster) && processor.address == addresses[cluster] &&
+			processor.replicationTopic == r.clusterMetadata.GetReplicationTopic(cluster) {
+			continue
+		}
+		r.logger.WithFields(bark.Fields{
+			logging.TagSourceCluster: cluster,
+		}).Info("Stopping replication task processor of changed remote cluster.")
+		processor.processor.Stop()
+		delete(r.processors, cluster)
+	}
+
+	for cluster := range r.clusterMetadata.GetAllClusterFailoverVersions() {
+		if _, ok := r.processors[cluster]; ok || cluster == currentClusterName || !r.clusterMetadata.IsClusterEnabled(cluster) {
+			continue
+		}
+
+		adminClients := make(map[string]admin.Client)
+		address, ok := addresses[cluster]
+		if ok {
+			adminClients[cluster] = r.clientFactory.NewRemoteAdminClient(address.RPCName, address.RPCAddress)
+		}
+		rereplicator := xdc.NewHistoryRereplicator(r.domainCache, adminClients,
+			func(ctx context.Context, request *h.ReplicateEventsRequest) error {
+				return r.historyClient.ReplicateEvents(ctx, request)
+			},
+			persistence.NewHistorySerializer(), r.logger)
+
+		consumerName := messaging.GetReplicationConsumerName(currentClusterName, cluster)
+		processor := newReplicationTaskProcessor(currentClusterName, cluster, consumerName, r.client,
+			r.dlq, r.config, r.logger, r.metricsClient, r.domainReplicator, r.historyClient, rereplicator)
+		if err := processor.Start(); err != nil {
+			return err
+		}
+		r.processors[cluster] = &clusterProcessor{
+			processor:        processor,
+			address:          address,
+			replicationTopic: r.clusterMetadata.GetReplicationTopic(cluster),
+		}
+	}
+	return nil
 }
@@ -21,6 +21,8 @@
 package worker
 
 import (
+	"time"
+
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/persistence"
@@ -47,6 +49,19 @@ type (
 		ReplicatorConcurrency      int
 		ReplicatorBufferRetryCount int
 		ReplicationTaskMaxRetry    int
+		// ReplicatorClusterSyncInterval is the interval to pick up the remote clusters changed at runtime
+		ReplicatorClusterSyncInterval dynamicconfig.DurationPropertyFn
+
+		// TaskListScavenger settings
+		EnableTaskListScavenger        dynamicconfig.BoolPropertyFn
+		TaskListScavengerInterval      dynamicconfig.DurationPropertyFn
+		TaskListScavengerMaxIdleTime   dynamicconfig.DurationPropertyFn
+		TaskListScavengerTaskBatchSize dynamicconfig.IntPropertyFn
+
+		// DomainReconciler settings
+		EnableDomainReconciler       dynamicconfig.BoolPropertyFn
+		DomainReconcilerInterval     dynamicconfig.DurationPropertyFn
+		EnableDomainReconcilerRepair dynamicconfig.BoolPropertyFn
 	}
 )
 
@@ -63,10 +78,20 @@ func NewService(params *service.BootstrapParams) common.Daemon {
 // NewConfig builds the new Config for cadence-worker service
 func NewConfig(dc *dynamicconfig.Collection) *Config {
 	return &Config{
-		PersistenceMaxQPS:          dc.GetIntProperty(dynamicconfig.WorkerPersistenceMaxQPS, 500),
-		ReplicatorConcurrency:      1000,
-		ReplicatorBufferRetryCount: 8,
-		ReplicationTaskMaxRetry:    50,
+		PersistenceMaxQPS:             dc.GetIntProperty(dynamicconfig.WorkerPersistenceMaxQPS, 500),
+		ReplicatorConcurrency:         1000,
+		ReplicatorBufferRetryCount:    8,
+		ReplicationTaskMaxRetry:       50,
+		ReplicatorClusterSyncInterval: dc.GetDurationProperty(dynamicconfig.ReplicatorClusterSyncInterval, time.Minute),
+
+		EnableTaskListScavenger:        dc.GetBoolProperty(dynamicconfig.EnableTaskListScavenger, false),
+		TaskListScavengerInterval:      dc.GetDurationProperty(dynamicconfig.TaskListScavengerInterval, time.Hour),
+		TaskListScavengerMaxIdleTime:   dc.GetDurationProperty(dynamicconfig.TaskListScavengerMaxIdleTime, 24*time.Hour),
+		TaskListScavengerTaskBatchSize: dc.GetIntProperty(dynamicconfig.TaskListScavengerTaskBatchSize, 1000),
+
+		EnableDomainReconciler:       dc.GetBoolProperty(dynamicconfig.EnableDomainReconciler, false),
+		DomainReconcilerInterval:     dc.GetDurationProperty(dynamicconfig.DomainReconcilerInterval, time.Hour),
+		EnableDomainReconcilerRepair: dc.GetBoolProperty(dynamicconfig.EnableDomainReconcilerRepair, false),
 	}
 }
 
@@ -105,13 +130,39 @@ func (s *Service) Start() {
 		log.Fatalf("failed to create history service client: %v", err)
 	}
 
-	replicator := NewReplicator(p.ClusterMetadata, metadataManager, history, s.config, p.MessagingClient, log,
-		s.metricsClient)
+	replicator := NewReplicator(p.ClusterMetadata, metadataManager, history, base.GetClientFactory(), s.config,
+		p.MessagingClient, log, s.metricsClient)
 	if err := replicator.Start(); err != nil {
 		replicator.Stop()
 		log.Fatalf("Fail to start replicator: %v", err)
 	}
 
+	if s.config.EnableDomainReconciler() && p.ClusterMetadata.IsGlobalDomainEnabled() {
+		reconciler := NewDomainReconciler(p.ClusterMetadata, metadataManager, base.GetClientFactory(), s.config,
+			log, s.metricsClient)
+		reconciler.Start()
+		defer reconciler.Stop()
+	}
+
+	if s.config.EnableTaskListScavenger() {
+		taskManager, err := cassandra.NewTaskPersistence(p.CassandraConfig.Hosts,
+			p.CassandraConfig.Port,
+			p.CassandraConfig.User,
+			p.CassandraConfig.Password,
+			p.CassandraConfig.Datacenter,
+			p.CassandraConfig.Keyspace,
+			p.Logger)
+		if err != nil {
+			log.Fatalf("failed to create task manager: %v", err)
+		}
+		taskManager = persistence.NewTaskPersistenceRateLimitedClient(taskManager, persistenceRateLimiter, log)
+		taskManager = persistence.NewTaskPersistenceMetricsClient(taskManager, base.GetMetricsClient(), log)
+
+		scavenger := NewTaskListScavenger(taskManager, s.config, log, s.metricsClient)
+		scavenger.Start()
+		defer scavenger.Stop()
+	}
+
 	log.Infof("%v started", common.WorkerServiceName)
 	<-s.stopC
 	base.Stop()
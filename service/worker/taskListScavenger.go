@@ -0,0 +1,231 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package worker
+
+import (
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/uber-common/bark"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/logging"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/persistence"
+)
+
+type (
+	// TaskListScavenger periodically scans all task lists and deletes the tasks of idle task lists
+	// which have either been acked or have expired. Task lists which are left without any tasks are
+	// deleted as well.
+	TaskListScavenger struct {
+		taskManager   persistence.TaskManager
+		config        *Config
+		logger        bark.Logger
+		metricsClient metrics.Client
+		isStarted     int32
+		isStopped     int32
+		shutdownWG    sync.WaitGroup
+		shutdownCh    chan struct{}
+	}
+)
+
+const (
+	taskListScavengerListPageSize = 100
+)
+
+// NewTaskListScavenger creates a new scavenger for idle task lists
+func NewTaskListScavenger(taskManager persistence.TaskManager, config *Config, logger bark.Logger,
+	metricsClient metrics.Client) *TaskListScavenger {
+	return &TaskListScavenger{
+		taskManager: taskManager,
+		config:      config,
+		logger: logger.WithFields(bark.Fields{
+			logging.TagWorkflowComponent: logging.TagValueTaskListScavengerComponent,
+		}),
+		metricsClient: metricsClient,
+		shutdownCh:    make(chan struct{}),
+	}
+}
+
+// Start is called to start the scavenger
+func (s *TaskListScavenger) Start() {
+	if !atomic.CompareAndSwapInt32(&s.isStarted, 0, 1) {
+		return
+	}
+
+	s.shutdownWG.Add(1)
+	go s.scavengerPump()
+
+	s.logger.Info("Task list scavenger started.")
+}
+
+// Stop is called to stop the scavenger
+func (s *TaskListScavenger) Stop() {
+	if !atomic.CompareAndSwapInt32(&s.isStopped, 0, 1) {
+		return
+	}
+
+	if atomic.LoadInt32(&s.isStarted) == 1 {
+		close(s.shutdownCh)
+	}
+
+	if success := common.AwaitWaitGroup(&s.shutdownWG, time.Minute); !success {
+		s.logger.Warn("Task list scavenger timed out on shutdown.")
+	}
+	s.logger.Info("Task list scavenger stopped.")
+}
+
+func (s *TaskListScavenger) scavengerPump() {
+	defer s.shutdownWG.Done()
+
+	timer := time.NewTimer(s.config.TaskListScavengerInterval())
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-s.shutdownCh:
+			return
+		case <-timer.C:
+			s.runPass()
+			timer.Reset(s.config.TaskListScavengerInterval())
+		}
+	}
+}
+
+// runPass walks through all the task lists once and scavenges the idle ones
+func (s *TaskListScavenger) runPass() {
+	sw := s.metricsClient.StartTimer(metrics.TaskListScavengerScope, metrics.TaskListScavengerPassLatency)
+	defer sw.Stop()
+
+	var pageToken []byte
+	for {
+		resp, err := s.taskManager.ListTaskList(&persistence.ListTaskListRequest{
+			PageSize:  taskListScavengerListPageSize,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			s.metricsClient.IncCounter(metrics.TaskListScavengerScope, metrics.TaskListScavengerFailures)
+			s.logger.WithField(logging.TagErr, err).Error("Failed to list task lists.")
+			return
+		}
+
+		for _, info := range resp.Items {
+			select {
+			case <-s.shutdownCh:
+				return
+			default:
+			}
+
+			if err := s.scavengeTaskList(info); err != nil {
+				s.metricsClient.IncCounter(metrics.TaskListScavengerScope, metrics.TaskListScavengerFailures)
+				s.logger.WithFields(bark.Fields{
+					logging.TagDomainID:     info.DomainID,
+					logging.TagTaskListName: info.Name,
+					logging.TagTaskListType: info.TaskType,
+					logging.TagErr:          err,
+				}).Warn("Failed to scavenge task list.")
+			}
+		}
+
+		if len(resp.NextPageToken) == 0 {
+			return
+		}
+		pageToken = resp.NextPageToken
+	}
+}
+
+// scavengeTaskList deletes the acked and expired tasks at the head of an idle task list, and
+// deletes the task list itself once it has no tasks left
+func (s *TaskListScavenger) scavengeTaskList(info *persistence.TaskListInfo) error {
+	if time.Since(info.LastUpdated) < s.config.TaskListScavengerMaxIdleTime() {
+		return nil
+	}
+	s.metricsClient.IncCounter(metrics.TaskListScavengerScope, metrics.TaskListScavengerProcessedCount)
+
+	batchSize := s.config.TaskListScavengerTaskBatchSize()
+	readLevel := int64(-1)
+	for {
+		resp, err := s.taskManager.GetTasks(&persistence.GetTasksRequest{
+			DomainID:     info.DomainID,
+			TaskList:     info.Name,
+			TaskType:     info.TaskType,
+			ReadLevel:    readLevel,
+			MaxReadLevel: math.MaxInt64,
+			BatchSize:    batchSize,
+			RangeID:      info.RangeID,
+		})
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		deletable := 0
+		for _, task := range resp.Tasks {
+			if task.TaskID > info.AckLevel && (task.Expiry.IsZero() || task.Expiry.After(now)) {
+				break
+			}
+			deletable++
+		}
+
+		if deletable > 0 {
+			lastTaskID := resp.Tasks[deletable-1].TaskID
+			_, err := s.taskManager.CompleteTasksLessThan(&persistence.CompleteTasksLessThanRequest{
+				DomainID:     info.DomainID,
+				TaskListName: info.Name,
+				TaskType:     info.TaskType,
+				TaskID:       lastTaskID,
+				Limit:        deletable,
+			})
+			if err != nil {
+				return err
+			}
+			s.metricsClient.AddCounter(metrics.TaskListScavengerScope, metrics.TaskListScavengerTasksDeletedCount,
+				int64(deletable))
+			readLevel = lastTaskID
+		}
+
+		if deletable < len(resp.Tasks) {
+			// the task list still has live tasks, leave it in place
+			return nil
+		}
+		if len(resp.Tasks) < batchSize {
+			break
+		}
+	}
+
+	err := s.taskManager.DeleteTaskList(&persistence.DeleteTaskListRequest{
+		DomainID:     info.DomainID,
+		TaskListName: info.Name,
+		TaskListType: info.TaskType,
+		RangeID:      info.RangeID,
+	})
+	if err != nil {
+		if _, ok := err.(*persistence.ConditionFailedError); ok {
+			// the task list got leased by a matching host in the meantime
+			return nil
+		}
+		return err
+	}
+	s.metricsClient.IncCounter(metrics.TaskListScavengerScope, metrics.TaskListScavengerTaskListsDeletedCount)
+	return nil
+}
@@ -0,0 +1,139 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package worker
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-common/bark"
+	"github.com/uber-go/tally"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/mocks"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+)
+
+type (
+	taskListScavengerSuite struct {
+		suite.Suite
+		mockTaskManager *mocks.TaskManager
+		scavenger       *TaskListScavenger
+	}
+)
+
+func TestTaskListScavengerSuite(t *testing.T) {
+	s := new(taskListScavengerSuite)
+	suite.Run(t, s)
+}
+
+func (s *taskListScavengerSuite) SetupTest() {
+	s.mockTaskManager = &mocks.TaskManager{}
+	config := NewConfig(dynamicconfig.NewNopCollection())
+	config.TaskListScavengerTaskBatchSize = dynamicconfig.GetIntPropertyFn(2)
+	s.scavenger = NewTaskListScavenger(s.mockTaskManager, config, bark.NewLoggerFromLogrus(logrus.New()),
+		metrics.NewClient(tally.NoopScope, metrics.Worker))
+}
+
+func (s *taskListScavengerSuite) TearDownTest() {
+	s.mockTaskManager.AssertExpectations(s.T())
+}
+
+func (s *taskListScavengerSuite) TestSkipActiveTaskList() {
+	info := s.newTaskListInfo(time.Now())
+	s.NoError(s.scavenger.scavengeTaskList(info))
+}
+
+func (s *taskListScavengerSuite) TestDeleteIdleTaskList() {
+	info := s.newTaskListInfo(time.Now().Add(-48 * time.Hour))
+	info.AckLevel = 11
+
+	s.mockTaskManager.On("GetTasks", mock.MatchedBy(func(r *persistence.GetTasksRequest) bool {
+		return r.ReadLevel == -1
+	})).Return(&persistence.GetTasksResponse{
+		Tasks: []*persistence.TaskInfo{{TaskID: 10}, {TaskID: 11}},
+	}, nil).Once()
+	s.mockTaskManager.On("CompleteTasksLessThan", mock.MatchedBy(func(r *persistence.CompleteTasksLessThanRequest) bool {
+		return r.TaskID == 11 && r.Limit == 2
+	})).Return(2, nil).Once()
+	s.mockTaskManager.On("GetTasks", mock.MatchedBy(func(r *persistence.GetTasksRequest) bool {
+		return r.ReadLevel == 11
+	})).Return(&persistence.GetTasksResponse{
+		Tasks: []*persistence.TaskInfo{{TaskID: 12, Expiry: time.Now().Add(-time.Minute)}},
+	}, nil).Once()
+	s.mockTaskManager.On("CompleteTasksLessThan", mock.MatchedBy(func(r *persistence.CompleteTasksLessThanRequest) bool {
+		return r.TaskID == 12 && r.Limit == 1
+	})).Return(1, nil).Once()
+	s.mockTaskManager.On("DeleteTaskList", &persistence.DeleteTaskListRequest{
+		DomainID:     info.DomainID,
+		TaskListName: info.Name,
+		TaskListType: info.TaskType,
+		RangeID:      info.RangeID,
+	}).Return(nil).Once()
+
+	s.NoError(s.scavenger.scavengeTaskList(info))
+}
+
+func (s *taskListScavengerSuite) TestKeepTaskListWithLiveTasks() {
+	info := s.newTaskListInfo(time.Now().Add(-48 * time.Hour))
+	info.AckLevel = 10
+
+	s.mockTaskManager.On("GetTasks", mock.Anything).Return(&persistence.GetTasksResponse{
+		Tasks: []*persistence.TaskInfo{{TaskID: 10}, {TaskID: 11}},
+	}, nil).Once()
+	s.mockTaskManager.On("CompleteTasksLessThan", mock.MatchedBy(func(r *persistence.CompleteTasksLessThanRequest) bool {
+		return r.TaskID == 10 && r.Limit == 1
+	})).Return(1, nil).Once()
+
+	s.NoError(s.scavenger.scavengeTaskList(info))
+}
+
+func (s *taskListScavengerSuite) TestTaskListLeasedConcurrently() {
+	info := s.newTaskListInfo(time.Now().Add(-48 * time.Hour))
+
+	s.mockTaskManager.On("GetTasks", mock.Anything).Return(&persistence.GetTasksResponse{}, nil).Once()
+	s.mockTaskManager.On("DeleteTaskList", mock.Anything).Return(&persistence.ConditionFailedError{}).Once()
+
+	s.NoError(s.scavenger.scavengeTaskList(info))
+}
+
+func (s *taskListScavengerSuite) TestGetTasksFailure() {
+	info := s.newTaskListInfo(time.Now().Add(-48 * time.Hour))
+
+	s.mockTaskManager.On("GetTasks", mock.Anything).Return(nil, errors.New("some random error")).Once()
+
+	s.Error(s.scavenger.scavengeTaskList(info))
+}
+
+func (s *taskListScavengerSuite) newTaskListInfo(lastUpdated time.Time) *persistence.TaskListInfo {
+	return &persistence.TaskListInfo{
+		DomainID:    "some random domain ID",
+		Name:        "some random task list",
+		TaskType:    persistence.TaskListTypeActivity,
+		RangeID:     5,
+		Kind:        persistence.TaskListKindNormal,
+		LastUpdated: lastUpdated,
+	}
+}
@@ -42,6 +42,40 @@ func newAdminWorkflowCommands() []cli.Command {
 				AdminDescribeWorkflow(c)
 			},
 		},
+		{
+			Name:  "export",
+			Usage: "Export the persisted history batches of workflow execution to a file",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagWorkflowIDWithAlias,
+					Usage: "WorkflowID",
+				},
+				cli.StringFlag{
+					Name:  FlagRunIDWithAlias,
+					Usage: "RunID",
+				},
+				cli.StringFlag{
+					Name:  FlagOutputFilenameWithAlias,
+					Usage: "File to write the exported history to",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminExportWorkflow(c)
+			},
+		},
+		{
+			Name:  "import",
+			Usage: "Recreate workflow execution in the domain from a file written by export",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagInputFileWithAlias,
+					Usage: "File written by export",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminImportWorkflow(c)
+			},
+		},
 	}
 }
 
@@ -75,3 +109,158 @@ func newAdminHistoryHostCommands() []cli.Command {
 		},
 	}
 }
+
+func newAdminDLQCommands() []cli.Command {
+	return []cli.Command{
+		{
+			Name:  "read",
+			Usage: "Read the replication tasks in DLQ which have not been purged yet",
+			Flags: []cli.Flag{
+				cli.IntFlag{
+					Name:  FlagShardIDWithAlias,
+					Usage: "Only show the messages of the ShardID",
+				},
+				cli.IntFlag{
+					Name:  FlagPageSizeWithAlias,
+					Value: 100,
+					Usage: "Result page size",
+				},
+				cli.BoolFlag{
+					Name:  FlagMoreWithAlias,
+					Usage: "Read all messages instead of the first page",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminReadDLQMessages(c)
+			},
+		},
+		{
+			Name:  "purge",
+			Usage: "Discard the messages in DLQ partition up to and including the offset",
+			Flags: []cli.Flag{
+				cli.IntFlag{
+					Name:  FlagPartitionWithAlias,
+					Usage: "DLQ partition",
+				},
+				cli.Int64Flag{
+					Name:  FlagOffsetWithAlias,
+					Usage: "Inclusive end offset of the purged messages",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminPurgeDLQMessages(c)
+			},
+		},
+		{
+			Name:  "merge",
+			Usage: "Re-apply the messages in DLQ, merged messages are not purged",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagMessageIDsWithAlias,
+					Usage: "Comma separated message IDs in the format of partition:offset, as shown by dlq read",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminMergeDLQMessages(c)
+			},
+		},
+	}
+}
+
+func newAdminReplicationCommands() []cli.Command {
+	return []cli.Command{
+		{
+			Name:  "status",
+			Usage: "Describe how far behind each remote cluster is, summarized over all shards",
+			Flags: []cli.Flag{
+				cli.IntFlag{
+					Name:  FlagShardIDWithAlias,
+					Usage: "Only describe the ShardID",
+				},
+				cli.BoolFlag{
+					Name:  FlagPrintFullyDetailWithAlias,
+					Usage: "Print the replication status of every shard",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminDescribeReplicationStatus(c)
+			},
+		},
+	}
+}
+
+func newAdminClusterCommands() []cli.Command {
+	return []cli.Command{
+		{
+			Name:  "add",
+			Usage: "Register a cluster in the metadata store of the current cluster, run it against every cluster",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagClusterNameWithAlias,
+					Usage: "Name of the cluster",
+				},
+				cli.Int64Flag{
+					Name:  FlagInitialVersionWithAlias,
+					Usage: "Initial failover version of the cluster, unique among the clusters",
+				},
+				cli.StringFlag{
+					Name:  FlagRPCNameWithAlias,
+					Value: defaultFrontendRPCName,
+					Usage: "Service name of the frontend of the cluster",
+				},
+				cli.StringFlag{
+					Name:  FlagRPCAddressWithAlias,
+					Usage: "host:port of the frontend of the cluster",
+				},
+				cli.StringFlag{
+					Name:  FlagReplicationTopicWithAlias,
+					Usage: "Topic the cluster publishes replication tasks to, the configured topic is used if not set",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminAddCluster(c)
+			},
+		},
+		{
+			Name:  "update",
+			Usage: "Enable or disable a cluster, or change its frontend address or replication topic",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagClusterNameWithAlias,
+					Usage: "Name of the cluster",
+				},
+				cli.BoolFlag{
+					Name:  FlagEnable,
+					Usage: "Enable the cluster",
+				},
+				cli.BoolFlag{
+					Name:  FlagDisable,
+					Usage: "Disable the cluster, requests are no longer forwarded to nor replication tasks received from it",
+				},
+				cli.StringFlag{
+					Name:  FlagRPCNameWithAlias,
+					Usage: "Service name of the frontend of the cluster",
+				},
+				cli.StringFlag{
+					Name:  FlagRPCAddressWithAlias,
+					Usage: "host:port of the frontend of the cluster",
+				},
+				cli.StringFlag{
+					Name:  FlagReplicationTopicWithAlias,
+					Usage: "Topic the cluster publishes replication tasks to",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminUpdateCluster(c)
+			},
+		},
+		{
+			Name:    "list",
+			Aliases: []string{"l"},
+			Usage:   "List the clusters in the static config and the registered ones",
+			Action: func(c *cli.Context) {
+				AdminListClusters(c)
+			},
+		},
+	}
+}
@@ -21,6 +21,20 @@
 package cli
 
 import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	serverAdmin "github.com/uber/cadence/.gen/go/admin"
+	serverAdminClient "github.com/uber/cadence/.gen/go/admin/adminserviceclient"
+	"github.com/uber/cadence/.gen/go/replicator"
+	serverShared "github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/common"
 	"github.com/urfave/cli"
 	"go.uber.org/cadence/.gen/go/admin"
@@ -37,6 +51,15 @@ func getAdminServiceClient(c *cli.Context) adminserviceclient.Interface {
 	return client
 }
 
+func getServerAdminServiceClient(c *cli.Context) serverAdminClient.Interface {
+	client, err := cBuilder.BuildServerAdminServiceClient(c)
+	if err != nil {
+		ExitIfError(err)
+	}
+
+	return client
+}
+
 // AdminDescribeWorkflow describe a new workflow execution for admin
 func AdminDescribeWorkflow(c *cli.Context) {
 	// using service client instead of cadence.Client because we need to directly pass the json blob as input.
@@ -63,6 +86,88 @@ func AdminDescribeWorkflow(c *cli.Context) {
 	prettyPrintJSONObject(resp)
 }
 
+// rawHistoryFile is the content of the file written by workflow export and read by workflow import
+type rawHistoryFile struct {
+	WorkflowID     string
+	RunID          string
+	HistoryBatches []*serverShared.DataBlob
+}
+
+// AdminExportWorkflow writes the persisted history batches of workflow execution to a file
+func AdminExportWorkflow(c *cli.Context) {
+	adminClient := getServerAdminServiceClient(c)
+
+	domain := getRequiredGlobalOption(c, FlagDomain)
+	wid := getRequiredOption(c, FlagWorkflowID)
+	rid := getRequiredOption(c, FlagRunID)
+	outputFileName := getRequiredOption(c, FlagOutputFilename)
+
+	exported := &rawHistoryFile{WorkflowID: wid, RunID: rid}
+	request := &serverAdmin.GetWorkflowExecutionRawHistoryRequest{
+		Domain: common.StringPtr(domain),
+		Execution: &serverShared.WorkflowExecution{
+			WorkflowId: common.StringPtr(wid),
+			RunId:      common.StringPtr(rid),
+		},
+	}
+	for {
+		ctx, cancel := newContext()
+		resp, err := adminClient.GetWorkflowExecutionRawHistory(ctx, request)
+		cancel()
+		if err != nil {
+			ErrorAndExit("Get raw workflow history failed", err)
+		}
+
+		exported.HistoryBatches = append(exported.HistoryBatches, resp.HistoryBatches...)
+		request.NextPageToken = resp.NextPageToken
+		if len(resp.NextPageToken) == 0 {
+			break
+		}
+	}
+
+	data, err := json.Marshal(exported)
+	if err != nil {
+		ErrorAndExit("Serialize exported history failed", err)
+	}
+	if err := ioutil.WriteFile(outputFileName, data, 0666); err != nil {
+		ErrorAndExit("Write exported history failed", err)
+	}
+	fmt.Printf("Exported %v history batches to %v.\n", len(exported.HistoryBatches), outputFileName)
+}
+
+// AdminImportWorkflow recreates workflow execution from a file written by export
+func AdminImportWorkflow(c *cli.Context) {
+	adminClient := getServerAdminServiceClient(c)
+
+	domain := getRequiredGlobalOption(c, FlagDomain)
+	inputFileName := getRequiredOption(c, FlagInputFile)
+
+	data, err := ioutil.ReadFile(inputFileName)
+	if err != nil {
+		ErrorAndExit("Read exported history failed", err)
+	}
+	var exported rawHistoryFile
+	if err := json.Unmarshal(data, &exported); err != nil {
+		ErrorAndExit("Input file is not an exported history", err)
+	}
+
+	ctx, cancel := newContext()
+	defer cancel()
+
+	err = adminClient.ImportWorkflowExecution(ctx, &serverAdmin.ImportWorkflowExecutionRequest{
+		Domain: common.StringPtr(domain),
+		Execution: &serverShared.WorkflowExecution{
+			WorkflowId: common.StringPtr(exported.WorkflowID),
+			RunId:      common.StringPtr(exported.RunID),
+		},
+		HistoryBatches: exported.HistoryBatches,
+	})
+	if err != nil {
+		ErrorAndExit("Import workflow execution failed", err)
+	}
+	fmt.Printf("Imported workflow %v, run %v.\n", exported.WorkflowID, exported.RunID)
+}
+
 // AdminDescribeHistoryHost describes history host
 func AdminDescribeHistoryHost(c *cli.Context) {
 	// using service client instead of cadence.Client because we need to directly pass the json blob as input.
@@ -102,3 +207,385 @@ func AdminDescribeHistoryHost(c *cli.Context) {
 	}
 	prettyPrintJSONObject(resp)
 }
+
+// AdminReadDLQMessages prints the replication tasks in DLQ
+func AdminReadDLQMessages(c *cli.Context) {
+	adminClient := getServerAdminServiceClient(c)
+
+	request := &serverAdmin.ReadDLQMessagesRequest{
+		MaximumPageSize: common.Int32Ptr(int32(c.Int(FlagPageSize))),
+	}
+	if c.IsSet(FlagShardID) {
+		request.ShardID = common.Int32Ptr(int32(c.Int(FlagShardID)))
+	}
+	if domain := c.GlobalString(FlagDomain); domain != "" {
+		request.Domain = common.StringPtr(domain)
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetBorder(false)
+	table.SetColumnSeparator("|")
+	table.SetHeader([]string{"Message ID", "Shard", "Type", "Workflow ID", "Run ID", "Events", "Version", "Attempts", "Failed Time", "Last Error"})
+	table.SetHeaderLine(false)
+
+	for {
+		ctx, cancel := newContext()
+		resp, err := adminClient.ReadDLQMessages(ctx, request)
+		cancel()
+		if err != nil {
+			ErrorAndExit("Read DLQ messages failed", err)
+		}
+
+		for _, message := range resp.Messages {
+			table.Append(dlqMessageToRow(message))
+		}
+
+		request.NextPageToken = resp.NextPageToken
+		if len(resp.NextPageToken) == 0 || !c.Bool(FlagMore) {
+			break
+		}
+	}
+	table.Render()
+
+	if len(request.NextPageToken) != 0 {
+		fmt.Println("More messages are available, use --more to read all of them.")
+	}
+}
+
+// AdminPurgeDLQMessages discards the messages in DLQ partition up to and including the offset
+func AdminPurgeDLQMessages(c *cli.Context) {
+	adminClient := getServerAdminServiceClient(c)
+
+	if !c.IsSet(FlagPartition) || !c.IsSet(FlagOffset) {
+		ErrorAndExit(fmt.Sprintf("%s and %s are required", FlagPartition, FlagOffset), nil)
+	}
+
+	ctx, cancel := newContext()
+	defer cancel()
+
+	err := adminClient.PurgeDLQMessages(ctx, &serverAdmin.PurgeDLQMessagesRequest{
+		Partition:          common.Int32Ptr(int32(c.Int(FlagPartition))),
+		InclusiveEndOffset: common.Int64Ptr(c.Int64(FlagOffset)),
+	})
+	if err != nil {
+		ErrorAndExit("Purge DLQ messages failed", err)
+	}
+	fmt.Println("DLQ messages purged.")
+}
+
+// AdminMergeDLQMessages re-applies the messages in DLQ
+func AdminMergeDLQMessages(c *cli.Context) {
+	adminClient := getServerAdminServiceClient(c)
+
+	var messageIDs []*replicator.DLQMessageID
+	for _, id := range strings.Split(getRequiredOption(c, FlagMessageIDs), ",") {
+		messageID, err := parseDLQMessageID(strings.TrimSpace(id))
+		if err != nil {
+			ErrorAndExit(fmt.Sprintf("Invalid message ID %v", id), err)
+		}
+		messageIDs = append(messageIDs, messageID)
+	}
+
+	ctx, cancel := newContext()
+	defer cancel()
+
+	err := adminClient.MergeDLQMessages(ctx, &serverAdmin.MergeDLQMessagesRequest{
+		MessageIDs: messageIDs,
+	})
+	if err != nil {
+		ErrorAndExit("Merge DLQ messages failed", err)
+	}
+	fmt.Println("DLQ messages merged, purge them once verified.")
+}
+
+// AdminDescribeReplicationStatus prints the replication lag of every remote cluster and the offsets of the
+// consumers receiving the replication tasks of the remote clusters
+func AdminDescribeReplicationStatus(c *cli.Context) {
+	adminClient := getServerAdminServiceClient(c)
+
+	request := &serverAdmin.DescribeReplicationStatusRequest{}
+	if c.IsSet(FlagShardID) {
+		request.ShardIDs = []int32{int32(c.Int(FlagShardID))}
+	}
+
+	ctx, cancel := newContext()
+	defer cancel()
+	resp, err := adminClient.DescribeReplicationStatus(ctx, request)
+	if err != nil {
+		ErrorAndExit("Describe replication status failed", err)
+	}
+
+	shardIDs := make([]int, 0, len(resp.StatusByShard))
+	for shardID := range resp.StatusByShard {
+		shardIDs = append(shardIDs, int(shardID))
+	}
+	sort.Ints(shardIDs)
+
+	if c.Bool(FlagPrintFullyDetail) {
+		table := newReplicationStatusTable([]string{"Shard", "Remote Cluster", "Ack Level", "Max Read Level", "Task ID Lag", "Oldest Unacked Task Age"})
+		for _, shardID := range shardIDs {
+			status := resp.StatusByShard[int32(shardID)]
+			for _, cluster := range getSortedClusters(status.RemoteClusters) {
+				clusterStatus := status.RemoteClusters[cluster]
+				table.Append([]string{
+					strconv.Itoa(shardID),
+					cluster,
+					strconv.FormatInt(clusterStatus.GetAckLevel(), 10),
+					strconv.FormatInt(status.GetMaxReadLevel(), 10),
+					strconv.FormatInt(status.GetMaxReadLevel()-clusterStatus.GetAckLevel(), 10),
+					formatReplicationAge(clusterStatus.GetOldestUnackedTaskAgeInSeconds()),
+				})
+			}
+		}
+		table.Render()
+		fmt.Println()
+	}
+
+	table := newReplicationStatusTable([]string{"Remote Cluster", "Shards", "Shards Behind", "Max Task ID Lag", "Oldest Unacked Task Age", "Most Behind Shard"})
+	for _, summary := range summarizeReplicationStatus(resp.StatusByShard) {
+		mostBehindShard := ""
+		if summary.shardsBehind > 0 {
+			mostBehindShard = strconv.Itoa(int(summary.mostBehindShard))
+		}
+		table.Append([]string{
+			summary.cluster,
+			strconv.Itoa(summary.shards),
+			strconv.Itoa(summary.shardsBehind),
+			strconv.FormatInt(summary.maxTaskIDLag, 10),
+			formatReplicationAge(summary.oldestUnackedTaskAge),
+			mostBehindShard,
+		})
+	}
+	table.Render()
+
+	if len(resp.ConsumersBySourceCluster) == 0 {
+		return
+	}
+	fmt.Println()
+	table = newReplicationStatusTable([]string{"Source Cluster", "Consumer", "Partition", "Committed Offset", "Latest Offset", "Lag"})
+	sourceClusters := make([]string, 0, len(resp.ConsumersBySourceCluster))
+	for cluster := range resp.ConsumersBySourceCluster {
+		sourceClusters = append(sourceClusters, cluster)
+	}
+	sort.Strings(sourceClusters)
+	for _, cluster := range sourceClusters {
+		consumer := resp.ConsumersBySourceCluster[cluster]
+		for _, partition := range consumer.Partitions {
+			table.Append([]string{
+				cluster,
+				consumer.GetConsumerName(),
+				strconv.Itoa(int(partition.GetPartition())),
+				strconv.FormatInt(partition.GetCommittedOffset(), 10),
+				strconv.FormatInt(partition.GetLatestOffset(), 10),
+				strconv.FormatInt(partition.GetLatestOffset()-partition.GetCommittedOffset(), 10),
+			})
+		}
+	}
+	table.Render()
+}
+
+// replicationSummary is the replication status of a remote cluster over all shards
+type replicationSummary struct {
+	cluster              string
+	shards               int
+	shardsBehind         int
+	maxTaskIDLag         int64
+	oldestUnackedTaskAge int64
+	mostBehindShard      int32
+}
+
+func summarizeReplicationStatus(statusByShard map[int32]*replicator.ShardReplicationStatus) []*replicationSummary {
+	summaries := make(map[string]*replicationSummary)
+	for shardID, status := range statusByShard {
+		for cluster, clusterStatus := range status.RemoteClusters {
+			summary, ok := summaries[cluster]
+			if !ok {
+				summary = &replicationSummary{cluster: cluster}
+				summaries[cluster] = summary
+			}
+			summary.shards++
+
+			taskIDLag := status.GetMaxReadLevel() - clusterStatus.GetAckLevel()
+			if taskIDLag > summary.maxTaskIDLag {
+				summary.maxTaskIDLag = taskIDLag
+			}
+			age := clusterStatus.GetOldestUnackedTaskAgeInSeconds()
+			if age > 0 {
+				summary.shardsBehind++
+			}
+			if age > summary.oldestUnackedTaskAge || (age > 0 && age == summary.oldestUnackedTaskAge && shardID < summary.mostBehindShard) {
+				summary.oldestUnackedTaskAge = age
+				summary.mostBehindShard = shardID
+			}
+		}
+	}
+
+	result := make([]*replicationSummary, 0, len(summaries))
+	for _, summary := range summaries {
+		result = append(result, summary)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].cluster < result[j].cluster })
+	return result
+}
+
+// AdminAddCluster registers a cluster in the metadata store of the cluster the command is run against
+func AdminAddCluster(c *cli.Context) {
+	adminClient := getServerAdminServiceClient(c)
+	clusterName := getRequiredOption(c, FlagClusterName)
+	if !c.IsSet(FlagInitialVersion) {
+		ErrorAndExit("Add cluster failed", fmt.Errorf("option %s is required", FlagInitialVersion))
+	}
+
+	ctx, cancel := newContext()
+	defer cancel()
+	err := adminClient.AddCluster(ctx, &serverAdmin.AddClusterRequest{
+		ClusterName:            common.StringPtr(clusterName),
+		InitialFailoverVersion: common.Int64Ptr(c.Int64(FlagInitialVersion)),
+		RpcName:                common.StringPtr(c.String(FlagRPCName)),
+		RpcAddress:             common.StringPtr(getRequiredOption(c, FlagRPCAddress)),
+		ReplicationTopic:       common.StringPtr(c.String(FlagReplicationTopic)),
+	})
+	if err != nil {
+		ErrorAndExit("Add cluster failed", err)
+	}
+	fmt.Printf("Cluster %s added, it is picked up within the cluster metadata refresh interval.\n", clusterName)
+}
+
+// AdminUpdateCluster enables or disables a cluster, or changes its frontend address or replication topic
+func AdminUpdateCluster(c *cli.Context) {
+	adminClient := getServerAdminServiceClient(c)
+	clusterName := getRequiredOption(c, FlagClusterName)
+
+	request := &serverAdmin.UpdateClusterRequest{
+		ClusterName: common.StringPtr(clusterName),
+	}
+	if c.Bool(FlagEnable) && c.Bool(FlagDisable) {
+		ErrorAndExit("Update cluster failed", fmt.Errorf("only one of %s and %s can be set", FlagEnable, FlagDisable))
+	} else if c.Bool(FlagEnable) {
+		request.Enabled = common.BoolPtr(true)
+	} else if c.Bool(FlagDisable) {
+		request.Enabled = common.BoolPtr(false)
+	}
+	if c.IsSet(FlagRPCName) {
+		request.RpcName = common.StringPtr(c.String(FlagRPCName))
+	}
+	if c.IsSet(FlagRPCAddress) {
+		request.RpcAddress = common.StringPtr(c.String(FlagRPCAddress))
+	}
+	if c.IsSet(FlagReplicationTopic) {
+		request.ReplicationTopic = common.StringPtr(c.String(FlagReplicationTopic))
+	}
+
+	ctx, cancel := newContext()
+	defer cancel()
+	if err := adminClient.UpdateCluster(ctx, request); err != nil {
+		ErrorAndExit("Update cluster failed", err)
+	}
+	fmt.Printf("Cluster %s updated, the change is picked up within the cluster metadata refresh interval.\n", clusterName)
+}
+
+// AdminListClusters prints the clusters known to the cluster the command is run against
+func AdminListClusters(c *cli.Context) {
+	adminClient := getServerAdminServiceClient(c)
+
+	ctx, cancel := newContext()
+	defer cancel()
+	resp, err := adminClient.ListClusters(ctx, &serverAdmin.ListClustersRequest{})
+	if err != nil {
+		ErrorAndExit("List clusters failed", err)
+	}
+
+	table := newReplicationStatusTable([]string{"Cluster", "Initial Failover Version", "Enabled", "RPC Name", "RPC Address", "Replication Topic", "Current", "Registered"})
+	for _, cluster := range resp.Clusters {
+		table.Append([]string{
+			cluster.GetClusterName(),
+			strconv.FormatInt(cluster.GetInitialFailoverVersion(), 10),
+			strconv.FormatBool(cluster.GetEnabled()),
+			cluster.GetRpcName(),
+			cluster.GetRpcAddress(),
+			cluster.GetReplicationTopic(),
+			strconv.FormatBool(cluster.GetIsCurrentCluster()),
+			strconv.FormatBool(cluster.GetIsRegistered()),
+		})
+	}
+	table.Render()
+}
+
+func newReplicationStatusTable(header []string) *tablewriter.Table {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetBorder(false)
+	table.SetColumnSeparator("|")
+	table.SetHeader(header)
+	table.SetHeaderLine(false)
+	return table
+}
+
+func getSortedClusters(remoteClusters map[string]*replicator.RemoteClusterReplicationStatus) []string {
+	clusters := make([]string, 0, len(remoteClusters))
+	for cluster := range remoteClusters {
+		clusters = append(clusters, cluster)
+	}
+	sort.Strings(clusters)
+	return clusters
+}
+
+func formatReplicationAge(ageInSeconds int64) string {
+	return (time.Duration(ageInSeconds) * time.Second).String()
+}
+
+func dlqMessageToRow(message *replicator.DLQMessage) []string {
+	dlqTask := message.GetReplicationTask()
+	task := dlqTask.GetTask()
+
+	shard := ""
+	if message.ShardID != nil {
+		shard = strconv.Itoa(int(message.GetShardID()))
+	}
+	taskType := ""
+	if task.TaskType != nil {
+		taskType = task.GetTaskType().String()
+	}
+	workflowID, runID, events, version := "", "", "", ""
+	if attr := task.GetHistoryTaskAttributes(); attr != nil {
+		workflowID = attr.GetWorkflowId()
+		runID = attr.GetRunId()
+		events = fmt.Sprintf("[%v, %v)", attr.GetFirstEventId(), attr.GetNextEventId())
+		version = strconv.FormatInt(attr.GetVersion(), 10)
+	}
+	failedTime := ""
+	if dlqTask.Timestamp != nil {
+		failedTime = convertTime(dlqTask.GetTimestamp(), false)
+	}
+
+	return []string{
+		fmt.Sprintf("%v:%v", message.GetID().GetPartition(), message.GetID().GetOffset()),
+		shard,
+		taskType,
+		workflowID,
+		runID,
+		events,
+		version,
+		strconv.Itoa(int(dlqTask.GetAttempts())),
+		failedTime,
+		truncate(dlqTask.GetLastError()),
+	}
+}
+
+func parseDLQMessageID(id string) (*replicator.DLQMessageID, error) {
+	parts := strings.Split(id, ":")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("message ID should be in the format of partition:offset")
+	}
+	partition, err := strconv.ParseInt(parts[0], 10, 32)
+	if err != nil {
+		return nil, err
+	}
+	offset, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &replicator.DLQMessageID{
+		Partition: common.Int32Ptr(int32(partition)),
+		Offset:    common.Int64Ptr(offset),
+	}, nil
+}
@@ -83,6 +83,23 @@ func NewCliApp() *cli.App {
 					Usage:       "Run admin operation on history host",
 					Subcommands: newAdminHistoryHostCommands(),
 				},
+				{
+					Name:        "dlq",
+					Usage:       "Run admin operation on replication DLQ",
+					Subcommands: newAdminDLQCommands(),
+				},
+				{
+					Name:        "replication",
+					Aliases:     []string{"repl"},
+					Usage:       "Run admin operation on cross cluster replication",
+					Subcommands: newAdminReplicationCommands(),
+				},
+				{
+					Name:        "cluster",
+					Aliases:     []string{"cl"},
+					Usage:       "Run admin operation on the clusters known to the current cluster",
+					Subcommands: newAdminClusterCommands(),
+				},
 			},
 		},
 	}
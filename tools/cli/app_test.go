@@ -21,6 +21,8 @@
 package cli
 
 import (
+	"io/ioutil"
+	"os"
 	"testing"
 	"time"
 
@@ -28,6 +30,13 @@ import (
 	"github.com/olekukonko/tablewriter"
 	"github.com/pborman/uuid"
 	"github.com/stretchr/testify/suite"
+	serverAdmin "github.com/uber/cadence/.gen/go/admin"
+	serverAdminClient "github.com/uber/cadence/.gen/go/admin/adminserviceclient"
+	serverAdminTest "github.com/uber/cadence/.gen/go/admin/adminservicetest"
+	serverFrontend "github.com/uber/cadence/.gen/go/cadence/workflowserviceclient"
+	serverFrontendTest "github.com/uber/cadence/.gen/go/cadence/workflowservicetest"
+	"github.com/uber/cadence/.gen/go/replicator"
+	serverShared "github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/common"
 	"github.com/urfave/cli"
 	"go.uber.org/cadence/.gen/go/admin"
@@ -41,15 +50,19 @@ import (
 
 type cliAppSuite struct {
 	suite.Suite
-	app          *cli.App
-	mockCtrl     *gomock.Controller
-	service      *workflowservicetest.MockClient
-	adminService *adminservicetest.MockClient
+	app           *cli.App
+	mockCtrl      *gomock.Controller
+	service       *workflowservicetest.MockClient
+	adminService  *adminservicetest.MockClient
+	serverService *serverFrontendTest.MockClient
+	serverAdmin   *serverAdminTest.MockClient
 }
 
 type workflowClientBuilderMock struct {
-	service      workflowserviceclient.Interface
-	adminService adminserviceclient.Interface
+	service       workflowserviceclient.Interface
+	adminService  adminserviceclient.Interface
+	serverService serverFrontend.Interface
+	serverAdmin   serverAdminClient.Interface
 }
 
 func (mock *workflowClientBuilderMock) BuildServiceClient(c *cli.Context) (workflowserviceclient.Interface, error) {
@@ -60,6 +73,14 @@ func (mock *workflowClientBuilderMock) BuildAdminServiceClient(c *cli.Context) (
 	return mock.adminService, nil
 }
 
+func (mock *workflowClientBuilderMock) BuildServerServiceClient(c *cli.Context) (serverFrontend.Interface, error) {
+	return mock.serverService, nil
+}
+
+func (mock *workflowClientBuilderMock) BuildServerAdminServiceClient(c *cli.Context) (serverAdminClient.Interface, error) {
+	return mock.serverAdmin, nil
+}
+
 // this is the mock for yarpcCallOptions, make sure length are the same
 var callOptions = []interface{}{gomock.Any(), gomock.Any(), gomock.Any()}
 
@@ -84,7 +105,10 @@ func (s *cliAppSuite) SetupTest() {
 	s.mockCtrl = gomock.NewController(s.T())
 	s.service = workflowservicetest.NewMockClient(s.mockCtrl)
 	s.adminService = adminservicetest.NewMockClient(s.mockCtrl)
-	SetBuilder(&workflowClientBuilderMock{service: s.service, adminService: s.adminService})
+	s.serverService = serverFrontendTest.NewMockClient(s.mockCtrl)
+	s.serverAdmin = serverAdminTest.NewMockClient(s.mockCtrl)
+	SetBuilder(&workflowClientBuilderMock{service: s.service, adminService: s.adminService, serverService: s.serverService,
+		serverAdmin: s.serverAdmin})
 }
 
 func (s *cliAppSuite) TearDownTest() {
@@ -171,27 +195,134 @@ func (s *cliAppSuite) TestDomainUpdate_Failed() {
 	s.Nil(err)
 }
 
+var serverDescribeDomainResponse = &serverShared.DescribeDomainResponse{
+	DomainInfo: &serverShared.DomainInfo{
+		Name:        common.StringPtr("test-domain"),
+		Description: common.StringPtr("a test domain"),
+		OwnerEmail:  common.StringPtr("test@uber.com"),
+	},
+	Configuration: &serverShared.DomainConfiguration{
+		WorkflowExecutionRetentionPeriodInDays: common.Int32Ptr(3),
+		EmitMetric:                             common.BoolPtr(true),
+	},
+	ReplicationConfiguration: &serverShared.DomainReplicationConfiguration{
+		ActiveClusterName: common.StringPtr("active"),
+		Clusters: []*serverShared.ClusterReplicationConfiguration{
+			&serverShared.ClusterReplicationConfiguration{
+				ClusterName: common.StringPtr("active"),
+			},
+			&serverShared.ClusterReplicationConfiguration{
+				ClusterName: common.StringPtr("standby"),
+			},
+		},
+	},
+}
+
 func (s *cliAppSuite) TestDomainDescribe() {
-	resp := describeDomainResponse
-	s.service.EXPECT().DescribeDomain(gomock.Any(), gomock.Any(), callOptions...).Return(resp, nil)
+	resp := serverDescribeDomainResponse
+	s.serverService.EXPECT().DescribeDomain(gomock.Any(), gomock.Any()).Return(resp, nil)
+	err := s.app.Run([]string{"", "--do", domainName, "domain", "describe"})
+	s.Nil(err)
+}
+
+func (s *cliAppSuite) TestDomainDescribe_FailoverInProgress() {
+	resp := *serverDescribeDomainResponse
+	resp.FailoverInfo = &serverShared.FailoverInfo{
+		PendingActiveClusterName: common.StringPtr("standby"),
+		FailoverEndTimestamp:     common.Int64Ptr(time.Now().Add(time.Minute).UnixNano()),
+	}
+	s.serverService.EXPECT().DescribeDomain(gomock.Any(), gomock.Any()).Return(&resp, nil)
 	err := s.app.Run([]string{"", "--do", domainName, "domain", "describe"})
 	s.Nil(err)
 }
 
 func (s *cliAppSuite) TestDomainDescribe_DomainNotExist() {
-	resp := describeDomainResponse
-	s.service.EXPECT().DescribeDomain(gomock.Any(), gomock.Any(), callOptions...).Return(resp, &shared.EntityNotExistsError{})
+	resp := serverDescribeDomainResponse
+	s.serverService.EXPECT().DescribeDomain(gomock.Any(), gomock.Any()).Return(resp, &serverShared.EntityNotExistsError{})
 	err := s.app.Run([]string{"", "--do", domainName, "domain", "describe"})
 	s.Nil(err)
 }
 
 func (s *cliAppSuite) TestDomainDescribe_Failed() {
-	resp := describeDomainResponse
-	s.service.EXPECT().DescribeDomain(gomock.Any(), gomock.Any(), callOptions...).Return(resp, &shared.BadRequestError{"faked error"})
+	resp := serverDescribeDomainResponse
+	s.serverService.EXPECT().DescribeDomain(gomock.Any(), gomock.Any()).Return(resp, &serverShared.BadRequestError{Message: "faked error"})
 	err := s.app.Run([]string{"", "--do", domainName, "domain", "describe"})
 	s.Nil(err)
 }
 
+func (s *cliAppSuite) TestDomainFailover() {
+	resp := &serverShared.UpdateDomainResponse{
+		ReplicationConfiguration: &serverShared.DomainReplicationConfiguration{
+			ActiveClusterName: common.StringPtr("standby"),
+		},
+		FailoverVersion: common.Int64Ptr(11),
+	}
+	s.serverService.EXPECT().UpdateDomain(gomock.Any(), &serverShared.UpdateDomainRequest{
+		Name: common.StringPtr(domainName),
+		ReplicationConfiguration: &serverShared.DomainReplicationConfiguration{
+			ActiveClusterName: common.StringPtr("standby"),
+		},
+		FailoverReason: common.StringPtr("some reason"),
+		Identity:       common.StringPtr(getCliIdentity()),
+	}).Return(resp, nil)
+	err := s.app.Run([]string{"", "--do", domainName, "domain", "failover", "--ac", "standby", "--reason", "some reason"})
+	s.Nil(err)
+}
+
+func (s *cliAppSuite) TestDomainFailover_Graceful() {
+	resp := &serverShared.UpdateDomainResponse{
+		ReplicationConfiguration: &serverShared.DomainReplicationConfiguration{
+			ActiveClusterName: common.StringPtr("active"),
+		},
+		FailoverVersion: common.Int64Ptr(10),
+		FailoverInfo: &serverShared.FailoverInfo{
+			PendingActiveClusterName: common.StringPtr("standby"),
+			FailoverEndTimestamp:     common.Int64Ptr(time.Now().Add(2 * time.Minute).UnixNano()),
+		},
+	}
+	s.serverService.EXPECT().UpdateDomain(gomock.Any(), &serverShared.UpdateDomainRequest{
+		Name: common.StringPtr(domainName),
+		ReplicationConfiguration: &serverShared.DomainReplicationConfiguration{
+			ActiveClusterName: common.StringPtr("standby"),
+		},
+		FailoverReason:           common.StringPtr(""),
+		Identity:                 common.StringPtr(getCliIdentity()),
+		FailoverTimeoutInSeconds: common.Int32Ptr(120),
+	}).Return(resp, nil)
+	err := s.app.Run([]string{"", "--do", domainName, "domain", "failover", "--ac", "standby", "--graceful", "--fot", "120"})
+	s.Nil(err)
+}
+
+func (s *cliAppSuite) TestDomainFailoverHistory() {
+	resp := *serverDescribeDomainResponse
+	resp.FailoverHistory = []*serverShared.DomainFailoverEvent{
+		{
+			FailoverTimestamp: common.Int64Ptr(time.Now().Add(-time.Hour).UnixNano()),
+			FromCluster:       common.StringPtr("active"),
+			ToCluster:         common.StringPtr("standby"),
+			FailoverVersion:   common.Int64Ptr(11),
+			Identity:          common.StringPtr("some identity"),
+			Reason:            common.StringPtr("some reason"),
+		},
+		{
+			FailoverTimestamp: common.Int64Ptr(time.Now().UnixNano()),
+			FromCluster:       common.StringPtr("standby"),
+			ToCluster:         common.StringPtr("active"),
+			FailoverVersion:   common.Int64Ptr(20),
+			Identity:          common.StringPtr("some identity"),
+		},
+	}
+	s.serverService.EXPECT().DescribeDomain(gomock.Any(), gomock.Any()).Return(&resp, nil)
+	err := s.app.Run([]string{"", "--do", domainName, "domain", "failover-history"})
+	s.Nil(err)
+}
+
+func (s *cliAppSuite) TestDomainFailoverHistory_NoFailover() {
+	s.serverService.EXPECT().DescribeDomain(gomock.Any(), gomock.Any()).Return(serverDescribeDomainResponse, nil)
+	err := s.app.Run([]string{"", "--do", domainName, "domain", "fh"})
+	s.Nil(err)
+}
+
 var (
 	eventType = shared.EventTypeWorkflowExecutionStarted
 
@@ -242,6 +373,30 @@ func (s *cliAppSuite) TestShowHistory_PrintDateTime() {
 	s.Nil(err)
 }
 
+func (s *cliAppSuite) TestShowHistory_Last() {
+	resp := &serverShared.GetWorkflowExecutionHistoryResponse{
+		History: &serverShared.History{
+			Events: []*serverShared.HistoryEvent{
+				{
+					EventId:   common.Int64Ptr(5),
+					EventType: serverShared.EventTypeDecisionTaskCompleted.Ptr(),
+					DecisionTaskCompletedEventAttributes: &serverShared.DecisionTaskCompletedEventAttributes{
+						ScheduledEventId: common.Int64Ptr(3),
+						StartedEventId:   common.Int64Ptr(4),
+					},
+				},
+			},
+		},
+	}
+	s.serverService.EXPECT().GetWorkflowExecutionHistory(gomock.Any(), gomock.Any()).
+		Do(func(_ interface{}, request *serverShared.GetWorkflowExecutionHistoryRequest) {
+			s.Equal(int32(1), request.GetLastEventCount())
+			s.True(request.GetReverseOrder())
+		}).Return(resp, nil)
+	err := s.app.Run([]string{"", "--do", domainName, "workflow", "show", "-w", "wid", "--last", "1", "--reverse", "-eid", "5"})
+	s.Nil(err)
+}
+
 func (s *cliAppSuite) TestStartWorkflow() {
 	resp := &shared.StartWorkflowExecutionResponse{RunId: common.StringPtr(uuid.New())}
 	s.service.EXPECT().StartWorkflowExecution(gomock.Any(), gomock.Any()).Return(resp, nil).Times(2)
@@ -417,6 +572,227 @@ func (s *cliAppSuite) TestAdminDescribeWorkflow() {
 	s.Nil(err)
 }
 
+func (s *cliAppSuite) TestAdminReadDLQMessages() {
+	resp := &serverAdmin.ReadDLQMessagesResponse{
+		Messages: []*replicator.DLQMessage{
+			{
+				ID:      &replicator.DLQMessageID{Partition: common.Int32Ptr(1), Offset: common.Int64Ptr(10)},
+				ShardID: common.Int32Ptr(3),
+				ReplicationTask: &replicator.DLQReplicationTask{
+					Task: &replicator.ReplicationTask{
+						TaskType: replicator.ReplicationTaskTypeHistory.Ptr(),
+						HistoryTaskAttributes: &replicator.HistoryTaskAttributes{
+							WorkflowId:   common.StringPtr("test-wf-id"),
+							RunId:        common.StringPtr("test-run-id"),
+							FirstEventId: common.Int64Ptr(5),
+							NextEventId:  common.Int64Ptr(8),
+						},
+					},
+					LastError: common.StringPtr("test error"),
+					Attempts:  common.Int32Ptr(3),
+				},
+			},
+		},
+	}
+	s.serverAdmin.EXPECT().ReadDLQMessages(gomock.Any(), &serverAdmin.ReadDLQMessagesRequest{
+		ShardID:         common.Int32Ptr(3),
+		Domain:          common.StringPtr(domainName),
+		MaximumPageSize: common.Int32Ptr(100),
+	}).Return(resp, nil)
+	err := s.app.Run([]string{"", "--do", domainName, "admin", "dlq", "read", "--sid", "3"})
+	s.Nil(err)
+}
+
+func (s *cliAppSuite) TestAdminPurgeDLQMessages() {
+	s.serverAdmin.EXPECT().PurgeDLQMessages(gomock.Any(), &serverAdmin.PurgeDLQMessagesRequest{
+		Partition:          common.Int32Ptr(1),
+		InclusiveEndOffset: common.Int64Ptr(10),
+	}).Return(nil)
+	err := s.app.Run([]string{"", "admin", "dlq", "purge", "--pt", "1", "--os", "10"})
+	s.Nil(err)
+}
+
+func (s *cliAppSuite) TestAdminMergeDLQMessages() {
+	s.serverAdmin.EXPECT().MergeDLQMessages(gomock.Any(), &serverAdmin.MergeDLQMessagesRequest{
+		MessageIDs: []*replicator.DLQMessageID{
+			{Partition: common.Int32Ptr(1), Offset: common.Int64Ptr(10)},
+			{Partition: common.Int32Ptr(2), Offset: common.Int64Ptr(20)},
+		},
+	}).Return(nil)
+	err := s.app.Run([]string{"", "admin", "dlq", "merge", "--mids", "1:10, 2:20"})
+	s.Nil(err)
+}
+
+func (s *cliAppSuite) TestAdminDescribeReplicationStatus() {
+	resp := &serverAdmin.DescribeReplicationStatusResponse{
+		StatusByShard: map[int32]*replicator.ShardReplicationStatus{
+			3: {
+				ShardID:            common.Int32Ptr(3),
+				ReplicatorAckLevel: common.Int64Ptr(10),
+				MaxReadLevel:       common.Int64Ptr(20),
+				RemoteClusters: map[string]*replicator.RemoteClusterReplicationStatus{
+					"standby": {AckLevel: common.Int64Ptr(10), OldestUnackedTaskAgeInSeconds: common.Int64Ptr(5)},
+				},
+			},
+		},
+		ConsumersBySourceCluster: map[string]*serverAdmin.ReplicationConsumerStatus{
+			"standby": {
+				ConsumerName: common.StringPtr("active_consumer_for_standby"),
+				Partitions: []*replicator.ConsumerPartitionOffset{
+					{Partition: common.Int32Ptr(0), CommittedOffset: common.Int64Ptr(7), LatestOffset: common.Int64Ptr(9)},
+				},
+			},
+		},
+	}
+	s.serverAdmin.EXPECT().DescribeReplicationStatus(gomock.Any(), &serverAdmin.DescribeReplicationStatusRequest{
+		ShardIDs: []int32{3},
+	}).Return(resp, nil)
+	err := s.app.Run([]string{"", "admin", "replication", "status", "--shard_id", "3", "--pf"})
+	s.Nil(err)
+}
+
+func (s *cliAppSuite) TestAdminAddCluster() {
+	s.serverAdmin.EXPECT().AddCluster(gomock.Any(), &serverAdmin.AddClusterRequest{
+		ClusterName:            common.StringPtr("other"),
+		InitialFailoverVersion: common.Int64Ptr(2),
+		RpcName:                common.StringPtr("cadence-frontend"),
+		RpcAddress:             common.StringPtr("127.0.0.1:9933"),
+		ReplicationTopic:       common.StringPtr("other-topic"),
+	}).Return(nil)
+	err := s.app.Run([]string{"", "admin", "cluster", "add", "--cn", "other", "--ifv", "2",
+		"--ra", "127.0.0.1:9933", "--rt", "other-topic"})
+	s.Nil(err)
+}
+
+func (s *cliAppSuite) TestAdminUpdateCluster() {
+	s.serverAdmin.EXPECT().UpdateCluster(gomock.Any(), &serverAdmin.UpdateClusterRequest{
+		ClusterName: common.StringPtr("other"),
+		Enabled:     common.BoolPtr(false),
+	}).Return(nil)
+	err := s.app.Run([]string{"", "admin", "cluster", "update", "--cn", "other", "--disable"})
+	s.Nil(err)
+
+	s.serverAdmin.EXPECT().UpdateCluster(gomock.Any(), &serverAdmin.UpdateClusterRequest{
+		ClusterName: common.StringPtr("other"),
+		Enabled:     common.BoolPtr(true),
+		RpcAddress:  common.StringPtr("127.0.0.1:10933"),
+	}).Return(nil)
+	err = s.app.Run([]string{"", "admin", "cluster", "update", "--cn", "other", "--enable", "--ra", "127.0.0.1:10933"})
+	s.Nil(err)
+}
+
+func (s *cliAppSuite) TestAdminListClusters() {
+	resp := &serverAdmin.ListClustersResponse{
+		Clusters: []*serverAdmin.ClusterInfo{
+			{
+				ClusterName:            common.StringPtr("active"),
+				InitialFailoverVersion: common.Int64Ptr(0),
+				Enabled:                common.BoolPtr(true),
+				RpcName:                common.StringPtr("cadence-frontend"),
+				RpcAddress:             common.StringPtr("127.0.0.1:7933"),
+				IsCurrentCluster:       common.BoolPtr(true),
+				IsRegistered:           common.BoolPtr(false),
+			},
+			{
+				ClusterName:            common.StringPtr("other"),
+				InitialFailoverVersion: common.Int64Ptr(2),
+				Enabled:                common.BoolPtr(false),
+				ReplicationTopic:       common.StringPtr("other-topic"),
+				IsCurrentCluster:       common.BoolPtr(false),
+				IsRegistered:           common.BoolPtr(true),
+			},
+		},
+	}
+	s.serverAdmin.EXPECT().ListClusters(gomock.Any(), &serverAdmin.ListClustersRequest{}).Return(resp, nil)
+	err := s.app.Run([]string{"", "admin", "cluster", "list"})
+	s.Nil(err)
+}
+
+func (s *cliAppSuite) TestSummarizeReplicationStatus() {
+	statusByShard := map[int32]*replicator.ShardReplicationStatus{
+		1: {
+			MaxReadLevel: common.Int64Ptr(100),
+			RemoteClusters: map[string]*replicator.RemoteClusterReplicationStatus{
+				"standby": {AckLevel: common.Int64Ptr(100), OldestUnackedTaskAgeInSeconds: common.Int64Ptr(0)},
+			},
+		},
+		2: {
+			MaxReadLevel: common.Int64Ptr(200),
+			RemoteClusters: map[string]*replicator.RemoteClusterReplicationStatus{
+				"standby": {AckLevel: common.Int64Ptr(150), OldestUnackedTaskAgeInSeconds: common.Int64Ptr(30)},
+			},
+		},
+		3: {
+			MaxReadLevel: common.Int64Ptr(300),
+			RemoteClusters: map[string]*replicator.RemoteClusterReplicationStatus{
+				"standby": {AckLevel: common.Int64Ptr(290), OldestUnackedTaskAgeInSeconds: common.Int64Ptr(10)},
+			},
+		},
+	}
+
+	summaries := summarizeReplicationStatus(statusByShard)
+	s.Equal(1, len(summaries))
+	s.Equal("standby", summaries[0].cluster)
+	s.Equal(3, summaries[0].shards)
+	s.Equal(2, summaries[0].shardsBehind)
+	s.Equal(int64(50), summaries[0].maxTaskIDLag)
+	s.Equal(int64(30), summaries[0].oldestUnackedTaskAge)
+	s.Equal(int32(2), summaries[0].mostBehindShard)
+}
+
+func (s *cliAppSuite) TestAdminExportImportWorkflow() {
+	file, err := ioutil.TempFile("", "cadence-export")
+	s.NoError(err)
+	file.Close()
+	defer os.Remove(file.Name())
+
+	execution := &serverShared.WorkflowExecution{
+		WorkflowId: common.StringPtr("test-wf-id"),
+		RunId:      common.StringPtr("test-run-id"),
+	}
+	batches := []*serverShared.DataBlob{
+		{EncodingType: serverShared.EncodingTypeThriftRW.Ptr(), Data: []byte("batch-1")},
+		{EncodingType: serverShared.EncodingTypeJSON.Ptr(), Data: []byte("batch-2")},
+	}
+	s.serverAdmin.EXPECT().GetWorkflowExecutionRawHistory(gomock.Any(), &serverAdmin.GetWorkflowExecutionRawHistoryRequest{
+		Domain:    common.StringPtr(domainName),
+		Execution: execution,
+	}).Return(&serverAdmin.GetWorkflowExecutionRawHistoryResponse{
+		HistoryBatches: batches[:1],
+		NextPageToken:  []byte("token"),
+	}, nil)
+	s.serverAdmin.EXPECT().GetWorkflowExecutionRawHistory(gomock.Any(), &serverAdmin.GetWorkflowExecutionRawHistoryRequest{
+		Domain:        common.StringPtr(domainName),
+		Execution:     execution,
+		NextPageToken: []byte("token"),
+	}).Return(&serverAdmin.GetWorkflowExecutionRawHistoryResponse{
+		HistoryBatches: batches[1:],
+	}, nil)
+	err = s.app.Run([]string{"", "--do", domainName, "admin", "wf", "export", "-w", "test-wf-id", "-r", "test-run-id",
+		"--of", file.Name()})
+	s.Nil(err)
+
+	s.serverAdmin.EXPECT().ImportWorkflowExecution(gomock.Any(), &serverAdmin.ImportWorkflowExecutionRequest{
+		Domain:         common.StringPtr("dev-domain"),
+		Execution:      execution,
+		HistoryBatches: batches,
+	}).Return(nil)
+	err = s.app.Run([]string{"", "--do", "dev-domain", "admin", "wf", "import", "--if", file.Name()})
+	s.Nil(err)
+}
+
+func (s *cliAppSuite) TestParseDLQMessageID() {
+	id, err := parseDLQMessageID("1:10")
+	s.NoError(err)
+	s.Equal(int32(1), id.GetPartition())
+	s.Equal(int64(10), id.GetOffset())
+
+	_, err = parseDLQMessageID("1")
+	s.Error(err)
+	_, err = parseDLQMessageID("a:10")
+	s.Error(err)
+}
+
 func (s *cliAppSuite) TestDescribeTaskList() {
 	resp := describeTaskListResponse
 	s.service.EXPECT().DescribeTaskList(gomock.Any(), gomock.Any(), callOptions...).Return(resp, nil)
@@ -431,6 +807,23 @@ func (s *cliAppSuite) TestDescribeTaskList_Activity() {
 	s.Nil(err)
 }
 
+func (s *cliAppSuite) TestListTaskLists() {
+	resp := &serverShared.ListTaskListsResponse{
+		TaskLists: []*serverShared.TaskListStatus{
+			&serverShared.TaskListStatus{
+				TaskList:       &serverShared.TaskList{Name: common.StringPtr("test-taskList"), Kind: common.TaskListKindPtr(serverShared.TaskListKindNormal)},
+				TaskListType:   common.TaskListTypePtr(serverShared.TaskListTypeDecision),
+				AckLevel:       common.Int64Ptr(10),
+				LastUpdateTime: common.Int64Ptr(time.Now().UnixNano()),
+				Loaded:         common.BoolPtr(true),
+			},
+		},
+	}
+	s.serverService.EXPECT().ListTaskLists(gomock.Any(), gomock.Any()).Return(resp, nil)
+	err := s.app.Run([]string{"", "--do", domainName, "tasklist", "list"})
+	s.Nil(err)
+}
+
 func (s *cliAppSuite) TestObserveWorkflow() {
 	history := getWorkflowExecutionHistoryResponse
 	s.service.EXPECT().GetWorkflowExecutionHistory(gomock.Any(), gomock.Any(), callOptions...).Return(history, nil).Times(2)
@@ -37,6 +37,8 @@ import (
 	"github.com/fatih/color"
 	"github.com/olekukonko/tablewriter"
 	"github.com/pborman/uuid"
+	serverFrontend "github.com/uber/cadence/.gen/go/cadence/workflowserviceclient"
+	serverShared "github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/common"
 	"github.com/urfave/cli"
 
@@ -123,6 +125,32 @@ const (
 	FlagEventIDWithAlias           = FlagEventID + ", eid"
 	FlagMaxFieldLength             = "max_field_length"
 	FlagMaxFieldLengthWithAlias    = FlagMaxFieldLength + ", maxl"
+	FlagReverse                    = "reverse"
+	FlagReverseWithAlias           = FlagReverse + ", rev"
+	FlagLastEventCount             = "last"
+	FlagLastEventCountWithAlias    = FlagLastEventCount + ", le"
+	FlagPartition                  = "partition"
+	FlagPartitionWithAlias         = FlagPartition + ", pt"
+	FlagOffset                     = "offset"
+	FlagOffsetWithAlias            = FlagOffset + ", os"
+	FlagMessageIDs                 = "message_ids"
+	FlagMessageIDsWithAlias        = FlagMessageIDs + ", mids"
+	FlagGraceful                   = "graceful"
+	FlagGracefulWithAlias          = FlagGraceful + ", g"
+	FlagFailoverTimeout            = "failover_timeout"
+	FlagFailoverTimeoutWithAlias   = FlagFailoverTimeout + ", fot"
+	FlagClusterName                = "cluster_name"
+	FlagClusterNameWithAlias       = FlagClusterName + ", cn"
+	FlagInitialVersion             = "initial_failover_version"
+	FlagInitialVersionWithAlias    = FlagInitialVersion + ", ifv"
+	FlagRPCName                    = "rpc_name"
+	FlagRPCNameWithAlias           = FlagRPCName + ", rn"
+	FlagRPCAddress                 = "rpc_address"
+	FlagRPCAddressWithAlias        = FlagRPCAddress + ", ra"
+	FlagReplicationTopic           = "replication_topic"
+	FlagReplicationTopicWithAlias  = FlagReplicationTopic + ", rt"
+	FlagEnable                     = "enable"
+	FlagDisable                    = "disable"
 )
 
 const (
@@ -139,6 +167,8 @@ const (
 	defaultContextTimeoutForLongPoll = 2 * time.Minute
 	defaultDecisionTimeoutInSeconds  = 10
 	defaultPageSizeForList           = 500
+	defaultFailoverTimeoutInSeconds  = 60
+	defaultFrontendRPCName           = "cadence-frontend"
 )
 
 // For color output to terminal
@@ -362,14 +392,16 @@ func UpdateDomain(c *cli.Context) {
 
 // DescribeDomain updates a domain
 func DescribeDomain(c *cli.Context) {
-	domainClient := getDomainClient(c)
+	serviceClient := getServerWorkflowServiceClient(c)
 	domain := getRequiredGlobalOption(c, FlagDomain)
 
 	ctx, cancel := newContext()
 	defer cancel()
-	resp, err := domainClient.Describe(ctx, domain)
+	resp, err := serviceClient.DescribeDomain(ctx, &serverShared.DescribeDomainRequest{
+		Name: common.StringPtr(domain),
+	})
 	if err != nil {
-		if _, ok := err.(*s.EntityNotExistsError); !ok {
+		if _, ok := err.(*serverShared.EntityNotExistsError); !ok {
 			fmt.Printf("Operation failed: %v.\n", err.Error())
 		} else {
 			fmt.Printf("Domain %s does not exist.\n", domain)
@@ -386,7 +418,93 @@ func DescribeDomain(c *cli.Context) {
 			resp.Configuration.GetEmitMetric(),
 			resp.ReplicationConfiguration.GetActiveClusterName(),
 			clustersToString(resp.ReplicationConfiguration.Clusters))
+		if failoverInfo := resp.FailoverInfo; failoverInfo != nil {
+			fmt.Printf("PendingActiveClusterName: %v\nFailoverEndTime: %v\n",
+				failoverInfo.GetPendingActiveClusterName(),
+				convertTime(failoverInfo.GetFailoverEndTimestamp(), false))
+		}
+	}
+}
+
+// FailoverDomain fails a domain over to another cluster
+func FailoverDomain(c *cli.Context) {
+	serviceClient := getServerWorkflowServiceClient(c)
+	domain := getRequiredGlobalOption(c, FlagDomain)
+	activeCluster := getRequiredOption(c, FlagActiveClusterName)
+
+	request := &serverShared.UpdateDomainRequest{
+		Name: common.StringPtr(domain),
+		ReplicationConfiguration: &serverShared.DomainReplicationConfiguration{
+			ActiveClusterName: common.StringPtr(activeCluster),
+		},
+		FailoverReason: common.StringPtr(c.String(FlagReason)),
+		Identity:       common.StringPtr(getCliIdentity()),
+	}
+	if c.Bool(FlagGraceful) {
+		timeout := c.Int(FlagFailoverTimeout)
+		if timeout <= 0 {
+			ErrorAndExit("Failover domain failed", fmt.Errorf("%s must be positive", FlagFailoverTimeout))
+		}
+		request.FailoverTimeoutInSeconds = common.Int32Ptr(int32(timeout))
+	}
+
+	ctx, cancel := newContext()
+	defer cancel()
+	resp, err := serviceClient.UpdateDomain(ctx, request)
+	if err != nil {
+		ErrorAndExit("Failover domain failed", err)
+	}
+	// a graceful failover switches the active cluster in the background, once replication caught up or timed out
+	if failoverInfo := resp.FailoverInfo; failoverInfo != nil {
+		fmt.Printf("Domain %s is failing over to cluster %s, the active cluster is switched by %v at the latest.\n",
+			domain, failoverInfo.GetPendingActiveClusterName(),
+			convertTime(failoverInfo.GetFailoverEndTimestamp(), false))
+		return
+	}
+	fmt.Printf("Domain %s is active in cluster %s with failover version %v.\n",
+		domain, resp.ReplicationConfiguration.GetActiveClusterName(), resp.GetFailoverVersion())
+}
+
+// ShowDomainFailoverHistory shows the recorded failovers of a domain, most recent first
+func ShowDomainFailoverHistory(c *cli.Context) {
+	serviceClient := getServerWorkflowServiceClient(c)
+	domain := getRequiredGlobalOption(c, FlagDomain)
+
+	ctx, cancel := newContext()
+	defer cancel()
+	resp, err := serviceClient.DescribeDomain(ctx, &serverShared.DescribeDomainRequest{
+		Name: common.StringPtr(domain),
+	})
+	if err != nil {
+		ErrorAndExit("Describe domain failed", err)
 	}
+	if len(resp.FailoverHistory) == 0 {
+		fmt.Printf("Domain %s has no recorded failovers.\n", domain)
+		return
+	}
+
+	printRawTime := c.Bool(FlagPrintRawTime)
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetBorder(false)
+	table.SetColumnSeparator("|")
+	table.SetHeader([]string{"Time", "From Cluster", "To Cluster", "Failover Version", "Identity", "Reason"})
+	table.SetHeaderLine(false)
+	for i := len(resp.FailoverHistory) - 1; i >= 0; i-- {
+		event := resp.FailoverHistory[i]
+		failoverTime := convertTime(event.GetFailoverTimestamp(), false)
+		if printRawTime {
+			failoverTime = strconv.FormatInt(event.GetFailoverTimestamp(), 10)
+		}
+		table.Append([]string{
+			failoverTime,
+			event.GetFromCluster(),
+			event.GetToCluster(),
+			strconv.FormatInt(event.GetFailoverVersion(), 10),
+			event.GetIdentity(),
+			event.GetReason(),
+		})
+	}
+	table.Render()
 }
 
 // ShowHistory shows the history of given workflow execution based on workflowID and runID.
@@ -424,7 +542,14 @@ func showHistoryHelper(c *cli.Context, wid, rid string) {
 
 	ctx, cancel := newContext()
 	defer cancel()
-	history, err := GetHistory(ctx, wfClient, wid, rid)
+	var history *s.History
+	var err error
+	if c.Bool(FlagReverse) || c.IsSet(FlagLastEventCount) {
+		domain := getRequiredGlobalOption(c, FlagDomain)
+		history, err = getHistoryFromNewest(ctx, c, domain, wid, rid, c.Bool(FlagReverse), c.Int(FlagLastEventCount))
+	} else {
+		history, err = GetHistory(ctx, wfClient, wid, rid)
+	}
 	if err != nil {
 		ExitIfError(err)
 	}
@@ -434,12 +559,18 @@ func showHistoryHelper(c *cli.Context, wid, rid string) {
 			fmt.Println(anyToString(e, true, maxFieldLength))
 		}
 	} else if c.IsSet(FlagEventID) { // only dump that event
-		eventID := c.Int(FlagEventID)
-		if eventID <= 0 || eventID > len(history.Events) {
-			ErrorAndExit("EventId out of range.", fmt.Errorf("number should be 1 - %d inclusive", len(history.Events)))
+		eventID := int64(c.Int(FlagEventID))
+		var event *s.HistoryEvent
+		for _, e := range history.Events {
+			if e.GetEventId() == eventID {
+				event = e
+				break
+			}
 		}
-		e := history.Events[eventID-1]
-		fmt.Println(anyToString(e, true, 0))
+		if event == nil {
+			ErrorAndExit("EventId out of range.", fmt.Errorf("event %d is not in the history shown", eventID))
+		}
+		fmt.Println(anyToString(event, true, 0))
 	} else { // use table to pretty output, will trim long text
 		table := tablewriter.NewWriter(os.Stdout)
 		table.SetBorder(false)
@@ -475,6 +606,48 @@ func showHistoryHelper(c *cli.Context, wid, rid string) {
 	}
 }
 
+// getHistoryFromNewest reads the history from the newest event using the server side API, which supports
+// reverse and last N events reads, and converts the events to the client types used for printing
+func getHistoryFromNewest(ctx context.Context, c *cli.Context, domain, wid, rid string, reverse bool,
+	lastEventCount int) (*s.History, error) {
+
+	serviceClient := getServerWorkflowServiceClient(c)
+	request := &serverShared.GetWorkflowExecutionHistoryRequest{
+		Domain:       common.StringPtr(domain),
+		Execution:    &serverShared.WorkflowExecution{WorkflowId: common.StringPtr(wid)},
+		ReverseOrder: common.BoolPtr(reverse),
+	}
+	if rid != "" {
+		request.Execution.RunId = common.StringPtr(rid)
+	}
+	if lastEventCount > 0 {
+		request.LastEventCount = common.Int32Ptr(int32(lastEventCount))
+	}
+
+	history := &s.History{Events: []*s.HistoryEvent{}}
+	for {
+		response, err := serviceClient.GetWorkflowExecutionHistory(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range response.History.Events {
+			value, err := e.ToWire()
+			if err != nil {
+				return nil, err
+			}
+			event := &s.HistoryEvent{}
+			if err := event.FromWire(value); err != nil {
+				return nil, err
+			}
+			history.Events = append(history.Events, event)
+		}
+		if len(response.NextPageToken) == 0 {
+			return history, nil
+		}
+		request.NextPageToken = response.NextPageToken
+	}
+}
+
 // StartWorkflow starts a new workflow execution
 func StartWorkflow(c *cli.Context) {
 	// using service client instead of cadence.Client because we need to directly pass the json blob as input.
@@ -1073,6 +1246,59 @@ func DescribeTaskList(c *cli.Context) {
 	table.Render()
 }
 
+// ListTaskLists show all task lists of a domain
+func ListTaskLists(c *cli.Context) {
+	serviceClient := getServerWorkflowServiceClient(c)
+	domain := getRequiredGlobalOption(c, FlagDomain)
+	pageSize := c.Int(FlagPageSize)
+	if pageSize <= 0 {
+		pageSize = defaultPageSizeForList
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetBorder(false)
+	table.SetColumnSeparator("|")
+	table.SetHeader([]string{"Name", "Type", "Kind", "Ack Level", "Last Update Time", "Loaded"})
+	table.SetHeaderLine(false)
+	table.SetHeaderColor(tableHeaderBlue, tableHeaderBlue, tableHeaderBlue, tableHeaderBlue, tableHeaderBlue, tableHeaderBlue)
+
+	var nextPageToken []byte
+	for {
+		ctx, cancel := newContext()
+		response, err := serviceClient.ListTaskLists(ctx, &serverShared.ListTaskListsRequest{
+			Domain:        common.StringPtr(domain),
+			PageSize:      common.Int32Ptr(int32(pageSize)),
+			NextPageToken: nextPageToken,
+		})
+		cancel()
+		if err != nil {
+			ErrorAndExit("ListTaskLists failed", err)
+		}
+
+		for _, tl := range response.TaskLists {
+			table.Append([]string{
+				tl.TaskList.GetName(),
+				strings.ToLower(tl.GetTaskListType().String()),
+				strings.ToLower(tl.TaskList.GetKind().String()),
+				strconv.FormatInt(tl.GetAckLevel(), 10),
+				convertTime(tl.GetLastUpdateTime(), false),
+				strconv.FormatBool(tl.GetLoaded()),
+			})
+		}
+
+		if len(response.NextPageToken) == 0 {
+			break
+		}
+		nextPageToken = response.NextPageToken
+	}
+
+	if table.NumLines() == 0 {
+		fmt.Println(colorMagenta("No tasklist found in domain: " + domain))
+		return
+	}
+	table.Render()
+}
+
 // ObserveHistory show the process of running workflow
 func ObserveHistory(c *cli.Context) {
 	wid := getRequiredOption(c, FlagWorkflowID)
@@ -1133,6 +1359,15 @@ func getWorkflowServiceClient(c *cli.Context) workflowserviceclient.Interface {
 	return client
 }
 
+func getServerWorkflowServiceClient(c *cli.Context) serverFrontend.Interface {
+	client, err := cBuilder.BuildServerServiceClient(c)
+	if err != nil {
+		ExitIfError(err)
+	}
+
+	return client
+}
+
 func getRequiredOption(c *cli.Context, optionName string) string {
 	value := c.String(optionName)
 	if len(value) == 0 {
@@ -1295,7 +1530,7 @@ func trimWorkflowType(str string) string {
 	return res
 }
 
-func clustersToString(clusters []*s.ClusterReplicationConfiguration) string {
+func clustersToString(clusters []*serverShared.ClusterReplicationConfiguration) string {
 	var res string
 	for i, cluster := range clusters {
 		if i == 0 {
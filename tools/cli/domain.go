@@ -148,5 +148,47 @@ func newDomainCommands() []cli.Command {
 				DescribeDomain(c)
 			},
 		},
+		{
+			Name:    "failover",
+			Aliases: []string{"fo"},
+			Usage:   "Failover existing global workflow domain to another cluster",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagActiveClusterNameWithAlias,
+					Usage: "Cluster to make active",
+				},
+				cli.BoolFlag{
+					Name: FlagGracefulWithAlias,
+					Usage: "Stop new mutations in the current active cluster and fail over in the background once " +
+						"replication caught up, must be run against the current active cluster",
+				},
+				cli.IntFlag{
+					Name:  FlagFailoverTimeoutWithAlias,
+					Value: defaultFailoverTimeoutInSeconds,
+					Usage: "Max seconds to wait for replication to catch up in a graceful failover",
+				},
+				cli.StringFlag{
+					Name:  FlagReasonWithAlias,
+					Usage: "Reason for the failover, recorded in the domain failover history",
+				},
+			},
+			Action: func(c *cli.Context) {
+				FailoverDomain(c)
+			},
+		},
+		{
+			Name:    "failover-history",
+			Aliases: []string{"fh"},
+			Usage:   "Show the recorded failovers of a global workflow domain, most recent first",
+			Flags: []cli.Flag{
+				cli.BoolFlag{
+					Name:  FlagPrintRawTimeWithAlias,
+					Usage: "Print raw timestamp",
+				},
+			},
+			Action: func(c *cli.Context) {
+				ShowDomainFailoverHistory(c)
+			},
+		},
 	}
 }
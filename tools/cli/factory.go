@@ -23,6 +23,8 @@ package cli
 import (
 	"errors"
 
+	serverAdmin "github.com/uber/cadence/.gen/go/admin/adminserviceclient"
+	serverFrontend "github.com/uber/cadence/.gen/go/cadence/workflowserviceclient"
 	"github.com/urfave/cli"
 	"go.uber.org/cadence/.gen/go/admin/adminserviceclient"
 	"go.uber.org/cadence/.gen/go/cadence/workflowserviceclient"
@@ -43,6 +45,8 @@ const (
 type WorkflowClientBuilderInterface interface {
 	BuildServiceClient(c *cli.Context) (workflowserviceclient.Interface, error)
 	BuildAdminServiceClient(c *cli.Context) (adminserviceclient.Interface, error)
+	BuildServerServiceClient(c *cli.Context) (serverFrontend.Interface, error)
+	BuildServerAdminServiceClient(c *cli.Context) (serverAdmin.Interface, error)
 }
 
 // WorkflowClientBuilder build client to cadence service
@@ -100,6 +104,44 @@ func (b *WorkflowClientBuilder) BuildAdminServiceClient(c *cli.Context) (adminse
 	return adminserviceclient.New(b.dispatcher.ClientConfig(_cadenceFrontendService)), nil
 }
 
+// BuildServerServiceClient builds a rpc service client to cadence service using the server side IDL, which
+// exposes the APIs not yet available through the client library
+func (b *WorkflowClientBuilder) BuildServerServiceClient(c *cli.Context) (serverFrontend.Interface, error) {
+	b.hostPort = localHostPort
+	if addr := c.GlobalString(FlagAddress); addr != "" {
+		b.hostPort = addr
+	}
+
+	if err := b.build(); err != nil {
+		return nil, err
+	}
+
+	if b.dispatcher == nil {
+		b.logger.Fatal("No RPC dispatcher provided to create a connection to Cadence Service")
+	}
+
+	return serverFrontend.New(b.dispatcher.ClientConfig(_cadenceFrontendService)), nil
+}
+
+// BuildServerAdminServiceClient builds a rpc service client to cadence admin service using the server side IDL,
+// which exposes the admin APIs not yet available through the client library
+func (b *WorkflowClientBuilder) BuildServerAdminServiceClient(c *cli.Context) (serverAdmin.Interface, error) {
+	b.hostPort = localHostPort
+	if addr := c.GlobalString(FlagAddress); addr != "" {
+		b.hostPort = addr
+	}
+
+	if err := b.build(); err != nil {
+		return nil, err
+	}
+
+	if b.dispatcher == nil {
+		b.logger.Fatal("No RPC dispatcher provided to create a connection to Cadence Service")
+	}
+
+	return serverAdmin.New(b.dispatcher.ClientConfig(_cadenceFrontendService)), nil
+}
+
 func (b *WorkflowClientBuilder) build() error {
 	if b.dispatcher != nil {
 		return nil
@@ -43,5 +43,20 @@ func newTaskListCommands() []cli.Command {
 				DescribeTaskList(c)
 			},
 		},
+		{
+			Name:    "list",
+			Aliases: []string{"l"},
+			Usage:   "List all tasklists of the domain",
+			Flags: []cli.Flag{
+				cli.IntFlag{
+					Name:  FlagPageSizeWithAlias,
+					Value: 10,
+					Usage: "Result page size",
+				},
+			},
+			Action: func(c *cli.Context) {
+				ListTaskLists(c)
+			},
+		},
 	}
 }
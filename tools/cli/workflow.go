@@ -65,6 +65,14 @@ func newWorkflowCommands() []cli.Command {
 					Usage: "Maximum length for each attribute field",
 					Value: defaultMaxFieldLength,
 				},
+				cli.BoolFlag{
+					Name:  FlagReverseWithAlias,
+					Usage: "Show the history from the newest event",
+				},
+				cli.IntFlag{
+					Name:  FlagLastEventCountWithAlias,
+					Usage: "Show only the last N events",
+				},
 			},
 			Action: func(c *cli.Context) {
 				ShowHistory(c)
@@ -104,6 +112,14 @@ func newWorkflowCommands() []cli.Command {
 					Usage: "Maximum length for each attribute field",
 					Value: defaultMaxFieldLength,
 				},
+				cli.BoolFlag{
+					Name:  FlagReverseWithAlias,
+					Usage: "Show the history from the newest event",
+				},
+				cli.IntFlag{
+					Name:  FlagLastEventCountWithAlias,
+					Usage: "Show only the last N events",
+				},
 			},
 			Action: func(c *cli.Context) {
 				ShowHistoryWithWID(c)